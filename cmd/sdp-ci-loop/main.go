@@ -15,6 +15,10 @@ import (
 
 	"github.com/fall-out-bug/sdp/internal/ciloop"
 	"github.com/fall-out-bug/sdp/internal/orchestrate"
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+	"github.com/fall-out-bug/sdp/src/sdp/model"
+	"github.com/fall-out-bug/sdp/src/sdp/runtime"
+	"github.com/fall-out-bug/sdp/src/sdp/state"
 )
 
 // exitCodes matches WS AC.
@@ -22,6 +26,7 @@ const (
 	exitGreen    = 0
 	exitEscalate = 1
 	exitMaxIter  = 2
+	exitBudget   = orchestrate.ExitBudgetExhausted
 )
 
 func main() {
@@ -32,6 +37,7 @@ func main() {
 	runsDir := flag.String("runs-dir", ".sdp/runs", "Directory containing run files")
 	pollDelay := flag.Duration("poll-delay", 60*time.Second, "Delay between polls")
 	retryDelay := flag.Duration("retry-delay", 60*time.Second, "Delay when checks are pending")
+	size := flag.String("size", "", "Workstream size (S, M, L) used to route the fixer model")
 	flag.Parse()
 
 	// Resolve PR number and branch: flags take precedence, then checkpoint.
@@ -54,7 +60,6 @@ func main() {
 	defer stop()
 
 	runner := &ciloop.ExecRunner{Ctx: ctx}
-	poller := ciloop.NewPoller(runner)
 
 	onEscalate := func(checks []ciloop.CheckResult) error {
 		names := make([]string, len(checks))
@@ -62,8 +67,14 @@ func main() {
 			names[i] = c.Name
 		}
 		title := fmt.Sprintf("CI BLOCKED: %s (PR #%d)", strings.Join(names, ", "), *prNum)
+		labels := fmt.Sprintf("ci-finding,%s", ciloop.SanitizeLabel(*feature))
+		if flaky := ciloop.FlakySummary(checks); flaky != "" {
+			// Flaky failures need a rerun or a test fix, not a code fix.
+			title = fmt.Sprintf("CI FLAKY: %s — %s (PR #%d)", strings.Join(names, ", "), flaky, *prNum)
+			labels += ",flaky-test"
+		}
 		slog.Warn("escalating", "title", title, "checks", names, "pr", *prNum)
-		cmd := exec.Command("bd", "create", "--title", title, "--priority", "0", "--labels", fmt.Sprintf("ci-finding,%s", ciloop.SanitizeLabel(*feature)))
+		cmd := exec.Command("bd", "create", "--title", title, "--priority", "0", "--labels", labels)
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		if err := cmd.Run(); err != nil {
@@ -78,6 +89,10 @@ func main() {
 		projectRoot = "."
 	}
 
+	// The CI provider circuit is shared across runs via .sdp/state (see `sdp health`).
+	ciCircuit := runtime.NewCircuitBreaker(runtime.DefaultCircuitConfig).Persist(state.NewStore(projectRoot), ciloop.CircuitTarget)
+	poller := ciloop.NewPoller(runner).WithCircuit(ciCircuit)
+
 	// Remove orphan .tmp files from previous runs
 	ciloop.RemoveOrphanTmpFiles(
 		filepath.Join(projectRoot, ".sdp", "checkpoints"),
@@ -86,17 +101,39 @@ func main() {
 		filepath.Join(projectRoot, ".sdp", "ci-fixes"),
 	)
 
-	innerFixer := ciloop.NewFixer(ciloop.FixerOptions{
-		PRNumber:  *prNum,
-		FeatureID: *feature,
-		Ctx:       ctx,
-		Committer: &ciloop.GitCommitter{},
-		LogFetcher: &ciloop.GhLogFetcher{Runner: runner},
+	fixOpts := ciloop.FixerOptions{
+		PRNumber:    *prNum,
+		FeatureID:   *feature,
+		Ctx:         ctx,
+		ProjectRoot: projectRoot,
+		Committer:   &ciloop.GitCommitter{},
+		LogFetcher:  &ciloop.GhLogFetcher{Runner: runner},
 		DecisionLogger: func(decision, rationale string) error {
 			fmt.Printf("DECISION: %s — %s\n", decision, rationale)
 			return nil
 		},
-	})
+	}
+	// The fix agent runs only when opencode is installed. Like the orchestrator,
+	// it walks the ci-fix fallback chain, and the gateway records each call's
+	// spend in the ledger budgetCheck reads.
+	if _, err := exec.LookPath("opencode"); err == nil {
+		gateway, err := orchestrate.NewGateway(projectRoot)
+		if err != nil {
+			slog.Error("load budgets failed", "error", err)
+			os.Exit(exitEscalate)
+		}
+		fixOpts.Agent = &orchestrate.RoutedInvoker{
+			Gateway:     gateway,
+			Router:      model.NewRouter(),
+			ProjectRoot: projectRoot,
+			Phase:       model.PhaseCIFix,
+			Size:        *size,
+			FeatureID:   *feature,
+			State:       state.NewStore(projectRoot),
+		}
+		fixOpts.Committer = &ciloop.GitCommitter{Tracked: true}
+	}
+	innerFixer := ciloop.NewFixer(fixOpts)
 
 	runFileLogger := func(fixerNames []string, duration time.Duration) {
 		if *feature == "" {
@@ -107,12 +144,15 @@ func main() {
 	}
 
 	fixer := &ciloop.DeterministicFirstFixer{
-		ProjectRoot:   projectRoot,
-		Registry:      ciloop.NewAutofixerRegistry(projectRoot),
-		Runner:        runner,
-		Committer:     &ciloop.AllFilesCommitter{},
-		LogFetcher:    &ciloop.GhLogFetcher{Runner: runner},
-		DecisionLog:   func(decision, rationale string) error { fmt.Printf("DECISION: %s — %s\n", decision, rationale); return nil },
+		ProjectRoot: projectRoot,
+		Registry:    ciloop.NewAutofixerRegistry(projectRoot),
+		Runner:      runner,
+		Committer:   &ciloop.AllFilesCommitter{},
+		LogFetcher:  &ciloop.GhLogFetcher{Runner: runner},
+		DecisionLog: func(decision, rationale string) error {
+			fmt.Printf("DECISION: %s — %s\n", decision, rationale)
+			return nil
+		},
 		RunFileLogger: runFileLogger,
 		Inner:         innerFixer,
 		PRNumber:      *prNum,
@@ -131,11 +171,25 @@ func main() {
 		slog.Debug("saved checkpoint on poll error", "feature", *feature, "poll_err", err)
 	}
 
+	enforcer, err := budget.LoadEnforcer(projectRoot)
+	if err != nil {
+		slog.Error("load budgets failed", "error", err)
+		os.Exit(exitEscalate)
+	}
+	budgetCheck := func() error { return enforcer.Check(*feature, "") }
+
 	opts := ciloop.LoopOptions{Context: ctx, PRNumber: *prNum, MaxIter: *maxIter,
 		MaxPendingRetries: ciloop.DefaultMaxPendingRetries, PollDelay: *pollDelay, RetryDelay: *retryDelay,
-		Poller: poller, OnEscalate: onEscalate, OnPollError: onPollError, Fixer: fixer}
+		Poller: poller, OnEscalate: onEscalate, OnPollError: onPollError, Fixer: fixer, BudgetCheck: budgetCheck,
+		Flakes: &ciloop.HistoryClassifier{ProjectRoot: projectRoot, LogFetcher: &ciloop.GhLogFetcher{Runner: runner}, PRNumber: *prNum}}
 
 	result, err := ciloop.RunLoop(opts)
+	if result == ciloop.ResultBudgetExhausted {
+		// Recoverable: keep the checkpoint so a re-run after raising the budget resumes here.
+		onPollError(err)
+		fmt.Fprintf(os.Stderr, "halted: %v\nRaise the limit in %s (or reset the ledger) and re-run to resume.\n", err, budget.ConfigPath)
+		os.Exit(exitBudget)
+	}
 	if err != nil {
 		slog.Error("ci-loop failed", "error", err, "pr", *prNum, "feature", *feature)
 		os.Exit(exitEscalate)
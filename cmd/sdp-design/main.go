@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+
+	"github.com/fall-out-bug/sdp/src/sdp/cli"
+	"github.com/spf13/cobra"
+)
+
+// sdp-design runs the multi-agent design panel. The sdp CLI forwards
+// `sdp design --panel` here when this binary is on PATH.
+func main() {
+	root := &cobra.Command{
+		Use:          "sdp-design",
+		Short:        "Resolve design decisions with an agent panel",
+		SilenceUsage: true,
+	}
+	cli.RegisterDesignCommand(root)
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
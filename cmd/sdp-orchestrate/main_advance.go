@@ -38,7 +38,7 @@ func runAdvance(projectRoot, featureID, cpPath, runsPath, result string, skipGua
 				if errors.As(err, &scopeErr) {
 					fmt.Fprintf(os.Stderr, "SCOPE VIOLATION: %s\n", err)
 					if createErr := orchestrate.CreateScopeEscalationBead(scopeErr.WSID, scopeErr.Violations); createErr != nil {
-						fmt.Fprintf(os.Stderr, "warning: bd create failed: %v\n", createErr)
+						fmt.Fprintf(os.Stderr, "warning: scope escalation issue not filed: %v\n", createErr)
 					}
 				}
 				fmt.Fprintf(os.Stderr, "error: advance blocked by scope guard: %v\n", err)
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/fall-out-bug/sdp/internal/sdputil"
+	"github.com/fall-out-bug/sdp/src/sdp/checkpoint"
 )
 
 // Checkpoint mirrors the .sdp/checkpoints/F{NNN}.json schema.
@@ -41,13 +42,21 @@ func LoadCheckpoint(dir, featureID string) (*Checkpoint, error) {
 }
 
 // SaveCheckpoint writes the checkpoint back to disk atomically.
+// Fields this struct does not know (workstreams, review written by sdp-orchestrate)
+// are preserved. The checkpoint is also recorded as the ciloop section of the
+// feature's run in the unified checkpoint store.
 // Caller is responsible for setting cp.Phase and cp.UpdatedAt before calling.
 func SaveCheckpoint(dir string, cp *Checkpoint) error {
 	if err := sdputil.ValidateFeatureID(cp.FeatureID); err != nil {
 		return err
 	}
 	cp.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
-	data, err := json.MarshalIndent(cp, "", "  ")
+	path := filepath.Join(dir, cp.FeatureID+".json")
+	fields, err := mergeFields(path, cp)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(fields, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal checkpoint: %w", err)
 	}
@@ -55,10 +64,36 @@ func SaveCheckpoint(dir string, cp *Checkpoint) error {
 	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
 		return fmt.Errorf("write checkpoint: %w", err)
 	}
-	path := filepath.Join(dir, cp.FeatureID+".json")
 	if err := os.Rename(tmpPath, path); err != nil {
 		_ = os.Remove(tmpPath)
 		return fmt.Errorf("rename checkpoint: %w", err)
 	}
+	if err := checkpoint.NewStore(dir).Put(cp.FeatureID, cp.FeatureID, checkpoint.EngineCILoop, cp.FeatureID+".json", cp); err != nil {
+		return fmt.Errorf("store checkpoint: %w", err)
+	}
 	return nil
 }
+
+// mergeFields overlays cp onto the fields of the existing checkpoint file.
+func mergeFields(path string, cp *Checkpoint) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read checkpoint %s: %w", path, err)
+	}
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return nil, fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	var own map[string]json.RawMessage
+	if err := json.Unmarshal(data, &own); err != nil {
+		return nil, fmt.Errorf("marshal checkpoint: %w", err)
+	}
+	for k, v := range own {
+		fields[k] = v
+	}
+	return fields, nil
+}
@@ -3,6 +3,7 @@ package ciloop_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/fall-out-bug/sdp/internal/ciloop"
@@ -98,3 +99,29 @@ func TestSaveCheckpoint(t *testing.T) {
 		t.Error("expected updated_at to be set")
 	}
 }
+
+func TestSaveCheckpointPreservesOrchestrateFields(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"schema":"1.0","feature_id":"F014","branch":"b","phase":"ci","workstreams":[{"id":"00-014-01","status":"done"}]}`
+	if err := os.WriteFile(filepath.Join(dir, "F014.json"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := ciloop.LoadCheckpoint(dir, "F014")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.Phase = "done"
+	if err := ciloop.SaveCheckpoint(dir, cp); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "F014.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "00-014-01") || !strings.Contains(string(data), `"phase": "done"`) {
+		t.Errorf("expected workstreams preserved and phase updated, got %s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "runs", "F014.json")); err != nil {
+		t.Errorf("expected unified store entry: %v", err)
+	}
+}
@@ -41,7 +41,10 @@ func SanitizeLabel(s string) string {
 }
 
 // GitCommitter implements Committer via git CLI.
-type GitCommitter struct{}
+type GitCommitter struct {
+	// Tracked also stages changes to tracked files (the fix agent's edits).
+	Tracked bool
+}
 
 // AllFilesCommitter commits all changes (for deterministic fixers: goimports, go mod tidy).
 type AllFilesCommitter struct{}
@@ -78,18 +81,25 @@ func (g *AllFilesCommitter) Push(ctx context.Context) error {
 	return cmd.Run()
 }
 
-// Commit adds .sdp/ci-fixes/ and commits with the given message.
+// Commit adds .sdp/ci-fixes/, plus tracked changes when Tracked is set, and
+// commits with the given message.
 func (g *GitCommitter) Commit(ctx context.Context, msg string) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	runCtx, cancel := context.WithTimeout(ctx, gitOperationTimeout)
 	defer cancel()
-	add := exec.CommandContext(runCtx, "git", "add", ".sdp/ci-fixes/")
-	add.Stdout = os.Stdout
-	add.Stderr = os.Stderr
-	if err := add.Run(); err != nil {
-		return err
+	adds := [][]string{{"add", ".sdp/ci-fixes/"}}
+	if g.Tracked {
+		adds = append(adds, []string{"add", "-u"})
+	}
+	for _, args := range adds {
+		add := exec.CommandContext(runCtx, "git", args...)
+		add.Stdout = os.Stdout
+		add.Stderr = os.Stderr
+		if err := add.Run(); err != nil {
+			return err
+		}
 	}
 	cmd := exec.CommandContext(runCtx, "git", "commit", "-m", msg)
 	cmd.Stdout = os.Stdout
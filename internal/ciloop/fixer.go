@@ -32,6 +32,12 @@ type FixerOptions struct {
 	Committer      Committer
 	LogFetcher     LogFetcher
 	DecisionLogger func(decision, rationale string) error
+	// Agent, when set, patches the failures before the commit. The ci-loop
+	// passes an orchestrate.RoutedInvoker for the ci-fix phase, so the model
+	// comes from the router fallback chain and spend lands in the budget ledger.
+	Agent AgentInvoker
+	// ProjectRoot is the directory the agent runs in. Defaults to ".".
+	ProjectRoot string
 }
 
 // AutoFixer applies rule-based fixes for classifiable CI failures.
@@ -47,8 +53,8 @@ func NewFixer(opts FixerOptions) *AutoFixer {
 // Fix implements the Fixer interface: parses CI logs, writes a diagnostics file,
 // commits, and pushes. Returns an error if any check cannot be parsed or committed.
 //
-// v1 behaviour: fixes are recorded as diagnostics files (.sdp/ci-fixes/); no
-// automatic source patching is attempted. If no parseable pattern is found,
+// Fixes are recorded as diagnostics files (.sdp/ci-fixes/); source patching
+// happens only when an Agent is configured. If no parseable pattern is found,
 // the error propagates and RunLoop escalates.
 func (f *AutoFixer) Fix(checks []CheckResult) error {
 	log, err := f.opts.LogFetcher.FailedLogs(f.opts.PRNumber)
@@ -65,6 +71,16 @@ func (f *AutoFixer) Fix(checks []CheckResult) error {
 		fixDescs = append(fixDescs, desc)
 	}
 
+	ctx := f.opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if f.opts.Agent != nil {
+		if err := f.runAgent(ctx, checks, fixDescs, log); err != nil {
+			return fmt.Errorf("fix agent: %w", err)
+		}
+	}
+
 	// Write a diagnostics file so git commit has something to stage.
 	if err := f.writeDiagnostics(checks, fixDescs, log); err != nil {
 		return fmt.Errorf("write diagnostics: %w", err)
@@ -76,10 +92,6 @@ func (f *AutoFixer) Fix(checks []CheckResult) error {
 		f.opts.FeatureID,
 	)
 
-	ctx := f.opts.Ctx
-	if ctx == nil {
-		ctx = context.Background()
-	}
 	if err := f.opts.Committer.Commit(ctx, msg); err != nil {
 		return fmt.Errorf("commit fix: %w", err)
 	}
@@ -0,0 +1,56 @@
+package ciloop
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AgentInvoker runs a coding agent in dir. orchestrate.MeteredInvoker and
+// orchestrate.RoutedInvoker implement it, so each call is metered against
+// the budget ledger.
+type AgentInvoker interface {
+	Invoke(ctx context.Context, dir, agent, prompt string) (output string, exitCode int, err error)
+}
+
+// fixAgent is the opencode agent that patches CI failures.
+const fixAgent = "implementer"
+
+// agentLogTail bounds how much of the CI log is passed to the fix agent.
+const agentLogTail = 8000
+
+// runAgent asks the fix agent to patch the failing checks in the working tree.
+// The agent output is not echoed: it may quote the CI log (security: a8ae).
+func (f *AutoFixer) runAgent(ctx context.Context, checks []CheckResult, fixDescs []string, log string) error {
+	dir := f.opts.ProjectRoot
+	if dir == "" {
+		dir = "."
+	}
+	_, code, err := f.opts.Agent.Invoke(ctx, dir, fixAgent, agentPrompt(f.opts.PRNumber, checks, fixDescs, log))
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return fmt.Errorf("%s agent exited %d", fixAgent, code)
+	}
+	return nil
+}
+
+func agentPrompt(prNumber int, checks []CheckResult, fixDescs []string, log string) string {
+	names := make([]string, len(checks))
+	for i, c := range checks {
+		names[i] = c.Name
+	}
+	if len(log) > agentLogTail {
+		log = log[len(log)-agentLogTail:]
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "CI failed on PR #%d: %s.\n\n", prNumber, strings.Join(names, ", "))
+	b.WriteString("Fix the failures in the working tree with the smallest change that makes the checks pass. Do not commit.\n\n")
+	b.WriteString("Diagnosis:\n")
+	for _, d := range fixDescs {
+		fmt.Fprintf(&b, "- %s\n", d)
+	}
+	fmt.Fprintf(&b, "\nFailed log (tail):\n\n%s\n", log)
+	return b.String()
+}
@@ -313,3 +313,51 @@ func TestRunLoopWithFixerGreenAfterFix(t *testing.T) {
 		t.Errorf("expected 1 auto-fix commit, got %d", len(committer.commits))
 	}
 }
+
+// fakeAgent records fix-agent calls.
+type fakeAgent struct {
+	dir, agent, prompt string
+	code               int
+}
+
+func (f *fakeAgent) Invoke(ctx context.Context, dir, agent, prompt string) (string, int, error) {
+	f.dir, f.agent, f.prompt = dir, agent, prompt
+	return "patched", f.code, nil
+}
+
+func TestFixerRunsAgentBeforeCommit(t *testing.T) {
+	agent := &fakeAgent{}
+	committer := &fakeCommitter{}
+	fixer := ciloop.NewFixer(ciloop.FixerOptions{
+		PRNumber:       42,
+		FeatureID:      "F014",
+		DiagnosticsDir: t.TempDir(),
+		ProjectRoot:    "/repo",
+		Committer:      committer,
+		LogFetcher:     &fakeLogFetcher{logs: map[string]string{"run1": goTestFailureLog}},
+		Agent:          agent,
+	})
+	if err := fixer.Fix([]ciloop.CheckResult{{Name: "go-test", State: ciloop.StateFailure}}); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if agent.dir != "/repo" || agent.agent != "implementer" {
+		t.Errorf("agent called with dir %q agent %q", agent.dir, agent.agent)
+	}
+	for _, want := range []string{"PR #42", "go-test: skip/fix failing test TestFoo", "foo_test.go:12"} {
+		if !strings.Contains(agent.prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, agent.prompt)
+		}
+	}
+	if len(committer.commits) != 1 {
+		t.Errorf("expected 1 commit, got %d", len(committer.commits))
+	}
+
+	agent.code = 1
+	committer.commits = nil
+	if err := fixer.Fix([]ciloop.CheckResult{{Name: "go-test", State: ciloop.StateFailure}}); err == nil {
+		t.Error("expected error when the agent fails")
+	}
+	if len(committer.commits) != 0 {
+		t.Error("a failed agent run must not commit")
+	}
+}
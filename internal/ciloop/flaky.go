@@ -0,0 +1,191 @@
+package ciloop
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FlakyTest is a failing CI test that `sdp` has classified as flaky or
+// quarantined (see `sdp quality flaky`).
+type FlakyTest struct {
+	ID          string
+	Quarantined bool
+	Reason      string
+}
+
+// FlakeClassifier recognises CI test failures caused only by flaky tests,
+// which a fix iteration cannot repair.
+type FlakeClassifier interface {
+	// Flakes returns the failing tests behind checks when every one of them
+	// is flaky or quarantined, and nil otherwise.
+	Flakes(checks []CheckResult) ([]FlakyTest, error)
+}
+
+// Files written by the sdp flake detector under the project root.
+const (
+	flakyDir            = ".sdp/flaky"
+	flakyHistoryFile    = "history.json"
+	flakyQuarantineFile = "quarantine.json"
+)
+
+// HistoryClassifier implements FlakeClassifier from the failed CI log and
+// the flaky history and quarantine files in ProjectRoot/.sdp/flaky.
+type HistoryClassifier struct {
+	ProjectRoot string
+	LogFetcher  LogFetcher
+	PRNumber    int
+	Now         func() time.Time // defaults to time.Now
+}
+
+// Flakes fetches the failed log and checks each failing test against the
+// quarantine list (unexpired entries) and the recorded classification.
+func (h *HistoryClassifier) Flakes(checks []CheckResult) ([]FlakyTest, error) {
+	log, err := h.LogFetcher.FailedLogs(h.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetch logs: %w", err)
+	}
+	ids := FailedTestIDs(log)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	quarantine, classes, err := loadFlakyState(filepath.Join(h.ProjectRoot, flakyDir))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if h.Now != nil {
+		now = h.Now()
+	}
+	flakes := make([]FlakyTest, 0, len(ids))
+	for _, id := range ids {
+		if e, ok := quarantine[id]; ok && (e.ExpiresAt.IsZero() || now.Before(e.ExpiresAt)) {
+			flakes = append(flakes, FlakyTest{ID: id, Quarantined: true, Reason: e.Reason})
+			continue
+		}
+		if classes[id] == "flaky" {
+			flakes = append(flakes, FlakyTest{ID: id, Reason: "classified flaky"})
+			continue
+		}
+		return nil, nil
+	}
+	return flakes, nil
+}
+
+// quarantineEntry mirrors the entries of .sdp/flaky/quarantine.json.
+type quarantineEntry struct {
+	TestID    string    `json:"test_id"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func loadFlakyState(dir string) (map[string]quarantineEntry, map[string]string, error) {
+	var entries []quarantineEntry
+	if err := readFlakyFile(filepath.Join(dir, flakyQuarantineFile), &entries); err != nil {
+		return nil, nil, err
+	}
+	var history map[string]struct {
+		Classification string `json:"classification"`
+	}
+	if err := readFlakyFile(filepath.Join(dir, flakyHistoryFile), &history); err != nil {
+		return nil, nil, err
+	}
+	quarantine := make(map[string]quarantineEntry, len(entries))
+	for _, e := range entries {
+		quarantine[e.TestID] = e
+	}
+	classes := make(map[string]string, len(history))
+	for id, h := range history {
+		classes[id] = h.Classification
+	}
+	return quarantine, classes, nil
+}
+
+func readFlakyFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && len(data) == 0) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+var (
+	ciGoFailLine    = regexp.MustCompile(`--- FAIL: ([^\s/]+)`)
+	ciGoPackageLine = regexp.MustCompile(`(?:^|\s)(FAIL|ok)\s+(\S+)\s+(?:[\d.]+s|\(cached\)|\[)`)
+	ciPytestFailed  = regexp.MustCompile(`(?:^|\s)FAILED (\S+)`)
+)
+
+// FailedTestIDs extracts failed test IDs from a CI log in the format the sdp
+// flake detector records them: "<import path>.<TestName>" for Go (subtests
+// count as their top-level test) and node IDs for pytest. Prefixes added by
+// `gh run view --log-failed` are tolerated.
+func FailedTestIDs(log string) []string {
+	var ids, pending []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, line := range strings.Split(log, "\n") {
+		if m := ciGoFailLine.FindStringSubmatch(line); m != nil {
+			pending = append(pending, m[1])
+			continue
+		}
+		if m := ciGoPackageLine.FindStringSubmatch(line); m != nil {
+			if m[1] == "FAIL" {
+				for _, name := range pending {
+					add(m[2] + "." + name)
+				}
+			}
+			pending = nil
+			continue
+		}
+		if m := ciPytestFailed.FindStringSubmatch(line); m != nil {
+			add(m[1])
+		}
+	}
+	return ids
+}
+
+// flakyNames formats flaky tests for escalation titles.
+func flakyNames(tests []FlakyTest) string {
+	names := make([]string, len(tests))
+	for i, t := range tests {
+		names[i] = t.ID
+		if t.Quarantined {
+			names[i] += " (quarantined)"
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// FlakySummary describes the flaky tests attached to escalated checks, or
+// "" when none are flaky.
+func FlakySummary(checks []CheckResult) string {
+	var all []FlakyTest
+	seen := make(map[string]bool)
+	for _, c := range checks {
+		for _, t := range c.Flaky {
+			if !seen[t.ID] {
+				seen[t.ID] = true
+				all = append(all, t)
+			}
+		}
+	}
+	if len(all) == 0 {
+		return ""
+	}
+	return "flaky: " + flakyNames(all)
+}
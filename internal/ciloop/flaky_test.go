@@ -0,0 +1,107 @@
+package ciloop_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/ciloop"
+)
+
+const flakyCILog = "test\tRun go test\t2026-01-02T00:00:00.0000000Z --- FAIL: TestFlaky (0.00s)\n" +
+	"test\tRun go test\t2026-01-02T00:00:00.0000000Z     --- FAIL: TestFlaky/sub (0.00s)\n" +
+	"test\tRun go test\t2026-01-02T00:00:00.0000000Z FAIL\n" +
+	"test\tRun go test\t2026-01-02T00:00:00.0000000Z FAIL\texample.com/m/a\t0.012s\n" +
+	"test\tRun go test\t2026-01-02T00:00:00.0000000Z ok  \texample.com/m/b\t0.003s\n"
+
+func TestFailedTestIDs(t *testing.T) {
+	if got := ciloop.FailedTestIDs(flakyCILog); !reflect.DeepEqual(got, []string{"example.com/m/a.TestFlaky"}) {
+		t.Errorf("FailedTestIDs = %v", got)
+	}
+	got := ciloop.FailedTestIDs("FAILED tests/test_x.py::test_a - assert 0\n")
+	if !reflect.DeepEqual(got, []string{"tests/test_x.py::test_a"}) {
+		t.Errorf("pytest FailedTestIDs = %v", got)
+	}
+}
+
+func writeFlakyState(t *testing.T, quarantine, history string) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, ".sdp", "flaky")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "quarantine.json"), []byte(quarantine), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "history.json"), []byte(history), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestHistoryClassifierFlakes(t *testing.T) {
+	now := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	checks := []ciloop.CheckResult{{Name: "go-test", State: ciloop.StateFailure}}
+	quarantined := `[{"test_id":"example.com/m/a.TestFlaky","reason":"failed 2 of 4 recent runs","expires_at":"2026-01-03T00:00:00Z"}]`
+
+	root := writeFlakyState(t, quarantined, `{}`)
+	c := &ciloop.HistoryClassifier{ProjectRoot: root, LogFetcher: &fakeLogFetcher{logs: map[string]string{"r": flakyCILog}}, Now: func() time.Time { return now }}
+	got, err := c.Flakes(checks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ciloop.FlakyTest{{ID: "example.com/m/a.TestFlaky", Quarantined: true, Reason: "failed 2 of 4 recent runs"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Flakes = %+v, want %+v", got, want)
+	}
+
+	c.Now = func() time.Time { return now.Add(48 * time.Hour) }
+	if got, _ := c.Flakes(checks); got != nil {
+		t.Errorf("expired quarantine should not count, got %+v", got)
+	}
+
+	c.ProjectRoot = writeFlakyState(t, `[]`, `{"example.com/m/a.TestFlaky":{"runs":[],"classification":"flaky"}}`)
+	if got, _ := c.Flakes(checks); len(got) != 1 || got[0].Quarantined {
+		t.Errorf("history classification should count as flaky, got %+v", got)
+	}
+
+	c.LogFetcher = &fakeLogFetcher{logs: map[string]string{"r": goTestFailureLog}}
+	if got, _ := c.Flakes(checks); got != nil {
+		t.Errorf("unknown failing test must not be flaky, got %+v", got)
+	}
+}
+
+type fakeFlakes struct{ tests []ciloop.FlakyTest }
+
+func (f *fakeFlakes) Flakes(_ []ciloop.CheckResult) ([]ciloop.FlakyTest, error) { return f.tests, nil }
+
+func TestRunLoopEscalatesFlakyTests(t *testing.T) {
+	runner := newSequence([][]byte{[]byte(`[{"name":"go-test","state":"FAILURE"}]`)})
+	var escalated []ciloop.CheckResult
+	fixer := &countingFixer{}
+	opts := ciloop.LoopOptions{
+		PRNumber:   42,
+		MaxIter:    5,
+		Poller:     ciloop.NewPoller(runner),
+		OnEscalate: func(checks []ciloop.CheckResult) error { escalated = checks; return nil },
+		Fixer:      fixer,
+		Flakes:     &fakeFlakes{tests: []ciloop.FlakyTest{{ID: "example.com/m/a.TestFlaky", Quarantined: true}}},
+	}
+	result, err := ciloop.RunLoop(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != ciloop.ResultEscalated || fixer.calls != 0 {
+		t.Fatalf("expected escalation without a fix, got %v after %d fixes", result, fixer.calls)
+	}
+	if got := ciloop.FlakySummary(escalated); got != "flaky: example.com/m/a.TestFlaky (quarantined)" {
+		t.Errorf("FlakySummary = %q", got)
+	}
+}
+
+type countingFixer struct{ calls int }
+
+func (f *countingFixer) Fix(_ []ciloop.CheckResult) error { f.calls++; return nil }
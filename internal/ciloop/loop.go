@@ -2,6 +2,7 @@ package ciloop
 
 import (
 	"context"
+	"log/slog"
 	"time"
 )
 
@@ -9,9 +10,10 @@ import (
 type LoopResult int
 
 const (
-	ResultGreen     LoopResult = iota // all checks passed
-	ResultEscalated                   // escalation triggered
-	ResultMaxIter                     // max iterations exceeded
+	ResultGreen           LoopResult = iota // all checks passed
+	ResultEscalated                         // escalation triggered
+	ResultMaxIter                           // max iterations exceeded
+	ResultBudgetExhausted                   // token/cost budget hard stop reached (recoverable)
 )
 
 // DefaultMaxPendingRetries is the default cap on PENDING-only polling rounds.
@@ -28,7 +30,7 @@ type Fixer interface {
 // LoopOptions configures RunLoop behaviour.
 type LoopOptions struct {
 	// Context allows cancellation (e.g. SIGINT/SIGTERM). When cancelled, RunLoop returns ResultEscalated.
-	Context context.Context
+	Context  context.Context
 	PRNumber int
 	MaxIter  int
 	// MaxPendingRetries caps how many consecutive PENDING-only rounds before escalation.
@@ -44,6 +46,14 @@ type LoopOptions struct {
 	// Fixer handles auto-fixable failures.
 	// When nil, auto-fixable failures escalate immediately (same as non-auto-fixable).
 	Fixer Fixer
+	// Flakes, when set, is consulted for auto-fixable go-test failures. If
+	// every failing test is flaky or quarantined, the checks are escalated
+	// with their Flaky field set instead of entering a fix iteration that
+	// cannot succeed.
+	Flakes FlakeClassifier
+	// BudgetCheck is called before each fix iteration. A non-nil error halts the
+	// loop with ResultBudgetExhausted. Nil disables budget enforcement.
+	BudgetCheck func() error
 }
 
 // RunLoop polls CI checks until green, escalation, or max iterations.
@@ -52,11 +62,13 @@ type LoopOptions struct {
 // Up to MaxPendingRetries consecutive pending-only rounds are allowed; after that, escalate.
 // FAILURE checks are classified: non-auto-fixable (or auto-fixable with nil Fixer) → escalate.
 // Auto-fixable failures with a Fixer: call Fixer.Fix, increment iter, re-poll.
+// go-test failures caused only by flaky or quarantined tests (per Flakes) escalate.
 //
 // Exit criteria:
 //   - ResultGreen     when IsAllGreen
 //   - ResultEscalated when OnEscalate is called or on error
 //   - ResultMaxIter   when iter >= MaxIter
+//   - ResultBudgetExhausted when BudgetCheck fails before a fix
 func RunLoop(opts LoopOptions) (LoopResult, error) {
 	iter := 0
 	pendingRounds := 0
@@ -134,6 +146,10 @@ func RunLoop(opts LoopOptions) (LoopResult, error) {
 			}
 		}
 
+		if len(autoFixChecks) > 0 && opts.Flakes != nil {
+			autoFixChecks, escalateChecks = splitFlaky(opts.Flakes, autoFixChecks, escalateChecks)
+		}
+
 		if len(escalateChecks) > 0 {
 			if opts.OnEscalate != nil {
 				if err := opts.OnEscalate(escalateChecks); err != nil {
@@ -143,6 +159,12 @@ func RunLoop(opts LoopOptions) (LoopResult, error) {
 			return ResultEscalated, nil
 		}
 
+		if opts.BudgetCheck != nil {
+			if err := opts.BudgetCheck(); err != nil {
+				return ResultBudgetExhausted, err
+			}
+		}
+
 		// Auto-fixable failures with Fixer: count iteration and attempt fix.
 		iter++
 		if iter >= opts.MaxIter {
@@ -159,3 +181,36 @@ func RunLoop(opts LoopOptions) (LoopResult, error) {
 		}
 	}
 }
+
+// splitFlaky moves go-test checks whose failures are all flaky tests from
+// autoFix to escalate, annotating them. Classifier errors leave the checks
+// to the fixer.
+func splitFlaky(flakes FlakeClassifier, autoFix, escalate []CheckResult) ([]CheckResult, []CheckResult) {
+	var tests []CheckResult
+	for _, c := range autoFix {
+		if FixType(c.Name) == "go-test" {
+			tests = append(tests, c)
+		}
+	}
+	if len(tests) == 0 {
+		return autoFix, escalate
+	}
+	flaky, err := flakes.Flakes(tests)
+	if err != nil {
+		slog.Debug("flaky test classification failed", "error", err)
+		return autoFix, escalate
+	}
+	if len(flaky) == 0 {
+		return autoFix, escalate
+	}
+	remaining := make([]CheckResult, 0, len(autoFix))
+	for _, c := range autoFix {
+		if FixType(c.Name) == "go-test" {
+			c.Flaky = flaky
+			escalate = append(escalate, c)
+		} else {
+			remaining = append(remaining, c)
+		}
+	}
+	return remaining, escalate
+}
@@ -32,12 +32,12 @@ func newSequence(responses [][]byte) *sequenceRunner {
 func TestRunLoopGreenFirstTry(t *testing.T) {
 	runner := newSequence([][]byte{greenJSON})
 	opts := ciloop.LoopOptions{
-		PRNumber:    42,
-		MaxIter:     5,
-		PollDelay:   0,
-		RetryDelay:  0,
-		Poller:      ciloop.NewPoller(runner),
-		OnEscalate:  func(checks []ciloop.CheckResult) error { return nil },
+		PRNumber:   42,
+		MaxIter:    5,
+		PollDelay:  0,
+		RetryDelay: 0,
+		Poller:     ciloop.NewPoller(runner),
+		OnEscalate: func(checks []ciloop.CheckResult) error { return nil },
 	}
 	result, err := ciloop.RunLoop(opts)
 	if err != nil {
@@ -272,3 +272,33 @@ func TestFixPushStillFailingMaxIter(t *testing.T) {
 		t.Errorf("expected MaxIter, got %v", result)
 	}
 }
+
+func TestRunLoopBudgetExhaustedHaltsBeforeFix(t *testing.T) {
+	goTestFailure := []byte(`[{"name":"go-test","state":"FAILURE"}]`)
+	runner := newSequence([][]byte{goTestFailure})
+	budgetErr := errors.New("budget exhausted")
+	fixed := false
+	opts := ciloop.LoopOptions{
+		PRNumber:    42,
+		MaxIter:     5,
+		Poller:      ciloop.NewPoller(runner),
+		OnEscalate:  func(checks []ciloop.CheckResult) error { return nil },
+		Fixer:       fixerFunc(func() { fixed = true }),
+		BudgetCheck: func() error { return budgetErr },
+	}
+	result, err := ciloop.RunLoop(opts)
+	if !errors.Is(err, budgetErr) {
+		t.Errorf("expected budget error, got %v", err)
+	}
+	if result != ciloop.ResultBudgetExhausted {
+		t.Errorf("expected BudgetExhausted, got %v", result)
+	}
+	if fixed {
+		t.Error("fixer must not run once the budget is exhausted")
+	}
+}
+
+// fixerFunc adapts a callback to the Fixer interface.
+type fixerFunc func()
+
+func (f fixerFunc) Fix(_ []ciloop.CheckResult) error { f(); return nil }
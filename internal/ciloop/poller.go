@@ -2,6 +2,7 @@ package ciloop
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/fall-out-bug/sdp/internal/sdputil"
+	"github.com/fall-out-bug/sdp/src/sdp/runtime"
 )
 
 // CheckState represents the state of a CI check.
@@ -27,6 +29,9 @@ const (
 type CheckResult struct {
 	Name  string     `json:"name"`
 	State CheckState `json:"state"`
+	// Flaky lists the flaky tests behind a failing check, set by RunLoop
+	// when Flakes classifies the failure. Not part of the gh output.
+	Flaky []FlakyTest `json:"-"`
 }
 
 // CommandRunner executes an external command and returns its stdout.
@@ -36,7 +41,18 @@ type CommandRunner interface {
 
 // Poller polls GitHub PR checks via the gh CLI.
 type Poller struct {
-	runner CommandRunner
+	runner  CommandRunner
+	circuit *runtime.CircuitBreaker
+}
+
+// CircuitTarget is the .sdp/state circuit for the GitHub checks provider.
+const CircuitTarget = "ci:github"
+
+// WithCircuit guards gh calls with cb. While the circuit is open GetChecks
+// fails fast with runtime.ErrCircuitOpen instead of retrying. Returns p.
+func (p *Poller) WithCircuit(cb *runtime.CircuitBreaker) *Poller {
+	p.circuit = cb
+	return p
 }
 
 // NewPoller creates a Poller backed by the given runner.
@@ -46,20 +62,22 @@ func NewPoller(runner CommandRunner) *Poller {
 
 // GetChecks fetches current check states for the given PR number.
 // Retries with exponential backoff (2s, 4s, 8s) on transient failures, max 3 retries.
+// Exhausted retries count as one failure against the circuit, when set.
 func (p *Poller) GetChecks(prNumber int) ([]CheckResult, error) {
-	delays := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second}
 	var out []byte
+	fetch := func() error {
+		var err error
+		out, err = p.fetchChecks(prNumber)
+		return err
+	}
 	var err error
-	for attempt := 0; attempt <= len(delays); attempt++ {
-		out, err = p.runner.Run("gh", "pr", "checks", strconv.Itoa(prNumber), "--json", "name,state")
-		if err == nil {
-			break
-		}
-		if attempt < len(delays) {
-			time.Sleep(delays[attempt])
-		} else {
-			return nil, fmt.Errorf("gh pr checks: %w", err)
-		}
+	if p.circuit != nil {
+		err = p.circuit.Execute(context.Background(), fetch)
+	} else {
+		err = fetch()
+	}
+	if err != nil {
+		return nil, err
 	}
 	var raw []map[string]string
 	if err := json.NewDecoder(io.LimitReader(bytes.NewReader(out), sdputil.MaxJSONDecodeBytes)).Decode(&raw); err != nil {
@@ -75,6 +93,21 @@ func (p *Poller) GetChecks(prNumber int) ([]CheckResult, error) {
 	return results, nil
 }
 
+// fetchChecks runs gh with exponential backoff (2s, 4s, 8s), max 3 retries.
+func (p *Poller) fetchChecks(prNumber int) ([]byte, error) {
+	delays := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for attempt := 0; ; attempt++ {
+		out, err := p.runner.Run("gh", "pr", "checks", strconv.Itoa(prNumber), "--json", "name,state")
+		if err == nil {
+			return out, nil
+		}
+		if attempt >= len(delays) {
+			return nil, fmt.Errorf("gh pr checks: %w", err)
+		}
+		time.Sleep(delays[attempt])
+	}
+}
+
 // FilterByState returns checks matching the given state.
 func FilterByState(checks []CheckResult, state CheckState) []CheckResult {
 	var out []CheckResult
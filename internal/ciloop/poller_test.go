@@ -3,8 +3,11 @@ package ciloop_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/fall-out-bug/sdp/internal/ciloop"
+	"github.com/fall-out-bug/sdp/src/sdp/runtime"
+	"github.com/fall-out-bug/sdp/src/sdp/state"
 )
 
 type fakeRunner struct {
@@ -117,3 +120,40 @@ func TestIsAllGreen(t *testing.T) {
 		t.Error("expected not all green when pending present")
 	}
 }
+
+type countingRunner struct {
+	calls int
+}
+
+func (c *countingRunner) Run(_ string, _ ...string) ([]byte, error) {
+	c.calls++
+	return greenJSON, nil
+}
+
+func TestGetChecksOpenCircuitFailsFast(t *testing.T) {
+	store := state.NewStore(t.TempDir())
+	if err := store.UpdateCircuit(ciloop.CircuitTarget, func(c *state.Circuit) {
+		c.State = state.CircuitOpen
+		c.LastFailure = time.Now()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	runner := &countingRunner{}
+	cb := runtime.NewCircuitBreaker(runtime.DefaultCircuitConfig).Persist(store, ciloop.CircuitTarget)
+	p := ciloop.NewPoller(runner).WithCircuit(cb)
+
+	_, err := p.GetChecks(1)
+	if !errors.Is(err, runtime.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if runner.calls != 0 {
+		t.Errorf("gh must not be called while the circuit is open, got %d calls", runner.calls)
+	}
+
+	if err := store.ResetCircuit(ciloop.CircuitTarget); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.GetChecks(1); err != nil || runner.calls != 1 {
+		t.Errorf("expected call after reset, err=%v calls=%d", err, runner.calls)
+	}
+}
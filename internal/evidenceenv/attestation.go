@@ -63,8 +63,26 @@ type GateResult struct {
 }
 
 type Coverage struct {
-	Value     float64 `json:"value"`
-	Threshold float64 `json:"threshold"`
+	Value     float64        `json:"value"`
+	Threshold float64        `json:"threshold"`
+	Patch     *PatchCoverage `json:"patch,omitempty"`
+}
+
+// PatchCoverage is coverage of the lines changed since the base branch.
+type PatchCoverage struct {
+	Base         string              `json:"base"`
+	Value        float64             `json:"value"`
+	Threshold    float64             `json:"threshold"`
+	CoveredLines int                 `json:"covered_lines"`
+	TotalLines   int                 `json:"total_lines"`
+	Files        []PatchFileCoverage `json:"files,omitempty"`
+}
+
+type PatchFileCoverage struct {
+	File           string `json:"file"`
+	CoveredLines   int    `json:"covered_lines"`
+	TotalLines     int    `json:"total_lines"`
+	UncoveredLines []int  `json:"uncovered_lines,omitempty"`
 }
 
 type Review struct {
@@ -52,7 +52,9 @@ func AutoAttest(opts AutoAttestOptions) (CodingWorkflowStatement, error) {
 		issueID = fmt.Sprintf("ci-auto-pr%s", opts.PRNumber)
 	}
 
-	testResults, coverage := collectTestResults(opts.RepoRoot)
+	profile := filepath.Join(os.TempDir(), fmt.Sprintf("sdp-auto-attest-%d.cover", os.Getpid()))
+	defer os.Remove(profile)
+	testResults, coverage := collectTestResults(opts.RepoRoot, profile)
 	lintResults := collectLintResults(opts.RepoRoot)
 
 	boundary, boundaryOK := checkScopeCompliance(opts.RepoRoot, changedFiles)
@@ -86,7 +88,8 @@ func AutoAttest(opts AutoAttestOptions) (CodingWorkflowStatement, error) {
 			Lint:  lintResults,
 			Coverage: func() *Coverage {
 				if coverage >= 0 {
-					return &Coverage{Value: coverage, Threshold: 80}
+					patch, _ := CollectPatchCoverage(opts.RepoRoot, opts.BaseBranch, profile)
+					return &Coverage{Value: coverage, Threshold: loadQualityConfig(opts.RepoRoot).coverageThreshold(), Patch: patch}
 				}
 				return nil
 			}(),
@@ -176,8 +179,9 @@ func extractWorkstreamsFromBranch(branch string) []string {
 }
 
 // collectTestResults runs go test with -count=1 -cover and parses JSON output.
-func collectTestResults(repoRoot string) ([]GateResult, float64) {
-	cmd := exec.Command("go", "test", "./...", "-count=1", "-cover", "-json")
+// The cover profile is written to profilePath for patch coverage.
+func collectTestResults(repoRoot, profilePath string) ([]GateResult, float64) {
+	cmd := exec.Command("go", "test", "./...", "-count=1", "-cover", "-coverprofile="+profilePath, "-json")
 	cmd.Dir = repoRoot
 	out, err := cmd.Output()
 
@@ -450,6 +454,11 @@ func WriteAutoAttestationReport(outputPath string, stmt CodingWorkflowStatement)
 		report["coverage_pct"] = stmt.Predicate.Verification.Coverage.Value
 		report["coverage_threshold"] = stmt.Predicate.Verification.Coverage.Threshold
 		report["coverage_ok"] = stmt.Predicate.Verification.Coverage.Value >= stmt.Predicate.Verification.Coverage.Threshold
+		if patch := stmt.Predicate.Verification.Coverage.Patch; patch != nil {
+			report["patch_coverage_pct"] = patch.Value
+			report["patch_coverage_lines"] = fmt.Sprintf("%d/%d", patch.CoveredLines, patch.TotalLines)
+			report["patch_coverage_ok"] = patch.Value >= patch.Threshold
+		}
 	}
 
 	b, err := json.MarshalIndent(report, "", "  ")
@@ -70,6 +70,9 @@ func formatAttestationSummary(stmt CodingWorkflowStatement) string {
 	sb.WriteString(fmt.Sprintf("  tests: %d\n", len(p.Verification.Tests)))
 	if p.Verification.Coverage != nil {
 		sb.WriteString(fmt.Sprintf("  coverage: %.0f%%\n", p.Verification.Coverage.Value))
+		if patch := p.Verification.Coverage.Patch; patch != nil {
+			sb.WriteString(fmt.Sprintf("  patch_coverage: %.0f%% (%d/%d lines since %s)\n", patch.Value, patch.CoveredLines, patch.TotalLines, patch.Base))
+		}
 	}
 
 	sb.WriteString(fmt.Sprintf("boundary_compliance: ok=%v reason=%s\n", p.Boundary.Compliance.OK, p.Boundary.Compliance.Reason))
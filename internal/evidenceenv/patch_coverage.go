@@ -0,0 +1,112 @@
+package evidenceenv
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/basebranch"
+)
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// CollectPatchCoverage intersects a coverage report (a Go cover profile,
+// Cobertura XML or coverage.py JSON, as `sdp verify` reads them) with the
+// lines added since the branch diverged from the base (origin/<base> when
+// available). Only lines the report instruments count; files without any
+// are omitted.
+// Like `sdp verify`, the base is quality.patch_coverage_base when set, and the
+// threshold quality.patch_coverage_threshold (else coverage_threshold). Without
+// a configured base, baseBranch is used, then the repository's default branch.
+func CollectPatchCoverage(repoRoot, baseBranch, profilePath string) (*PatchCoverage, error) {
+	quality := loadQualityConfig(repoRoot)
+	if quality.PatchCoverageBase != "" {
+		baseBranch = quality.PatchCoverageBase
+	}
+	if baseBranch == "" {
+		baseBranch = basebranch.Default(repoRoot)
+	}
+	covered, err := ParseCoverageReport(profilePath, repoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("read coverage report: %w", err)
+	}
+
+	base := "origin/" + baseBranch
+	diff, err := runGit(repoRoot, "diff", "-U0", "--no-color", base+"...HEAD")
+	if err != nil {
+		base = baseBranch
+		if diff, err = runGit(repoRoot, "diff", "-U0", "--no-color", base+"...HEAD"); err != nil {
+			return nil, err
+		}
+	}
+
+	patch := &PatchCoverage{Base: base, Threshold: quality.patchThreshold(), Value: 100}
+	added := parseAddedLines(diff)
+	files := make([]string, 0, len(added))
+	for f := range added {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		lines, ok := covered[file]
+		if !ok {
+			continue
+		}
+		fc := PatchFileCoverage{File: file}
+		for _, l := range added[file] {
+			hit, executable := lines[l]
+			if !executable {
+				continue
+			}
+			fc.TotalLines++
+			if hit {
+				fc.CoveredLines++
+			} else {
+				fc.UncoveredLines = append(fc.UncoveredLines, l)
+			}
+		}
+		if fc.TotalLines > 0 {
+			patch.Files = append(patch.Files, fc)
+			patch.CoveredLines += fc.CoveredLines
+			patch.TotalLines += fc.TotalLines
+		}
+	}
+	if patch.TotalLines > 0 {
+		patch.Value = float64(patch.CoveredLines) / float64(patch.TotalLines) * 100
+	}
+	return patch, nil
+}
+
+// parseAddedLines reads a zero-context unified diff into added line numbers.
+func parseAddedLines(diff string) map[string][]int {
+	added := make(map[string][]int)
+	var file string
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "+++ "); ok {
+			file = ""
+			if name != "/dev/null" {
+				file = strings.TrimPrefix(name, "b/")
+			}
+			continue
+		}
+		m := hunkHeaderRe.FindStringSubmatch(line)
+		if m == nil || file == "" {
+			continue
+		}
+		start, _ := strconv.Atoi(m[1])
+		count := 1
+		if m[2] != "" {
+			count, _ = strconv.Atoi(m[2])
+		}
+		for l := start; l < start+count; l++ {
+			added[file] = append(added[file], l)
+		}
+	}
+	return added
+}
@@ -0,0 +1,128 @@
+package evidenceenv
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCollectPatchCoverage(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("go.mod", "module example.com/app\n\ngo 1.22\n")
+	write("calc.go", "package app\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n")
+	run("init", "-q", "-b", "master")
+	run("add", ".")
+	run("commit", "-qm", "base")
+	run("checkout", "-q", "-b", "feature")
+	write("calc.go", "package app\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n\nfunc Sub(a, b int) int {\n\tif a < b {\n\t\treturn 0\n\t}\n\treturn a - b\n}\n")
+	run("commit", "-qam", "add Sub")
+
+	profile := filepath.Join(t.TempDir(), "cover.out")
+	if err := os.WriteFile(profile, []byte(`mode: set
+example.com/app/calc.go:3.24,5.2 1 0
+example.com/app/calc.go:7.24,8.10 1 1
+example.com/app/calc.go:8.10,10.3 1 0
+example.com/app/calc.go:11.2,11.14 1 1
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	patch, err := CollectPatchCoverage(dir, "master", profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch.Base != "master" {
+		t.Errorf("expected fallback to local base without origin, got %q", patch.Base)
+	}
+	if patch.CoveredLines != 3 || patch.TotalLines != 5 || patch.Value != 60 {
+		t.Errorf("expected 3/5 (60%%), got %d/%d (%.1f%%)", patch.CoveredLines, patch.TotalLines, patch.Value)
+	}
+	if len(patch.Files) != 1 || !reflect.DeepEqual(patch.Files[0].UncoveredLines, []int{9, 10}) {
+		t.Errorf("unexpected file breakdown: %+v", patch.Files)
+	}
+	if patch.Threshold != 80 {
+		t.Errorf("expected default threshold 80, got %.0f", patch.Threshold)
+	}
+
+	// coverage.py and Cobertura reports are read like `sdp verify` reads them.
+	pyReport := filepath.Join(t.TempDir(), "coverage.json")
+	if err := os.WriteFile(pyReport, []byte(`{"files": {"calc.go": {"executed_lines": [7, 8, 11], "missing_lines": [3, 9, 10]}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if patch, err = CollectPatchCoverage(dir, "master", pyReport); err != nil || patch.CoveredLines != 3 || patch.TotalLines != 5 {
+		t.Errorf("coverage.py report: got %+v, %v", patch, err)
+	}
+	xmlReport := filepath.Join(t.TempDir(), "coverage.xml")
+	if err := os.WriteFile(xmlReport, []byte(`<coverage><packages><package><classes><class filename="calc.go"><lines>
+<line number="7" hits="1"/><line number="9" hits="0"/></lines></class></classes></package></packages></coverage>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if patch, err = CollectPatchCoverage(dir, "master", xmlReport); err != nil || patch.CoveredLines != 1 || patch.TotalLines != 2 {
+		t.Errorf("Cobertura report: got %+v, %v", patch, err)
+	}
+
+	// Without a base the default branch is detected; config wins over both.
+	if patch, err = CollectPatchCoverage(dir, "", profile); err != nil || patch.Base != "master" {
+		t.Fatalf("expected detected base master, got %+v, %v", patch, err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write(".sdp/config.yml", "quality:\n  coverage_threshold: 90\n  patch_coverage_threshold: 70\n  patch_coverage_base: feature\n")
+	if patch, err = CollectPatchCoverage(dir, "master", profile); err != nil {
+		t.Fatal(err)
+	}
+	if patch.Base != "feature" || patch.Threshold != 70 || patch.TotalLines != 0 {
+		t.Errorf("expected configured base and threshold, got base %q threshold %.0f lines %d", patch.Base, patch.Threshold, patch.TotalLines)
+	}
+}
+
+func TestParseAddedLinesSkipsDeletedFiles(t *testing.T) {
+	diff := "+++ b/a.go\n@@ -1,0 +2,2 @@\n+x\n+y\n+++ /dev/null\n@@ -1,3 +0,0 @@\n"
+	if got := parseAddedLines(diff); !reflect.DeepEqual(got, map[string][]int{"a.go": {2, 3}}) {
+		t.Errorf("parseAddedLines = %v", got)
+	}
+}
+
+// TestCoverageParse_PluginCopyMatches keeps coverage_parse.go in step with
+// the sdp CLI's report parser, its source of truth; only the package clause
+// differs.
+func TestCoverageParse_PluginCopyMatches(t *testing.T) {
+	plugin, err := os.ReadFile(filepath.Join("..", "..", "sdp-plugin", "internal", "quality", "patch_coverage_parse.go"))
+	if os.IsNotExist(err) {
+		t.Skip("sdp-plugin not checked out")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := os.ReadFile("coverage_parse.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin = bytes.Replace(plugin, []byte("package quality\n"), []byte("package evidenceenv\n"), 1)
+	if !bytes.Equal(local, plugin) {
+		t.Error("internal/evidenceenv/coverage_parse.go differs from sdp-plugin/internal/quality/patch_coverage_parse.go; copy the change over")
+	}
+}
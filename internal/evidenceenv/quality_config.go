@@ -0,0 +1,50 @@
+package evidenceenv
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultCoverageThreshold applies when .sdp/config.yml sets no threshold,
+// matching the sdp CLI default.
+const defaultCoverageThreshold = 80.0
+
+// qualityConfig is the quality section of .sdp/config.yml, read the same way
+// the sdp CLI reads it so attestations and `sdp verify` agree.
+type qualityConfig struct {
+	CoverageThreshold      int    `yaml:"coverage_threshold"`
+	PatchCoverageThreshold int    `yaml:"patch_coverage_threshold"`
+	PatchCoverageBase      string `yaml:"patch_coverage_base"`
+}
+
+// loadQualityConfig reads quality settings from repoRoot. A missing or
+// unreadable config yields zero values, i.e. the defaults.
+func loadQualityConfig(repoRoot string) qualityConfig {
+	var cfg struct {
+		Quality qualityConfig `yaml:"quality"`
+	}
+	data, err := os.ReadFile(filepath.Join(repoRoot, ".sdp", "config.yml"))
+	if err == nil {
+		_ = yaml.Unmarshal(data, &cfg)
+	}
+	return cfg.Quality
+}
+
+// coverageThreshold returns quality.coverage_threshold or the default.
+func (q qualityConfig) coverageThreshold() float64 {
+	if q.CoverageThreshold > 0 {
+		return float64(q.CoverageThreshold)
+	}
+	return defaultCoverageThreshold
+}
+
+// patchThreshold returns quality.patch_coverage_threshold, falling back to
+// the whole-project threshold.
+func (q qualityConfig) patchThreshold() float64 {
+	if q.PatchCoverageThreshold > 0 {
+		return float64(q.PatchCoverageThreshold)
+	}
+	return q.coverageThreshold()
+}
@@ -0,0 +1,29 @@
+package filelock
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPluginCopyMatches keeps this copy in sync with the sdp CLI's, the
+// source of truth.
+func TestPluginCopyMatches(t *testing.T) {
+	for _, name := range []string{"filelock_unix.go", "filelock_windows.go"} {
+		plugin, err := os.ReadFile(filepath.Join("..", "..", "sdp-plugin", "internal", "filelock", name))
+		if os.IsNotExist(err) {
+			t.Skip("sdp-plugin not checked out")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(root, plugin) {
+			t.Errorf("internal/filelock/%s differs from sdp-plugin/internal/filelock/%s; copy the change over", name, name)
+		}
+	}
+}
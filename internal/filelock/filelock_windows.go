@@ -0,0 +1,18 @@
+//go:build windows
+
+package filelock
+
+import "os"
+
+// Lock is a no-op on Windows; locking uses flock on UNIX only, so
+// concurrent sessions on Windows may lose updates.
+func Lock(f *os.File) error {
+	_ = f
+	return nil
+}
+
+// Unlock is a no-op on Windows.
+func Unlock(f *os.File) error {
+	_ = f
+	return nil
+}
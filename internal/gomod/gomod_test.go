@@ -0,0 +1,27 @@
+package gomod
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPluginCopyMatches keeps this copy in sync with the sdp CLI's, the
+// source of truth.
+func TestPluginCopyMatches(t *testing.T) {
+	plugin, err := os.ReadFile(filepath.Join("..", "..", "sdp-plugin", "internal", "gomod", "gomod.go"))
+	if os.IsNotExist(err) {
+		t.Skip("sdp-plugin not checked out")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.ReadFile("gomod.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(root, plugin) {
+		t.Error("internal/gomod/gomod.go differs from sdp-plugin/internal/gomod/gomod.go; copy the change over")
+	}
+}
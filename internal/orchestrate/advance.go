@@ -1,9 +1,11 @@
 package orchestrate
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/fall-out-bug/sdp/internal/guard"
 )
@@ -31,14 +33,28 @@ func (e *ScopeViolationError) Error() string {
 		e.WSID, len(e.Violations), strings.Join(e.Violations, ", "))
 }
 
-// CreateScopeEscalationBead runs bd create for a scope violation.
+// CreateScopeEscalationBead files a P1 issue for a scope violation: with
+// bd when Beads is installed, otherwise in the project's queue through
+// "sdp queue create". The command is killed after cliExecTimeout.
 func CreateScopeEscalationBead(wsID string, violations []string) error {
 	title := fmt.Sprintf("SCOPE VIOLATION: %s touched %s", wsID, strings.Join(violations, ", "))
 	if len(title) > 200 {
 		title = title[:197] + "..."
 	}
-	cmd := exec.Command("bd", "create", "--title", title, "--priority", "1", "--labels", "scope-violation")
+	ctx, cancel := context.WithTimeout(context.Background(), cliExecTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "bd", "create", "--title", title, "--priority", "1", "--labels", "scope-violation")
+	if _, err := exec.LookPath("bd"); err != nil {
+		cmd = exec.CommandContext(ctx, "sdp", "queue", "create", "--title", title, "--priority", "1", "--label", "scope-violation", "--ws", wsID)
+	}
 	cmd.Stdout = nil
 	cmd.Stderr = nil
-	return cmd.Run()
+	cmd.WaitDelay = time.Second
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("create scope escalation: timed out after %s", cliExecTimeout)
+		}
+		return err
+	}
+	return nil
 }
@@ -88,6 +88,31 @@ func TestRunGuardCheck_AdvanceWithViolationBlocked(t *testing.T) {
 	}
 }
 
+func TestCreateScopeEscalationBead_FallsBackToQueue(t *testing.T) {
+	if _, err := exec.LookPath("bd"); err == nil {
+		t.Skip("bd installed; escalation goes to Beads")
+	}
+	bin := t.TempDir()
+	argsFile := filepath.Join(bin, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "sdp"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if err := orchestrate.CreateScopeEscalationBead("00-023-01", []string{"main.go"}); err != nil {
+		t.Fatalf("CreateScopeEscalationBead: %v", err)
+	}
+	got, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "queue create --title SCOPE VIOLATION: 00-023-01 touched main.go --priority 1 --label scope-violation --ws 00-023-01\n"
+	if string(got) != want {
+		t.Errorf("sdp args = %q, want %q", got, want)
+	}
+}
+
 func setupGuardTestProject(t *testing.T, dir string) {
 	t.Helper()
 	runGit(t, dir, "init")
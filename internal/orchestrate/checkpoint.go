@@ -10,30 +10,31 @@ import (
 	"time"
 
 	"github.com/fall-out-bug/sdp/internal/sdputil"
+	"github.com/fall-out-bug/sdp/src/sdp/checkpoint"
 )
 
 // Checkpoint is the .sdp/checkpoints/F{NNN}.json schema for the orchestrate state machine.
 // Compatible with ciloop.Checkpoint for pr_number, feature_id, branch (used by sdp-ci-loop and stop gate).
 type Checkpoint struct {
-	Schema     string        `json:"schema"`
-	FeatureID  string        `json:"feature_id"`
-	Branch     string        `json:"branch"`
-	PRNumber   *int          `json:"pr_number,omitempty"`
-	PRURL      string        `json:"pr_url,omitempty"`
-	Phase      string        `json:"phase"`
-	CreatedAt  string        `json:"created_at,omitempty"`
-	UpdatedAt  string        `json:"updated_at,omitempty"`
-	Workstreams []WSStatus   `json:"workstreams,omitempty"`
-	Review     *ReviewStatus `json:"review,omitempty"`
+	Schema      string        `json:"schema"`
+	FeatureID   string        `json:"feature_id"`
+	Branch      string        `json:"branch"`
+	PRNumber    *int          `json:"pr_number,omitempty"`
+	PRURL       string        `json:"pr_url,omitempty"`
+	Phase       string        `json:"phase"`
+	CreatedAt   string        `json:"created_at,omitempty"`
+	UpdatedAt   string        `json:"updated_at,omitempty"`
+	Workstreams []WSStatus    `json:"workstreams,omitempty"`
+	Review      *ReviewStatus `json:"review,omitempty"`
 }
 
 // WSStatus tracks a single workstream's execution.
 type WSStatus struct {
-	ID         string `json:"id"`
-	Status     string `json:"status"` // pending, in_progress, done
+	ID          string `json:"id"`
+	Status      string `json:"status"` // pending, in_progress, done
 	VerdictFile string `json:"verdict_file,omitempty"`
-	Commit     string `json:"commit,omitempty"`
-	Attempts   int    `json:"attempts,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	Attempts    int    `json:"attempts,omitempty"`
 }
 
 // ReviewStatus tracks review phase state.
@@ -70,7 +71,8 @@ func LoadCheckpoint(dir, featureID string) (*Checkpoint, error) {
 	return &cp, nil
 }
 
-// SaveCheckpoint writes the checkpoint to disk atomically.
+// SaveCheckpoint writes the checkpoint to disk atomically and records it as the
+// orchestrate section of the feature's run in the unified checkpoint store.
 func SaveCheckpoint(dir string, cp *Checkpoint) error {
 	if err := sdputil.ValidateFeatureID(cp.FeatureID); err != nil {
 		return err
@@ -89,5 +91,8 @@ func SaveCheckpoint(dir string, cp *Checkpoint) error {
 		_ = os.Remove(tmpPath)
 		return fmt.Errorf("rename checkpoint: %w", err)
 	}
+	if err := checkpoint.NewStore(dir).Put(cp.FeatureID, cp.FeatureID, checkpoint.EngineOrchestrate, cp.FeatureID+".json", cp); err != nil {
+		return fmt.Errorf("store checkpoint: %w", err)
+	}
 	return nil
 }
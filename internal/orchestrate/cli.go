@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/fall-out-bug/sdp/internal/sdputil"
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
 )
 
 const (
@@ -156,5 +157,10 @@ func RunCILoop(ctx context.Context, pr int, featureID, checkpointDir, runsDir st
 	cmd := exec.CommandContext(ctx, path, "--pr", fmt.Sprintf("%d", pr), "--feature", featureID, "--checkpoint-dir", checkpointDir, "--runs-dir", runsDir)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == ExitBudgetExhausted {
+		return fmt.Errorf("sdp-ci-loop: %w", budget.ErrBudgetExhausted)
+	}
+	return err
 }
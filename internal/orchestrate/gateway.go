@@ -0,0 +1,106 @@
+package orchestrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+	"github.com/fall-out-bug/sdp/src/sdp/hooks"
+	"github.com/fall-out-bug/sdp/src/sdp/model"
+)
+
+// ExitBudgetExhausted is the process exit code when a budget hard stop halts the run.
+// The checkpoint is saved first, so raising the budget and re-running resumes the feature.
+const ExitBudgetExhausted = 3
+
+// ErrRateLimited is returned by MeteredInvoker when the runtime exits non-zero
+// with rate-limit output, so RoutedInvoker can fall back to the next model.
+var ErrRateLimited = errors.New("rate limited")
+
+// rateLimitTail bounds how much trailing output is scanned for rate-limit markers.
+const rateLimitTail = 2048
+
+// NewGateway returns a hook registry with the default gateway handlers and,
+// when .sdp/budgets.yaml exists, budget enforcement.
+func NewGateway(projectRoot string) (*hooks.HookRegistry, error) {
+	registry := hooks.NewRegistry()
+	hooks.RegisterGatewayHooks(registry)
+	enforcer, err := budget.LoadEnforcer(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	budget.RegisterGatewayHooks(registry, enforcer)
+	return registry, nil
+}
+
+// MeteredInvoker wraps an LLMInvoker and publishes gateway hook events around
+// each call, so budgets are enforced before the call and spend recorded after.
+// Token counts are estimated from prompt and output size because the opencode
+// runtime does not report usage.
+type MeteredInvoker struct {
+	Inner     LLMInvoker
+	Gateway   *hooks.HookRegistry
+	FeatureID string
+	WSID      string
+	Profile   model.Profile
+}
+
+// Invoke implements LLMInvoker.
+func (m *MeteredInvoker) Invoke(ctx context.Context, dir, agent, prompt string) (string, int, error) {
+	inner := m.Inner
+	if inner == nil {
+		inner = DefaultLLMInvoker
+	}
+	if m.Gateway == nil {
+		return m.invoke(ctx, inner, dir, agent, prompt)
+	}
+
+	promptHash := ComputePromptHash(prompt)
+	inTokens := budget.EstimateTokens(prompt)
+	req := hooks.NewGatewayEvent(m.Profile.ModelID, promptHash, inTokens, 0, 0, 0, nil).WithScope(m.FeatureID, m.WSID)
+	if err := m.Gateway.Publish(req.ToHookEvent(hooks.EventTypeGatewayRequest)); err != nil {
+		return "", -1, err
+	}
+
+	start := time.Now()
+	out, code, err := m.invoke(ctx, inner, dir, agent, prompt)
+	outTokens := budget.EstimateTokens(out)
+	cost := model.EstimateCost(inTokens+outTokens, m.Profile)
+	resp := hooks.NewGatewayEvent(m.Profile.ModelID, promptHash, inTokens, outTokens, time.Since(start), cost, err).WithScope(m.FeatureID, m.WSID)
+
+	eventType := hooks.EventTypeGatewayResponse
+	if err != nil {
+		eventType = hooks.EventTypeGatewayError
+	}
+	if pubErr := m.Gateway.Publish(resp.ToHookEvent(eventType)); pubErr != nil {
+		slog.Warn("gateway hook failed", "error", pubErr, "feature", m.FeatureID, "ws", m.WSID)
+	}
+	return out, code, err
+}
+
+// invoke pins the profile's model when the inner invoker supports it and turns
+// a non-zero exit with rate-limit output into ErrRateLimited.
+func (m *MeteredInvoker) invoke(ctx context.Context, inner LLMInvoker, dir, agent, prompt string) (string, int, error) {
+	var out string
+	var code int
+	var err error
+	if mi, ok := inner.(ModelInvoker); ok && m.Profile.ModelID != "" {
+		out, code, err = mi.InvokeModel(ctx, dir, agent, m.Profile.ModelID, prompt)
+	} else {
+		out, code, err = inner.Invoke(ctx, dir, agent, prompt)
+	}
+	if err == nil && code != 0 && outputRateLimited(out) {
+		err = fmt.Errorf("%w: %s exited %d on %s", ErrRateLimited, agent, code, m.Profile.ModelID)
+	}
+	return out, code, err
+}
+
+func outputRateLimited(out string) bool {
+	if len(out) > rateLimitTail {
+		out = out[len(out)-rateLimitTail:]
+	}
+	return hooks.GatewayEvent{Error: errors.New(out)}.IsRateLimited()
+}
@@ -0,0 +1,66 @@
+package orchestrate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+	"github.com/fall-out-bug/sdp/src/sdp/model"
+)
+
+func TestMeteredInvoker_RecordsSpendThenHalts(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "defaults:\n  workstream: {max_tokens: 100}\n"
+	if err := os.WriteFile(filepath.Join(dir, budget.ConfigPath), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gateway, err := NewGateway(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeLLMInvoker{output: strings.Repeat("x", 400)}
+	invoker := &MeteredInvoker{
+		Inner:     fake,
+		Gateway:   gateway,
+		FeatureID: "F027",
+		WSID:      "00-027-01",
+		Profile:   model.Profile{ModelID: "claude-sonnet", CostPer1K: 0.003},
+	}
+
+	if _, _, err := invoker.Invoke(context.Background(), dir, "implementer", "prompt"); err != nil {
+		t.Fatalf("first call under budget: %v", err)
+	}
+	ld, err := budget.NewLedger(dir).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spend := ld.Workstreams["00-027-01"]
+	if spend.OutputTokens != 100 || spend.Cost == 0 {
+		t.Errorf("expected estimated spend recorded, got %+v", spend)
+	}
+
+	fake.invoked = false
+	_, _, err = invoker.Invoke(context.Background(), dir, "implementer", "prompt")
+	if !errors.Is(err, budget.ErrBudgetExhausted) {
+		t.Fatalf("expected ErrBudgetExhausted, got %v", err)
+	}
+	if fake.invoked {
+		t.Error("LLM must not be invoked once the budget is exhausted")
+	}
+}
+
+func TestMeteredInvoker_NoGatewayPassesThrough(t *testing.T) {
+	fake := &fakeLLMInvoker{output: "ok"}
+	out, _, err := (&MeteredInvoker{Inner: fake}).Invoke(context.Background(), t.TempDir(), "reviewer", "p")
+	if err != nil || out != "ok" || !fake.invoked {
+		t.Errorf("expected passthrough, got out=%q err=%v invoked=%v", out, err, fake.invoked)
+	}
+}
@@ -7,9 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"strings"
 
-	"github.com/fall-out-bug/sdp/internal/prompt"
 	"github.com/fall-out-bug/sdp/internal/sdputil"
 )
 
@@ -19,12 +17,14 @@ const contextPacketPath = ".sdp/context-packet.json"
 // All fields are sourced deterministically (file read, git status, bd show — no LLM).
 type ContextPacket struct {
 	Workstream         string            `json:"workstream"`
+	Size               string            `json:"size,omitempty"`
 	AcceptanceCriteria []string          `json:"acceptance_criteria"`
 	ScopeFiles         []string          `json:"scope_files"`
 	Checkpoint         *Checkpoint       `json:"checkpoint,omitempty"`
 	Dependencies       map[string]string `json:"dependencies,omitempty"`
 	QualityGates       string            `json:"quality_gates"`
 	DriftStatus        string            `json:"drift_status"`
+	PriorLessons       []PriorLesson     `json:"prior_lessons,omitempty"`
 }
 
 // Hydrate gathers all context deterministically and writes .sdp/context-packet.json.
@@ -42,12 +42,23 @@ func Hydrate(projectRoot, featureID, wsID string, cp *Checkpoint) (*ContextPacke
 	}
 	pkt.Workstream = string(wsContent)
 	pkt.AcceptanceCriteria, pkt.ScopeFiles = parseWorkstreamSections(string(wsContent))
+	pkt.Size = parseSize(string(wsContent))
 	pkt.Checkpoint = cp
 
+	// Prior lessons are advisory: a failed lookup never blocks hydration
+	pkt.PriorLessons, err = queryPriorLessons(projectRoot, parseGoal(string(wsContent)), pkt.ScopeFiles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: prior lessons: %v\n", err)
+	}
+
 	deps := parseDependsOn(string(wsContent))
 	if len(deps) > 0 {
 		pkt.Dependencies = make(map[string]string)
 		for _, dep := range deps {
+			if out, ok := queueShow(projectRoot, dep); ok {
+				pkt.Dependencies[dep] = out
+				continue
+			}
 			beadsID := wsIDToBeadsID(projectRoot, dep)
 			if beadsID != "" {
 				out, err := bdShow(projectRoot, beadsID)
@@ -102,6 +113,7 @@ func HydrateForReview(projectRoot, featureID string, cp *Checkpoint, workstreams
 		p := filepath.Join(projectRoot, "docs", "workstreams", "backlog", workstreams[i]+".md")
 		if b, err := os.ReadFile(p); err == nil {
 			pkt.Workstream += "\n\n---\n\n" + string(b)
+			pkt.Size = largerSize(pkt.Size, parseSize(string(b)))
 		}
 	}
 	return pkt, nil
@@ -152,21 +164,8 @@ func LoadContextPacket(projectRoot string) (*ContextPacket, error) {
 	return &pkt, nil
 }
 
-// FormatForPrompt returns the packet as a string suitable for injection into the LLM prompt.
+// FormatForPrompt returns the packet as a string suitable for injection into the LLM prompt,
+// within DefaultContextTokenBudget. Use Assemble to read scope files and see what was omitted.
 func (p *ContextPacket) FormatForPrompt() string {
-	var b strings.Builder
-	b.WriteString("\n\n## Context Packet (pre-hydrated)\n\n")
-	b.WriteString("### Workstream\n\n")
-	b.WriteString(p.Workstream)
-	b.WriteString("\n\n")
-	b.WriteString(prompt.AcceptanceCriteriaSection(p.AcceptanceCriteria))
-	b.WriteString(prompt.ScopeFilesSection(p.ScopeFiles))
-	b.WriteString("### Quality Gates\n\n")
-	b.WriteString(p.QualityGates)
-	b.WriteString("\n\n### Drift Status (git status --porcelain)\n\n")
-	b.WriteString(p.DriftStatus)
-	if p.DriftStatus == "" {
-		b.WriteString("(clean)\n")
-	}
-	return b.String()
+	return p.Assemble("", DefaultContextTokenBudget).Text
 }
@@ -0,0 +1,150 @@
+package orchestrate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+)
+
+// minSectionTokens is the smallest remainder worth truncating a section
+// into; below it the section is dropped.
+const minSectionTokens = 32
+
+// Actions recorded for context that did not enter the prompt in full.
+const (
+	OmitTruncated = "truncated"
+	OmitCondensed = "condensed"
+	OmitDropped   = "dropped"
+)
+
+// ContextSection is one part of the context packet prompt. Variants are
+// renderings from fullest to most compact (e.g. file bodies, go/ast
+// outlines, a path list); the assembler uses the first that fits.
+type ContextSection struct {
+	Name     string
+	Priority int  // lower is kept first
+	Required bool // truncated if necessary but never dropped
+	Variants []ContextVariant
+}
+
+// ContextVariant is one rendering of a section. Label describes it in
+// provenance when it replaces the full rendering.
+type ContextVariant struct {
+	Label string
+	Text  string
+}
+
+// fullSection is a section with a single rendering.
+func fullSection(name string, priority int, text string) ContextSection {
+	return ContextSection{Name: name, Priority: priority, Variants: []ContextVariant{{Label: "full", Text: text}}}
+}
+
+// OmittedContext records a section the agent did not see in full, for
+// prompt provenance.
+type OmittedContext struct {
+	Section string `json:"section"`
+	Action  string `json:"action"`           // truncated, condensed or dropped
+	Tokens  int    `json:"tokens"`           // estimated tokens left out
+	Detail  string `json:"detail,omitempty"` // e.g. which variant was used
+}
+
+// AssembledContext is the budgeted prompt text plus what was left out.
+type AssembledContext struct {
+	Text    string
+	Tokens  int
+	Budget  int
+	Omitted []OmittedContext
+	Lessons []PriorLesson // prior lessons rendered into Text in full
+}
+
+// AssembleContext renders sections within tokenBudget. Sections claim
+// budget in priority order and are written in their original order. A
+// section that does not fit falls back to a more compact variant, then is
+// truncated, then dropped; each step is recorded in Omitted. A budget of
+// zero or less disables the limit.
+func AssembleContext(sections []ContextSection, tokenBudget int) AssembledContext {
+	order := make([]int, len(sections))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		sa, sb := sections[order[a]], sections[order[b]]
+		if sa.Required != sb.Required {
+			return sa.Required
+		}
+		return sa.Priority < sb.Priority
+	})
+
+	out := AssembledContext{Budget: tokenBudget}
+	texts := make([]string, len(sections))
+	omitted := make([]*OmittedContext, len(sections))
+	remaining := tokenBudget
+	for _, i := range order {
+		s := sections[i]
+		if len(s.Variants) == 0 || s.Variants[0].Text == "" {
+			continue
+		}
+		full := budget.EstimateTokens(s.Variants[0].Text)
+		text, action, detail := fitSection(s, remaining, tokenBudget <= 0)
+		texts[i] = text
+		used := budget.EstimateTokens(text)
+		remaining -= used
+		if action != "" {
+			omitted[i] = &OmittedContext{Section: s.Name, Action: action, Tokens: max(full-used, 0), Detail: detail}
+		}
+	}
+
+	var b strings.Builder
+	for i, t := range texts {
+		b.WriteString(t)
+		if omitted[i] != nil {
+			out.Omitted = append(out.Omitted, *omitted[i])
+		}
+	}
+	out.Text = b.String()
+	out.Tokens = budget.EstimateTokens(out.Text)
+	return out
+}
+
+// fitSection picks the rendering of s that fits in remaining tokens and
+// reports how it was reduced ("" when shown in full).
+func fitSection(s ContextSection, remaining int, unlimited bool) (text, action, detail string) {
+	if unlimited {
+		return s.Variants[0].Text, "", ""
+	}
+	for i, v := range s.Variants {
+		if budget.EstimateTokens(v.Text) <= remaining {
+			if i == 0 {
+				return v.Text, "", ""
+			}
+			return v.Text, OmitCondensed, v.Label
+		}
+	}
+	last := s.Variants[len(s.Variants)-1]
+	if remaining < minSectionTokens && !s.Required {
+		return "", OmitDropped, ""
+	}
+	if len(s.Variants) > 1 {
+		detail = last.Label
+	}
+	return truncateToTokens(last.Text, max(remaining, minSectionTokens)), OmitTruncated, detail
+}
+
+// truncateToTokens cuts text at a line boundary so that it, plus a
+// truncation note, fits in about tokens.
+func truncateToTokens(text string, tokens int) string {
+	const note = "\n... (truncated to fit the context budget)\n\n"
+	limit := tokens*4 - len(note)
+	if limit <= 0 {
+		return strings.TrimPrefix(note, "\n")
+	}
+	if len(text) <= limit {
+		return text
+	}
+	cut := text[:limit]
+	if nl := strings.LastIndexByte(cut, '\n'); nl > 0 {
+		cut = cut[:nl]
+	}
+	return strings.ToValidUTF8(cut, "") + note
+}
@@ -0,0 +1,85 @@
+package orchestrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+)
+
+func TestAssembleContext_PriorityAndOrder(t *testing.T) {
+	sections := []ContextSection{
+		{Name: "workstream", Required: true, Variants: []ContextVariant{{Label: "full", Text: "# WS\n"}}},
+		fullSection("low", 5, strings.Repeat("low priority line\n", 40)),
+		fullSection("high", 1, "- AC one\n"),
+		{Name: "scope", Priority: 2, Variants: []ContextVariant{
+			{Label: "full", Text: strings.Repeat("func body line\n", 100)},
+			{Label: "go/ast outlines", Text: "func A()\n"},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		budget  int
+		omitted map[string]string // section -> action
+	}{
+		{"unlimited", 0, map[string]string{}},
+		{"everything fits", 10000, map[string]string{}},
+		{"scope condensed, low truncated", 120, map[string]string{"scope": OmitCondensed, "low": OmitTruncated}},
+		{"low dropped", 30, map[string]string{"scope": OmitCondensed, "low": OmitDropped}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssembleContext(sections, tt.budget)
+			if tt.budget > 0 && got.Tokens > tt.budget+minSectionTokens {
+				t.Errorf("Tokens = %d, budget %d", got.Tokens, tt.budget)
+			}
+			actions := map[string]string{}
+			for _, o := range got.Omitted {
+				actions[o.Section] = o.Action
+				if o.Action != OmitCondensed && o.Tokens <= 0 {
+					t.Errorf("%s: omitted tokens = %d", o.Section, o.Tokens)
+				}
+			}
+			if len(actions) != len(tt.omitted) {
+				t.Errorf("Omitted = %+v, want %v", got.Omitted, tt.omitted)
+			}
+			for name, action := range tt.omitted {
+				if actions[name] != action {
+					t.Errorf("%s action = %q, want %q", name, actions[name], action)
+				}
+			}
+			if ws, ac := strings.Index(got.Text, "# WS"), strings.Index(got.Text, "- AC one"); ws != 0 || ac < ws {
+				t.Errorf("sections out of order:\n%s", got.Text)
+			}
+		})
+	}
+}
+
+func TestAssembleContext_RequiredIsTruncatedNotDropped(t *testing.T) {
+	long := strings.Repeat("workstream line\n", 200)
+	got := AssembleContext([]ContextSection{
+		{Name: "workstream", Required: true, Variants: []ContextVariant{{Label: "full", Text: long}}},
+		fullSection("drift", 5, strings.Repeat("M file.go\n", 20)),
+	}, 50)
+	if !strings.HasPrefix(got.Text, "workstream line\n") || !strings.Contains(got.Text, "truncated to fit the context budget") {
+		t.Errorf("required section not truncated:\n%s", got.Text)
+	}
+	if len(got.Omitted) != 2 || got.Omitted[0].Action != OmitTruncated || got.Omitted[1].Action != OmitDropped {
+		t.Errorf("Omitted = %+v", got.Omitted)
+	}
+}
+
+func TestTruncateToTokens(t *testing.T) {
+	text := "line one\nline two\nline three\n"
+	if got := truncateToTokens(text, 100); got != text {
+		t.Errorf("short text changed: %q", got)
+	}
+	got := truncateToTokens(strings.Repeat(text, 50), 20)
+	if budget.EstimateTokens(got) > 20 {
+		t.Errorf("truncated text has %d tokens, want <= 20", budget.EstimateTokens(got))
+	}
+	if !strings.HasPrefix(got, "line one\n") || !strings.Contains(got, "\n... (truncated") {
+		t.Errorf("truncation should keep whole lines: %q", got)
+	}
+}
@@ -0,0 +1,77 @@
+package orchestrate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fall-out-bug/sdp/internal/prompt"
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+	"github.com/fall-out-bug/sdp/src/sdp/model"
+)
+
+// DefaultContextTokenBudget caps the context packet when .sdp/budgets.yaml
+// configures no context budget for the runtime or model.
+const DefaultContextTokenBudget = 32000
+
+// RuntimeOpenCode names the opencode runtime in context budget config.
+const RuntimeOpenCode = "opencode"
+
+// Section priorities: lower values keep their budget when the packet is
+// too large. The workstream itself is required.
+const (
+	priorityAcceptance = iota + 1
+	priorityScope
+	priorityBoundary
+	priorityLessons
+	priorityDrift
+)
+
+// ContextTokenBudget returns the context packet budget for a runtime and
+// model from .sdp/budgets.yaml, falling back to DefaultContextTokenBudget.
+// A malformed config is reported on stderr and does not block the build.
+func ContextTokenBudget(projectRoot, runtime, modelID string) int {
+	cfg, err := budget.LoadConfig(projectRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: context budget: %v\n", err)
+	}
+	if cfg != nil {
+		if n := cfg.ContextBudget(runtime, modelID); n > 0 {
+			return n
+		}
+	}
+	return DefaultContextTokenBudget
+}
+
+// phaseContextBudget resolves the budget for the model routed to a phase.
+func phaseContextBudget(projectRoot, phase, size string) int {
+	modelID := model.NewRouter().SelectForPhase(phase, size).ModelID
+	return ContextTokenBudget(projectRoot, RuntimeOpenCode, modelID)
+}
+
+// Sections splits the packet into prioritised prompt sections: acceptance
+// criteria, scope files, the quality-gate boundary, prior lessons and git
+// status. Scope file contents are read from projectRoot when it is set.
+func (p *ContextPacket) Sections(projectRoot string) []ContextSection {
+	workstream := "\n\n## Context Packet (pre-hydrated)\n\n### Workstream\n\n" + p.Workstream + "\n\n"
+	drift := p.DriftStatus
+	if drift == "" {
+		drift = "(clean)\n"
+	}
+	return []ContextSection{
+		{Name: "workstream", Required: true, Variants: []ContextVariant{{Label: "full", Text: workstream}}},
+		fullSection("acceptance_criteria", priorityAcceptance, prompt.AcceptanceCriteriaSection(p.AcceptanceCriteria)),
+		{Name: "scope_files", Priority: priorityScope, Variants: scopeFileVariants(projectRoot, p.ScopeFiles)},
+		fullSection("prior_lessons", priorityLessons, FormatPriorLessons(p.PriorLessons, priorLessonsTokenBudget)),
+		fullSection("quality_gates", priorityBoundary, "### Quality Gates\n\n"+p.QualityGates+"\n\n"),
+		fullSection("drift_status", priorityDrift, "### Drift Status (git status --porcelain)\n\n"+drift),
+	}
+}
+
+// Assemble renders the packet within tokenBudget, recording every section
+// that was condensed, truncated or dropped and the prior lessons that
+// made it into the text.
+func (p *ContextPacket) Assemble(projectRoot string, tokenBudget int) AssembledContext {
+	out := AssembleContext(p.Sections(projectRoot), tokenBudget)
+	out.Lessons = renderedPriorLessons(p.PriorLessons, out.Text)
+	return out
+}
@@ -0,0 +1,119 @@
+package orchestrate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContextTokenBudget(t *testing.T) {
+	root := t.TempDir()
+	if got := ContextTokenBudget(root, RuntimeOpenCode, "claude-sonnet"); got != DefaultContextTokenBudget {
+		t.Errorf("without config = %d, want %d", got, DefaultContextTokenBudget)
+	}
+	writeContextBudget(t, root, "context:\n  runtimes: {opencode: 9000}\n  models: {claude-opus: 12000}\n")
+	if got := ContextTokenBudget(root, RuntimeOpenCode, "claude-opus"); got != 12000 {
+		t.Errorf("model budget = %d, want 12000", got)
+	}
+	if got := ContextTokenBudget(root, RuntimeOpenCode, "claude-sonnet"); got != 9000 {
+		t.Errorf("runtime budget = %d, want 9000", got)
+	}
+}
+
+func TestContextPacket_AssembleWithinBudget(t *testing.T) {
+	root := t.TempDir()
+	body := "package big\n\nfunc Handle() {\n" + strings.Repeat("\tprintln(\"step\")\n", 400) + "}\n"
+	if err := os.WriteFile(filepath.Join(root, "big.go"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pkt := &ContextPacket{
+		Workstream:         "# 00-046-01: Budgeted context",
+		AcceptanceCriteria: []string{"Context fits the budget"},
+		ScopeFiles:         []string{"big.go"},
+		QualityGates:       "go test ./...",
+		DriftStatus:        strings.Repeat(" M internal/file.go\n", 200),
+	}
+
+	got := pkt.Assemble(root, 400)
+	if got.Tokens > 400 {
+		t.Errorf("Tokens = %d, want <= 400", got.Tokens)
+	}
+	for _, want := range []string{"Context fits the budget", "#### big.go (outline)", "func Handle()", "go test ./..."} {
+		if !strings.Contains(got.Text, want) {
+			t.Errorf("assembled context missing %q:\n%s", want, got.Text)
+		}
+	}
+	actions := map[string]string{}
+	for _, o := range got.Omitted {
+		actions[o.Section] = o.Action + ":" + o.Detail
+	}
+	if actions["scope_files"] != OmitCondensed+":go/ast outlines" || !strings.HasPrefix(actions["drift_status"], OmitTruncated) {
+		t.Errorf("Omitted = %+v", got.Omitted)
+	}
+
+	if full := pkt.Assemble(root, 0); len(full.Omitted) != 0 || !strings.Contains(full.Text, "println") {
+		t.Errorf("unlimited budget should include everything, omitted %+v", full.Omitted)
+	}
+}
+
+func TestContextPacket_AssembleRecordsRenderedLessons(t *testing.T) {
+	long := strings.Repeat("retry storm ", 400)
+	pkt := &ContextPacket{
+		Workstream: "# 00-045-01: Lesson provenance",
+		PriorLessons: []PriorLesson{
+			{Title: "first", Ref: "a", Outcome: "failed", Summary: "short"},
+			{Title: "second", Ref: "b", Outcome: "failed", Summary: long},
+			{Title: "third", Ref: "c", Outcome: "mixed"},
+		},
+	}
+	got := pkt.Assemble("", 0)
+	if len(got.Lessons) != 2 || got.Lessons[0].Ref != "a" || got.Lessons[1].Ref != "b" {
+		t.Errorf("Lessons = %+v, want the two that fit the lessons budget", got.Lessons)
+	}
+	if got := pkt.Assemble("", 20); len(got.Lessons) != 0 {
+		t.Errorf("Lessons = %+v, want none when the section is dropped", got.Lessons)
+	}
+}
+
+func TestRunBuildPhase_RecordsOmittedContext(t *testing.T) {
+	root := writeHydrateProjectRoot(t)
+	writeContextBudget(t, root, "context:\n  default: 60\n")
+	pkt := &ContextPacket{
+		Workstream:   "# 00-022-01: Test hydrate fixture",
+		QualityGates: "go build ./...",
+		DriftStatus:  strings.Repeat(" M internal/file.go\n", 100),
+	}
+	if err := WriteContextPacket(filepath.Join(root, contextPacketPath), pkt); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := RunBuildPhase(context.Background(), root, "F022", "00-022-01", &fakeLLMInvoker{}); err != nil {
+		t.Fatalf("RunBuildPhase: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, ".sdp", "prompt-provenance.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var prov struct {
+		Omitted []OmittedContext `json:"omitted_context"`
+	}
+	if err := json.Unmarshal(data, &prov); err != nil {
+		t.Fatal(err)
+	}
+	if len(prov.Omitted) != 1 || prov.Omitted[0].Section != "drift_status" || prov.Omitted[0].Tokens == 0 {
+		t.Errorf("omitted_context = %+v", prov.Omitted)
+	}
+}
+
+func writeContextBudget(t *testing.T, root, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(root, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".sdp", "budgets.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,172 @@
+package orchestrate
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/sdputil"
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+)
+
+const (
+	// priorLessonsLimit is the top-k lessons requested per workstream
+	priorLessonsLimit = 5
+	// priorLessonsTokenBudget caps the "Prior Lessons" prompt section
+	priorLessonsTokenBudget = 600
+	// omittedNoteTokens is kept free for the "N more omitted" line
+	omittedNoteTokens = 16
+)
+
+// priorLessonsTimeout bounds the sdp memory lessons call so a hung memory
+// index cannot stall hydration. A variable so tests can shorten it.
+var priorLessonsTimeout = cliExecTimeout
+
+// PriorLesson is a recorded failure or mixed outcome relevant to the
+// workstream. It mirrors memory.Lesson in sdp-plugin, as printed by
+// "sdp memory lessons --json".
+type PriorLesson struct {
+	Source  string  `json:"source"`
+	Ref     string  `json:"ref"`
+	Title   string  `json:"title"`
+	Summary string  `json:"summary"`
+	Outcome string  `json:"outcome"`
+	Score   float64 `json:"score"`
+}
+
+// queryPriorLessons asks the sdp CLI for lessons matching the goal and
+// scope files (hybrid memory search plus failed decisions). Lessons are
+// advisory: if sdp is not installed it returns nil without error. The call
+// is killed after priorLessonsTimeout.
+func queryPriorLessons(projectRoot, goal string, scopeFiles []string) ([]PriorLesson, error) {
+	if strings.TrimSpace(goal) == "" && len(scopeFiles) == 0 {
+		return nil, nil
+	}
+	sdpPath, err := exec.LookPath("sdp")
+	if err != nil {
+		return nil, nil
+	}
+	args := []string{"memory", "lessons", "--json", "--limit", strconv.Itoa(priorLessonsLimit)}
+	if goal != "" {
+		args = append(args, "--goal", goal)
+	}
+	for _, f := range scopeFiles {
+		args = append(args, "--file", f)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), priorLessonsTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, sdpPath, args...)
+	cmd.Dir = projectRoot
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("sdp memory lessons: timed out after %s", priorLessonsTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sdp memory lessons: %w", err)
+	}
+	var lessons []PriorLesson
+	if err := json.NewDecoder(io.LimitReader(bytes.NewReader(out), sdputil.MaxJSONDecodeBytes)).Decode(&lessons); err != nil {
+		return nil, fmt.Errorf("parse prior lessons: %w", err)
+	}
+	return lessons, nil
+}
+
+// FormatPriorLessons renders lessons within the token budget, most
+// relevant first. Summaries are cut to fit; lessons that do not fit at
+// all are counted in a trailing note.
+func FormatPriorLessons(lessons []PriorLesson, tokenBudget int) string {
+	text, _ := renderPriorLessons(lessons, tokenBudget)
+	return text
+}
+
+// renderPriorLessons is FormatPriorLessons that also returns the entry
+// written for each lesson, in order; lessons omitted for the budget have
+// none.
+func renderPriorLessons(lessons []PriorLesson, tokenBudget int) (string, []string) {
+	if len(lessons) == 0 {
+		return "", nil
+	}
+	var entries []string
+	var b strings.Builder
+	b.WriteString("### Prior Lessons (similar past failures — avoid repeating them)\n\n")
+	used := budget.EstimateTokens(b.String())
+	for i, l := range lessons {
+		entry := fmt.Sprintf("- [%s] %s (%s)\n", l.Outcome, l.Title, l.Ref)
+		if l.Summary != "" && l.Summary != l.Title {
+			entry += "  " + strings.ReplaceAll(strings.TrimSpace(l.Summary), "\n", " ") + "\n"
+		}
+		remaining := (tokenBudget - used - omittedNoteTokens) * 4
+		if len(entry) > remaining {
+			if remaining < 80 {
+				fmt.Fprintf(&b, "- (%d more omitted to fit the context budget)\n", len(lessons)-i)
+				break
+			}
+			entry = strings.ToValidUTF8(entry[:remaining-4], "") + "...\n"
+		}
+		b.WriteString(entry)
+		entries = append(entries, entry)
+		used += budget.EstimateTokens(entry)
+	}
+	b.WriteString("\n")
+	return b.String(), entries
+}
+
+// renderedPriorLessons returns the lessons whose entry appears in full in
+// the assembled prompt text.
+func renderedPriorLessons(lessons []PriorLesson, text string) []PriorLesson {
+	_, entries := renderPriorLessons(lessons, priorLessonsTokenBudget)
+	var out []PriorLesson
+	for i, entry := range entries {
+		if strings.Contains(text, entry) {
+			out = append(out, lessons[i])
+		}
+	}
+	return out
+}
+
+// PriorLessonSources records lessons as prompt provenance; pass the
+// lessons of AssembledContext, which entered the prompt. Hash covers the
+// lesson title and summary.
+func PriorLessonSources(lessons []PriorLesson) []ContextSource {
+	var out []ContextSource
+	for _, l := range lessons {
+		h := sha256.Sum256([]byte(l.Title + "\n" + l.Summary))
+		out = append(out, ContextSource{Type: "prior_lesson", Path: l.Ref, Hash: hex.EncodeToString(h[:])})
+	}
+	return out
+}
+
+// parseGoal returns the text of the "## Goal" section, falling back to
+// the first "# " heading.
+func parseGoal(content string) string {
+	var goal []string
+	inGoal := false
+	title := ""
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "## Goal":
+			inGoal = true
+		case strings.HasPrefix(trimmed, "#"):
+			if title == "" && strings.HasPrefix(trimmed, "# ") {
+				title = strings.TrimPrefix(trimmed, "# ")
+			}
+			inGoal = false
+		case inGoal && trimmed != "":
+			goal = append(goal, trimmed)
+		}
+	}
+	if len(goal) > 0 {
+		return strings.Join(goal, " ")
+	}
+	return title
+}
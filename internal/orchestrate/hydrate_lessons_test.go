@@ -0,0 +1,130 @@
+package orchestrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// installFakeSDP puts an sdp script on PATH that records its arguments
+// and prints output.
+func installFakeSDP(t *testing.T, output string) (argsFile string) {
+	t.Helper()
+	bin := t.TempDir()
+	argsFile = filepath.Join(bin, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat <<'JSON'\n" + output + "\nJSON\n"
+	if err := os.WriteFile(filepath.Join(bin, "sdp"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return argsFile
+}
+
+const fakeLessonsJSON = `[
+  {"source": "memory", "ref": ".sdp/log/events.jsonl#ev-1", "title": "lesson: 00-021-01", "summary": "failed: hydrate wrote packet before validation", "outcome": "failed", "score": 1.2},
+  {"source": "decision", "ref": "docs/decisions/decisions.jsonl#4", "title": "Cache context packets?", "summary": "Cache per feature", "outcome": "mixed", "score": 0.5}
+]`
+
+func TestHydrate_PriorLessons(t *testing.T) {
+	argsFile := installFakeSDP(t, fakeLessonsJSON)
+	root := writeHydrateProjectRoot(t)
+
+	pkt, err := Hydrate(root, "F022", "00-022-01", nil)
+	if err != nil {
+		t.Fatalf("Hydrate: %v", err)
+	}
+	if len(pkt.PriorLessons) != 2 || pkt.PriorLessons[0].Outcome != "failed" {
+		t.Fatalf("PriorLessons = %+v", pkt.PriorLessons)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"memory lessons --json --limit 5", "--goal 00-022-01: Test hydrate fixture", "--file internal/orchestrate/hydrate.go"} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("sdp args %q missing %q", args, want)
+		}
+	}
+
+	out := pkt.FormatForPrompt()
+	if !strings.Contains(out, "### Prior Lessons") || !strings.Contains(out, "- [failed] lesson: 00-021-01 (.sdp/log/events.jsonl#ev-1)") {
+		t.Errorf("prompt missing prior lessons:\n%s", out)
+	}
+
+	sources := PriorLessonSources(pkt.PriorLessons)
+	if len(sources) != 2 || sources[1].Type != "prior_lesson" || sources[1].Path != "docs/decisions/decisions.jsonl#4" || len(sources[1].Hash) != 64 {
+		t.Errorf("sources = %+v", sources)
+	}
+}
+
+func TestHydrate_PriorLessonsFailureIsAdvisory(t *testing.T) {
+	installFakeSDP(t, "not json")
+	root := writeHydrateProjectRoot(t)
+	pkt, err := Hydrate(root, "F022", "00-022-01", nil)
+	if err != nil {
+		t.Fatalf("Hydrate should not fail on lesson lookup errors: %v", err)
+	}
+	if pkt.PriorLessons != nil {
+		t.Errorf("PriorLessons = %+v", pkt.PriorLessons)
+	}
+}
+
+func TestQueryPriorLessons_Timeout(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "sdp"), []byte("#!/bin/sh\nexec sleep 30\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	old := priorLessonsTimeout
+	priorLessonsTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { priorLessonsTimeout = old })
+
+	start := time.Now()
+	_, err := queryPriorLessons(t.TempDir(), "retry webhooks", nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("err = %v, want timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("query took %s, want it killed at the timeout", elapsed)
+	}
+}
+
+func TestFormatPriorLessons_Budget(t *testing.T) {
+	if got := FormatPriorLessons(nil, 100); got != "" {
+		t.Errorf("empty lessons should render nothing, got %q", got)
+	}
+	long := strings.Repeat("retry storm ", 100)
+	lessons := []PriorLesson{
+		{Title: "first", Ref: "a", Outcome: "failed", Summary: long},
+		{Title: "second", Ref: "b", Outcome: "failed", Summary: long},
+		{Title: "third", Ref: "c", Outcome: "mixed"},
+	}
+	out := FormatPriorLessons(lessons, 200)
+	if tokens := (len(out) + 3) / 4; tokens > 210 {
+		t.Errorf("section uses %d tokens, budget 200:\n%s", tokens, out)
+	}
+	if !strings.Contains(out, "[failed] first") || !strings.Contains(out, "...") {
+		t.Errorf("first lesson should be truncated, not dropped:\n%s", out)
+	}
+	if !strings.Contains(out, "2 more omitted") {
+		t.Errorf("expected omission note:\n%s", out)
+	}
+}
+
+func TestParseGoal(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"# 00-001-01: Title\n\n## Goal\nShip it\nsafely.\n\n## Scope Files\n- `a.go`\n", "Ship it safely."},
+		{"---\nws_id: x\n---\n# 00-001-01: Title only\n", "00-001-01: Title only"},
+		{"no headings", ""},
+	}
+	for _, tt := range tests {
+		if got := parseGoal(tt.content); got != tt.want {
+			t.Errorf("parseGoal(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
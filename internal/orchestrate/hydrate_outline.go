@@ -0,0 +1,78 @@
+package orchestrate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/prompt"
+)
+
+// GoOutline returns the declarations of a Go source file without function
+// bodies or comments: package clause, imports, types, constants,
+// variables and function signatures.
+func GoOutline(filename string, src []byte) (string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return "", fmt.Errorf("parse %s: %w", filename, err)
+	}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			fn.Body = nil
+		}
+	}
+	var b bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+	if err := cfg.Fprint(&b, fset, f); err != nil {
+		return "", fmt.Errorf("print outline %s: %w", filename, err)
+	}
+	return b.String(), nil
+}
+
+// scopeFileVariants renders the scope files section from fullest to most
+// compact: file contents, go/ast outlines of Go files, then paths only.
+// Without a project root only the path list is available.
+func scopeFileVariants(projectRoot string, files []string) []ContextVariant {
+	list := prompt.ScopeFilesSection(files)
+	paths := ContextVariant{Label: "paths only", Text: list}
+	if projectRoot == "" || len(files) == 0 {
+		return []ContextVariant{paths}
+	}
+
+	var full, outline strings.Builder
+	full.WriteString(list)
+	outline.WriteString(list)
+	for _, f := range files {
+		src, err := readScopeFile(projectRoot, f)
+		if err != nil {
+			continue // new or unreadable files stay in the path list
+		}
+		fmt.Fprintf(&full, "#### %s\n\n```\n%s\n```\n\n", f, strings.TrimRight(string(src), "\n"))
+		if filepath.Ext(f) != ".go" {
+			continue
+		}
+		if o, err := GoOutline(f, src); err == nil {
+			fmt.Fprintf(&outline, "#### %s (outline)\n\n```go\n%s\n```\n\n", f, strings.TrimRight(o, "\n"))
+		}
+	}
+	variants := []ContextVariant{{Label: "full", Text: full.String()}}
+	if outline.Len() > len(list) {
+		variants = append(variants, ContextVariant{Label: "go/ast outlines", Text: outline.String()})
+	}
+	return append(variants, paths)
+}
+
+// readScopeFile reads a scope file, refusing paths outside the project.
+func readScopeFile(projectRoot, rel string) ([]byte, error) {
+	if !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("scope file %s is outside the project", rel)
+	}
+	return os.ReadFile(filepath.Join(projectRoot, rel))
+}
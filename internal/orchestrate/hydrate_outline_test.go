@@ -0,0 +1,80 @@
+package orchestrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const outlineSrc = `package demo
+
+import "fmt"
+
+// Limit caps retries.
+const Limit = 3
+
+// Client talks to the API.
+type Client struct {
+	Name string
+}
+
+// Do sends a request.
+func (c *Client) Do(path string) (string, error) {
+	secret := "body detail"
+	return fmt.Sprint(secret, path), nil
+}
+`
+
+func TestGoOutline(t *testing.T) {
+	got, err := GoOutline("demo.go", []byte(outlineSrc))
+	if err != nil {
+		t.Fatalf("GoOutline: %v", err)
+	}
+	for _, want := range []string{"package demo", "const Limit = 3", "type Client struct", "func (c *Client) Do(path string) (string, error)\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("outline missing %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"body detail", "// Do sends"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("outline should not contain %q:\n%s", unwanted, got)
+		}
+	}
+	if _, err := GoOutline("bad.go", []byte("package")); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestScopeFileVariants(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "demo.go"), []byte(outlineSrc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("# Demo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{"demo.go", "README.md", "new.go", "../outside.go"}
+
+	variants := scopeFileVariants(root, files)
+	if len(variants) != 3 {
+		t.Fatalf("got %d variants, want full, outlines, paths", len(variants))
+	}
+	full, outline, paths := variants[0].Text, variants[1].Text, variants[2].Text
+	if !strings.Contains(full, "body detail") || !strings.Contains(full, "#### README.md") {
+		t.Errorf("full variant missing file contents:\n%s", full)
+	}
+	if strings.Contains(outline, "body detail") || !strings.Contains(outline, "#### demo.go (outline)") || strings.Contains(outline, "#### README.md") {
+		t.Errorf("outline variant:\n%s", outline)
+	}
+	if strings.Contains(paths, "####") || !strings.Contains(paths, "- new.go") {
+		t.Errorf("paths variant:\n%s", paths)
+	}
+	if strings.Contains(full, "#### ../outside.go") || strings.Contains(full, "#### new.go") {
+		t.Errorf("missing or outside files must stay in the path list:\n%s", full)
+	}
+
+	if got := scopeFileVariants("", files); len(got) != 1 || got[0].Label != "paths only" {
+		t.Errorf("without project root: %+v", got)
+	}
+}
@@ -3,12 +3,15 @@ package orchestrate
 import (
 	"regexp"
 	"strings"
+
+	"github.com/fall-out-bug/sdp/src/sdp/model"
 )
 
 var (
 	reScopeFile  = regexp.MustCompile(`^-\s+` + "`" + `([^` + "`" + `]+)` + "`")
 	reAcceptance = regexp.MustCompile(`^-\s+\[[ x]\]\s+(.+)`)
 	reDependsOn  = regexp.MustCompile(`(?m)^depends_on:\s*\[(.*?)\]`)
+	reSize       = regexp.MustCompile(`(?m)^size:\s*["']?([A-Za-z]+)`)
 )
 
 func parseWorkstreamSections(content string) (acceptance []string, scopeFiles []string) {
@@ -57,6 +60,22 @@ func parseDependsOn(content string) []string {
 	return deps
 }
 
+// parseSize returns the frontmatter size (S, M, L) or "" when absent.
+func parseSize(content string) string {
+	if m := reSize.FindStringSubmatch(content); len(m) > 1 {
+		return strings.ToUpper(m[1])
+	}
+	return ""
+}
+
+// largerSize returns whichever size routes to the higher complexity.
+func largerSize(a, b string) string {
+	if a == "" || (b != "" && model.ComplexityForSize(b) > model.ComplexityForSize(a)) {
+		return b
+	}
+	return a
+}
+
 func parseQualityGates(agentsContent string) string {
 	_, rest, ok := strings.Cut(agentsContent, "## Quality Gates")
 	if !ok {
@@ -1,10 +1,12 @@
 package orchestrate
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 func gitLSFiles(projectRoot string) (map[string]bool, error) {
@@ -43,6 +45,27 @@ func bdShow(projectRoot, beadsID string) (string, error) {
 	return string(out), nil
 }
 
+// queueShow reads the issue linked to a workstream through "sdp queue
+// show", which uses the project's queue backend (built-in file queue or
+// Beads). ok is false when sdp is not installed, no issue is linked or
+// the call does not finish within cliExecTimeout.
+func queueShow(projectRoot, wsID string) (string, bool) {
+	sdpPath, err := exec.LookPath("sdp")
+	if err != nil {
+		return "", false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cliExecTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, sdpPath, "queue", "show", wsID)
+	cmd.Dir = projectRoot
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	return string(out), true
+}
+
 func wsIDToBeadsID(projectRoot, wsID string) string {
 	mappingPath := filepath.Join(projectRoot, ".beads-sdp-mapping.jsonl")
 	data, err := os.ReadFile(mappingPath)
@@ -119,6 +119,20 @@ func TestHydrate_RecordsDependencyLookupError(t *testing.T) {
 	}
 }
 
+func TestHydrate_ReadsDependencyFromQueue(t *testing.T) {
+	installFakeSDP(t, "sdp-3fa9c1  closed  Add retry policy")
+	root := t.TempDir()
+	writeHydrateFixture(t, root, "00-022-01", true)
+
+	pkt, err := Hydrate(root, "F022", "00-022-01", &Checkpoint{FeatureID: "F022", Phase: PhaseBuild})
+	if err != nil {
+		t.Fatalf("Hydrate: %v", err)
+	}
+	if msg := pkt.Dependencies["00-016-04"]; !strings.Contains(msg, "Add retry policy") {
+		t.Fatalf("expected dependency from sdp queue, got %q", msg)
+	}
+}
+
 func TestParseWorkstreamSections(t *testing.T) {
 	content := "---\nws_id: 00-022-01\ndepends_on: [\"00-016-04\"]\n---\n\n" +
 		"## Scope Files\n\n" +
@@ -139,6 +153,21 @@ func TestParseWorkstreamSections(t *testing.T) {
 	}
 }
 
+func TestParseSize(t *testing.T) {
+	if got := parseSize("---\nws_id: 00-028-01\nsize: m\n---\n"); got != "M" {
+		t.Errorf("parseSize = %q, want M", got)
+	}
+	if got := parseSize("---\nws_id: 00-028-01\n---\n"); got != "" {
+		t.Errorf("parseSize without size = %q, want empty", got)
+	}
+	if got := largerSize("S", "L"); got != "L" {
+		t.Errorf("largerSize(S, L) = %q", got)
+	}
+	if got := largerSize("M", ""); got != "M" {
+		t.Errorf("largerSize(M, \"\") = %q", got)
+	}
+}
+
 func TestParseQualityGates(t *testing.T) {
 	content := "# Agents\n\n## Quality Gates\n\nBefore pushing:\n\n```bash\ngo build ./...\n```\n\n## Other\n"
 	got := parseQualityGates(content)
@@ -10,15 +10,20 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/fall-out-bug/sdp/src/sdp/model"
 )
 
-// buildPromptWithContext injects the pre-hydrated context packet into the prompt.
-func buildPromptWithContext(dir, basePrompt string) string {
+// buildPromptWithContext injects the pre-hydrated context packet into the prompt within the
+// context budget of the model routed to phase, returning the assembled packet (what the agent
+// will not see in full and the prior lessons it will).
+func buildPromptWithContext(dir, basePrompt, phase string) (string, AssembledContext) {
 	pkt, err := LoadContextPacket(dir)
 	if err != nil || pkt == nil {
-		return basePrompt
+		return basePrompt, AssembledContext{}
 	}
-	return basePrompt + pkt.FormatForPrompt()
+	assembled := pkt.Assemble(dir, phaseContextBudget(dir, phase, pkt.Size))
+	return basePrompt + assembled.Text, assembled
 }
 
 // ComputePromptHash returns SHA-256 hex of the rendered prompt (captures exactly what was sent to the LLM).
@@ -77,10 +82,11 @@ func BuildContextSources(projectRoot, featureID, wsID string, scopeFiles []strin
 	return out
 }
 
-// WritePromptProvenance writes prompt_hash and context_sources to .sdp/prompt-provenance.json.
+// WritePromptProvenance writes prompt_hash, context_sources and omitted_context (sections the
+// token budget condensed, truncated or dropped) to .sdp/prompt-provenance.json.
 // Downstream (evidence builder, post-build hook) can merge into the evidence envelope.
 // Uses tmp+rename for atomic write.
-func WritePromptProvenance(projectRoot string, promptHash string, sources []ContextSource) error {
+func WritePromptProvenance(projectRoot string, promptHash string, sources []ContextSource, omitted []OmittedContext) error {
 	sdpDir := filepath.Join(projectRoot, ".sdp")
 	if err := os.MkdirAll(sdpDir, 0o755); err != nil {
 		return err
@@ -88,6 +94,9 @@ func WritePromptProvenance(projectRoot string, promptHash string, sources []Cont
 	path := filepath.Join(sdpDir, "prompt-provenance.json")
 	tmpPath := path + ".tmp"
 	body := map[string]any{"prompt_hash": promptHash, "context_sources": sources}
+	if len(omitted) > 0 {
+		body["omitted_context"] = omitted
+	}
 	data, err := json.MarshalIndent(body, "", "  ")
 	if err != nil {
 		return err
@@ -105,10 +114,23 @@ func WritePromptProvenance(projectRoot string, promptHash string, sources []Cont
 // InvokeOpenCode runs `opencode run --agent orchestrator` with the given prompt.
 // Returns the combined stdout+stderr and exit code.
 func InvokeOpenCode(ctx context.Context, dir, agent, prompt string) (string, int, error) {
+	return InvokeOpenCodeModel(ctx, dir, agent, "", prompt)
+}
+
+// InvokeOpenCodeModel is InvokeOpenCode pinned to a model. An empty modelID uses
+// the runtime default; IDs without a provider are assumed to be Anthropic models.
+func InvokeOpenCodeModel(ctx context.Context, dir, agent, modelID, prompt string) (string, int, error) {
 	if agent == "" {
 		agent = "orchestrator"
 	}
-	cmd := exec.CommandContext(ctx, "opencode", "run", "--agent", agent)
+	args := []string{"run", "--agent", agent}
+	if modelID != "" {
+		if !strings.Contains(modelID, "/") {
+			modelID = "anthropic/" + modelID
+		}
+		args = append(args, "--model", modelID)
+	}
+	cmd := exec.CommandContext(ctx, "opencode", args...)
 	cmd.Dir = dir
 	cmd.Stdin = strings.NewReader(prompt)
 	out, err := cmd.CombinedOutput()
@@ -126,14 +148,15 @@ func RunBuildPhase(ctx context.Context, projectRoot, featureID, wsID string, inv
 	if invoker == nil {
 		invoker = DefaultLLMInvoker
 	}
-	prompt := buildPromptWithContext(projectRoot, fmt.Sprintf("Execute @build %s. Output only code and commit message. After commit, output the commit hash.", wsID))
+	prompt, assembled := buildPromptWithContext(projectRoot, fmt.Sprintf("Execute @build %s. Output only code and commit message. After commit, output the commit hash.", wsID), model.PhaseBuild)
 	promptHash := ComputePromptHash(prompt)
 	var scopeFiles []string
 	if pkt, err := LoadContextPacket(projectRoot); err == nil && pkt != nil {
 		scopeFiles = pkt.ScopeFiles
 	}
 	sources := BuildContextSources(projectRoot, featureID, wsID, scopeFiles)
-	_ = WritePromptProvenance(projectRoot, promptHash, sources)
+	sources = append(sources, PriorLessonSources(assembled.Lessons)...)
+	_ = WritePromptProvenance(projectRoot, promptHash, sources, assembled.Omitted)
 	out, code, err := invoker.Invoke(ctx, projectRoot, "implementer", prompt)
 	if err != nil {
 		return "", err
@@ -156,7 +179,7 @@ func RunReviewPhase(ctx context.Context, dir, featureID string, invoker LLMInvok
 	if invoker == nil {
 		invoker = DefaultLLMInvoker
 	}
-	prompt := buildPromptWithContext(dir, fmt.Sprintf("Execute @review %s. Fix P0/P1 findings. Output APPROVED when done.", featureID))
+	prompt, _ := buildPromptWithContext(dir, fmt.Sprintf("Execute @review %s. Fix P0/P1 findings. Output APPROVED when done.", featureID), model.PhaseReview)
 	out, code, err := invoker.Invoke(ctx, dir, "reviewer", prompt)
 	if err != nil {
 		return false, err
@@ -122,7 +122,7 @@ func TestWritePromptProvenance(t *testing.T) {
 	sources := []ContextSource{
 		{Type: "workstream_spec", Path: "docs/ws.md", Hash: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
 	}
-	if err := WritePromptProvenance(dir, "abc123", sources); err != nil {
+	if err := WritePromptProvenance(dir, "abc123", sources, nil); err != nil {
 		t.Fatalf("WritePromptProvenance: %v", err)
 	}
 	path := filepath.Join(dir, ".sdp", "prompt-provenance.json")
@@ -6,6 +6,12 @@ type LLMInvoker interface {
 	Invoke(ctx context.Context, dir, agent, prompt string) (output string, exitCode int, err error)
 }
 
+// ModelInvoker is implemented by invokers that can pin the model for a call.
+// MeteredInvoker uses it to apply the routed profile.
+type ModelInvoker interface {
+	InvokeModel(ctx context.Context, dir, agent, modelID, prompt string) (output string, exitCode int, err error)
+}
+
 var DefaultLLMInvoker LLMInvoker = openCodeInvoker{}
 
 type openCodeInvoker struct{}
@@ -13,3 +19,7 @@ type openCodeInvoker struct{}
 func (openCodeInvoker) Invoke(ctx context.Context, dir, agent, prompt string) (string, int, error) {
 	return InvokeOpenCode(ctx, dir, agent, prompt)
 }
+
+func (openCodeInvoker) InvokeModel(ctx context.Context, dir, agent, modelID, prompt string) (string, int, error) {
+	return InvokeOpenCodeModel(ctx, dir, agent, modelID, prompt)
+}
@@ -2,12 +2,17 @@ package orchestrate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+
+	"github.com/fall-out-bug/sdp/src/sdp/budget"
+	"github.com/fall-out-bug/sdp/src/sdp/model"
+	"github.com/fall-out-bug/sdp/src/sdp/state"
 )
 
 func fatal(format string, args ...any) {
@@ -15,11 +20,26 @@ func fatal(format string, args ...any) {
 	os.Exit(1)
 }
 
+// haltOnBudget saves the checkpoint and exits with ExitBudgetExhausted so the
+// run can be resumed after the budget is raised.
+func haltOnBudget(cpPath string, cp *Checkpoint, err error) {
+	_ = SaveCheckpoint(cpPath, cp) // best-effort so resume continues from this phase
+	fmt.Fprintf(os.Stderr, "halted: %v\nRaise the limit in %s (or reset the ledger) and re-run to resume.\n", err, budget.ConfigPath)
+	os.Exit(ExitBudgetExhausted)
+}
+
 // RunOpenCodeLoop drives the full workflow using opencode as the inner loop.
 func RunOpenCodeLoop(projectRoot, featureID, cpPath, runsPath string, cp *Checkpoint, workstreams []string) {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	gateway, err := NewGateway(projectRoot)
+	if err != nil {
+		fatal("error: gateway: %v", err)
+	}
+	router := model.NewRouter()
+	breakers := state.NewStore(projectRoot)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -40,13 +60,18 @@ func RunOpenCodeLoop(projectRoot, featureID, cpPath, runsPath string, cp *Checkp
 			if err := RunHooks(ctx, projectRoot, "build", "pre", hookEnv, func(msg string) { slog.Info("hook", "msg", msg) }); err != nil {
 				fatal("error: pre-build hook: %v", err)
 			}
-			if _, err := Hydrate(projectRoot, featureID, action.WSID, cp); err != nil {
+			pkt, err := Hydrate(projectRoot, featureID, action.WSID, cp)
+			if err != nil {
 				slog.Error("hydration failed", "error", err, "ws", action.WSID)
 				os.Exit(1)
 			}
 			phaseCtx, cancel := context.WithTimeout(ctx, buildPhaseTimeout)
-			commit, err := RunBuildPhase(phaseCtx, projectRoot, action.Feature, action.WSID, nil)
+			invoker := &RoutedInvoker{Gateway: gateway, Router: router, ProjectRoot: projectRoot, State: breakers, Phase: model.PhaseBuild, Size: pkt.Size, FeatureID: featureID, WSID: action.WSID}
+			commit, err := RunBuildPhase(phaseCtx, projectRoot, action.Feature, action.WSID, invoker)
 			cancel()
+			if errors.Is(err, budget.ErrBudgetExhausted) {
+				haltOnBudget(cpPath, cp, err)
+			}
 			if err != nil {
 				slog.Error("opencode build failed", "error", err, "ws", action.WSID)
 				os.Exit(1)
@@ -74,13 +99,18 @@ func RunOpenCodeLoop(projectRoot, featureID, cpPath, runsPath string, cp *Checkp
 			if err := RunHooks(ctx, projectRoot, "review", "pre", hookEnv, func(msg string) { slog.Info("hook", "msg", msg) }); err != nil {
 				fatal("error: pre-review hook: %v", err)
 			}
-			if _, err := HydrateForReview(projectRoot, action.Feature, cp, workstreams); err != nil {
+			pkt, err := HydrateForReview(projectRoot, action.Feature, cp, workstreams)
+			if err != nil {
 				slog.Error("hydration failed", "error", err, "feature", action.Feature)
 				os.Exit(1)
 			}
 			phaseCtx, cancel := context.WithTimeout(ctx, reviewPhaseTimeout)
-			approved, err := RunReviewPhase(phaseCtx, projectRoot, action.Feature, nil)
+			invoker := &RoutedInvoker{Gateway: gateway, Router: router, ProjectRoot: projectRoot, State: breakers, Phase: model.PhaseReview, Size: pkt.Size, FeatureID: featureID}
+			approved, err := RunReviewPhase(phaseCtx, projectRoot, action.Feature, invoker)
 			cancel()
+			if errors.Is(err, budget.ErrBudgetExhausted) {
+				haltOnBudget(cpPath, cp, err)
+			}
 			if err != nil || !approved {
 				slog.Error("opencode review failed", "error", err, "approved", approved, "feature", action.Feature)
 				os.Exit(1)
@@ -100,6 +130,9 @@ func RunOpenCodeLoop(projectRoot, featureID, cpPath, runsPath string, cp *Checkp
 			}
 		case "ci-loop":
 			if err := AdvanceCIPhase(ctx, projectRoot, featureID, cpPath, runsPath, cp); err != nil {
+				if errors.Is(err, budget.ErrBudgetExhausted) {
+					haltOnBudget(cpPath, cp, err)
+				}
 				fatal("error: %v", err)
 			}
 		case "done":
@@ -0,0 +1,120 @@
+package orchestrate
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/fall-out-bug/sdp/src/sdp/hooks"
+	"github.com/fall-out-bug/sdp/src/sdp/model"
+	"github.com/fall-out-bug/sdp/src/sdp/runtime"
+	"github.com/fall-out-bug/sdp/src/sdp/state"
+)
+
+// RoutedInvoker selects the model for each call from the router by phase and
+// workstream size, and falls back along the phase's chain when a call is rate
+// limited or its circuit is open. Every attempt is metered through the gateway.
+// Fallback statistics are persisted to .sdp/model-stats.json for `sdp metrics report`.
+type RoutedInvoker struct {
+	Inner       LLMInvoker
+	Gateway     *hooks.HookRegistry
+	Router      *model.Router
+	ProjectRoot string
+	Phase       string // model.PhaseBuild, model.PhaseReview, model.PhaseCIFix
+	Size        string // workstream size (S, M, L)
+	FeatureID   string
+	WSID        string
+	// State, when set, keeps a circuit breaker per model in .sdp/state/ so a
+	// rate-limited model is skipped by later calls and other processes.
+	State *state.Store
+}
+
+// Invoke implements LLMInvoker.
+func (r *RoutedInvoker) Invoke(ctx context.Context, dir, agent, prompt string) (string, int, error) {
+	router := r.Router
+	if router == nil {
+		router = model.NewRouter()
+	}
+	chain := model.ChainForPhase(router, r.Phase, r.Size)
+	chain.SetFallbackOn(shouldFallback)
+
+	var out string
+	var code int
+	err := chain.Execute(ctx, func(profileName string) error {
+		profile, _ := router.GetProfile(profileName)
+		metered := &MeteredInvoker{Inner: r.Inner, Gateway: r.Gateway, FeatureID: r.FeatureID, WSID: r.WSID, Profile: profile}
+		if r.State == nil {
+			var err error
+			out, code, err = metered.Invoke(ctx, dir, agent, prompt)
+			if isRateLimited(err) {
+				slog.Warn("model rate limited", "profile", profileName, "model", profile.ModelID, "phase", r.Phase)
+			}
+			return err
+		}
+
+		// Only provider failures count against the model's circuit; other
+		// errors (e.g. budget exhaustion) are passed through untouched.
+		var callErr error
+		err := runtime.NewCircuitBreaker(runtime.DefaultCircuitConfig).Persist(r.State, CircuitTarget(profile.ModelID)).Execute(ctx, func() error {
+			out, code, callErr = metered.Invoke(ctx, dir, agent, prompt)
+			if isRateLimited(callErr) {
+				slog.Warn("model rate limited", "profile", profileName, "model", profile.ModelID, "phase", r.Phase)
+				return callErr
+			}
+			return nil
+		})
+		if errors.Is(err, runtime.ErrCircuitOpen) {
+			slog.Warn("model circuit open, skipping", "profile", profileName, "model", profile.ModelID, "phase", r.Phase)
+			return err
+		}
+		return callErr
+	})
+
+	root := r.ProjectRoot
+	if root == "" {
+		root = dir
+	}
+	if statsErr := model.NewStatsStore(root).Add(chain.GetStats()); statsErr != nil {
+		slog.Warn("persist model stats", "error", statsErr)
+	}
+	if r.State != nil {
+		r.trackDegraded(err)
+	}
+	return out, code, err
+}
+
+// trackDegraded enters the persisted degraded mode when every model in the
+// phase's chain was rate limited or circuit-open, and leaves it once a call
+// for the phase succeeds again. `sdp health` reports it.
+func (r *RoutedInvoker) trackDegraded(err error) {
+	dm := runtime.NewDegradedMode().Persist(r.State)
+	reason := DegradedReason(r.Phase)
+	switch {
+	case errors.Is(err, model.ErrAllModelsFailed):
+		slog.Warn("entering degraded mode", "reason", reason)
+		dm.Enter(reason)
+	case err == nil && dm.IsActive() && dm.Reason() == reason:
+		dm.Exit()
+	}
+}
+
+// DegradedReason is the degraded-mode reason recorded when no model is
+// available for phase.
+func DegradedReason(phase string) string {
+	return "all " + phase + " models unavailable"
+}
+
+// CircuitTarget names the .sdp/state circuit for a model.
+func CircuitTarget(modelID string) string {
+	return "model:" + modelID
+}
+
+// isRateLimited classifies an invocation error via the gateway event rules.
+func isRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited) || hooks.GatewayEvent{Error: err}.IsRateLimited()
+}
+
+// shouldFallback moves to the next model on rate limits and open circuits.
+func shouldFallback(err error) bool {
+	return isRateLimited(err) || errors.Is(err, runtime.ErrCircuitOpen)
+}
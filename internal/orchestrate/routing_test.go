@@ -0,0 +1,217 @@
+package orchestrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/ciloop"
+	"github.com/fall-out-bug/sdp/src/sdp/model"
+	"github.com/fall-out-bug/sdp/src/sdp/runtime"
+	"github.com/fall-out-bug/sdp/src/sdp/state"
+)
+
+// modelLLMInvoker returns a scripted result per pinned model.
+type modelLLMInvoker struct {
+	results map[string]fakeResult
+	calls   []string
+}
+
+type fakeResult struct {
+	output   string
+	exitCode int
+}
+
+func (f *modelLLMInvoker) Invoke(ctx context.Context, dir, agent, prompt string) (string, int, error) {
+	return f.InvokeModel(ctx, dir, agent, "", prompt)
+}
+
+func (f *modelLLMInvoker) InvokeModel(ctx context.Context, dir, agent, modelID, prompt string) (string, int, error) {
+	f.calls = append(f.calls, modelID)
+	r := f.results[modelID]
+	return r.output, r.exitCode, nil
+}
+
+func TestRoutedInvoker_FallsBackOnRateLimit(t *testing.T) {
+	dir := t.TempDir()
+	gateway, err := NewGateway(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &modelLLMInvoker{results: map[string]fakeResult{
+		"claude-opus":   {output: "Error: 429 Too Many Requests", exitCode: 1},
+		"claude-sonnet": {output: "APPROVED"},
+	}}
+	invoker := &RoutedInvoker{Inner: fake, Gateway: gateway, ProjectRoot: dir, Phase: model.PhaseReview, Size: "M", FeatureID: "F028"}
+
+	out, code, err := invoker.Invoke(context.Background(), dir, "reviewer", "review F028")
+	if err != nil || code != 0 || out != "APPROVED" {
+		t.Fatalf("expected fallback success, got out=%q code=%d err=%v", out, code, err)
+	}
+	if len(fake.calls) != 2 || fake.calls[0] != "claude-opus" || fake.calls[1] != "claude-sonnet" {
+		t.Errorf("expected opus then sonnet, got %v", fake.calls)
+	}
+
+	sd, err := model.NewStatsStore(dir).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := sd.Models["quality"]; q.Calls != 1 || q.Fallbacks != 1 {
+		t.Errorf("expected persisted fallback for quality, got %+v", q)
+	}
+	if sd.Fallbacks != 1 || sd.Successes != 1 {
+		t.Errorf("unexpected persisted totals: %+v", sd)
+	}
+}
+
+func TestRoutedInvoker_NoFallbackOnOrdinaryFailure(t *testing.T) {
+	dir := t.TempDir()
+	fake := &modelLLMInvoker{results: map[string]fakeResult{
+		"claude-sonnet": {output: "build failed: undefined: foo", exitCode: 1},
+	}}
+	invoker := &RoutedInvoker{Inner: fake, ProjectRoot: dir, Phase: model.PhaseBuild, Size: "S", WSID: "00-028-01"}
+
+	out, code, err := invoker.Invoke(context.Background(), dir, "implementer", "build")
+	if err != nil || code != 1 || out == "" {
+		t.Fatalf("expected non-zero exit passed through, got out=%q code=%d err=%v", out, code, err)
+	}
+	if len(fake.calls) != 1 {
+		t.Errorf("ordinary failures must not fall back, got %v", fake.calls)
+	}
+}
+
+func TestRoutedInvoker_AllRateLimited(t *testing.T) {
+	dir := t.TempDir()
+	fake := &modelLLMInvoker{results: map[string]fakeResult{
+		"claude-sonnet": {output: "rate limit exceeded", exitCode: 1},
+		"claude-opus":   {output: "rate limit exceeded", exitCode: 1},
+	}}
+	invoker := &RoutedInvoker{Inner: fake, ProjectRoot: dir, Phase: model.PhaseBuild}
+
+	_, _, err := invoker.Invoke(context.Background(), dir, "implementer", "build")
+	if !errors.Is(err, model.ErrAllModelsFailed) || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected exhausted chain with rate-limit cause, got %v", err)
+	}
+	if len(fake.calls) != 3 {
+		t.Errorf("expected every profile in the code chain tried, got %v", fake.calls)
+	}
+}
+
+func TestRoutedInvoker_SkipsModelWithOpenCircuit(t *testing.T) {
+	dir := t.TempDir()
+	store := state.NewStore(dir)
+	if err := store.UpdateCircuit(CircuitTarget("claude-opus"), func(c *state.Circuit) {
+		c.State = state.CircuitOpen
+		c.LastFailure = time.Now()
+	}); err != nil {
+		t.Fatal(err)
+	}
+	fake := &modelLLMInvoker{results: map[string]fakeResult{"claude-sonnet": {output: "APPROVED"}}}
+	invoker := &RoutedInvoker{Inner: fake, ProjectRoot: dir, State: store, Phase: model.PhaseReview}
+
+	out, _, err := invoker.Invoke(context.Background(), dir, "reviewer", "review")
+	if err != nil || out != "APPROVED" {
+		t.Fatalf("expected fallback past open circuit, got out=%q err=%v", out, err)
+	}
+	if len(fake.calls) != 1 || fake.calls[0] != "claude-sonnet" {
+		t.Errorf("open circuit model must not be invoked, got %v", fake.calls)
+	}
+}
+
+func TestRoutedInvoker_RateLimitsTripCircuit(t *testing.T) {
+	dir := t.TempDir()
+	store := state.NewStore(dir)
+	fake := &modelLLMInvoker{results: map[string]fakeResult{
+		"claude-opus":   {output: "429 Too Many Requests", exitCode: 1},
+		"claude-sonnet": {output: "APPROVED"},
+	}}
+	invoker := &RoutedInvoker{Inner: fake, ProjectRoot: dir, State: store, Phase: model.PhaseReview}
+	for range runtime.DefaultCircuitConfig.FailureThreshold {
+		if _, _, err := invoker.Invoke(context.Background(), dir, "reviewer", "review"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := store.Circuit(CircuitTarget("claude-opus"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.State != state.CircuitOpen {
+		t.Errorf("expected opus circuit open after repeated rate limits, got %+v", c)
+	}
+	if sonnet, _ := store.Circuit(CircuitTarget("claude-sonnet")); sonnet.IsOpen() {
+		t.Errorf("healthy model must stay closed, got %+v", sonnet)
+	}
+}
+
+type ciLogs string
+
+func (l ciLogs) FailedLogs(int) (string, error) { return string(l), nil }
+
+type ciCommits int
+
+func (c *ciCommits) Commit(context.Context, string) error { *c++; return nil }
+func (c *ciCommits) Push(context.Context) error           { return nil }
+
+func TestRoutedInvoker_CIFixAgentFallsBack(t *testing.T) {
+	dir := t.TempDir()
+	fake := &modelLLMInvoker{results: map[string]fakeResult{
+		"claude-sonnet": {output: "Error: 429 Too Many Requests", exitCode: 1},
+		"claude-opus":   {output: "fixed"},
+	}}
+	// The default debug chain is fast then balanced; point balanced at another model.
+	router := model.NewRouter()
+	router.AddProfile(model.Profile{Name: "balanced", ModelID: "claude-opus", Speed: "balanced", MaxTokens: 200000, CostPer1K: 0.015})
+	var commits ciCommits
+	fixer := ciloop.NewFixer(ciloop.FixerOptions{
+		PRNumber:       7,
+		FeatureID:      "F028",
+		DiagnosticsDir: t.TempDir(),
+		Committer:      &commits,
+		LogFetcher:     ciLogs("--- FAIL: TestFoo (0.00s)"),
+		Agent:          &RoutedInvoker{Inner: fake, Router: router, ProjectRoot: dir, Phase: model.PhaseCIFix, FeatureID: "F028"},
+	})
+
+	if err := fixer.Fix([]ciloop.CheckResult{{Name: "go-test", State: ciloop.StateFailure}}); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if len(fake.calls) != 2 || fake.calls[0] != "claude-sonnet" || fake.calls[1] != "claude-opus" {
+		t.Errorf("expected the ci-fix chain to fall back from sonnet to opus, got %v", fake.calls)
+	}
+	if commits != 1 {
+		t.Errorf("expected 1 commit, got %d", commits)
+	}
+}
+
+func TestRoutedInvoker_DegradedModeWhenChainExhausted(t *testing.T) {
+	dir := t.TempDir()
+	store := state.NewStore(dir)
+	fake := &modelLLMInvoker{results: map[string]fakeResult{
+		"claude-opus":   {output: "Error: 429 Too Many Requests", exitCode: 1},
+		"claude-sonnet": {output: "Error: 429 Too Many Requests", exitCode: 1},
+	}}
+	invoker := &RoutedInvoker{Inner: fake, ProjectRoot: dir, State: store, Phase: model.PhaseReview, FeatureID: "F029"}
+
+	if _, _, err := invoker.Invoke(context.Background(), dir, "reviewer", "review"); !errors.Is(err, model.ErrAllModelsFailed) {
+		t.Fatalf("expected exhausted chain, got %v", err)
+	}
+	d, err := store.Degraded()
+	if err != nil || !d.Active || d.Reason != DegradedReason(model.PhaseReview) {
+		t.Fatalf("expected persisted degraded mode, got %+v (%v)", d, err)
+	}
+
+	// A later success for the phase leaves degraded mode. Close the circuits
+	// the rate limits may have opened first.
+	for _, target := range []string{CircuitTarget("claude-opus"), CircuitTarget("claude-sonnet")} {
+		if err := store.ResetCircuit(target); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fake.results["claude-opus"] = fakeResult{output: "APPROVED"}
+	if _, _, err := invoker.Invoke(context.Background(), dir, "reviewer", "review"); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := store.Degraded(); d.Active {
+		t.Errorf("expected degraded mode cleared after success, got %+v", d)
+	}
+}
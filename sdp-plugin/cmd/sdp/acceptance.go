@@ -23,11 +23,16 @@ func acceptanceCmd() *cobra.Command {
 }
 
 func acceptanceRunCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "run",
 		Short: "Execute acceptance test command",
-		RunE:  runAcceptanceRun,
+		Long: `Execute the acceptance test command. Test commands (go test ./..., pytest,
+jest, vitest) run only the tests affected by uncommitted changes; use --full
+to run the command as written.`,
+		RunE: runAcceptanceRun,
 	}
+	cmd.Flags().Bool("full", false, "Run the command in full instead of only affected tests")
+	return cmd
 }
 
 func runAcceptanceRun(cmd *cobra.Command, args []string) error {
@@ -43,11 +48,13 @@ func runAcceptanceRun(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		timeout = 30 * time.Second
 	}
+	full, _ := cmd.Flags().GetBool("full")
 	r := &acceptance.Runner{
 		Command:  cfg.Acceptance.Command,
 		Timeout:  timeout,
 		Expected: cfg.Acceptance.Expected,
 		Dir:      root,
+		Selector: affectedSelector(root, "", full),
 	}
 	res, err := r.Run(context.Background())
 	if err != nil {
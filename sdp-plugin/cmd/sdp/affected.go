@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fall-out-bug/sdp/internal/impact"
+)
+
+// changedFilesForTests returns the files whose dependents' tests should run:
+// changes since the merge base with base, or uncommitted changes when base
+// is empty. It returns nil, meaning run full test commands, when full is
+// set, nothing changed, or the changes cannot be determined.
+func changedFilesForTests(dir, base string, full bool) []string {
+	if full {
+		return nil
+	}
+	changed, err := impact.ChangedFiles(dir, base)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: affected tests: %v; running full test commands\n", err)
+		return nil
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return changed
+}
+
+// affectedSelector returns a Selector for test commands run in dir, or nil
+// to run them unchanged. See changedFilesForTests.
+func affectedSelector(dir, base string, full bool) *impact.Selector {
+	changed := changedFilesForTests(dir, base, full)
+	if changed == nil {
+		return nil
+	}
+	return impact.NewSelector(dir, changed)
+}
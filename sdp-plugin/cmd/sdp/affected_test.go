@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestAffectedSelector(t *testing.T) {
+	dir := t.TempDir()
+	if sel := affectedSelector(dir, "", false); sel != nil {
+		t.Errorf("expected nil selector outside a git repository, got %+v", sel)
+	}
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"-c", "user.name=t", "-c", "user.email=t@t", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v %s", args, err, out)
+		}
+	}
+	if sel := affectedSelector(dir, "", false); sel != nil {
+		t.Errorf("expected nil selector without changes, got %+v", sel)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sel := affectedSelector(dir, "", false)
+	if sel == nil || len(sel.Changed) != 1 {
+		t.Fatalf("expected selector for the untracked file, got %+v", sel)
+	}
+	if sel := affectedSelector(dir, "", true); sel != nil {
+		t.Error("expected --full to disable selection")
+	}
+}
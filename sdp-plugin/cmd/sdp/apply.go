@@ -60,7 +60,9 @@ Examples:
 			// Create executor with CLIRunner (sdp build) for non-dry-run
 			var runner executor.WorkstreamRunner
 			if !dryRun {
-				runner = executor.NewCLIRunner("sdp", "build")
+				cli := executor.NewCLIRunner("sdp", "build")
+				cli.Flakes = projectFlakeChecker("executor")
+				runner = cli
 			}
 			exec := executor.NewExecutor(executor.ExecutorConfig{
 				BacklogDir:      backlogDir,
@@ -24,7 +24,9 @@ Examples:
   sdp beads ready
   sdp beads show sdp-abc
   sdp beads update sdp-abc --status in_progress
-  sdp beads sync`,
+  sdp beads sync
+
+Without Beads installed, use "sdp queue" for the built-in file-backed queue.`,
 	}
 
 	cmd.AddCommand(beadsReadyCmd())
@@ -12,7 +12,9 @@ var checkpointCmd = &cobra.Command{
 Commands:
   create   Create a new checkpoint
   resume   Resume from an existing checkpoint
-  list     List all checkpoints
+  list     List checkpointed runs from every engine
+  diff     Show what changed between two revisions of a run
+  rollback Restore a run to an earlier revision
   clean    Clean old checkpoints
 
 Examples:
@@ -22,19 +24,26 @@ Examples:
   # List all checkpoints
   sdp checkpoint list
 
+  # Show the last change to F042 and undo it
+  sdp checkpoint diff F042
+  sdp checkpoint rollback F042 2
+
   # Resume from checkpoint
   sdp checkpoint resume my-feature
 
-  # Clean checkpoints older than 48 hours
+  # Clean runs older than 48 hours
   sdp checkpoint clean --age 48`,
 }
 
 func init() {
 	checkpointCmd.PersistentFlags().String("dir", "", "Checkpoint directory (default: .sdp/checkpoints)")
 	checkpointCleanCmd.Flags().Int("age", 24, "Age in hours (default: 24)")
+	checkpointCleanCmd.Flags().Int("keep", 0, "Also trim each run's history to this many revisions")
 
 	checkpointCmd.AddCommand(checkpointCreateCmd)
 	checkpointCmd.AddCommand(checkpointResumeCmd)
 	checkpointCmd.AddCommand(checkpointListCmd)
+	checkpointCmd.AddCommand(checkpointDiffCmd)
+	checkpointCmd.AddCommand(checkpointRollbackCmd)
 	checkpointCmd.AddCommand(checkpointCleanCmd)
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/fall-out-bug/sdp/internal/checkpoint"
 	"github.com/fall-out-bug/sdp/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -12,49 +11,55 @@ import (
 var checkpointCleanCmd = &cobra.Command{
 	Use:   "clean",
 	Short: "Clean old checkpoints",
-	Long: `Remove checkpoints older than the specified age.
+	Long: `Remove checkpointed runs older than the specified age, and trim the
+revision history of the remaining runs.
 
-This command permanently deletes checkpoint files that have not been modified
-within the specified time period. Use with caution.`,
-	Example: `  # Clean checkpoints older than 24 hours (default)
+A run is removed with its history and the legacy checkpoint files of every
+engine that wrote it. Runs whose orchestrator checkpoint is not completed are
+kept. Writes already keep at most 20 revisions per run; --keep trims further.
+This permanently deletes files. Use with caution.`,
+	Example: `  # Clean runs older than 24 hours (default)
   sdp checkpoint clean
 
-  # Clean checkpoints older than 48 hours
-  sdp checkpoint clean --age 48
+  # Clean runs older than 7 days
+  sdp checkpoint clean --age 168
 
-  # Clean checkpoints older than 7 days
-  sdp checkpoint clean --age 168`,
+  # Also keep only the last 5 revisions of each run
+  sdp checkpoint clean --keep 5`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		dir, err := cmd.Flags().GetString("dir")
+		ageHours, err := cmd.Flags().GetInt("age")
 		if err != nil {
-			return fmt.Errorf("failed to get dir flag: %w", err)
+			return fmt.Errorf("failed to get age flag: %w", err)
 		}
-
-		if dir == "" {
-			dir, err = checkpoint.GetDefaultDir()
-			if err != nil {
-				return fmt.Errorf("failed to get default checkpoint directory: %w", err)
-			}
+		keep, err := cmd.Flags().GetInt("keep")
+		if err != nil {
+			return fmt.Errorf("failed to get keep flag: %w", err)
 		}
 
-		ageHours, err := cmd.Flags().GetInt("age")
+		store, err := openCheckpointStore(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to get age flag: %w", err)
+			return err
 		}
 
-		manager := checkpoint.NewManager(dir)
-
-		age := time.Duration(ageHours) * time.Hour
-		deleted, err := manager.Clean(age)
+		removed, err := store.Clean(time.Now().Add(-time.Duration(ageHours) * time.Hour))
 		if err != nil {
 			return fmt.Errorf("failed to clean checkpoints: %w", err)
 		}
-
-		if deleted == 0 {
+		if len(removed) == 0 {
 			ui.InfoLine("No old checkpoints to clean")
 		} else {
-			ui.SuccessLine("Cleaned %d old checkpoint(s)", deleted)
+			ui.SuccessLine("Cleaned %d old run(s)", len(removed))
+		}
+
+		if keep > 0 {
+			pruned, err := store.PruneHistory(keep)
+			if err != nil {
+				return fmt.Errorf("failed to prune checkpoint history: %w", err)
+			}
+			if pruned > 0 {
+				ui.SuccessLine("Pruned %d old revision(s)", pruned)
+			}
 		}
 
 		return nil
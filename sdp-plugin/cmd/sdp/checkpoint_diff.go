@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fall-out-bug/sdp/internal/checkpoint"
+	"github.com/fall-out-bug/sdp/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var checkpointDiffCmd = &cobra.Command{
+	Use:   "diff <run> [rev-a] [rev-b]",
+	Short: "Show what changed between two revisions of a run",
+	Long: `Compare two revisions of a checkpointed run field by field.
+
+Without revisions, compares the previous revision with the current one.
+With one revision, compares it with the current one.`,
+	Example: `  sdp checkpoint diff F042
+  sdp checkpoint diff F042 3
+  sdp checkpoint diff F042 3 5`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := openCheckpointStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		runID := args[0]
+		cur, err := store.Load(runID)
+		if err != nil {
+			return fmt.Errorf("failed to load run: %w", err)
+		}
+		if cur == nil {
+			return fmt.Errorf("no checkpoint for run %s", runID)
+		}
+
+		revA, revB := cur.Revision-1, cur.Revision
+		if len(args) > 1 {
+			if revA, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid revision %q", args[1])
+			}
+		}
+		if len(args) > 2 {
+			if revB, err = strconv.Atoi(args[2]); err != nil {
+				return fmt.Errorf("invalid revision %q", args[2])
+			}
+		}
+
+		var a *checkpoint.Envelope
+		if revA > 0 {
+			if a, err = store.Revision(runID, revA); err != nil {
+				return err
+			}
+		}
+		b, err := store.Revision(runID, revB)
+		if err != nil {
+			return err
+		}
+
+		changes := checkpoint.Diff(a, b)
+		ui.Header(fmt.Sprintf("%s: revision %d → %d", runID, revA, revB))
+		if len(changes) == 0 {
+			ui.InfoLine("No changes")
+			return nil
+		}
+		for _, c := range changes {
+			fmt.Println(ui.BoldText(c.Path))
+			if c.Old != "" {
+				fmt.Printf("  - %s\n", ui.Error(c.Old))
+			}
+			if c.New != "" {
+				fmt.Printf("  + %s\n", ui.Success(c.New))
+			}
+		}
+
+		return nil
+	},
+}
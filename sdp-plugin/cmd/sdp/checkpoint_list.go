@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/fall-out-bug/sdp/internal/checkpoint"
 	"github.com/fall-out-bug/sdp/internal/ui"
@@ -10,49 +12,64 @@ import (
 
 var checkpointListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List all checkpoints",
+	Short: "List checkpointed runs from every engine",
 	Example: `  sdp checkpoint list
   sdp checkpoint list --dir /tmp/checkpoints`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		dir, err := cmd.Flags().GetString("dir")
+		store, err := openCheckpointStore(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to get dir flag: %w", err)
+			return err
 		}
 
-		if dir == "" {
-			dir, err = checkpoint.GetDefaultDir()
-			if err != nil {
-				return fmt.Errorf("failed to get default checkpoint directory: %w", err)
-			}
-		}
-
-		manager := checkpoint.NewManager(dir)
-
-		checkpoints, err := manager.List()
+		runs, err := store.Runs()
 		if err != nil {
 			return fmt.Errorf("failed to list checkpoints: %w", err)
 		}
 
-		if len(checkpoints) == 0 {
+		if len(runs) == 0 {
 			ui.InfoLine("No checkpoints found")
 			return nil
 		}
 
-		ui.Header(fmt.Sprintf("Found %d checkpoint(s)", len(checkpoints)))
-		for _, cp := range checkpoints {
-			fmt.Printf("ID:        %s\n", ui.BoldText(cp.ID))
-			fmt.Printf("  Feature:  %s\n", cp.FeatureID)
-			fmt.Printf("  Status:   %s\n", ui.Info(string(cp.Status)))
-			fmt.Printf("  Current:  %s\n", cp.CurrentWorkstream)
-			fmt.Printf("  Progress: %d/%d workstreams\n",
-				len(cp.CompletedWorkstreams),
-				len(cp.CompletedWorkstreams)+1) // +1 for current
-			fmt.Printf("  Created:  %s\n", ui.Dim(cp.CreatedAt.Format("2006-01-02 15:04:05")))
-			fmt.Printf("  Updated:  %s\n", ui.Dim(cp.UpdatedAt.Format("2006-01-02 15:04:05")))
+		ui.Header(fmt.Sprintf("Found %d checkpointed run(s)", len(runs)))
+		for _, env := range runs {
+			engines := make([]string, 0, len(env.Sections))
+			for engine := range env.Sections {
+				engines = append(engines, engine)
+			}
+			slices.Sort(engines)
+
+			fmt.Printf("Run:        %s\n", ui.BoldText(env.RunID))
+			fmt.Printf("  Feature:  %s\n", env.FeatureID)
+			fmt.Printf("  Engines:  %s\n", ui.Info(strings.Join(engines, ", ")))
+			fmt.Printf("  Revision: %d (last write: %s)\n", env.Revision, env.Engine)
+			fmt.Printf("  Updated:  %s\n", ui.Dim(env.UpdatedAt.Local().Format("2006-01-02 15:04:05")))
 			fmt.Println()
 		}
 
 		return nil
 	},
 }
+
+// openCheckpointStore opens the unified store in --dir (default .sdp/checkpoints)
+// and imports any legacy checkpoint files it does not know yet.
+func openCheckpointStore(cmd *cobra.Command) (*checkpoint.Store, error) {
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dir flag: %w", err)
+	}
+
+	if dir == "" {
+		dir, err = checkpoint.GetDefaultDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get default checkpoint directory: %w", err)
+		}
+	}
+
+	store := checkpoint.NewStore(dir)
+	if _, err := store.Migrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate legacy checkpoints: %w", err)
+	}
+	return store, nil
+}
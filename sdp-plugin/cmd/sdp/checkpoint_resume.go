@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/fall-out-bug/sdp/internal/checkpoint"
@@ -13,37 +15,45 @@ var checkpointResumeCmd = &cobra.Command{
 	Use:   "resume <id>",
 	Short: "Resume from an existing checkpoint",
 	Example: `  sdp checkpoint resume feature-01
-  sdp checkpoint resume feature-01 --dir /tmp/checkpoints`,
+  sdp checkpoint resume F042 --dir /tmp/checkpoints`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		id := args[0]
 
-		dir, err := cmd.Flags().GetString("dir")
+		store, err := openCheckpointStore(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to get dir flag: %w", err)
+			return err
 		}
 
-		if dir == "" {
-			dir, err = checkpoint.GetDefaultDir()
-			if err != nil {
-				return fmt.Errorf("failed to get default checkpoint directory: %w", err)
-			}
+		env, err := store.Load(id)
+		if err != nil {
+			return fmt.Errorf("failed to resume checkpoint: %w", err)
+		}
+		if env == nil {
+			return fmt.Errorf("failed to resume checkpoint: no checkpointed run %q", id)
 		}
 
-		manager := checkpoint.NewManager(dir)
+		engines := make([]string, 0, len(env.Sections))
+		for engine := range env.Sections {
+			engines = append(engines, engine)
+		}
+		slices.Sort(engines)
 
-		cp, err := manager.Resume(id)
-		if err != nil {
+		ui.SuccessLine("Resumed checkpoint: %s", ui.BoldText(env.RunID))
+		fmt.Printf("   Feature:              %s\n", env.FeatureID)
+		fmt.Printf("   Engines:              %s\n", ui.Info(strings.Join(engines, ", ")))
+		fmt.Printf("   Revision:             %d (last write: %s)\n", env.Revision, env.Engine)
+
+		var cp checkpoint.Checkpoint
+		if ok, err := store.Section(id, checkpoint.EngineOrchestrator, &cp); err != nil {
 			return fmt.Errorf("failed to resume checkpoint: %w", err)
+		} else if ok {
+			fmt.Printf("   Status:               %s\n", ui.Info(string(cp.Status)))
+			fmt.Printf("   Current Workstream:   %s\n", cp.CurrentWorkstream)
+			fmt.Printf("   Completed Workstreams: %d\n", len(cp.CompletedWorkstreams))
+			fmt.Printf("   Created:              %s\n", ui.Dim(cp.CreatedAt.Format(time.RFC3339)))
 		}
-
-		ui.SuccessLine("Resumed checkpoint: %s", ui.BoldText(cp.ID))
-		fmt.Printf("   Feature:              %s\n", cp.FeatureID)
-		fmt.Printf("   Status:               %s\n", ui.Info(string(cp.Status)))
-		fmt.Printf("   Current Workstream:   %s\n", cp.CurrentWorkstream)
-		fmt.Printf("   Completed Workstreams: %d\n", len(cp.CompletedWorkstreams))
-		fmt.Printf("   Created:              %s\n", ui.Dim(cp.CreatedAt.Format(time.RFC3339)))
-		fmt.Printf("   Updated:              %s\n", ui.Dim(cp.UpdatedAt.Format(time.RFC3339)))
+		fmt.Printf("   Updated:              %s\n", ui.Dim(env.UpdatedAt.Format(time.RFC3339)))
 
 		return nil
 	},
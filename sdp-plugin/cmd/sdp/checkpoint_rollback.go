@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fall-out-bug/sdp/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var checkpointRollbackCmd = &cobra.Command{
+	Use:   "rollback <run> <rev>",
+	Short: "Restore a run to an earlier revision",
+	Long: `Make an earlier revision of a run current again.
+
+The rollback is recorded as a new revision, so it can itself be rolled back.
+Each engine's checkpoint file is rewritten, so the next resume starts from
+the restored state.`,
+	Example: `  sdp checkpoint rollback F042 3`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rev, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid revision %q", args[1])
+		}
+
+		store, err := openCheckpointStore(cmd)
+		if err != nil {
+			return err
+		}
+
+		env, err := store.Rollback(args[0], rev)
+		if err != nil {
+			return fmt.Errorf("failed to roll back: %w", err)
+		}
+
+		ui.SuccessLine("Rolled back %s to revision %d (now revision %d)", ui.BoldText(env.RunID), rev, env.Revision)
+		return nil
+	},
+}
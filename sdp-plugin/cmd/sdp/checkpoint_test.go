@@ -1,7 +1,11 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/checkpoint"
 )
 
 // TestCheckpointCreateCmd tests the checkpoint create command structure
@@ -80,6 +84,19 @@ func TestCheckpointListCmd(t *testing.T) {
 	}
 }
 
+// TestCheckpointDiffRollbackCmd tests the diff and rollback argument validation
+func TestCheckpointDiffRollbackCmd(t *testing.T) {
+	if err := checkpointDiffCmd.Args(checkpointDiffCmd, []string{}); err == nil {
+		t.Error("checkpointDiffCmd accepted no args")
+	}
+	if err := checkpointDiffCmd.Args(checkpointDiffCmd, []string{"F042", "1", "2"}); err != nil {
+		t.Errorf("checkpointDiffCmd rejected run and two revisions: %v", err)
+	}
+	if err := checkpointRollbackCmd.Args(checkpointRollbackCmd, []string{"F042"}); err == nil {
+		t.Error("checkpointRollbackCmd accepted a run without revision")
+	}
+}
+
 // TestCheckpointCleanCmd tests the checkpoint clean command structure
 func TestCheckpointCleanCmd(t *testing.T) {
 	cmd := checkpointCleanCmd
@@ -92,9 +109,46 @@ func TestCheckpointCleanCmd(t *testing.T) {
 		t.Errorf("checkpointCleanCmd has wrong use: %s", cmd.Use)
 	}
 
-	// Check that age flag exists
-	if cmd.Flags().Lookup("age") == nil {
-		t.Error("checkpointCleanCmd missing --age flag")
+	// Check that age and keep flags exist
+	if cmd.Flags().Lookup("age") == nil || cmd.Flags().Lookup("keep") == nil {
+		t.Error("checkpointCleanCmd missing --age or --keep flag")
+	}
+}
+
+// TestCheckpointResumeAndCleanUseStore runs resume and clean against the unified store
+func TestCheckpointResumeAndCleanUseStore(t *testing.T) {
+	dir := t.TempDir()
+	legacy := `{"id":"run-1","feature_id":"F030","status":"completed","completed_workstreams":["00-030-01"]}`
+	if err := os.WriteFile(filepath.Join(dir, "run-1.json"), []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store := checkpoint.NewStore(dir)
+	if err := store.Put("F030", "F030", checkpoint.EngineOrchestrate, "F030.json", map[string]string{"phase": "build"}); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpointCmd.SetArgs([]string{"resume", "F030", "--dir", dir})
+	if err := checkpointCmd.Execute(); err != nil {
+		t.Fatalf("resume of a run without orchestrator section: %v", err)
+	}
+	checkpointCmd.SetArgs([]string{"resume", "run-1", "--dir", dir})
+	if err := checkpointCmd.Execute(); err != nil {
+		t.Fatalf("resume of a migrated legacy checkpoint: %v", err)
+	}
+	checkpointCmd.SetArgs([]string{"resume", "missing", "--dir", dir})
+	if err := checkpointCmd.Execute(); err == nil {
+		t.Error("expected error for unknown run")
+	}
+
+	checkpointCmd.SetArgs([]string{"clean", "--age", "0", "--keep", "0", "--dir", dir})
+	if err := checkpointCmd.Execute(); err != nil {
+		t.Fatalf("clean: %v", err)
+	}
+	if runs, _ := store.Runs(); len(runs) != 0 {
+		t.Errorf("expected every run cleaned, got %d", len(runs))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "run-1.json")); !os.IsNotExist(err) {
+		t.Error("clean must remove the legacy file of a cleaned run")
 	}
 }
 
@@ -108,7 +162,7 @@ func TestCheckpointCmdStructure(t *testing.T) {
 	}
 
 	// Test subcommands
-	expectedSubcommands := []string{"create", "resume", "list", "clean"}
+	expectedSubcommands := []string{"create", "resume", "list", "diff", "rollback", "clean"}
 	for _, expected := range expectedSubcommands {
 		found := false
 		for _, c := range cmd.Commands() {
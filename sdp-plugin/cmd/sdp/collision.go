@@ -8,10 +8,12 @@ func collisionCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "collision",
 		Short: "Scope collision detection for parallel workstreams",
-		Long:  `Detect when in-progress workstreams touch the same files or directories.`,
+		Long: `Detect when in-progress workstreams touch the same files or directories,
+and predict merge conflicts between in-flight branches.`,
 	}
 	cmd.AddCommand(collisionCheckCmd())
 	cmd.AddCommand(collisionDetectCmd())
+	cmd.AddCommand(collisionPredictCmd())
 	return cmd
 }
 
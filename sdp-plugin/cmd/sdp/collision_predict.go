@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/fall-out-bug/sdp/internal/basebranch"
+	"github.com/fall-out-bug/sdp/internal/collision"
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+func collisionPredictCmd() *cobra.Command {
+	var base string
+	var extra []string
+	var outputJSON bool
+	cmd := &cobra.Command{
+		Use:   "predict",
+		Short: "Predict merge conflicts between in-flight branches",
+		Long: `Run in-memory three-way merges (git merge-tree) between every pair of
+active worktree branches, and between each branch and the base, without
+touching any checkout.
+
+Reports textual conflicts git would stop on, plus declarations edited on
+both sides that merge cleanly but may clash. Results are cached in
+.sdp/collision-predictions.json and shown by 'sdp status'.`,
+		Example: `  sdp collision predict
+  sdp collision predict --base main --branch feature/F042`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCollisionPredict(base, extra, outputJSON)
+		},
+	}
+	cmd.Flags().StringVar(&base, "base", "", "Integration branch (default: dev, main or master)")
+	cmd.Flags().StringSliceVar(&extra, "branch", nil, "Additional branch to include (repeatable)")
+	cmd.Flags().BoolVar(&outputJSON, "output-json", false, "Output in JSON format")
+	return cmd
+}
+
+func runCollisionPredict(base string, extra []string, outputJSON bool) error {
+	root, err := config.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("find project root: %w", err)
+	}
+	if base == "" {
+		base = basebranch.Default(root)
+	}
+
+	worktrees, err := worktree.NewCreator(root).List()
+	if err != nil {
+		return err
+	}
+	var branches []string
+	for _, wt := range worktrees {
+		if wt.Branch != "" && wt.Branch != base && !slices.Contains(branches, wt.Branch) {
+			branches = append(branches, wt.Branch)
+		}
+	}
+	for _, b := range extra {
+		if b != base && !slices.Contains(branches, b) {
+			branches = append(branches, b)
+		}
+	}
+
+	report, err := collision.NewPredictor(root, base).Predict(branches)
+	if err != nil {
+		return err
+	}
+	if err := collision.SavePredictions(root, report); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to cache predictions: %v\n", err)
+	}
+
+	if outputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printPredictions(report)
+	return nil
+}
+
+func printPredictions(report *collision.PredictionReport) {
+	if len(report.Predictions) == 0 {
+		fmt.Printf("No merge conflicts predicted across %d branch(es) and %s.\n", len(report.Branches), report.Base)
+		return
+	}
+	conflicting := 0
+	for _, p := range report.Predictions {
+		if p.HasConflicts() {
+			conflicting++
+			fmt.Printf("❌ %s ↔ %s\n", p.BranchA, p.BranchB)
+		} else {
+			fmt.Printf("⚠️  %s ↔ %s\n", p.BranchA, p.BranchB)
+		}
+		for _, c := range p.Conflicts {
+			fmt.Printf("    CONFLICT (%s) %s\n", c.Kind, c.File)
+		}
+		for _, e := range p.SymbolEdits {
+			fmt.Printf("    both edit %s in %s\n", e.Symbol, e.File)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("  %d pair(s) would conflict, %d pair(s) edit the same symbols\n",
+		conflicting, len(report.Predictions)-conflicting)
+	fmt.Println("  Recommendation: merge one branch first and rebase the other, or coordinate the shared edits")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/collision"
+)
+
+func TestCollisionPredictCmdFlags(t *testing.T) {
+	cmd := collisionPredictCmd()
+	for _, name := range []string{"base", "branch", "output-json"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("collision predict missing --%s flag", name)
+		}
+	}
+}
+
+func TestPrintPredictions(t *testing.T) {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	printPredictions(&collision.PredictionReport{
+		Base:     "main",
+		Branches: []string{"feature/a", "feature/b"},
+		Predictions: []collision.Prediction{{
+			BranchA:     "feature/a",
+			BranchB:     "feature/b",
+			Conflicts:   []collision.FileConflict{{File: "go.mod", Kind: "content"}},
+			SymbolEdits: []collision.SymbolEdit{{File: "app.go", Symbol: "Run"}},
+		}},
+	})
+
+	w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+
+	out := buf.String()
+	for _, want := range []string{"feature/a ↔ feature/b", "CONFLICT (content) go.mod", "both edit Run in app.go", "1 pair(s) would conflict"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/fall-out-bug/sdp/internal/contract"
 	"github.com/spf13/cobra"
 )
 
@@ -71,7 +72,8 @@ that multiple features must respect.`,
 		Long: `Lock contract to prevent modifications during implementation.
 
 Creates .lock file with SHA256 checksum. Prevents agents
-from diverging from agreed contract.`,
+from diverging from agreed contract. Works for OpenAPI (.yaml)
+and protobuf (.proto) contracts.`,
 		RunE: runContractLock,
 	}
 
@@ -85,6 +87,29 @@ from diverging from agreed contract.`,
 
 	cmd.AddCommand(lockCmd)
 
+	// generate-code subcommand
+	generateCodeCmd := &cobra.Command{
+		Use:   "generate-code",
+		Short: "Generate server interfaces or clients from a locked contract",
+		Long: `Generate code from a locked OpenAPI contract.
+
+--lang go emits request/response structs, a net/http Handler interface and
+a Register function for http.ServeMux. --lang ts emits TypeScript types and
+a fetch-based client. Files are marked as generated and recorded in the
+lock, and "sdp contract verify" fails when they drift from the lock.`,
+		Example: `  sdp contract generate-code --feature users --lang go
+  sdp contract generate-code --contract .contracts/users.yaml --lang ts --output web/src/api/users.gen.ts`,
+		RunE: runContractGenerateCode,
+	}
+
+	generateCodeCmd.Flags().String("lang", contract.LangGo, "Target language: go or ts")
+	generateCodeCmd.Flags().String("feature", "", "Feature name")
+	generateCodeCmd.Flags().String("contract", "", "Contract file path")
+	generateCodeCmd.Flags().String("output", "", "Output file (default api/<pkg>/<pkg>_gen.go or api/<feature>.gen.ts)")
+	generateCodeCmd.Flags().String("package", "", "Go package name (default from feature)")
+
+	cmd.AddCommand(generateCodeCmd)
+
 	// validate subcommand
 	var contractPaths []string
 	var reportPath string
@@ -120,7 +145,16 @@ Flags:
 		Short: "Verify contract matches lock",
 		Long: `Verify that contract file matches the locked version.
 
-Returns exit code 0 if match, 1 if mismatch.`,
+When an OpenAPI contract has changed, it is diffed against the locked
+version (from the lock snapshot, or git at the locked SHA) and each change
+is classified as breaking or non-breaking. Breaking changes fail unless a
+decision for the feature tagged "breaking-change" was logged after the
+lock. Set contracts.fail_on: any in .sdp/config.yml to fail on any change.
+Files from "sdp contract generate-code" must match the locked contract.
+With --impl, the gRPC servers registered under that directory must
+implement every RPC of a .proto contract.
+
+Returns exit code 0 if match or accepted changes, 1 otherwise.`,
 		RunE: runContractVerify,
 	}
 
@@ -129,6 +163,8 @@ Returns exit code 0 if match, 1 if mismatch.`,
 
 	verifyCmd.Flags().StringVar(&verifyFeature, "feature", "", "Feature name")
 	verifyCmd.Flags().StringVar(&verifyContract, "contract", "", "Contract file path")
+	verifyCmd.Flags().String("report", "", "Write the Markdown change report to this path")
+	verifyCmd.Flags().String("impl", "", "Go directory whose registered gRPC servers must implement the .proto contract")
 
 	cmd.AddCommand(verifyCmd)
 
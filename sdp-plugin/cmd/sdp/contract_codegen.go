@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/fall-out-bug/sdp/internal/contract"
+	"github.com/spf13/cobra"
+)
+
+func runContractGenerateCode(cmd *cobra.Command, args []string) error {
+	flags := make(map[string]string)
+	for _, name := range []string{"lang", "feature", "contract", "output", "package"} {
+		v, err := cmd.Flags().GetString(name)
+		if err != nil {
+			return fmt.Errorf("failed to get %s flag: %w", name, err)
+		}
+		flags[name] = v
+	}
+	lang, featureName, contractPath := flags["lang"], flags["feature"], flags["contract"]
+	outputPath, pkg := flags["output"], flags["package"]
+
+	if contractPath == "" && featureName != "" {
+		contractPath = featureContractPath(featureName)
+	}
+	if contractPath == "" {
+		return fmt.Errorf("either --feature or --contract must be specified")
+	}
+	if featureName == "" {
+		base := filepath.Base(contractPath)
+		featureName = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	if pkg == "" {
+		pkg = goPackageName(featureName)
+	}
+	if outputPath == "" {
+		outputPath = defaultGeneratedPath(lang, featureName, pkg)
+	}
+	lockPath := strings.TrimSuffix(contractPath, filepath.Ext(contractPath)) + ".lock"
+	return runContractGenerateCodeInternal(contractPath, lockPath, contract.GeneratedFile{Path: outputPath, Lang: lang, Package: pkg})
+}
+
+// runContractGenerateCodeInternal generates code from a locked contract
+// and records the output in the lock.
+func runContractGenerateCodeInternal(contractPath, lockPath string, file contract.GeneratedFile) error {
+	if contract.IsProtoFile(contractPath) {
+		return fmt.Errorf("code generation supports OpenAPI contracts only; use protoc for %s", contractPath)
+	}
+	if file.Lang != contract.LangGo {
+		file.Package = ""
+	}
+	lock, err := contract.ReadLock(lockPath)
+	if err != nil {
+		return fmt.Errorf("contract must be locked before generating code (sdp contract lock --contract %s): %w", contractPath, err)
+	}
+	content, err := os.ReadFile(contractPath)
+	if err != nil {
+		return fmt.Errorf("failed to read contract: %w", err)
+	}
+	if contract.Hash(content) != lock.ContractHash {
+		return fmt.Errorf("contract %s has changed since lock; re-lock before generating code", contractPath)
+	}
+
+	code, err := lock.GenerateLocked(content, file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(file.Path, code, 0o644); err != nil {
+		return fmt.Errorf("failed to write generated code: %w", err)
+	}
+	lock.RecordGenerated(file)
+	if err := contract.WriteLock(lockPath, lock); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Generated %s code: %s\n", file.Lang, file.Path)
+	fmt.Printf("✓ Recorded in lock: %s\n", lockPath)
+	return nil
+}
+
+// verifyGeneratedCode reports whether the files generated from the locked
+// contract are unchanged. Locks without generated files pass.
+func verifyGeneratedCode(lockPath string) (bool, error) {
+	lock, err := contract.ReadLock(lockPath)
+	if err != nil {
+		return false, err
+	}
+	if len(lock.Generated) == 0 {
+		return true, nil
+	}
+	content, _, err := lock.LockedContent()
+	if err != nil {
+		fmt.Printf("✗ Cannot check generated code: %v\n", err)
+		return false, nil
+	}
+	stale, err := lock.StaleGenerated(content)
+	if err != nil {
+		return false, err
+	}
+	if len(stale) == 0 {
+		fmt.Printf("✓ Generated code in sync (%d file(s))\n", len(lock.Generated))
+		return true, nil
+	}
+	for _, path := range stale {
+		fmt.Printf("✗ Generated code out of sync with lock: %s\n", path)
+	}
+	fmt.Println("  Regenerate with: sdp contract generate-code")
+	return false, nil
+}
+
+// defaultGeneratedPath places generated code under api/.
+func defaultGeneratedPath(lang, feature, pkg string) string {
+	if lang == contract.LangTS {
+		return filepath.Join("api", feature+".gen.ts")
+	}
+	return filepath.Join("api", pkg, pkg+"_gen.go")
+}
+
+// goPackageName lower-cases name and drops characters Go does not allow.
+func goPackageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || (unicode.IsDigit(r) && b.Len() > 0) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "api"
+	}
+	return b.String()
+}
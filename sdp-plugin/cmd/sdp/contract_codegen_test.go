@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/contract"
+)
+
+func TestRunContractGenerateCode(t *testing.T) {
+	contractPath, lockPath := setupLockedContract(t)
+	goFile := contract.GeneratedFile{Path: filepath.Join("api", "users", "users_gen.go"), Lang: contract.LangGo, Package: "users"}
+	tsFile := contract.GeneratedFile{Path: filepath.Join("web", "users.gen.ts"), Lang: contract.LangTS, Package: "ignored"}
+
+	for _, f := range []contract.GeneratedFile{goFile, tsFile} {
+		if err := runContractGenerateCodeInternal(contractPath, lockPath, f); err != nil {
+			t.Fatalf("generate %s: %v", f.Lang, err)
+		}
+	}
+	code, err := os.ReadFile(goFile.Path)
+	if err != nil || !strings.Contains(string(code), "type Handler interface") {
+		t.Fatalf("Go file not generated: %v", err)
+	}
+	lock, err := contract.ReadLock(lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lock.Generated) != 2 || lock.Generated[1].Package != "" {
+		t.Errorf("lock generated = %+v", lock.Generated)
+	}
+
+	inSync, err := verifyGeneratedCode(lockPath)
+	if err != nil || !inSync {
+		t.Errorf("fresh code: inSync=%v err=%v", inSync, err)
+	}
+
+	if err := os.WriteFile(tsFile.Path, []byte("// hand edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	inSync, err = verifyGeneratedCode(lockPath)
+	if err != nil || inSync {
+		t.Errorf("edited code: inSync=%v err=%v", inSync, err)
+	}
+
+	// Re-locking keeps the record, so stale files are still caught.
+	if err := runContractLockInternal("users", "unknown", contractPath, lockPath, true); err != nil {
+		t.Fatal(err)
+	}
+	if lock, _ := contract.ReadLock(lockPath); len(lock.Generated) != 2 {
+		t.Errorf("re-lock dropped generated files: %+v", lock.Generated)
+	}
+}
+
+func TestRunContractGenerateCode_RequiresMatchingLock(t *testing.T) {
+	contractPath, lockPath := setupLockedContract(t)
+	file := contract.GeneratedFile{Path: "users.gen.ts", Lang: contract.LangTS}
+
+	if err := os.WriteFile(contractPath, []byte(lockedContract+"# edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runContractGenerateCodeInternal(contractPath, lockPath, file); err == nil || !strings.Contains(err.Error(), "changed since lock") {
+		t.Errorf("expected changed-since-lock error, got %v", err)
+	}
+
+	if err := os.Remove(lockPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := runContractGenerateCodeInternal(contractPath, lockPath, file); err == nil || !strings.Contains(err.Error(), "must be locked") {
+		t.Errorf("expected must-be-locked error, got %v", err)
+	}
+}
+
+func TestGoPackageName(t *testing.T) {
+	for in, want := range map[string]string{"users": "users", "F054-Orders": "f054orders", "2fa": "fa", "---": "api"} {
+		if got := goPackageName(in); got != want {
+			t.Errorf("goPackageName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
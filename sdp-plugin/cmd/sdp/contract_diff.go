@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/contract"
+	"github.com/fall-out-bug/sdp/internal/decision"
+)
+
+// runContractDiff compares a changed contract with its locked version,
+// prints the change report and applies the contracts.fail_on policy. It
+// returns true when verification passes despite the hash mismatch.
+func runContractDiff(featureName, contractPath, lockPath, reportPath string) (bool, error) {
+	lock, err := contract.ReadLock(lockPath)
+	if err != nil {
+		return false, err
+	}
+	locked, source, err := lock.LockedContent()
+	if err != nil {
+		fmt.Println()
+		fmt.Printf("Contract has been modified since lock (%v).\n", err)
+		fmt.Println("Please re-lock or restore original contract.")
+		return false, nil
+	}
+	current, err := os.ReadFile(contractPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read contract: %w", err)
+	}
+	diff, err := contract.CompareContent(contractPath, locked, current)
+	if err != nil {
+		fmt.Println()
+		fmt.Printf("Contract has been modified since lock (no semantic diff: %v).\n", err)
+		fmt.Println("Please re-lock or restore original contract.")
+		return false, nil
+	}
+
+	report := diff.Report(contractPath)
+	fmt.Printf("  Locked version: %s\n\n", source)
+	fmt.Print(report)
+	if reportPath != "" {
+		if err := os.WriteFile(reportPath, []byte(report), 0o644); err != nil {
+			return false, fmt.Errorf("failed to write report: %w", err)
+		}
+		fmt.Printf("\nReport written to %s\n", reportPath)
+	}
+
+	feature := contractFeature(featureName, lock, contractPath)
+	verdict := contractPolicy().Evaluate(feature, lock.LockedAt, diff)
+	fmt.Println()
+	switch {
+	case verdict.Failed:
+		fmt.Printf("✗ %s\n", verdict.Reason)
+		fmt.Printf("  Approve with: sdp decisions log --feature-id %s --tags %s --decision \"...\"\n", feature, contract.ApprovalTag)
+		fmt.Println("  or re-lock the contract.")
+		return false, nil
+	case verdict.Reason != "":
+		fmt.Printf("✓ %s\n", verdict.Reason)
+	default:
+		fmt.Println("✓ No breaking changes")
+	}
+	return true, nil
+}
+
+// contractPolicy builds the verify policy from project config and the
+// decision log. Missing config or decisions fall back to defaults.
+func contractPolicy() contract.Policy {
+	policy := contract.Policy{FailOn: contract.FailOnBreaking}
+	root, err := config.FindProjectRoot()
+	if err != nil {
+		return policy
+	}
+	if cfg, err := config.Load(root); err == nil && cfg.Contracts.FailOn != "" {
+		policy.FailOn = cfg.Contracts.FailOn
+	}
+	if logger, err := decision.NewLogger(root); err == nil {
+		if decisions, err := logger.LoadAll(); err == nil {
+			policy.Decisions = decisions
+		}
+	}
+	return policy
+}
+
+// contractFeature names the feature whose decisions may approve changes:
+// the --feature flag, then the lock metadata, then the contract file name.
+func contractFeature(flag string, lock *contract.Lock, contractPath string) string {
+	if flag != "" {
+		return flag
+	}
+	if lock.Metadata.Feature != "" {
+		return lock.Metadata.Feature
+	}
+	base := filepath.Base(contractPath)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// featureContractPath returns the default contract for a feature:
+// .contracts/<feature>.yaml, or .contracts/<feature>.proto when only a
+// protobuf contract exists.
+func featureContractPath(featureName string) string {
+	path := fmt.Sprintf(".contracts/%s.yaml", featureName)
+	proto := fmt.Sprintf(".contracts/%s.proto", featureName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if _, err := os.Stat(proto); err == nil {
+			return proto
+		}
+	}
+	return path
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+)
+
+const lockedContract = `openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: ok
+  /users/{id}:
+    delete:
+      responses:
+        "204":
+          description: deleted
+`
+
+func setupLockedContract(t *testing.T) (contractPath, lockPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll(".contracts", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	contractPath = filepath.Join(".contracts", "users.yaml")
+	lockPath = filepath.Join(".contracts", "users.lock")
+	if err := os.WriteFile(contractPath, []byte(lockedContract), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runContractLockInternal("users", "unknown", contractPath, lockPath, false); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	return contractPath, lockPath
+}
+
+func TestRunContractDiff_NonBreaking(t *testing.T) {
+	contractPath, lockPath := setupLockedContract(t)
+	changed := lockedContract + "  /teams:\n    get:\n      responses:\n        \"200\":\n          description: ok\n"
+	if err := os.WriteFile(contractPath, []byte(changed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+
+	passed, err := runContractDiff("", contractPath, lockPath, reportPath)
+	if err != nil {
+		t.Fatalf("runContractDiff: %v", err)
+	}
+	if !passed {
+		t.Error("non-breaking change should pass")
+	}
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	if !strings.Contains(string(report), "`/teams` path added") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestRunContractDiff_Breaking(t *testing.T) {
+	contractPath, lockPath := setupLockedContract(t)
+	changed := strings.Replace(lockedContract, "    delete:", "    patch:", 1)
+	if err := os.WriteFile(contractPath, []byte(changed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	passed, err := runContractDiff("", contractPath, lockPath, "")
+	if err != nil {
+		t.Fatalf("runContractDiff: %v", err)
+	}
+	if passed {
+		t.Fatal("breaking change without approval should fail")
+	}
+
+	logger, err := decision.NewLogger(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Log(decision.Decision{
+		Timestamp: time.Now().Add(time.Minute),
+		Type:      decision.DecisionTypeExplicit,
+		FeatureID: "users",
+		Decision:  "replace DELETE with PATCH",
+		Tags:      []string{"breaking-change"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	passed, err = runContractDiff("", contractPath, lockPath, "")
+	if err != nil {
+		t.Fatalf("runContractDiff: %v", err)
+	}
+	if !passed {
+		t.Error("approved breaking change should pass")
+	}
+}
+
+func TestRunContractDiff_FailOnAny(t *testing.T) {
+	contractPath, lockPath := setupLockedContract(t)
+	if err := os.MkdirAll(".sdp", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(".sdp/config.yml", []byte("version: 1\ncontracts:\n  fail_on: any\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Even an edit with no semantic changes fails, as before.
+	changed := strings.Replace(lockedContract, "description: ok", "description: all users", 1)
+	if err := os.WriteFile(contractPath, []byte(changed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	passed, err := runContractDiff("", contractPath, lockPath, "")
+	if err != nil || passed {
+		t.Errorf("fail_on any: passed=%v err=%v", passed, err)
+	}
+}
+
+func TestRunContractDiff_NotRecoverable(t *testing.T) {
+	contractPath, lockPath := setupLockedContract(t)
+	if err := os.RemoveAll(filepath.Join(".contracts", ".locked")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(contractPath, []byte(lockedContract+"# edit\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	passed, err := runContractDiff("", contractPath, lockPath, "")
+	if err != nil || passed {
+		t.Errorf("unrecoverable lock: passed=%v err=%v", passed, err)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fall-out-bug/sdp/internal/contract"
+)
+
+// verifyGRPCImplementation checks that the gRPC servers registered under
+// implDir implement every RPC of the .proto contract.
+func verifyGRPCImplementation(contractPath, implDir string) (bool, error) {
+	if filepath.Ext(contractPath) != ".proto" {
+		return false, fmt.Errorf("--impl applies to .proto contracts, got %s", contractPath)
+	}
+	data, err := os.ReadFile(contractPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read contract: %w", err)
+	}
+	proto, err := contract.ParseProto(data)
+	if err != nil {
+		return false, err
+	}
+	servers, err := contract.ScanGRPCServers(implDir)
+	if err != nil {
+		return false, err
+	}
+	problems := contract.CheckGRPCImplementation(proto, servers)
+	if len(problems) == 0 {
+		fmt.Printf("✓ gRPC servers in %s implement every contract RPC\n", implDir)
+		return true, nil
+	}
+	for _, p := range problems {
+		fmt.Printf("✗ %s\n", p)
+	}
+	return false, nil
+}
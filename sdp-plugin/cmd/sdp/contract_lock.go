@@ -9,24 +9,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fall-out-bug/sdp/internal/contract"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
 // ContractLock represents the lock file structure
-type ContractLock struct {
-	ContractFile string `yaml:"contract_file"`
-	ContractHash string `yaml:"contract_hash"`
-	GitSHA       string `yaml:"git_sha"`
-	LockedAt     string `yaml:"locked_at"`
-	Checksum     string `yaml:"checksum"`
-	Metadata     struct {
-		Feature   string `yaml:"feature"`
-		Version   string `yaml:"version"`
-		Endpoints int    `yaml:"endpoints"`
-		Schemas   int    `yaml:"schemas"`
-	} `yaml:"metadata,omitempty"`
-}
+type ContractLock = contract.Lock
 
 func runContractLock(cmd *cobra.Command, args []string) error {
 	contractPath, err := cmd.Flags().GetString("contract")
@@ -108,25 +97,44 @@ func runContractLockInternal(featureName, gitSHA, contractPath, lockPath string,
 	}
 
 	// Try to parse contract to extract metadata
-	var contract map[string]any
-	if err := yaml.Unmarshal(contractContent, &contract); err == nil {
+	var doc map[string]any
+	if contract.IsProtoFile(contractPath) {
+		if proto, err := contract.ParseProto(contractContent); err == nil {
+			lock.Metadata.Feature = featureName
+			lock.Metadata.Version = "1.0.0"
+			lock.Metadata.Endpoints = proto.RPCCount()
+			lock.Metadata.Schemas = len(proto.Messages)
+		}
+	} else if err := yaml.Unmarshal(contractContent, &doc); err == nil {
 		// Extract metadata if available
-		if _, ok := contract["info"].(map[string]any); ok {
+		if _, ok := doc["info"].(map[string]any); ok {
 			lock.Metadata.Feature = featureName
 			lock.Metadata.Version = "1.0.0"
 		}
 
 		// Count endpoints and schemas
-		if paths, ok := contract["paths"].(map[string]any); ok {
+		if paths, ok := doc["paths"].(map[string]any); ok {
 			lock.Metadata.Endpoints = len(paths)
 		}
-		if schemas, ok := contract["components"].(map[string]any); ok {
+		if schemas, ok := doc["components"].(map[string]any); ok {
 			if s, ok := schemas["schemas"].(map[string]any); ok {
 				lock.Metadata.Schemas = len(s)
 			}
 		}
 	}
 
+	// Snapshot the contract so verify can diff against it after it changes
+	backupPath, err := contract.WriteSnapshot(lockPath, contractPath, contractContent)
+	if err != nil {
+		return err
+	}
+	lock.Backup = backupPath
+
+	// Keep generated files on re-lock; verify flags them until regenerated
+	if prev, err := contract.ReadLock(lockPath); err == nil {
+		lock.Generated = prev.Generated
+	}
+
 	// Marshal lock to YAML
 	lockData, err := yaml.Marshal(lock)
 	if err != nil {
@@ -142,6 +150,7 @@ func runContractLockInternal(featureName, gitSHA, contractPath, lockPath string,
 	fmt.Printf("✓ Contract read: %s\n", contractPath)
 	fmt.Printf("✓ Contract hash: %s\n", contractHash[:16]+"...")
 	fmt.Printf("✓ Lock file created: %s\n", lockPath)
+	fmt.Printf("✓ Snapshot: %s\n", backupPath)
 	fmt.Printf("✓ Locked at: %s\n", timestamp)
 	fmt.Printf("✓ Git SHA: %s\n", gitSHA)
 	fmt.Println()
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/contract"
+)
+
+const lockedProto = `syntax = "proto3";
+package users.v1;
+
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User);
+}
+
+message GetUserRequest { string id = 1; }
+
+message User {
+  string id = 1;
+  string email = 2;
+}
+`
+
+func setupLockedProto(t *testing.T) (contractPath, lockPath string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(".contracts", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	contractPath = filepath.Join(".contracts", "users.proto")
+	lockPath = filepath.Join(".contracts", "users.lock")
+	if err := os.WriteFile(contractPath, []byte(lockedProto), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runContractLockInternal("users", "unknown", contractPath, lockPath, false); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	return contractPath, lockPath
+}
+
+func TestContractLock_ProtoMetadata(t *testing.T) {
+	_, lockPath := setupLockedProto(t)
+	lock, err := contract.ReadLock(lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lock.Metadata.Feature != "users" || lock.Metadata.Endpoints != 1 || lock.Metadata.Schemas != 2 {
+		t.Errorf("metadata = %+v", lock.Metadata)
+	}
+	if got := featureContractPath("users"); got != filepath.Join(".contracts", "users.proto") {
+		t.Errorf("featureContractPath = %s", got)
+	}
+}
+
+func TestRunContractDiff_Proto(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    string
+		new    string
+		passed bool
+	}{
+		{"field added", "string email = 2;", "string email = 2;\n  string name = 3;", true},
+		{"field number reused", "string email = 2;", "int64 age = 2;", false},
+		{"rpc removed", "  rpc GetUser(GetUserRequest) returns (User);\n", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contractPath, lockPath := setupLockedProto(t)
+			changed := strings.Replace(lockedProto, tt.old, tt.new, 1)
+			if err := os.WriteFile(contractPath, []byte(changed), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			passed, err := runContractDiff("", contractPath, lockPath, "")
+			if err != nil {
+				t.Fatalf("runContractDiff: %v", err)
+			}
+			if passed != tt.passed {
+				t.Errorf("passed = %v, want %v", passed, tt.passed)
+			}
+		})
+	}
+}
+
+func TestRunContractGenerateCode_RejectsProto(t *testing.T) {
+	contractPath, lockPath := setupLockedProto(t)
+	err := runContractGenerateCodeInternal(contractPath, lockPath, contract.GeneratedFile{Path: "api/users.gen.ts", Lang: contract.LangTS})
+	if err == nil || !strings.Contains(err.Error(), "protoc") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestVerifyGRPCImplementation(t *testing.T) {
+	contractPath, _ := setupLockedProto(t)
+	files := map[string]string{
+		"go.mod": "module example.com/users\n\ngo 1.21\n",
+		"gen/users.go": "package usersv1\n\ntype UserServiceServer interface {\n\tGetUser(req any) (any, error)\n}\n\n" +
+			"func RegisterUserServiceServer(s any, srv UserServiceServer) {}\n",
+		"server/server.go": "package server\n\nimport pb \"example.com/users/gen\"\n\ntype server struct{}\n\n" +
+			"func (s *server) GetUser(req any) (any, error) { return nil, nil }\n\n" +
+			"func Register(s any) { pb.RegisterUserServiceServer(s, &server{}) }\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ok, err := verifyGRPCImplementation(contractPath, "server"); err != nil || !ok {
+		t.Errorf("verifyGRPCImplementation = %v, %v; want implemented", ok, err)
+	}
+	withDelete := strings.Replace(lockedProto, "returns (User);", "returns (User);\n  rpc DeleteUser(GetUserRequest) returns (User);", 1)
+	if err := os.WriteFile(contractPath, []byte(withDelete), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := verifyGRPCImplementation(contractPath, "server"); err != nil || ok {
+		t.Errorf("verifyGRPCImplementation = %v, %v; want DeleteUser missing", ok, err)
+	}
+	if _, err := verifyGRPCImplementation(filepath.Join(".contracts", "users.yaml"), "server"); err == nil {
+		t.Error("expected error for a non-proto contract")
+	}
+}
@@ -21,10 +21,14 @@ func runContractVerify(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get contract flag: %w", err)
 	}
+	reportPath, err := cmd.Flags().GetString("report")
+	if err != nil {
+		return fmt.Errorf("failed to get report flag: %w", err)
+	}
 
 	// Default contract path from feature name
 	if contractPath == "" && featureName != "" {
-		contractPath = fmt.Sprintf(".contracts/%s.yaml", featureName)
+		contractPath = featureContractPath(featureName)
 	}
 
 	if contractPath == "" {
@@ -40,13 +44,41 @@ func runContractVerify(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if matched {
-		// Exit code 0 for success
-		return nil
+	if !matched {
+		// Changed contracts pass when the policy accepts the semantic diff
+		passed, err := runContractDiff(featureName, contractPath, lockPath, reportPath)
+		if err != nil {
+			return err
+		}
+		if !passed {
+			// Exit code 1 for mismatch
+			return fmt.Errorf("contract mismatch detected")
+		}
+	}
+
+	// Generated code must still match the locked contract
+	inSync, err := verifyGeneratedCode(lockPath)
+	if err != nil {
+		return err
+	}
+	if !inSync {
+		return fmt.Errorf("generated code out of sync with contract lock")
 	}
 
-	// Exit code 1 for mismatch
-	return fmt.Errorf("contract mismatch detected")
+	implDir, err := cmd.Flags().GetString("impl")
+	if err != nil {
+		return fmt.Errorf("failed to get impl flag: %w", err)
+	}
+	if implDir != "" {
+		implemented, err := verifyGRPCImplementation(contractPath, implDir)
+		if err != nil {
+			return err
+		}
+		if !implemented {
+			return fmt.Errorf("gRPC implementation does not match contract")
+		}
+	}
+	return nil
 }
 
 // runContractVerifyInternal implements the core verify logic
@@ -103,8 +135,5 @@ func runContractVerifyInternal(contractPath, lockPath string) (bool, error) {
 	}
 	fmt.Printf("  Expected hash: %s\n", expectedHashDisplay)
 	fmt.Printf("  Actual hash:   %s\n", actualHashDisplay)
-	fmt.Println()
-	fmt.Println("Contract has been modified since lock.")
-	fmt.Println("Please re-lock or restore original contract.")
 	return false, nil
 }
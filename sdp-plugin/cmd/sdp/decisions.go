@@ -27,6 +27,7 @@ and can be queried using this command.`,
 	cmd.AddCommand(decisionsSearchCmd())
 	cmd.AddCommand(decisionsExportCmd())
 	cmd.AddCommand(decisionsLogCmd())
+	cmd.AddCommand(decisionsGraphCmd())
 
 	return cmd
 }
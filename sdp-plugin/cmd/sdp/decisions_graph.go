@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/spf13/cobra"
+)
+
+// decisionsGraphCmd shows reversal chains and contradictions
+func decisionsGraphCmd() *cobra.Command {
+	var export bool
+	var adrDir string
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Show reversal chains and contradicting decisions",
+		Long: `Build the decision graph from the decision log.
+
+Follows --reverses links to mark superseded decisions, and flags active
+decisions with overlapping tags (or related via the memory index, when
+.sdp/memory.db exists) that make conflicting choices. With --export,
+writes each active decision as a numbered ADR for drift validation.`,
+		Example: `  sdp decisions graph
+  sdp decisions graph --export --adr-dir docs/decisions/adr`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := findProjectRoot()
+			if err != nil {
+				return err
+			}
+			logger, err := decision.NewLogger(root)
+			if err != nil {
+				return err
+			}
+			decisions, err := logger.LoadAll()
+			if err != nil {
+				return err
+			}
+			if len(decisions) == 0 {
+				fmt.Println("No decisions found yet")
+				return nil
+			}
+
+			g := decision.BuildGraph(decisions)
+			printDecisionGraph(g, g.Conflicts(memoryRelated(root, g)))
+
+			if !export {
+				return nil
+			}
+			if !filepath.IsAbs(adrDir) {
+				adrDir = filepath.Join(root, adrDir)
+			}
+			written, err := g.ExportADRs(adrDir)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n✓ Exported %d ADR(s) to %s\n", len(written), adrDir)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&export, "export", false, "Write active decisions as numbered ADR files")
+	cmd.Flags().StringVar(&adrDir, "adr-dir", filepath.Join("docs", "decisions", "adr"), "ADR output directory")
+
+	return cmd
+}
+
+// printDecisionGraph prints the graph summary, chains and conflicts
+func printDecisionGraph(g *decision.Graph, conflicts []decision.Conflict) {
+	active := len(g.Active())
+	fmt.Printf("Decisions: %d (%d active, %d superseded)\n", len(g.Nodes), active, len(g.Nodes)-active)
+
+	if chains := g.Chains(); len(chains) > 0 {
+		fmt.Println("\nReversal chains:")
+		for _, chain := range chains {
+			ids := make([]string, len(chain))
+			for i, n := range chain {
+				ids[i] = n.ID
+			}
+			fmt.Printf("  %s\n", strings.Join(ids, " → "))
+			for _, n := range chain {
+				mark := "✓"
+				if n.Status == decision.StatusSuperseded {
+					mark = "✗"
+				}
+				fmt.Printf("    %s %s %s (%s)\n", mark, n.ID, n.Decision.Decision, n.Status)
+			}
+		}
+	}
+
+	for _, ref := range g.Unresolved {
+		fmt.Printf("\n⚠ Unresolved reversal %s\n", ref)
+	}
+
+	if len(conflicts) == 0 {
+		fmt.Println("\n✓ No contradicting active decisions")
+		return
+	}
+	fmt.Printf("\nContradictions (%d):\n", len(conflicts))
+	for _, c := range conflicts {
+		fmt.Printf("  ✗ %s %q vs %s %q\n", c.A.ID, c.A.Decision.Decision, c.B.ID, c.B.Decision.Decision)
+		if len(c.SharedTags) > 0 {
+			fmt.Printf("    Tags: %s\n", strings.Join(c.SharedTags, ", "))
+		}
+		fmt.Printf("    %s\n", c.Reason)
+	}
+	fmt.Println("\nResolve with: sdp decisions log --reverses <ID> ...")
+}
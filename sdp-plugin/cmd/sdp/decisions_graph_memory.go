@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/fall-out-bug/sdp/internal/memory"
+)
+
+const (
+	// maxRelatedKeywords caps the terms in each memory query
+	maxRelatedKeywords = 12
+	// maxRelatedResults caps memory hits considered per decision
+	maxRelatedResults = 10
+)
+
+var adrFileRe = regexp.MustCompile(`^(\d{4})-.*\.md$`)
+
+// memoryRelated links decisions through the memory index: a decision is
+// related to another when searching memory for its question and choice
+// finds the other decision's exported ADR. It returns nil when no memory
+// index exists, so only tag overlap is used.
+func memoryRelated(root string, g *decision.Graph) decision.RelatedFunc {
+	dbPath := filepath.Join(root, ".sdp", "memory.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil
+	}
+	store, err := memory.NewStore(dbPath)
+	if err != nil {
+		return nil
+	}
+	defer store.Close()
+	searcher := memory.NewSearcher(store)
+
+	pairs := make(map[[2]string]bool)
+	for _, n := range g.Active() {
+		query := keywordQuery(n.Decision.Question + " " + n.Decision.Decision)
+		if query == "" {
+			continue
+		}
+		result, err := searcher.Search(query, memory.SearchOptions{Mode: memory.SearchModeSemantic, Limit: maxRelatedResults})
+		if err != nil {
+			continue
+		}
+		for _, a := range result.Artifacts {
+			m := adrFileRe.FindStringSubmatch(filepath.Base(a.Path))
+			if m == nil {
+				continue
+			}
+			num, _ := strconv.Atoi(m[1]) //nolint:errcheck // regex guarantees digits
+			if other := decision.NodeID(num); other != n.ID {
+				pairs[[2]string{n.ID, other}] = true
+				pairs[[2]string{other, n.ID}] = true
+			}
+		}
+	}
+	return func(a, b *decision.Node) bool {
+		return pairs[[2]string{a.ID, b.ID}]
+	}
+}
+
+// keywordQuery builds an FTS OR-query from the keywords in text
+func keywordQuery(text string) string {
+	return strings.Join(memory.Keywords(text, maxRelatedKeywords), " OR ")
+}
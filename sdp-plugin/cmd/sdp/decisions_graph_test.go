@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/fall-out-bug/sdp/internal/memory"
+)
+
+func TestKeywordQuery(t *testing.T) {
+	got := keywordQuery("Which message queue should we use? Use Kafka, Kafka!")
+	if want := "message OR queue OR kafka"; got != want {
+		t.Errorf("keywordQuery = %q, want %q", got, want)
+	}
+	if got := keywordQuery("Go? ok."); got != "" {
+		t.Errorf("keywordQuery = %q, want empty", got)
+	}
+}
+
+func TestMemoryRelated(t *testing.T) {
+	root := t.TempDir()
+	g := decision.BuildGraph([]decision.Decision{
+		{Question: "Which broker for events?", Decision: "Use Kafka"},
+		{Question: "Event transport?", Decision: "Use NATS for events"},
+	})
+	if memoryRelated(root, g) != nil {
+		t.Fatal("expected nil without a memory index")
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	store, err := memory.NewStore(filepath.Join(root, ".sdp", "memory.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	adr := &memory.Artifact{
+		ID:      "adr-2",
+		Path:    "decisions/adr/0002-use-nats-for-events.md",
+		Type:    "doc",
+		Title:   "ADR-0002: Use NATS for events",
+		Content: "Event transport? Use NATS for events",
+	}
+	if err := store.Save(adr); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	related := memoryRelated(root, g)
+	if related == nil {
+		t.Fatal("expected memory-backed related func")
+	}
+	if !related(g.Nodes[0], g.Nodes[1]) || !related(g.Nodes[1], g.Nodes[0]) {
+		t.Error("decisions sharing an indexed ADR topic should be related")
+	}
+	conflicts := g.Conflicts(related)
+	if len(conflicts) != 0 {
+		t.Errorf("related but compatible decisions should not conflict: %+v", conflicts)
+	}
+}
@@ -134,7 +134,7 @@ func decisionsLogCmd() *cobra.Command {
 	cmd.Flags().StringVar(&outcome, "outcome", "", "Expected outcome")
 	cmd.Flags().StringVar(&maker, "maker", "", "Decision maker (user/claude/system)")
 	cmd.Flags().StringSliceVar(&tags, "tags", []string{}, "Tags for categorization")
-	cmd.Flags().StringVar(&reverses, "reverses", "", "ID of previous decision being overturned (AC7)")
+	cmd.Flags().StringVar(&reverses, "reverses", "", "ID of previous decision being overturned, e.g. 3 or ADR-0003 as numbered by 'decisions list' (AC7)")
 
 	return cmd
 }
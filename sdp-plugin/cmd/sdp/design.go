@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
 
 	"github.com/fall-out-bug/sdp/internal/evidence"
@@ -13,12 +15,33 @@ func designCmd() *cobra.Command {
 	var wsCount int
 	var wsID string
 	var metadataPairs []string
+	var panel panelFlags
 
 	cmd := &cobra.Command{
 		Use:   "design",
-		Short: "Design-phase evidence (plan events)",
-		Long:  `Record @design completion: emit plan event with WS count and decomposition metadata.`,
+		Short: "Design-phase evidence and decision panel",
+		Long: `Record @design completion: emit plan event with WS count and decomposition metadata.
+
+With --panel, the question goes to the agent panel configured in
+.sdp/design-panel.yaml; the outcome is recorded as a decision event.
+The panel runs in the sdp-design binary, which must be on PATH.`,
+		Example: `  sdp design record --feature F056 --ws-count 4
+  sdp design --panel -q "Which queue backend?" --option files --option beads`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !panel.enabled {
+				return cmd.Help()
+			}
+			return runDesignPanel(panel)
+		},
 	}
+	cmd.Flags().BoolVar(&panel.enabled, "panel", false, "Ask the configured agent panel for proposals")
+	cmd.Flags().StringVarP(&panel.question, "question", "q", "", "Design question (required with --panel)")
+	cmd.Flags().StringArrayVar(&panel.options, "option", nil, "Candidate option (repeatable)")
+	cmd.Flags().StringVar(&panel.context, "context", "", "Path to a file with background context")
+	cmd.Flags().StringVar(&panel.config, "config", "", "Panel configuration file (default .sdp/design-panel.yaml)")
+	cmd.Flags().StringVar(&panel.wsID, "ws", "", "Workstream ID for the decision event")
+	cmd.Flags().StringArrayVar(&panel.tags, "tag", nil, "Decision tag (repeatable)")
+	cmd.Flags().BoolVar(&panel.json, "json", false, "Print the panel decision as JSON")
 
 	recordCmd := &cobra.Command{
 		Use:   "record",
@@ -55,3 +78,53 @@ func designCmd() *cobra.Command {
 	cmd.AddCommand(recordCmd)
 	return cmd
 }
+
+// panelFlags are the `sdp design --panel` flags forwarded to sdp-design.
+type panelFlags struct {
+	enabled  bool
+	question string
+	options  []string
+	context  string
+	config   string
+	wsID     string
+	tags     []string
+	json     bool
+}
+
+func (p panelFlags) args() []string {
+	args := []string{"design", "--panel", "--question", p.question}
+	for _, o := range p.options {
+		args = append(args, "--option", o)
+	}
+	for _, kv := range [][2]string{{"--context", p.context}, {"--config", p.config}, {"--ws", p.wsID}} {
+		if kv[1] != "" {
+			args = append(args, kv[0], kv[1])
+		}
+	}
+	for _, t := range p.tags {
+		args = append(args, "--tag", t)
+	}
+	if p.json {
+		args = append(args, "--json")
+	}
+	return args
+}
+
+// runDesignPanel runs the panel through the sdp-design binary.
+func runDesignPanel(p panelFlags) error {
+	if strings.TrimSpace(p.question) == "" {
+		return fmt.Errorf("--question is required with --panel")
+	}
+	bin, err := exec.LookPath("sdp-design")
+	if err != nil {
+		return fmt.Errorf("sdp-design not found on PATH: install it with `go install github.com/fall-out-bug/sdp/cmd/sdp-design@latest`")
+	}
+	c := exec.Command(bin, p.args()...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("design panel: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDesignPanel_ForwardsToSdpDesign(t *testing.T) {
+	bin := t.TempDir()
+	out := filepath.Join(bin, "args")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + out + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "sdp-design"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cmd := designCmd()
+	cmd.SetArgs([]string{"--panel", "-q", "Which queue?", "--option", "files", "--option", "beads", "--ws", "00-026-01", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("design --panel: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "design\n--panel\n--question\nWhich queue?\n--option\nfiles\n--option\nbeads\n--ws\n00-026-01\n--json\n"
+	if string(data) != want {
+		t.Errorf("forwarded args = %q, want %q", data, want)
+	}
+}
+
+func TestDesignPanel_Errors(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	cmd := designCmd()
+	cmd.SetArgs([]string{"--panel"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--question") {
+		t.Errorf("missing question: err = %v", err)
+	}
+	cmd = designCmd()
+	cmd.SetArgs([]string{"--panel", "-q", "Which queue?"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "sdp-design not found") {
+		t.Errorf("missing binary: err = %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/fall-out-bug/sdp/internal/drift"
+	"github.com/fall-out-bug/sdp/internal/memory"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +26,7 @@ workstream descriptions match actual codebase reality.`,
 }
 
 func driftDetectCmd() *cobra.Command {
+	var save bool
 	cmd := &cobra.Command{
 		Use:   "detect <ws-id>",
 		Short: "Detect drift for a workstream",
@@ -33,10 +35,14 @@ func driftDetectCmd() *cobra.Command {
 Parses the workstream markdown file and checks:
   - All files in scope exist (ERROR if missing)
   - Files contain expected entities (WARNING if empty)
+  - Symbols declared as inline code in Goal/AC (e.g. ` + "`func Hello() string`" + `)
+    exist with the declared signature (ERROR if missing or changed,
+    WARNING if apparently renamed)
   - Generates actionable recommendations
 
 Example:
-  sdp drift detect 00-050-01`,
+  sdp drift detect 00-050-01
+  sdp drift detect 00-050-01 --save`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			wsID := args[0]
@@ -62,22 +68,53 @@ Example:
 				return fmt.Errorf("failed to detect drift: %w", err)
 			}
 
-			// Print report
+			// Check declared symbols against the code
+			specReport, err := detector.DetectSpecDrift(wsPath)
+			if err != nil {
+				return fmt.Errorf("failed to detect spec drift: %w", err)
+			}
+
+			// Print reports
 			fmt.Println(report.String())
+			fmt.Println(specReport.String())
+
+			if save {
+				if err := saveSpecDriftReport(projectRoot, specReport); err != nil {
+					return err
+				}
+			}
 
 			// Exit with error if verdict is FAIL
 			if report.Verdict == "FAIL" {
 				return fmt.Errorf("drift detected - %d error(s), %d warning(s)",
 					countDriftErrors(report), countDriftWarnings(report))
 			}
+			if specReport.Verdict == drift.VerdictFail {
+				return fmt.Errorf("spec drift detected - declared symbols do not match code")
+			}
 
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&save, "save", false, "Store the spec drift report in project memory (.sdp/memory.db)")
+
 	return cmd
 }
 
+// saveSpecDriftReport stores the report in the project memory database.
+func saveSpecDriftReport(projectRoot string, report *drift.EnhancedDriftReport) error {
+	store, err := memory.NewStore(filepath.Join(projectRoot, ".sdp", "memory.db"))
+	if err != nil {
+		return fmt.Errorf("failed to open memory store: %w", err)
+	}
+	defer store.Close()
+	if err := memory.NewDriftAdapter(store).SaveEnhancedDriftReport(report); err != nil {
+		return fmt.Errorf("failed to save drift report: %w", err)
+	}
+	return nil
+}
+
 // findDriftProjectRoot finds the project root by looking for .beads or docs directory
 func findDriftProjectRoot() (string, error) {
 	cwd, err := os.Getwd()
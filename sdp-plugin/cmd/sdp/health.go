@@ -11,7 +11,7 @@ import (
 
 // healthCmd returns the health command
 func healthCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "health",
 		Short: "Check SDP system health",
 		Long: `Check SDP system health status.
@@ -22,6 +22,9 @@ Verifies:
   - Git configuration
   - Essential directories
 
+Also shows open circuit breakers and active degraded mode
+(.sdp/state/); clear them with 'sdp health reset'.
+
 Exit codes:
   0 - All checks passed
   1 - Some checks failed`,
@@ -55,6 +58,7 @@ Exit codes:
 				}
 				fmt.Printf("%s %s: %s\n", icon, check.name, message)
 			}
+			printBreakerState(root)
 
 			fmt.Println()
 			if allPassed {
@@ -64,6 +68,8 @@ Exit codes:
 			return fmt.Errorf("some health checks failed")
 		},
 	}
+	cmd.AddCommand(healthResetCmd())
+	return cmd
 }
 
 func checkSDPDir(root string) (bool, string) {
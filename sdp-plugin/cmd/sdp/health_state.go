@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/breakerstate"
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// printBreakerState prints circuits and degraded mode. Open circuits are
+// reported, not failed: they close on their own once the provider recovers.
+func printBreakerState(root string) {
+	store := breakerstate.NewStore(root)
+	circuits, err := store.Circuits()
+	if err != nil {
+		fmt.Printf("! Circuits: unreadable (%v)\n", err)
+	}
+	for _, c := range circuits {
+		if c.IsOpen() {
+			fmt.Printf("! Circuit %s: %s (%d failures, last %s)\n", c.Target, c.State, c.Failures, c.LastFailure.Local().Format(time.RFC3339))
+		} else {
+			fmt.Printf("✓ Circuit %s: %s\n", c.Target, c.State)
+		}
+	}
+
+	d, err := store.Degraded()
+	switch {
+	case err != nil:
+		fmt.Printf("! Degraded mode: unreadable (%v)\n", err)
+	case d.Active:
+		fmt.Printf("! Degraded mode: active since %s (%s)\n", d.Since.Local().Format(time.RFC3339), d.Reason)
+	}
+	if slices.ContainsFunc(circuits, breakerstate.Circuit.IsOpen) || d.Active {
+		fmt.Println("  Run 'sdp health reset' to clear breaker state.")
+	}
+}
+
+// resetBreakerState closes the given targets (every circuit when all is set)
+// and, if degraded is set, clears degraded mode. Each reset takes the state
+// file's lock. Returns the targets reset.
+func resetBreakerState(root string, targets []string, all, degraded bool) ([]string, error) {
+	store := breakerstate.NewStore(root)
+	circuits, err := store.Circuits()
+	if err != nil {
+		return nil, err
+	}
+	var reset []string
+	for _, c := range circuits {
+		if !all && !slices.Contains(targets, c.Target) {
+			continue
+		}
+		if err := store.ResetCircuit(c.Target); err != nil {
+			return reset, err
+		}
+		reset = append(reset, c.Target)
+	}
+	if degraded {
+		if err := store.ResetDegraded(); err != nil {
+			return reset, err
+		}
+	}
+	return reset, nil
+}
+
+// healthResetCmd implements "sdp health reset".
+func healthResetCmd() *cobra.Command {
+	var all bool
+	var degraded bool
+
+	cmd := &cobra.Command{
+		Use:   "reset [target...]",
+		Short: "Close circuit breakers and clear degraded mode",
+		Long: `Close persisted circuit breakers (e.g. model:claude-opus, ci:github)
+and optionally clear degraded mode, so the next run calls the provider again.`,
+		Example: `  # Close one circuit
+  sdp health reset model:claude-opus
+
+  # Close every circuit and clear degraded mode
+  sdp health reset --all`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !all && !degraded {
+				return fmt.Errorf("specify a target, --all or --degraded")
+			}
+			root, err := config.FindProjectRoot()
+			if err != nil {
+				return fmt.Errorf("failed to find project root: %w", err)
+			}
+			reset, err := resetBreakerState(root, args, all, degraded || all)
+			if err != nil {
+				return fmt.Errorf("reset breaker state: %w", err)
+			}
+			for _, target := range reset {
+				fmt.Printf("✓ Circuit %s closed\n", target)
+			}
+			if degraded || all {
+				fmt.Println("✓ Degraded mode cleared")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Close every circuit and clear degraded mode")
+	cmd.Flags().BoolVar(&degraded, "degraded", false, "Clear degraded mode")
+	return cmd
+}
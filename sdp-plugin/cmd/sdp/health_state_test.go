@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/breakerstate"
+)
+
+func writeBreakerFixture(t *testing.T, root, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, breakerstate.Dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResetBreakerState(t *testing.T) {
+	root := t.TempDir()
+	writeBreakerFixture(t, root, "circuit-model_claude-opus-9665e2f5.json", `{"target":"model:claude-opus","state":"open"}`)
+	writeBreakerFixture(t, root, "circuit-ci_github-2e3074eb.json", `{"target":"ci:github","state":"open"}`)
+	writeBreakerFixture(t, root, "degraded.json", `{"active":true,"reason":"github unavailable"}`)
+
+	reset, err := resetBreakerState(root, []string{"ci:github"}, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reset) != 1 || reset[0] != "ci:github" {
+		t.Errorf("expected only ci:github reset, got %v", reset)
+	}
+	store := breakerstate.NewStore(root)
+	if d, _ := store.Degraded(); !d.Active {
+		t.Error("degraded mode must stay active without --degraded")
+	}
+
+	reset, err = resetBreakerState(root, nil, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reset) != 1 || reset[0] != "model:claude-opus" {
+		t.Errorf("expected remaining circuit reset, got %v", reset)
+	}
+	if circuits, _ := store.Circuits(); len(circuits) != 0 {
+		t.Errorf("expected no circuits after --all, got %+v", circuits)
+	}
+	if d, _ := store.Degraded(); d.Active {
+		t.Error("expected degraded mode cleared")
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/issues"
+	"github.com/fall-out-bug/sdp/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func issuesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "issues",
+		Short: "Sync workstreams with GitHub Issues or GitLab issues",
+	}
+	cmd.AddCommand(issuesSyncCmd())
+	return cmd
+}
+
+func issuesSyncCmd() *cobra.Command {
+	var tracker, repo, apiURL, onConflict string
+	var dryRun, full, asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Two-way sync between workstreams and tracker issues",
+		Long: `Map every workstream to a tracker issue and sync them both ways.
+
+  status, labels, assignee   both ways (frontmatter status/labels/assignee,
+                             issue state plus a status:<s> label)
+  title, goal, depends_on    workstream to issue; dependencies become a
+                             task list in an SDP-owned part of the body
+
+Links live in .sdp-issues-mapping.jsonl and the updated-since cursor in
+.sdp/issues-cursor.json; commit both. Finished workstreams without an issue
+are skipped. A field changed on both sides since the last sync is resolved
+by --on-conflict (issues.on_conflict):
+  manual  leave both sides and report the conflict (default)
+  local   keep the workstream value
+  remote  keep the issue value
+  newer   keep the side modified last
+
+Tokens come from GITHUB_TOKEN (or GH_TOKEN) and GITLAB_TOKEN.`,
+		Example: `  sdp issues sync --tracker github --repo acme/app --dry-run
+  sdp issues sync --on-conflict remote
+  sdp issues sync --full --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := queueRoot()
+			cfg, err := config.Load(root)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if tracker == "" {
+				tracker = cfg.Issues.Tracker
+			}
+			if repo == "" {
+				repo = cfg.Issues.Repo
+			}
+			if apiURL == "" {
+				apiURL = cfg.Issues.URL
+			}
+			if onConflict == "" {
+				onConflict = cfg.Issues.OnConflict
+			}
+			rule, err := issues.ParseRule(onConflict)
+			if err != nil {
+				return err
+			}
+			tr, err := newIssueTracker(tracker, repo, apiURL)
+			if err != nil {
+				return err
+			}
+			s := &issues.Syncer{Root: root, Tracker: tr, Key: tracker + ":" + repo, Rule: rule, DryRun: dryRun, Full: full}
+			report, err := s.Sync()
+			if report != nil {
+				if asJSON {
+					if jerr := writeQueueJSON(cmd, report); jerr != nil {
+						return jerr
+					}
+				} else {
+					printIssuesReport(report, dryRun)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("sync failed: %w", err)
+			}
+			if manual := countManualConflicts(report); manual > 0 {
+				return fmt.Errorf("%d conflict(s) need resolution: edit one side to match or rerun with --on-conflict", manual)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&tracker, "tracker", "", "Override issues.tracker (github, gitlab)")
+	cmd.Flags().StringVar(&repo, "repo", "", "Override issues.repo (owner/name or GitLab project path)")
+	cmd.Flags().StringVar(&apiURL, "url", "", "Override issues.url (API base URL)")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", "", "Override issues.on_conflict (manual, local, remote, newer)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would change without writing")
+	cmd.Flags().BoolVar(&full, "full", false, "Fetch all issues, ignoring the updated-since cursor")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output the report as JSON")
+	return cmd
+}
+
+// newIssueTracker builds the REST client for a tracker with its token
+// from the environment
+func newIssueTracker(tracker, repo, apiURL string) (issues.Tracker, error) {
+	if repo == "" {
+		return nil, fmt.Errorf("no repository: set issues.repo in .sdp/config.yml or pass --repo")
+	}
+	switch tracker {
+	case "github":
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			token = os.Getenv("GH_TOKEN")
+		}
+		if token == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN is not set")
+		}
+		return issues.NewGitHub(apiURL, repo, token), nil
+	case "gitlab":
+		token := os.Getenv("GITLAB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITLAB_TOKEN is not set")
+		}
+		return issues.NewGitLab(apiURL, repo, token), nil
+	case "":
+		return nil, fmt.Errorf("no tracker: set issues.tracker in .sdp/config.yml or pass --tracker")
+	}
+	return nil, fmt.Errorf("unknown tracker %q (valid: github, gitlab)", tracker)
+}
+
+func printIssuesReport(r *issues.Report, dryRun bool) {
+	title := "Issue sync"
+	if dryRun {
+		title += " (dry run)"
+	}
+	ui.Header(title)
+	for _, group := range []struct {
+		label string
+		ids   []string
+	}{
+		{"Created", r.Created},
+		{"Pushed", r.Pushed},
+		{"Pulled", r.Pulled},
+		{"Skipped (finished, no issue)", r.Skipped},
+	} {
+		if len(group.ids) == 0 {
+			continue
+		}
+		fmt.Printf("  %s:\n", group.label)
+		for _, id := range group.ids {
+			fmt.Printf("    • %s\n", id)
+		}
+	}
+	for _, c := range r.Conflicts {
+		fmt.Printf("  Conflict %s %s: local %q, remote %q -> %s\n", c.WSID, c.Field, c.Local, c.Remote, c.Resolved)
+	}
+	ui.InfoLine("Fetched %d issue(s); cursor %s", r.Fetched, orDash(formatCursor(r)))
+	if len(r.Created)+len(r.Pushed)+len(r.Pulled)+len(r.Conflicts) == 0 {
+		ui.SuccessLine("Workstreams and issues are in sync")
+	}
+}
+
+func formatCursor(r *issues.Report) string {
+	if r.Cursor.IsZero() {
+		return ""
+	}
+	return r.Cursor.Format("2006-01-02 15:04:05Z07:00")
+}
+
+func countManualConflicts(r *issues.Report) int {
+	n := 0
+	for _, c := range r.Conflicts {
+		if c.Resolved == string(issues.RuleManual) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/issues"
+)
+
+func runIssues(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := issuesCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestIssuesSync_GitHub(t *testing.T) {
+	var created []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`[]`))
+		case http.MethodPost:
+			var body struct {
+				Title string `json:"title"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body.Title)
+			_, _ = w.Write([]byte(`{"number":5,"state":"open","html_url":"https://github.test/acme/app/issues/5","updated_at":"2026-10-18T00:00:00Z"}`))
+		default:
+			http.Error(w, "unexpected", http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	wsDir := filepath.Join("docs", "workstreams", "backlog")
+	if err := os.MkdirAll(wsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(".git", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ws := "---\nws_id: 00-050-01\nfeature_id: F050\nstatus: backlog\n---\n\n# 00-050-01: Tracker sync\n"
+	if err := os.WriteFile(filepath.Join(wsDir, "00-050-01.md"), []byte(ws), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_TOKEN", "tok")
+
+	out, err := runIssues(t, "sync", "--tracker", "github", "--repo", "acme/app", "--url", srv.URL, "--json")
+	if err != nil {
+		t.Fatalf("sync: %v\n%s", err, out)
+	}
+	var report issues.Report
+	if err := json.Unmarshal([]byte(out), &report); err != nil || strings.Join(report.Created, ",") != "00-050-01" {
+		t.Fatalf("report = %s (%v)", out, err)
+	}
+	if len(created) != 1 || created[0] != "00-050-01: Tracker sync" {
+		t.Errorf("created = %v", created)
+	}
+	data, err := os.ReadFile(issues.MappingFile)
+	if err != nil || !strings.Contains(string(data), `"tracker":"github:acme/app","issue_number":5`) {
+		t.Errorf("mapping = %s, %v", data, err)
+	}
+}
+
+func TestIssuesSync_Errors(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.Mkdir(".git", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"sync"}, "no repository"},
+		{[]string{"sync", "--repo", "acme/app"}, "no tracker"},
+		{[]string{"sync", "--repo", "acme/app", "--tracker", "jira"}, "unknown tracker"},
+		{[]string{"sync", "--repo", "acme/app", "--tracker", "github"}, "GITHUB_TOKEN"},
+		{[]string{"sync", "--repo", "acme/app", "--tracker", "github", "--on-conflict", "mine"}, "unknown conflict rule"},
+	} {
+		if _, err := runIssues(t, tc.args...); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%v: err = %v, want %q", tc.args, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+
+	"github.com/fall-out-bug/sdp/internal/lsp"
+	"github.com/spf13/cobra"
+)
+
+func lspCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a language server for workstream and spec markdown",
+		Long: `Run a Language Server Protocol server on stdin/stdout.
+
+Editors get, for docs/workstreams/**/*.md and other SDP markdown:
+  - diagnostics from 'sdp parse --validate' checks, unknown or malformed
+    depends_on IDs and dependency cycles
+  - completion of workstream IDs (depends_on), feature IDs (feature_id)
+    and project files (scope files)
+  - hover with a workstream's status, goal and dependencies, or a
+    feature's workstreams
+  - go-to-definition from workstream IDs to their files
+
+The server indexes the workspace root sent by the editor, falling back to
+the git root of the current directory.`,
+		Example: `  sdp lsp --stdio`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := findProjectRoot()
+			if err != nil {
+				if root, err = os.Getwd(); err != nil {
+					return err
+				}
+			}
+			return lsp.NewServer(root, version).Serve(cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	// Editors commonly pass --stdio; it is the only transport
+	cmd.Flags().Bool("stdio", true, "Communicate over stdin/stdout")
+	return cmd
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLspCmd_InitializeAndShutdown(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var in strings.Builder
+	for _, body := range []string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	} {
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+
+	cmd := lspCmd()
+	var out bytes.Buffer
+	cmd.SetIn(strings.NewReader(in.String()))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--stdio"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("lsp: %v", err)
+	}
+	if !strings.Contains(out.String(), `"serverInfo":{"name":"sdp"`) || !strings.Contains(out.String(), `"id":2,"result":null`) {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+}
@@ -162,10 +162,13 @@ directory (.claude/, .cursor/, .opencode/, or .codex/).`,
 	rootCmd.AddCommand(planCmd())
 	rootCmd.AddCommand(applyCmd())
 	rootCmd.AddCommand(beadsCmd())
+	rootCmd.AddCommand(queueCmd())
+	rootCmd.AddCommand(issuesCmd())
 	rootCmd.AddCommand(buildCmd())
 	rootCmd.AddCommand(tddCmd())
 	rootCmd.AddCommand(driftCmd())
 	rootCmd.AddCommand(qualityCmd())
+	rootCmd.AddCommand(realityCmd())
 	rootCmd.AddCommand(watchCmd())
 	rootCmd.AddCommand(telemetryCmd)
 	rootCmd.AddCommand(checkpointCmd)
@@ -180,6 +183,7 @@ directory (.claude/, .cursor/, .opencode/, or .codex/).`,
 	rootCmd.AddCommand(sessionCmd())
 	rootCmd.AddCommand(gitCmd())
 	rootCmd.AddCommand(memoryCmd())
+	rootCmd.AddCommand(lspCmd())
 	rootCmd.AddCommand(coordinationCmd())
 	rootCmd.AddCommand(healthCmd())
 	rootCmd.AddCommand(diagnoseCmd)
@@ -16,12 +16,14 @@ provides hybrid search capabilities (full-text + semantic).
 Examples:
   sdp memory index              # Index all docs/ artifacts
   sdp memory search "API"       # Search for "API" in artifacts
-  sdp memory stats              # Show memory statistics`,
+  sdp memory stats              # Show memory statistics
+  sdp memory lessons --goal "..." # Past failures related to a goal`,
 	}
 
 	cmd.AddCommand(memoryIndexCmd())
 	cmd.AddCommand(memorySearchCmd())
 	cmd.AddCommand(memoryStatsCmd())
+	cmd.AddCommand(memoryLessonsCmd())
 
 	return cmd
 }
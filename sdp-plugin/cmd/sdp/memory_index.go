@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/fall-out-bug/sdp/internal/evidence"
 	"github.com/fall-out-bug/sdp/internal/memory"
 	"github.com/spf13/cobra"
 )
@@ -16,7 +17,8 @@ func memoryIndexCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "index",
 		Short: "Index project artifacts",
-		Long: `Index all markdown files in the docs/ directory.
+		Long: `Index all markdown files in the docs/ directory, plus evidence
+log events so failed lessons can be found by "sdp memory lessons".
 
 Stores indexed artifacts in SQLite database (.sdp/memory.db) with
 full-text search capabilities.
@@ -70,6 +72,17 @@ based on SHA256 hash comparison.`,
 				fmt.Printf("  Errors: %d\n", stats.Errors)
 			}
 
+			// Import evidence events so lessons are searchable
+			if logPath, err := evidenceLogPath(); err == nil {
+				if events, err := evidence.NewReader(logPath).ReadAll(); err == nil && len(events) > 0 {
+					imported, err := indexer.ImportEvidence(events)
+					if err != nil {
+						return fmt.Errorf("evidence import failed: %w", err)
+					}
+					fmt.Printf("  Evidence events: %d\n", imported)
+				}
+			}
+
 			return nil
 		},
 	}
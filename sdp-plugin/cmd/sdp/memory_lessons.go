@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/fall-out-bug/sdp/internal/memory"
+	"github.com/spf13/cobra"
+)
+
+func memoryLessonsCmd() *cobra.Command {
+	var goal, dbPath string
+	var files []string
+	var limit int
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "lessons",
+		Short: "Find past failures relevant to a goal",
+		Long: `Find lessons with failed or mixed outcomes related to a goal and its
+scope files.
+
+Combines hybrid memory search (run 'sdp memory index' to include evidence
+lessons) with failed decisions from the decision log. Used by context
+hydration to warn agents about mistakes already recorded.`,
+		Example: `  sdp memory lessons --goal "Add retry to webhook delivery" --file internal/webhook/send.go
+  sdp memory lessons --goal "..." --limit 3 --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if goal == "" && len(files) == 0 {
+				return fmt.Errorf("required flags: --goal or --file")
+			}
+			if dbPath == "" {
+				dbPath = ".sdp/memory.db"
+			}
+			if !filepath.IsAbs(dbPath) {
+				cwd, _ := os.Getwd()
+				dbPath = filepath.Join(cwd, dbPath)
+			}
+
+			var searcher *memory.Searcher
+			if _, err := os.Stat(dbPath); err == nil {
+				store, err := memory.NewStore(dbPath)
+				if err != nil {
+					return fmt.Errorf("failed to open store: %w", err)
+				}
+				defer store.Close()
+				searcher = memory.NewSearcher(store)
+			}
+
+			var decisions []decision.Decision
+			if root, err := findProjectRoot(); err == nil {
+				if logger, err := decision.NewLogger(root); err == nil {
+					decisions, _ = logger.LoadAll() //nolint:errcheck // decisions are optional
+				}
+			}
+
+			lessons := memory.FindLessons(searcher, decisions, memory.LessonQuery{Goal: goal, Files: files, Limit: limit})
+			if asJSON {
+				if lessons == nil {
+					lessons = []memory.Lesson{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(lessons)
+			}
+
+			if len(lessons) == 0 {
+				fmt.Println("No prior lessons found")
+				return nil
+			}
+			fmt.Printf("Found %d prior lesson(s):\n\n", len(lessons))
+			for i, l := range lessons {
+				fmt.Printf("%d. [%s] %s\n", i+1, l.Outcome, l.Title)
+				fmt.Printf("   Source: %s (%s)\n", l.Ref, l.Source)
+				if l.Summary != "" {
+					fmt.Printf("   %s\n", l.Summary)
+				}
+				fmt.Println()
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&goal, "goal", "", "Goal of the planned work")
+	cmd.Flags().StringSliceVar(&files, "file", nil, "Scope file (repeatable)")
+	cmd.Flags().IntVarP(&limit, "limit", "n", memory.DefaultLessonLimit, "Maximum lessons to return")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output lessons as JSON")
+	cmd.Flags().StringVar(&dbPath, "db", "", "Database path (default: .sdp/memory.db)")
+
+	return cmd
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/fall-out-bug/sdp/internal/memory"
+)
+
+func TestMemoryLessonsCmd_JSON(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	logger, err := decision.NewLogger(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := logger.Log(decision.Decision{Question: "Retry webhook delivery inline?", Decision: "Inline", Outcome: "failed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := memoryLessonsCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--goal", "Add webhook retry", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("lessons: %v", err)
+	}
+	var lessons []memory.Lesson
+	if err := json.Unmarshal(out.Bytes(), &lessons); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if len(lessons) != 1 || lessons[0].Ref != "docs/decisions/decisions.jsonl#1" {
+		t.Errorf("lessons = %+v", lessons)
+	}
+
+	cmd = memoryLessonsCmd()
+	cmd.SetArgs([]string{})
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error without --goal or --file")
+	}
+}
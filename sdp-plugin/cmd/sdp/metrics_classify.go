@@ -48,6 +48,7 @@ func metricsClassifyCmd() *cobra.Command {
 					metrics.FailureTestPassingWrong: true,
 					metrics.FailureCompilationError: true,
 					metrics.FailureImportError:      true,
+					metrics.FailureFlakyTest:        true,
 				}
 				if !validTypes[failureType] {
 					return fmt.Errorf("invalid failure type: %s\nValid types: %v", failureType, getValidTypes())
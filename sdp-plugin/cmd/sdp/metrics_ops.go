@@ -18,6 +18,7 @@ func getValidTypes() []string {
 		"test_passing_but_wrong",
 		"compilation_error",
 		"import_error",
+		"flaky_test",
 	}
 }
 
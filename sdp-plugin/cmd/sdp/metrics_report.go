@@ -45,6 +45,7 @@ func metricsReportCmd() *cobra.Command {
 
 			// Create reporter
 			reporter := metrics.NewReporter(metricsPath, taxonomyPath)
+			reporter.SetModelStatsPath(".sdp/model-stats.json")
 
 			// Generate report based on format
 			var report string
@@ -12,6 +12,9 @@ var (
 	runQualitySizeCmd       = runQualitySize
 	runQualityTypesCmd      = runQualityTypes
 	runQualityAllCmd        = runQualityAll
+	runQualityMutateCmd     = runQualityMutate
+	runQualityFlakyCmd      = runQualityFlaky
+	runQualityArchCmd       = runQualityArch
 )
 
 func qualityCmd() *cobra.Command {
@@ -24,9 +27,12 @@ func qualityCmd() *cobra.Command {
 
 Checks include:
   coverage   - Test coverage analysis (≥80% required)
-  complexity - Cyclomatic complexity analysis (<10 required)
+  complexity - Cyclomatic/cognitive complexity analysis (<10 required)
   size       - File size analysis (<200 LOC required)
   types      - Type checking (mypy, go vet, etc.)
+  mutate     - Mutation testing of a workstream's Go scope files
+  flaky      - Flaky test history and quarantine list
+  arch       - Import layering against .sdp/architecture.yml
   all        - Run all quality checks
 
 Pragmatic Mode (default):
@@ -39,21 +45,39 @@ Strict Mode (--strict):
 	// Add persistent flag for strict mode (applies to all subcommands)
 	cmd.PersistentFlags().BoolVar(&strict, "strict", false, "Enable strict quality gates (file size violations = errors)")
 
-	cmd.AddCommand(&cobra.Command{
+	var patchBase string
+	var patchScope []string
+	coverageCmd := &cobra.Command{
 		Use:   "coverage",
 		Short: "Check test coverage",
+		Long: `Check test coverage.
+
+With --base, the gate applies to patch coverage instead: only lines added
+or changed since the merge base with that ref count, read from coverage.out,
+coverage.xml (Cobertura) or coverage.json (coverage.py).`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runQualityCoverageCmd(strict)
+			return runQualityCoverageCmd(strict, patchBase, patchScope)
 		},
-	})
+	}
+	coverageCmd.Flags().StringVar(&patchBase, "base", "", "Gate on coverage of lines changed since the merge base with this ref")
+	coverageCmd.Flags().StringSliceVar(&patchScope, "scope", nil, "Limit patch coverage to these files or directories")
+	cmd.AddCommand(coverageCmd)
 
-	cmd.AddCommand(&cobra.Command{
+	var complexityBase string
+	complexityCmd := &cobra.Command{
 		Use:   "complexity",
-		Short: "Check cyclomatic complexity",
+		Short: "Check cyclomatic and cognitive complexity",
+		Long: `Check cyclomatic and cognitive complexity.
+
+Go projects are analysed in-process per function. With --base, only
+functions changed since the merge base with that ref can fail the gate,
+and only when they got worse and exceed the threshold.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runQualityComplexityCmd(strict)
+			return runQualityComplexityCmd(strict, complexityBase)
 		},
-	})
+	}
+	complexityCmd.Flags().StringVar(&complexityBase, "base", "", "Compare against the merge base with this ref (Go only)")
+	cmd.AddCommand(complexityCmd)
 
 	cmd.AddCommand(&cobra.Command{
 		Use:   "size",
@@ -71,6 +95,74 @@ Strict Mode (--strict):
 		},
 	})
 
+	var mutate mutateOptions
+	mutateCmd := &cobra.Command{
+		Use:   "mutate",
+		Short: "Run mutation testing on a workstream's scope files",
+		Long: `Run mutation testing on a workstream's scope files.
+
+Go files in the workstream's implementation scope are mutated one change at
+a time (negated and shifted conditionals, swapped arithmetic and logical
+operators, removed statements) and their package tests run against each
+mutant. The score is the share of mutants the tests detect; surviving
+mutants point at untested behaviour. Enable the gate for sdp verify with
+quality.mutation.enabled in .sdp/config.yml.`,
+		RunE: func(c *cobra.Command, args []string) error {
+			ctx := c.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			return runQualityMutateCmd(ctx, mutate)
+		},
+	}
+	mutateCmd.Flags().StringVar(&mutate.wsID, "ws", "", "Workstream ID (required)")
+	mutateCmd.Flags().DurationVar(&mutate.budget, "budget", 0, "Total time budget (default: quality.mutation.budget or 10m)")
+	mutateCmd.Flags().IntVar(&mutate.threshold, "threshold", 0, "Minimum mutation score in percent (default: quality.mutation.threshold or 60)")
+	mutateCmd.Flags().IntVar(&mutate.maxMutants, "max-mutants", 0, "Limit the number of mutants (default: quality.mutation.max_mutants)")
+	_ = mutateCmd.MarkFlagRequired("ws")
+	cmd.AddCommand(mutateCmd)
+
+	var flakyOpts flakyOptions
+	flakyCmd := &cobra.Command{
+		Use:   "flaky",
+		Short: "Show and manage flaky test quarantine",
+		Long: `Show and manage flaky test quarantine.
+
+When quality.flaky.enabled is set (it is off by default), sdp verify,
+sdp tdd and workstream execution re-run failed tests in isolation
+(quality.flaky.reruns times). A test that passes a re-run is
+flaky and does not block; once its failure rate crosses
+quality.flaky.threshold it is quarantined for quality.flaky.quarantine_ttl.
+Quarantined tests are non-blocking until the entry expires. History and
+quarantine live in .sdp/flaky/.
+
+Test IDs are "<import path>.<TestName>" for Go and node IDs for pytest.`,
+		RunE: func(c *cobra.Command, args []string) error {
+			return runQualityFlakyCmd(flakyOpts)
+		},
+	}
+	flakyCmd.Flags().StringVar(&flakyOpts.quarantine, "quarantine", "", "Quarantine a test by ID")
+	flakyCmd.Flags().StringVar(&flakyOpts.release, "release", "", "Release a test from quarantine")
+	flakyCmd.Flags().StringVar(&flakyOpts.reason, "reason", "", "Reason recorded with --quarantine")
+	flakyCmd.Flags().DurationVar(&flakyOpts.ttl, "ttl", 0, "Quarantine duration (default: quality.flaky.quarantine_ttl or 168h)")
+	flakyCmd.Flags().BoolVar(&flakyOpts.prune, "prune", false, "Remove expired quarantine entries")
+	flakyCmd.MarkFlagsMutuallyExclusive("quarantine", "release", "prune")
+	cmd.AddCommand(flakyCmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "arch",
+		Short: "Check imports against the declared architecture layers",
+		Long: `Check imports against the declared architecture layers.
+
+.sdp/architecture.yml assigns packages to layers by path glob and lists the
+layers each layer may import. Go, Python and TypeScript/JavaScript imports
+that cross layers against it fail the check. Use sdp reality arch to infer
+a starting spec from the current import graph.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQualityArchCmd()
+		},
+	})
+
 	cmd.AddCommand(&cobra.Command{
 		Use:   "all",
 		Short: "Run all quality checks",
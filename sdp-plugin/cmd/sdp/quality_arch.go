@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fall-out-bug/sdp/internal/arch"
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+func runQualityArch() error {
+	root, err := config.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("project root: %w", err)
+	}
+	spec, err := arch.LoadSpec(root)
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		fmt.Printf("No architecture spec (%s); run 'sdp reality arch --write' to infer one\n", arch.SpecFile)
+		return nil
+	}
+	violations, err := arch.CheckProject(root, spec)
+	if err != nil {
+		return fmt.Errorf("architecture check failed: %w", err)
+	}
+
+	fmt.Printf("Layers: %d\n", len(spec.Layers))
+	printArchViolations(violations)
+	fmt.Printf("\nStatus: ")
+	if len(violations) > 0 {
+		fmt.Println("✗ FAILED")
+		return fmt.Errorf("quality check failed")
+	}
+	fmt.Println("✓ PASSED")
+	return nil
+}
+
+func printArchViolations(violations []arch.Violation) {
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Printf("\n✗ VIOLATIONS (%d):\n", len(violations))
+	for _, v := range violations {
+		fmt.Printf("  %s:%d: %s\n      %s\n", v.File, v.Line, v.Message(), v.Text)
+	}
+}
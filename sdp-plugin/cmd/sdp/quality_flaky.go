@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/flaky"
+)
+
+// flakyOptions are the flags of `sdp quality flaky`.
+type flakyOptions struct {
+	quarantine string
+	release    string
+	reason     string
+	ttl        time.Duration
+	prune      bool
+}
+
+func runQualityFlaky(opts flakyOptions) error {
+	root, err := config.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("project root: %w", err)
+	}
+	store, err := flaky.Open(root)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	switch {
+	case opts.quarantine != "":
+		ttl := opts.ttl
+		if ttl == 0 {
+			ttl = flaky.DefaultQuarantineTTL
+			if cfg, err := config.Load(root); err == nil && cfg != nil {
+				ttl = config.TimeoutFromConfigOrEnv(cfg.Quality.Flaky.QuarantineTTL, "SDP_FLAKY_QUARANTINE_TTL", ttl)
+			}
+		}
+		reason := opts.reason
+		if reason == "" {
+			reason = "quarantined manually"
+		}
+		store.Quarantine(flaky.Entry{TestID: opts.quarantine, Reason: reason, AddedAt: now.UTC(), ExpiresAt: now.Add(ttl).UTC()})
+		if err := store.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Quarantined %s until %s\n", opts.quarantine, now.Add(ttl).Format(time.RFC3339))
+		return nil
+	case opts.release != "":
+		if !store.Release(opts.release) {
+			return fmt.Errorf("%s is not quarantined", opts.release)
+		}
+		if err := store.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Released %s from quarantine\n", opts.release)
+		return nil
+	case opts.prune:
+		n := store.Prune(now)
+		if err := store.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Removed %d expired quarantine entries\n", n)
+		return nil
+	}
+
+	entries := store.Entries()
+	fmt.Printf("Quarantined tests: %d\n", len(entries))
+	for _, e := range entries {
+		status := "until " + e.ExpiresAt.Local().Format(time.RFC3339)
+		if !e.Active(now) {
+			status = "expired"
+		}
+		fmt.Printf("  %s (%s) — %s\n", e.TestID, status, e.Reason)
+	}
+
+	var flakes []string
+	for _, id := range store.Histories() {
+		if h, _ := store.History(id); h.Classification == flaky.Flaky {
+			p, f := h.Counts()
+			flakes = append(flakes, fmt.Sprintf("  %s — failed %d of %d recent runs", id, f, p+f))
+		}
+	}
+	fmt.Printf("Flaky tests: %d\n", len(flakes))
+	for _, line := range flakes {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// projectFlakeChecker returns a flaky.Checker for the current project, or nil
+// when flaky detection is disabled or unavailable.
+func projectFlakeChecker(source string) *flaky.Checker {
+	root, err := config.FindProjectRoot()
+	if err != nil {
+		if root, err = os.Getwd(); err != nil {
+			return nil
+		}
+	}
+	c, err := flaky.New(root, source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: flaky test detection: %v\n", err)
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/flaky"
+)
+
+func TestRunQualityFlaky(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+
+	if err := runQualityFlaky(flakyOptions{quarantine: "example.com/m/a.TestX", ttl: time.Hour}); err != nil {
+		t.Fatalf("quarantine: %v", err)
+	}
+	store, err := flaky.Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := store.Quarantined("example.com/m/a.TestX", time.Now())
+	if !ok || e.Reason != "quarantined manually" {
+		t.Fatalf("expected quarantine entry, got %+v", e)
+	}
+	if err := runQualityFlaky(flakyOptions{}); err != nil {
+		t.Errorf("list: %v", err)
+	}
+	if err := runQualityFlaky(flakyOptions{release: "example.com/m/a.TestX"}); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if err := runQualityFlaky(flakyOptions{release: "example.com/m/a.TestX"}); err == nil {
+		t.Error("expected error releasing a test that is not quarantined")
+	}
+}
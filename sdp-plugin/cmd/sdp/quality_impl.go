@@ -10,7 +10,7 @@ import (
 	"github.com/fall-out-bug/sdp/internal/quality"
 )
 
-func runQualityCoverage(strict bool) error {
+func runQualityCoverage(strict bool, base string, scope []string) error {
 	projectPath, err := config.FindProjectRoot()
 	if err != nil {
 		var wdErr error
@@ -54,18 +54,48 @@ func runQualityCoverage(strict bool) error {
 		}
 	}
 
+	passed, coverage := result.Passed, result.Coverage
+	if base != "" {
+		patch, err := checker.CheckPatchCoverage(base, scope)
+		if err != nil {
+			return fmt.Errorf("patch coverage check failed: %w", err)
+		}
+		printPatchCoverage(patch)
+		passed, coverage = patch.Passed, patch.Coverage
+	}
+
 	if evidence.Enabled() {
-		if err := evidence.EmitSync(evidence.VerificationEvent("", result.Passed, "coverage", result.Coverage)); err != nil {
+		if err := evidence.EmitSync(evidence.VerificationEvent("", passed, "coverage", coverage)); err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "warning: evidence emit: %v\n", err)
 		}
 	}
-	if !result.Passed {
+	if !passed {
 		return fmt.Errorf("quality check failed")
 	}
 	return nil
 }
 
-func runQualityComplexity(strict bool) error {
+func printPatchCoverage(patch *quality.PatchCoverageResult) {
+	base := patch.Base
+	if len(base) > 12 {
+		base = base[:12]
+	}
+	fmt.Printf("\nPatch coverage since %s: %.1f%% (%d/%d changed lines, threshold: %.1f%%) ",
+		base, patch.Coverage, patch.Covered, patch.Total, patch.Threshold)
+	if patch.Passed {
+		fmt.Println("✓")
+	} else {
+		fmt.Println("✗")
+	}
+	for _, f := range patch.Files {
+		if len(f.Uncovered) == 0 {
+			continue
+		}
+		fmt.Printf("  %s: %d/%d covered, uncovered lines %v\n", f.File, f.Covered, f.Total, f.Uncovered)
+	}
+}
+
+func runQualityComplexity(strict bool, base string) error {
 	projectPath, err := config.FindProjectRoot()
 	if err != nil {
 		var wdErr error
@@ -82,6 +112,7 @@ func runQualityComplexity(strict bool) error {
 		return fmt.Errorf("failed to create checker: %w", err)
 	}
 	checker.SetStrictMode(strict)
+	checker.SetComplexityBase(base)
 
 	result, err := checker.CheckComplexity()
 	if err != nil {
@@ -90,6 +121,9 @@ func runQualityComplexity(strict bool) error {
 
 	fmt.Printf("Average CC: %.1f\n", result.AverageCC)
 	fmt.Printf("Max CC: %d\n", result.MaxCC)
+	if result.MaxCognitive > 0 {
+		fmt.Printf("Max cognitive: %d\n", result.MaxCognitive)
+	}
 	fmt.Printf("Threshold: %d\n", result.Threshold)
 	fmt.Printf("Status: ")
 	if result.Passed {
@@ -107,8 +141,16 @@ func runQualityComplexity(strict bool) error {
 		fmt.Printf("\n%d files exceed threshold:\n", len(result.ComplexFiles))
 		for _, f := range result.ComplexFiles {
 			fmt.Printf("  %s: CC %.1f (max: %d)\n", f.File, f.AverageCC, f.MaxCC)
+			for _, fn := range f.Functions {
+				if fn.Cyclomatic > result.Threshold {
+					fmt.Printf("    %s:%d %s: CC %d, cognitive %d\n", f.File, fn.Line, fn.Name, fn.Cyclomatic, fn.Cognitive)
+				}
+			}
 		}
 	}
+	if result.Base != "" {
+		printComplexityDeltas(result)
+	}
 
 	if !result.Passed {
 		return fmt.Errorf("quality check failed")
@@ -117,6 +159,29 @@ func runQualityComplexity(strict bool) error {
 	return nil
 }
 
+func printComplexityDeltas(result *quality.ComplexityResult) {
+	base := result.Base
+	if len(base) > 12 {
+		base = base[:12]
+	}
+	if len(result.Deltas) == 0 {
+		fmt.Printf("\nNo complexity changes since %s\n", base)
+		return
+	}
+	fmt.Printf("\nComplexity changes since %s:\n", base)
+	for _, d := range result.Deltas {
+		marker := " "
+		if d.Worsened() && d.Cyclomatic > result.Threshold {
+			marker = "✗"
+		}
+		if d.New {
+			fmt.Printf("  %s %s %s: CC %d, cognitive %d (new)\n", marker, d.File, d.Function, d.Cyclomatic, d.Cognitive)
+			continue
+		}
+		fmt.Printf("  %s %s %s: CC %d → %d, cognitive %d → %d\n", marker, d.File, d.Function, d.BaseCyclomatic, d.Cyclomatic, d.BaseCognitive, d.Cognitive)
+	}
+}
+
 func runQualitySize(strict bool) error {
 	projectPath, err := config.FindProjectRoot()
 	if err != nil {
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/mutation"
+	"github.com/fall-out-bug/sdp/internal/parser"
+)
+
+// mutateOptions are the flags of `sdp quality mutate`. Zero values fall back
+// to quality.mutation in .sdp/config.yml.
+type mutateOptions struct {
+	wsID       string
+	budget     time.Duration
+	threshold  int
+	maxMutants int
+}
+
+func runQualityMutate(ctx context.Context, opts mutateOptions) error {
+	projectRoot, err := findDriftProjectRoot()
+	if err != nil {
+		return fmt.Errorf("project root: %w", err)
+	}
+	wsPath, err := findDriftWorkstreamFile(projectRoot, opts.wsID)
+	if err != nil {
+		return err
+	}
+	ws, err := parser.ParseWorkstream(wsPath)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", opts.wsID, err)
+	}
+
+	var section config.MutationSection
+	if cfg, err := config.Load(projectRoot); err == nil && cfg != nil {
+		section = cfg.Quality.Mutation
+	}
+	threshold := opts.threshold
+	if threshold == 0 {
+		threshold = section.Threshold
+	}
+	if threshold == 0 {
+		threshold = 60
+	}
+	budget := opts.budget
+	if budget == 0 {
+		budget = config.TimeoutFromConfigOrEnv(section.Budget, "SDP_MUTATION_BUDGET", 10*time.Minute)
+	}
+	maxMutants := opts.maxMutants
+	if maxMutants == 0 {
+		maxMutants = section.MaxMutants
+	}
+
+	runner := &mutation.Runner{Root: projectRoot, Budget: budget, MaxMutants: maxMutants}
+	report, err := runner.Run(ctx, ws.Scope.Implementation)
+	if err != nil {
+		return fmt.Errorf("mutation testing failed: %w", err)
+	}
+	if len(report.Files) == 0 {
+		fmt.Printf("No Go implementation files in %s scope\n", opts.wsID)
+		return nil
+	}
+
+	fmt.Printf("Workstream: %s\n", opts.wsID)
+	fmt.Printf("Files: %d, mutants: %d (%s)\n", len(report.Files), len(report.Results), report.Duration.Round(time.Second))
+	fmt.Printf("Killed: %d, survived: %d, timed out: %d, invalid: %d, skipped: %d\n",
+		report.Killed, report.Survived, report.TimedOut, report.Invalid, report.Skipped)
+	var passed bool
+	switch {
+	case report.Evaluated() > 0:
+		fmt.Printf("Mutation score: %.1f%% (threshold: %d%%) ", report.Score, threshold)
+		passed = report.Score >= float64(threshold)
+	case len(report.Results) == 0:
+		// Nothing to mutate is not a failure of the tests.
+		fmt.Print("Mutation score: N/A (no mutants evaluated: nothing to mutate) ")
+		passed = true
+	default:
+		fmt.Printf("Mutation score: N/A (no mutants evaluated, threshold: %d%%) ", threshold)
+	}
+	if passed {
+		fmt.Println("✓")
+	} else {
+		fmt.Println("✗")
+	}
+	if survivors := report.Survivors(); len(survivors) > 0 {
+		fmt.Println("\nSurviving mutants:")
+		for _, m := range survivors {
+			fmt.Printf("  %s\n", m)
+		}
+	}
+	if report.Skipped > 0 {
+		fmt.Printf("\n%d mutants skipped: time budget %s exhausted\n", report.Skipped, budget)
+	}
+	if !passed {
+		return fmt.Errorf("quality check failed")
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -17,7 +20,7 @@ func TestQualityCmd(t *testing.T) {
 	}
 
 	// Test subcommands
-	expectedSubcommands := []string{"coverage", "complexity", "size", "types", "all"}
+	expectedSubcommands := []string{"coverage", "complexity", "size", "types", "mutate", "flaky", "arch", "all"}
 	for _, expected := range expectedSubcommands {
 		found := false
 		for _, c := range cmd.Commands() {
@@ -39,7 +42,7 @@ func TestQualityCoverageCmd(t *testing.T) {
 	expectedErr := errors.New("coverage failed")
 
 	originalRunner := runQualityCoverageCmd
-	runQualityCoverageCmd = func(strict bool) error {
+	runQualityCoverageCmd = func(strict bool, base string, scope []string) error {
 		called = true
 		gotStrict = strict
 		return expectedErr
@@ -103,6 +106,68 @@ func TestQualityComplexityCmd(t *testing.T) {
 	}
 }
 
+// TestQualityMutateCmd tests flag wiring of the quality mutate command
+func TestQualityMutateCmd(t *testing.T) {
+	var got mutateOptions
+	originalRunner := runQualityMutateCmd
+	runQualityMutateCmd = func(ctx context.Context, opts mutateOptions) error {
+		got = opts
+		return nil
+	}
+	t.Cleanup(func() {
+		runQualityMutateCmd = originalRunner
+	})
+
+	cmd := qualityCmd()
+	cmd.SetArgs([]string{"mutate", "--ws", "00-001-01", "--budget", "2m", "--threshold", "70", "--max-mutants", "50"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("quality mutate: %v", err)
+	}
+	want := mutateOptions{wsID: "00-001-01", budget: 2 * time.Minute, threshold: 70, maxMutants: 50}
+	if got != want {
+		t.Errorf("runner got %+v, want %+v", got, want)
+	}
+
+	cmd = qualityCmd()
+	cmd.SetArgs([]string{"mutate"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error without --ws")
+	}
+}
+
+// TestQualityFlakyCmd tests flag wiring of the quality flaky command
+func TestQualityFlakyCmd(t *testing.T) {
+	var got flakyOptions
+	originalRunner := runQualityFlakyCmd
+	runQualityFlakyCmd = func(opts flakyOptions) error {
+		got = opts
+		return nil
+	}
+	t.Cleanup(func() {
+		runQualityFlakyCmd = originalRunner
+	})
+
+	cmd := qualityCmd()
+	cmd.SetArgs([]string{"flaky", "--quarantine", "a.TestX", "--reason", "timing", "--ttl", "24h"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("quality flaky: %v", err)
+	}
+	want := flakyOptions{quarantine: "a.TestX", reason: "timing", ttl: 24 * time.Hour}
+	if got != want {
+		t.Errorf("runner got %+v, want %+v", got, want)
+	}
+
+	cmd = qualityCmd()
+	cmd.SetArgs([]string{"flaky", "--quarantine", "a.TestX", "--release", "a.TestX"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for --quarantine with --release")
+	}
+}
+
 // TestQualitySizeCmd tests the quality size command
 func TestQualitySizeCmd(t *testing.T) {
 	cmd := qualityCmd()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/fall-out-bug/sdp/internal/arch"
 	"github.com/fall-out-bug/sdp/internal/evidence"
 	"github.com/fall-out-bug/sdp/internal/quality"
 )
@@ -143,8 +144,28 @@ func runQualityAll(ctx context.Context, strict bool) error {
 		fmt.Println("✗")
 	}
 
+	// Architecture (only when a spec is declared)
+	archPassed := true
+	if spec, err := arch.LoadSpec(projectPath); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: architecture check failed: %v\n", err)
+		archPassed = false
+	} else if spec != nil {
+		fmt.Println("\n=== Architecture ===")
+		violations, err := arch.CheckProject(projectPath, spec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: architecture check failed: %v\n", err)
+		}
+		archPassed = err == nil && len(violations) == 0
+		fmt.Printf("Violations: %d ", len(violations))
+		if archPassed {
+			fmt.Println("✓")
+		} else {
+			fmt.Println("✗")
+		}
+	}
+
 	fmt.Println()
-	allPassed := covResult.Passed && ccResult.Passed && sizeResult.Passed && typeResult.Passed
+	allPassed := covResult.Passed && ccResult.Passed && sizeResult.Passed && typeResult.Passed && archPassed
 	if allPassed {
 		fmt.Println("Overall: ✓ ALL CHECKS PASSED")
 	} else {
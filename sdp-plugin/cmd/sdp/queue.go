@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/queue"
+	"github.com/fall-out-bug/sdp/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func queueCmd() *cobra.Command {
+	var backend string
+
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Operator Mode issue queue (built-in file backend or Beads)",
+		Long: `Manage the issue queue behind Operator Mode.
+
+The backend comes from queue.backend in .sdp/config.yml:
+  auto   Beads when bd is on PATH and .beads/ exists, else file (default)
+  file   Built-in JSONL queue at queue.path (.sdp/queue/issues.jsonl),
+         one issue per line so it can be committed and merged with git
+  beads  The bd CLI
+
+Use 'sdp queue migrate' to move issues between backends.`,
+		Example: `  sdp queue create --title "Add retry" --priority 1 --ws 00-049-01
+  sdp queue ready
+  sdp queue update sdp-3fa9c1 --status in_progress --assignee alice
+  sdp queue dep add sdp-3fa9c1 sdp-07b2e4
+  sdp queue migrate --from beads --to file`,
+	}
+	cmd.PersistentFlags().StringVar(&backend, "backend", "", "Override queue.backend (auto, file, beads)")
+
+	open := func() (queue.IssueQueue, error) { return openQueue(backend) }
+	cmd.AddCommand(queueListCmd("ready", "List open issues whose dependencies are closed", open))
+	cmd.AddCommand(queueListCmd("list", "List all issues", open))
+	cmd.AddCommand(queueShowCmd(open))
+	cmd.AddCommand(queueCreateCmd(open))
+	cmd.AddCommand(queueUpdateCmd(open))
+	cmd.AddCommand(queueDepCmd(open))
+	cmd.AddCommand(queueMigrateCmd())
+	return cmd
+}
+
+// openQueue opens the configured queue, or backend when it is set
+func openQueue(backend string) (queue.IssueQueue, error) {
+	root := queueRoot()
+	cfg, err := config.Load(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if backend == "" {
+		backend = cfg.Queue.Backend
+	}
+	return queue.OpenBackend(root, backend, cfg.Queue.Path)
+}
+
+// queueRoot is the git root, or the working directory outside a repository
+func queueRoot() string {
+	if root, err := findProjectRoot(); err == nil {
+		return root
+	}
+	cwd, _ := os.Getwd()
+	return cwd
+}
+
+// queueActor names who changes an issue: SDP_ACTOR, git user.name or USER
+func queueActor() string {
+	if actor := os.Getenv("SDP_ACTOR"); actor != "" {
+		return actor
+	}
+	if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+	return os.Getenv("USER")
+}
+
+func queueListCmd(use, short string, open func() (queue.IssueQueue, error)) *cobra.Command {
+	var status string
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := open()
+			if err != nil {
+				return err
+			}
+			list := q.List
+			if use == "ready" {
+				list = q.Ready
+			}
+			issues, err := list()
+			if err != nil {
+				return fmt.Errorf("failed to list issues: %w", err)
+			}
+			if status != "" {
+				filtered := []queue.Issue{}
+				for _, is := range issues {
+					if is.Status == status {
+						filtered = append(filtered, is)
+					}
+				}
+				issues = filtered
+			}
+			if asJSON {
+				return writeQueueJSON(cmd, issues)
+			}
+			if len(issues) == 0 {
+				ui.InfoLine("No issues")
+				return nil
+			}
+			ui.Header(fmt.Sprintf("%d issue(s) [%s]", len(issues), q.Name()))
+			for _, is := range issues {
+				line := fmt.Sprintf("  • %s P%d %-11s %s", ui.BoldText(is.ID), is.Priority, is.Status, is.Title)
+				if is.Assignee != "" {
+					line += " @" + is.Assignee
+				}
+				if is.WSID != "" {
+					line += " (" + is.WSID + ")"
+				}
+				fmt.Println(line)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", "", "Only issues with this status")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output as JSON")
+	return cmd
+}
+
+func queueShowCmd(open func() (queue.IssueQueue, error)) *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "show <issue-id|ws-id>",
+		Short: "Show an issue with dependencies and status history",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := open()
+			if err != nil {
+				return err
+			}
+			var is *queue.Issue
+			if wsIDPattern.MatchString(args[0]) {
+				is, err = queue.FindByWS(q, args[0])
+			} else {
+				is, err = q.Show(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to show issue: %w", err)
+			}
+			if asJSON {
+				return writeQueueJSON(cmd, is)
+			}
+			ui.Subheader("Issue " + is.ID)
+			fmt.Printf("  Title:      %s\n", is.Title)
+			fmt.Printf("  Status:     %s\n", ui.Info(is.Status))
+			fmt.Printf("  Priority:   P%d\n", is.Priority)
+			for _, f := range [][2]string{{"Type", is.Type}, {"Assignee", is.Assignee}, {"Workstream", is.WSID},
+				{"Labels", strings.Join(is.Labels, ", ")}, {"Depends on", strings.Join(is.DependsOn, ", ")}, {"Notes", is.Notes}} {
+				if f[1] != "" {
+					fmt.Printf("  %-11s %s\n", f[0]+":", f[1])
+				}
+			}
+			if is.Description != "" {
+				fmt.Printf("\n%s\n", is.Description)
+			}
+			if len(is.History) > 0 {
+				fmt.Println("\n  History:")
+				for _, h := range is.History {
+					by := ""
+					if h.By != "" {
+						by = " by " + h.By
+					}
+					fmt.Printf("    %s %s -> %s%s\n", h.At.Format("2006-01-02 15:04"), orDash(h.From), h.To, by)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output as JSON")
+	return cmd
+}
+
+func writeQueueJSON(cmd *cobra.Command, v any) error {
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
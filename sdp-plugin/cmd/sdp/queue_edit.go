@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/fall-out-bug/sdp/internal/queue"
+	"github.com/fall-out-bug/sdp/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func queueCreateCmd(open func() (queue.IssueQueue, error)) *cobra.Command {
+	var issue queue.Issue
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an issue",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if issue.Title == "" {
+				return fmt.Errorf("--title is required")
+			}
+			if issue.WSID != "" && !wsIDPattern.MatchString(issue.WSID) {
+				return fmt.Errorf("invalid --ws %q: want 00-XXX-YY", issue.WSID)
+			}
+			q, err := open()
+			if err != nil {
+				return err
+			}
+			created, err := q.Create(issue)
+			if err != nil {
+				return fmt.Errorf("failed to create issue: %w", err)
+			}
+			ui.SuccessLine("Created issue %s", ui.BoldText(created.ID))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&issue.Title, "title", "", "Issue title (required)")
+	cmd.Flags().StringVar(&issue.Description, "description", "", "Issue description")
+	cmd.Flags().IntVar(&issue.Priority, "priority", queue.DefaultPriority, "Priority, 0 (highest) to 4")
+	cmd.Flags().StringVar(&issue.Type, "type", "task", "Issue type (task, bug, feature, epic, chore)")
+	cmd.Flags().StringVar(&issue.Assignee, "assignee", "", "Assignee")
+	cmd.Flags().StringSliceVar(&issue.Labels, "label", nil, "Label (repeatable)")
+	cmd.Flags().StringSliceVar(&issue.DependsOn, "depends-on", nil, "Issue this one waits for (repeatable)")
+	cmd.Flags().StringVar(&issue.WSID, "ws", "", "Linked workstream ID")
+	return cmd
+}
+
+func queueUpdateCmd(open func() (queue.IssueQueue, error)) *cobra.Command {
+	var status, assignee, notes string
+	var priority int
+
+	cmd := &cobra.Command{
+		Use:   "update <issue-id>",
+		Short: "Update status, assignee, priority or notes",
+		Long: `Update an issue. Status changes are recorded in the issue's history
+with the actor from SDP_ACTOR, git user.name or USER.
+
+Statuses: open, in_progress, blocked, closed`,
+		Example: `  sdp queue update sdp-3fa9c1 --status in_progress --assignee alice
+  sdp queue update sdp-3fa9c1 --status closed`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			u := queue.Update{Status: status, Actor: queueActor()}
+			if status != "" && !queue.ValidStatus(status) {
+				return fmt.Errorf("invalid --status %q (valid: open, in_progress, blocked, closed)", status)
+			}
+			if cmd.Flags().Changed("assignee") {
+				u.Assignee = &assignee
+			}
+			if cmd.Flags().Changed("priority") {
+				u.Priority = &priority
+			}
+			if cmd.Flags().Changed("notes") {
+				u.Notes = &notes
+			}
+			if u.Status == "" && u.Assignee == nil && u.Priority == nil && u.Notes == nil {
+				return fmt.Errorf("nothing to update: set --status, --assignee, --priority or --notes")
+			}
+			q, err := open()
+			if err != nil {
+				return err
+			}
+			updated, err := q.Update(args[0], u)
+			if err != nil {
+				return fmt.Errorf("failed to update issue: %w", err)
+			}
+			ui.SuccessLine("Updated %s: %s", ui.BoldText(updated.ID), updated.Status)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&status, "status", "", "New status")
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Assignee (empty to unassign)")
+	cmd.Flags().IntVar(&priority, "priority", queue.DefaultPriority, "Priority, 0 (highest) to 4")
+	cmd.Flags().StringVar(&notes, "notes", "", "Replace notes")
+	return cmd
+}
+
+func queueDepCmd(open func() (queue.IssueQueue, error)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dep",
+		Short: "Manage issue dependencies",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "add <issue-id> <depends-on-id>",
+		Short: "Make an issue wait for another to close",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := open()
+			if err != nil {
+				return err
+			}
+			if err := q.AddDependency(args[0], args[1]); err != nil {
+				return fmt.Errorf("failed to add dependency: %w", err)
+			}
+			ui.SuccessLine("%s now depends on %s", args[0], args[1])
+			return nil
+		},
+	})
+	return cmd
+}
+
+func queueMigrateCmd() *cobra.Command {
+	var from, to string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy every issue from one backend to another",
+		Long: `Copy issues with status, assignee, labels and dependencies from one
+backend to another. The file backend keeps issue IDs, so migrating Beads
+into it leaves .beads-sdp-mapping.jsonl valid; Beads assigns new IDs and
+the mapping is printed. Status history is not stored by Beads.`,
+		Example: `  sdp queue migrate --from beads --to file`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from == to {
+				return fmt.Errorf("--from and --to must differ")
+			}
+			src, err := openQueue(from)
+			if err != nil {
+				return err
+			}
+			dst, err := openQueue(to)
+			if err != nil {
+				return err
+			}
+			ids, err := queue.Migrate(src, dst)
+			for old, id := range ids {
+				if old != id {
+					fmt.Printf("  %s -> %s\n", old, id)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("migration stopped after %d issue(s): %w", len(ids), err)
+			}
+			ui.SuccessLine("Migrated %d issue(s) from %s to %s", len(ids), src.Name(), dst.Name())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&from, "from", queue.BackendBeads, "Source backend (file, beads)")
+	cmd.Flags().StringVar(&to, "to", queue.BackendFile, "Target backend (file, beads)")
+	return cmd
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/queue"
+)
+
+func runQueue(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := queueCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestQueueCmd_Subcommands(t *testing.T) {
+	cmd := queueCmd()
+	for _, sub := range []string{"ready", "list", "show", "create", "update", "dep", "migrate"} {
+		found := false
+		for _, c := range cmd.Commands() {
+			if c.Name() == sub {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("queueCmd() missing subcommand: %s", sub)
+		}
+	}
+}
+
+func TestQueueCmd_FileBackendFlow(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.Mkdir(".git", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SDP_ACTOR", "carol")
+
+	if _, err := runQueue(t, "create", "--backend", "file", "--title", "Parent", "--ws", "00-049-01"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	parent, err := queue.FindByWS(queue.NewFileQueue(queue.DefaultPath), "00-049-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runQueue(t, "create", "--backend", "file", "--title", "Child", "--depends-on", parent.ID); err != nil {
+		t.Fatalf("create child: %v", err)
+	}
+
+	out, err := runQueue(t, "ready", "--backend", "file", "--json")
+	if err != nil {
+		t.Fatalf("ready: %v", err)
+	}
+	var ready []queue.Issue
+	if err := json.Unmarshal([]byte(out), &ready); err != nil || len(ready) != 1 || ready[0].ID != parent.ID {
+		t.Fatalf("ready = %s (%v)", out, err)
+	}
+
+	if _, err := runQueue(t, "update", "--backend", "file", parent.ID, "--status", "closed"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	out, err = runQueue(t, "show", "--backend", "file", "00-049-01", "--json")
+	if err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	var shown queue.Issue
+	if err := json.Unmarshal([]byte(out), &shown); err != nil {
+		t.Fatal(err)
+	}
+	if last := shown.History[len(shown.History)-1]; last.To != queue.StatusClosed || last.By != "carol" {
+		t.Errorf("history = %+v", shown.History)
+	}
+
+	for _, bad := range [][]string{
+		{"create", "--backend", "file"},
+		{"create", "--backend", "file", "--title", "x", "--ws", "49"},
+		{"update", "--backend", "file", parent.ID},
+		{"update", "--backend", "file", parent.ID, "--status", "done"},
+		{"migrate", "--from", "file", "--to", "file"},
+	} {
+		if _, err := runQueue(t, bad...); err == nil {
+			t.Errorf("%s: expected error", strings.Join(bad, " "))
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fall-out-bug/sdp/internal/arch"
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var runRealityArchCmd = runRealityArch
+
+// realityArchOptions are the flags of `sdp reality arch`.
+type realityArchOptions struct {
+	depth int
+	write bool
+	force bool
+}
+
+func realityCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reality",
+		Short: "Inspect the project as it is",
+	}
+
+	var opts realityArchOptions
+	archCmd := &cobra.Command{
+		Use:   "arch",
+		Short: "Infer an architecture spec from the current import graph",
+		Long: `Infer an architecture spec from the current import graph.
+
+Imports of Go, Python and TypeScript/JavaScript sources are grouped into
+one layer per directory (or per directory prefix with --depth), and each
+layer may import exactly the layers it imports today. Edit the result into
+the intended layering; sdp guard check --staged and sdp quality arch then
+reject imports that cross layers against it.`,
+		Example: `  # Print a spec with one layer per top-two-level directory
+  sdp reality arch --depth 2
+
+  # Save it as .sdp/architecture.yml
+  sdp reality arch --depth 2 --write`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRealityArchCmd(opts)
+		},
+	}
+	archCmd.Flags().IntVar(&opts.depth, "depth", 0, "Group directories by their first N path segments (0 = one layer per directory)")
+	archCmd.Flags().BoolVar(&opts.write, "write", false, "Write the spec to "+arch.SpecFile)
+	archCmd.Flags().BoolVar(&opts.force, "force", false, "Overwrite an existing spec with --write")
+	cmd.AddCommand(archCmd)
+
+	return cmd
+}
+
+func runRealityArch(opts realityArchOptions) error {
+	root, err := config.FindProjectRoot()
+	if err != nil {
+		return fmt.Errorf("project root: %w", err)
+	}
+	var exclude []string
+	if spec, err := arch.LoadSpec(root); err == nil && spec != nil {
+		exclude = spec.Exclude
+	}
+	imports, err := arch.NewScanner(root, exclude).Scan()
+	if err != nil {
+		return err
+	}
+	spec := arch.Infer(imports, opts.depth)
+	spec.Exclude = exclude
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(spec); err != nil {
+		return fmt.Errorf("marshal spec: %w", err)
+	}
+	data := buf.Bytes()
+	if !opts.write {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	path := filepath.Join(root, arch.SpecFile)
+	if _, err := os.Stat(path); err == nil && !opts.force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", arch.SpecFile)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(arch.SpecFile), err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", arch.SpecFile, err)
+	}
+	fmt.Printf("✓ Wrote %s (%d layers)\n", arch.SpecFile, len(spec.Layers))
+	return nil
+}
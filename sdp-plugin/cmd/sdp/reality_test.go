@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/arch"
+)
+
+// archProject writes a Go project whose domain package imports infra.
+func archProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		".sdp/config.yml":         "version: \"0.10.0\"\n",
+		"go.mod":                  "module example.com/app\n",
+		"internal/domain/a.go":    "package domain\n\nimport \"example.com/app/internal/infra\"\n",
+		"internal/infra/infra.go": "package infra\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(root)
+	return root
+}
+
+func TestRunRealityArchAndQualityArch(t *testing.T) {
+	root := archProject(t)
+
+	if err := runQualityArch(); err != nil {
+		t.Fatalf("quality arch without spec: %v", err)
+	}
+	if err := runRealityArch(realityArchOptions{depth: 2, write: true}); err != nil {
+		t.Fatalf("reality arch --write: %v", err)
+	}
+	spec, err := arch.LoadSpec(root)
+	if err != nil || spec == nil {
+		t.Fatalf("LoadSpec = %v, %v", spec, err)
+	}
+	if l := spec.LayerOf("internal/domain/a.go"); l == nil || len(l.MayImport) != 1 || l.MayImport[0] != "internal/infra" {
+		t.Errorf("inferred domain layer = %+v, want may_import [internal/infra]", l)
+	}
+	if err := runRealityArch(realityArchOptions{write: true}); err == nil {
+		t.Error("expected error overwriting a spec without --force")
+	}
+
+	// The inferred spec accepts the current graph
+	if err := runQualityArch(); err != nil {
+		t.Errorf("quality arch with inferred spec: %v", err)
+	}
+
+	// Forbid domain -> infra
+	strict := "layers:\n  - name: domain\n    packages: [\"internal/domain/**\"]\n  - name: infra\n    packages: [\"internal/infra/**\"]\n"
+	if err := os.WriteFile(filepath.Join(root, arch.SpecFile), []byte(strict), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runQualityArch(); err == nil {
+		t.Error("expected quality arch to fail on domain importing infra")
+	}
+}
+
+func TestRealityCmd(t *testing.T) {
+	called := false
+	original := runRealityArchCmd
+	runRealityArchCmd = func(opts realityArchOptions) error {
+		called = opts.depth == 3 && opts.write
+		return nil
+	}
+	t.Cleanup(func() { runRealityArchCmd = original })
+
+	cmd := realityCmd()
+	cmd.SetArgs([]string{"arch", "--depth", "3", "--write"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if !called {
+		t.Error("reality arch runner not called with flags")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fall-out-bug/sdp/internal/nextstep"
 )
@@ -64,6 +65,28 @@ func printStatusText(view *nextstep.StatusView) error {
 		fmt.Println()
 	}
 
+	if len(view.Budgets) > 0 {
+		fmt.Println("Budgets:")
+		for _, b := range view.Budgets {
+			fmt.Printf("  %-12s %-10s %s\n", b.ID, b.Level, budgetBurnDown(b))
+		}
+		fmt.Println()
+	}
+
+	if len(view.Conflicts) > 0 {
+		fmt.Println("Predicted Merge Conflicts:")
+		for _, c := range view.Conflicts {
+			fmt.Printf("  %s %s ↔ %s\n", conflictIcon(c.Level), c.BranchA, c.BranchB)
+			for _, f := range c.Files {
+				fmt.Printf("      conflict: %s\n", f)
+			}
+			for _, s := range c.Symbols {
+				fmt.Printf("      both edit: %s\n", s)
+			}
+		}
+		fmt.Println()
+	}
+
 	fmt.Println("Next Action:")
 	fmt.Printf("  Command:      %s\n", view.NextAction)
 	if view.NextStep != nil {
@@ -75,6 +98,28 @@ func printStatusText(view *nextstep.StatusView) error {
 	return nil
 }
 
+// budgetBurnDown renders spend against the configured limits, e.g. "$3.20/$10.00 (32%)".
+func budgetBurnDown(b nextstep.BudgetStatus) string {
+	var parts []string
+	if b.MaxCost > 0 {
+		parts = append(parts, fmt.Sprintf("$%.2f/$%.2f", b.Cost, b.MaxCost))
+	}
+	if b.MaxTokens > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d tokens", b.Tokens, b.MaxTokens))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("$%.2f, %d tokens (no limit)", b.Cost, b.Tokens)
+	}
+	return fmt.Sprintf("%s (%.0f%%)", strings.Join(parts, ", "), b.Used*100)
+}
+
+func conflictIcon(level string) string {
+	if level == "conflict" {
+		return "❌"
+	}
+	return "⚠️ "
+}
+
 func printStatusJSON(view *nextstep.StatusView) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -13,6 +13,7 @@ import (
 )
 
 func tddCmd() *cobra.Command {
+	var full bool
 	cmd := &cobra.Command{
 		Use:   "tdd <phase> [path]",
 		Short: "Run TDD cycle (Red-Green-Refactor)",
@@ -28,7 +29,8 @@ Arguments:
   path    - Package path to test (default: ./internal/parser)
 
 The command automatically detects the project language (Go, Python, Java)
-and runs the appropriate test runner.
+and runs the appropriate test runner. Only tests affected by uncommitted
+changes run; use --full to run every test under path.
 
 Examples:
   sdp tdd green ./internal/parser
@@ -59,6 +61,12 @@ Examples:
 
 			// Detect language and create runner
 			runner := tdd.NewRunner(tdd.Go) // Default to Go, will be overridden by auto-detection
+			if cwd, err := os.Getwd(); err == nil {
+				runner.SetChangedFiles(changedFilesForTests(cwd, "", full))
+			}
+			if fc := projectFlakeChecker("tdd"); fc != nil {
+				runner.SetFlakeChecker(fc)
+			}
 
 			// Create context with cancellation
 			ctx, cancel := context.WithCancel(context.Background())
@@ -106,5 +114,7 @@ Examples:
 		},
 	}
 
+	cmd.Flags().BoolVar(&full, "full", false, "Run all tests under path instead of only affected tests")
+
 	return cmd
 }
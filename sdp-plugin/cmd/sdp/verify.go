@@ -6,6 +6,7 @@ import (
 	"os"
 	"regexp"
 
+	"github.com/fall-out-bug/sdp/internal/basebranch"
 	"github.com/fall-out-bug/sdp/internal/config"
 	"github.com/fall-out-bug/sdp/internal/evidence"
 	"github.com/fall-out-bug/sdp/internal/verify"
@@ -15,7 +16,8 @@ import (
 var wsIDPattern = regexp.MustCompile(`^\d{2}-\d{3}-\d{2}$`)
 
 func verifyCmd() *cobra.Command {
-	var wsDir string
+	var wsDir, base string
+	var full bool
 
 	cmd := &cobra.Command{
 		Use:   "verify <ws-id>",
@@ -24,6 +26,14 @@ func verifyCmd() *cobra.Command {
   - All scope_files output exist
   - All Verification commands pass
   - Test coverage meets threshold
+  - Each structured acceptance criterion passes its bound check
+    (command, named test, file/symbol or local HTTP probe)
+
+Test commands (go test ./..., pytest, jest, vitest) run only the tests
+affected by changes since the merge base with --base; use --full to run
+them as written. With quality.flaky.enabled, failed tests are re-run in
+isolation; failures that are all flaky or quarantined (see sdp quality
+flaky) do not fail verification.
 
 Usage:
   sdp verify 00-001-01`,
@@ -40,7 +50,23 @@ Usage:
 			}
 
 			// Create verifier
-			verifier := verify.NewVerifier(wsDir)
+			var opts []verify.VerifierOption
+			if cwd, err := os.Getwd(); err == nil {
+				root, err := config.FindProjectRoot()
+				if err != nil {
+					root = cwd
+				}
+				if base == "" {
+					base = basebranch.Default(root)
+				}
+				if sel := affectedSelector(cwd, base, full); sel != nil {
+					opts = append(opts, verify.WithTestSelector(sel))
+				}
+				if fc := verify.DefaultFlakeChecker(root, wsID); fc != nil {
+					opts = append(opts, verify.WithFlakeChecker(fc))
+				}
+			}
+			verifier := verify.NewVerifierWithOptions(wsDir, opts...)
 
 			// Run verification (use command context for cancellation)
 			ctx := cmd.Context()
@@ -64,6 +90,11 @@ Usage:
 						_, _ = fmt.Fprintf(os.Stderr, "warning: evidence emit: %v\n", err)
 					}
 				}
+				for _, ac := range result.Acceptance {
+					if err := evidence.EmitSync(evidence.AcceptanceEvent(wsID, ac)); err != nil {
+						_, _ = fmt.Fprintf(os.Stderr, "warning: evidence emit: %v\n", err)
+					}
+				}
 			}
 
 			// Print results
@@ -87,6 +118,17 @@ Usage:
 				}
 			}
 
+			if len(result.Acceptance) > 0 {
+				fmt.Printf("\nAcceptance criteria (%d):\n", len(result.Acceptance))
+				for _, ac := range result.Acceptance {
+					status := "✅"
+					if !ac.Passed {
+						status = "❌"
+					}
+					fmt.Printf("  %s %s %s: %s\n", status, ac.ID, ac.Criterion, ac.Message)
+				}
+			}
+
 			if result.CoverageActual > 0 {
 				fmt.Printf("\nCoverage: %.1f%%\n", result.CoverageActual)
 			}
@@ -133,6 +175,8 @@ Usage:
 	}
 
 	cmd.Flags().StringVar(&wsDir, "ws-dir", "", "Workstream directory (default: docs/workstreams)")
+	cmd.Flags().StringVar(&base, "base", "", "Select tests affected by changes since the merge base with this ref (default: dev, main or master)")
+	cmd.Flags().BoolVar(&full, "full", false, "Run test commands in full instead of only affected tests")
 
 	return cmd
 }
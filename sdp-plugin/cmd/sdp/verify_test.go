@@ -18,4 +18,8 @@ func TestVerifyCmdConstructed(t *testing.T) {
 	if cmd.Args == nil {
 		t.Error("expected Args validator to be set")
 	}
+	// An empty --base resolves to the repository's default branch at run time.
+	if f := cmd.Flags().Lookup("base"); f == nil || f.DefValue != "" {
+		t.Errorf("expected --base to default to empty, got %+v", f)
+	}
 }
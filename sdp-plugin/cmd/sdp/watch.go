@@ -12,6 +12,8 @@ import (
 func watchCmd() *cobra.Command {
 	var (
 		quiet     bool
+		tests     bool
+		full      bool
 		include   []string
 		exclude   []string
 		watchPath string
@@ -27,6 +29,7 @@ Monitors source files and runs quality checks on save. Detects:
   - Cyclomatic complexity >= 10
   - Type errors (via go vet)
   - Coverage violations (via coverage check)
+  - Failing tests affected by the saved file (--full: whole suite)
 
 Press Ctrl+C to stop watching.`,
 		Args: cobra.MaximumNArgs(1),
@@ -44,6 +47,8 @@ Press Ctrl+C to stop watching.`,
 				IncludePatterns: include,
 				ExcludePatterns: exclude,
 				Quiet:           quiet,
+				RunTests:        tests,
+				FullTests:       full,
 			}
 
 			qw, err := watcher.NewQualityWatcher(watchPath, config)
@@ -87,6 +92,8 @@ Press Ctrl+C to stop watching.`,
 	}
 
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Suppress output")
+	cmd.Flags().BoolVar(&tests, "tests", true, "Run tests affected by each saved file")
+	cmd.Flags().BoolVar(&full, "full", false, "Run the whole test suite on save instead of affected tests")
 	cmd.Flags().StringSliceVarP(&include, "include", "i", []string{"*.go"}, "Include patterns (glob)")
 	cmd.Flags().StringSliceVarP(&exclude, "exclude", "e", []string{"*_test.go", "mock_*.go"}, "Exclude patterns (glob)")
 
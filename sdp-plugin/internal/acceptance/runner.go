@@ -1,7 +1,10 @@
 package acceptance
 
 import (
+	"context"
 	"time"
+
+	"github.com/fall-out-bug/sdp/internal/impact"
 )
 
 // Runner runs the acceptance test command (AC2, AC3).
@@ -10,6 +13,8 @@ type Runner struct {
 	Timeout  time.Duration
 	Expected string // substring match (AC5)
 	Dir      string // optional: run command in this directory (project root)
+	// Selector optionally narrows test commands to affected tests.
+	Selector *impact.Selector
 }
 
 // Result is the outcome of Run (AC4, AC8).
@@ -27,3 +32,16 @@ func ParseTimeout(s string) (time.Duration, error) {
 	}
 	return time.ParseDuration(s)
 }
+
+// selectArgs narrows parts to the affected tests when a Selector is set.
+// Selection errors fall back to the full command.
+func (r *Runner) selectArgs(ctx context.Context, parts []string) ([]string, bool) {
+	if r.Selector == nil {
+		return parts, false
+	}
+	args, skip, err := r.Selector.Rewrite(ctx, parts)
+	if err != nil {
+		return parts, false
+	}
+	return args, skip
+}
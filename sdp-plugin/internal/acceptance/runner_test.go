@@ -2,8 +2,12 @@ package acceptance
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/fall-out-bug/sdp/internal/impact"
 )
 
 func TestRunner_Run_ExitZero(t *testing.T) {
@@ -102,3 +106,23 @@ func TestParseTimeout(t *testing.T) {
 		t.Errorf("empty want 30s default, got %v", d)
 	}
 }
+
+func TestRunner_Run_NoAffectedTests(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/m\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r := &Runner{
+		Command:  "go test ./...",
+		Timeout:  30 * time.Second,
+		Dir:      dir,
+		Selector: impact.NewSelector(dir, []string{"README.md"}),
+	}
+	res, err := r.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !res.Passed || res.Output != "no affected tests" {
+		t.Errorf("expected skipped pass, got %+v", res)
+	}
+}
@@ -36,6 +36,16 @@ func (r *Runner) Run(ctx context.Context) (*Result, error) {
 		}, nil
 	}
 
+	// Narrow whole-suite test commands to affected tests
+	parts, skip := r.selectArgs(ctx, parts)
+	if skip {
+		return &Result{
+			Passed:   true,
+			Duration: time.Since(start),
+			Output:   "no affected tests",
+		}, nil
+	}
+
 	// Create context with timeout
 	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
 	defer cancel()
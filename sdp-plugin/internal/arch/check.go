@@ -0,0 +1,63 @@
+package arch
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Violation is an import that crosses layers against the spec.
+type Violation struct {
+	Import
+	From string // layer of the importing file
+	To   string // layer of the imported package
+}
+
+// Message describes the violation, e.g.
+// `layer "domain" must not import "infrastructure" (example.com/app/infra/db)`.
+func (v Violation) Message() string {
+	return fmt.Sprintf("layer %q must not import %q (%s)", v.From, v.To, v.Path)
+}
+
+// Check returns the imports that go from one layer to another the first is
+// not allowed to import. Files and packages outside every layer are
+// unconstrained. Violations are sorted by file and line.
+func Check(spec *Spec, imports []Import) []Violation {
+	var violations []Violation
+	for _, imp := range imports {
+		from := spec.LayerOf(imp.File)
+		if from == nil {
+			continue
+		}
+		to := spec.LayerOf(imp.Key())
+		if to == nil || from.allows(to.Name) {
+			continue
+		}
+		violations = append(violations, Violation{Import: imp, From: from.Name, To: to.Name})
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+	return violations
+}
+
+// CheckProject scans every source file under root against spec.
+func CheckProject(root string, spec *Spec) ([]Violation, error) {
+	imports, err := NewScanner(root, spec.Exclude).Scan()
+	if err != nil {
+		return nil, err
+	}
+	return Check(spec, imports), nil
+}
+
+// CheckFiles checks only the imports of the given repo-relative files, as
+// for staged changes.
+func CheckFiles(root string, spec *Spec, files []string) ([]Violation, error) {
+	imports, err := NewScanner(root, spec.Exclude).ScanFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	return Check(spec, imports), nil
+}
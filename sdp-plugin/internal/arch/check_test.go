@@ -0,0 +1,80 @@
+package arch
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCheckProject(t *testing.T) {
+	root := fixtureProject(t)
+	spec, err := ParseSpec([]byte(testSpec + `  - name: py-domain
+    packages: ["src/shop/domain/**"]
+  - name: py-infra
+    packages: ["src/shop/infra/**"]
+  - name: web-domain
+    packages: ["web/domain/**"]
+  - name: web-infra
+    packages: ["web/infra/**"]
+    may_import: [web-domain]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := CheckProject(root, spec)
+	if err != nil {
+		t.Fatalf("CheckProject: %v", err)
+	}
+	want := []string{
+		"internal/domain/user.go:3 domain->infra",
+		"src/shop/domain/order.py:2 py-domain->py-infra",
+		"src/shop/domain/order.py:3 py-domain->py-infra",
+		"web/domain/cart.ts:1 web-domain->web-infra",
+	}
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.File+":"+strconv.Itoa(v.Line)+" "+v.From+"->"+v.To)
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("violations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if v := violations[0]; v.Text != `import "example.com/app/internal/infra/db"` {
+		t.Errorf("Text = %q, want the import line", v.Text)
+	}
+	if msg := violations[0].Message(); !strings.Contains(msg, `"domain" must not import "infra"`) {
+		t.Errorf("Message = %q", msg)
+	}
+}
+
+func TestCheck_ExternalLayer(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imports := []Import{
+		{File: "internal/app/repo.go", Line: 5, Path: "database/sql"},
+		{File: "internal/infra/db/db.go", Line: 3, Path: "database/sql"},
+		{File: "cmd/main.go", Line: 3, Path: "database/sql"},
+	}
+	violations := Check(spec, imports)
+	if len(violations) != 1 || violations[0].File != "internal/app/repo.go" {
+		t.Errorf("violations = %+v, want only app importing database/sql", violations)
+	}
+}
+
+func TestCheckFiles(t *testing.T) {
+	root := fixtureProject(t)
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations, err := CheckFiles(root, spec, []string{"internal/app/service.go"})
+	if err != nil || len(violations) != 0 {
+		t.Errorf("CheckFiles(clean) = %+v, %v; want none", violations, err)
+	}
+	violations, _ = CheckFiles(root, spec, []string{"internal/domain/user.go"})
+	if len(violations) != 1 {
+		t.Errorf("CheckFiles(domain) = %+v, want 1", violations)
+	}
+}
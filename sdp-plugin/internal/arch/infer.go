@@ -0,0 +1,92 @@
+package arch
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Infer builds a starting spec from the current import graph: one layer per
+// source directory, or per directory prefix of depth segments when depth is
+// positive, each allowed to import exactly the layers it imports today.
+// Only imports that resolve inside the repo are considered.
+func Infer(imports []Import, depth int) *Spec {
+	deps := make(map[string]map[string]bool)
+	touch := func(key string) map[string]bool {
+		if deps[key] == nil {
+			deps[key] = make(map[string]bool)
+		}
+		return deps[key]
+	}
+	for _, imp := range imports {
+		from := layerKey(path.Dir(imp.File), depth)
+		out := touch(from)
+		if imp.Target == "" {
+			continue
+		}
+		to := layerKey(targetDir(imp.Target), depth)
+		touch(to)
+		if to != from {
+			out[to] = true
+		}
+	}
+
+	keys := make([]string, 0, len(deps))
+	for key := range deps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	spec := &Spec{Version: 1}
+	for _, key := range keys {
+		layer := Layer{Name: layerName(key), Packages: layerPackages(key, depth)}
+		for dep := range deps[key] {
+			layer.MayImport = append(layer.MayImport, layerName(dep))
+		}
+		sort.Strings(layer.MayImport)
+		spec.Layers = append(spec.Layers, layer)
+	}
+	return spec
+}
+
+// layerKey collapses dir to its first depth segments.
+func layerKey(dir string, depth int) string {
+	if depth <= 0 {
+		return dir
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// layerName names the layer of a key; the repository root becomes "root".
+func layerName(key string) string {
+	if key == "." {
+		return "root"
+	}
+	return key
+}
+
+// layerPackages returns globs for a layer key. Keys at full depth own their
+// subtree; shallower keys own only their direct files, so they do not
+// swallow the deeper layers that follow them.
+func layerPackages(key string, depth int) []string {
+	direct := key + "/*"
+	if key == "." {
+		direct = "*"
+	}
+	if depth > 0 && len(strings.Split(key, "/")) == depth {
+		return []string{key, key + "/**"}
+	}
+	return []string{key, direct}
+}
+
+// targetDir is the directory of a resolved import target.
+func targetDir(target string) string {
+	if languageOf(target) != "" {
+		return path.Dir(target)
+	}
+	return target
+}
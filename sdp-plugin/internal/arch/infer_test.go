@@ -0,0 +1,58 @@
+package arch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	root := fixtureProject(t)
+	imports, err := NewScanner(root, []string{"src/**", "web/**", "tools/**"}).Scan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := Infer(imports, 0)
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("inferred spec invalid: %v", err)
+	}
+	got := make(map[string][]string)
+	for _, l := range spec.Layers {
+		got[l.Name] = l.MayImport
+	}
+	want := map[string][]string{
+		"internal/app":      {"internal/domain"},
+		"internal/domain":   {"internal/infra/db"},
+		"internal/infra/db": nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("layers = %v, want %v", got, want)
+	}
+	if violations := Check(spec, imports); len(violations) != 0 {
+		t.Errorf("inferred spec reports violations on its own graph: %+v", violations)
+	}
+}
+
+func TestInfer_Depth(t *testing.T) {
+	imports := []Import{
+		{File: "internal/app/x/a.go", Target: "internal/domain/y"},
+		{File: "main.go", Target: "internal/app/x"},
+		{File: "cmd/sdp/main.go", Target: "internal/app"},
+	}
+	spec := Infer(imports, 2)
+	names := make([]string, len(spec.Layers))
+	for i, l := range spec.Layers {
+		names[i] = l.Name
+	}
+	if want := []string{"root", "cmd/sdp", "internal/app", "internal/domain"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("layers = %v, want %v", names, want)
+	}
+	if l := spec.LayerOf("internal/app/x/a.go"); l == nil || l.Name != "internal/app" {
+		t.Errorf("LayerOf(deep file) = %v, want internal/app", l)
+	}
+	if l := spec.LayerOf("main.go"); l == nil || l.Name != "root" {
+		t.Errorf("LayerOf(main.go) = %v, want root", l)
+	}
+	if len(Check(spec, imports)) != 0 {
+		t.Error("inferred spec should accept its own graph")
+	}
+}
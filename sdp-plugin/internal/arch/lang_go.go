@@ -0,0 +1,58 @@
+package arch
+
+import (
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/gomod"
+)
+
+// goModule is the module enclosing a directory.
+type goModule struct {
+	dir  string // repo-relative directory holding go.mod
+	path string // module path; "" outside any module
+}
+
+func (s *Scanner) scanGo(file string, src []byte) []Import {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.ImportsOnly)
+	if err != nil {
+		return nil // the compiler reports syntax errors
+	}
+	lines := strings.Split(string(src), "\n")
+	mod := s.goModuleOf(path.Dir(file))
+
+	imports := make([]Import, 0, len(f.Imports))
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		line := fset.Position(spec.Pos()).Line
+		imp := Import{File: file, Line: line, Text: lineText(lines, line), Path: p}
+		if mod.path != "" && (p == mod.path || strings.HasPrefix(p, mod.path+"/")) {
+			imp.Target, _ = inRepo(path.Join(mod.dir, strings.TrimPrefix(p, mod.path)))
+		}
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+// goModuleOf finds the nearest go.mod at or above dir, within Root.
+func (s *Scanner) goModuleOf(dir string) goModule {
+	if mod, ok := s.modules[dir]; ok {
+		return mod
+	}
+	var mod goModule
+	if p := gomod.ModulePath(filepath.Join(s.Root, filepath.FromSlash(dir))); p != "" {
+		mod = goModule{dir: dir, path: p}
+	} else if dir != "." {
+		mod = s.goModuleOf(path.Dir(dir))
+	}
+	s.modules[dir] = mod
+	return mod
+}
@@ -0,0 +1,83 @@
+package arch
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	pyImport     = regexp.MustCompile(`^\s*import\s+([\w.]+(?:\s+as\s+\w+)?(?:\s*,\s*[\w.]+(?:\s+as\s+\w+)?)*)`)
+	pyFromImport = regexp.MustCompile(`^\s*from\s+(\.*[\w.]*)\s+import\s+\(?\s*([\w*]+)`)
+)
+
+// pythonRoots are the directories absolute module names resolve against.
+var pythonRoots = []string{"", "src"}
+
+func (s *Scanner) scanPython(file string, src []byte) []Import {
+	var imports []Import
+	for i, line := range strings.Split(string(src), "\n") {
+		add := func(module, target string) {
+			imports = append(imports, Import{File: file, Line: i + 1, Text: strings.TrimSpace(line), Path: module, Target: target})
+		}
+		if m := pyFromImport.FindStringSubmatch(line); m != nil {
+			module := m[1]
+			// "from pkg import mod" may name a submodule
+			target := s.resolvePython(file, joinModule(module, m[2]))
+			if target == "" {
+				target = s.resolvePython(file, module)
+			}
+			add(module, target)
+			continue
+		}
+		if m := pyImport.FindStringSubmatch(line); m != nil {
+			for _, part := range strings.Split(m[1], ",") {
+				module := strings.Fields(part)[0]
+				add(module, s.resolvePython(file, module))
+			}
+		}
+	}
+	return imports
+}
+
+func joinModule(module, name string) string {
+	if name == "*" {
+		return module
+	}
+	if strings.HasSuffix(module, ".") {
+		return module + name
+	}
+	return module + "." + name
+}
+
+// resolvePython maps a module name to a package directory or .py file in
+// the repo, or "" for third-party and standard library modules.
+func (s *Scanner) resolvePython(file, module string) string {
+	rel := strings.TrimLeft(module, ".")
+	levels := len(module) - len(rel)
+	relPath := strings.ReplaceAll(rel, ".", "/")
+
+	var bases []string
+	if levels > 0 {
+		base := path.Dir(file)
+		for i := 1; i < levels; i++ {
+			base = path.Dir(base)
+		}
+		bases = []string{base}
+	} else {
+		bases = pythonRoots
+	}
+	for _, base := range bases {
+		candidate, ok := inRepo(path.Join(base, relPath))
+		if !ok || candidate == "." && levels == 0 {
+			continue
+		}
+		if s.exists(candidate + ".py") {
+			return candidate + ".py"
+		}
+		if s.exists(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
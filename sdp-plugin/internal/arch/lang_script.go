@@ -0,0 +1,51 @@
+package arch
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// scriptImport matches ES module, dynamic import and require specifiers.
+var scriptImport = regexp.MustCompile(`(?:\bfrom\s*|^\s*import\s*|\bimport\s*\(\s*|\brequire\s*\(\s*)['"]([^'"]+)['"]`)
+
+func (s *Scanner) scanScript(file string, src []byte) []Import {
+	var imports []Import
+	for i, line := range strings.Split(string(src), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		for _, m := range scriptImport.FindAllStringSubmatch(line, -1) {
+			imports = append(imports, Import{
+				File:   file,
+				Line:   i + 1,
+				Text:   strings.TrimSpace(line),
+				Path:   m[1],
+				Target: s.resolveScript(file, m[1]),
+			})
+		}
+	}
+	return imports
+}
+
+// resolveScript resolves relative specifiers to a file or directory in the
+// repo. Bare specifiers are packages and stay external.
+func (s *Scanner) resolveScript(file, spec string) string {
+	if !strings.HasPrefix(spec, "./") && !strings.HasPrefix(spec, "../") {
+		return ""
+	}
+	p, ok := inRepo(path.Join(path.Dir(file), spec))
+	if !ok {
+		return ""
+	}
+	if path.Ext(p) != "" && s.exists(p) {
+		return p
+	}
+	for _, ext := range scriptExts {
+		if s.exists(p + ext) {
+			return p + ext
+		}
+	}
+	// A directory import (index file) or an unresolved relative path.
+	return p
+}
@@ -0,0 +1,152 @@
+package arch
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/pathmatch"
+)
+
+// Import is one import statement found in a source file.
+type Import struct {
+	File   string // repo-relative importing file
+	Line   int
+	Text   string // the import line as written, trimmed
+	Path   string // imported package or module as written
+	Target string // repo-relative file or directory it resolves to; "" when external
+}
+
+// Key is the path layer globs are matched against: the resolved target for
+// imports inside the repo, the import path otherwise.
+func (i Import) Key() string {
+	if i.Target != "" {
+		return i.Target
+	}
+	return i.Path
+}
+
+// Scanner reads imports from Go, Python and TypeScript/JavaScript files.
+type Scanner struct {
+	Root    string
+	Exclude []string // path globs to skip
+
+	modules map[string]goModule // repo-relative dir -> enclosing module
+}
+
+// NewScanner returns a scanner for the project at root.
+func NewScanner(root string, exclude []string) *Scanner {
+	return &Scanner{Root: root, Exclude: exclude, modules: make(map[string]goModule)}
+}
+
+// skipDirs are never descended into by Scan.
+var skipDirs = map[string]bool{"node_modules": true, "vendor": true, "testdata": true, "__pycache__": true}
+
+// Scan reads imports from every supported source file under Root.
+func (s *Scanner) Scan() ([]Import, error) {
+	var files []string
+	err := filepath.WalkDir(s.Root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(s.Root, p)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			name := d.Name()
+			if rel != "." && (strings.HasPrefix(name, ".") || skipDirs[name] || pathmatch.Any(s.Exclude, rel)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		files = append(files, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan %s: %w", s.Root, err)
+	}
+	return s.ScanFiles(files)
+}
+
+// ScanFiles reads imports from the given repo-relative files. Unsupported,
+// excluded, test and missing files are skipped.
+func (s *Scanner) ScanFiles(files []string) ([]Import, error) {
+	var imports []Import
+	for _, file := range files {
+		file = path.Clean(filepath.ToSlash(file))
+		if languageOf(file) == "" || isTestFile(file) || pathmatch.Any(s.Exclude, file) {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(s.Root, filepath.FromSlash(file)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		switch languageOf(file) {
+		case "go":
+			imports = append(imports, s.scanGo(file, src)...)
+		case "python":
+			imports = append(imports, s.scanPython(file, src)...)
+		case "script":
+			imports = append(imports, s.scanScript(file, src)...)
+		}
+	}
+	return imports, nil
+}
+
+// scriptExts are TypeScript and JavaScript extensions, in resolution order.
+var scriptExts = []string{".ts", ".tsx", ".js", ".jsx", ".mjs", ".cjs"}
+
+func languageOf(file string) string {
+	ext := path.Ext(file)
+	switch ext {
+	case ".go":
+		return "go"
+	case ".py":
+		return "python"
+	}
+	for _, e := range scriptExts {
+		if ext == e && !strings.HasSuffix(file, ".d.ts") {
+			return "script"
+		}
+	}
+	return ""
+}
+
+// isTestFile reports test sources, which may depend across layers.
+func isTestFile(file string) bool {
+	base := path.Base(file)
+	switch languageOf(file) {
+	case "go":
+		return strings.HasSuffix(base, "_test.go")
+	case "python":
+		return strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py") || base == "conftest.py"
+	case "script":
+		return strings.Contains(base, ".test.") || strings.Contains(base, ".spec.")
+	}
+	return false
+}
+
+// exists reports whether the repo-relative path exists.
+func (s *Scanner) exists(rel string) bool {
+	_, err := os.Stat(filepath.Join(s.Root, filepath.FromSlash(rel)))
+	return err == nil
+}
+
+// lineText returns the trimmed 1-based line n of src.
+func lineText(lines []string, n int) string {
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[n-1])
+}
+
+// inRepo cleans a joined path and reports whether it stays inside the repo.
+func inRepo(p string) (string, bool) {
+	p = path.Clean(p)
+	return p, p != ".." && !strings.HasPrefix(p, "../")
+}
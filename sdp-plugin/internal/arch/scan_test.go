@@ -0,0 +1,96 @@
+package arch
+
+import (
+	"testing"
+)
+
+// fixtureProject writes a small Go, Python and TypeScript project.
+func fixtureProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeFile(t, root, "internal/domain/user.go", "package domain\n\nimport \"example.com/app/internal/infra/db\"\n\nvar _ = db.X\n")
+	writeFile(t, root, "internal/domain/user_test.go", "package domain\n\nimport \"example.com/app/internal/app\"\n")
+	writeFile(t, root, "internal/app/service.go", "package app\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/app/internal/domain\"\n)\n")
+	writeFile(t, root, "internal/infra/db/db.go", "package db\n\nimport \"database/sql\"\n\nvar X sql.DB\n")
+	writeFile(t, root, "tools/go.mod", "module example.com/tools\n")
+	writeFile(t, root, "tools/gen/main.go", "package main\n\nimport \"example.com/tools/lib\"\n")
+
+	writeFile(t, root, "src/shop/__init__.py", "")
+	writeFile(t, root, "src/shop/domain/__init__.py", "")
+	writeFile(t, root, "src/shop/domain/order.py", "import os, json\nfrom shop.infra import repo\nfrom ..infra.repo import save\n")
+	writeFile(t, root, "src/shop/infra/repo.py", "from . import helpers\nimport requests\n")
+	writeFile(t, root, "src/shop/infra/helpers.py", "")
+
+	writeFile(t, root, "web/domain/cart.ts", "import { api } from '../infra/api';\nimport React from 'react';\n// import x from '../ignored';\nconst u = require('./util');\n")
+	writeFile(t, root, "web/domain/util.ts", "export {}\n")
+	writeFile(t, root, "web/infra/api.ts", "export const api = {}\n")
+	writeFile(t, root, "node_modules/pkg/index.js", "import '../../web/domain/cart'\n")
+	return root
+}
+
+func TestScan(t *testing.T) {
+	root := fixtureProject(t)
+	imports, err := NewScanner(root, nil).Scan()
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	got := make(map[string]Import)
+	for _, imp := range imports {
+		got[imp.File+" "+imp.Path] = imp
+	}
+	tests := []struct {
+		key, target string
+		line        int
+	}{
+		{"internal/domain/user.go example.com/app/internal/infra/db", "internal/infra/db", 3},
+		{"internal/app/service.go fmt", "", 4},
+		{"internal/app/service.go example.com/app/internal/domain", "internal/domain", 6},
+		{"tools/gen/main.go example.com/tools/lib", "tools/lib", 3},
+		{"src/shop/domain/order.py os", "", 1},
+		{"src/shop/domain/order.py json", "", 1},
+		{"src/shop/domain/order.py shop.infra", "src/shop/infra/repo.py", 2},
+		{"src/shop/domain/order.py ..infra.repo", "src/shop/infra/repo.py", 3},
+		{"src/shop/infra/repo.py .", "src/shop/infra/helpers.py", 1},
+		{"src/shop/infra/repo.py requests", "", 2},
+		{"web/domain/cart.ts ../infra/api", "web/infra/api.ts", 1},
+		{"web/domain/cart.ts react", "", 2},
+		{"web/domain/cart.ts ./util", "web/domain/util.ts", 4},
+	}
+	for _, tt := range tests {
+		imp, ok := got[tt.key]
+		if !ok {
+			t.Errorf("missing import %q", tt.key)
+			continue
+		}
+		if imp.Target != tt.target || imp.Line != tt.line {
+			t.Errorf("%s: target %q line %d, want %q line %d", tt.key, imp.Target, imp.Line, tt.target, tt.line)
+		}
+	}
+	if _, ok := got["internal/domain/user_test.go example.com/app/internal/app"]; ok {
+		t.Error("test files should not be scanned")
+	}
+	if _, ok := got["web/domain/cart.ts ../ignored"]; ok {
+		t.Error("commented imports should be skipped")
+	}
+	for _, imp := range imports {
+		if imp.File == "node_modules/pkg/index.js" {
+			t.Error("node_modules should not be scanned")
+		}
+	}
+	if len(imports) != len(got) {
+		t.Errorf("duplicate imports: %d scanned, %d unique", len(imports), len(got))
+	}
+}
+
+func TestScanFiles_Exclude(t *testing.T) {
+	root := fixtureProject(t)
+	imports, err := NewScanner(root, []string{"web/**"}).ScanFiles([]string{"web/domain/cart.ts", "internal/app/service.go", "missing.go", "README.md"})
+	if err != nil {
+		t.Fatalf("ScanFiles: %v", err)
+	}
+	if len(imports) != 2 {
+		t.Errorf("imports = %+v, want the two service.go imports", imports)
+	}
+}
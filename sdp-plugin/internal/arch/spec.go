@@ -0,0 +1,115 @@
+// Package arch checks imports against a declared layered architecture.
+//
+// The spec in .sdp/architecture.yml assigns packages to layers by path glob
+// and lists, per layer, which other layers it may import. Imports are read
+// from Go, Python and TypeScript/JavaScript sources.
+package arch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fall-out-bug/sdp/internal/pathmatch"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFile is the architecture spec location relative to the project root.
+const SpecFile = ".sdp/architecture.yml"
+
+// Spec declares the layers of a project.
+type Spec struct {
+	Version int      `yaml:"version"`
+	Layers  []Layer  `yaml:"layers"`
+	Exclude []string `yaml:"exclude,omitempty"` // path globs never scanned
+}
+
+// Layer is a named group of packages. Packages are globs over repo-relative
+// paths (e.g. "internal/domain/**"); for imports that do not resolve to a
+// file in the repo they are matched against the import path itself
+// (e.g. "database/sql"). MayImport lists the other layers this layer may
+// depend on; imports within a layer are always allowed.
+type Layer struct {
+	Name      string   `yaml:"name"`
+	Packages  []string `yaml:"packages"`
+	MayImport []string `yaml:"may_import,omitempty"`
+}
+
+// LoadSpec reads root/.sdp/architecture.yml. It returns nil, nil when the
+// project declares no architecture.
+func LoadSpec(root string) (*Spec, error) {
+	data, err := os.ReadFile(filepath.Join(root, SpecFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", SpecFile, err)
+	}
+	spec, err := ParseSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", SpecFile, err)
+	}
+	return spec, nil
+}
+
+// ParseSpec decodes and validates a spec.
+func ParseSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks that layer names are unique and dependencies name layers.
+func (s *Spec) Validate() error {
+	names := make(map[string]bool, len(s.Layers))
+	for i, l := range s.Layers {
+		if l.Name == "" {
+			return fmt.Errorf("layers[%d]: name is required", i)
+		}
+		if names[l.Name] {
+			return fmt.Errorf("layers[%d]: duplicate layer %q", i, l.Name)
+		}
+		if len(l.Packages) == 0 {
+			return fmt.Errorf("layer %q: packages is required", l.Name)
+		}
+		names[l.Name] = true
+	}
+	for _, l := range s.Layers {
+		for _, dep := range l.MayImport {
+			if !names[dep] {
+				return fmt.Errorf("layer %q: may_import names unknown layer %q", l.Name, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// LayerOf returns the first layer whose packages match path, or nil.
+func (s *Spec) LayerOf(path string) *Layer {
+	for i := range s.Layers {
+		for _, glob := range s.Layers[i].Packages {
+			if pathmatch.Match(glob, path) {
+				return &s.Layers[i]
+			}
+		}
+	}
+	return nil
+}
+
+// allows reports whether layer from may import layer to.
+func (l *Layer) allows(to string) bool {
+	if l.Name == to {
+		return true
+	}
+	for _, dep := range l.MayImport {
+		if dep == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package arch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSpec = `version: 1
+layers:
+  - name: domain
+    packages: ["internal/domain/**"]
+  - name: app
+    packages: ["internal/app/**"]
+    may_import: [domain]
+  - name: infra
+    packages: ["internal/infra/**", "database/sql"]
+    may_import: [domain, app]
+`
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("ParseSpec: %v", err)
+	}
+	if len(spec.Layers) != 3 {
+		t.Fatalf("layers = %d, want 3", len(spec.Layers))
+	}
+	if l := spec.LayerOf("internal/app/service.go"); l == nil || l.Name != "app" {
+		t.Errorf("LayerOf(app file) = %v, want app", l)
+	}
+	if l := spec.LayerOf("database/sql"); l == nil || l.Name != "infra" {
+		t.Errorf("LayerOf(database/sql) = %v, want infra", l)
+	}
+	if l := spec.LayerOf("cmd/main.go"); l != nil {
+		t.Errorf("LayerOf(cmd) = %v, want nil", l.Name)
+	}
+}
+
+func TestParseSpec_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown dependency": "layers:\n  - name: a\n    packages: [a]\n    may_import: [b]\n",
+		"duplicate layer":    "layers:\n  - name: a\n    packages: [a]\n  - name: a\n    packages: [b]\n",
+		"missing packages":   "layers:\n  - name: a\n",
+		"missing name":       "layers:\n  - packages: [a]\n",
+	}
+	for name, data := range tests {
+		if _, err := ParseSpec([]byte(data)); err == nil {
+			t.Errorf("%s: ParseSpec succeeded, want error", name)
+		}
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	root := t.TempDir()
+	spec, err := LoadSpec(root)
+	if err != nil || spec != nil {
+		t.Fatalf("LoadSpec without file = %v, %v; want nil, nil", spec, err)
+	}
+	writeFile(t, root, SpecFile, "layers: [")
+	if _, err := LoadSpec(root); err == nil || !strings.Contains(err.Error(), SpecFile) {
+		t.Errorf("LoadSpec invalid = %v, want error naming %s", err, SpecFile)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,22 @@
+// Package basebranch picks the branch a feature branch is compared against
+// when no base is configured.
+//
+// This file is the source of truth. The root module cannot import it, so
+// internal/basebranch/basebranch.go at the repository root is a
+// byte-for-byte copy; the root tests fail when the two differ.
+package basebranch
+
+import "os/exec"
+
+// Default returns the first of dev, main and master that exists locally in
+// repoDir, or HEAD when none does.
+func Default(repoDir string) string {
+	for _, b := range []string{"dev", "main", "master"} {
+		cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+b)
+		cmd.Dir = repoDir
+		if err := cmd.Run(); err == nil {
+			return b
+		}
+	}
+	return "HEAD"
+}
@@ -0,0 +1,33 @@
+package basebranch
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-q", "-b", "trunk")
+	if got := Default(dir); got != "HEAD" {
+		t.Errorf("Default without commits = %q, want HEAD", got)
+	}
+	run("commit", "-q", "--allow-empty", "-m", "init")
+	run("branch", "master")
+	if got := Default(dir); got != "master" {
+		t.Errorf("Default = %q, want master", got)
+	}
+	run("branch", "dev")
+	if got := Default(dir); got != "dev" {
+		t.Errorf("Default = %q, want dev before master", got)
+	}
+}
@@ -392,3 +392,21 @@ func createTestMappingFile(dir string) string {
 	os.WriteFile(mappingPath, []byte(content), 0644)
 	return mappingPath
 }
+
+func TestUpdateMappingCreatesFileAndLinks(t *testing.T) {
+	client := NewClientWithDir(t.TempDir())
+
+	links, err := client.Links()
+	if err != nil || len(links) != 0 {
+		t.Fatalf("Links() on missing file = %v, %v", links, err)
+	}
+	for _, id := range []string{"sdp-a1", "sdp-b2"} {
+		if err := client.UpdateMapping("00-049-01", id); err != nil {
+			t.Fatalf("UpdateMapping() failed: %v", err)
+		}
+	}
+	links, err = client.Links()
+	if err != nil || len(links) != 1 || links["sdp-b2"] != "00-049-01" {
+		t.Errorf("Links() = %v, %v; want one rewritten entry", links, err)
+	}
+}
@@ -5,11 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
+// MappingFile links workstream IDs to Beads IDs, one JSON entry per line
+const MappingFile = ".beads-sdp-mapping.jsonl"
+
 // Client wraps the Beads CLI for task tracking
 type Client struct {
+	dir            string // bd working directory; "" is the current one
 	mappingPath    string
 	beadsInstalled bool
 }
@@ -34,7 +39,7 @@ func NewClient() (*Client, error) {
 	beadsInstalled := isBeadsInstalled()
 	mappingPath, err := findMappingFile()
 	if err != nil {
-		mappingPath = ".beads-sdp-mapping.jsonl"
+		mappingPath = MappingFile
 	}
 
 	return &Client{
@@ -43,6 +48,16 @@ func NewClient() (*Client, error) {
 	}, nil
 }
 
+// NewClientWithDir creates a client that runs bd in dir and keeps the
+// mapping file there
+func NewClientWithDir(dir string) *Client {
+	return &Client{
+		dir:            dir,
+		mappingPath:    filepath.Join(dir, MappingFile),
+		beadsInstalled: isBeadsInstalled(),
+	}
+}
+
 // Ready returns available tasks
 func (c *Client) Ready() ([]Task, error) {
 	if !c.beadsInstalled {
@@ -51,7 +66,7 @@ func (c *Client) Ready() ([]Task, error) {
 
 	output, err := c.runBeadsCommand("ready")
 	if err != nil {
-		return []Task{}, fmt.Errorf("bd ready failed: %w", err)
+		return []Task{}, err
 	}
 
 	tasks := c.parseTaskList(output)
@@ -69,7 +84,7 @@ func (c *Client) Show(beadsID string) (*Task, error) {
 
 	output, err := c.runBeadsCommand("show", beadsID)
 	if err != nil {
-		return nil, fmt.Errorf("bd show failed: %w", err)
+		return nil, err
 	}
 
 	task := &Task{ID: beadsID}
@@ -93,11 +108,7 @@ func (c *Client) Update(beadsID string, status string) error {
 	}
 
 	_, err := c.runBeadsCommand("update", beadsID, "--status", status)
-	if err != nil {
-		return fmt.Errorf("bd update failed: %w", err)
-	}
-
-	return nil
+	return err
 }
 
 // MapWSToBeads converts workstream ID to Beads ID
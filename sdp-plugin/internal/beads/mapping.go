@@ -2,15 +2,49 @@ package beads
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
 )
 
-// UpdateMapping updates the mapping file with a new entry
-func (c *Client) UpdateMapping(wsID, beadsID string) error {
+// Links maps Beads IDs to workstream IDs; a missing mapping file is empty
+func (c *Client) Links() (map[string]string, error) {
+	links := make(map[string]string)
 	entries, err := c.readMapping()
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return links, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.BeadsID != "" {
+			links[entry.BeadsID] = entry.SdpID
+		}
+	}
+	return links, nil
+}
+
+// UpdateMapping updates the mapping file with a new entry. The file is
+// rewritten under a lock on its directory, so concurrent sessions do not
+// lose entries.
+func (c *Client) UpdateMapping(wsID, beadsID string) error {
+	lock, err := os.Open(filepath.Dir(c.mappingPath))
+	if err != nil {
+		return fmt.Errorf("failed to open mapping directory: %w", err)
+	}
+	defer func() { _ = lock.Close() }()
+	if err := filelock.Lock(lock); err != nil {
+		return fmt.Errorf("failed to lock mapping file: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lock) }()
+
+	entries, err := c.readMapping()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return err
 	}
 
@@ -40,28 +74,35 @@ func (c *Client) UpdateMapping(wsID, beadsID string) error {
 	return c.writeMapping(entries)
 }
 
-// writeMapping writes entries to the mapping file
+// writeMapping replaces the mapping file with entries via a temp file
 func (c *Client) writeMapping(entries []mappingEntry) error {
-	file, err := os.Create(c.mappingPath)
-	if err != nil {
-		return fmt.Errorf("failed to create mapping file: %w", err)
-	}
-	defer func() {
-		if cerr := file.Close(); cerr != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to close mapping file: %v\n", cerr)
-		}
-	}()
-
+	var data []byte
 	for _, entry := range entries {
-		data, err := json.Marshal(entry)
+		line, err := json.Marshal(entry)
 		if err != nil {
 			return fmt.Errorf("failed to marshal entry: %w", err)
 		}
-
-		if _, err := file.Write(append(data, '\n')); err != nil {
-			return fmt.Errorf("failed to write entry: %w", err)
-		}
+		data = append(append(data, line...), '\n')
 	}
 
+	tmp, err := os.CreateTemp(filepath.Dir(c.mappingPath), ".beads-sdp-mapping-*")
+	if err != nil {
+		return fmt.Errorf("failed to create mapping file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to create mapping file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write mapping file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.mappingPath); err != nil {
+		return fmt.Errorf("failed to replace mapping file: %w", err)
+	}
 	return nil
 }
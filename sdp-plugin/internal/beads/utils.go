@@ -1,17 +1,34 @@
 package beads
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 )
 
+// Run executes a Beads CLI command in the client's directory and returns
+// its stdout. A failing command reports what bd printed on stderr.
+func (c *Client) Run(args ...string) ([]byte, error) {
+	cmd := exec.Command("bd", args...)
+	cmd.Dir = c.dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("bd %s failed: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("bd %s failed: %w", args[0], err)
+	}
+	return out, nil
+}
+
 // runBeadsCommand executes a Beads CLI command
 func (c *Client) runBeadsCommand(args ...string) (string, error) {
-	cmd := exec.Command("bd", args...)
-	output, err := cmd.Output()
+	output, err := c.Run(args...)
 	if err != nil {
-		return "", fmt.Errorf("command failed: %w", err)
+		return "", err
 	}
 
 	return string(output), nil
@@ -27,8 +44,8 @@ func isBeadsInstalled() bool {
 func findMappingFile() (string, error) {
 	// Try common locations
 	locations := []string{
-		".beads-sdp-mapping.jsonl",
-		"../.beads-sdp-mapping.jsonl",
+		MappingFile,
+		"../" + MappingFile,
 	}
 
 	for _, loc := range locations {
@@ -0,0 +1,143 @@
+// Package breakerstate reads and resets the circuit breaker and degraded-mode
+// state that the orchestrator and sdp-ci-loop persist under .sdp/state/.
+//
+// The writer is src/sdp/state in the root module, which is the source of truth
+// for the file layout and lock protocol. This package mirrors the parts
+// `sdp health` needs: reads, and resets taken under the same per-file lock.
+package breakerstate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// Dir holds breaker state, relative to the project root.
+const Dir = ".sdp/state"
+
+const (
+	circuitPrefix = "circuit-"
+	degradedFile  = "degraded.json"
+)
+
+// Circuit is the persisted state of one circuit breaker target.
+type Circuit struct {
+	Target      string    `json:"target"`
+	State       string    `json:"state"` // closed, open, half-open
+	Failures    int       `json:"failures"`
+	LastFailure time.Time `json:"last_failure"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// IsOpen reports whether the circuit is rejecting or probing calls.
+func (c Circuit) IsOpen() bool {
+	return c.State == "open" || c.State == "half-open"
+}
+
+// Degraded is the persisted degraded-mode state.
+type Degraded struct {
+	Active bool      `json:"active"`
+	Reason string    `json:"reason"`
+	Since  time.Time `json:"since"`
+}
+
+// Store reads and resets state files under .sdp/state/.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at projectRoot/.sdp/state.
+func NewStore(projectRoot string) *Store {
+	return &Store{dir: filepath.Join(projectRoot, Dir)}
+}
+
+// Circuits returns every persisted circuit sorted by target.
+func (s *Store) Circuits() ([]Circuit, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, circuitPrefix+"*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var out []Circuit
+	for _, path := range matches {
+		var c Circuit
+		if err := readJSON(path, &c); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	slices.SortFunc(out, func(a, b Circuit) int { return strings.Compare(a.Target, b.Target) })
+	return out, nil
+}
+
+// ResetCircuit closes the target's circuit by removing its state.
+func (s *Store) ResetCircuit(target string) error {
+	return s.removeLocked(s.circuitPath(target))
+}
+
+// Degraded returns the degraded-mode state. A missing file yields inactive.
+func (s *Store) Degraded() (Degraded, error) {
+	var d Degraded
+	err := readJSON(filepath.Join(s.dir, degradedFile), &d)
+	return d, err
+}
+
+// ResetDegraded clears degraded mode.
+func (s *Store) ResetDegraded() error {
+	return s.removeLocked(filepath.Join(s.dir, degradedFile))
+}
+
+var reUnsafeTarget = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// circuitPath maps a target such as "model:claude-opus" to its file name,
+// exactly as src/sdp/state does: the sanitized target plus a hash of the
+// raw target, so targets that sanitize alike do not share a file.
+func (s *Store) circuitPath(target string) string {
+	sum := sha256.Sum256([]byte(target))
+	name := reUnsafeTarget.ReplaceAllString(target, "_") + "-" + hex.EncodeToString(sum[:4])
+	return filepath.Join(s.dir, circuitPrefix+name+".json")
+}
+
+// removeLocked deletes path under its .lock file, so a breaker update in
+// flight cannot rewrite the state right after the reset.
+func (s *Store) removeLocked(path string) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	lf, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open state lock: %w", err)
+	}
+	defer lf.Close()
+	if err := filelock.Lock(lf); err != nil {
+		return fmt.Errorf("acquire state lock: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lf) }()
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse state %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package breakerstate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeState(t *testing.T, root, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, Dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStore_Circuits(t *testing.T) {
+	root := t.TempDir()
+	writeState(t, root, "circuit-model_claude-opus-9665e2f5.json", `{"target":"model:claude-opus","state":"open","failures":5}`)
+	writeState(t, root, "circuit-ci_github-2e3074eb.json", `{"target":"ci:github","state":"closed"}`)
+
+	circuits, err := NewStore(root).Circuits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(circuits) != 2 || circuits[0].Target != "ci:github" || circuits[1].Target != "model:claude-opus" {
+		t.Fatalf("expected circuits sorted by target, got %+v", circuits)
+	}
+	if circuits[0].IsOpen() || !circuits[1].IsOpen() {
+		t.Errorf("unexpected open states: %+v", circuits)
+	}
+}
+
+func TestStore_Reset(t *testing.T) {
+	root := t.TempDir()
+	writeState(t, root, "circuit-model_claude-opus-9665e2f5.json", `{"target":"model:claude-opus","state":"open"}`)
+	writeState(t, root, "degraded.json", `{"active":true,"reason":"github unavailable"}`)
+	s := NewStore(root)
+
+	if d, err := s.Degraded(); err != nil || !d.Active || d.Reason != "github unavailable" {
+		t.Fatalf("Degraded = %+v, %v", d, err)
+	}
+	if err := s.ResetCircuit("model:claude-opus"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ResetDegraded(); err != nil {
+		t.Fatal(err)
+	}
+	if circuits, _ := s.Circuits(); len(circuits) != 0 {
+		t.Errorf("expected no circuits after reset, got %+v", circuits)
+	}
+	if d, _ := s.Degraded(); d.Active {
+		t.Error("expected degraded mode cleared")
+	}
+	if _, err := os.Stat(filepath.Join(root, Dir, "degraded.json.lock")); err != nil {
+		t.Errorf("reset must take the state lock: %v", err)
+	}
+	if err := s.ResetCircuit("model:missing"); err != nil {
+		t.Errorf("resetting a missing circuit: %v", err)
+	}
+}
+
+func TestStore_CircuitPathIsCollisionFree(t *testing.T) {
+	s := NewStore(t.TempDir())
+	// Pinned to the name src/sdp/state writes for the same target.
+	if got := filepath.Base(s.circuitPath("model:claude-opus")); got != "circuit-model_claude-opus-9665e2f5.json" {
+		t.Errorf("circuitPath = %s", got)
+	}
+	if s.circuitPath("model:a/b") == s.circuitPath("model:a_b") {
+		t.Error("targets that sanitize alike must not share a file")
+	}
+}
@@ -53,7 +53,8 @@ func NewManager(dir string) *Manager {
 	}
 }
 
-// Save saves a checkpoint to disk
+// Save saves a checkpoint to disk and records it as the orchestrator
+// section of its run in the unified checkpoint store.
 func (m *Manager) Save(cp Checkpoint) error {
 	// Ensure directory exists
 	if err := os.MkdirAll(m.dir, 0o755); err != nil {
@@ -72,6 +73,10 @@ func (m *Manager) Save(cp Checkpoint) error {
 		return fmt.Errorf("failed to write checkpoint file: %w", err)
 	}
 
+	if err := NewStore(m.dir).Put(cp.ID, cp.FeatureID, EngineOrchestrator, filepath.Base(filename), cp); err != nil {
+		return fmt.Errorf("failed to store checkpoint: %w", err)
+	}
+
 	return nil
 }
 
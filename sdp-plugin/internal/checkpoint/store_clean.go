@@ -0,0 +1,93 @@
+package checkpoint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// Clean removes runs last updated before cutoff, with their history and the
+// legacy files their sections name, so Migrate does not import them again.
+// Runs whose orchestrator section is not completed are kept. Returns the
+// removed run IDs.
+func (s *Store) Clean(cutoff time.Time) ([]string, error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	for _, env := range runs {
+		if !env.UpdatedAt.Before(cutoff) {
+			continue
+		}
+		var cp Checkpoint
+		if ok, err := s.Section(env.RunID, EngineOrchestrator, &cp); err != nil {
+			return removed, err
+		} else if ok && cp.Status != StatusCompleted {
+			continue
+		}
+		if err := s.remove(env); err != nil {
+			return removed, fmt.Errorf("remove run %s: %w", env.RunID, err)
+		}
+		removed = append(removed, env.RunID)
+	}
+	return removed, nil
+}
+
+// PruneHistory trims the history of every run to the newest keep revisions.
+// Returns the number of revisions removed.
+func (s *Store) PruneHistory(keep int) (int, error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, env := range runs {
+		err := s.withRunLock(env.RunID, func() error {
+			n, err := s.prune(env.RunID, keep)
+			total += n
+			return err
+		})
+		if err != nil {
+			return total, fmt.Errorf("prune %s: %w", env.RunID, err)
+		}
+	}
+	return total, nil
+}
+
+// remove deletes a run's legacy files, history and envelope under its lock.
+func (s *Store) remove(env Envelope) error {
+	return s.withRunLock(env.RunID, func() error {
+		for _, sec := range env.Sections {
+			if sec.File == "" {
+				continue
+			}
+			if err := os.Remove(filepath.Join(s.dir, filepath.Base(sec.File))); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.RemoveAll(s.historyDir(env.RunID)); err != nil {
+			return err
+		}
+		if err := os.Remove(s.runPath(env.RunID)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *Store) withRunLock(runID string, fn func() error) error {
+	lf, err := os.OpenFile(s.runPath(runID)+".lock", os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open checkpoint lock: %w", err)
+	}
+	defer lf.Close()
+	if err := filelock.Lock(lf); err != nil {
+		return fmt.Errorf("acquire checkpoint lock: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lf) }()
+	return fn()
+}
@@ -0,0 +1,73 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+)
+
+// Change is one differing leaf between two revisions. Path is
+// "<engine>.<json path>"; Old or New is empty when the leaf was added or removed.
+type Change struct {
+	Path string
+	Old  string
+	New  string
+}
+
+// Diff compares the sections of two revisions leaf by leaf, sorted by path.
+func Diff(a, b *Envelope) []Change {
+	left, right := flattenSections(a), flattenSections(b)
+	var changes []Change
+	for path, old := range left {
+		if cur, ok := right[path]; !ok || cur != old {
+			changes = append(changes, Change{Path: path, Old: old, New: right[path]})
+		}
+	}
+	for path, cur := range right {
+		if _, ok := left[path]; !ok {
+			changes = append(changes, Change{Path: path, New: cur})
+		}
+	}
+	slices.SortFunc(changes, func(x, y Change) int {
+		switch {
+		case x.Path < y.Path:
+			return -1
+		case x.Path > y.Path:
+			return 1
+		}
+		return 0
+	})
+	return changes
+}
+
+func flattenSections(env *Envelope) map[string]string {
+	out := make(map[string]string)
+	if env == nil {
+		return out
+	}
+	for engine, sec := range env.Sections {
+		var v any
+		if json.Unmarshal(sec.Data, &v) != nil {
+			out[engine] = string(sec.Data)
+			continue
+		}
+		flatten(engine, v, out)
+	}
+	return out
+}
+
+func flatten(prefix string, v any, out map[string]string) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			flatten(prefix+"."+k, child, out)
+		}
+	case []any:
+		for i, child := range t {
+			flatten(prefix+"["+strconv.Itoa(i)+"]", child, out)
+		}
+	default:
+		data, _ := json.Marshal(t)
+		out[prefix] = string(data)
+	}
+}
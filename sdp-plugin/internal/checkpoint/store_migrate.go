@@ -0,0 +1,81 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// graphSuffix is the legacy file suffix of src/sdp/graph checkpoints.
+const graphSuffix = "-checkpoint.json"
+
+// legacyFields holds the keys used to tell the pre-store formats apart.
+type legacyFields struct {
+	ID        string `json:"id"`
+	Schema    string `json:"schema"`
+	FeatureID string `json:"feature_id"`
+	Phase     string `json:"phase"`
+}
+
+// Migrate imports legacy checkpoint files (version 1) into the store as
+// sections of their run. Files whose engine already has a section are left
+// alone, so Migrate is safe to call before every read. Returns the number of
+// sections imported.
+func (s *Store) Migrate() (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint directory: %w", err)
+	}
+	imported := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return imported, err
+		}
+		var f legacyFields
+		if json.Unmarshal(data, &f) != nil {
+			continue // corrupt or foreign file
+		}
+		runID, engine := classifyLegacy(name, f)
+		if engine == "" || ValidateRunID(runID) != nil {
+			continue
+		}
+		env, err := s.Load(runID)
+		if err != nil {
+			return imported, err
+		}
+		if env != nil {
+			if _, ok := env.Sections[engine]; ok {
+				continue
+			}
+		}
+		if err := s.Put(runID, f.FeatureID, engine, name, json.RawMessage(data)); err != nil {
+			return imported, fmt.Errorf("migrate %s: %w", name, err)
+		}
+		imported++
+	}
+	return imported, nil
+}
+
+// classifyLegacy returns the run and engine of a legacy checkpoint file.
+// Orchestrate and ciloop share F{NNN}.json; it is imported as orchestrate.
+func classifyLegacy(name string, f legacyFields) (runID, engine string) {
+	switch {
+	case strings.HasSuffix(name, graphSuffix):
+		return strings.TrimSuffix(name, graphSuffix), EngineGraph
+	case f.Schema != "" && f.FeatureID != "" && f.Phase != "":
+		return f.FeatureID, EngineOrchestrate
+	case f.ID != "":
+		return f.ID, EngineOrchestrator
+	}
+	return "", ""
+}
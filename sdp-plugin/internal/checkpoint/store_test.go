@@ -0,0 +1,144 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeLegacy(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoreMigrateLegacyFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeLegacy(t, dir, "F014.json", `{"schema":"1.0","feature_id":"F014","branch":"b","phase":"build"}`)
+	writeLegacy(t, dir, "F014-checkpoint.json", `{"feature_id":"F014","completed_nodes":["00-014-01"]}`)
+	writeLegacy(t, dir, "run-1.json", `{"id":"run-1","feature_id":"F020","status":"in_progress"}`)
+	writeLegacy(t, dir, "notes.json", `{"foo":"bar"}`)
+
+	s := NewStore(dir)
+	n, err := s.Migrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("expected 3 imported sections, got %d", n)
+	}
+	env, err := s.Load("F014")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := env.Sections[EngineOrchestrate]; !ok {
+		t.Errorf("expected orchestrate section, got %v", env.Sections)
+	}
+	if sec := env.Sections[EngineGraph]; sec.File != "F014-checkpoint.json" {
+		t.Errorf("expected graph section from F014-checkpoint.json, got %+v", sec)
+	}
+
+	// Second run is a no-op.
+	if n, err := s.Migrate(); err != nil || n != 0 {
+		t.Errorf("expected idempotent migrate, got %d, %v", n, err)
+	}
+}
+
+func TestManagerSaveRecordsRun(t *testing.T) {
+	dir := t.TempDir()
+	m := NewManager(dir)
+	if err := m.Save(Checkpoint{ID: "F020", FeatureID: "F020", Status: StatusInProgress}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save(Checkpoint{ID: "F020", FeatureID: "F020", Status: StatusCompleted}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewStore(dir)
+	prev, err := s.Revision("F020", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cur, err := s.Load("F020")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := Diff(prev, cur)
+	if len(changes) != 1 || changes[0].Path != "orchestrator.status" ||
+		changes[0].Old != `"in_progress"` || changes[0].New != `"completed"` {
+		t.Fatalf("unexpected diff: %+v", changes)
+	}
+
+	if _, err := s.Rollback("F020", 1); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := m.Load("F020")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.Status != StatusInProgress {
+		t.Errorf("expected rollback to restore in_progress, got %s", cp.Status)
+	}
+
+	runs, err := m.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Errorf("expected store directory to be ignored by List, got %d checkpoints", len(runs))
+	}
+}
+
+func TestDiffAddedAndRemovedFields(t *testing.T) {
+	a := &Envelope{Sections: map[string]Section{"graph": {Data: []byte(`{"nodes":["a"],"gone":1}`)}}}
+	b := &Envelope{Sections: map[string]Section{"graph": {Data: []byte(`{"nodes":["a","b"]}`)}}}
+	var paths []string
+	for _, c := range Diff(a, b) {
+		paths = append(paths, c.Path)
+	}
+	if got := strings.Join(paths, ","); got != "graph.gone,graph.nodes[1]" {
+		t.Errorf("unexpected changed paths: %s", got)
+	}
+}
+
+func TestStoreCleanAndPruneHistory(t *testing.T) {
+	dir := t.TempDir()
+	writeLegacy(t, dir, "F014.json", `{"schema":"1.0","feature_id":"F014","branch":"b","phase":"build"}`)
+	writeLegacy(t, dir, "run-1.json", `{"id":"run-1","feature_id":"F020","status":"in_progress"}`)
+	s := NewStore(dir)
+	if _, err := s.Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 3 {
+		if err := s.Put("F014", "F014", EngineCILoop, "F014.json", map[string]int{"iter": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n, err := s.PruneHistory(1); err != nil || n != 2 {
+		t.Errorf("PruneHistory = %d, %v; want 2 removed", n, err)
+	}
+	if revs, _ := s.History("F014"); len(revs) != 1 || revs[0] != 3 {
+		t.Errorf("history after prune = %v", revs)
+	}
+
+	removed, err := s.Clean(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0] != "F014" {
+		t.Fatalf("Clean removed %v; in-progress orchestrator runs are kept", removed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "F014.json")); !os.IsNotExist(err) {
+		t.Error("legacy file of a cleaned run must be removed")
+	}
+	if n, _ := s.Migrate(); n != 0 {
+		t.Errorf("cleaned run re-imported (%d sections)", n)
+	}
+	if env, _ := s.Load("run-1"); env == nil {
+		t.Error("in-progress run must be kept")
+	}
+}
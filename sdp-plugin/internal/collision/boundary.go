@@ -3,7 +3,6 @@ package collision
 import (
 	"encoding/json"
 	"slices"
-	"strings"
 )
 
 // FeatureScope represents a feature's scope for boundary detection.
@@ -16,6 +15,7 @@ type FeatureScope struct {
 type SharedBoundary struct {
 	FileName       string      `json:"fileName"`
 	TypeName       string      `json:"typeName"`
+	Language       string      `json:"language,omitempty"` // go, typescript, python
 	Fields         []FieldInfo `json:"fields"`
 	Features       []string    `json:"features"`
 	Recommendation string      `json:"recommendation"`
@@ -28,6 +28,8 @@ type FieldInfo struct {
 }
 
 // DetectBoundaries analyzes scope files to identify shared boundaries (types/interfaces).
+// Go structs, TypeScript interfaces/object types and Python dataclasses/pydantic
+// models are supported.
 func DetectBoundaries(features []FeatureScope) []SharedBoundary {
 	fileToFeatures := buildFileToFeatures(features)
 	var boundaries []SharedBoundary
@@ -40,18 +42,18 @@ func DetectBoundaries(features []FeatureScope) []SharedBoundary {
 		// Resolve relative path before parsing (bug fix for sdp-zidp)
 		resolvedFile := resolveFilePath(file)
 
-		// Parse Go file to extract types
-		types, err := extractGoTypes(resolvedFile)
+		// Parse file to extract types
+		decls, err := extractTypeDecls(resolvedFile)
 		if err != nil {
 			continue // Skip files that can't be parsed
 		}
 
-		for _, typeName := range types {
-			fields := extractStructFields(resolvedFile, typeName)
+		for _, decl := range decls {
 			boundaries = append(boundaries, SharedBoundary{
 				FileName:       file, // Store original path
-				TypeName:       typeName,
-				Fields:         fields,
+				TypeName:       decl.Name,
+				Language:       languageOf(file),
+				Fields:         decl.Fields,
 				Features:       featureIDs,
 				Recommendation: "Create shared interface contract",
 			})
@@ -71,8 +73,8 @@ func buildFileToFeatures(features []FeatureScope) map[string][]string {
 			if file == "" {
 				continue
 			}
-			// Check if it's a Go file
-			if !strings.HasSuffix(file, ".go") {
+			// Check if it's a supported language
+			if languageOf(file) == "" {
 				continue
 			}
 			// Deduplicate: only add if featureID not already present
@@ -0,0 +1,217 @@
+package collision
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// typeDecl is a record type found in a source file.
+type typeDecl struct {
+	Name   string
+	Fields []FieldInfo
+}
+
+// extractTypeDecls returns the record types declared in a Go, TypeScript or
+// Python file. Go returns every named type (non-structs without fields);
+// TypeScript returns interfaces and object type aliases; Python returns
+// dataclasses, pydantic models and TypedDicts.
+// Note: path should already be resolved via resolveFilePath().
+func extractTypeDecls(path string) ([]typeDecl, error) {
+	switch languageOf(path) {
+	case LangGo:
+		names, err := extractGoTypes(path)
+		if err != nil {
+			return nil, err
+		}
+		decls := make([]typeDecl, 0, len(names))
+		for _, n := range names {
+			decls = append(decls, typeDecl{Name: n, Fields: extractStructFields(path, n)})
+		}
+		return decls, nil
+	case LangTypeScript:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return extractTSTypes(string(data)), nil
+	case LangPython:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return extractPythonModels(string(data)), nil
+	}
+	return nil, fmt.Errorf("unsupported language: %s", path)
+}
+
+// extractTypeFields returns the fields of typeName in path and whether the
+// type is declared there.
+func extractTypeFields(path, typeName string) ([]FieldInfo, bool, error) {
+	decls, err := extractTypeDecls(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("parse implementation: %w", err)
+	}
+	for _, d := range decls {
+		if d.Name == typeName {
+			return d.Fields, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+var (
+	reTSComment   = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	reTSTypeStart = regexp.MustCompile(`(?m)^\s*(?:export\s+)?(?:declare\s+)?(?:interface\s+(\w+)(?:\s*<[^>{]*>)?(?:\s+extends\s+[^{]+)?|type\s+(\w+)(?:\s*<[^>=]*>)?\s*=)\s*\{`)
+	reTSMember    = regexp.MustCompile(`^(?:readonly\s+)?["']?([A-Za-z_$][\w$]*)["']?(\?)?\s*:\s*(.+)$`)
+)
+
+// extractTSTypes finds interfaces and `type X = { ... }` aliases. Method
+// signatures and index signatures are skipped.
+func extractTSTypes(src string) []typeDecl {
+	src = reTSComment.ReplaceAllString(src, "")
+	var decls []typeDecl
+	for _, m := range reTSTypeStart.FindAllStringSubmatchIndex(src, -1) {
+		var name string
+		if m[2] >= 0 {
+			name = src[m[2]:m[3]] // interface
+		} else {
+			name = src[m[4]:m[5]] // type alias
+		}
+		body, ok := braceBody(src, m[1]-1)
+		if !ok {
+			continue
+		}
+		d := typeDecl{Name: name}
+		for _, member := range splitTSMembers(body) {
+			mm := reTSMember.FindStringSubmatch(member)
+			if mm == nil {
+				continue
+			}
+			typ := strings.TrimSpace(mm[3])
+			if mm[2] == "?" {
+				typ += " | undefined"
+			}
+			d.Fields = append(d.Fields, FieldInfo{Name: mm[1], Type: typ})
+		}
+		decls = append(decls, d)
+	}
+	return decls
+}
+
+// braceBody returns the text between the brace at open and its match.
+func braceBody(src string, open int) (string, bool) {
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return src[open+1 : i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitTSMembers splits an object type body on ; , and newlines at depth 0.
+func splitTSMembers(body string) []string {
+	var out []string
+	depth, start := 0, 0
+	flush := func(end int) {
+		if s := strings.TrimSpace(body[start:end]); s != "" {
+			out = append(out, s)
+		}
+		start = end + 1
+	}
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '{', '(', '[', '<':
+			depth++
+		case '}', ')', ']', '>':
+			if body[i] == '>' && i > 0 && body[i-1] == '=' {
+				continue // arrow in a function type
+			}
+			depth--
+		case ';', ',', '\n':
+			if depth == 0 {
+				flush(i)
+			}
+		}
+	}
+	flush(len(body))
+	return out
+}
+
+var (
+	rePyClass = regexp.MustCompile(`^class\s+(\w+)\s*(?:\(([^)]*)\))?\s*:`)
+	rePyField = regexp.MustCompile(`^(\w+)\s*:\s*([^=#]+?)\s*(?:=.*)?(?:#.*)?$`)
+)
+
+// extractPythonModels finds @dataclass classes, pydantic BaseModel
+// subclasses and TypedDicts, reading annotated fields at class-body level.
+func extractPythonModels(src string) []typeDecl {
+	var decls []typeDecl
+	var cur *typeDecl
+	bodyIndent := -1
+	dataclass := false
+
+	sc := bufio.NewScanner(strings.NewReader(src))
+	for sc.Scan() {
+		line := sc.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if indent == 0 {
+			if cur != nil {
+				decls = append(decls, *cur)
+				cur = nil
+			}
+			if strings.HasPrefix(trimmed, "@dataclass") || strings.HasPrefix(trimmed, "@dataclasses.dataclass") {
+				dataclass = true
+				continue
+			}
+			if m := rePyClass.FindStringSubmatch(trimmed); m != nil && (dataclass || isPythonModelBase(m[2])) {
+				cur = &typeDecl{Name: m[1]}
+				bodyIndent = -1
+			}
+			if !strings.HasPrefix(trimmed, "@") {
+				dataclass = false
+			}
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		if bodyIndent < 0 {
+			bodyIndent = indent
+		}
+		if indent != bodyIndent {
+			continue // method bodies and continuation lines
+		}
+		if m := rePyField.FindStringSubmatch(trimmed); m != nil && !strings.HasPrefix(m[2], "ClassVar") {
+			cur.Fields = append(cur.Fields, FieldInfo{Name: m[1], Type: strings.TrimSpace(m[2])})
+		}
+	}
+	if cur != nil {
+		decls = append(decls, *cur)
+	}
+	return decls
+}
+
+func isPythonModelBase(bases string) bool {
+	for _, b := range strings.Split(bases, ",") {
+		switch strings.TrimSpace(b) {
+		case "BaseModel", "pydantic.BaseModel", "TypedDict", "typing.TypedDict":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package collision
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractTSTypes(t *testing.T) {
+	src := `// user types
+export interface User extends Base {
+  readonly id: string;
+  email?: string
+  roles: Array<'admin' | 'user'>, // inline comment
+  onChange(value: string): void;
+  meta: { source: string };
+}
+
+export type Address = {
+  line1: string;
+  zip: number;
+};
+
+type Alias = string;
+`
+	decls := extractTSTypes(src)
+	if len(decls) != 2 {
+		t.Fatalf("expected User and Address, got %+v", decls)
+	}
+	user := decls[0]
+	want := []FieldInfo{
+		{Name: "id", Type: "string"},
+		{Name: "email", Type: "string | undefined"},
+		{Name: "roles", Type: "Array<'admin' | 'user'>"},
+		{Name: "meta", Type: "{ source: string }"},
+	}
+	if user.Name != "User" || len(user.Fields) != len(want) {
+		t.Fatalf("unexpected User: %+v", user)
+	}
+	for i, f := range want {
+		if user.Fields[i] != f {
+			t.Errorf("field %d = %+v, want %+v", i, user.Fields[i], f)
+		}
+	}
+	if decls[1].Name != "Address" || len(decls[1].Fields) != 2 {
+		t.Errorf("unexpected Address: %+v", decls[1])
+	}
+}
+
+func TestExtractPythonModels(t *testing.T) {
+	src := `from dataclasses import dataclass
+from pydantic import BaseModel
+
+
+@dataclass(frozen=True)
+class User:
+    user_id: str
+    email: Optional[str] = None  # may be empty
+    tags: list[str] = field(default_factory=list)
+    MAX: ClassVar[int] = 3
+
+    def display(self) -> str:
+        name: str = self.email
+        return name
+
+
+class Address(BaseModel):
+    line1: str
+    zip: int
+
+
+class Helper:
+    value: int
+`
+	decls := extractPythonModels(src)
+	if len(decls) != 2 {
+		t.Fatalf("expected User and Address, got %+v", decls)
+	}
+	if decls[0].Name != "User" || len(decls[0].Fields) != 3 || decls[0].Fields[1].Type != "Optional[str]" {
+		t.Errorf("unexpected User: %+v", decls[0])
+	}
+	if decls[1].Name != "Address" || len(decls[1].Fields) != 2 {
+		t.Errorf("unexpected Address: %+v", decls[1])
+	}
+}
+
+func TestDetectBoundaries_TypeScriptAndPython(t *testing.T) {
+	dir := t.TempDir()
+	ts := filepath.Join(dir, "user.ts")
+	py := filepath.Join(dir, "user.py")
+	if err := os.WriteFile(ts, []byte("export interface User {\n  id: string;\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(py, []byte("@dataclass\nclass User:\n    id: str\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	boundaries := DetectBoundaries([]FeatureScope{
+		{FeatureID: "F001", ScopeFiles: []string{ts, py}},
+		{FeatureID: "F002", ScopeFiles: []string{ts, py}},
+	})
+	langs := map[string]bool{}
+	for _, b := range boundaries {
+		if b.TypeName != "User" || len(b.Fields) != 1 {
+			t.Errorf("unexpected boundary: %+v", b)
+		}
+		langs[b.Language] = true
+	}
+	if !langs[LangTypeScript] || !langs[LangPython] {
+		t.Errorf("expected typescript and python boundaries, got %+v", boundaries)
+	}
+}
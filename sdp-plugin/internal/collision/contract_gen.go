@@ -12,6 +12,7 @@ import (
 // Contract represents a shared interface contract file.
 type Contract struct {
 	TypeName   string      `yaml:"typeName"`
+	Language   string      `yaml:"language,omitempty"` // source language; empty means go
 	Fields     []FieldInfo `yaml:"fields"`
 	RequiredBy []string    `yaml:"requiredBy"`
 	Status     string      `yaml:"status"`
@@ -36,6 +37,7 @@ func GenerateContracts(boundaries []SharedBoundary, outputDir string) ([]Contrac
 
 			contract := Contract{
 				TypeName:   b.TypeName,
+				Language:   b.Language,
 				Fields:     b.Fields,
 				RequiredBy: b.Features,
 				Status:     "draft",
@@ -0,0 +1,332 @@
+package collision
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Languages with boundary extraction.
+const (
+	LangGo         = "go"
+	LangTypeScript = "typescript"
+	LangPython     = "python"
+)
+
+// languageOf returns the boundary language of a source file, or "" if unsupported.
+func languageOf(path string) string {
+	switch filepath.Ext(path) {
+	case ".go":
+		return LangGo
+	case ".ts", ".tsx":
+		return LangTypeScript
+	case ".py":
+		return LangPython
+	}
+	return ""
+}
+
+// Canonical types of the shared lattice. Composite types are written
+// list<T>, map<K,V> and optional<T>; named types as object:Name.
+// "number" (TypeScript) sits above int and float; "any" is the top.
+const (
+	typeString = "string"
+	typeInt    = "int"
+	typeFloat  = "float"
+	typeNumber = "number"
+	typeBool   = "bool"
+	typeBytes  = "bytes"
+	typeTime   = "time"
+	typeAny    = "any"
+)
+
+// canonicalType maps a language type expression onto the lattice.
+func canonicalType(lang, raw string) string {
+	raw = strings.TrimSpace(raw)
+	switch lang {
+	case LangGo:
+		return canonicalGo(raw)
+	case LangTypeScript:
+		return canonicalTS(raw)
+	case LangPython:
+		return canonicalPython(raw)
+	}
+	return typeAny
+}
+
+func canonicalGo(t string) string {
+	switch {
+	case strings.HasPrefix(t, "*"):
+		return "optional<" + canonicalGo(t[1:]) + ">"
+	case t == "[]byte":
+		return typeBytes
+	case strings.HasPrefix(t, "[]"):
+		return "list<" + canonicalGo(t[2:]) + ">"
+	case strings.HasPrefix(t, "map["):
+		if k, v, ok := splitGoMap(t); ok {
+			return "map<" + canonicalGo(k) + "," + canonicalGo(v) + ">"
+		}
+		return typeAny
+	}
+	switch t {
+	case "string":
+		return typeString
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "time.Duration":
+		return typeInt
+	case "float32", "float64":
+		return typeFloat
+	case "bool":
+		return typeBool
+	case "time.Time":
+		return typeTime
+	case "any", "interface{}", "json.RawMessage":
+		return typeAny
+	}
+	return objectType(t)
+}
+
+// splitGoMap splits "map[K]V" at the bracket that closes the key.
+func splitGoMap(t string) (key, value string, ok bool) {
+	depth := 0
+	for i := len("map"); i < len(t); i++ {
+		switch t[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return t[len("map["):i], t[i+1:], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func canonicalTS(t string) string {
+	if strings.HasPrefix(t, "(") && strings.HasSuffix(t, ")") {
+		t = strings.TrimSpace(t[1 : len(t)-1])
+	}
+	parts := splitTopLevelOn(t, '|')
+	if len(parts) > 1 {
+		var rest []string
+		nullable := false
+		for _, p := range parts {
+			p = strings.TrimSpace(p)
+			if p == "null" || p == "undefined" {
+				nullable = true
+				continue
+			}
+			rest = append(rest, p)
+		}
+		inner := typeAny
+		switch {
+		case len(rest) == 1:
+			inner = canonicalTS(rest[0])
+		case allQuoted(rest):
+			inner = typeString
+		}
+		if nullable {
+			return "optional<" + inner + ">"
+		}
+		return inner
+	}
+	switch {
+	case strings.HasSuffix(t, "[]"):
+		return "list<" + canonicalTS(strings.TrimSuffix(t, "[]")) + ">"
+	case strings.HasPrefix(t, "Array<") || strings.HasPrefix(t, "ReadonlyArray<"):
+		return "list<" + canonicalTS(genericArgs(t)[0]) + ">"
+	case strings.HasPrefix(t, "Record<"):
+		if args := genericArgs(t); len(args) == 2 {
+			return "map<" + canonicalTS(args[0]) + "," + canonicalTS(args[1]) + ">"
+		}
+	case strings.HasPrefix(t, "{") && strings.Contains(t, "[") && strings.Contains(t, "]:"):
+		// index signature: { [key: string]: V }
+		inner := strings.TrimSpace(strings.Trim(t, "{} ;"))
+		if _, v, ok := strings.Cut(inner, "]:"); ok {
+			return "map<" + typeString + "," + canonicalTS(strings.TrimSpace(v)) + ">"
+		}
+	case isQuoted(t):
+		return typeString
+	}
+	switch t {
+	case "string":
+		return typeString
+	case "number":
+		return typeNumber
+	case "bigint":
+		return typeInt
+	case "boolean":
+		return typeBool
+	case "Date":
+		return typeTime
+	case "Uint8Array", "ArrayBuffer":
+		return typeBytes
+	case "any", "unknown", "object":
+		return typeAny
+	}
+	return objectType(t)
+}
+
+func canonicalPython(t string) string {
+	t = strings.Trim(t, `"'`) // forward references
+	if parts := splitTopLevelOn(t, '|'); len(parts) > 1 {
+		return canonicalPythonUnion(parts)
+	}
+	name, args := t, []string(nil)
+	if i := strings.IndexByte(t, '['); i > 0 && strings.HasSuffix(t, "]") {
+		name, args = t[:i], splitTopLevelOn(t[i+1:len(t)-1], ',')
+	}
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "typing."), "datetime.")
+	switch name {
+	case "Optional":
+		if len(args) == 1 {
+			return "optional<" + canonicalPython(args[0]) + ">"
+		}
+	case "Union":
+		return canonicalPythonUnion(args)
+	case "list", "List", "Sequence", "set", "Set", "frozenset", "tuple", "Tuple":
+		if len(args) >= 1 {
+			return "list<" + canonicalPython(args[0]) + ">"
+		}
+		return "list<" + typeAny + ">"
+	case "dict", "Dict", "Mapping":
+		if len(args) == 2 {
+			return "map<" + canonicalPython(args[0]) + "," + canonicalPython(args[1]) + ">"
+		}
+		return "map<" + typeAny + "," + typeAny + ">"
+	case "Literal":
+		return typeString
+	case "str", "UUID", "EmailStr", "HttpUrl":
+		return typeString
+	case "int":
+		return typeInt
+	case "float", "Decimal":
+		return typeFloat
+	case "bool":
+		return typeBool
+	case "bytes":
+		return typeBytes
+	case "datetime", "date":
+		return typeTime
+	case "Any", "object":
+		return typeAny
+	}
+	return objectType(name)
+}
+
+func canonicalPythonUnion(parts []string) string {
+	var rest []string
+	nullable := false
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p == "None" {
+			nullable = true
+			continue
+		}
+		rest = append(rest, p)
+	}
+	inner := typeAny
+	if len(rest) == 1 {
+		inner = canonicalPython(rest[0])
+	}
+	if nullable {
+		return "optional<" + inner + ">"
+	}
+	return inner
+}
+
+// objectType names a user-defined type, dropping any package qualifier.
+func objectType(t string) string {
+	if i := strings.LastIndexByte(t, '.'); i >= 0 {
+		t = t[i+1:]
+	}
+	if i := strings.IndexByte(t, '<'); i >= 0 {
+		t = t[:i]
+	}
+	return "object:" + t
+}
+
+// typesCompatible reports whether two canonical types can carry the same
+// values, ignoring nullability (reported separately).
+func typesCompatible(a, b string) bool {
+	a, b = stripOptional(a), stripOptional(b)
+	if a == b || a == typeAny || b == typeAny {
+		return true
+	}
+	if a == typeNumber && (b == typeInt || b == typeFloat) || b == typeNumber && (a == typeInt || a == typeFloat) {
+		return true
+	}
+	if ea, ok := compositeArgs(a, "list<"); ok {
+		if eb, ok := compositeArgs(b, "list<"); ok {
+			return typesCompatible(ea[0], eb[0])
+		}
+	}
+	if ka, ok := compositeArgs(a, "map<"); ok && len(ka) == 2 {
+		if kb, ok := compositeArgs(b, "map<"); ok && len(kb) == 2 {
+			return typesCompatible(ka[0], kb[0]) && typesCompatible(ka[1], kb[1])
+		}
+	}
+	return false
+}
+
+func isOptional(t string) bool { return strings.HasPrefix(t, "optional<") }
+
+func stripOptional(t string) string {
+	for isOptional(t) {
+		t = t[len("optional<") : len(t)-1]
+	}
+	return t
+}
+
+func compositeArgs(t, prefix string) ([]string, bool) {
+	if !strings.HasPrefix(t, prefix) || !strings.HasSuffix(t, ">") {
+		return nil, false
+	}
+	return splitTopLevelOn(t[len(prefix):len(t)-1], ','), true
+}
+
+// genericArgs returns the type arguments of "Name<A, B>".
+func genericArgs(t string) []string {
+	i := strings.IndexByte(t, '<')
+	if i < 0 || !strings.HasSuffix(t, ">") {
+		return []string{typeAny}
+	}
+	return splitTopLevelOn(t[i+1:len(t)-1], ',')
+}
+
+// splitTopLevelOn splits s on sep outside brackets and trims each part.
+func splitTopLevelOn(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}', '>':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '\'' || s[0] == '"' || s[0] == '`') && s[len(s)-1] == s[0]
+}
+
+func allQuoted(parts []string) bool {
+	for _, p := range parts {
+		if !isQuoted(p) {
+			return false
+		}
+	}
+	return len(parts) > 0
+}
+
+// fieldKey normalizes a field name across naming conventions, so Go UserID,
+// TypeScript userId and Python user_id compare equal.
+func fieldKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
@@ -0,0 +1,67 @@
+package collision
+
+import "testing"
+
+func TestCanonicalType(t *testing.T) {
+	tests := []struct {
+		lang, raw, want string
+	}{
+		{LangGo, "int64", "int"},
+		{LangGo, "*time.Time", "optional<time>"},
+		{LangGo, "[]byte", "bytes"},
+		{LangGo, "map[string][]int", "map<string,list<int>>"},
+		{LangGo, "models.Address", "object:Address"},
+		{LangTypeScript, "number", "number"},
+		{LangTypeScript, "string | null", "optional<string>"},
+		{LangTypeScript, "'admin' | 'user'", "string"},
+		{LangTypeScript, "Array<Address>", "list<object:Address>"},
+		{LangTypeScript, "(string | number)[]", "list<any>"},
+		{LangTypeScript, "Record<string, number>", "map<string,number>"},
+		{LangTypeScript, "{ [key: string]: boolean }", "map<string,bool>"},
+		{LangPython, "Optional[datetime]", "optional<time>"},
+		{LangPython, "list[str] | None", "optional<list<string>>"},
+		{LangPython, "Dict[str, int]", "map<string,int>"},
+		{LangPython, "Literal['a', 'b']", "string"},
+	}
+	for _, tt := range tests {
+		if got := canonicalType(tt.lang, tt.raw); got != tt.want {
+			t.Errorf("canonicalType(%s, %q) = %q, want %q", tt.lang, tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestTypesCompatible(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"int", "number", true},
+		{"float", "number", true},
+		{"int", "float", false},
+		{"optional<string>", "string", true},
+		{"list<int>", "list<number>", true},
+		{"map<string,int>", "map<string,string>", false},
+		{"object:Address", "any", true},
+		{"object:Address", "object:User", false},
+		{"string", "bool", false},
+	}
+	for _, tt := range tests {
+		if got := typesCompatible(tt.a, tt.b); got != tt.want {
+			t.Errorf("typesCompatible(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFieldKeyAndCaseSplit(t *testing.T) {
+	if fieldKey("UserID") != fieldKey("userId") || fieldKey("userId") != fieldKey("user_id") {
+		t.Error("expected UserID, userId and user_id to normalize equally")
+	}
+	for in, want := range map[string]string{"UserID": "user_id", "HTTPServer": "http_server", "User": "user"} {
+		if got := toSnake(in); got != want {
+			t.Errorf("toSnake(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := toKebab("UserProfile"); got != "user-profile" {
+		t.Errorf("toKebab = %q", got)
+	}
+}
@@ -0,0 +1,191 @@
+package collision
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PredictionsPath is where the last `sdp collision predict` run is cached for `sdp status`.
+const PredictionsPath = ".sdp/collision-predictions.json"
+
+// FileConflict is a textual conflict git would report when merging two branches.
+type FileConflict struct {
+	File string `json:"file"`
+	Kind string `json:"kind"` // content, add/add, modify/delete, rename/delete, ...
+}
+
+// SymbolEdit is a declaration both branches changed. The edits may merge
+// cleanly but still clash semantically.
+type SymbolEdit struct {
+	File   string `json:"file"`
+	Symbol string `json:"symbol"`
+}
+
+// Prediction is the merge outcome for one pair of in-flight branches.
+type Prediction struct {
+	BranchA     string         `json:"branch_a"`
+	BranchB     string         `json:"branch_b"`
+	MergeBase   string         `json:"merge_base"`
+	Conflicts   []FileConflict `json:"conflicts,omitempty"`
+	SymbolEdits []SymbolEdit   `json:"symbol_edits,omitempty"`
+}
+
+// HasConflicts reports whether the merge would stop with textual conflicts.
+func (p Prediction) HasConflicts() bool { return len(p.Conflicts) > 0 }
+
+// PredictionReport is the result of one prediction run.
+type PredictionReport struct {
+	Base        string       `json:"base"`
+	Branches    []string     `json:"branches"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Predictions []Prediction `json:"predictions"`
+}
+
+// Predictor runs in-memory three-way merges (git merge-tree --write-tree)
+// without touching any worktree or index.
+type Predictor struct {
+	// RepoDir is any directory inside the repository.
+	RepoDir string
+	// Base is the integration branch every in-flight branch merges into.
+	Base string
+}
+
+// NewPredictor creates a predictor for the repository at repoDir.
+func NewPredictor(repoDir, base string) *Predictor {
+	return &Predictor{RepoDir: repoDir, Base: base}
+}
+
+// Predict merges every pair of branches and each branch with the base.
+// Pairs that merge cleanly and share no edited symbol are omitted.
+func (p *Predictor) Predict(branches []string) (*PredictionReport, error) {
+	report := &PredictionReport{Base: p.Base, Branches: branches, GeneratedAt: time.Now().UTC()}
+	refs := append([]string{p.Base}, branches...)
+	for _, ref := range refs {
+		if _, err := p.git("rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+			return nil, fmt.Errorf("unknown branch %q", ref)
+		}
+	}
+	for i, a := range refs {
+		for _, b := range refs[i+1:] {
+			pred, err := p.predictPair(a, b)
+			if err != nil {
+				return nil, err
+			}
+			if pred.HasConflicts() || len(pred.SymbolEdits) > 0 {
+				report.Predictions = append(report.Predictions, *pred)
+			}
+		}
+	}
+	return report, nil
+}
+
+func (p *Predictor) predictPair(a, b string) (*Prediction, error) {
+	base, err := p.git("merge-base", a, b)
+	if err != nil {
+		return nil, fmt.Errorf("merge-base %s %s: %w", a, b, err)
+	}
+	pred := &Prediction{BranchA: a, BranchB: b, MergeBase: strings.TrimSpace(string(base))}
+
+	out, err := p.git("merge-tree", "--write-tree", "--name-only", a, b)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		pred.Conflicts = parseMergeTree(out)
+	default:
+		return nil, fmt.Errorf("merge-tree %s %s: %w", a, b, err)
+	}
+
+	edits, err := p.symbolEdits(pred.MergeBase, a, b, pred.Conflicts)
+	if err != nil {
+		return nil, err
+	}
+	pred.SymbolEdits = edits
+	return pred, nil
+}
+
+var reConflictMsg = regexp.MustCompile(`^CONFLICT \(([^)]+)\):`)
+
+// parseMergeTree reads `git merge-tree --write-tree --name-only` output:
+// the tree OID, conflicted paths, a blank line, then CONFLICT messages.
+func parseMergeTree(out []byte) []FileConflict {
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Scan() // tree OID
+	var files []string
+	for sc.Scan() && sc.Text() != "" {
+		if f := sc.Text(); !slices.Contains(files, f) {
+			files = append(files, f)
+		}
+	}
+	kinds := make(map[string]string)
+	for sc.Scan() {
+		line := sc.Text()
+		m := reConflictMsg.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, f := range files {
+			if _, ok := kinds[f]; !ok && strings.Contains(line, f) {
+				kinds[f] = m[1]
+			}
+		}
+	}
+	conflicts := make([]FileConflict, 0, len(files))
+	for _, f := range files {
+		kind := kinds[f]
+		if kind == "" {
+			kind = "content"
+		}
+		conflicts = append(conflicts, FileConflict{File: f, Kind: kind})
+	}
+	return conflicts
+}
+
+func (p *Predictor) git(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = p.RepoDir
+	return cmd.Output()
+}
+
+// SavePredictions caches the report under the project root for `sdp status`.
+func SavePredictions(projectRoot string, report *PredictionReport) error {
+	path := filepath.Join(projectRoot, PredictionsPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create .sdp: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal predictions: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write predictions: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadPredictions reads the cached report. Returns nil if none was saved.
+func LoadPredictions(projectRoot string) (*PredictionReport, error) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, PredictionsPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var report PredictionReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", PredictionsPath, err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,192 @@
+package collision
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// lineRange is an inclusive range of lines in the merge-base version of a file.
+type lineRange struct{ start, end int }
+
+func (r lineRange) overlaps(o lineRange) bool { return r.start <= o.end && o.start <= r.end }
+
+// hunk is one change from `git diff -U0`, located in the merge-base version.
+type hunk struct {
+	lines   lineRange
+	context string // enclosing function as reported by git's diff driver
+}
+
+// symbolEdits finds declarations changed on both sides of the merge in files
+// that merge without textual conflicts.
+func (p *Predictor) symbolEdits(base, a, b string, conflicts []FileConflict) ([]SymbolEdit, error) {
+	filesA, err := p.changedFiles(base, a)
+	if err != nil {
+		return nil, err
+	}
+	filesB, err := p.changedFiles(base, b)
+	if err != nil {
+		return nil, err
+	}
+	var edits []SymbolEdit
+	for _, f := range filesA {
+		if !slices.Contains(filesB, f) || slices.ContainsFunc(conflicts, func(c FileConflict) bool { return c.File == f }) {
+			continue
+		}
+		hunksA, err := p.hunks(base, a, f)
+		if err != nil {
+			return nil, err
+		}
+		hunksB, err := p.hunks(base, b, f)
+		if err != nil {
+			return nil, err
+		}
+		var symbols []string
+		if strings.HasSuffix(f, ".go") {
+			src, err := p.git("show", base+":"+f)
+			if err != nil {
+				continue // added on both sides; merge-tree reports add/add when contents differ
+			}
+			symbols = sharedGoSymbols(src, hunksA, hunksB)
+		} else {
+			symbols = sharedContexts(hunksA, hunksB)
+		}
+		for _, s := range symbols {
+			edits = append(edits, SymbolEdit{File: f, Symbol: s})
+		}
+	}
+	return edits, nil
+}
+
+func (p *Predictor) changedFiles(base, ref string) ([]string, error) {
+	out, err := p.git("diff", "--name-only", "--no-renames", base, ref)
+	if err != nil {
+		return nil, fmt.Errorf("diff %s %s: %w", base, ref, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+var reHunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+\d+(?:,\d+)? @@ ?(.*)$`)
+
+func (p *Predictor) hunks(base, ref, file string) ([]hunk, error) {
+	out, err := p.git("diff", "-U0", "--no-renames", base, ref, "--", file)
+	if err != nil {
+		return nil, fmt.Errorf("diff %s %s -- %s: %w", base, ref, file, err)
+	}
+	return parseHunks(out), nil
+}
+
+// parseHunks reads hunk headers. Pure insertions (count 0) are placed on the
+// line they follow, so an insertion inside a function counts as editing it.
+func parseHunks(diff []byte) []hunk {
+	var out []hunk
+	sc := bufio.NewScanner(bytes.NewReader(diff))
+	for sc.Scan() {
+		m := reHunkHeader.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		start, _ := strconv.Atoi(m[1])
+		count := 1
+		if m[2] != "" {
+			count, _ = strconv.Atoi(m[2])
+		}
+		r := lineRange{start, start + count - 1}
+		if count == 0 {
+			r = lineRange{start, start}
+		}
+		out = append(out, hunk{lines: r, context: strings.TrimSpace(m[3])})
+	}
+	return out
+}
+
+// sharedGoSymbols maps both sides' hunks onto the top-level declarations of
+// the merge-base source and returns those touched by both.
+func sharedGoSymbols(src []byte, hunksA, hunksB []hunk) []string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution|parser.ParseComments)
+	if err != nil {
+		return sharedContexts(hunksA, hunksB)
+	}
+	var shared []string
+	for _, decl := range f.Decls {
+		name := declName(decl)
+		if name == "" {
+			continue
+		}
+		start := decl.Pos()
+		if fd, ok := decl.(*ast.FuncDecl); ok && fd.Doc != nil {
+			start = fd.Doc.Pos()
+		}
+		r := lineRange{fset.Position(start).Line, fset.Position(decl.End()).Line}
+		if touches(hunksA, r) && touches(hunksB, r) {
+			shared = append(shared, name)
+		}
+	}
+	return shared
+}
+
+func touches(hunks []hunk, r lineRange) bool {
+	return slices.ContainsFunc(hunks, func(h hunk) bool { return h.lines.overlaps(r) })
+}
+
+// declName names a top-level declaration: "Hello", "Server.Start", "type Config".
+func declName(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			return recvName(d.Recv.List[0].Type) + "." + d.Name.Name
+		}
+		return d.Name.Name
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, "type "+s.Name.Name)
+			case *ast.ValueSpec:
+				for _, n := range s.Names {
+					names = append(names, d.Tok.String()+" "+n.Name)
+				}
+			}
+		}
+		return strings.Join(names, ", ")
+	}
+	return ""
+}
+
+func recvName(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StarExpr:
+		return recvName(t.X)
+	case *ast.IndexExpr:
+		return recvName(t.X)
+	case *ast.IndexListExpr:
+		return recvName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// sharedContexts falls back to git's hunk context line (the enclosing
+// function heuristic) for languages without a parser here.
+func sharedContexts(hunksA, hunksB []hunk) []string {
+	var shared []string
+	for _, h := range hunksA {
+		if h.context == "" || slices.Contains(shared, h.context) {
+			continue
+		}
+		if slices.ContainsFunc(hunksB, func(o hunk) bool { return o.context == h.context }) {
+			shared = append(shared, h.context)
+		}
+	}
+	return shared
+}
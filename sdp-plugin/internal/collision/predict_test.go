@@ -0,0 +1,123 @@
+package collision
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const predictBase = `package app
+
+func Run() int {
+	x := 1
+	y := 2
+	z := 3
+	return x + y + z
+}
+
+func Stop() {}
+`
+
+// predictRepo creates a repo with branch a editing Run's body, b editing the
+// same line (textual conflict) and c changing another line of Run.
+func predictRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "app.go"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q", "-b", "main")
+	write(predictBase)
+	run("add", ".")
+	run("commit", "-qm", "base")
+	for branch, content := range map[string]string{
+		"a": strings.Replace(predictBase, "x := 1", "x := 2", 1),
+		"b": strings.Replace(predictBase, "x := 1", "x := 3", 1),
+		"c": strings.Replace(predictBase, "return x + y + z", "return x * y * z", 1),
+	} {
+		run("checkout", "-q", "-b", branch, "main")
+		write(content)
+		run("commit", "-qam", branch)
+	}
+	run("checkout", "-q", "main")
+	return dir
+}
+
+func TestPredictorReportsConflictsAndSymbolEdits(t *testing.T) {
+	dir := predictRepo(t)
+	report, err := NewPredictor(dir, "main").Predict([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byPair := make(map[string]Prediction)
+	for _, p := range report.Predictions {
+		byPair[p.BranchA+"-"+p.BranchB] = p
+	}
+	if len(byPair) != 3 {
+		t.Fatalf("expected predictions for a-b, a-c and b-c only, got %+v", report.Predictions)
+	}
+	ab := byPair["a-b"]
+	if !ab.HasConflicts() || ab.Conflicts[0].File != "app.go" || ab.Conflicts[0].Kind != "content" {
+		t.Errorf("expected content conflict in app.go for a-b, got %+v", ab)
+	}
+	ac := byPair["a-c"]
+	if ac.HasConflicts() {
+		t.Errorf("expected a-c to merge cleanly, got %+v", ac.Conflicts)
+	}
+	if len(ac.SymbolEdits) != 1 || ac.SymbolEdits[0].Symbol != "Run" {
+		t.Errorf("expected both a and c to edit Run, got %+v", ac.SymbolEdits)
+	}
+}
+
+func TestPredictorUnknownBranch(t *testing.T) {
+	dir := predictRepo(t)
+	if _, err := NewPredictor(dir, "main").Predict([]string{"missing"}); err == nil {
+		t.Error("expected error for unknown branch")
+	}
+}
+
+func TestParseHunks(t *testing.T) {
+	diff := []byte("@@ -4 +4 @@ func Run() int {\n-\tx := 1\n+\tx := 2\n@@ -7,0 +8 @@ func Stop() {}\n+// new\n")
+	hunks := parseHunks(diff)
+	if len(hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %+v", hunks)
+	}
+	if hunks[0].lines != (lineRange{4, 4}) || hunks[0].context != "func Run() int {" {
+		t.Errorf("unexpected first hunk: %+v", hunks[0])
+	}
+	if hunks[1].lines != (lineRange{7, 7}) {
+		t.Errorf("expected insertion anchored at line 7, got %+v", hunks[1])
+	}
+}
+
+func TestSaveLoadPredictions(t *testing.T) {
+	root := t.TempDir()
+	if got, err := LoadPredictions(root); err != nil || got != nil {
+		t.Fatalf("expected nil without cache, got %+v, %v", got, err)
+	}
+	if err := SavePredictions(root, &PredictionReport{Base: "main", Predictions: []Prediction{{BranchA: "a", BranchB: "b"}}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPredictions(root)
+	if err != nil || got == nil || got.Predictions[0].BranchB != "b" {
+		t.Errorf("unexpected round trip: %+v, %v", got, err)
+	}
+}
@@ -9,28 +9,53 @@ import (
 
 // Violation represents a contract validation issue.
 type Violation struct {
-	Type     string `json:"type"`     // missing_field, type_mismatch, extra_field
-	Field    string `json:"field"`    // Field name
-	Expected string `json:"expected"` // Expected type/value
-	Actual   string `json:"actual"`   // Actual type/value
-	Severity string `json:"severity"` // error, warning
-	Message  string `json:"message"`  // Human-readable message
+	Type     string `json:"type"`               // missing_field, type_mismatch, extra_field
+	Field    string `json:"field"`              // Field name
+	Expected string `json:"expected"`           // Expected type/value
+	Actual   string `json:"actual"`             // Actual type/value
+	Severity string `json:"severity"`           // error, warning
+	Message  string `json:"message"`            // Human-readable message
+	Language string `json:"language,omitempty"` // implementation language when it differs from the contract
+}
+
+// language returns the contract's source language (go for contracts that predate the field).
+func (c Contract) language() string {
+	if c.Language == "" {
+		return LangGo
+	}
+	return c.Language
 }
 
 // ValidateContractAgainstImpl validates implementation against a contract.
+// An implementation in the contract's language is compared field by field
+// with exact types; one in another language is compared through the shared
+// type lattice with names normalized across naming conventions.
 func ValidateContractAgainstImpl(contractPath, implPath string) ([]Violation, error) {
 	contract, err := loadContract(contractPath)
 	if err != nil {
 		return nil, err
 	}
+	return validateContract(contract, implPath)
+}
 
-	implFields, err := extractImplFields(implPath, contract.TypeName)
+func validateContract(contract Contract, implPath string) ([]Violation, error) {
+	implLang := languageOf(implPath)
+	if implLang == LangGo && contract.language() == LangGo {
+		implFields, err := extractImplFields(implPath, contract.TypeName)
+		if err != nil {
+			return nil, err
+		}
+		return compareFields(buildContractFieldMap(contract.Fields), implFields), nil
+	}
+
+	fields, _, err := extractTypeFields(implPath, contract.TypeName)
 	if err != nil {
 		return nil, err
 	}
-
-	contractFields := buildContractFieldMap(contract.Fields)
-	return compareFields(contractFields, implFields), nil
+	if implLang == contract.language() {
+		return compareFields(buildContractFieldMap(contract.Fields), buildContractFieldMap(fields)), nil
+	}
+	return compareAcrossLanguages(contract, implLang, fields), nil
 }
 
 // loadContract loads and parses a contract YAML file.
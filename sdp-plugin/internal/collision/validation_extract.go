@@ -7,6 +7,7 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // extractImplFields extracts struct fields from a Go implementation file.
@@ -56,6 +57,9 @@ func addFieldNames(names []*ast.Ident, fieldType string, fields map[string]strin
 }
 
 // ValidateContractsInDir validates all contracts in a directory.
+// Each contract must have an implementation in its own language; any
+// implementation of the same type in another supported language found in
+// implDir is checked too, so a Go struct and a TS interface cannot drift apart.
 func ValidateContractsInDir(contractsDir, implDir string) ([]Violation, error) {
 	var allViolations []Violation
 
@@ -73,37 +77,52 @@ func ValidateContractsInDir(contractsDir, implDir string) ([]Violation, error) {
 		typeName := filepath.Base(e.Name())
 		typeName = typeName[:len(typeName)-5] // Remove .yaml
 
-		// Find implementation file
-		implPath := filepath.Join(implDir, typeName+".go")
-		implFound := true
-		if _, err := os.Stat(implPath); os.IsNotExist(err) {
-			// Try lowercase
-			implPath = filepath.Join(implDir, toLowerFirst(typeName)+".go")
-			if _, err := os.Stat(implPath); os.IsNotExist(err) {
-				// Implementation file missing - add violation (bug fix for sdp-1lqm)
-				implFound = false
-			}
+		contract, err := loadContract(contractPath)
+		if err != nil {
+			return nil, fmt.Errorf("validate %s: %w", typeName, err)
 		}
+		lang := contract.language()
 
+		// Find implementation file
+		implPath, implFound := findImpl(implDir, lang, typeName)
 		if !implFound {
-			// Add violation for missing implementation file
+			// Add violation for missing implementation file (bug fix for sdp-1lqm)
+			candidates := implCandidates(lang, typeName)
 			allViolations = append(allViolations, Violation{
 				Type:     "missing_implementation",
 				Field:    typeName,
-				Expected: typeName + ".go or " + toLowerFirst(typeName) + ".go",
+				Expected: strings.Join(candidates, " or "),
 				Actual:   "not found",
 				Severity: "error",
-				Message:  fmt.Sprintf("Implementation file not found for contract %s: expected %s or %s in %s", typeName, typeName+".go", toLowerFirst(typeName)+".go", implDir),
+				Message:  fmt.Sprintf("Implementation file not found for contract %s: expected %s in %s", typeName, strings.Join(candidates, " or "), implDir),
 			})
 			continue
 		}
 
-		violations, err := ValidateContractAgainstImpl(contractPath, implPath)
+		violations, err := validateContract(contract, implPath)
 		if err != nil {
 			return nil, fmt.Errorf("validate %s: %w", typeName, err)
 		}
-
 		allViolations = append(allViolations, violations...)
+
+		// Implementations of the same type in other languages
+		for _, other := range []string{LangGo, LangTypeScript, LangPython} {
+			if other == lang {
+				continue
+			}
+			path, ok := findImpl(implDir, other, typeName)
+			if !ok {
+				continue
+			}
+			if _, declared, err := extractTypeFields(path, typeName); err != nil || !declared {
+				continue
+			}
+			violations, err := validateContract(contract, path)
+			if err != nil {
+				return nil, fmt.Errorf("validate %s (%s): %w", typeName, other, err)
+			}
+			allViolations = append(allViolations, violations...)
+		}
 	}
 
 	return allViolations, nil
@@ -0,0 +1,124 @@
+package collision
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// compareAcrossLanguages compares a contract with an implementation in
+// another language. Fields match by normalized name; types match when
+// compatible in the lattice. Nullability differences are warnings.
+func compareAcrossLanguages(contract Contract, implLang string, implFields []FieldInfo) []Violation {
+	contractLang := contract.language()
+	impl := make(map[string]FieldInfo, len(implFields))
+	for _, f := range implFields {
+		impl[fieldKey(f.Name)] = f
+	}
+
+	var violations []Violation
+	matched := make(map[string]bool)
+	for _, cf := range contract.Fields {
+		if cf.Name == "" {
+			continue // embedded Go field
+		}
+		key := fieldKey(cf.Name)
+		f, ok := impl[key]
+		if !ok {
+			violations = append(violations, Violation{
+				Type:     "missing_field",
+				Field:    cf.Name,
+				Expected: cf.Type,
+				Actual:   "missing",
+				Severity: "error",
+				Language: implLang,
+				Message:  fmt.Sprintf("[%s] Missing field %s (%s %s)", implLang, cf.Name, contractLang, cf.Type),
+			})
+			continue
+		}
+		matched[key] = true
+		ct, it := canonicalType(contractLang, cf.Type), canonicalType(implLang, f.Type)
+		switch {
+		case !typesCompatible(ct, it):
+			violations = append(violations, Violation{
+				Type:     "type_mismatch",
+				Field:    cf.Name,
+				Expected: ct,
+				Actual:   it,
+				Severity: "warning",
+				Language: implLang,
+				Message:  fmt.Sprintf("[%s] Type mismatch for %s: %s %s (%s) vs %s %s (%s)", implLang, cf.Name, contractLang, cf.Type, ct, implLang, f.Type, it),
+			})
+		case isOptional(ct) != isOptional(it):
+			violations = append(violations, Violation{
+				Type:     "nullability_mismatch",
+				Field:    cf.Name,
+				Expected: ct,
+				Actual:   it,
+				Severity: "warning",
+				Language: implLang,
+				Message:  fmt.Sprintf("[%s] Nullability differs for %s: %s %s vs %s %s", implLang, cf.Name, contractLang, cf.Type, implLang, f.Type),
+			})
+		}
+	}
+	for _, f := range implFields {
+		if matched[fieldKey(f.Name)] {
+			continue
+		}
+		violations = append(violations, Violation{
+			Type:     "extra_field",
+			Field:    f.Name,
+			Expected: "not in contract",
+			Actual:   f.Type,
+			Severity: "warning",
+			Language: implLang,
+			Message:  fmt.Sprintf("[%s] Extra field not in contract: %s (%s)", implLang, f.Name, f.Type),
+		})
+	}
+	return violations
+}
+
+// implCandidates lists the file names an implementation of typeName may use.
+func implCandidates(lang, typeName string) []string {
+	switch lang {
+	case LangTypeScript:
+		return []string{typeName + ".ts", toLowerFirst(typeName) + ".ts", toKebab(typeName) + ".ts"}
+	case LangPython:
+		return []string{toSnake(typeName) + ".py", strings.ToLower(typeName) + ".py"}
+	}
+	return []string{typeName + ".go", toLowerFirst(typeName) + ".go"}
+}
+
+// findImpl returns the first candidate file for lang that exists in implDir.
+func findImpl(implDir, lang, typeName string) (string, bool) {
+	for _, name := range implCandidates(lang, typeName) {
+		path := filepath.Join(implDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func toSnake(s string) string { return caseSplit(s, '_') }
+func toKebab(s string) string { return caseSplit(s, '-') }
+
+// caseSplit lowercases a CamelCase name, inserting sep at word boundaries
+// (UserID -> user_id, HTTPServer -> http_server).
+func caseSplit(s string, sep rune) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteRune(sep)
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package collision
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateContractsInDir_CrossLanguageDrift(t *testing.T) {
+	tmpDir := t.TempDir()
+	contractsDir := filepath.Join(tmpDir, "contracts")
+	implDir := filepath.Join(tmpDir, "impl")
+	for _, d := range []string{contractsDir, implDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(filepath.Join(contractsDir, "User.yaml"), `typeName: User
+language: go
+fields:
+  - name: UserID
+    type: string
+  - name: Age
+    type: int
+  - name: Nickname
+    type: "*string"
+  - name: Email
+    type: string
+requiredBy: [F054, F055]
+status: locked
+`)
+	write(filepath.Join(implDir, "user.go"), "package impl\n\ntype User struct {\n\tUserID   string\n\tAge      int\n\tNickname *string\n\tEmail    string\n}\n")
+	// TS drifted: age became a string, nickname lost nullability, email missing, extra field added.
+	write(filepath.Join(implDir, "user.ts"), "export interface User {\n  userId: string;\n  age: string;\n  nickname: string;\n  avatar: string;\n}\n")
+	// Python matches through the lattice.
+	write(filepath.Join(implDir, "user.py"), "class User(BaseModel):\n    user_id: str\n    age: int\n    nickname: Optional[str]\n    email: str\n")
+
+	violations, err := ValidateContractsInDir(contractsDir, implDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for _, v := range violations {
+		if v.Language != LangTypeScript {
+			t.Errorf("unexpected violation outside the TypeScript implementation: %+v", v)
+			continue
+		}
+		got[v.Field] = v.Type
+	}
+	want := map[string]string{
+		"Age":      "type_mismatch",
+		"Nickname": "nullability_mismatch",
+		"Email":    "missing_field",
+		"avatar":   "extra_field",
+	}
+	for field, typ := range want {
+		if got[field] != typ {
+			t.Errorf("%s: got %q, want %q (all: %+v)", field, got[field], typ, violations)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("expected %d TypeScript violations, got %+v", len(want), got)
+	}
+}
+
+func TestValidateContractsInDir_TypeScriptContract(t *testing.T) {
+	tmpDir := t.TempDir()
+	contract := "typeName: Order\nlanguage: typescript\nfields:\n  - name: total\n    type: number\nrequiredBy: [F001]\nstatus: draft\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "Order.yaml"), []byte(contract), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	violations, err := ValidateContractsInDir(tmpDir, tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].Type != "missing_implementation" || violations[0].Expected != "Order.ts or order.ts or order.ts" {
+		t.Errorf("expected missing TypeScript implementation, got %+v", violations)
+	}
+}
@@ -21,6 +21,9 @@ type Config struct {
 	Quality    QualitySection    `yaml:"quality"`
 	Guard      GuardSection      `yaml:"guard"`
 	Timeouts   TimeoutsSection   `yaml:"timeouts"`
+	Contracts  ContractsSection  `yaml:"contracts"`
+	Queue      QueueSection      `yaml:"queue"`
+	Issues     IssuesSection     `yaml:"issues"`
 }
 
 // TimeoutsSection holds configurable timeouts (override via SDP_TIMEOUT_* env).
@@ -50,12 +53,35 @@ type EvidenceSection struct {
 
 // QualitySection holds quality gate settings (stub for WS-06).
 type QualitySection struct {
-	CoverageThreshold   int      `yaml:"coverage_threshold"`
-	MaxFileLOC          int      `yaml:"max_file_loc"`
-	CoverageExclude     []string `yaml:"coverage_exclude"`
-	ComplexityThreshold int      `yaml:"complexity_threshold"`
-	ComplexityExclude   []string `yaml:"complexity_exclude"`
-	SizeExclude         []string `yaml:"size_exclude"`
+	CoverageThreshold      int             `yaml:"coverage_threshold"`
+	MaxFileLOC             int             `yaml:"max_file_loc"`
+	CoverageExclude        []string        `yaml:"coverage_exclude"`
+	ComplexityThreshold    int             `yaml:"complexity_threshold"`
+	ComplexityExclude      []string        `yaml:"complexity_exclude"`
+	SizeExclude            []string        `yaml:"size_exclude"`
+	PatchCoverageThreshold int             `yaml:"patch_coverage_threshold"` // 0 = coverage_threshold
+	PatchCoverageBase      string          `yaml:"patch_coverage_base"`
+	Mutation               MutationSection `yaml:"mutation"`
+	Flaky                  FlakySection    `yaml:"flaky"`
+}
+
+// MutationSection configures the optional mutation testing gate run by
+// `sdp verify` on workstream scope files.
+type MutationSection struct {
+	Enabled    bool   `yaml:"enabled"`
+	Threshold  int    `yaml:"threshold"`   // minimum mutation score, %
+	Budget     string `yaml:"budget"`      // total time budget, e.g. "10m"
+	MaxMutants int    `yaml:"max_mutants"` // 0 = no limit
+}
+
+// FlakySection configures flaky test detection: failed tests are re-run in
+// isolation and quarantined tests do not block verify, TDD or the CI loop.
+// Detection is opt-in because re-runs slow down every failing gate.
+type FlakySection struct {
+	Enabled       bool   `yaml:"enabled"`
+	Reruns        int    `yaml:"reruns"`         // isolated re-runs per failed test
+	Threshold     int    `yaml:"threshold"`      // minimum failure rate, %, to classify as flaky
+	QuarantineTTL string `yaml:"quarantine_ttl"` // quarantine expiry, e.g. "168h"
 }
 
 // GuardSection holds guard policy settings (WS-063-03).
@@ -65,6 +91,30 @@ type GuardSection struct {
 	SeverityMapping map[string]string `yaml:"severity_mapping"`
 }
 
+// ContractsSection configures `sdp contract verify`.
+type ContractsSection struct {
+	// FailOn is "breaking" (fail on unapproved breaking changes) or "any"
+	// (fail on any change to a locked contract).
+	FailOn string `yaml:"fail_on"`
+}
+
+// QueueSection selects the issue queue behind Operator Mode.
+type QueueSection struct {
+	// Backend is "file" (built-in JSONL queue), "beads" (bd CLI) or "auto"
+	// (beads when bd and .beads/ are present, else file).
+	Backend string `yaml:"backend"`
+	Path    string `yaml:"path"` // file backend store, relative to the project root
+}
+
+// IssuesSection configures `sdp issues sync` with an external tracker.
+// Tokens come from GITHUB_TOKEN or GITLAB_TOKEN, never from config.
+type IssuesSection struct {
+	Tracker    string `yaml:"tracker"`     // "github" or "gitlab"
+	Repo       string `yaml:"repo"`        // owner/name, or the GitLab project path
+	URL        string `yaml:"url"`         // API base URL for GitHub Enterprise or self-hosted GitLab
+	OnConflict string `yaml:"on_conflict"` // manual, local, remote or newer
+}
+
 // DefaultConfig returns config with sensible defaults (AC4).
 func DefaultConfig() *Config {
 	return &Config{
@@ -82,6 +132,12 @@ func DefaultConfig() *Config {
 			CoverageThreshold:   80,
 			MaxFileLOC:          200,
 			ComplexityThreshold: 40,
+			Flaky: FlakySection{
+				Enabled:       false,
+				Reruns:        3,
+				Threshold:     10,
+				QuarantineTTL: "168h",
+			},
 		},
 		Guard: GuardSection{
 			Mode:      "standard",
@@ -102,6 +158,16 @@ func DefaultConfig() *Config {
 			CoverageList:        "10s",
 			CoverageJava:        "30s",
 		},
+		Contracts: ContractsSection{
+			FailOn: "breaking",
+		},
+		Queue: QueueSection{
+			Backend: "auto",
+			Path:    ".sdp/queue/issues.jsonl",
+		},
+		Issues: IssuesSection{
+			OnConflict: "manual",
+		},
 	}
 }
 
@@ -150,6 +216,15 @@ func Load(projectRoot string) (*Config, error) {
 			return nil, fmt.Errorf("guard.rules_file: %w", err)
 		}
 	}
+	if cfg.Queue.Path != "" && rootForValidation != "" {
+		resolvedQueue := cfg.Queue.Path
+		if !filepath.IsAbs(resolvedQueue) {
+			resolvedQueue = filepath.Join(rootForValidation, resolvedQueue)
+		}
+		if err := validatePathWithinRoot(rootForValidation, resolvedQueue); err != nil {
+			return nil, fmt.Errorf("queue.path: %w", err)
+		}
+	}
 	return cfg, nil
 }
 
@@ -189,6 +264,8 @@ func (c *Config) Validate() error {
 		{"timeouts.coverage_go", c.Timeouts.CoverageGo},
 		{"timeouts.coverage_list", c.Timeouts.CoverageList},
 		{"timeouts.coverage_java", c.Timeouts.CoverageJava},
+		{"quality.mutation.budget", c.Quality.Mutation.Budget},
+		{"quality.flaky.quarantine_ttl", c.Quality.Flaky.QuarantineTTL},
 	}
 	for _, f := range timeoutFields {
 		if f.val != "" {
@@ -197,6 +274,26 @@ func (c *Config) Validate() error {
 			}
 		}
 	}
+	switch c.Contracts.FailOn {
+	case "", "breaking", "any":
+	default:
+		return fmt.Errorf("contracts.fail_on: must be breaking or any, got %q", c.Contracts.FailOn)
+	}
+	switch c.Queue.Backend {
+	case "", "auto", "file", "beads":
+	default:
+		return fmt.Errorf("queue.backend: must be auto, file or beads, got %q", c.Queue.Backend)
+	}
+	switch c.Issues.Tracker {
+	case "", "github", "gitlab":
+	default:
+		return fmt.Errorf("issues.tracker: must be github or gitlab, got %q", c.Issues.Tracker)
+	}
+	switch c.Issues.OnConflict {
+	case "", "manual", "local", "remote", "newer":
+	default:
+		return fmt.Errorf("issues.on_conflict: must be manual, local, remote or newer, got %q", c.Issues.OnConflict)
+	}
 	if c.Acceptance.Timeout != "" {
 		if _, err := time.ParseDuration(c.Acceptance.Timeout); err != nil {
 			return fmt.Errorf("acceptance.timeout: invalid duration %q: %w", c.Acceptance.Timeout, err)
@@ -4,24 +4,61 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 const guardRulesFile = "guard-rules.yml"
 
+// Guard rule plugin types. A rule without a type is one of the built-in
+// rules selected by its ID.
+const (
+	GuardRuleRegex   = "regex"
+	GuardRuleGoAST   = "go-ast"
+	GuardRuleCommand = "command"
+)
+
+// GuardASTQueries lists the query forms accepted by go-ast rules:
+// "call:<func>" (builtin, or import path and function such as
+// "call:fmt.Println"), "import:<path>" and "exported-func-without-doc".
+var GuardASTQueries = []string{"call:", "import:", "exported-func-without-doc"}
+
 // GuardRules holds guard rule definitions.
 type GuardRules struct {
-	Version int         `yaml:"version"`
-	Rules   []GuardRule `yaml:"rules"`
+	Version    int              `yaml:"version"`
+	Rules      []GuardRule      `yaml:"rules"`
+	Exceptions []GuardException `yaml:"exceptions"`
 }
 
 // GuardRule represents a single guard rule.
 type GuardRule struct {
-	ID       string         `yaml:"id"`
-	Enabled  bool           `yaml:"enabled"`
-	Severity string         `yaml:"severity"`
-	Config   map[string]any `yaml:"config"`
+	ID          string         `yaml:"id"`
+	Enabled     bool           `yaml:"enabled"`
+	Severity    string         `yaml:"severity"`
+	Description string         `yaml:"description"`
+	Config      map[string]any `yaml:"config"`
+
+	Type    string   `yaml:"type"`    // "", regex, go-ast or command
+	Paths   []string `yaml:"paths"`   // globs the rule applies to; empty = all files
+	Exclude []string `yaml:"exclude"` // globs the rule skips
+	Message string   `yaml:"message"` // finding message; defaults per type
+	Pattern string   `yaml:"pattern"` // regex: matched per line
+	Query   string   `yaml:"query"`   // go-ast: see GuardASTQueries
+	Command []string `yaml:"command"` // command: argv; matching files are appended
+	Timeout string   `yaml:"timeout"` // command: e.g. "30s"
+}
+
+// GuardException suppresses findings of a rule (or all rules when RuleID is
+// empty) in files matching PathGlob until ExpiresAt (RFC 3339).
+type GuardException struct {
+	RuleID    string `yaml:"rule_id"`
+	PathGlob  string `yaml:"path_glob"`
+	Reason    string `yaml:"reason"`
+	Owner     string `yaml:"owner"`
+	ExpiresAt string `yaml:"expires_at"`
 }
 
 // DefaultGuardRules returns default guard rules when no rules file exists.
@@ -92,7 +129,49 @@ func validateGuardRules(rules *GuardRules) error {
 			return fmt.Errorf("rule %s: invalid severity %q, must be one of: error, warning, info",
 				rule.ID, rule.Severity)
 		}
+		if err := validateGuardRulePlugin(rule); err != nil {
+			return fmt.Errorf("rule %s: %w", rule.ID, err)
+		}
 	}
 
 	return nil
 }
+
+// validateGuardRulePlugin checks the type-specific fields of a rule.
+func validateGuardRulePlugin(rule GuardRule) error {
+	switch rule.Type {
+	case "":
+		return nil
+	case GuardRuleRegex:
+		if rule.Pattern == "" {
+			return fmt.Errorf("regex rule requires 'pattern'")
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	case GuardRuleGoAST:
+		known := false
+		for _, q := range GuardASTQueries {
+			if strings.HasSuffix(q, ":") {
+				known = known || (strings.HasPrefix(rule.Query, q) && len(rule.Query) > len(q))
+			} else {
+				known = known || rule.Query == q
+			}
+		}
+		if !known {
+			return fmt.Errorf("invalid go-ast query %q, must be one of: call:<func>, import:<path>, exported-func-without-doc", rule.Query)
+		}
+	case GuardRuleCommand:
+		if len(rule.Command) == 0 {
+			return fmt.Errorf("command rule requires 'command'")
+		}
+		if rule.Timeout != "" {
+			if _, err := time.ParseDuration(rule.Timeout); err != nil {
+				return fmt.Errorf("invalid timeout %q: %w", rule.Timeout, err)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid type %q, must be one of: regex, go-ast, command", rule.Type)
+	}
+	return nil
+}
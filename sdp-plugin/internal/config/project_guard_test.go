@@ -174,3 +174,70 @@ rules:
 		t.Error("expected error for invalid severity")
 	}
 }
+
+func TestLoadGuardRules_PluginRules(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		dir := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(dir, ".sdp"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, ".sdp", "guard-rules.yml"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	dir := write(t, `version: 1
+rules:
+  - id: no-panic
+    enabled: true
+    severity: error
+    type: go-ast
+    query: call:panic
+    paths: ["internal/**"]
+    exclude: ["**/*_test.go"]
+  - id: ext
+    enabled: true
+    severity: warning
+    type: command
+    command: ["./lint.sh", "--json"]
+    timeout: 30s
+exceptions:
+  - rule_id: no-panic
+    path_glob: internal/legacy/**
+    reason: migration
+    expires_at: "2030-01-01T00:00:00Z"
+`)
+	rules, err := LoadGuardRules(dir)
+	if err != nil {
+		t.Fatalf("LoadGuardRules failed: %v", err)
+	}
+	if r := rules.Rules[0]; r.Type != GuardRuleGoAST || r.Query != "call:panic" || len(r.Paths) != 1 || len(r.Exclude) != 1 {
+		t.Errorf("go-ast rule not parsed: %+v", r)
+	}
+	if r := rules.Rules[1]; len(r.Command) != 2 || r.Timeout != "30s" {
+		t.Errorf("command rule not parsed: %+v", r)
+	}
+	if len(rules.Exceptions) != 1 || rules.Exceptions[0].PathGlob != "internal/legacy/**" {
+		t.Errorf("exceptions not parsed: %+v", rules.Exceptions)
+	}
+
+	invalid := map[string]string{
+		"unknown type": "type: lint",
+		"bad pattern":  "type: regex\n    pattern: \"(\"",
+		"no pattern":   "type: regex",
+		"bad query":    "type: go-ast\n    query: calls:panic",
+		"empty call":   "type: go-ast\n    query: \"call:\"",
+		"no command":   "type: command",
+		"bad timeout":  "type: command\n    command: [x]\n    timeout: soon",
+	}
+	for name, fields := range invalid {
+		t.Run(name, func(t *testing.T) {
+			dir := write(t, "version: 1\nrules:\n  - id: r\n    enabled: true\n    severity: error\n    "+fields+"\n")
+			if _, err := LoadGuardRules(dir); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
@@ -305,3 +305,41 @@ func TestConfigValidate_InvalidTimeout(t *testing.T) {
 		t.Error("expected error for invalid timeout")
 	}
 }
+
+func TestConfigValidate_ContractsFailOn(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Contracts.FailOn != "breaking" {
+		t.Errorf("expected default fail_on breaking, got %q", cfg.Contracts.FailOn)
+	}
+	cfg.Contracts.FailOn = "sometimes"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for invalid contracts.fail_on")
+	}
+}
+
+func TestConfigValidate_QueueBackend(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Queue.Backend != "auto" || cfg.Queue.Path != ".sdp/queue/issues.jsonl" {
+		t.Errorf("unexpected queue defaults: %+v", cfg.Queue)
+	}
+	cfg.Queue.Backend = "jira"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for invalid queue.backend")
+	}
+}
+
+func TestConfigValidate_Issues(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Issues.OnConflict != "manual" {
+		t.Errorf("unexpected issues defaults: %+v", cfg.Issues)
+	}
+	cfg.Issues.Tracker = "jira"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for invalid issues.tracker")
+	}
+	cfg.Issues.Tracker = "gitlab"
+	cfg.Issues.OnConflict = "mine"
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for invalid issues.on_conflict")
+	}
+}
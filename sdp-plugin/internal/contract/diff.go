@@ -0,0 +1,124 @@
+package contract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change kinds reported by Compare.
+const (
+	PathRemoved         = "path-removed"
+	PathAdded           = "path-added"
+	MethodRemoved       = "method-removed"
+	MethodAdded         = "method-added"
+	ParameterAdded      = "parameter-added"
+	ParameterRemoved    = "parameter-removed"
+	ParameterRequired   = "parameter-required"
+	BodyRequired        = "request-body-required"
+	FieldAdded          = "field-added"
+	FieldRemoved        = "field-removed"
+	FieldRequired       = "field-required"
+	FieldOptional       = "field-optional"
+	TypeNarrowed        = "type-narrowed"
+	TypeWidened         = "type-widened"
+	TypeChanged         = "type-changed"
+	EnumChanged         = "enum-changed"
+	ResponseRemoved     = "response-removed"
+	ResponseAdded       = "response-added"
+	ContentTypeRemoved  = "content-type-removed"
+	ContentTypeAdded    = "content-type-added"
+	OperationIDModified = "operation-id-changed"
+)
+
+// Change is one semantic difference between two contract versions.
+type Change struct {
+	Kind     string `json:"kind"`
+	Location string `json:"location"` // e.g. "POST /users request.email"
+	Detail   string `json:"detail"`
+	Breaking bool   `json:"breaking"`
+}
+
+func (c Change) String() string {
+	label := "non-breaking"
+	if c.Breaking {
+		label = "BREAKING"
+	}
+	return fmt.Sprintf("[%s] %s: %s", label, c.Location, c.Detail)
+}
+
+// Diff is the result of comparing a locked contract with the current one.
+type Diff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking returns the breaking changes.
+func (d *Diff) Breaking() []Change {
+	var out []Change
+	for _, c := range d.Changes {
+		if c.Breaking {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// HasBreaking reports whether any change is breaking.
+func (d *Diff) HasBreaking() bool {
+	return len(d.Breaking()) > 0
+}
+
+// differ accumulates changes between two contracts.
+type differ struct {
+	old, cur *OpenAPI
+	changes  []Change
+}
+
+func (d *differ) add(kind, location string, breaking bool, format string, args ...any) {
+	d.changes = append(d.changes, Change{Kind: kind, Location: location, Detail: fmt.Sprintf(format, args...), Breaking: breaking})
+}
+
+// Compare classifies every change from old (the locked contract) to cur.
+// Changes that can break an existing client are breaking: removed paths,
+// methods and response codes, new required parameters or request fields,
+// narrowed request types and removed or widened response fields.
+func Compare(old, cur *OpenAPI) *Diff {
+	d := &differ{old: old, cur: cur}
+	for _, path := range sortedKeys(old.Paths) {
+		curItem, ok := cur.Paths[path]
+		if !ok {
+			d.add(PathRemoved, path, true, "path removed")
+			continue
+		}
+		oldItem := old.Paths[path]
+		for _, method := range sortedKeys(oldItem) {
+			loc := strings.ToUpper(method) + " " + path
+			curOp, ok := curItem[method]
+			if !ok {
+				d.add(MethodRemoved, loc, true, "method removed")
+				continue
+			}
+			d.operation(loc, oldItem[method], curOp)
+		}
+		for _, method := range sortedKeys(curItem) {
+			if _, ok := oldItem[method]; !ok {
+				d.add(MethodAdded, strings.ToUpper(method)+" "+path, false, "method added")
+			}
+		}
+	}
+	for _, path := range sortedKeys(cur.Paths) {
+		if _, ok := old.Paths[path]; !ok {
+			d.add(PathAdded, path, false, "path added")
+		}
+	}
+	return &Diff{Changes: d.changes}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,91 @@
+package contract
+
+import "fmt"
+
+func (d *differ) operation(loc string, old, cur Operation) {
+	if old.OperationID != "" && cur.OperationID != old.OperationID {
+		// Generated clients name methods after the operation ID.
+		d.add(OperationIDModified, loc, true, "operationId %q changed to %q", old.OperationID, cur.OperationID)
+	}
+	d.parameters(loc, old.Parameters, cur.Parameters)
+	d.requestBody(loc, old.RequestBody, cur.RequestBody)
+
+	for _, code := range sortedKeys(old.Responses) {
+		curResp, ok := cur.Responses[code]
+		if !ok {
+			d.add(ResponseRemoved, loc, true, "response %s removed", code)
+			continue
+		}
+		d.content(loc+" response "+code, old.Responses[code].Content, curResp.Content, response)
+	}
+	for _, code := range sortedKeys(cur.Responses) {
+		if _, ok := old.Responses[code]; !ok {
+			d.add(ResponseAdded, loc, false, "response %s added", code)
+		}
+	}
+}
+
+func (d *differ) parameters(loc string, old, cur []Parameter) {
+	key := func(p Parameter) string { return p.In + ":" + p.Name }
+	oldByKey := make(map[string]Parameter, len(old))
+	for _, p := range old {
+		oldByKey[key(p)] = p
+	}
+	curKeys := make(map[string]bool, len(cur))
+	for _, p := range cur {
+		curKeys[key(p)] = true
+		ploc := fmt.Sprintf("%s %s parameter %s", loc, p.In, p.Name)
+		prev, ok := oldByKey[key(p)]
+		switch {
+		case !ok && p.Required:
+			d.add(ParameterAdded, ploc, true, "required parameter added")
+		case !ok:
+			d.add(ParameterAdded, ploc, false, "optional parameter added")
+		default:
+			if p.Required && !prev.Required {
+				d.add(ParameterRequired, ploc, true, "parameter became required")
+			}
+			d.schema(ploc, prev.Schema, p.Schema, request)
+		}
+	}
+	for _, p := range old {
+		if !curKeys[key(p)] {
+			d.add(ParameterRemoved, fmt.Sprintf("%s %s parameter %s", loc, p.In, p.Name), false, "parameter removed")
+		}
+	}
+}
+
+func (d *differ) requestBody(loc string, old, cur *RequestBody) {
+	switch {
+	case old == nil && cur == nil:
+		return
+	case old == nil:
+		d.add(BodyRequired, loc+" request", cur.Required, "request body added")
+		return
+	case cur == nil:
+		d.add(FieldRemoved, loc+" request", false, "request body removed")
+		return
+	}
+	if cur.Required && !old.Required {
+		d.add(BodyRequired, loc+" request", true, "request body became required")
+	}
+	d.content(loc+" request", old.Content, cur.Content, request)
+}
+
+func (d *differ) content(loc string, old, cur map[string]MediaType, dir direction) {
+	for _, ct := range sortedKeys(old) {
+		curMedia, ok := cur[ct]
+		if !ok {
+			d.add(ContentTypeRemoved, loc, true, "content type %s removed", ct)
+			continue
+		}
+		d.schema(loc, old[ct].Schema, curMedia.Schema, dir)
+	}
+	for _, ct := range sortedKeys(cur) {
+		if _, ok := old[ct]; !ok {
+			// A new request content type is an alternative; a new response
+			// content type is only sent when a client asks for it.
+			d.add(ContentTypeAdded, loc, false, "content type %s added", ct)
+		}
+	}
+}
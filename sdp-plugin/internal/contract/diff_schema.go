@@ -0,0 +1,152 @@
+package contract
+
+import (
+	"fmt"
+	"sort"
+)
+
+// direction tells schema comparison who produces the data: clients send
+// requests, so narrowing them breaks; servers send responses, so widening
+// them or dropping fields breaks.
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+// maxSchemaDepth bounds comparison of recursive schemas.
+const maxSchemaDepth = 12
+
+func (d *differ) schema(loc string, old, cur *Schema, dir direction) {
+	d.schemaAt(loc, old, cur, dir, 0)
+}
+
+func (d *differ) schemaAt(loc string, old, cur *Schema, dir direction, depth int) {
+	old, cur = d.old.resolve(old), d.cur.resolve(cur)
+	if old == nil || cur == nil || depth > maxSchemaDepth {
+		return
+	}
+
+	if old.Type != cur.Type {
+		d.typeChange(loc, old.Type, cur.Type, dir)
+		return
+	}
+	if old.Format != cur.Format {
+		// A format constrains values: adding one narrows, removing widens.
+		narrowed := cur.Format != ""
+		d.add(TypeChanged, loc, narrowed == (dir == request), "format %q changed to %q", old.Format, cur.Format)
+	}
+	if old.Nullable && !cur.Nullable && dir == request {
+		d.add(TypeNarrowed, loc, true, "no longer nullable")
+	}
+	if !old.Nullable && cur.Nullable && dir == response {
+		d.add(TypeWidened, loc, true, "became nullable")
+	}
+	d.enum(loc, old.Enum, cur.Enum, dir)
+
+	if old.Items != nil || cur.Items != nil {
+		d.schemaAt(loc+"[]", old.Items, cur.Items, dir, depth+1)
+	}
+	d.properties(loc, old, cur, dir, depth)
+}
+
+func (d *differ) properties(loc string, old, cur *Schema, dir direction, depth int) {
+	oldReq, curReq := old.requiredSet(), cur.requiredSet()
+	for _, name := range sortedKeys(old.Properties) {
+		floc := loc + "." + name
+		curProp, ok := cur.Properties[name]
+		if !ok {
+			d.add(FieldRemoved, floc, dir == response, "field removed")
+			continue
+		}
+		switch {
+		case curReq[name] && !oldReq[name]:
+			d.add(FieldRequired, floc, dir == request, "field became required")
+		case !curReq[name] && oldReq[name]:
+			d.add(FieldOptional, floc, dir == response, "field became optional")
+		}
+		d.schemaAt(floc, old.Properties[name], curProp, dir, depth+1)
+	}
+	for _, name := range sortedKeys(cur.Properties) {
+		if _, ok := old.Properties[name]; ok {
+			continue
+		}
+		if curReq[name] && dir == request {
+			d.add(FieldAdded, loc+"."+name, true, "required field added")
+		} else {
+			d.add(FieldAdded, loc+"."+name, false, "field added")
+		}
+	}
+}
+
+// typeChange classifies a changed JSON Schema type. integer -> number
+// widens; number -> integer narrows; anything else is incompatible.
+func (d *differ) typeChange(loc, old, cur string, dir direction) {
+	switch {
+	case old == "integer" && cur == "number", old == "" && cur != "":
+		kind, breaking := TypeWidened, dir == response
+		if old == "" {
+			kind, breaking = TypeNarrowed, dir == request
+		}
+		d.add(kind, loc, breaking, "type %s changed to %s", typeName(old), typeName(cur))
+	case old == "number" && cur == "integer", old != "" && cur == "":
+		kind, breaking := TypeNarrowed, dir == request
+		if cur == "" {
+			kind, breaking = TypeWidened, dir == response
+		}
+		d.add(kind, loc, breaking, "type %s changed to %s", typeName(old), typeName(cur))
+	default:
+		d.add(TypeChanged, loc, true, "type %s changed to %s", typeName(old), typeName(cur))
+	}
+}
+
+func typeName(t string) string {
+	if t == "" {
+		return "any"
+	}
+	return t
+}
+
+// enum compares allowed values. Removing values narrows; adding values to
+// a previously unrestricted schema narrows too.
+func (d *differ) enum(loc string, old, cur []any, dir direction) {
+	if len(old) == 0 && len(cur) == 0 {
+		return
+	}
+	oldSet, curSet := valueSet(old), valueSet(cur)
+	var removed, added []string
+	for v := range oldSet {
+		if !curSet[v] {
+			removed = append(removed, v)
+		}
+	}
+	for v := range curSet {
+		if !oldSet[v] {
+			added = append(added, v)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+	switch {
+	case len(old) == 0:
+		d.add(EnumChanged, loc, dir == request, "restricted to %v", added)
+	case len(cur) == 0:
+		d.add(EnumChanged, loc, dir == response, "no longer restricted to %v", removed)
+	default:
+		if len(removed) > 0 {
+			d.add(EnumChanged, loc, dir == request, "enum values removed: %v", removed)
+		}
+		if len(added) > 0 {
+			d.add(EnumChanged, loc, dir == response, "enum values added: %v", added)
+		}
+	}
+}
+
+func valueSet(values []any) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[fmt.Sprint(v)] = true
+	}
+	return set
+}
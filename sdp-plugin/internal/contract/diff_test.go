@@ -0,0 +1,95 @@
+package contract
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, data string) *OpenAPI {
+	t.Helper()
+	spec, err := ParseOpenAPI([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseOpenAPI: %v", err)
+	}
+	return spec
+}
+
+func TestCompare_NoChanges(t *testing.T) {
+	d := Compare(mustParse(t, usersV1), mustParse(t, usersV1))
+	if len(d.Changes) != 0 {
+		t.Errorf("expected no changes, got %v", d.Changes)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string // replaced in usersV1
+		kind     string
+		location string
+		breaking bool
+	}{
+		{"path removed", "  /users/{id}:\n    delete:", "  /other/{id}:\n    delete:", PathRemoved, "/users/{id}", true},
+		{"path added", "  /users/{id}:\n    delete:", "  /other/{id}:\n    delete:", PathAdded, "/other/{id}", false},
+		{"method removed", "    delete:", "    patch:", MethodRemoved, "DELETE /users/{id}", true},
+		{"method added", "    delete:", "    patch:", MethodAdded, "PATCH /users/{id}", false},
+		{"required parameter added", "      responses:\n        \"204\"", "      parameters:\n        - name: force\n          in: query\n          required: true\n      responses:\n        \"204\"", ParameterAdded, "DELETE /users/{id} query parameter force", true},
+		{"optional parameter added", "      responses:\n        \"204\"", "      parameters:\n        - name: force\n          in: query\n      responses:\n        \"204\"", ParameterAdded, "DELETE /users/{id} query parameter force", false},
+		{"parameter narrowed", "          schema:\n            type: integer", "          schema:\n            type: integer\n            format: int32", TypeChanged, "GET /users query parameter limit", true},
+		{"response code changed", "\"204\":", "\"200\":", ResponseRemoved, "DELETE /users/{id}", true},
+		{"response code added", "\"201\":\n          description: created", "\"201\":\n          description: created\n        \"409\":\n          description: conflict", ResponseAdded, "POST /users", false},
+		{"required request field added", "      required: [email]\n      properties:\n", "      required: [email, name]\n      properties:\n        name:\n          type: string\n", FieldAdded, "POST /users request.name", true},
+		{"request field made required", "      required: [email]\n", "      required: [email, age]\n", FieldRequired, "POST /users request.age", true},
+		{"request type narrowed", "        age:\n          type: integer", "        age:\n          type: string", TypeChanged, "POST /users request.age", true},
+		{"response field removed", "        role:\n          type: string\n          enum: [admin, member]\n", "", FieldRemoved, "GET /users response 200[].role", true},
+		{"unused component added", "    NewUser:", "    Unused:\n      type: object\n    NewUser:", "", "", false},
+		{"response field made optional", "      required: [id, email]", "      required: [id]", FieldOptional, "GET /users response 200[].email", true},
+		{"response enum widened", "enum: [admin, member]", "enum: [admin, member, guest]", EnumChanged, "GET /users response 200[].role", true},
+		{"response enum narrowed", "enum: [admin, member]", "enum: [admin]", EnumChanged, "GET /users response 200[].role", false},
+		{"response integer widened", "        id:\n          type: integer", "        id:\n          type: number", TypeWidened, "GET /users response 200[].id", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(usersV1, tt.old) {
+				t.Fatalf("fixture does not contain %q", tt.old)
+			}
+			d := Compare(mustParse(t, usersV1), mustParse(t, strings.Replace(usersV1, tt.old, tt.new, 1)))
+			if tt.kind == "" {
+				if len(d.Changes) != 0 {
+					t.Errorf("expected no changes, got %v", d.Changes)
+				}
+				return
+			}
+			for _, c := range d.Changes {
+				if c.Kind == tt.kind && c.Location == tt.location {
+					if c.Breaking != tt.breaking {
+						t.Errorf("%s: breaking = %v, want %v", c, c.Breaking, tt.breaking)
+					}
+					return
+				}
+			}
+			t.Errorf("no %s change at %q in %v", tt.kind, tt.location, d.Changes)
+		})
+	}
+}
+
+func TestCompare_RequestWideningIsCompatible(t *testing.T) {
+	old := strings.Replace(usersV1, "      required: [email]\n", "      required: [email, age]\n", 1)
+	d := Compare(mustParse(t, old), mustParse(t, usersV1))
+	if d.HasBreaking() {
+		t.Errorf("making a request field optional should not break: %v", d.Breaking())
+	}
+}
+
+func TestDiff_Report(t *testing.T) {
+	cur := strings.Replace(usersV1, "    delete:", "    patch:", 1)
+	report := Compare(mustParse(t, usersV1), mustParse(t, cur)).Report("users.yaml")
+	for _, want := range []string{"# Contract Changes: users.yaml", "2 change(s), 1 breaking", "## Breaking", "`DELETE /users/{id}` method removed", "## Non-breaking"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if got := (&Diff{}).Report("x"); !strings.Contains(got, "No semantic changes") {
+		t.Errorf("empty report = %q", got)
+	}
+}
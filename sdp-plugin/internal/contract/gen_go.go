@@ -0,0 +1,166 @@
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// goGen renders Go source for a contract.
+type goGen struct {
+	spec     *OpenAPI
+	types    bytes.Buffer // type declarations
+	pending  []namedSchema
+	declared map[string]bool
+	imports  map[string]bool
+}
+
+type namedSchema struct {
+	name   string
+	schema *Schema
+}
+
+func generateGo(spec *OpenAPI, opts GenerateOptions) ([]byte, error) {
+	g := &goGen{spec: spec, declared: make(map[string]bool), imports: map[string]bool{"net/http": true}}
+	for _, name := range sortedKeys(spec.Components.Schemas) {
+		g.declare(exportedName(name), spec.Components.Schemas[name])
+	}
+	g.drain()
+	handler := g.handler(operations(spec))
+	g.drain()
+
+	var src bytes.Buffer
+	src.WriteString(generatedHeader("//", opts))
+	fmt.Fprintf(&src, "\n// Package %s implements the %s API contract.\npackage %s\n\nimport (\n", opts.Package, spec.Info.Title, opts.Package)
+	for _, imp := range sortedKeys(g.imports) {
+		fmt.Fprintf(&src, "\t%q\n", imp)
+	}
+	src.WriteString(")\n")
+	src.Write(g.types.Bytes())
+	src.Write(handler)
+
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generate go: %w", err)
+	}
+	return out, nil
+}
+
+// declare queues a named type declaration once.
+func (g *goGen) declare(name string, s *Schema) {
+	if !g.declared[name] {
+		g.declared[name] = true
+		g.pending = append(g.pending, namedSchema{name, s})
+	}
+}
+
+// drain writes queued type declarations, including those they queue.
+func (g *goGen) drain() {
+	for len(g.pending) > 0 {
+		next := g.pending[0]
+		g.pending = g.pending[1:]
+		g.typeDecl(next.name, next.schema)
+	}
+}
+
+func (g *goGen) typeDecl(name string, s *Schema) {
+	if s != nil && s.Ref == "" && len(s.Properties) > 0 {
+		g.structDecl(name, s)
+		return
+	}
+	fmt.Fprintf(&g.types, "\n// %s is the %s schema.%s\ntype %s %s\n", name, name, enumDoc(s), name, g.typeOf(s, name+"Value"))
+}
+
+func (g *goGen) structDecl(name string, s *Schema) {
+	fmt.Fprintf(&g.types, "\n// %s is the %s schema.\ntype %s struct {\n", name, name, name)
+	required := s.requiredSet()
+	for _, prop := range sortedKeys(s.Properties) {
+		ps := s.Properties[prop]
+		typ := g.typeOf(ps, name+exportedName(prop))
+		tag := prop
+		if resolved := g.spec.resolve(ps); !required[prop] || (resolved != nil && resolved.Nullable) {
+			typ = optionalGo(typ)
+			if !required[prop] {
+				tag += ",omitempty"
+			}
+		}
+		field := exportedName(prop)
+		if field == "" {
+			field = "Field"
+		}
+		fmt.Fprintf(&g.types, "\t%s %s `json:%q`%s\n", field, typ, tag, enumComment(ps))
+	}
+	g.types.WriteString("}\n")
+}
+
+// typeOf maps a schema to a Go type, declaring inline objects as hint.
+func (g *goGen) typeOf(s *Schema, hint string) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		if name, ok := refName(s.Ref); ok {
+			return exportedName(name)
+		}
+		return "any"
+	}
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.typeOf(s.Items, hint+"Item")
+	case "object", "":
+		if len(s.Properties) > 0 {
+			g.declare(hint, s)
+			return hint
+		}
+		if s.Type == "object" {
+			return "map[string]any"
+		}
+	}
+	return "any"
+}
+
+// optionalGo makes a field type optional: scalars and structs become
+// pointers; slices, maps and any already have a zero value for "absent".
+func optionalGo(typ string) string {
+	if typ == "any" || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || strings.HasPrefix(typ, "*") {
+		return typ
+	}
+	return "*" + typ
+}
+
+func enumComment(s *Schema) string {
+	if s == nil || len(s.Enum) == 0 {
+		return ""
+	}
+	return " // one of: " + enumList(s.Enum)
+}
+
+func enumDoc(s *Schema) string {
+	if s == nil || len(s.Enum) == 0 {
+		return ""
+	}
+	return "\n// One of: " + enumList(s.Enum) + "."
+}
+
+func enumList(values []any) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,140 @@
+package contract
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// handler renders parameter structs into g.types and returns the Handler
+// interface and Register function.
+func (g *goGen) handler(ops []genOp) []byte {
+	var iface, routes bytes.Buffer
+	title := g.spec.Info.Title
+	if title == "" {
+		title = "contract"
+	}
+	fmt.Fprintf(&iface, "\n// Handler serves the %s API. Methods write their own responses.\ntype Handler interface {\n", title)
+	routes.WriteString("\n// Register routes each operation to h on mux, decoding parameters and\n// JSON request bodies. Malformed requests get 400 Bad Request.\nfunc Register(mux *http.ServeMux, h Handler) {\n")
+
+	for _, op := range ops {
+		args, call := "w http.ResponseWriter, r *http.Request", "w, r"
+		fmt.Fprintf(&routes, "\tmux.HandleFunc(%q, func(w http.ResponseWriter, r *http.Request) {\n", op.Method+" "+op.Path)
+		if len(op.Op.Parameters) > 0 {
+			params := op.Name + "Params"
+			g.paramsDecl(params, op)
+			args += ", params " + params
+			call += ", params"
+			fmt.Fprintf(&routes, "\t\tvar params %s\n", params)
+			for _, p := range op.Op.Parameters {
+				g.paramDecode(&routes, p)
+			}
+		}
+		if op.Op.RequestBody != nil {
+			if s := jsonSchema(op.Op.RequestBody.Content); s != nil {
+				typ := g.typeOf(s, op.Name+"Request")
+				args += ", body " + typ
+				call += ", body"
+				g.bodyDecode(&routes, typ, op.Op.RequestBody.Required)
+			}
+		}
+		if code, s := successResponse(op.Op); s != nil {
+			// Declares inline response types; handlers encode them.
+			g.typeOf(s, op.Name+"Response"+code)
+		}
+		fmt.Fprintf(&iface, "\t// %s handles %s %s.\n\t%s(%s)\n", op.Name, op.Method, op.Path, op.Name, args)
+		fmt.Fprintf(&routes, "\t\th.%s(%s)\n\t})\n", op.Name, call)
+	}
+	iface.WriteString("}\n")
+	routes.WriteString("}\n")
+	return append(iface.Bytes(), routes.Bytes()...)
+}
+
+func (g *goGen) paramsDecl(name string, op genOp) {
+	fmt.Fprintf(&g.types, "\n// %s holds the parameters of %s %s.\ntype %s struct {\n", name, op.Method, op.Path, name)
+	for _, p := range op.Op.Parameters {
+		typ := paramType(g.spec.resolve(p.Schema))
+		if !p.Required {
+			typ = "*" + typ
+		}
+		fmt.Fprintf(&g.types, "\t%s %s // %s %s\n", exportedName(p.Name), typ, p.In, p.Name)
+	}
+	g.types.WriteString("}\n")
+}
+
+// paramType maps scalar parameter schemas to Go; other parameters are
+// passed through as their raw string value.
+func paramType(s *Schema) string {
+	if s == nil {
+		return "string"
+	}
+	switch s.Type {
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "string"
+}
+
+// paramSources reads a parameter's raw value from a request.
+var paramSources = map[string]string{
+	"path":   "r.PathValue(%q)",
+	"query":  "r.URL.Query().Get(%q)",
+	"header": "r.Header.Get(%q)",
+	"cookie": "cookieValue(r, %q)",
+}
+
+func (g *goGen) paramDecode(b *bytes.Buffer, p Parameter) {
+	source, ok := paramSources[p.In]
+	if !ok {
+		return
+	}
+	if p.In == "cookie" && !g.declared["cookieValue"] {
+		g.declared["cookieValue"] = true
+		g.types.WriteString("\nfunc cookieValue(r *http.Request, name string) string {\n\tif c, err := r.Cookie(name); err == nil {\n\t\treturn c.Value\n\t}\n\treturn \"\"\n}\n")
+	}
+	field := "params." + exportedName(p.Name)
+	ref := "v"
+	fmt.Fprintf(b, "\t\tif v := "+source+"; v != \"\" {\n", p.Name)
+	typ := paramType(g.spec.resolve(p.Schema))
+	parse := map[string]string{
+		"int64":   "strconv.ParseInt(v, 10, 64)",
+		"int32":   "strconv.ParseInt(v, 10, 32)",
+		"float64": "strconv.ParseFloat(v, 64)",
+		"bool":    "strconv.ParseBool(v)",
+	}[typ]
+	if parse != "" {
+		g.imports["strconv"] = true
+		fmt.Fprintf(b, "\t\t\tparsed, err := %s\n\t\t\tif err != nil {\n\t\t\t\thttp.Error(w, %q, http.StatusBadRequest)\n\t\t\t\treturn\n\t\t\t}\n",
+			parse, fmt.Sprintf("invalid %s parameter %s", p.In, p.Name))
+		ref = "parsed"
+		if typ == "int32" {
+			fmt.Fprintf(b, "\t\t\tn := int32(parsed)\n")
+			ref = "n"
+		}
+	}
+	if p.Required {
+		fmt.Fprintf(b, "\t\t\t%s = %s\n", field, ref)
+		fmt.Fprintf(b, "\t\t} else {\n\t\t\thttp.Error(w, %q, http.StatusBadRequest)\n\t\t\treturn\n\t\t}\n", fmt.Sprintf("missing %s parameter %s", p.In, p.Name))
+		return
+	}
+	fmt.Fprintf(b, "\t\t\t%s = &%s\n\t\t}\n", field, ref)
+}
+
+func (g *goGen) bodyDecode(b *bytes.Buffer, typ string, required bool) {
+	g.imports["encoding/json"] = true
+	fmt.Fprintf(b, "\t\tvar body %s\n", typ)
+	if required {
+		b.WriteString("\t\tif err := json.NewDecoder(r.Body).Decode(&body); err != nil {\n")
+	} else {
+		g.imports["errors"] = true
+		g.imports["io"] = true
+		b.WriteString("\t\tif err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {\n")
+	}
+	b.WriteString("\t\t\thttp.Error(w, \"invalid request body: \"+err.Error(), http.StatusBadRequest)\n\t\t\treturn\n\t\t}\n")
+}
@@ -0,0 +1,45 @@
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// RecordGenerated adds f to the lock's generated files, replacing an
+// earlier entry for the same path.
+func (l *Lock) RecordGenerated(f GeneratedFile) {
+	for i := range l.Generated {
+		if l.Generated[i].Path == f.Path {
+			l.Generated[i] = f
+			return
+		}
+	}
+	l.Generated = append(l.Generated, f)
+}
+
+// GenerateLocked renders f from the locked contract content.
+func (l *Lock) GenerateLocked(content []byte, f GeneratedFile) ([]byte, error) {
+	spec, err := ParseOpenAPI(content)
+	if err != nil {
+		return nil, err
+	}
+	return Generate(spec, GenerateOptions{Lang: f.Lang, Package: f.Package, Source: l.ContractFile, Hash: l.ContractHash})
+}
+
+// StaleGenerated regenerates every file recorded in the lock from content,
+// the locked contract, and returns the paths that are missing or differ.
+func (l *Lock) StaleGenerated(content []byte) ([]string, error) {
+	var stale []string
+	for _, f := range l.Generated {
+		want, err := l.GenerateLocked(content, f)
+		if err != nil {
+			return nil, fmt.Errorf("regenerate %s: %w", f.Path, err)
+		}
+		got, err := os.ReadFile(f.Path)
+		if err != nil || !bytes.Equal(got, want) {
+			stale = append(stale, f.Path)
+		}
+	}
+	return stale, nil
+}
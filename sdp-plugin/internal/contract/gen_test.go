@@ -0,0 +1,176 @@
+package contract
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":    "UserID",
+		"userId":     "UserID",
+		"list-users": "ListUsers",
+		"api_url":    "APIURL",
+		"get":        "Get",
+		"":           "",
+	}
+	for in, want := range tests {
+		if got := exportedName(in); got != want {
+			t.Errorf("exportedName(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := routeName("delete", "/users/{user_id}/roles"); got != "DeleteUsersByUserIDRoles" {
+		t.Errorf("routeName = %q", got)
+	}
+}
+
+func TestGenerate_Go(t *testing.T) {
+	spec := mustParse(t, usersV1)
+	code, err := Generate(spec, GenerateOptions{Lang: LangGo, Package: "users", Source: ".contracts/users.yaml", Hash: "abc"})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(code)
+	for _, want := range []string{
+		"// Code generated by sdp contract generate-code from .contracts/users.yaml. DO NOT EDIT.",
+		"Role  *string `json:\"role,omitempty\"` // one of: admin, member",
+		"GetUsers(w http.ResponseWriter, r *http.Request, params GetUsersParams)",
+		"PostUsers(w http.ResponseWriter, r *http.Request, body NewUser)",
+		`mux.HandleFunc("DELETE /users/{id}"`,
+		`params.ID = v`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated Go missing %q:\n%s", want, src)
+		}
+	}
+
+	if f := typeCheck(t, "users", code); !ast.IsGenerated(f) {
+		t.Error("generated Go is not marked as generated")
+	}
+}
+
+// typeCheck parses and type-checks generated Go against the standard library.
+func typeCheck(t *testing.T, pkg string, code []byte) *ast.File {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, pkg+"_gen.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated Go does not parse: %v", err)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err := conf.Check(pkg, fset, []*ast.File{f}, nil); err != nil {
+		t.Errorf("generated Go does not type-check: %v\n%s", err, code)
+	}
+	return f
+}
+
+func TestGenerate_GoInlineTypes(t *testing.T) {
+	spec := mustParse(t, `openapi: 3.0.0
+info: {title: Orders}
+paths:
+  /orders/{id}:
+    get:
+      parameters:
+        - {name: expand, in: query, schema: {type: boolean}}
+        - {name: session, in: cookie, required: true}
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id: {type: integer, format: int32}
+                  lines: {type: array, items: {type: object, properties: {sku: {type: string}}}}
+    put:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, properties: {note: {type: string, nullable: true}}}
+      responses:
+        "204": {description: updated}
+`)
+	code, err := Generate(spec, GenerateOptions{Lang: LangGo, Package: "orders"})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	for _, want := range []string{"type GetOrdersByIDResponse200 struct", "Lines []GetOrdersByIDResponse200LinesItem", "type PutOrdersByIDRequest struct", "!errors.Is(err, io.EOF)", "func cookieValue"} {
+		if !strings.Contains(string(code), want) {
+			t.Errorf("generated Go missing %q:\n%s", want, code)
+		}
+	}
+	typeCheck(t, "orders", code)
+}
+
+func TestGenerate_TS(t *testing.T) {
+	spec := mustParse(t, usersV1)
+	code, err := Generate(spec, GenerateOptions{Lang: LangTS, Source: ".contracts/users.yaml", Hash: "abc"})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	src := string(code)
+	for _, want := range []string{
+		"// Code generated by sdp contract generate-code",
+		"export interface User {\n  email: string;\n  id: number;\n  role?: \"admin\" | \"member\";\n}",
+		"export interface GetUsersParams {\n  limit?: number;\n}",
+		"export class UsersClient {",
+		"async getUsers(params: GetUsersParams = {}): Promise<User[]> {",
+		"async postUsers(body: NewUser): Promise<void> {",
+		"`/users/${encodeURIComponent(String(params.id))}`",
+		"export class ApiError extends Error",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated TS missing %q:\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	spec := mustParse(t, usersV1)
+	if _, err := Generate(spec, GenerateOptions{Lang: "rust"}); err == nil {
+		t.Error("expected error for unsupported language")
+	}
+	if _, err := Generate(spec, GenerateOptions{Lang: LangGo}); err == nil {
+		t.Error("expected error for missing Go package")
+	}
+}
+
+func TestLock_StaleGenerated(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(usersV1)
+	lock := &Lock{ContractFile: "users.yaml", ContractHash: Hash(content)}
+	file := GeneratedFile{Path: filepath.Join(dir, "users.gen.ts"), Lang: LangTS}
+	lock.RecordGenerated(file)
+	lock.RecordGenerated(file)
+	if len(lock.Generated) != 1 {
+		t.Fatalf("RecordGenerated duplicated entry: %+v", lock.Generated)
+	}
+
+	if stale, err := lock.StaleGenerated(content); err != nil || len(stale) != 1 {
+		t.Errorf("missing file: stale=%v err=%v", stale, err)
+	}
+	code, err := lock.GenerateLocked(content, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file.Path, code, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if stale, err := lock.StaleGenerated(content); err != nil || len(stale) != 0 {
+		t.Errorf("fresh file: stale=%v err=%v", stale, err)
+	}
+	if err := os.WriteFile(file.Path, append(code, "// edited\n"...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if stale, _ := lock.StaleGenerated(content); len(stale) != 1 {
+		t.Errorf("edited file should be stale, got %v", stale)
+	}
+}
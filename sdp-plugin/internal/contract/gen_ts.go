@@ -0,0 +1,186 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func generateTS(spec *OpenAPI, opts GenerateOptions) []byte {
+	var b bytes.Buffer
+	b.WriteString(generatedHeader("//", opts))
+	for _, name := range sortedKeys(spec.Components.Schemas) {
+		s := spec.Components.Schemas[name]
+		if s != nil && s.Ref == "" && len(s.Properties) > 0 {
+			fmt.Fprintf(&b, "\nexport interface %s %s\n", exportedName(name), tsObject(s, ""))
+		} else {
+			fmt.Fprintf(&b, "\nexport type %s = %s;\n", exportedName(name), tsType(s, ""))
+		}
+	}
+
+	ops := operations(spec)
+	for _, op := range ops {
+		if len(op.Op.Parameters) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n/** Parameters of %s %s. */\nexport interface %sParams {\n", op.Method, op.Path, op.Name)
+		for _, p := range op.Op.Parameters {
+			fmt.Fprintf(&b, "  %s%s: %s;\n", tsKey(p.Name), optionalMark(p.Required), tsType(p.Schema, "  "))
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString(tsRuntime)
+	client := exportedName(spec.Info.Title)
+	if client == "" {
+		client = "API"
+	}
+	fmt.Fprintf(&b, "\n/** Client for the %s API. */\nexport class %sClient {\n", spec.Info.Title, client)
+	b.WriteString("  constructor(\n    private readonly baseUrl: string,\n    private readonly fetchImpl: typeof fetch = globalThis.fetch.bind(globalThis),\n  ) {}\n")
+	for _, op := range ops {
+		b.WriteString(tsMethod(op))
+	}
+	b.WriteString(tsRequest)
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// tsMethod renders one client method.
+func tsMethod(op genOp) string {
+	var args []string
+	hasParams := len(op.Op.Parameters) > 0
+	if hasParams {
+		arg := "params: " + op.Name + "Params"
+		if !anyRequired(op.Op.Parameters) {
+			arg += " = {}"
+		}
+		args = append(args, arg)
+	}
+	body := "undefined"
+	if op.Op.RequestBody != nil {
+		if s := jsonSchema(op.Op.RequestBody.Content); s != nil {
+			args = append(args, "body"+optionalMark(op.Op.RequestBody.Required)+": "+tsType(s, "  "))
+			body = "body"
+		}
+	}
+	result := "void"
+	if _, s := successResponse(op.Op); s != nil {
+		result = tsType(s, "  ")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n  /** %s %s */\n  async %s(%s): Promise<%s> {\n", op.Method, op.Path, lowerFirst(op.Name), strings.Join(args, ", "), result)
+	path := op.Path
+	b.WriteString("    const query = new URLSearchParams();\n    const headers: Record<string, string> = {};\n")
+	for _, p := range op.Op.Parameters {
+		value := "params" + tsAccess(p.Name)
+		switch p.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", "${encodeURIComponent(String("+value+"))}")
+		case "query":
+			fmt.Fprintf(&b, "    if (%s !== undefined) query.set(%q, String(%s));\n", value, p.Name, value)
+		case "header":
+			fmt.Fprintf(&b, "    if (%s !== undefined) headers[%q] = String(%s);\n", value, p.Name, value)
+		}
+	}
+	fmt.Fprintf(&b, "    return this.request<%s>(%q, `%s`, query, headers, %s);\n  }\n", result, op.Method, path, body)
+	return b.String()
+}
+
+// tsType maps a schema to a TypeScript type; indent is the current
+// indentation for inline object types.
+func tsType(s *Schema, indent string) string {
+	if s == nil {
+		return "unknown"
+	}
+	if s.Ref != "" {
+		if name, ok := refName(s.Ref); ok {
+			return exportedName(name)
+		}
+		return "unknown"
+	}
+	var t string
+	switch {
+	case len(s.Enum) > 0:
+		literals := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			lit, _ := json.Marshal(v)
+			literals[i] = string(lit)
+		}
+		t = strings.Join(literals, " | ")
+	case s.Type == "string":
+		t = "string"
+	case s.Type == "integer", s.Type == "number":
+		t = "number"
+	case s.Type == "boolean":
+		t = "boolean"
+	case s.Type == "array":
+		item := tsType(s.Items, indent)
+		if strings.ContainsAny(item, " |") && !strings.HasPrefix(item, "{") {
+			item = "(" + item + ")"
+		}
+		t = item + "[]"
+	case len(s.Properties) > 0:
+		t = tsObject(s, indent)
+	case s.Type == "object":
+		t = "Record<string, unknown>"
+	default:
+		t = "unknown"
+	}
+	if s.Nullable {
+		t += " | null"
+	}
+	return t
+}
+
+func tsObject(s *Schema, indent string) string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	required := s.requiredSet()
+	for _, prop := range sortedKeys(s.Properties) {
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, tsKey(prop), optionalMark(required[prop]), tsType(s.Properties[prop], indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+var tsIdent = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func tsKey(name string) string {
+	if tsIdent.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+func tsAccess(name string) string {
+	if tsIdent.MatchString(name) {
+		return "." + name
+	}
+	return fmt.Sprintf("[%q]", name)
+}
+
+func optionalMark(required bool) string {
+	if required {
+		return ""
+	}
+	return "?"
+}
+
+func anyRequired(params []Parameter) bool {
+	for _, p := range params {
+		if p.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
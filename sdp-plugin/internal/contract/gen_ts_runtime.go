@@ -0,0 +1,38 @@
+package contract
+
+// tsRuntime is emitted before the client class.
+const tsRuntime = `
+/** Error thrown for non-2xx responses. */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly body: string,
+  ) {
+    super(` + "`HTTP ${status}: ${body}`" + `);
+  }
+}
+`
+
+// tsRequest is the client's shared request method.
+const tsRequest = `
+  private async request<T>(
+    method: string,
+    path: string,
+    query: URLSearchParams,
+    headers: Record<string, string>,
+    body: unknown,
+  ): Promise<T> {
+    const qs = query.toString();
+    const init: RequestInit = { method, headers };
+    if (body !== undefined) {
+      init.headers = { "Content-Type": "application/json", ...headers };
+      init.body = JSON.stringify(body);
+    }
+    const res = await this.fetchImpl(this.baseUrl + path + (qs ? "?" + qs : ""), init);
+    const text = await res.text();
+    if (!res.ok) {
+      throw new ApiError(res.status, text);
+    }
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+`
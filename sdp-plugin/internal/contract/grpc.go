@@ -0,0 +1,324 @@
+package contract
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/gomod"
+)
+
+// GRPCServer is a service registered with Register<Service>Server, resolved
+// to the RPCs its implementation type defines.
+type GRPCServer struct {
+	Service string            // service name, e.g. "UserService"
+	Impl    string            // implementation type, "<import path>.<Name>"
+	RPCs    map[string]string // RPC name -> "file:line" of the implementing method
+}
+
+// ScanGRPCServers finds Register<Service>Server(srv, impl) calls in the Go
+// packages under dir. Each implementation, a composite literal or a
+// constructor call, is resolved within the enclosing module; its RPCs are
+// the methods of the generated <Service>Server interface that the type
+// defines itself, so other exported methods and RPCs left to an embedded
+// Unimplemented<Service>Server do not count.
+func ScanGRPCServers(dir string) ([]GRPCServer, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("scan gRPC servers: %w", err)
+	}
+	modRoot, err := gomod.Root(dir)
+	if err != nil {
+		return nil, fmt.Errorf("scan gRPC servers: %w", err)
+	}
+	l := &goLoader{fset: token.NewFileSet(), modRoot: modRoot, modPath: gomod.ModulePath(modRoot), pkgs: map[string]*goPkg{}}
+	if l.modPath == "" {
+		return nil, fmt.Errorf("scan gRPC servers: no module path in %s", filepath.Join(modRoot, "go.mod"))
+	}
+
+	var pkgs []*goPkg
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if name := d.Name(); p != dir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+		pkg, err := l.loadDir(p)
+		if pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan gRPC servers: %w", err)
+	}
+
+	var servers []GRPCServer
+	for _, pkg := range pkgs {
+		for _, f := range pkg.files {
+			var scanErr error
+			ast.Inspect(f, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || scanErr != nil {
+					return scanErr == nil
+				}
+				srv, ok, err := l.registration(pkg, f, call)
+				if err != nil {
+					scanErr = err
+				} else if ok {
+					servers = append(servers, srv)
+				}
+				return true
+			})
+			if scanErr != nil {
+				return nil, scanErr
+			}
+		}
+	}
+	sort.SliceStable(servers, func(i, j int) bool { return servers[i].Service < servers[j].Service })
+	return servers, nil
+}
+
+// CheckGRPCImplementation lists the contract RPCs that no registered server
+// implements, as "Service/RPC: reason".
+func CheckGRPCImplementation(p *Proto, servers []GRPCServer) []string {
+	byService := make(map[string][]GRPCServer)
+	for _, s := range servers {
+		byService[s.Service] = append(byService[s.Service], s)
+	}
+	var problems []string
+	for _, svc := range p.Services {
+		registered := byService[svc.Name]
+		if len(registered) == 0 {
+			problems = append(problems, fmt.Sprintf("%s: no Register%sServer call", svc.Name, svc.Name))
+			continue
+		}
+		for _, rpc := range svc.RPCs {
+			implemented := false
+			for _, s := range registered {
+				if _, ok := s.RPCs[rpc.Name]; ok {
+					implemented = true
+					break
+				}
+			}
+			if !implemented {
+				problems = append(problems, fmt.Sprintf("%s/%s: not implemented by %s", svc.Name, rpc.Name, registered[0].Impl))
+			}
+		}
+	}
+	return problems
+}
+
+// registration resolves a Register<Service>Server(srv, impl) call.
+func (l *goLoader) registration(pkg *goPkg, f *ast.File, call *ast.CallExpr) (GRPCServer, bool, error) {
+	var fn string
+	switch v := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		fn = v.Sel.Name
+	case *ast.Ident:
+		fn = v.Name
+	}
+	service := strings.TrimSuffix(strings.TrimPrefix(fn, "Register"), "Server")
+	if len(call.Args) != 2 || service == "" || service == fn || !strings.HasSuffix(fn, "Server") {
+		return GRPCServer{}, false, nil
+	}
+	genPkg, _ := l.resolve(pkg, f, call.Fun)
+	if genPkg == nil || genPkg.ifaces[service+"Server"] == nil {
+		// Not a generated registration, or generated code outside the module.
+		return GRPCServer{}, false, nil
+	}
+	iface := genPkg.ifaces[service+"Server"]
+
+	implPkg, impl := l.implType(pkg, f, call.Args[1])
+	if implPkg == nil {
+		pos := l.fset.Position(call.Pos())
+		return GRPCServer{}, false, fmt.Errorf("%s:%d: cannot resolve the %s implementation", pos.Filename, pos.Line, service)
+	}
+	srv := GRPCServer{Service: service, Impl: implPkg.path + "." + impl, RPCs: map[string]string{}}
+	methods := implPkg.methods[impl]
+	for _, m := range iface.Methods.List {
+		for _, name := range m.Names {
+			if pos, ok := methods[name.Name]; ok && name.IsExported() {
+				p := l.fset.Position(pos)
+				srv.RPCs[name.Name] = fmt.Sprintf("%s:%d", p.Filename, p.Line)
+			}
+		}
+	}
+	return srv, true, nil
+}
+
+// implType resolves the implementation argument of a registration: a
+// (pointer to a) composite literal or a call to a constructor returning the
+// type.
+func (l *goLoader) implType(pkg *goPkg, f *ast.File, arg ast.Expr) (*goPkg, string) {
+	if u, ok := arg.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		arg = u.X
+	}
+	switch v := arg.(type) {
+	case *ast.CompositeLit:
+		return l.resolve(pkg, f, v.Type)
+	case *ast.CallExpr:
+		ctorPkg, name := l.resolve(pkg, f, v.Fun)
+		if ctorPkg == nil {
+			return nil, ""
+		}
+		ctor := ctorPkg.funcs[name]
+		if ctor == nil || ctor.Recv != nil || ctor.Type.Results == nil || len(ctor.Type.Results.List) == 0 {
+			return nil, ""
+		}
+		return l.resolve(ctorPkg, ctorPkg.fileOf[name], ctor.Type.Results.List[0].Type)
+	}
+	return nil, ""
+}
+
+// goLoader parses the packages of one module on demand.
+type goLoader struct {
+	fset    *token.FileSet
+	modRoot string
+	modPath string
+	pkgs    map[string]*goPkg // by directory
+}
+
+// goPkg holds the declarations of one package that registration
+// resolution needs.
+type goPkg struct {
+	path    string
+	files   []*ast.File
+	funcs   map[string]*ast.FuncDecl
+	fileOf  map[string]*ast.File // function name -> declaring file
+	methods map[string]map[string]token.Pos
+	ifaces  map[string]*ast.InterfaceType
+}
+
+// loadDir parses the non-test Go files in dir. It returns nil for a
+// directory without Go files.
+func (l *goLoader) loadDir(dir string) (*goPkg, error) {
+	if pkg, ok := l.pkgs[dir]; ok {
+		return pkg, nil
+	}
+	l.pkgs[dir] = nil
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(l.modRoot, dir)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &goPkg{
+		path:    path.Join(l.modPath, filepath.ToSlash(rel)),
+		funcs:   map[string]*ast.FuncDecl{},
+		fileOf:  map[string]*ast.File{},
+		methods: map[string]map[string]token.Pos{},
+		ifaces:  map[string]*ast.InterfaceType{},
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(l.fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("parse Go file: %w", err)
+		}
+		pkg.add(f)
+	}
+	if len(pkg.files) == 0 {
+		return nil, nil
+	}
+	l.pkgs[dir] = pkg
+	return pkg, nil
+}
+
+func (p *goPkg) add(f *ast.File) {
+	p.files = append(p.files, f)
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				p.funcs[d.Name.Name] = d
+				p.fileOf[d.Name.Name] = f
+				continue
+			}
+			if len(d.Recv.List) != 1 {
+				continue
+			}
+			recv := baseTypeName(d.Recv.List[0].Type)
+			if p.methods[recv] == nil {
+				p.methods[recv] = map[string]token.Pos{}
+			}
+			p.methods[recv][d.Name.Name] = d.Pos()
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					if it, ok := ts.Type.(*ast.InterfaceType); ok {
+						p.ifaces[ts.Name.Name] = it
+					}
+				}
+			}
+		}
+	}
+}
+
+// resolve returns the package and name an identifier or qualified
+// identifier in file f of pkg refers to. Packages outside the module
+// resolve to nil.
+func (l *goLoader) resolve(pkg *goPkg, f *ast.File, expr ast.Expr) (*goPkg, string) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return l.resolve(pkg, f, t.X)
+	case *ast.IndexExpr:
+		return l.resolve(pkg, f, t.X)
+	case *ast.IndexListExpr:
+		return l.resolve(pkg, f, t.X)
+	case *ast.Ident:
+		return pkg, t.Name
+	case *ast.SelectorExpr:
+		q, ok := t.X.(*ast.Ident)
+		if !ok || f == nil {
+			return nil, ""
+		}
+		for _, imp := range f.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil || (importPath != l.modPath && !strings.HasPrefix(importPath, l.modPath+"/")) {
+				continue
+			}
+			dep, err := l.loadDir(filepath.Join(l.modRoot, filepath.FromSlash(strings.TrimPrefix(importPath, l.modPath))))
+			if err != nil || dep == nil {
+				continue
+			}
+			name := dep.files[0].Name.Name
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if name == q.Name {
+				return dep, t.Sel.Name
+			}
+		}
+	}
+	return nil, ""
+}
+
+// baseTypeName returns the name of a (pointer to a, generic) receiver type.
+func baseTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return baseTypeName(t.X)
+	case *ast.IndexExpr:
+		return baseTypeName(t.X)
+	case *ast.IndexListExpr:
+		return baseTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package contract
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// grpcModule is a module with generated gRPC code, a health checker built
+// by a constructor in another package, and two packages that both declare
+// a type named server.
+var grpcModule = map[string]string{
+	"go.mod": "module example.com/users\n\ngo 1.21\n",
+	"gen/usersv1/users_grpc.pb.go": `package usersv1
+
+type UserServiceServer interface {
+	GetUser(ctx any, req any) (any, error)
+	DeleteUser(ctx any, req any) (any, error)
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+type UnimplementedUserServiceServer struct{}
+
+func (UnimplementedUserServiceServer) GetUser(ctx any, req any) (any, error)    { return nil, nil }
+func (UnimplementedUserServiceServer) DeleteUser(ctx any, req any) (any, error) { return nil, nil }
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+func RegisterUserServiceServer(s any, srv UserServiceServer) {}
+
+type HealthServer interface {
+	Check(ctx any, req any) (any, error)
+}
+
+func RegisterHealthServer(s any, srv HealthServer) {}
+`,
+	"internal/health/health.go": `package health
+
+type Checker struct{}
+
+func New() *Checker { return &Checker{} }
+
+func (c *Checker) Check(ctx any, req any) (any, error) { return nil, nil }
+`,
+	"internal/other/other.go": `package other
+
+type server struct{}
+
+func (s *server) DeleteUser(ctx any, req any) (any, error) { return nil, nil }
+`,
+	"internal/server/server.go": `package server
+
+import (
+	"example.com/users/internal/health"
+	pb "example.com/users/gen/usersv1"
+)
+
+type server struct {
+	pb.UnimplementedUserServiceServer
+}
+
+func (s *server) GetUser(ctx any, req any) (any, error) { return nil, nil }
+
+func (s *server) Helper() {}
+
+type fake struct{}
+
+func (fake) RegisterAuditServer(a, b any) {}
+
+func Register(s any) {
+	pb.RegisterUserServiceServer(s, &server{})
+	pb.RegisterHealthServer(s, health.New())
+	fake{}.RegisterAuditServer(s, nil)
+}
+`,
+	"internal/server/server_test.go": "package server\n\nfunc (s *server) DeleteUser(ctx any, req any) (any, error) { return nil, nil }\n",
+}
+
+func writeGRPCModule(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range grpcModule {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestScanGRPCServers(t *testing.T) {
+	dir := writeGRPCModule(t)
+	servers, err := ScanGRPCServers(filepath.Join(dir, "internal"))
+	if err != nil {
+		t.Fatalf("ScanGRPCServers: %v", err)
+	}
+	got := map[string][]string{}
+	for _, s := range servers {
+		var rpcs []string
+		for name := range s.RPCs {
+			rpcs = append(rpcs, name)
+		}
+		sort.Strings(rpcs)
+		got[s.Service+" "+s.Impl] = rpcs
+	}
+	want := map[string][]string{
+		"Health example.com/users/internal/health.Checker":     {"Check"},
+		"UserService example.com/users/internal/server.server": {"GetUser"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("servers = %v, want %v", got, want)
+	}
+	if loc := servers[1].RPCs["GetUser"]; !strings.HasSuffix(loc, filepath.Join("server", "server.go")+":12") {
+		t.Errorf("GetUser location = %s", loc)
+	}
+
+	p, err := ParseProto([]byte(`syntax = "proto3";
+service UserService {
+  rpc GetUser(Req) returns (Res);
+  rpc DeleteUser(Req) returns (Res);
+}
+service Audit { rpc Log(Req) returns (Res); }
+message Req {}
+message Res {}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems := CheckGRPCImplementation(p, servers)
+	wantProblems := []string{
+		"UserService/DeleteUser: not implemented by example.com/users/internal/server.server",
+		"Audit: no RegisterAuditServer call",
+	}
+	if !reflect.DeepEqual(problems, wantProblems) {
+		t.Errorf("problems = %q, want %q", problems, wantProblems)
+	}
+}
+
+func TestScanGRPCServers_Errors(t *testing.T) {
+	if _, err := ScanGRPCServers(t.TempDir()); err == nil {
+		t.Error("expected error outside a Go module")
+	}
+	dir := writeGRPCModule(t)
+	if err := os.WriteFile(filepath.Join(dir, "internal", "bad.go"), []byte("package x\nfunc {"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ScanGRPCServers(dir); err == nil {
+		t.Error("expected parse error")
+	}
+}
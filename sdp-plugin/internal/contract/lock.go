@@ -0,0 +1,135 @@
+package contract
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// snapshotDir holds copies of locked contracts next to their lock files.
+// recovery.LockRecoveryManager backups keep only the lock metadata, so the
+// contract body is snapshotted here to be diffable after it changes.
+const snapshotDir = ".locked"
+
+// Lock is the lock file written by "sdp contract lock".
+type Lock struct {
+	ContractFile string          `yaml:"contract_file"`
+	ContractHash string          `yaml:"contract_hash"`
+	GitSHA       string          `yaml:"git_sha"`
+	LockedAt     string          `yaml:"locked_at"`
+	Checksum     string          `yaml:"checksum"`
+	Backup       string          `yaml:"backup,omitempty"`
+	Metadata     LockMetadata    `yaml:"metadata,omitempty"`
+	Generated    []GeneratedFile `yaml:"generated,omitempty"`
+}
+
+// LockMetadata summarizes the locked contract.
+type LockMetadata struct {
+	Feature   string `yaml:"feature"`
+	Version   string `yaml:"version"`
+	Endpoints int    `yaml:"endpoints"`
+	Schemas   int    `yaml:"schemas"`
+}
+
+// ErrNotRecoverable means neither the snapshot nor git has the locked
+// contract content.
+var ErrNotRecoverable = errors.New("locked contract content not recoverable")
+
+// Hash returns the hex SHA-256 used for ContractHash.
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// ReadLock loads a lock file.
+func ReadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file: %w", err)
+	}
+	return &lock, nil
+}
+
+// WriteLock writes lock to path.
+func WriteLock(path string, lock *Lock) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lock: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// WriteSnapshot stores content as the locked copy of contractPath and
+// returns its path.
+func WriteSnapshot(lockPath, contractPath string, content []byte) (string, error) {
+	dir := filepath.Join(filepath.Dir(lockPath), snapshotDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	path := filepath.Join(dir, filepath.Base(contractPath))
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write contract snapshot: %w", err)
+	}
+	return path, nil
+}
+
+// LockedContent recovers the contract as it was locked, from the snapshot,
+// the unchanged contract file or git at GitSHA. The content must match
+// ContractHash. It returns the content and where it came from.
+func (l *Lock) LockedContent() ([]byte, string, error) {
+	for _, path := range []string{l.Backup, l.ContractFile} {
+		if path == "" {
+			continue
+		}
+		if data, err := os.ReadFile(path); err == nil && Hash(data) == l.ContractHash {
+			return data, path, nil
+		}
+	}
+	if l.GitSHA != "" && l.GitSHA != "unknown" && l.ContractFile != "" {
+		if data, err := gitShow(l.GitSHA, l.ContractFile); err == nil && Hash(data) == l.ContractHash {
+			return data, "git " + l.GitSHA, nil
+		}
+	}
+	return nil, "", ErrNotRecoverable
+}
+
+// gitShow reads file at rev. Relative paths are taken from the working
+// directory, as the lock command records them.
+func gitShow(rev, file string) ([]byte, error) {
+	spec := rev + ":./" + filepath.ToSlash(file)
+	if filepath.IsAbs(file) {
+		spec = rev + ":" + filepath.ToSlash(file)
+		if top, err := git("rev-parse", "--show-toplevel"); err == nil {
+			if rel, err := filepath.Rel(strings.TrimSpace(string(top)), file); err == nil {
+				spec = rev + ":" + filepath.ToSlash(rel)
+			}
+		}
+	}
+	return git("show", spec)
+}
+
+func git(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
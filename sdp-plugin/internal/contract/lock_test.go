@@ -0,0 +1,95 @@
+package contract
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.lock")
+	data := "contract_file: users.yaml\ncontract_hash: abc\ngit_sha: HEAD\nlocked_at: \"2026-01-02T03:04:05Z\"\nbackup: .locked/users.yaml\nmetadata:\n  feature: users\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lock, err := ReadLock(path)
+	if err != nil {
+		t.Fatalf("ReadLock: %v", err)
+	}
+	if lock.ContractHash != "abc" || lock.Backup != ".locked/users.yaml" || lock.Metadata.Feature != "users" {
+		t.Errorf("unexpected lock: %+v", lock)
+	}
+	if _, err := ReadLock(filepath.Join(t.TempDir(), "missing.lock")); err == nil {
+		t.Error("expected error for missing lock")
+	}
+}
+
+func TestLockedContent_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(usersV1)
+	backup, err := WriteSnapshot(filepath.Join(dir, "users.lock"), filepath.Join(dir, "users.yaml"), content)
+	if err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	if want := filepath.Join(dir, ".locked", "users.yaml"); backup != want {
+		t.Errorf("snapshot path = %s, want %s", backup, want)
+	}
+
+	lock := &Lock{ContractHash: Hash(content), Backup: backup}
+	got, source, err := lock.LockedContent()
+	if err != nil || string(got) != usersV1 || source != backup {
+		t.Errorf("LockedContent = %q, %q, %v", got, source, err)
+	}
+
+	// A snapshot that no longer matches the lock is not trusted.
+	if err := os.WriteFile(backup, []byte("tampered"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := lock.LockedContent(); !errors.Is(err, ErrNotRecoverable) {
+		t.Errorf("expected ErrNotRecoverable, got %v", err)
+	}
+}
+
+func TestLockedContent_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	t.Chdir(dir)
+	run := func(args ...string) string {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "test")
+	if err := os.MkdirAll(".contracts", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(".contracts/users.yaml", []byte(usersV1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-qm", "contract")
+	sha := run("rev-parse", "HEAD")
+	if err := os.WriteFile(".contracts/users.yaml", []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lock := &Lock{ContractFile: ".contracts/users.yaml", ContractHash: Hash([]byte(usersV1)), GitSHA: sha}
+	got, source, err := lock.LockedContent()
+	if err != nil || string(got) != usersV1 || source != "git "+sha {
+		t.Errorf("LockedContent = %q, %q, %v", got, source, err)
+	}
+
+	lock.GitSHA = "unknown"
+	if _, _, err := lock.LockedContent(); !errors.Is(err, ErrNotRecoverable) {
+		t.Errorf("expected ErrNotRecoverable without SHA, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Package contract models locked API contracts and compares contract
+// versions for breaking changes.
+//
+// The OpenAPI model mirrors agents.OpenAPIContract in the root module
+// (src/sdp/agents), which this module cannot import, and extends it with
+// parameters, components and $ref so contracts written by either side can
+// be compared.
+package contract
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI is an OpenAPI 3 contract.
+type OpenAPI struct {
+	OpenAPI    string              `yaml:"openapi"`
+	Info       Info                `yaml:"info"`
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components Components          `yaml:"components,omitempty"`
+}
+
+// Info is the OpenAPI info block.
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// PathItem maps lower-case HTTP methods to operations.
+type PathItem map[string]Operation
+
+// httpMethods are the PathItem keys that hold operations.
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true,
+	"options": true, "head": true, "patch": true, "trace": true,
+}
+
+// UnmarshalYAML keeps only operations, skipping path-level keys such as
+// parameters and summary.
+func (p *PathItem) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: path item must be a mapping", node.Line)
+	}
+	item := make(PathItem)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		method := strings.ToLower(node.Content[i].Value)
+		if !httpMethods[method] {
+			continue
+		}
+		var op Operation
+		if err := node.Content[i+1].Decode(&op); err != nil {
+			return err
+		}
+		item[method] = op
+	}
+	*p = item
+	return nil
+}
+
+// Operation is one HTTP operation.
+type Operation struct {
+	OperationID string              `yaml:"operationId,omitempty"`
+	Summary     string              `yaml:"summary,omitempty"`
+	Parameters  []Parameter         `yaml:"parameters,omitempty"`
+	RequestBody *RequestBody        `yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+// Parameter is a path, query, header or cookie parameter.
+type Parameter struct {
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required,omitempty"`
+	Schema   *Schema `yaml:"schema,omitempty"`
+}
+
+// RequestBody is an operation's request body.
+type RequestBody struct {
+	Required bool                 `yaml:"required,omitempty"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+// Response is one response of an operation.
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content,omitempty"`
+}
+
+// MediaType holds the schema of one content type.
+type MediaType struct {
+	Schema *Schema `yaml:"schema,omitempty"`
+}
+
+// Schema is the subset of JSON Schema used by contracts.
+type Schema struct {
+	Ref        string             `yaml:"$ref,omitempty"`
+	Type       string             `yaml:"type,omitempty"`
+	Format     string             `yaml:"format,omitempty"`
+	Enum       []any              `yaml:"enum,omitempty"`
+	Nullable   bool               `yaml:"nullable,omitempty"`
+	Properties map[string]*Schema `yaml:"properties,omitempty"`
+	Required   []string           `yaml:"required,omitempty"`
+	Items      *Schema            `yaml:"items,omitempty"`
+}
+
+// Components holds reusable schemas.
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas,omitempty"`
+}
+
+// ParseOpenAPI decodes a YAML or JSON OpenAPI contract.
+func ParseOpenAPI(data []byte) (*OpenAPI, error) {
+	var doc OpenAPI
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse contract: %w", err)
+	}
+	if doc.OpenAPI == "" {
+		return nil, fmt.Errorf("parse contract: not an OpenAPI document (missing openapi version)")
+	}
+	return &doc, nil
+}
+
+// maxRefDepth bounds $ref resolution of recursive schemas.
+const maxRefDepth = 16
+
+// resolve follows local "#/components/schemas/<name>" references. It
+// returns nil for dangling or cyclic references.
+func (c *OpenAPI) resolve(s *Schema) *Schema {
+	for depth := 0; s != nil && s.Ref != ""; depth++ {
+		if depth == maxRefDepth {
+			return nil
+		}
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok {
+			return s
+		}
+		s = c.Components.Schemas[name]
+	}
+	return s
+}
+
+// requiredSet returns the names in s.Required.
+func (s *Schema) requiredSet() map[string]bool {
+	set := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		set[name] = true
+	}
+	return set
+}
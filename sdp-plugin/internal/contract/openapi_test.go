@@ -0,0 +1,110 @@
+package contract
+
+import "testing"
+
+const usersV1 = `openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    parameters:
+      - name: trace
+        in: header
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewUser'
+      responses:
+        "201":
+          description: created
+  /users/{id}:
+    delete:
+      responses:
+        "204":
+          description: deleted
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, email]
+      properties:
+        id:
+          type: integer
+        email:
+          type: string
+        role:
+          type: string
+          enum: [admin, member]
+    NewUser:
+      type: object
+      required: [email]
+      properties:
+        email:
+          type: string
+        age:
+          type: integer
+`
+
+func TestParseOpenAPI(t *testing.T) {
+	spec, err := ParseOpenAPI([]byte(usersV1))
+	if err != nil {
+		t.Fatalf("ParseOpenAPI: %v", err)
+	}
+	if spec.Info.Title != "Users" {
+		t.Errorf("title = %q", spec.Info.Title)
+	}
+	item := spec.Paths["/users"]
+	if len(item) != 2 {
+		t.Errorf("/users operations = %d, want 2 (path-level parameters skipped)", len(item))
+	}
+	if got := item["get"].Parameters[0].Name; got != "limit" {
+		t.Errorf("get parameter = %q", got)
+	}
+	user := spec.resolve(item["get"].Responses["200"].Content["application/json"].Schema.Items)
+	if user == nil || user.Properties["email"] == nil {
+		t.Fatalf("User schema not resolved: %+v", user)
+	}
+	if !user.requiredSet()["id"] {
+		t.Error("id should be required")
+	}
+}
+
+func TestParseOpenAPI_Errors(t *testing.T) {
+	for name, data := range map[string]string{
+		"not openapi": "title: x\n",
+		"malformed":   "openapi: [",
+	} {
+		if _, err := ParseOpenAPI([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestResolve_Cycle(t *testing.T) {
+	spec := &OpenAPI{Components: Components{Schemas: map[string]*Schema{
+		"A": {Ref: "#/components/schemas/B"},
+		"B": {Ref: "#/components/schemas/A"},
+	}}}
+	if got := spec.resolve(&Schema{Ref: "#/components/schemas/A"}); got != nil {
+		t.Errorf("cyclic ref resolved to %+v, want nil", got)
+	}
+}
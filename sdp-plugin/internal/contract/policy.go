@@ -0,0 +1,71 @@
+package contract
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+)
+
+// ApprovalTag marks a decision record that approves breaking changes to a
+// feature's locked contract.
+const ApprovalTag = "breaking-change"
+
+// Fail-on modes for Policy.
+const (
+	FailOnBreaking = "breaking"
+	FailOnAny      = "any"
+)
+
+// Policy decides whether contract changes fail verification.
+type Policy struct {
+	FailOn    string // FailOnBreaking (default) or FailOnAny
+	Decisions []decision.Decision
+}
+
+// Verdict is the outcome of applying a Policy to a Diff.
+type Verdict struct {
+	Failed   bool
+	Reason   string
+	Approval *decision.Decision // decision that approved breaking changes
+}
+
+// Evaluate applies the policy to a contract of feature that no longer
+// matches the lock taken at lockedAt (RFC 3339). FailOnAny fails on any
+// edit, as verify did before semantic diffs; otherwise breaking changes
+// pass only when a decision for the feature tagged ApprovalTag was
+// recorded after the lock.
+func (p Policy) Evaluate(feature, lockedAt string, d *Diff) Verdict {
+	if p.FailOn == FailOnAny {
+		return Verdict{Failed: true, Reason: fmt.Sprintf("contract changed since lock (%d semantic change(s), fail_on: any)", len(d.Changes))}
+	}
+	breaking := d.Breaking()
+	if len(breaking) == 0 {
+		return Verdict{}
+	}
+	if approval := p.approval(feature, lockedAt); approval != nil {
+		return Verdict{Reason: fmt.Sprintf("%d breaking change(s) approved by decision %q", len(breaking), approval.Decision), Approval: approval}
+	}
+	return Verdict{Failed: true, Reason: fmt.Sprintf("%d breaking change(s) without an approving decision (tag %q)", len(breaking), ApprovalTag)}
+}
+
+// approval returns the latest approving decision for feature made after
+// the lock, or nil.
+func (p Policy) approval(feature, lockedAt string) *decision.Decision {
+	since, err := time.Parse(time.RFC3339, lockedAt)
+	if err != nil {
+		since = time.Time{}
+	}
+	var found *decision.Decision
+	for i := range p.Decisions {
+		dec := &p.Decisions[i]
+		if dec.FeatureID != feature || !slices.Contains(dec.Tags, ApprovalTag) || dec.Timestamp.Before(since) {
+			continue
+		}
+		if found == nil || dec.Timestamp.After(found.Timestamp) {
+			found = dec
+		}
+	}
+	return found
+}
@@ -0,0 +1,47 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	lockedAt := "2026-03-01T00:00:00Z"
+	breaking := &Diff{Changes: []Change{{Kind: PathRemoved, Location: "/users", Breaking: true}}}
+	compatible := &Diff{Changes: []Change{{Kind: PathAdded, Location: "/teams"}}}
+	approve := func(feature string, at time.Time, tags ...string) decision.Decision {
+		return decision.Decision{FeatureID: feature, Timestamp: at, Tags: tags, Decision: "drop /users"}
+	}
+	after := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		diff     *Diff
+		failed   bool
+		approved bool
+	}{
+		{"compatible change passes", Policy{}, compatible, false, false},
+		{"any edit fails under any", Policy{FailOn: FailOnAny}, &Diff{}, true, false},
+		{"compatible change fails under any", Policy{FailOn: FailOnAny}, compatible, true, false},
+		{"breaking change fails", Policy{}, breaking, true, false},
+		{"approved breaking change passes", Policy{Decisions: []decision.Decision{approve("users", after, "api", ApprovalTag)}}, breaking, false, true},
+		{"approval before lock ignored", Policy{Decisions: []decision.Decision{approve("users", before, ApprovalTag)}}, breaking, true, false},
+		{"approval for other feature ignored", Policy{Decisions: []decision.Decision{approve("teams", after, ApprovalTag)}}, breaking, true, false},
+		{"decision without tag ignored", Policy{Decisions: []decision.Decision{approve("users", after, "api")}}, breaking, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.policy.Evaluate("users", lockedAt, tt.diff)
+			if v.Failed != tt.failed {
+				t.Errorf("failed = %v, want %v (%s)", v.Failed, tt.failed, v.Reason)
+			}
+			if (v.Approval != nil) != tt.approved {
+				t.Errorf("approval = %v, want approved=%v", v.Approval, tt.approved)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package contract
+
+import "fmt"
+
+// Proto is a parsed .proto file: enough of the schema to lock, diff and
+// validate gRPC contracts without protoc.
+type Proto struct {
+	Syntax   string
+	Package  string
+	Services []ProtoService
+	Messages map[string]*ProtoMessage // by name, nested as "Outer.Inner"
+	Enums    map[string]*ProtoEnum    // by name, nested as "Outer.Inner"
+}
+
+// ProtoService is a gRPC service.
+type ProtoService struct {
+	Name string
+	RPCs []ProtoRPC
+}
+
+// ProtoRPC is one method of a service.
+type ProtoRPC struct {
+	Name            string
+	Request         string
+	Response        string
+	ClientStreaming bool
+	ServerStreaming bool
+}
+
+// ProtoMessage is a message with its fields and reservations.
+type ProtoMessage struct {
+	Name          string
+	Fields        []ProtoField
+	Reserved      []ProtoRange
+	ReservedNames []string
+}
+
+// ProtoField is a message field; map fields have Type "map<K, V>".
+type ProtoField struct {
+	Name   string
+	Number int
+	Type   string
+	Label  string // "repeated", "optional", "required" or ""
+	Oneof  string
+}
+
+// ProtoEnum is an enum with its values and reservations.
+type ProtoEnum struct {
+	Name          string
+	Values        []ProtoEnumValue
+	Reserved      []ProtoRange
+	ReservedNames []string
+}
+
+// ProtoEnumValue is one enum constant.
+type ProtoEnumValue struct {
+	Name   string
+	Number int
+}
+
+// ProtoRange is an inclusive range of reserved numbers.
+type ProtoRange struct {
+	Start, End int
+}
+
+// protoMaxField is the largest field number, used for "max" in ranges.
+const protoMaxField = 536870911
+
+// ParseProto parses a .proto file. Options, imports and extensions are
+// skipped; type names are kept as written, minus a leading dot.
+func ParseProto(data []byte) (*Proto, error) {
+	toks, err := lexProto(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse proto: %w", err)
+	}
+	p := &protoParser{toks: toks, file: &Proto{Messages: map[string]*ProtoMessage{}, Enums: map[string]*ProtoEnum{}}}
+	if err := p.parseFile(); err != nil {
+		return nil, fmt.Errorf("parse proto: %w", err)
+	}
+	return p.file, nil
+}
+
+// RPCCount returns the number of RPCs across services.
+func (p *Proto) RPCCount() int {
+	n := 0
+	for _, s := range p.Services {
+		n += len(s.RPCs)
+	}
+	return n
+}
+
+// IsReserved reports whether number falls in one of ranges.
+func IsReserved(ranges []ProtoRange, number int) bool {
+	for _, r := range ranges {
+		if number >= r.Start && number <= r.End {
+			return true
+		}
+	}
+	return false
+}
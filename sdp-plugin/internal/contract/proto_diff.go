@@ -0,0 +1,134 @@
+package contract
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Change kinds specific to proto contracts.
+const (
+	PackageChanged    = "package-changed"
+	ServiceRemoved    = "service-removed"
+	ServiceAdded      = "service-added"
+	RPCRemoved        = "rpc-removed"
+	RPCAdded          = "rpc-added"
+	RPCChanged        = "rpc-changed"
+	MessageRemoved    = "message-removed"
+	MessageAdded      = "message-added"
+	FieldNumberReused = "field-number-reused"
+	FieldRenamed      = "field-renamed"
+	ReservedRemoved   = "reserved-removed"
+	EnumValueRemoved  = "enum-value-removed"
+	EnumValueAdded    = "enum-value-added"
+)
+
+// CompareContent diffs two versions of a contract file, as proto when
+// path ends in .proto and as OpenAPI otherwise.
+func CompareContent(path string, old, cur []byte) (*Diff, error) {
+	if IsProtoFile(path) {
+		oldSpec, err := ParseProto(old)
+		if err != nil {
+			return nil, err
+		}
+		curSpec, err := ParseProto(cur)
+		if err != nil {
+			return nil, err
+		}
+		return CompareProto(oldSpec, curSpec), nil
+	}
+	oldSpec, err := ParseOpenAPI(old)
+	if err != nil {
+		return nil, err
+	}
+	curSpec, err := ParseOpenAPI(cur)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(oldSpec, curSpec), nil
+}
+
+// IsProtoFile reports whether path names a .proto contract.
+func IsProtoFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".proto")
+}
+
+// CompareProto classifies changes between proto contracts by wire and
+// generated-code compatibility: removed services, RPCs, messages and
+// unreserved fields, changed field types or RPC signatures, and reuse of
+// field numbers are breaking.
+func CompareProto(old, cur *Proto) *Diff {
+	d := &differ{}
+	if old.Package != cur.Package {
+		d.add(PackageChanged, "package", true, "package %q changed to %q", old.Package, cur.Package)
+	}
+	d.protoServices(old.Services, cur.Services)
+	for _, name := range sortedKeys(old.Messages) {
+		if msg, ok := cur.Messages[name]; ok {
+			d.protoMessage(old.Messages[name], msg)
+		} else {
+			d.add(MessageRemoved, "message "+name, true, "message removed")
+		}
+	}
+	for _, name := range sortedKeys(cur.Messages) {
+		if _, ok := old.Messages[name]; !ok {
+			d.add(MessageAdded, "message "+name, false, "message added")
+		}
+	}
+	for _, name := range sortedKeys(old.Enums) {
+		if enum, ok := cur.Enums[name]; ok {
+			d.protoEnum(old.Enums[name], enum)
+		} else {
+			d.add(MessageRemoved, "enum "+name, true, "enum removed")
+		}
+	}
+	return &Diff{Changes: d.changes}
+}
+
+func (d *differ) protoServices(old, cur []ProtoService) {
+	curByName := make(map[string]ProtoService, len(cur))
+	for _, s := range cur {
+		curByName[s.Name] = s
+	}
+	oldNames := make(map[string]bool, len(old))
+	for _, s := range old {
+		oldNames[s.Name] = true
+		next, ok := curByName[s.Name]
+		if !ok {
+			d.add(ServiceRemoved, "service "+s.Name, true, "service removed")
+			continue
+		}
+		for _, rpc := range s.RPCs {
+			loc := "rpc " + s.Name + "." + rpc.Name
+			i := slices.IndexFunc(next.RPCs, func(r ProtoRPC) bool { return r.Name == rpc.Name })
+			if i < 0 {
+				d.add(RPCRemoved, loc, true, "RPC removed")
+				continue
+			}
+			if sig, nextSig := rpcSignature(rpc), rpcSignature(next.RPCs[i]); sig != nextSig {
+				d.add(RPCChanged, loc, true, "signature %s changed to %s", sig, nextSig)
+			}
+		}
+		for _, rpc := range next.RPCs {
+			if !slices.ContainsFunc(s.RPCs, func(r ProtoRPC) bool { return r.Name == rpc.Name }) {
+				d.add(RPCAdded, "rpc "+s.Name+"."+rpc.Name, false, "RPC added")
+			}
+		}
+	}
+	for _, s := range cur {
+		if !oldNames[s.Name] {
+			d.add(ServiceAdded, "service "+s.Name, false, "service added")
+		}
+	}
+}
+
+func rpcSignature(r ProtoRPC) string {
+	stream := func(on bool) string {
+		if on {
+			return "stream "
+		}
+		return ""
+	}
+	return fmt.Sprintf("(%s%s) returns (%s%s)", stream(r.ClientStreaming), r.Request, stream(r.ServerStreaming), r.Response)
+}
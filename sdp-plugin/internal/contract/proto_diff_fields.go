@@ -0,0 +1,90 @@
+package contract
+
+import "slices"
+
+// protoMessage compares fields by number, the identity on the wire.
+func (d *differ) protoMessage(old, cur *ProtoMessage) {
+	loc := "message " + old.Name
+	curByNum := make(map[int]ProtoField, len(cur.Fields))
+	for _, f := range cur.Fields {
+		curByNum[f.Number] = f
+	}
+	oldByNum := make(map[int]ProtoField, len(old.Fields))
+	for _, f := range old.Fields {
+		oldByNum[f.Number] = f
+		next, ok := curByNum[f.Number]
+		switch {
+		case !ok && IsReserved(cur.Reserved, f.Number):
+			d.add(FieldRemoved, loc, false, "field %d (%s) removed and reserved", f.Number, f.Name)
+		case !ok:
+			d.add(FieldRemoved, loc, true, "field %d (%s) removed without reserving its number", f.Number, f.Name)
+		case next.Name != f.Name && fieldType(next) != fieldType(f):
+			d.add(FieldNumberReused, loc, true, "field number %d reused: %s %s is now %s %s", f.Number, fieldType(f), f.Name, fieldType(next), next.Name)
+		case fieldType(next) != fieldType(f):
+			d.add(TypeChanged, loc, true, "field %d (%s) type %s changed to %s", f.Number, f.Name, fieldType(f), fieldType(next))
+		case next.Name != f.Name:
+			// Wire-compatible, but breaks JSON mapping and generated code.
+			d.add(FieldRenamed, loc, true, "field %d renamed from %s to %s", f.Number, f.Name, next.Name)
+		case next.Oneof != f.Oneof:
+			d.add(TypeChanged, loc, true, "field %d (%s) moved from oneof %q to %q", f.Number, f.Name, f.Oneof, next.Oneof)
+		}
+	}
+	for _, f := range cur.Fields {
+		if _, ok := oldByNum[f.Number]; ok {
+			continue
+		}
+		if IsReserved(old.Reserved, f.Number) || slices.Contains(old.ReservedNames, f.Name) {
+			d.add(FieldNumberReused, loc, true, "field %d (%s) reuses a reserved number or name", f.Number, f.Name)
+			continue
+		}
+		d.add(FieldAdded, loc, false, "field %d (%s) added", f.Number, f.Name)
+	}
+	d.protoReserved(loc, old.Reserved, cur.Reserved, func(n int) bool { _, used := curByNum[n]; return used })
+}
+
+// fieldType is the type with its cardinality; optional and implicit
+// presence encode the same on the wire.
+func fieldType(f ProtoField) string {
+	if f.Label == "repeated" {
+		return "repeated " + f.Type
+	}
+	return f.Type
+}
+
+// protoReserved reports reserved numbers that are no longer reserved,
+// which lets a later change reuse them.
+func (d *differ) protoReserved(loc string, old, cur []ProtoRange, used func(int) bool) {
+	for _, r := range old {
+		for n := r.Start; n <= r.End && n-r.Start < 1000; n++ {
+			if !IsReserved(cur, n) && !used(n) {
+				d.add(ReservedRemoved, loc, true, "reserved number %d no longer reserved", n)
+				break
+			}
+		}
+	}
+}
+
+func (d *differ) protoEnum(old, cur *ProtoEnum) {
+	loc := "enum " + old.Name
+	for _, v := range old.Values {
+		i := slices.IndexFunc(cur.Values, func(c ProtoEnumValue) bool { return c.Name == v.Name })
+		switch {
+		case i >= 0 && cur.Values[i].Number != v.Number:
+			d.add(TypeChanged, loc, true, "value %s number %d changed to %d", v.Name, v.Number, cur.Values[i].Number)
+		case i < 0 && IsReserved(cur.Reserved, v.Number):
+			d.add(EnumValueRemoved, loc, false, "value %s (%d) removed and reserved", v.Name, v.Number)
+		case i < 0:
+			d.add(EnumValueRemoved, loc, true, "value %s (%d) removed without reserving its number", v.Name, v.Number)
+		}
+	}
+	for _, v := range cur.Values {
+		if slices.ContainsFunc(old.Values, func(o ProtoEnumValue) bool { return o.Name == v.Name }) {
+			continue
+		}
+		if IsReserved(old.Reserved, v.Number) {
+			d.add(FieldNumberReused, loc, true, "value %s reuses reserved number %d", v.Name, v.Number)
+			continue
+		}
+		d.add(EnumValueAdded, loc, false, "value %s (%d) added", v.Name, v.Number)
+	}
+}
@@ -0,0 +1,75 @@
+package contract
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareProto(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string // replaced in usersProto
+		kind     string
+		detail   string
+		breaking bool
+	}{
+		{"rpc removed", "  rpc GetUser(GetUserRequest) returns (User);\n", "", RPCRemoved, "RPC removed", true},
+		{"rpc added", "  rpc GetUser(GetUserRequest) returns (User);\n", "  rpc GetUser(GetUserRequest) returns (User);\n  rpc DeleteUser(GetUserRequest) returns (User);\n", RPCAdded, "RPC added", false},
+		{"rpc response changed", "returns (stream User)", "returns (User)", RPCChanged, "(ListUsersRequest) returns (stream User) changed to (ListUsersRequest) returns (User)", true},
+		{"service removed", "service UserService", "service AccountService", ServiceRemoved, "service removed", true},
+		{"field type changed", "int64 count = 1;", "int32 count = 1;", TypeChanged, "field 1 (count) type int64 changed to int32", true},
+		{"field cardinality changed", "repeated string tags = 5;", "string tags = 5;", TypeChanged, "type repeated string changed to string", true},
+		{"field number reused", "int64 count = 1;", "string label = 1;", FieldNumberReused, "field number 1 reused: int64 count is now string label", true},
+		{"reserved number reused", "  string id = 1;\n  string email", "  string id = 1;\n  bool active = 9;\n  string email", FieldNumberReused, "field 9 (active) reuses a reserved number or name", true},
+		{"field removed", "  repeated string tags = 5;\n", "", FieldRemoved, "field 5 (tags) removed without reserving its number", true},
+		{"field removed and reserved", "  reserved 4, 8 to 10;\n", "  reserved 4, 5, 8 to 10;\n", "", "", false},
+		{"field renamed", "string email = 2", "string mail = 2", FieldRenamed, "field 2 renamed from email to mail", true},
+		{"field added", "int64 count = 1;", "int64 count = 1; string note = 2;", FieldAdded, "field 2 (note) added", false},
+		{"enum value removed", "    ROLE_ADMIN = 1;\n", "", EnumValueRemoved, "value ROLE_ADMIN (1) removed without reserving its number", true},
+		{"enum value reuses reserved", "    ROLE_ADMIN = 1;\n", "    ROLE_ADMIN = 1;\n    ROLE_GUEST = 2;\n", FieldNumberReused, "value ROLE_GUEST reuses reserved number 2", true},
+		{"reserved removed", "  reserved 4, 8 to 10;\n", "  reserved 8 to 10;\n", ReservedRemoved, "reserved number 4 no longer reserved", true},
+		{"message removed", "message UploadSummary { int64 count = 1; }", "", MessageRemoved, "message removed", true},
+		{"package changed", "package users.v1;", "package users.v2;", PackageChanged, `package "users.v1" changed to "users.v2"`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(usersProto, tt.old) {
+				t.Fatalf("fixture does not contain %q", tt.old)
+			}
+			cur := strings.Replace(usersProto, tt.old, tt.new, 1)
+			if tt.name == "field removed and reserved" {
+				cur = strings.Replace(cur, "  repeated string tags = 5;\n", "", 1)
+			}
+			d, err := CompareContent("users.proto", []byte(usersProto), []byte(cur))
+			if err != nil {
+				t.Fatalf("CompareContent: %v", err)
+			}
+			if tt.kind == "" {
+				if d.HasBreaking() {
+					t.Errorf("expected no breaking changes, got %v", d.Breaking())
+				}
+				return
+			}
+			for _, c := range d.Changes {
+				if c.Kind == tt.kind && strings.Contains(c.Detail, tt.detail) {
+					if c.Breaking != tt.breaking {
+						t.Errorf("%s: breaking = %v, want %v", c, c.Breaking, tt.breaking)
+					}
+					return
+				}
+			}
+			t.Errorf("no %s change %q in %v", tt.kind, tt.detail, d.Changes)
+		})
+	}
+}
+
+func TestCompareContent_OpenAPI(t *testing.T) {
+	cur := strings.Replace(usersV1, "    delete:", "    patch:", 1)
+	d, err := CompareContent("users.yaml", []byte(usersV1), []byte(cur))
+	if err != nil || !d.HasBreaking() {
+		t.Errorf("CompareContent = %v, %v", d, err)
+	}
+	if _, err := CompareContent("users.proto", []byte("message {"), []byte(usersProto)); err == nil {
+		t.Error("expected parse error")
+	}
+}
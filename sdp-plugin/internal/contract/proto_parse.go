@@ -0,0 +1,124 @@
+package contract
+
+import (
+	"strconv"
+	"strings"
+)
+
+func (p *protoParser) parseMessage(prefix string) error {
+	msg := &ProtoMessage{Name: prefix + p.next()}
+	p.file.Messages[msg.Name] = msg
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.parseMessageBody(msg, "")
+}
+
+// parseMessageBody parses fields up to the closing brace; oneof bodies
+// share it with oneof set.
+func (p *protoParser) parseMessageBody(msg *ProtoMessage, oneof string) error {
+	for {
+		switch tok := p.peek(); tok {
+		case "":
+			return p.errorf("unexpected end of file in message %s", msg.Name)
+		case "}":
+			p.next()
+			return nil
+		case ";":
+			p.next()
+		case "message", "enum", "oneof", "reserved", "option", "extensions", "extend":
+			p.next()
+			var err error
+			switch tok {
+			case "message":
+				err = p.parseMessage(msg.Name + ".")
+			case "enum":
+				err = p.parseEnum(msg.Name + ".")
+			case "oneof":
+				name := p.next()
+				if err = p.expect("{"); err == nil {
+					err = p.parseMessageBody(msg, name)
+				}
+			case "reserved":
+				msg.Reserved, msg.ReservedNames, err = p.parseReserved(msg.Reserved, msg.ReservedNames)
+			default:
+				err = p.skipStatement()
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			field, err := p.parseField()
+			if err != nil {
+				return err
+			}
+			field.Oneof = oneof
+			msg.Fields = append(msg.Fields, field)
+		}
+	}
+}
+
+// parseField parses "[label] type name = number [options];".
+func (p *protoParser) parseField() (ProtoField, error) {
+	var f ProtoField
+	if tok := p.peek(); tok == "repeated" || tok == "optional" || tok == "required" {
+		f.Label = p.next()
+	}
+	f.Type = normalizeType(p.next())
+	if f.Type == "map" && p.peek() == "<" {
+		p.next()
+		key := p.next()
+		if err := p.expect(","); err != nil {
+			return f, err
+		}
+		value := normalizeType(p.next())
+		if err := p.expect(">"); err != nil {
+			return f, err
+		}
+		f.Type = "map<" + key + ", " + value + ">"
+	}
+	f.Name = p.next()
+	if err := p.expect("="); err != nil {
+		return f, err
+	}
+	n, err := strconv.Atoi(p.next())
+	if err != nil {
+		p.pos--
+		return f, p.errorf("invalid field number for %s", f.Name)
+	}
+	f.Number = n
+	return f, p.skipStatement()
+}
+
+// parseReserved parses "reserved 1, 4 to 6, max;" or `reserved "a", "b";`.
+func (p *protoParser) parseReserved(ranges []ProtoRange, names []string) ([]ProtoRange, []string, error) {
+	for {
+		tok := p.next()
+		switch {
+		case tok == ";":
+			return ranges, names, nil
+		case tok == ",":
+		case strings.HasPrefix(tok, `"`) || strings.HasPrefix(tok, "'"):
+			names = append(names, unquote(tok))
+		case tok == "":
+			return nil, nil, p.errorf("unexpected end of file in reserved")
+		default:
+			start, err := strconv.Atoi(tok)
+			if err != nil {
+				// Editions write reserved names as bare identifiers.
+				names = append(names, tok)
+				continue
+			}
+			end := start
+			if p.peek() == "to" {
+				p.next()
+				if bound := p.next(); bound == "max" {
+					end = protoMaxField
+				} else if end, err = strconv.Atoi(bound); err != nil {
+					return nil, nil, p.errorf("invalid reserved range end %q", bound)
+				}
+			}
+			ranges = append(ranges, ProtoRange{start, end})
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package contract
+
+import "strconv"
+
+func (p *protoParser) parseEnum(prefix string) error {
+	enum := &ProtoEnum{Name: prefix + p.next()}
+	p.file.Enums[enum.Name] = enum
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		switch tok := p.next(); tok {
+		case "":
+			return p.errorf("unexpected end of file in enum %s", enum.Name)
+		case "}":
+			return nil
+		case ";":
+		case "option":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "reserved":
+			var err error
+			if enum.Reserved, enum.ReservedNames, err = p.parseReserved(enum.Reserved, enum.ReservedNames); err != nil {
+				return err
+			}
+		default:
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			n, err := strconv.Atoi(p.next())
+			if err != nil {
+				p.pos--
+				return p.errorf("invalid value for %s", tok)
+			}
+			enum.Values = append(enum.Values, ProtoEnumValue{Name: tok, Number: n})
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *protoParser) parseService() error {
+	svc := ProtoService{Name: p.next()}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		switch tok := p.next(); tok {
+		case "":
+			return p.errorf("unexpected end of file in service %s", svc.Name)
+		case "}":
+			p.file.Services = append(p.file.Services, svc)
+			return nil
+		case ";":
+		case "rpc":
+			rpc := ProtoRPC{Name: p.next()}
+			var err error
+			if rpc.Request, rpc.ClientStreaming, err = p.parseRPCType(); err != nil {
+				return err
+			}
+			if err := p.expect("returns"); err != nil {
+				return err
+			}
+			if rpc.Response, rpc.ServerStreaming, err = p.parseRPCType(); err != nil {
+				return err
+			}
+			svc.RPCs = append(svc.RPCs, rpc)
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseRPCType parses "( [stream] Type )".
+func (p *protoParser) parseRPCType() (string, bool, error) {
+	if err := p.expect("("); err != nil {
+		return "", false, err
+	}
+	stream := false
+	if p.peek() == "stream" && p.pos+1 < len(p.toks) && p.toks[p.pos+1].text != ")" {
+		p.next()
+		stream = true
+	}
+	typ := normalizeType(p.next())
+	return typ, stream, p.expect(")")
+}
@@ -0,0 +1,117 @@
+package contract
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type protoParser struct {
+	toks []protoToken
+	pos  int
+	file *Proto
+}
+
+func (p *protoParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos].text
+	}
+	return ""
+}
+
+func (p *protoParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *protoParser) errorf(format string, args ...any) error {
+	line := 0
+	if p.pos < len(p.toks) {
+		line = p.toks[p.pos].line
+	} else if len(p.toks) > 0 {
+		line = p.toks[len(p.toks)-1].line
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *protoParser) expect(want string) error {
+	if got := p.next(); got != want {
+		p.pos--
+		return p.errorf("expected %q, found %q", want, got)
+	}
+	return nil
+}
+
+// skipStatement skips to the end of the current statement: a ';' or a
+// balanced {...} block, whichever closes it.
+func (p *protoParser) skipStatement() error {
+	depth := 0
+	for p.pos < len(p.toks) {
+		switch p.next() {
+		case "{", "[", "(":
+			depth++
+		case "}", "]", ")":
+			depth--
+			if depth == 0 && p.toks[p.pos-1].text == "}" && p.peek() != ";" {
+				return nil
+			}
+		case ";":
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+	return p.errorf("unexpected end of file")
+}
+
+func (p *protoParser) parseFile() error {
+	for p.pos < len(p.toks) {
+		switch kw := p.next(); kw {
+		case "syntax", "edition":
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			p.file.Syntax = unquote(p.next())
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "package":
+			p.file.Package = p.next()
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage(""); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum(""); err != nil {
+				return err
+			}
+		case "service":
+			if err := p.parseService(); err != nil {
+				return err
+			}
+		case ";":
+		default:
+			// import, option, extend: not part of the contract model.
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func unquote(s string) string {
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return strings.Trim(s, `"'`)
+}
+
+// normalizeType drops a leading dot from fully-qualified type names.
+func normalizeType(t string) string {
+	return strings.TrimPrefix(t, ".")
+}
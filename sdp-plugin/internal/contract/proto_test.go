@@ -0,0 +1,115 @@
+package contract
+
+import "testing"
+
+const usersProto = `// User service contract.
+syntax = "proto3";
+
+package users.v1;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/users/v1;usersv1";
+
+/* Users and their roles. */
+service UserService {
+  option (google.api.default_host) = "users.example.com";
+
+  rpc GetUser(GetUserRequest) returns (User);
+  rpc ListUsers(ListUsersRequest) returns (stream User) {
+    option (google.api.http) = { get: "/v1/users" };
+  }
+  rpc Upload(stream .users.v1.User) returns (UploadSummary) {}
+}
+
+message User {
+  reserved 4, 8 to 10;
+  reserved "legacy_name";
+
+  string id = 1;
+  string email = 2 [deprecated = true];
+  Role role = 3;
+  repeated string tags = 5;
+  map<string, string> labels = 6;
+  google.protobuf.Timestamp created_at = 7;
+  oneof contact {
+    string phone = 11;
+    Address address = 12;
+  }
+
+  message Address {
+    string city = 1;
+  }
+
+  enum Role {
+    ROLE_UNSPECIFIED = 0;
+    ROLE_ADMIN = 1;
+    reserved 2;
+  }
+}
+
+message GetUserRequest { string id = 1; }
+message ListUsersRequest { int32 page_size = 1; optional string filter = 2; }
+message UploadSummary { int64 count = 1; }
+`
+
+func TestParseProto(t *testing.T) {
+	p, err := ParseProto([]byte(usersProto))
+	if err != nil {
+		t.Fatalf("ParseProto: %v", err)
+	}
+	if p.Syntax != "proto3" || p.Package != "users.v1" {
+		t.Errorf("syntax/package = %q/%q", p.Syntax, p.Package)
+	}
+	if len(p.Services) != 1 || len(p.Services[0].RPCs) != 3 || p.RPCCount() != 3 {
+		t.Fatalf("services = %+v", p.Services)
+	}
+	list, upload := p.Services[0].RPCs[1], p.Services[0].RPCs[2]
+	if !list.ServerStreaming || list.ClientStreaming || list.Response != "User" {
+		t.Errorf("ListUsers = %+v", list)
+	}
+	if !upload.ClientStreaming || upload.Request != "users.v1.User" {
+		t.Errorf("Upload = %+v", upload)
+	}
+
+	user := p.Messages["User"]
+	if user == nil || len(user.Fields) != 8 {
+		t.Fatalf("User = %+v", user)
+	}
+	byName := map[string]ProtoField{}
+	for _, f := range user.Fields {
+		byName[f.Name] = f
+	}
+	if f := byName["tags"]; f.Label != "repeated" || f.Number != 5 {
+		t.Errorf("tags = %+v", f)
+	}
+	if f := byName["labels"]; f.Type != "map<string, string>" {
+		t.Errorf("labels = %+v", f)
+	}
+	if f := byName["address"]; f.Oneof != "contact" || f.Type != "Address" {
+		t.Errorf("address = %+v", f)
+	}
+	if !IsReserved(user.Reserved, 9) || IsReserved(user.Reserved, 7) || user.ReservedNames[0] != "legacy_name" {
+		t.Errorf("reserved = %+v %v", user.Reserved, user.ReservedNames)
+	}
+	if p.Messages["User.Address"] == nil || p.Enums["User.Role"] == nil || len(p.Enums["User.Role"].Values) != 2 {
+		t.Errorf("nested types not parsed: %v %v", p.Messages, p.Enums)
+	}
+	if f := p.Messages["ListUsersRequest"].Fields[1]; f.Label != "optional" {
+		t.Errorf("filter = %+v", f)
+	}
+}
+
+func TestParseProto_Errors(t *testing.T) {
+	for name, src := range map[string]string{
+		"unterminated comment": "/* syntax",
+		"unterminated string":  `syntax = "proto3`,
+		"bad field number":     "message A { string id = x; }",
+		"unclosed message":     "message A { string id = 1;",
+		"bad rpc":              "service S { rpc Get(A) gives (B); }",
+	} {
+		if _, err := ParseProto([]byte(src)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
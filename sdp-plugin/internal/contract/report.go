@@ -0,0 +1,39 @@
+package contract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report renders d as Markdown with breaking changes listed first.
+func (d *Diff) Report(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Contract Changes: %s\n\n", title)
+	if len(d.Changes) == 0 {
+		b.WriteString("No semantic changes.\n")
+		return b.String()
+	}
+
+	breaking := d.Breaking()
+	fmt.Fprintf(&b, "%d change(s), %d breaking.\n", len(d.Changes), len(breaking))
+	writeSection(&b, "Breaking", breaking)
+
+	var compatible []Change
+	for _, c := range d.Changes {
+		if !c.Breaking {
+			compatible = append(compatible, c)
+		}
+	}
+	writeSection(&b, "Non-breaking", compatible)
+	return b.String()
+}
+
+func writeSection(b *strings.Builder, heading string, changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", heading)
+	for _, c := range changes {
+		fmt.Fprintf(b, "- `%s` %s (%s)\n", c.Location, c.Detail, c.Kind)
+	}
+}
@@ -0,0 +1,115 @@
+package decision
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxSlugLen caps the title part of ADR file names
+const maxSlugLen = 50
+
+// ADR statuses written to frontmatter; drift.ADRValidator warns on
+// superseded ones.
+const (
+	ADRStatusAccepted   = "accepted"
+	ADRStatusSuperseded = "superseded"
+)
+
+// ADRFileName returns the numbered file name for a node, e.g.
+// 0003-use-postgresql.md.
+func ADRFileName(n *Node) string {
+	slug := normalize(n.Decision.Decision)
+	if len(slug) > maxSlugLen {
+		slug = strings.TrimSpace(slug[:maxSlugLen])
+	}
+	slug = strings.ReplaceAll(slug, " ", "-")
+	if slug == "" {
+		slug = "decision"
+	}
+	return fmt.Sprintf("%04d-%s.md", n.Number, slug)
+}
+
+// ExportADRs writes every active decision to dir as a numbered ADR.
+// ADRs exported earlier for decisions that have since been reversed are
+// rewritten with status superseded. It returns the paths written.
+func (g *Graph) ExportADRs(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create ADR directory: %w", err)
+	}
+	var written []string
+	for _, n := range g.Nodes {
+		path := filepath.Join(dir, ADRFileName(n))
+		if n.Status != StatusActive {
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+		}
+		if err := os.WriteFile(path, []byte(g.RenderADR(n)), 0o644); err != nil {
+			return written, fmt.Errorf("failed to write ADR: %w", err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// RenderADR formats a decision as ADR markdown with frontmatter
+func (g *Graph) RenderADR(n *Node) string {
+	d := n.Decision
+	status := ADRStatusAccepted
+	if n.Status == StatusSuperseded {
+		status = ADRStatusSuperseded
+	}
+
+	var md strings.Builder
+	md.WriteString("---\n")
+	fmt.Fprintf(&md, "decision_id: %s\n", n.ID)
+	fmt.Fprintf(&md, "status: %s\n", status)
+	fmt.Fprintf(&md, "date: %s\n", d.Timestamp.Format("2006-01-02"))
+	if d.Type != "" {
+		fmt.Fprintf(&md, "type: %s\n", d.Type)
+	}
+	if d.FeatureID != "" {
+		fmt.Fprintf(&md, "feature_id: %s\n", d.FeatureID)
+	}
+	if len(d.Tags) > 0 {
+		fmt.Fprintf(&md, "tags: [%s]\n", strings.Join(d.Tags, ", "))
+	}
+	if n.Reverses != "" {
+		fmt.Fprintf(&md, "supersedes: %s\n", n.Reverses)
+	}
+	if n.SupersededBy != "" {
+		fmt.Fprintf(&md, "superseded_by: %s\n", n.SupersededBy)
+	}
+	md.WriteString("---\n\n")
+	fmt.Fprintf(&md, "# %s: %s\n\n", n.ID, d.Decision)
+
+	section(&md, "Context", d.Question)
+	section(&md, "Decision", d.Decision)
+	section(&md, "Rationale", d.Rationale)
+	if len(d.Alternatives) > 0 {
+		md.WriteString("## Alternatives Considered\n\n")
+		for _, alt := range d.Alternatives {
+			fmt.Fprintf(&md, "- %s\n", alt)
+		}
+		md.WriteString("\n")
+	}
+	section(&md, "Consequences", d.Outcome)
+
+	if chain := g.Chain(n.ID); len(chain) > 1 {
+		md.WriteString("## History\n\n")
+		for _, c := range chain {
+			fmt.Fprintf(&md, "- %s (%s): %s\n", c.ID, c.Decision.Timestamp.Format("2006-01-02"), c.Decision.Decision)
+		}
+		md.WriteString("\n")
+	}
+	return md.String()
+}
+
+func section(md *strings.Builder, title, body string) {
+	if strings.TrimSpace(body) == "" {
+		return
+	}
+	fmt.Fprintf(md, "## %s\n\n%s\n\n", title, body)
+}
@@ -0,0 +1,64 @@
+package decision_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/fall-out-bug/sdp/internal/drift"
+)
+
+func TestADRFileName(t *testing.T) {
+	g := decision.BuildGraph([]decision.Decision{
+		{Decision: "Use PostgreSQL (v16+) for storage!"},
+		{Decision: "???"},
+	})
+	if got := decision.ADRFileName(g.Nodes[0]); got != "0001-use-postgresql-v16-for-storage.md" {
+		t.Errorf("file name = %s", got)
+	}
+	if got := decision.ADRFileName(g.Nodes[1]); got != "0002-decision.md" {
+		t.Errorf("file name = %s", got)
+	}
+}
+
+func TestGraph_ExportADRs(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "docs", "decisions", "adr")
+	decisions := graphDecisions()[:3]
+
+	// First export: ADR-0001 is still active
+	written, err := decision.BuildGraph(decisions[:2]).ExportADRs(dir)
+	if err != nil || len(written) != 2 {
+		t.Fatalf("first export = %v, %v", written, err)
+	}
+
+	// ADR-0003 reverses ADR-0001: its file is rewritten as superseded
+	written, err = decision.BuildGraph(decisions).ExportADRs(dir)
+	if err != nil || len(written) != 3 {
+		t.Fatalf("second export = %v, %v", written, err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "0003-use-postgresql.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"decision_id: ADR-0003\n", "status: accepted\n", "supersedes: ADR-0001\n",
+		"tags: [database]\n", "# ADR-0003: Use PostgreSQL", "## Context\n\nWhich database?",
+		"## History\n\n- ADR-0001 (2026-01-10): Use MySQL\n",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("ADR missing %q:\n%s", want, content)
+		}
+	}
+
+	reports, err := drift.NewADRValidator(root).Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || len(reports[0].Issues) != 1 ||
+		reports[0].Issues[0].Message != "Decision ADR-0001 is superseded" {
+		t.Errorf("validator reports = %+v", reports)
+	}
+}
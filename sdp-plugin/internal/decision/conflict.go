@@ -0,0 +1,117 @@
+package decision
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// minAlternativeLen keeps short alternatives ("no", "A") from matching
+// inside unrelated decision text.
+const minAlternativeLen = 3
+
+// Conflict is a pair of active decisions that contradict each other
+type Conflict struct {
+	A, B       *Node
+	SharedTags []string
+	Reason     string
+}
+
+// RelatedFunc reports whether two decisions cover the same topic even
+// without shared tags, e.g. via semantic search. It may be nil.
+type RelatedFunc func(a, b *Node) bool
+
+// Conflicts returns active decision pairs that reverse the same decision,
+// and pairs that share a tag (or that related links) and make conflicting
+// choices: a different answer to the same question, or choosing an
+// alternative the other decision rejected.
+func (g *Graph) Conflicts(related RelatedFunc) []Conflict {
+	active := g.Active()
+	var out []Conflict
+	for i, a := range active {
+		for _, b := range active[i+1:] {
+			shared := sharedTags(a.Decision.Tags, b.Decision.Tags)
+			if a.Reverses != "" && a.Reverses == b.Reverses {
+				out = append(out, Conflict{A: a, B: b, SharedTags: shared, Reason: fmt.Sprintf("both reverse %s", a.Reverses)})
+				continue
+			}
+			if len(shared) == 0 && (related == nil || !related(a, b)) {
+				continue
+			}
+			if reason := conflictReason(a, b); reason != "" {
+				out = append(out, Conflict{A: a, B: b, SharedTags: shared, Reason: reason})
+			}
+		}
+	}
+	return out
+}
+
+// conflictReason explains why two related decisions contradict, or
+// returns "" when they are compatible.
+func conflictReason(a, b *Node) string {
+	qa, qb := normalize(a.Decision.Question), normalize(b.Decision.Question)
+	da, db := normalize(a.Decision.Decision), normalize(b.Decision.Decision)
+	if qa != "" && qa == qb && da != db {
+		return fmt.Sprintf("different answers to %q", a.Decision.Question)
+	}
+	if alt := rejected(b.Decision.Decision, a.Decision.Alternatives); alt != "" {
+		return fmt.Sprintf("%s chose %q, which %s rejected", b.ID, alt, a.ID)
+	}
+	if alt := rejected(a.Decision.Decision, b.Decision.Alternatives); alt != "" {
+		return fmt.Sprintf("%s chose %q, which %s rejected", a.ID, alt, b.ID)
+	}
+	return ""
+}
+
+// rejected returns the alternative that choice adopts, if any
+func rejected(choice string, alternatives []string) string {
+	choice = normalize(choice)
+	for _, alt := range alternatives {
+		n := normalize(alt)
+		if len(n) >= minAlternativeLen && containsWord(choice, n) {
+			return alt
+		}
+	}
+	return ""
+}
+
+// containsWord reports whether phrase occurs in text on word boundaries
+func containsWord(text, phrase string) bool {
+	for i := 0; ; {
+		j := strings.Index(text[i:], phrase)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(phrase)
+		if (start == 0 || text[start-1] == ' ') && (end == len(text) || text[end] == ' ') {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+// normalize lowercases text and collapses punctuation and whitespace
+func normalize(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	})
+	return strings.Join(fields, " ")
+}
+
+// sharedTags returns tags present in both lists, case-insensitively
+func sharedTags(a, b []string) []string {
+	set := make(map[string]bool, len(a))
+	for _, t := range a {
+		set[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+	var out []string
+	for _, t := range b {
+		key := strings.ToLower(strings.TrimSpace(t))
+		if key != "" && set[key] {
+			out = append(out, key)
+			delete(set, key)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
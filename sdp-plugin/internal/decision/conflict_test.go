@@ -0,0 +1,78 @@
+package decision_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+)
+
+func TestGraph_Conflicts(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   decision.Decision
+		reason string // empty means no conflict
+	}{
+		{
+			name:   "same question different answer",
+			a:      decision.Decision{Question: "Which queue?", Decision: "Use Kafka", Tags: []string{"Messaging"}},
+			b:      decision.Decision{Question: "which queue", Decision: "Use NATS", Tags: []string{"messaging"}},
+			reason: `different answers to "Which queue?"`,
+		},
+		{
+			name:   "chose rejected alternative",
+			a:      decision.Decision{Decision: "Use REST", Alternatives: []string{"gRPC"}, Tags: []string{"api"}},
+			b:      decision.Decision{Decision: "Use gRPC for internal calls", Tags: []string{"api"}},
+			reason: `ADR-0002 chose "gRPC", which ADR-0001 rejected`,
+		},
+		{
+			name: "no shared tags",
+			a:    decision.Decision{Question: "Which queue?", Decision: "Use Kafka", Tags: []string{"messaging"}},
+			b:    decision.Decision{Question: "Which queue?", Decision: "Use NATS", Tags: []string{"ops"}},
+		},
+		{
+			name: "compatible decisions",
+			a:    decision.Decision{Question: "Which queue?", Decision: "Use Kafka", Tags: []string{"messaging"}},
+			b:    decision.Decision{Question: "Retention?", Decision: "Keep 7 days", Tags: []string{"messaging"}},
+		},
+		{
+			name: "alternative only as part of a word",
+			a:    decision.Decision{Decision: "Use Go", Alternatives: []string{"Rust"}, Tags: []string{"lang"}},
+			b:    decision.Decision{Decision: "Trust the compiler", Tags: []string{"lang"}},
+		},
+		{
+			name: "superseded decision ignored",
+			a:    decision.Decision{Question: "Which queue?", Decision: "Use Kafka", Tags: []string{"messaging"}},
+			b:    decision.Decision{Question: "Which queue?", Decision: "Use NATS", Tags: []string{"messaging"}, Reverses: "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts := decision.BuildGraph([]decision.Decision{tt.a, tt.b}).Conflicts(nil)
+			if tt.reason == "" {
+				if len(conflicts) != 0 {
+					t.Errorf("unexpected conflict: %s", conflicts[0].Reason)
+				}
+				return
+			}
+			if len(conflicts) != 1 || conflicts[0].Reason != tt.reason {
+				t.Fatalf("conflicts = %+v, want reason %q", conflicts, tt.reason)
+			}
+		})
+	}
+}
+
+func TestGraph_ConflictsRelated(t *testing.T) {
+	g := decision.BuildGraph([]decision.Decision{
+		{Question: "Which queue?", Decision: "Use Kafka"},
+		{Question: "Which queue?", Decision: "Use NATS"},
+	})
+	if got := g.Conflicts(nil); len(got) != 0 {
+		t.Fatalf("untagged decisions should not conflict without related: %+v", got)
+	}
+	related := func(a, b *decision.Node) bool { return true }
+	got := g.Conflicts(related)
+	if len(got) != 1 || len(got[0].SharedTags) != 0 || !strings.Contains(got[0].Reason, "different answers") {
+		t.Errorf("conflicts = %+v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package decision
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Node status values in a decision graph
+const (
+	StatusActive     = "active"
+	StatusSuperseded = "superseded"
+)
+
+// Node is a decision in the graph. IDs follow the position in the log
+// (ADR-0001 is the first decision, as numbered by "sdp decisions list").
+type Node struct {
+	ID           string
+	Number       int
+	Decision     Decision
+	Status       string
+	Reverses     string // resolved ID of the reversed decision
+	SupersededBy string // ID of the first decision that reversed this one
+	// ReversedBy lists every decision reversing this one, oldest first.
+	// More than one is a contradiction reported by Conflicts.
+	ReversedBy []string
+}
+
+// Graph links decisions through their Reverses references
+type Graph struct {
+	Nodes []*Node
+	// Unresolved lists "ID: reference" for reversals matching no decision
+	Unresolved []string
+	byID       map[string]*Node
+}
+
+// NodeID formats the ID of the n-th (1-based) decision in the log
+func NodeID(n int) string {
+	return fmt.Sprintf("ADR-%04d", n)
+}
+
+// BuildGraph resolves reversal references and marks reversed decisions
+// as superseded. A reference may be an ID (ADR-0003), a list number (3),
+// an RFC 3339 timestamp or the exact decision text, and must point to an
+// earlier decision.
+func BuildGraph(decisions []Decision) *Graph {
+	g := &Graph{byID: make(map[string]*Node)}
+	for i, d := range decisions {
+		n := &Node{ID: NodeID(i + 1), Number: i + 1, Decision: d, Status: StatusActive}
+		g.Nodes = append(g.Nodes, n)
+		g.byID[n.ID] = n
+	}
+	for _, n := range g.Nodes {
+		ref := strings.TrimSpace(n.Decision.Reverses)
+		if ref == "" {
+			continue
+		}
+		target := g.resolve(ref, n.Number)
+		if target == nil {
+			g.Unresolved = append(g.Unresolved, fmt.Sprintf("%s: %s", n.ID, ref))
+			continue
+		}
+		n.Reverses = target.ID
+		target.Status = StatusSuperseded
+		target.ReversedBy = append(target.ReversedBy, n.ID)
+		if target.SupersededBy == "" {
+			target.SupersededBy = n.ID
+		}
+	}
+	return g
+}
+
+// resolve finds the decision a reference names among those before limit
+func (g *Graph) resolve(ref string, limit int) *Node {
+	num := strings.TrimPrefix(strings.ToUpper(ref), "ADR-")
+	if n, err := strconv.Atoi(num); err == nil {
+		if n >= 1 && n < limit {
+			return g.Nodes[n-1]
+		}
+		return nil
+	}
+	ts, tsErr := time.Parse(time.RFC3339, ref)
+	for _, n := range g.Nodes[:limit-1] {
+		if tsErr == nil && n.Decision.Timestamp.Equal(ts) {
+			return n
+		}
+		if strings.EqualFold(strings.TrimSpace(n.Decision.Decision), ref) {
+			return n
+		}
+	}
+	return nil
+}
+
+// Node returns the node with the given ID, or nil
+func (g *Graph) Node(id string) *Node {
+	return g.byID[id]
+}
+
+// Active returns decisions that no later decision reverses
+func (g *Graph) Active() []*Node {
+	var out []*Node
+	for _, n := range g.Nodes {
+		if n.Status == StatusActive {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// Chain returns the reversal chain containing id, oldest first. Where a
+// decision was reversed more than once the chain follows the first
+// reversal.
+func (g *Graph) Chain(id string) []*Node {
+	n := g.byID[id]
+	if n == nil {
+		return nil
+	}
+	for n.Reverses != "" {
+		n = g.byID[n.Reverses]
+	}
+	chain := []*Node{n}
+	for n.SupersededBy != "" {
+		n = g.byID[n.SupersededBy]
+		chain = append(chain, n)
+	}
+	return chain
+}
+
+// Chains returns every reversal chain with at least two decisions
+func (g *Graph) Chains() [][]*Node {
+	var chains [][]*Node
+	for _, n := range g.Nodes {
+		if n.Reverses == "" && n.SupersededBy != "" {
+			chains = append(chains, g.Chain(n.ID))
+		}
+	}
+	return chains
+}
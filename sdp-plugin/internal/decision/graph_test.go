@@ -0,0 +1,97 @@
+package decision_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+)
+
+var graphBase = time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+
+func graphDecisions() []decision.Decision {
+	return []decision.Decision{
+		{Timestamp: graphBase, Question: "Which database?", Decision: "Use MySQL", Tags: []string{"database"}},
+		{Timestamp: graphBase.Add(time.Hour), Question: "API style?", Decision: "Use REST", Alternatives: []string{"gRPC"}, Tags: []string{"api"}},
+		{Timestamp: graphBase.Add(2 * time.Hour), Question: "Which database?", Decision: "Use PostgreSQL", Tags: []string{"database"}, Reverses: "1"},
+		{Timestamp: graphBase.Add(3 * time.Hour), Question: "Which database?", Decision: "Use CockroachDB", Tags: []string{"database"}, Reverses: "ADR-0003"},
+		{Timestamp: graphBase.Add(4 * time.Hour), Decision: "Drop caching", Reverses: "missing"},
+	}
+}
+
+func TestBuildGraph_ReversalChain(t *testing.T) {
+	g := decision.BuildGraph(graphDecisions())
+
+	if got := len(g.Active()); got != 3 {
+		t.Errorf("active = %d, want 3", got)
+	}
+	first := g.Node("ADR-0001")
+	if first.Status != decision.StatusSuperseded || first.SupersededBy != "ADR-0003" {
+		t.Errorf("ADR-0001 = %+v", first)
+	}
+
+	chains := g.Chains()
+	if len(chains) != 1 {
+		t.Fatalf("chains = %d, want 1", len(chains))
+	}
+	var ids []string
+	for _, n := range chains[0] {
+		ids = append(ids, n.ID)
+	}
+	if want := "ADR-0001 ADR-0003 ADR-0004"; strings.Join(ids, " ") != want {
+		t.Errorf("chain = %s, want %s", strings.Join(ids, " "), want)
+	}
+	if got := len(g.Chain("ADR-0003")); got != 3 {
+		t.Errorf("Chain(ADR-0003) length = %d", got)
+	}
+	if len(g.Unresolved) != 1 || g.Unresolved[0] != "ADR-0005: missing" {
+		t.Errorf("unresolved = %v", g.Unresolved)
+	}
+}
+
+func TestBuildGraph_ReferenceForms(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{"number", "2", "ADR-0002"},
+		{"id", "adr-0001", "ADR-0001"},
+		{"timestamp", graphBase.Add(time.Hour).Format(time.RFC3339), "ADR-0002"},
+		{"decision text", "use mysql", "ADR-0001"},
+		{"self", "3", ""},
+		{"later decision", "4", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := graphDecisions()[:2]
+			ds = append(ds, decision.Decision{Decision: "Reverse", Reverses: tt.ref}, decision.Decision{Decision: "Later"})
+			n := decision.BuildGraph(ds).Node("ADR-0003")
+			if n.Reverses != tt.want {
+				t.Errorf("Reverses = %q, want %q", n.Reverses, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildGraph_ReversedTwice(t *testing.T) {
+	ds := append(graphDecisions()[:3], decision.Decision{
+		Timestamp: graphBase.Add(3 * time.Hour), Question: "Which database?", Decision: "Use SQLite", Reverses: "ADR-0001",
+	})
+	g := decision.BuildGraph(ds)
+
+	first := g.Node("ADR-0001")
+	if first.SupersededBy != "ADR-0003" || strings.Join(first.ReversedBy, " ") != "ADR-0003 ADR-0004" {
+		t.Errorf("ADR-0001 = %+v; both reversals must be kept", first)
+	}
+	var found bool
+	for _, c := range g.Conflicts(nil) {
+		if c.A.ID == "ADR-0003" && c.B.ID == "ADR-0004" && c.Reason == "both reverse ADR-0001" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("conflicts = %+v; want the two reversals of ADR-0001 reported", g.Conflicts(nil))
+	}
+}
@@ -60,7 +60,7 @@ func checkWorkstreamCircularDeps() DeepCheckResult {
 	}
 
 	// Check for cycles using DFS
-	cycles := detectCycles(deps)
+	cycles := DetectCycles(deps)
 	details["workstreams"] = len(deps)
 	details["cycles"] = cycles
 
@@ -83,8 +83,9 @@ func checkWorkstreamCircularDeps() DeepCheckResult {
 	}
 }
 
-// detectCycles finds circular dependencies using DFS
-func detectCycles(deps map[string][]string) []string {
+// DetectCycles finds circular dependencies using DFS. Each cycle is
+// reported as "a -> b -> a".
+func DetectCycles(deps map[string][]string) []string {
 	visited := make(map[string]bool)
 	recStack := make(map[string]bool)
 	var cycles []string
@@ -93,6 +94,7 @@ func detectCycles(deps map[string][]string) []string {
 	dfs = func(node string, path []string) bool {
 		visited[node] = true
 		recStack[node] = true
+		defer func() { recStack[node] = false }()
 
 		for _, neighbor := range deps[node] {
 			if !visited[neighbor] {
@@ -104,8 +106,6 @@ func detectCycles(deps map[string][]string) []string {
 				return true
 			}
 		}
-
-		recStack[node] = false
 		return false
 	}
 
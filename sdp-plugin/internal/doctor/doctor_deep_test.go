@@ -224,11 +224,16 @@ func TestDetectCycles(t *testing.T) {
 			deps:     map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}},
 			expected: 1,
 		},
+		{
+			name:     "several entries into one cycle",
+			deps:     map[string][]string{"x": {"a"}, "y": {"b"}, "a": {"b"}, "b": {"a"}},
+			expected: 1,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cycles := detectCycles(tt.deps)
+			cycles := DetectCycles(tt.deps)
 			if len(cycles) != tt.expected {
 				t.Errorf("Expected %d cycles, got %d: %v", tt.expected, len(cycles), cycles)
 			}
@@ -0,0 +1,173 @@
+package drift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/parser"
+	"github.com/fall-out-bug/sdp/internal/security"
+)
+
+// DriftTypeSpecCode is drift between symbols declared in the spec and the code.
+const DriftTypeSpecCode DriftType = "spec_code"
+
+// DetectSpecDrift checks the symbols a workstream declares in its Goal and
+// acceptance criteria (as inline code, e.g. `func Hello() string`) against the
+// declarations in its scope files. Missing and signature-changed symbols are
+// errors; a missing symbol with a likely rename in the scope is a warning.
+// Missing scope files are left to DetectDrift.
+func (d *Detector) DetectSpecDrift(wsPath string) (*EnhancedDriftReport, error) {
+	ws, err := parser.ParseWorkstream(wsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workstream: %w", err)
+	}
+
+	var files []string
+	for _, f := range ws.Scope.Implementation {
+		full, err := security.SafeJoinPath(d.projectRoot, f)
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(full); err == nil {
+			files = append(files, f)
+		}
+	}
+
+	expected := ExtractExpectedSymbols(ws.Goal, ws.Acceptance)
+	specFile := wsPath
+	if rel, err := filepath.Rel(d.projectRoot, wsPath); err == nil && !strings.HasPrefix(rel, "..") {
+		specFile = filepath.ToSlash(rel)
+	}
+	issues := checkSymbols(expected, buildSymbolIndex(d.projectRoot, files), specFile)
+
+	dt := DriftTypeReport{Type: DriftTypeSpecCode, Severity: SeverityInfo, Issues: issues}
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			dt.Severity = SeverityError
+			break
+		}
+		dt.Severity = SeverityWarning
+	}
+	if len(expected) == 0 {
+		dt.Suggestions = append(dt.Suggestions,
+			"Declare expected symbols as inline code in the Goal or acceptance criteria, e.g. `func Hello() string`")
+	}
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			dt.Suggestions = append(dt.Suggestions, "Update the code or the workstream spec so declared symbols match")
+			break
+		}
+	}
+
+	report := &EnhancedDriftReport{
+		WorkstreamID: ws.ID,
+		Timestamp:    time.Now(),
+		DriftTypes:   []DriftTypeReport{dt},
+	}
+	report.Verdict = report.GenerateVerdict()
+	return report, nil
+}
+
+// checkSymbols compares each expected symbol with the index. Symbols that are
+// missing outright are reported against specFile.
+func checkSymbols(expected []ExpectedSymbol, idx *symbolIndex, specFile string) []EnhancedDriftIssue {
+	declared := make(map[string]bool, len(expected))
+	for _, sym := range expected {
+		declared[sym.Kind+" "+sym.Key()] = true
+	}
+
+	var issues []EnhancedDriftIssue
+	for _, sym := range expected {
+		found, ok := idx.find(sym)
+		if !ok {
+			if cand, ok := idx.renameCandidate(sym, declared); ok {
+				issues = append(issues, EnhancedDriftIssue{
+					File:     cand.File,
+					Line:     cand.Line,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("%s: `%s` not found; %s looks renamed from it", sym.Source, sym.Decl, cand.Name),
+				})
+				continue
+			}
+			issues = append(issues, EnhancedDriftIssue{
+				File:     specFile,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("%s: `%s` not found in scope files", sym.Source, sym.Decl),
+			})
+			continue
+		}
+		if msg := mismatch(sym, found); msg != "" {
+			issues = append(issues, EnhancedDriftIssue{
+				File:     found.File,
+				Line:     found.Line,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("%s: %s %s", sym.Source, sym.Key(), msg),
+			})
+		}
+	}
+	return issues
+}
+
+// mismatch describes how a found symbol differs from the spec, or "".
+func mismatch(sym ExpectedSymbol, found FoundSymbol) string {
+	if sym.TypeKind != "" && sym.TypeKind != found.TypeKind {
+		kind := found.TypeKind
+		if kind == "" {
+			kind = "non-struct type"
+		}
+		return fmt.Sprintf("is a %s, spec declares %s", kind, sym.TypeKind)
+	}
+	if sym.Signature != "" && found.Signature != "" && sym.Signature != found.Signature {
+		return fmt.Sprintf("signature changed: code has %s, spec declares %s", found.Signature, sym.Signature)
+	}
+	return ""
+}
+
+func (idx *symbolIndex) find(sym ExpectedSymbol) (FoundSymbol, bool) {
+	if sym.IsGo() {
+		for _, p := range idx.goPkgs {
+			if found, ok := p.lookup(sym); ok {
+				return found, true
+			}
+		}
+		return FoundSymbol{}, false
+	}
+	for _, s := range idx.symbols {
+		if s.Name == sym.Name && s.Kind == sym.Kind {
+			return s, true
+		}
+	}
+	return FoundSymbol{}, false
+}
+
+// renameCandidate finds a declaration of the same kind that is probably the
+// expected symbol under another name: the same name in another case, or the
+// only undeclared symbol with an identical non-trivial signature.
+func (idx *symbolIndex) renameCandidate(sym ExpectedSymbol, declared map[string]bool) (FoundSymbol, bool) {
+	var sameSig []FoundSymbol
+	for _, s := range idx.symbols {
+		if s.Kind != sym.Kind || s.Receiver != sym.Receiver || declared[s.Kind+" "+symbolKey(s)] {
+			continue
+		}
+		if strings.EqualFold(s.Name, sym.Name) {
+			return s, true
+		}
+		if sym.Signature != "" && sym.Signature != "()" && s.Signature == sym.Signature {
+			sameSig = append(sameSig, s)
+		}
+	}
+	if len(sameSig) == 1 {
+		return sameSig[0], true
+	}
+	return FoundSymbol{}, false
+}
+
+func symbolKey(s FoundSymbol) string {
+	if s.Receiver != "" {
+		return s.Receiver + "." + s.Name
+	}
+	return s.Name
+}
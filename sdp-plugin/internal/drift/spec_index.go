@@ -0,0 +1,221 @@
+package drift
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FoundSymbol is a declaration located in the workstream's code.
+type FoundSymbol struct {
+	Kind      string
+	Name      string
+	Receiver  string
+	TypeKind  string
+	Signature string
+	File      string // relative to the project root
+	Line      int
+}
+
+// Ref returns "file:line".
+func (s FoundSymbol) Ref() string {
+	return s.File + ":" + strconv.Itoa(s.Line)
+}
+
+// symbolIndex holds every declaration in the scope: Go packages are parsed
+// and type-checked, Python and TypeScript files are scanned line by line.
+type symbolIndex struct {
+	symbols []FoundSymbol
+	goPkgs  []*goPackage
+}
+
+type goPackage struct {
+	fset  *token.FileSet
+	pkg   *types.Package
+	decls map[token.Pos]FoundSymbol // keyed by the position of the declared name
+}
+
+// stubImporter resolves every import to an empty package. Drift detection only
+// needs the package's own declarations and method sets, so types from other
+// packages are left unresolved instead of loading export data.
+type stubImporter struct{}
+
+func (stubImporter) Import(path string) (*types.Package, error) {
+	pkg := types.NewPackage(path, filepath.Base(path))
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+// buildSymbolIndex indexes the scope files. Go files pull in their whole
+// package directory (non-test files) so methods declared elsewhere in the
+// package are found.
+func buildSymbolIndex(projectRoot string, files []string) *symbolIndex {
+	idx := &symbolIndex{}
+	var goDirs []string
+	for _, f := range files {
+		switch filepath.Ext(f) {
+		case ".go":
+			if dir := filepath.Dir(f); !slices.Contains(goDirs, dir) {
+				goDirs = append(goDirs, dir)
+			}
+		case ".py", ".ts", ".tsx", ".js", ".jsx", ".mjs":
+			idx.symbols = append(idx.symbols, scanScriptFile(projectRoot, f)...)
+		}
+	}
+	for _, dir := range goDirs {
+		if pkg := indexGoDir(projectRoot, dir); pkg != nil {
+			idx.goPkgs = append(idx.goPkgs, pkg)
+			for _, s := range pkg.decls {
+				idx.symbols = append(idx.symbols, s)
+			}
+		}
+	}
+	slices.SortFunc(idx.symbols, func(a, b FoundSymbol) int {
+		return strings.Compare(a.Ref(), b.Ref())
+	})
+	return idx
+}
+
+func indexGoDir(projectRoot, dir string) *goPackage {
+	entries, err := os.ReadDir(filepath.Join(projectRoot, dir))
+	if err != nil {
+		return nil
+	}
+	p := &goPackage{fset: token.NewFileSet(), decls: make(map[token.Pos]FoundSymbol)}
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(p.fset, filepath.Join(projectRoot, dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		if len(files) > 0 && f.Name.Name != files[0].Name.Name {
+			continue // stray file of another package (e.g. a generator with //go:build ignore)
+		}
+		files = append(files, f)
+		p.collect(f, filepath.ToSlash(filepath.Join(dir, name)))
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	conf := types.Config{Importer: stubImporter{}, Error: func(error) {}}
+	p.pkg, _ = conf.Check(files[0].Name.Name, p.fset, files, nil) // errors expected from stubbed imports
+	return p
+}
+
+func (p *goPackage) collect(f *ast.File, rel string) {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			s := FoundSymbol{Kind: KindFunc, Name: d.Name.Name, Signature: goSignature(d.Type), File: rel, Line: p.fset.Position(d.Name.Pos()).Line}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				s.Kind, s.Receiver = KindMethod, receiverBase(d.Recv.List[0].Type)
+			}
+			p.decls[d.Name.Pos()] = s
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts := spec.(*ast.TypeSpec)
+				s := FoundSymbol{Kind: KindType, Name: ts.Name.Name, File: rel, Line: p.fset.Position(ts.Name.Pos()).Line}
+				switch ts.Type.(type) {
+				case *ast.StructType:
+					s.TypeKind = "struct"
+				case *ast.InterfaceType:
+					s.TypeKind = "interface"
+				}
+				p.decls[ts.Name.Pos()] = s
+			}
+		}
+	}
+}
+
+// lookup resolves an expected Go symbol through the type checker, so methods
+// promoted from embedded types in the same package count as present.
+func (p *goPackage) lookup(sym ExpectedSymbol) (FoundSymbol, bool) {
+	if p.pkg == nil {
+		return FoundSymbol{}, false
+	}
+	var obj types.Object
+	if sym.Kind == KindMethod {
+		tn, ok := p.pkg.Scope().Lookup(sym.Receiver).(*types.TypeName)
+		if !ok {
+			return FoundSymbol{}, false
+		}
+		obj, _, _ = types.LookupFieldOrMethod(types.NewPointer(tn.Type()), false, p.pkg, sym.Name)
+		if _, ok := obj.(*types.Func); !ok {
+			return FoundSymbol{}, false
+		}
+	} else {
+		obj = p.pkg.Scope().Lookup(sym.Name)
+		switch obj.(type) {
+		case *types.Func:
+			if sym.Kind != KindFunc {
+				return FoundSymbol{}, false
+			}
+		case *types.TypeName:
+			if sym.Kind != KindType {
+				return FoundSymbol{}, false
+			}
+		default:
+			return FoundSymbol{}, false
+		}
+	}
+	found, ok := p.decls[obj.Pos()]
+	return found, ok
+}
+
+var (
+	reScriptDef      = regexp.MustCompile(`^\s*(?:async\s+)?def\s+([A-Za-z_]\w*)\s*(\([^)]*\))?`)
+	reScriptClass    = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`)
+	reScriptFunction = regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*(\([^)]*\))?`)
+	reScriptArrow    = regexp.MustCompile(`^\s*(?:export\s+)?const\s+([A-Za-z_$][\w$]*)\s*(?::[^=]+)?=\s*(?:async\s+)?(\([^)]*\))\s*(?::[^=]+)?=>`)
+	reScriptIface    = regexp.MustCompile(`^\s*(?:export\s+)?interface\s+([A-Za-z_$][\w$]*)`)
+)
+
+// scanScriptFile finds Python and TypeScript declarations with line-anchored
+// patterns. Multi-line parameter lists lose their signature but keep the name.
+func scanScriptFile(projectRoot, rel string) []FoundSymbol {
+	f, err := os.Open(filepath.Join(projectRoot, rel))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var out []FoundSymbol
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		s := FoundSymbol{File: filepath.ToSlash(rel), Line: line}
+		if m := reScriptDef.FindStringSubmatch(text); m != nil && filepath.Ext(rel) == ".py" {
+			s.Kind, s.Name, s.Signature = KindDef, m[1], paramNames(m[2])
+		} else if m := reScriptClass.FindStringSubmatch(text); m != nil {
+			s.Kind, s.Name = KindClass, m[1]
+		} else if m := reScriptFunction.FindStringSubmatch(text); m != nil {
+			s.Kind, s.Name, s.Signature = KindFunction, m[1], paramNames(m[2])
+		} else if m := reScriptArrow.FindStringSubmatch(text); m != nil {
+			s.Kind, s.Name, s.Signature = KindFunction, m[1], paramNames(m[2])
+		} else if m := reScriptIface.FindStringSubmatch(text); m != nil {
+			s.Kind, s.Name = KindInterface, m[1]
+		} else {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
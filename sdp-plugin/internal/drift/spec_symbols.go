@@ -0,0 +1,230 @@
+package drift
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// Symbol kinds declared in workstream specs.
+const (
+	KindFunc      = "func"      // Go function
+	KindMethod    = "method"    // Go method
+	KindType      = "type"      // Go type
+	KindDef       = "def"       // Python function
+	KindClass     = "class"     // Python or TypeScript class
+	KindFunction  = "function"  // TypeScript function
+	KindInterface = "interface" // TypeScript interface
+)
+
+// ExpectedSymbol is a symbol a workstream declares in its Goal or acceptance
+// criteria, written as inline code (e.g. `func Hello() string`).
+type ExpectedSymbol struct {
+	Kind      string
+	Name      string
+	Receiver  string // Go methods: receiver base type
+	TypeKind  string // Go types: struct, interface or "" when not declared
+	Signature string // normalized; "" when the spec gives only the name
+	Source    string // Goal, AC1, AC2, ...
+	Decl      string // declaration as written in the spec
+}
+
+// Key identifies the symbol independent of its signature.
+func (s ExpectedSymbol) Key() string {
+	if s.Receiver != "" {
+		return s.Receiver + "." + s.Name
+	}
+	return s.Name
+}
+
+// IsGo reports whether the symbol uses Go declaration syntax.
+func (s ExpectedSymbol) IsGo() bool {
+	return s.Kind == KindFunc || s.Kind == KindMethod || s.Kind == KindType
+}
+
+var (
+	reInlineCode = regexp.MustCompile("`([^`\n]+)`")
+	reGoType     = regexp.MustCompile(`^type\s+([A-Za-z_]\w*)(?:\[[^\]]*\])?(?:\s+(struct|interface)\b)?`)
+	rePyDef      = regexp.MustCompile(`^(?:async\s+)?def\s+([A-Za-z_]\w*)\s*(\([^)]*\))?`)
+	reClass      = regexp.MustCompile(`^(?:export\s+)?(?:abstract\s+)?class\s+([A-Za-z_$][\w$]*)`)
+	reTSFunction = regexp.MustCompile(`^(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*([A-Za-z_$][\w$]*)\s*(?:<[^>]*>)?\s*(\([^)]*\))?`)
+	reTSIface    = regexp.MustCompile(`^(?:export\s+)?interface\s+([A-Za-z_$][\w$]*)`)
+)
+
+// ExtractExpectedSymbols finds declarations written as inline code in the
+// goal and acceptance criteria. Prose and non-declaration code spans are ignored.
+func ExtractExpectedSymbols(goal string, acceptance []string) []ExpectedSymbol {
+	var out []ExpectedSymbol
+	seen := make(map[string]bool)
+	add := func(text, source string) {
+		for _, m := range reInlineCode.FindAllStringSubmatch(text, -1) {
+			sym, ok := parseDeclaration(strings.TrimSpace(m[1]))
+			if !ok {
+				continue
+			}
+			id := sym.Kind + " " + sym.Key()
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			sym.Source = source
+			out = append(out, sym)
+		}
+	}
+	add(goal, "Goal")
+	for i, ac := range acceptance {
+		add(ac, fmt.Sprintf("AC%d", i+1))
+	}
+	return out
+}
+
+// parseDeclaration recognizes a single declaration in Go, Python or TypeScript syntax.
+func parseDeclaration(decl string) (ExpectedSymbol, bool) {
+	sym := ExpectedSymbol{Decl: decl}
+	switch {
+	case strings.HasPrefix(decl, "func ") || strings.HasPrefix(decl, "func("):
+		fn, ok := parseGoFuncDecl(decl)
+		if !ok {
+			return sym, false
+		}
+		sym.Name = fn.Name.Name
+		sym.Kind = KindFunc
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			sym.Kind = KindMethod
+			sym.Receiver = receiverBase(fn.Recv.List[0].Type)
+		}
+		sym.Signature = goSignature(fn.Type)
+	case reGoType.MatchString(decl):
+		m := reGoType.FindStringSubmatch(decl)
+		sym.Kind, sym.Name, sym.TypeKind = KindType, m[1], m[2]
+	case rePyDef.MatchString(decl):
+		m := rePyDef.FindStringSubmatch(decl)
+		sym.Kind, sym.Name, sym.Signature = KindDef, m[1], paramNames(m[2])
+	case reTSFunction.MatchString(decl):
+		m := reTSFunction.FindStringSubmatch(decl)
+		sym.Kind, sym.Name, sym.Signature = KindFunction, m[1], paramNames(m[2])
+	case reTSIface.MatchString(decl):
+		sym.Kind, sym.Name = KindInterface, reTSIface.FindStringSubmatch(decl)[1]
+	case reClass.MatchString(decl):
+		sym.Kind, sym.Name = KindClass, reClass.FindStringSubmatch(decl)[1]
+	default:
+		return sym, false
+	}
+	return sym, true
+}
+
+// parseGoFuncDecl parses a function or method header, with or without a body.
+func parseGoFuncDecl(decl string) (*ast.FuncDecl, bool) {
+	src := "package p\n" + strings.TrimSuffix(strings.TrimSpace(decl), "{")
+	if !strings.HasSuffix(src, "}") {
+		src += " {}"
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil || len(f.Decls) != 1 {
+		return nil, false
+	}
+	fn, ok := f.Decls[0].(*ast.FuncDecl)
+	return fn, ok
+}
+
+// goSignature renders a function type without parameter names, e.g.
+// "(string, ...int) (bool, error)", so renamed parameters do not count as drift.
+func goSignature(ft *ast.FuncType) string {
+	var b strings.Builder
+	if ft.TypeParams != nil {
+		b.WriteString("[" + strings.Join(fieldTypes(ft.TypeParams), ", ") + "]")
+	}
+	b.WriteString("(" + strings.Join(fieldTypes(ft.Params), ", ") + ")")
+	results := fieldTypes(ft.Results)
+	switch {
+	case len(results) == 1 && len(ft.Results.List[0].Names) == 0:
+		b.WriteString(" " + results[0])
+	case len(results) > 0:
+		b.WriteString(" (" + strings.Join(results, ", ") + ")")
+	}
+	return b.String()
+}
+
+func fieldTypes(fl *ast.FieldList) []string {
+	if fl == nil {
+		return nil
+	}
+	var out []string
+	for _, f := range fl.List {
+		t := typeString(f.Type)
+		n := max(len(f.Names), 1)
+		for range n {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// typeString prints a type expression, spelling interface{} as any.
+func typeString(e ast.Expr) string {
+	return strings.ReplaceAll(types.ExprString(e), "interface{}", "any")
+}
+
+func receiverBase(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StarExpr:
+		return receiverBase(t.X)
+	case *ast.IndexExpr:
+		return receiverBase(t.X)
+	case *ast.IndexListExpr:
+		return receiverBase(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// paramNames reduces a Python or TypeScript parameter list to its names,
+// dropping annotations, defaults and self/this: "(self, a: int = 1, *rest)" -> "(a, *rest)".
+func paramNames(params string) string {
+	if params == "" {
+		return ""
+	}
+	inner := strings.TrimSuffix(strings.TrimPrefix(params, "("), ")")
+	var names []string
+	for _, p := range splitTopLevel(inner) {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		fields := strings.FieldsFunc(p, func(r rune) bool { return r == ':' || r == '=' })
+		if len(fields) == 0 {
+			continue // only separators, e.g. "(:)"
+		}
+		name := strings.TrimSuffix(strings.TrimSpace(fields[0]), "?")
+		if name == "self" || name == "cls" || name == "this" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return "(" + strings.Join(names, ", ") + ")"
+}
+
+// splitTopLevel splits on commas outside brackets.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(', '[', '{', '<':
+			depth++
+		case ')', ']', '}', '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
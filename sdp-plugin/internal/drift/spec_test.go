@@ -0,0 +1,139 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func writeSpecFixture(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractExpectedSymbols(t *testing.T) {
+	syms := ExtractExpectedSymbols(
+		"Add `func Hello(name string) string` and `type Greeter struct`. Run `go test`.",
+		[]string{
+			"`func (g *Greeter) Greet(ctx context.Context, names ...string) (int, error)` returns count",
+			"Python `def render(self, tpl: str, strict=False) -> str` exists",
+			"TS `export function parse(src: string, opts?: Options): Ast`",
+			"`class Parser` and `interface Options`",
+		})
+	want := map[string]string{
+		"Hello":         "(string) string",
+		"Greeter":       "",
+		"Greeter.Greet": "(context.Context, ...string) (int, error)",
+		"render":        "(tpl, strict)",
+		"parse":         "(src, opts)",
+		"Parser":        "",
+		"Options":       "",
+	}
+	if len(syms) != len(want) {
+		t.Fatalf("expected %d symbols, got %+v", len(want), syms)
+	}
+	for _, s := range syms {
+		sig, ok := want[s.Key()]
+		if !ok || sig != s.Signature {
+			t.Errorf("unexpected symbol %s (%s) signature %q", s.Key(), s.Kind, s.Signature)
+		}
+	}
+	if syms[2].Source != "AC1" || syms[2].Kind != KindMethod {
+		t.Errorf("expected method from AC1, got %+v", syms[2])
+	}
+}
+
+func TestExtractExpectedSymbols_MalformedParams(t *testing.T) {
+	syms := ExtractExpectedSymbols("Broken `def f(:)` and `function g(=, a)`", nil)
+	want := map[string]string{"f": "()", "g": "(a)"}
+	if len(syms) != len(want) {
+		t.Fatalf("expected %d symbols, got %+v", len(want), syms)
+	}
+	for _, s := range syms {
+		if sig, ok := want[s.Key()]; !ok || sig != s.Signature {
+			t.Errorf("unexpected symbol %s signature %q", s.Key(), s.Signature)
+		}
+	}
+}
+
+func TestDetectSpecDrift(t *testing.T) {
+	root := t.TempDir()
+	writeSpecFixture(t, root, "pkg/greet/greet.go", `package greet
+
+import "context"
+
+type base struct{}
+
+func (base) Close() error { return nil }
+
+// Greeter embeds base, so Close is promoted.
+type Greeter struct{ base }
+
+func Hello() int { return 0 }
+
+func (g *Greeter) Welcome(ctx context.Context, names ...string) (int, error) { return 0, nil }
+`)
+	writeSpecFixture(t, root, "tools/render.py", "class Renderer:\n    def render(self, tpl, strict=False):\n        pass\n")
+	writeSpecFixture(t, root, "docs/ws/00-001-01.md", "---\nws_id: 00-001-01\nfeature: F001\n---\n"+
+		"## Goal\n\nProvide `func Hello() string` and `type Greeter struct`.\n\n"+
+		"## Acceptance Criteria\n\n"+
+		"- [ ] `func (g *Greeter) Greet(ctx context.Context, names ...string) (int, error)`\n"+
+		"- [ ] `func (g *Greeter) Close() error`\n"+
+		"- [ ] `def render(self, tpl, strict=False)` and `class Renderer`\n"+
+		"- [ ] `func Goodbye()`\n\n"+
+		"## Scope Files\n\n**Implementation:**\n- pkg/greet/greet.go\n- tools/render.py\n")
+
+	report, err := NewDetector(root).DetectSpecDrift(filepath.Join(root, "docs/ws/00-001-01.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Verdict != VerdictFail || len(report.DriftTypes) != 1 {
+		t.Fatalf("expected FAIL with one drift type, got %s", report.String())
+	}
+	issues := report.DriftTypes[0].Issues
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %s", report.String())
+	}
+
+	checks := []struct {
+		severity Severity
+		ref      string
+		contains string
+	}{
+		{SeverityError, "pkg/greet/greet.go:12", "signature changed: code has () int, spec declares () string"},
+		{SeverityWarning, "pkg/greet/greet.go:14", "Welcome looks renamed"},
+		{SeverityError, "docs/ws/00-001-01.md:0", "`func Goodbye()` not found"},
+	}
+	for i, c := range checks {
+		got := issues[i]
+		ref := got.File + ":" + strconv.Itoa(got.Line)
+		if got.Severity != c.severity || ref != c.ref || !strings.Contains(got.Message, c.contains) {
+			t.Errorf("issue %d = %+v, want %s at %s containing %q", i, got, c.severity, c.ref, c.contains)
+		}
+	}
+	if !strings.Contains(report.String(), "pkg/greet/greet.go:12") {
+		t.Errorf("expected line reference in report:\n%s", report.String())
+	}
+}
+
+func TestDetectSpecDriftWithoutDeclarations(t *testing.T) {
+	root := t.TempDir()
+	writeSpecFixture(t, root, "a.go", "package a\n")
+	writeSpecFixture(t, root, "ws.md", "---\nws_id: 00-001-02\nfeature: F001\n---\n## Goal\n\nDo things.\n\n## Scope Files\n\n- a.go\n")
+
+	report, err := NewDetector(root).DetectSpecDrift(filepath.Join(root, "ws.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Verdict != VerdictPass || len(report.DriftTypes[0].Suggestions) == 0 {
+		t.Errorf("expected PASS with a suggestion, got %+v", report)
+	}
+}
@@ -1,6 +1,7 @@
 package drift
 
 import (
+	"strconv"
 	"strings"
 	"time"
 )
@@ -83,7 +84,7 @@ func (r *EnhancedDriftReport) String() string {
 			builder.WriteString("- ")
 			builder.WriteString(issue.File)
 			if issue.Line > 0 {
-				builder.WriteString(":" + string(rune(issue.Line)))
+				builder.WriteString(":" + strconv.Itoa(issue.Line))
 			}
 			builder.WriteString(": ")
 			builder.WriteString(issue.Message)
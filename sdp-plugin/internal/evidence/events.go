@@ -1,5 +1,7 @@
 package evidence
 
+import "github.com/fall-out-bug/sdp/internal/verify"
+
 // GenerationEvent builds a generation event (AC2).
 func GenerationEvent(wsID string, filesChanged []string) *Event {
 	return &Event{
@@ -36,6 +38,22 @@ func VerificationEventWithFindings(wsID string, passed bool, gateName string, co
 	}
 }
 
+// AcceptanceEvent builds a verification event for one structured
+// acceptance criterion, gated as "acceptance:<id>".
+func AcceptanceEvent(wsID string, ac verify.AcceptanceResult) *Event {
+	ev := VerificationEventWithFindings(wsID, ac.Passed, "acceptance:"+ac.ID, 0, ac.Message)
+	data := ev.Data.(map[string]any)
+	data["acceptance_id"] = ac.ID
+	data["check"] = ac.Kind
+	if ac.Criterion != "" {
+		data["criterion"] = ac.Criterion
+	}
+	if ac.Evidence != "" {
+		data["evidence"] = ac.Evidence
+	}
+	return ev
+}
+
 // ApprovalEvent builds an approval event (F056: deploy).
 func ApprovalEvent(wsID, targetBranch, commitSHA, approvedBy string) *Event {
 	data := map[string]any{
@@ -1,6 +1,10 @@
 package evidence
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/verify"
+)
 
 func TestVerificationEvent(t *testing.T) {
 	ev := VerificationEvent("00-054-01", true, "coverage", 85.0)
@@ -23,6 +27,20 @@ func TestVerificationEventWithFindings(t *testing.T) {
 	}
 }
 
+func TestAcceptanceEvent(t *testing.T) {
+	ev := AcceptanceEvent("00-048-01", verify.AcceptanceResult{ID: "AC2", Criterion: "health endpoint", Kind: "http", Message: "status 500, want 200"})
+	if ev.Type != "verification" || ev.WSID != "00-048-01" {
+		t.Errorf("AcceptanceEvent: got %+v", ev)
+	}
+	m, _ := ev.Data.(map[string]any)
+	if m["gate_name"] != "acceptance:AC2" || m["acceptance_id"] != "AC2" || m["passed"] != false {
+		t.Errorf("data: got %v", m)
+	}
+	if m["criterion"] != "health endpoint" || m["check"] != "http" || m["findings"] != "status 500, want 200" {
+		t.Errorf("data: got %v", m)
+	}
+}
+
 func TestApprovalEvent(t *testing.T) {
 	ev := ApprovalEvent("00-000-00", "main", "abc123def", "CI")
 	if ev.Type != "approval" || ev.WSID != "00-000-00" {
@@ -46,6 +46,14 @@ func ExtractLesson(wsID string, result *verify.VerificationResult) Lesson {
 			l.WhatFailed = append(l.WhatFailed, "missing: "+f)
 		}
 	}
+	for _, ac := range result.Acceptance {
+		line := "acceptance " + ac.ID + ": " + ac.Message
+		if ac.Passed {
+			l.WhatWorked = append(l.WhatWorked, line)
+		} else {
+			l.WhatFailed = append(l.WhatFailed, line)
+		}
+	}
 	if len(l.WhatFailed) > 0 && len(l.WhatWorked) > 0 {
 		l.Outcome = "mixed"
 	}
@@ -58,6 +58,23 @@ func TestExtractLesson_Failed(t *testing.T) {
 	}
 }
 
+func TestExtractLesson_Acceptance(t *testing.T) {
+	result := &verify.VerificationResult{
+		Passed: false,
+		Acceptance: []verify.AcceptanceResult{
+			{ID: "AC1", Passed: true, Message: "OK"},
+			{ID: "AC2", Passed: false, Message: "exit code 1, want 0"},
+		},
+	}
+	l := ExtractLesson("00-048-01", result)
+	if l.Outcome != "mixed" {
+		t.Errorf("Outcome: want mixed, got %s", l.Outcome)
+	}
+	if len(l.WhatFailed) != 1 || l.WhatFailed[0] != "acceptance AC2: exit code 1, want 0" {
+		t.Errorf("WhatFailed: got %v", l.WhatFailed)
+	}
+}
+
 func TestLesson_MatchesOutcome(t *testing.T) {
 	l := Lesson{Outcome: "failed"}
 	if !l.MatchesOutcome("failed") {
@@ -1,16 +0,0 @@
-//go:build !windows
-
-package evidence
-
-import (
-	"os"
-	"syscall"
-)
-
-func lockFile(f *os.File) error {
-	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
-}
-
-func unlockFile(f *os.File) error {
-	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
-}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
 )
 
 const genesisHash = "genesis"
@@ -53,10 +55,10 @@ func (w *Writer) Append(ev *Event) error {
 	}
 	defer lf.Close()
 
-	if err := lockFile(lf); err != nil {
+	if err := filelock.Lock(lf); err != nil {
 		return fmt.Errorf("acquire file lock: %w", err)
 	}
-	defer func() { _ = unlockFile(lf) }() //nolint:errcheck // best-effort unlock on defer
+	defer func() { _ = filelock.Unlock(lf) }() //nolint:errcheck // best-effort unlock on defer
 
 	// Re-read last hash under flock — another process may have appended.
 	// This ensures prev_hash is always derived from on-disk state (atomic with append).
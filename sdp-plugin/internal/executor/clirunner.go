@@ -3,15 +3,25 @@ package executor
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"sync"
+
+	"github.com/fall-out-bug/sdp/internal/flaky"
 )
 
+// maxCapturedOutput bounds the output kept for flaky test triage.
+const maxCapturedOutput = 1 << 20
+
 // CLIRunner runs a command for each workstream. Implements WorkstreamRunner.
 // Use with NewExecutor to delegate workstream execution to a CLI (e.g. sdp build).
 type CLIRunner struct {
 	Command string   // Executable (e.g. "sdp")
 	Args    []string // Base args (e.g. ["build"]). wsID is appended.
+	// Flakes, when set, triages failed runs: a run that failed only on
+	// flaky or quarantined tests returns *flaky.Error.
+	Flakes *flaky.Checker
 }
 
 // NewCLIRunner creates a CLIRunner. Args are base args; wsID is appended per Run.
@@ -26,8 +36,36 @@ func (r *CLIRunner) Run(ctx context.Context, wsID string) error {
 	cmd := exec.CommandContext(ctx, r.Command, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	var captured tailBuffer
+	if r.Flakes != nil {
+		cmd.Stdout = io.MultiWriter(os.Stdout, &captured)
+		cmd.Stderr = io.MultiWriter(os.Stderr, &captured)
+	}
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s %s: %w", r.Command, wsID, err)
+		err = fmt.Errorf("%s %s: %w", r.Command, wsID, err)
+		if r.Flakes != nil && ctx.Err() == nil {
+			if outcome, ferr := r.Flakes.Triage(string(captured.buf)); ferr == nil && outcome.NonBlocking() {
+				return &flaky.Error{Outcome: outcome, Err: err}
+			}
+		}
+		return err
 	}
 	return nil
 }
+
+// tailBuffer keeps the last maxCapturedOutput bytes written to it. Safe for
+// the concurrent stdout and stderr copies of exec.Cmd.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > maxCapturedOutput {
+		t.buf = t.buf[len(t.buf)-maxCapturedOutput:]
+	}
+	return len(p), nil
+}
@@ -2,20 +2,27 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"time"
 
 	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/flaky"
 )
 
+// maxFlakeReruns bounds the extra runs granted to failures caused only by
+// flaky or quarantined tests, which do not consume a retry.
+const maxFlakeReruns = 2
+
 // executeWorkstreamWithRetry executes a workstream with retry logic
 //
 //nolint:gocognit // retry loop with context checks and progress output
 func (e *Executor) executeWorkstreamWithRetry(ctx context.Context, output io.Writer, wsID string, maxRetries int) (int, error) {
 	var lastErr error
 	retries := 0
+	flakeReruns := 0
 
 	if maxRetries <= 0 {
 		maxRetries = e.config.RetryCount
@@ -52,6 +59,16 @@ func (e *Executor) executeWorkstreamWithRetry(ctx context.Context, output io.Wri
 		lastErr = err
 		slog.Debug("workstream run failed", "ws_id", wsID, "attempt", attempt, "max_retries", maxRetries, "error", err)
 
+		var flakeErr *flaky.Error
+		if errors.As(err, &flakeErr) && flakeReruns < maxFlakeReruns {
+			flakeReruns++
+			attempt-- // a flaky failure does not burn a retry
+			if errW := writeLine(output, e.progress.Output(wsID, progress, "retrying", "non-blocking "+flakeErr.Outcome.Summary())); errW != nil {
+				return retries, fmt.Errorf("write: %w", errW)
+			}
+			continue
+		}
+
 		if attempt < maxRetries {
 			if errW := writeLine(output, e.progress.Output(wsID, progress, "retrying", fmt.Sprintf("failed: %v", err))); errW != nil {
 				return retries, fmt.Errorf("write: %w", errW)
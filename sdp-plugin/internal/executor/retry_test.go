@@ -1,6 +1,9 @@
 package executor
 
 import (
+	"bytes"
+	"context"
+	"strings"
 	"testing"
 )
 
@@ -22,3 +25,28 @@ func TestRetryDelayFromConfigCached(t *testing.T) {
 		t.Errorf("retry delay should be positive, got %v", d1)
 	}
 }
+
+// TestExecuteWorkstreamWithRetry_FlakyDoesNotBurnRetry verifies flaky-only failures are re-run without consuming a retry.
+func TestExecuteWorkstreamWithRetry_FlakyDoesNotBurnRetry(t *testing.T) {
+	runner := &flakyRunner{flakes: 2}
+	exec := NewExecutor(ExecutorConfig{BacklogDir: "testdata/backlog", RetryCount: 1}, runner)
+
+	var output bytes.Buffer
+	retries, err := exec.executeWorkstreamWithRetry(context.Background(), &output, "00-054-01", 0)
+	if err != nil {
+		t.Fatalf("expected success after flaky failures, got %v", err)
+	}
+	if retries != 0 || runner.calls != 3 {
+		t.Errorf("retries = %d, calls = %d; want 0 retries and 3 calls", retries, runner.calls)
+	}
+	if !strings.Contains(output.String(), "flaky: example.com/m/a.TestX") {
+		t.Errorf("expected flaky summary in output, got %q", output.String())
+	}
+
+	// Beyond maxFlakeReruns, flaky failures consume retries like any other.
+	runner = &flakyRunner{flakes: maxFlakeReruns + 2}
+	exec = NewExecutor(ExecutorConfig{BacklogDir: "testdata/backlog", RetryCount: 1}, runner)
+	if _, err := exec.executeWorkstreamWithRetry(context.Background(), &output, "00-054-01", 0); err == nil {
+		t.Error("expected failure once flaky reruns and retries are exhausted")
+	}
+}
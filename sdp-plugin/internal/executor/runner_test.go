@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/fall-out-bug/sdp/internal/flaky"
 )
 
 // testRunner is a mock WorkstreamRunner for tests.
@@ -32,6 +34,22 @@ func (r *testRunner) Run(_ context.Context, wsID string) error {
 	return nil
 }
 
+// flakyRunner fails with a flaky-only error on the first flakes attempts.
+type flakyRunner struct {
+	flakes int
+	calls  int
+}
+
+func (r *flakyRunner) Run(_ context.Context, wsID string) error {
+	r.calls++
+	if r.calls <= r.flakes {
+		outcome := &flaky.Outcome{Failed: []flaky.Test{{Framework: flaky.FrameworkGo, Package: "example.com/m/a", Name: "TestX"}}}
+		outcome.Flaky = outcome.Failed
+		return &flaky.Error{Outcome: outcome, Err: fmt.Errorf("sdp build %s: exit status 1", wsID)}
+	}
+	return nil
+}
+
 // blockingRunner is a WorkstreamRunner that blocks until ctx is cancelled.
 // Used to test mid-execution context cancellation.
 type blockingRunner struct{}
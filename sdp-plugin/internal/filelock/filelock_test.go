@@ -0,0 +1,25 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockUnlock(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "state.lock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if err := Lock(f); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if err := Unlock(f); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	if err := Lock(f); err != nil {
+		t.Fatalf("Lock after Unlock: %v", err)
+	}
+	_ = Unlock(f)
+}
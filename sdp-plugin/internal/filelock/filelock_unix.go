@@ -0,0 +1,24 @@
+//go:build !windows
+
+// Package filelock takes exclusive advisory locks on open files, so
+// separate sdp processes can update shared state files in turn.
+//
+// This package is the source of truth. The root module cannot import it,
+// so internal/filelock at the repository root holds byte-for-byte copies;
+// the root tests fail when they differ.
+package filelock
+
+import (
+	"os"
+	"syscall"
+)
+
+// Lock blocks until it holds an exclusive lock on f.
+func Lock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// Unlock releases a lock taken with Lock.
+func Unlock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
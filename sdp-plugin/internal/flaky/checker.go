@@ -0,0 +1,250 @@
+package flaky
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/metrics"
+)
+
+// Defaults used when .sdp/config.yml leaves quality.flaky fields unset.
+const (
+	DefaultReruns        = 3
+	DefaultThreshold     = 10 // percent
+	DefaultQuarantineTTL = 7 * 24 * time.Hour
+)
+
+// TaxonomyPath is where flaky classifications are recorded for `sdp metrics`.
+const TaxonomyPath = ".sdp/metrics/taxonomy.json"
+
+// Outcome is the triage of a failed test run.
+type Outcome struct {
+	Failed       []Test // every failed test parsed from the output
+	Unattributed bool   // some failure is not tied to a test
+	Flaky        []Test // failed, then passed when re-run in isolation or known flaky
+	Quarantined  []Test // covered by an active quarantine entry
+	Blocking     []Test // genuine failures
+}
+
+// NonBlocking reports whether the run failed only because of flaky or
+// quarantined tests.
+func (o *Outcome) NonBlocking() bool {
+	return o != nil && len(o.Failed) > 0 && !o.Unattributed && len(o.Blocking) == 0
+}
+
+// Summary describes the non-blocking tests, e.g. "flaky: a.TestX; quarantined: b.TestY".
+func (o *Outcome) Summary() string {
+	var parts []string
+	if len(o.Flaky) > 0 {
+		parts = append(parts, "flaky: "+joinIDs(o.Flaky))
+	}
+	if len(o.Quarantined) > 0 {
+		parts = append(parts, "quarantined: "+joinIDs(o.Quarantined))
+	}
+	if len(o.Blocking) > 0 {
+		parts = append(parts, "failing: "+joinIDs(o.Blocking))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func joinIDs(tests []Test) string {
+	ids := make([]string, len(tests))
+	for i, t := range tests {
+		ids[i] = t.ID()
+	}
+	return strings.Join(ids, ", ")
+}
+
+// Error reports a run that failed only because of flaky or quarantined
+// tests. Callers such as the executor retry it without counting an attempt.
+type Error struct {
+	Outcome *Outcome
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v (non-blocking %s)", e.Err, e.Outcome.Summary())
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Checker triages failed test runs for one project.
+type Checker struct {
+	Root          string
+	Source        string  // recorded with each run: verify, tdd, executor
+	WSID          string  // workstream recorded in taxonomy entries, optional
+	Reruns        int     // isolated re-runs per failed test
+	Threshold     float64 // minimum failure rate, 0-1, to classify as flaky
+	QuarantineTTL time.Duration
+
+	// runTest runs one test in isolation; swapped in tests.
+	runTest func(ctx context.Context, dir string, launcher []string, t Test) error
+	now     func() time.Time
+}
+
+// New returns a Checker configured from root/.sdp/config.yml, or nil when
+// quality.flaky.enabled is false.
+func New(root, source string) (*Checker, error) {
+	cfg, err := config.Load(root)
+	if err != nil {
+		return nil, err
+	}
+	fc := cfg.Quality.Flaky
+	if !fc.Enabled {
+		return nil, nil
+	}
+	c := &Checker{
+		Root:          root,
+		Source:        source,
+		Reruns:        fc.Reruns,
+		Threshold:     float64(fc.Threshold) / 100,
+		QuarantineTTL: config.TimeoutFromConfigOrEnv(fc.QuarantineTTL, "SDP_FLAKY_QUARANTINE_TTL", DefaultQuarantineTTL),
+	}
+	if c.Reruns < 0 {
+		c.Reruns = DefaultReruns
+	}
+	if fc.Threshold <= 0 {
+		c.Threshold = float64(DefaultThreshold) / 100
+	}
+	return c, nil
+}
+
+// Check triages a failed test command: args and output are the command and
+// its combined output, dir its working directory. Each failed test that is
+// not quarantined is re-run in isolation Reruns times; tests that pass a
+// re-run are flaky. Histories are updated, tests whose history crosses the
+// threshold are quarantined for QuarantineTTL, and flaky classifications are
+// recorded in the metrics taxonomy.
+func (c *Checker) Check(ctx context.Context, dir string, args []string, output string) (*Outcome, error) {
+	return c.triage(ctx, dir, args, output, true)
+}
+
+// Triage classifies failures from history and quarantine alone, without
+// re-running anything.
+func (c *Checker) Triage(output string) (*Outcome, error) {
+	return c.triage(context.Background(), "", nil, output, false)
+}
+
+func (c *Checker) triage(ctx context.Context, dir string, args []string, output string, rerun bool) (*Outcome, error) {
+	failures := ParseFailures(output)
+	o := &Outcome{Failed: failures.Tests, Unattributed: failures.Unattributed}
+	if len(failures.Tests) == 0 {
+		return o, nil
+	}
+	store, err := Open(c.Root)
+	if err != nil {
+		return o, err
+	}
+	now := c.clock()
+
+	var flaky []Test
+	for _, t := range failures.Tests {
+		id := t.ID()
+		if _, ok := store.Quarantined(id, now); ok {
+			store.Record(id, false, c.Source, now)
+			o.Quarantined = append(o.Quarantined, t)
+			continue
+		}
+		if !rerun {
+			if h, ok := store.History(id); ok && h.Classification == Flaky {
+				o.Flaky = append(o.Flaky, t)
+			} else {
+				o.Blocking = append(o.Blocking, t)
+			}
+			continue
+		}
+		store.Record(id, false, c.Source, now)
+		passed := 0
+		for i := 0; i < c.Reruns; i++ {
+			if ctx.Err() != nil {
+				return o, ctx.Err()
+			}
+			ok := c.run(ctx, dir, args, t) == nil
+			store.Record(id, ok, "rerun", c.clock())
+			if ok {
+				passed++
+			}
+		}
+		if passed == 0 {
+			store.Classify(id, c.Threshold)
+			o.Blocking = append(o.Blocking, t)
+			continue
+		}
+		o.Flaky = append(o.Flaky, t)
+		if store.Classify(id, c.Threshold) == Flaky {
+			h, _ := store.History(id)
+			p, f := h.Counts()
+			store.Quarantine(Entry{
+				TestID:    id,
+				Reason:    fmt.Sprintf("failed %d of %d recent runs", f, p+f),
+				AddedAt:   now.UTC(),
+				ExpiresAt: now.Add(c.QuarantineTTL).UTC(),
+			})
+			flaky = append(flaky, t)
+		}
+	}
+	if err := store.Save(); err != nil {
+		return o, err
+	}
+	if len(flaky) > 0 {
+		if err := c.recordTaxonomy(store, flaky); err != nil {
+			return o, err
+		}
+	}
+	return o, nil
+}
+
+// recordTaxonomy records flaky_test classifications in the metrics taxonomy.
+func (c *Checker) recordTaxonomy(store *Store, tests []Test) error {
+	taxonomy := metrics.NewTaxonomy(filepath.Join(c.Root, TaxonomyPath))
+	if err := taxonomy.Load(); err != nil {
+		return err
+	}
+	for _, t := range tests {
+		h, _ := store.History(t.ID())
+		p, f := h.Counts()
+		language := "go"
+		if t.Framework == FrameworkPytest {
+			language = "python"
+		}
+		taxonomy.ClassifyFlakyTest(t.ID(), c.WSID, language, fmt.Sprintf("failed %d of %d recent runs", f, p+f))
+	}
+	return taxonomy.Save()
+}
+
+func (c *Checker) run(ctx context.Context, dir string, launcher []string, t Test) error {
+	if c.runTest != nil {
+		return c.runTest(ctx, dir, launcher, t)
+	}
+	args := RerunCommand(launcher, t)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.WaitDelay = 5 * time.Second
+	return cmd.Run()
+}
+
+func (c *Checker) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// RerunCommand returns the command that runs t alone. launcher is the
+// original test command; for pytest it decides between `pytest` and
+// `python -m pytest`.
+func RerunCommand(launcher []string, t Test) []string {
+	if t.Framework == FrameworkGo {
+		return []string{"go", "test", "-count=1", "-run", "^" + regexp.QuoteMeta(t.Name) + "$", t.Package}
+	}
+	if len(launcher) > 0 && strings.HasPrefix(filepath.Base(launcher[0]), "python") {
+		return []string{launcher[0], "-m", "pytest", "-q", "-p", "no:cacheprovider", t.Name}
+	}
+	return []string{"pytest", "-q", "-p", "no:cacheprovider", t.Name}
+}
@@ -0,0 +1,142 @@
+package flaky
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/metrics"
+)
+
+const goOutput = `--- FAIL: TestFlaky (0.00s)
+--- FAIL: TestBroken (0.00s)
+FAIL
+FAIL	example.com/m/a	0.012s
+`
+
+func testChecker(t *testing.T, results map[string][]bool) *Checker {
+	t.Helper()
+	calls := make(map[string]int)
+	return &Checker{
+		Root:          t.TempDir(),
+		Source:        "verify",
+		Reruns:        3,
+		Threshold:     0.1,
+		QuarantineTTL: time.Hour,
+		now:           func() time.Time { return time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC) },
+		runTest: func(_ context.Context, _ string, _ []string, tt Test) error {
+			r := results[tt.Name]
+			i := calls[tt.Name]
+			calls[tt.Name]++
+			if i < len(r) && r[i] {
+				return nil
+			}
+			return errors.New("exit status 1")
+		},
+	}
+}
+
+func TestChecker_Check(t *testing.T) {
+	c := testChecker(t, map[string][]bool{"TestFlaky": {false, true, true}})
+	ctx := context.Background()
+
+	o, err := c.Check(ctx, "", []string{"go", "test", "./..."}, goOutput)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if o.NonBlocking() {
+		t.Error("a test failing every re-run must block")
+	}
+	if len(o.Flaky) != 1 || o.Flaky[0].Name != "TestFlaky" || len(o.Blocking) != 1 || o.Blocking[0].Name != "TestBroken" {
+		t.Fatalf("unexpected outcome %+v", o)
+	}
+
+	s, _ := Open(c.Root)
+	e, ok := s.Quarantined("example.com/m/a.TestFlaky", c.now())
+	if !ok || e.Reason != "failed 2 of 4 recent runs" || !e.ExpiresAt.Equal(c.now().Add(time.Hour)) {
+		t.Errorf("flaky test not quarantined: %+v", e)
+	}
+	if h, _ := s.History("example.com/m/a.TestBroken"); h.Classification != Failing {
+		t.Errorf("TestBroken classification = %s", h.Classification)
+	}
+
+	taxonomy := metrics.NewTaxonomy(filepath.Join(c.Root, TaxonomyPath))
+	if err := taxonomy.Load(); err != nil {
+		t.Fatal(err)
+	}
+	fc, ok := taxonomy.GetClassification("flaky:example.com/m/a.TestFlaky")
+	if !ok || fc.FailureType != metrics.FailureFlakyTest || fc.Language != "go" {
+		t.Errorf("taxonomy entry = %+v, %v", fc, ok)
+	}
+
+	// Once quarantined, the flaky test is not re-run and does not block.
+	o, err = c.Check(ctx, "", nil, "--- FAIL: TestFlaky (0.00s)\nFAIL\texample.com/m/a\t0.01s\n")
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if !o.NonBlocking() || len(o.Quarantined) != 1 {
+		t.Errorf("quarantined failure should be non-blocking: %+v", o)
+	}
+	if got := o.Summary(); got != "quarantined: example.com/m/a.TestFlaky" {
+		t.Errorf("Summary = %q", got)
+	}
+}
+
+func TestChecker_Triage(t *testing.T) {
+	c := testChecker(t, nil)
+	s, _ := Open(c.Root)
+	s.Record("example.com/m/a.TestFlaky", false, "", c.now())
+	s.Record("example.com/m/a.TestFlaky", true, "", c.now())
+	s.Classify("example.com/m/a.TestFlaky", 0.1)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := c.Triage(goOutput)
+	if err != nil {
+		t.Fatalf("Triage: %v", err)
+	}
+	if o.NonBlocking() || len(o.Flaky) != 1 || len(o.Blocking) != 1 {
+		t.Errorf("Triage = %+v", o)
+	}
+	if o, _ := c.Triage("FAIL\texample.com/m/a [build failed]\n"); o.NonBlocking() {
+		t.Error("build failures must block")
+	}
+}
+
+func TestNew(t *testing.T) {
+	root := t.TempDir()
+	if c, err := New(root, "verify"); err != nil || c != nil {
+		t.Fatalf("New = %v, %v; want disabled by default", c, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "version: 1\nquality:\n  flaky:\n    enabled: true\n"
+	if err := os.WriteFile(filepath.Join(root, ".sdp", "config.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(root, "verify")
+	if err != nil || c == nil {
+		t.Fatalf("New with flaky enabled = %v, %v", c, err)
+	}
+	if c.Reruns != DefaultReruns || c.Threshold != 0.1 || c.QuarantineTTL != DefaultQuarantineTTL {
+		t.Errorf("defaults = %+v", c)
+	}
+}
+
+func TestRerunCommand(t *testing.T) {
+	got := RerunCommand(nil, Test{FrameworkGo, "example.com/m/a", "TestA"})
+	if want := []string{"go", "test", "-count=1", "-run", "^TestA$", "example.com/m/a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("go = %v", got)
+	}
+	got = RerunCommand([]string{"python3", "-m", "pytest"}, Test{Framework: FrameworkPytest, Name: "t.py::x"})
+	if want := []string{"python3", "-m", "pytest", "-q", "-p", "no:cacheprovider", "t.py::x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pytest = %v", got)
+	}
+}
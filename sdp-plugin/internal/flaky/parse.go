@@ -0,0 +1,95 @@
+// Package flaky detects flaky tests by re-running failures in isolation,
+// keeps a per-test pass/fail history and a quarantine list under .sdp/flaky,
+// and tells gates whether a failed test run is blocking.
+package flaky
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Frameworks whose failures can be parsed and re-run.
+const (
+	FrameworkGo     = "go"
+	FrameworkPytest = "pytest"
+)
+
+// Test identifies one test case.
+type Test struct {
+	Framework string // FrameworkGo or FrameworkPytest
+	Package   string // Go import path; empty for pytest
+	Name      string // top-level Go test function or pytest node id
+}
+
+// ID returns the stable key used in history and quarantine files:
+// "<import path>.<TestName>" for Go and the node id for pytest.
+func (t Test) ID() string {
+	if t.Package == "" {
+		return t.Name
+	}
+	return t.Package + "." + t.Name
+}
+
+// Failures is the set of failed tests parsed from test command output.
+type Failures struct {
+	Tests []Test
+	// Unattributed is set when some failure cannot be tied to a test
+	// (build failures, collection errors, a failing TestMain). Such runs are
+	// always blocking.
+	Unattributed bool
+}
+
+var (
+	goFailLine     = regexp.MustCompile(`^--- FAIL: (\S+)`)
+	goPackageLine  = regexp.MustCompile(`^(FAIL|ok)\s+(\S+)(\s+.*)?$`)
+	pytestFailLine = regexp.MustCompile(`^FAILED (\S+)`)
+	pytestErrLine  = regexp.MustCompile(`^ERROR (\S+)`)
+)
+
+// ParseFailures extracts failed tests from `go test` or pytest output.
+// Go subtests are attributed to their top-level test, which is what a
+// re-run can select.
+func ParseFailures(output string) Failures {
+	var f Failures
+	seen := make(map[string]bool)
+	add := func(t Test) {
+		if !seen[t.ID()] {
+			seen[t.ID()] = true
+			f.Tests = append(f.Tests, t)
+		}
+	}
+
+	var pending []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := goFailLine.FindStringSubmatch(line); m != nil {
+			name, _, _ := strings.Cut(m[1], "/")
+			pending = append(pending, name)
+			continue
+		}
+		if m := goPackageLine.FindStringSubmatch(line); m != nil {
+			if m[1] == "FAIL" {
+				if len(pending) == 0 || strings.Contains(m[3], "failed]") {
+					f.Unattributed = true
+				}
+				for _, name := range pending {
+					add(Test{Framework: FrameworkGo, Package: m[2], Name: name})
+				}
+			}
+			pending = nil
+			continue
+		}
+		if m := pytestFailLine.FindStringSubmatch(line); m != nil {
+			add(Test{Framework: FrameworkPytest, Name: m[1]})
+			continue
+		}
+		if pytestErrLine.MatchString(line) {
+			f.Unattributed = true
+		}
+	}
+	if len(pending) > 0 {
+		// Test failures without a package summary (e.g. a killed run).
+		f.Unattributed = true
+	}
+	return f
+}
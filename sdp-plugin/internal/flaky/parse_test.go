@@ -0,0 +1,67 @@
+package flaky
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFailures_Go(t *testing.T) {
+	out := `--- FAIL: TestA (0.00s)
+    a_test.go:9: boom
+--- FAIL: TestB (0.01s)
+    --- FAIL: TestB/sub (0.00s)
+FAIL
+FAIL	example.com/m/a	0.012s
+ok  	example.com/m/b	(cached)
+--- FAIL: TestC (0.00s)
+FAIL
+FAIL	example.com/m/c	0.003s
+FAIL
+`
+	f := ParseFailures(out)
+	want := []Test{
+		{FrameworkGo, "example.com/m/a", "TestA"},
+		{FrameworkGo, "example.com/m/a", "TestB"},
+		{FrameworkGo, "example.com/m/c", "TestC"},
+	}
+	if !reflect.DeepEqual(f.Tests, want) || f.Unattributed {
+		t.Errorf("ParseFailures = %+v", f)
+	}
+	if got := f.Tests[0].ID(); got != "example.com/m/a.TestA" {
+		t.Errorf("ID = %q", got)
+	}
+}
+
+func TestParseFailures_Unattributed(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+	}{
+		{"build failure", "# example.com/m/a\na.go:3:1: syntax error\nFAIL\texample.com/m/a [build failed]\n"},
+		{"failing TestMain", "FAIL\texample.com/m/a\t0.010s\n"},
+		{"pytest collection error", "ERROR tests/test_x.py - ImportError\n"},
+		{"truncated", "--- FAIL: TestA (0.00s)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if f := ParseFailures(tt.out); !f.Unattributed {
+				t.Errorf("expected unattributed failure, got %+v", f)
+			}
+		})
+	}
+}
+
+func TestParseFailures_Pytest(t *testing.T) {
+	out := "=========== short test summary info ===========\n" +
+		"FAILED tests/test_x.py::test_a - assert 1 == 2\n" +
+		"FAILED tests/test_x.py::test_b[1-2]\n" +
+		"========= 2 failed, 3 passed in 0.12s =========\n"
+	f := ParseFailures(out)
+	want := []Test{
+		{Framework: FrameworkPytest, Name: "tests/test_x.py::test_a"},
+		{Framework: FrameworkPytest, Name: "tests/test_x.py::test_b[1-2]"},
+	}
+	if !reflect.DeepEqual(f.Tests, want) || f.Unattributed {
+		t.Errorf("ParseFailures = %+v", f)
+	}
+}
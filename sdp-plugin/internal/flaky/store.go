@@ -0,0 +1,351 @@
+package flaky
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// Layout of the flaky test state under the project root.
+const (
+	Dir            = ".sdp/flaky"
+	HistoryFile    = "history.json"
+	QuarantineFile = "quarantine.json"
+)
+
+// maxRuns bounds the per-test history window.
+const maxRuns = 50
+
+// Classification of a test from its run history.
+type Classification string
+
+const (
+	Stable  Classification = "stable"
+	Flaky   Classification = "flaky"
+	Failing Classification = "failing"
+)
+
+// Run is one recorded execution of a test.
+type Run struct {
+	At     time.Time `json:"at"`
+	Passed bool      `json:"passed"`
+	Source string    `json:"source,omitempty"` // verify, tdd, executor, rerun
+}
+
+// History is the recent pass/fail record of one test.
+type History struct {
+	Runs           []Run          `json:"runs"`
+	Classification Classification `json:"classification,omitempty"`
+}
+
+// Counts returns the number of passing and failing runs.
+func (h History) Counts() (passed, failed int) {
+	for _, r := range h.Runs {
+		if r.Passed {
+			passed++
+		} else {
+			failed++
+		}
+	}
+	return passed, failed
+}
+
+// Entry is a quarantined test. Quarantined tests do not block gates until
+// ExpiresAt.
+type Entry struct {
+	TestID    string    `json:"test_id"`
+	Reason    string    `json:"reason,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Active reports whether the entry is still in force at now.
+func (e Entry) Active(now time.Time) bool {
+	return e.ExpiresAt.IsZero() || now.Before(e.ExpiresAt)
+}
+
+// Store holds test histories and the quarantine list for a project.
+//
+// Changes made since Open are kept alongside the loaded state, and Save
+// merges them into the files as they are on disk under an exclusive lock,
+// so concurrent verify and tdd runs do not drop each other's runs.
+type Store struct {
+	dir        string
+	mu         sync.Mutex
+	history    map[string]*History
+	quarantine map[string]Entry
+
+	// Pending changes, applied to the on-disk state by Save.
+	runs       map[string][]Run
+	classified map[string]Classification
+	added      map[string]Entry
+	removed    map[string]bool
+}
+
+// Open loads the store under root/.sdp/flaky. Missing files are empty.
+func Open(root string) (*Store, error) {
+	s := &Store{dir: filepath.Join(root, Dir)}
+	history, quarantine, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	s.history, s.quarantine = history, quarantine
+	s.resetPending()
+	return s, nil
+}
+
+func (s *Store) load() (map[string]*History, map[string]Entry, error) {
+	history := make(map[string]*History)
+	if err := readJSON(filepath.Join(s.dir, HistoryFile), &history); err != nil {
+		return nil, nil, err
+	}
+	var entries []Entry
+	if err := readJSON(filepath.Join(s.dir, QuarantineFile), &entries); err != nil {
+		return nil, nil, err
+	}
+	quarantine := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		quarantine[e.TestID] = e
+	}
+	return history, quarantine, nil
+}
+
+func (s *Store) resetPending() {
+	s.runs = make(map[string][]Run)
+	s.classified = make(map[string]Classification)
+	s.added = make(map[string]Entry)
+	s.removed = make(map[string]bool)
+}
+
+// Record appends a run to the test's history, keeping the last maxRuns.
+func (s *Store) Record(id string, passed bool, source string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run := Run{At: at.UTC(), Passed: passed, Source: source}
+	appendRuns(s.history, id, run)
+	s.runs[id] = append(s.runs[id], run)
+}
+
+func appendRuns(history map[string]*History, id string, runs ...Run) *History {
+	h := history[id]
+	if h == nil {
+		h = &History{}
+		history[id] = h
+	}
+	h.Runs = append(h.Runs, runs...)
+	if len(h.Runs) > maxRuns {
+		h.Runs = h.Runs[len(h.Runs)-maxRuns:]
+	}
+	return h
+}
+
+// Classify classifies a test from its history and stores the result. A test
+// with both passing and failing runs is flaky when its failure rate is at
+// least threshold (0-1); one that never passed is failing.
+func (s *Store) Classify(id string, threshold float64) Classification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := s.history[id]
+	if h == nil {
+		return Stable
+	}
+	passed, failed := h.Counts()
+	switch {
+	case failed == 0:
+		h.Classification = Stable
+	case passed == 0:
+		h.Classification = Failing
+	case float64(failed)/float64(passed+failed) >= threshold:
+		h.Classification = Flaky
+	default:
+		h.Classification = Stable
+	}
+	s.classified[id] = h.Classification
+	return h.Classification
+}
+
+// History returns a copy of the test's history.
+func (s *Store) History(id string) (History, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.history[id]
+	if !ok {
+		return History{}, false
+	}
+	return History{Runs: append([]Run(nil), h.Runs...), Classification: h.Classification}, true
+}
+
+// Histories returns all test IDs with history, sorted.
+func (s *Store) Histories() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.history))
+	for id := range s.history {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Quarantine adds or replaces a quarantine entry.
+func (s *Store) Quarantine(e Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quarantine[e.TestID] = e
+	s.added[e.TestID] = e
+	delete(s.removed, e.TestID)
+}
+
+// Release removes a test from quarantine and reports whether it was there.
+func (s *Store) Release(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.quarantine[id]
+	s.drop(id)
+	return ok
+}
+
+// drop removes a quarantine entry and records the removal. Callers hold mu.
+func (s *Store) drop(id string) {
+	delete(s.quarantine, id)
+	delete(s.added, id)
+	s.removed[id] = true
+}
+
+// Quarantined returns the test's entry if it is quarantined and not expired.
+func (s *Store) Quarantined(id string, now time.Time) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.quarantine[id]
+	if !ok || !e.Active(now) {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Entries returns all quarantine entries, including expired ones, sorted by test ID.
+func (s *Store) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]Entry, 0, len(s.quarantine))
+	for _, e := range s.quarantine {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].TestID < entries[j].TestID })
+	return entries
+}
+
+// Prune drops expired quarantine entries and returns how many were removed.
+func (s *Store) Prune(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for id, e := range s.quarantine {
+		if !e.Active(now) {
+			s.drop(id)
+			n++
+		}
+	}
+	return n
+}
+
+// Save merges the changes made since Open, or since the last Save, into the
+// history and quarantine files under an exclusive lock, then reloads the
+// merged state.
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create flaky dir: %w", err)
+	}
+	lock, err := os.OpenFile(filepath.Join(s.dir, HistoryFile+".lock"), os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open flaky lock: %w", err)
+	}
+	defer func() { _ = lock.Close() }()
+	if err := filelock.Lock(lock); err != nil {
+		return fmt.Errorf("lock flaky store: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lock) }()
+
+	history, quarantine, err := s.load()
+	if err != nil {
+		return err
+	}
+	for id, runs := range s.runs {
+		appendRuns(history, id, runs...)
+	}
+	for id, c := range s.classified {
+		if h := history[id]; h != nil {
+			h.Classification = c
+		}
+	}
+	for id := range s.removed {
+		delete(quarantine, id)
+	}
+	for id, e := range s.added {
+		quarantine[id] = e
+	}
+
+	entries := make([]Entry, 0, len(quarantine))
+	for _, e := range quarantine {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].TestID < entries[j].TestID })
+	if err := writeJSON(filepath.Join(s.dir, HistoryFile), history); err != nil {
+		return err
+	}
+	if err := writeJSON(filepath.Join(s.dir, QuarantineFile), entries); err != nil {
+		return err
+	}
+	s.history, s.quarantine = history, quarantine
+	s.resetPending()
+	return nil
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", filepath.Base(path), err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", filepath.Base(path), err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,130 @@
+package flaky
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStore_ClassifyAndPersist(t *testing.T) {
+	root := t.TempDir()
+	s, err := Open(root)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.Record("a.TestFlaky", false, "verify", now)
+	s.Record("a.TestFlaky", true, "rerun", now)
+	s.Record("a.TestBroken", false, "verify", now)
+	s.Record("a.TestRare", false, "verify", now)
+	for i := 0; i < 19; i++ {
+		s.Record("a.TestRare", true, "rerun", now)
+	}
+
+	if got := s.Classify("a.TestFlaky", 0.1); got != Flaky {
+		t.Errorf("TestFlaky = %s, want flaky", got)
+	}
+	if got := s.Classify("a.TestBroken", 0.1); got != Failing {
+		t.Errorf("TestBroken = %s, want failing", got)
+	}
+	if got := s.Classify("a.TestRare", 0.1); got != Stable {
+		t.Errorf("TestRare (5%% failures) = %s, want stable", got)
+	}
+	if got := s.Classify("a.TestUnknown", 0.1); got != Stable {
+		t.Errorf("unknown test = %s, want stable", got)
+	}
+
+	s.Quarantine(Entry{TestID: "a.TestFlaky", AddedAt: now, ExpiresAt: now.Add(time.Hour)})
+	s.Quarantine(Entry{TestID: "a.TestOld", AddedAt: now, ExpiresAt: now.Add(-time.Hour)})
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	s, err = Open(root)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if h, ok := s.History("a.TestFlaky"); !ok || len(h.Runs) != 2 || h.Classification != Flaky {
+		t.Errorf("history not persisted: %+v", h)
+	}
+	if _, ok := s.Quarantined("a.TestFlaky", now); !ok {
+		t.Error("expected TestFlaky to be quarantined")
+	}
+	if _, ok := s.Quarantined("a.TestFlaky", now.Add(2*time.Hour)); ok {
+		t.Error("quarantine should expire")
+	}
+	if _, ok := s.Quarantined("a.TestOld", now); ok {
+		t.Error("expired entry should not be active")
+	}
+	if n := s.Prune(now); n != 1 || len(s.Entries()) != 1 {
+		t.Errorf("Prune = %d, entries %v", n, s.Entries())
+	}
+	if !s.Release("a.TestFlaky") || s.Release("a.TestFlaky") {
+		t.Error("Release should report whether the test was quarantined")
+	}
+}
+
+func TestStore_HistoryWindow(t *testing.T) {
+	s, _ := Open(t.TempDir())
+	for i := 0; i < maxRuns+10; i++ {
+		s.Record("x", i%2 == 0, "", time.Now())
+	}
+	if h, _ := s.History("x"); len(h.Runs) != maxRuns {
+		t.Errorf("history holds %d runs, want %d", len(h.Runs), maxRuns)
+	}
+}
+
+func TestStore_SaveMergesConcurrentStores(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	seed, err := Open(root)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	seed.Quarantine(Entry{TestID: "a.TestOld", AddedAt: now})
+	if err := seed.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s, err := Open(root)
+			if err != nil {
+				errs <- err
+				return
+			}
+			s.Record("a.TestShared", i%2 == 0, "verify", now)
+			s.Record(fmt.Sprintf("a.Test%d", i), true, "verify", now)
+			if i == 0 {
+				s.Release("a.TestOld")
+			}
+			errs <- s.Save()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent Save: %v", err)
+		}
+	}
+
+	s, err := Open(root)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if h, _ := s.History("a.TestShared"); len(h.Runs) != 10 {
+		t.Errorf("shared history has %d runs, want 10", len(h.Runs))
+	}
+	if n := len(s.Histories()); n != 11 {
+		t.Errorf("got %d histories, want 11", n)
+	}
+	if _, ok := s.Quarantined("a.TestOld", now); ok {
+		t.Error("release was overwritten by a concurrent save")
+	}
+}
@@ -0,0 +1,32 @@
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRootAndModulePath(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("// comment\nmodule \"example.com/m\"\n\ngo 1.24\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sub := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Root(sub)
+	if err != nil || got != root {
+		t.Fatalf("Root = %q, %v; want %q", got, err, root)
+	}
+	if p := ModulePath(got); p != "example.com/m" {
+		t.Errorf("ModulePath = %q", p)
+	}
+	if p := ModulePath(sub); p != "" {
+		t.Errorf("ModulePath without go.mod = %q", p)
+	}
+	if _, err := Root(t.TempDir()); err == nil {
+		t.Error("Root outside a module should fail")
+	}
+}
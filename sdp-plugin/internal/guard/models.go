@@ -2,6 +2,8 @@ package guard
 
 import (
 	"time"
+
+	"github.com/fall-out-bug/sdp/internal/pathmatch"
 )
 
 // Exit codes for guard check command
@@ -74,7 +76,7 @@ func (e *Exception) IsExpired() bool {
 
 // MatchesFile checks if the exception path glob matches a file
 func (e *Exception) MatchesFile(filePath string) bool {
-	return matchGlob(e.PathGlob, filePath)
+	return pathmatch.Match(e.PathGlob, filePath)
 }
 
 // AppliedExceptionInfo tracks which exception was applied for auditability.
@@ -4,6 +4,14 @@ package guard
 type Skill struct {
 	stateManager *StateManager
 	activeWS     string
+	// severityMapping is guard.severity_mapping from .sdp/config.yml.
+	severityMapping map[string]string
+	// baseRef is the revision go-ast rules compare against to find new
+	// declarations ("" = HEAD).
+	baseRef string
+	// projectRoot is where project-level specs such as
+	// .sdp/architecture.yml are read from ("" = working directory).
+	projectRoot string
 }
 
 // NewSkill creates a new guard skill
@@ -3,9 +3,11 @@ package guard
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/pathmatch"
 )
 
 // applyGuardRules applies loaded guard rules to staged files (AC1)
@@ -26,7 +28,19 @@ func (s *Skill) applyGuardRules(files []string, rules *config.GuardRules) []Find
 
 		// Check each enabled rule
 		for _, rule := range rules.Rules {
-			if !rule.Enabled {
+			if !rule.Enabled || !ruleAppliesTo(rule, file) {
+				continue
+			}
+
+			switch rule.Type {
+			case config.GuardRuleRegex:
+				findings = append(findings, s.checkRegexRule(file, content, rule)...)
+				continue
+			case config.GuardRuleGoAST:
+				findings = append(findings, s.checkGoASTRule(file, content, rule)...)
+				continue
+			case config.GuardRuleCommand:
+				// Run once for all files below
 				continue
 			}
 
@@ -36,6 +50,9 @@ func (s *Skill) applyGuardRules(files []string, rules *config.GuardRules) []Find
 			case "coverage-threshold":
 				// Coverage is checked at project level, not per file
 				continue
+			case architectureRuleID:
+				// Imports are checked for all files at once below
+				continue
 			case "max-cyclomatic-complexity":
 				// Complexity requires parsing - skip for now
 				continue
@@ -47,9 +64,73 @@ func (s *Skill) applyGuardRules(files []string, rules *config.GuardRules) []Find
 		}
 	}
 
+	// Command and architecture rules see all matching files at once
+	for _, rule := range rules.Rules {
+		if !rule.Enabled {
+			continue
+		}
+		switch {
+		case rule.Type == config.GuardRuleCommand:
+			if matched := matchingFiles(rule, files); len(matched) > 0 {
+				findings = append(findings, s.checkCommandRule(matched, rule)...)
+			}
+		case rule.Type == "" && rule.ID == architectureRuleID:
+			findings = append(findings, s.checkArchitecture(matchingFiles(rule, files), rule)...)
+		}
+	}
+
 	return findings
 }
 
+// matchingFiles returns the files a rule applies to.
+func matchingFiles(rule config.GuardRule, files []string) []string {
+	var matched []string
+	for _, file := range files {
+		if ruleAppliesTo(rule, file) {
+			matched = append(matched, file)
+		}
+	}
+	return matched
+}
+
+// ruleAppliesTo reports whether file matches the rule's paths and exclude globs.
+func ruleAppliesTo(rule config.GuardRule, file string) bool {
+	file = filepath.ToSlash(strings.TrimPrefix(file, "./"))
+	if pathmatch.Any(rule.Exclude, file) {
+		return false
+	}
+	return len(rule.Paths) == 0 || pathmatch.Any(rule.Paths, file)
+}
+
+// severityFor maps a rule severity (error, warning, info) through
+// guard.severity_mapping (block, warn, log). ok is false when findings are
+// only logged. Without a mapping, error blocks and anything else warns.
+func (s *Skill) severityFor(ruleSeverity string) (severity Severity, ok bool) {
+	switch s.severityMapping[strings.ToLower(ruleSeverity)] {
+	case "block":
+		return SeverityError, true
+	case "warn":
+		return SeverityWarning, true
+	case "log":
+		return "", false
+	}
+	if strings.EqualFold(ruleSeverity, "error") {
+		return SeverityError, true
+	}
+	return SeverityWarning, true
+}
+
+// ruleMessage returns the rule's configured message, its description, or fallback.
+func ruleMessage(rule config.GuardRule, fallback string) string {
+	if rule.Message != "" {
+		return rule.Message
+	}
+	if rule.Description != "" {
+		return rule.Description
+	}
+	return fallback
+}
+
 // checkMaxFileLOC checks if file exceeds max lines of code (AC1, AC6)
 func (s *Skill) checkMaxFileLOC(file string, content []byte, rule config.GuardRule) []Finding {
 	var findings []Finding
@@ -68,9 +149,9 @@ func (s *Skill) checkMaxFileLOC(file string, content []byte, rule config.GuardRu
 	loc := len(lines)
 
 	if loc > maxLines {
-		severity := SeverityWarning
-		if rule.Severity == "error" {
-			severity = SeverityError
+		severity, ok := s.severityFor(rule.Severity)
+		if !ok {
+			return nil
 		}
 
 		findings = append(findings, Finding{
@@ -110,9 +191,9 @@ func (s *Skill) checkCommentedCode(file string, content []byte, rule config.Guar
 
 			consecutiveComments++
 			if consecutiveComments >= maxComments {
-				severity := SeverityWarning
-				if rule.Severity == "error" {
-					severity = SeverityError
+				severity, ok := s.severityFor(rule.Severity)
+				if !ok {
+					return nil
 				}
 
 				findings = append(findings, Finding{
@@ -160,9 +241,9 @@ func (s *Skill) checkOrphanedTODOs(file string, content []byte, rule config.Guar
 			}
 
 			if !hasWSID {
-				severity := SeverityWarning
-				if rule.Severity == "error" {
-					severity = SeverityError
+				severity, ok := s.severityFor(rule.Severity)
+				if !ok {
+					return nil
 				}
 
 				findings = append(findings, Finding{
@@ -0,0 +1,47 @@
+package guard
+
+import (
+	"fmt"
+
+	"github.com/fall-out-bug/sdp/internal/arch"
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// architectureRuleID checks imports against .sdp/architecture.yml.
+const architectureRuleID = "clean-architecture-layers"
+
+// checkArchitecture reports imports in files that cross layers against the
+// project's architecture spec. Projects without a spec are not checked.
+func (s *Skill) checkArchitecture(files []string, rule config.GuardRule) []Finding {
+	severity, ok := s.severityFor(rule.Severity)
+	if !ok {
+		return nil
+	}
+	root := s.projectRoot
+	if root == "" {
+		root = "."
+	}
+	spec, err := arch.LoadSpec(root)
+	if err != nil {
+		return []Finding{{Severity: severity, Rule: rule.ID, File: arch.SpecFile, Message: fmt.Sprintf("Invalid architecture spec: %v", err)}}
+	}
+	if spec == nil {
+		return nil
+	}
+	violations, err := arch.CheckFiles(root, spec, files)
+	if err != nil {
+		return []Finding{{Severity: severity, Rule: rule.ID, Message: fmt.Sprintf("Architecture check failed: %v", err)}}
+	}
+
+	findings := make([]Finding, 0, len(violations))
+	for _, v := range violations {
+		findings = append(findings, Finding{
+			Severity: severity,
+			Rule:     rule.ID,
+			File:     v.File,
+			Line:     v.Line,
+			Message:  v.Message(),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,46 @@
+package guard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+func TestCheckArchitecture(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".sdp/architecture.yml":   "layers:\n  - name: domain\n    packages: [\"internal/domain/**\"]\n  - name: infra\n    packages: [\"internal/infra/**\"]\n    may_import: [domain]\n",
+		"go.mod":                  "module example.com/app\n",
+		"internal/domain/user.go": "package domain\n\nimport (\n\t\"fmt\"\n\t\"example.com/app/internal/infra\"\n)\n",
+		"internal/infra/db.go":    "package infra\n\nimport \"example.com/app/internal/domain\"\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := &Skill{projectRoot: root}
+	rules := &config.GuardRules{Rules: []config.GuardRule{{ID: architectureRuleID, Enabled: true, Severity: "error"}}}
+	findings := s.applyGuardRules([]string{"internal/domain/user.go", "internal/infra/db.go"}, rules)
+	if len(findings) != 1 {
+		t.Fatalf("findings = %+v, want 1", findings)
+	}
+	if f := findings[0]; f.File != "internal/domain/user.go" || f.Line != 5 || f.Severity != SeverityError {
+		t.Errorf("finding = %+v, want internal/domain/user.go:5 error", f)
+	}
+
+	// Without a spec the rule is a no-op
+	if err := os.Remove(filepath.Join(root, ".sdp/architecture.yml")); err != nil {
+		t.Fatal(err)
+	}
+	if findings := s.checkArchitecture([]string{"internal/domain/user.go"}, rules.Rules[0]); len(findings) != 0 {
+		t.Errorf("findings without spec = %+v, want none", findings)
+	}
+}
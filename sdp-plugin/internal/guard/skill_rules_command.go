@@ -0,0 +1,120 @@
+package guard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// defaultCommandRuleTimeout bounds an external rule without a timeout.
+const defaultCommandRuleTimeout = 60 * time.Second
+
+// CommandFinding is one finding in the JSON an external command rule prints
+// on stdout, either as {"findings": [...]} or as a bare array. Severity
+// (error, warning, info) overrides the rule's severity; Message defaults to
+// the rule's message.
+type CommandFinding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Severity string `json:"severity,omitempty"`
+}
+
+// checkCommandRule runs an external rule with the matching files appended to
+// its command. Exit code 0 or 1 (findings present) with valid JSON output is
+// success; anything else is reported as a finding of the rule.
+func (s *Skill) checkCommandRule(files []string, rule config.GuardRule) []Finding {
+	ruleSeverity, ok := s.severityFor(rule.Severity)
+	if !ok {
+		return nil
+	}
+	failed := func(format string, a ...any) []Finding {
+		return []Finding{{Severity: ruleSeverity, Rule: rule.ID, Message: "Rule command failed: " + fmt.Sprintf(format, a...)}}
+	}
+
+	timeout := defaultCommandRuleTimeout
+	if d, err := time.ParseDuration(rule.Timeout); err == nil && d > 0 {
+		timeout = d
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	args := append(append([]string{}, rule.Command[1:]...), files...)
+	cmd := exec.CommandContext(ctx, rule.Command[0], args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = time.Second
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return failed("timed out after %s", timeout)
+	}
+	if exitErr, isExit := err.(*exec.ExitError); err != nil && (!isExit || exitErr.ExitCode() != 1) {
+		return failed("%v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	reported, perr := parseCommandFindings(stdout.Bytes())
+	if perr != nil {
+		return failed("%v", perr)
+	}
+	if err != nil && len(reported) == 0 {
+		return failed("exit status 1 without findings: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	findings := make([]Finding, 0, len(reported))
+	for _, r := range reported {
+		severity := ruleSeverity
+		if r.Severity != "" {
+			if severity, ok = s.severityFor(r.Severity); !ok {
+				continue
+			}
+		}
+		findings = append(findings, Finding{
+			Severity: severity,
+			Rule:     rule.ID,
+			File:     r.File,
+			Line:     r.Line,
+			Column:   r.Column,
+			Message:  firstNonEmpty(r.Message, ruleMessage(rule, "External rule finding")),
+		})
+	}
+	return findings
+}
+
+// parseCommandFindings decodes command rule output; empty output means no findings.
+func parseCommandFindings(out []byte) ([]CommandFinding, error) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil, nil
+	}
+	if out[0] == '[' {
+		var list []CommandFinding
+		if err := json.Unmarshal(out, &list); err != nil {
+			return nil, fmt.Errorf("invalid findings JSON: %w", err)
+		}
+		return list, nil
+	}
+	var doc struct {
+		Findings []CommandFinding `json:"findings"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		return nil, fmt.Errorf("invalid findings JSON: %w", err)
+	}
+	return doc.Findings, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
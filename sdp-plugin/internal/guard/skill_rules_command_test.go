@@ -0,0 +1,82 @@
+package guard
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// writeRuleScript writes an executable shell script for a command rule.
+func writeRuleScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported")
+	}
+	path := filepath.Join(t.TempDir(), "rule.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCheckCommandRule(t *testing.T) {
+	script := writeRuleScript(t, `echo '{"findings":[{"file":"'"$1"'","line":3,"column":2,"message":"bad call"},{"file":"'"$2"'","line":1,"severity":"info"}]}'
+exit 1`)
+	s := &Skill{severityMapping: map[string]string{"error": "block", "warning": "warn", "info": "log"}}
+	rule := config.GuardRule{ID: "ext", Type: config.GuardRuleCommand, Severity: "error", Command: []string{script}}
+
+	findings := s.checkCommandRule([]string{"a.go", "b.go"}, rule)
+	if len(findings) != 1 {
+		t.Fatalf("findings = %+v, want 1 (info is logged only)", findings)
+	}
+	f := findings[0]
+	if f.File != "a.go" || f.Line != 3 || f.Column != 2 || f.Severity != SeverityError || f.Message != "bad call" || f.Rule != "ext" {
+		t.Errorf("finding = %+v", f)
+	}
+}
+
+func TestCheckCommandRule_BareArray(t *testing.T) {
+	script := writeRuleScript(t, `echo '[{"file":"x.py","line":7,"severity":"warning"}]'`)
+	s := &Skill{}
+	rule := config.GuardRule{ID: "ext", Type: config.GuardRuleCommand, Severity: "error", Message: "custom", Command: []string{script}}
+
+	findings := s.checkCommandRule([]string{"x.py"}, rule)
+	if len(findings) != 1 || findings[0].Severity != SeverityWarning || findings[0].Message != "custom" {
+		t.Errorf("findings = %+v, want one warning with rule message", findings)
+	}
+}
+
+func TestCheckCommandRule_Failures(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"crash", "echo oops >&2; exit 2", "oops"},
+		{"invalid json", "echo not-json", "invalid findings JSON"},
+		{"exit 1 without findings", "exit 1", "without findings"},
+		{"timeout", "exec sleep 5", "timed out"},
+	}
+	s := &Skill{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := config.GuardRule{ID: "ext", Severity: "warning", Timeout: "200ms", Command: []string{writeRuleScript(t, tt.body)}}
+			findings := s.checkCommandRule([]string{"a.go"}, rule)
+			if len(findings) != 1 || !strings.Contains(findings[0].Message, tt.want) || findings[0].Severity != SeverityWarning {
+				t.Errorf("findings = %+v, want one warning containing %q", findings, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCommandRule_NoFindings(t *testing.T) {
+	s := &Skill{}
+	rule := config.GuardRule{ID: "ext", Severity: "error", Command: []string{writeRuleScript(t, "exit 0")}}
+	if findings := s.checkCommandRule([]string{"a.go"}, rule); len(findings) != 0 {
+		t.Errorf("findings = %+v, want none", findings)
+	}
+}
@@ -0,0 +1,217 @@
+package guard
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// checkGoASTRule evaluates a go-ast rule query against a Go source file:
+//
+//	call:panic                 calls to a builtin or local function
+//	call:fmt.Println           calls to a function of an imported package
+//	import:github.com/x/y      imports of a package or its subpackages
+//	exported-func-without-doc  exported functions and methods without a doc
+//	                           comment that are new relative to the base revision
+func (s *Skill) checkGoASTRule(file string, content []byte, rule config.GuardRule) []Finding {
+	if !strings.HasSuffix(file, ".go") {
+		return nil
+	}
+	severity, ok := s.severityFor(rule.Severity)
+	if !ok {
+		return nil
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, content, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		// Unparseable files are reported by the compiler, not the guard.
+		return nil
+	}
+
+	var hits []astHit
+	switch {
+	case strings.HasPrefix(rule.Query, "call:"):
+		hits = findCalls(f, strings.TrimPrefix(rule.Query, "call:"))
+	case strings.HasPrefix(rule.Query, "import:"):
+		hits = findImports(f, strings.TrimPrefix(rule.Query, "import:"))
+	case rule.Query == "exported-func-without-doc":
+		if strings.HasSuffix(file, "_test.go") {
+			return nil
+		}
+		hits = findUndocumentedFuncs(f, s.existingFuncs(file))
+	default:
+		return []Finding{{Severity: severity, Rule: rule.ID, File: file, Message: fmt.Sprintf("invalid go-ast query %q", rule.Query)}}
+	}
+
+	findings := make([]Finding, 0, len(hits))
+	for _, h := range hits {
+		pos := fset.Position(h.pos)
+		findings = append(findings, Finding{
+			Severity: severity,
+			Rule:     rule.ID,
+			File:     file,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Message:  ruleMessage(rule, h.message),
+		})
+	}
+	return findings
+}
+
+// astHit is a query match before it becomes a Finding.
+type astHit struct {
+	pos     token.Pos
+	message string
+}
+
+// findCalls finds calls to target: a bare name, or an import path and
+// function name separated by the last dot (resolving import aliases).
+func findCalls(f *ast.File, target string) []astHit {
+	pkgPath, name := "", target
+	if i := strings.LastIndex(target, "."); i >= 0 {
+		pkgPath, name = target[:i], target[i+1:]
+	}
+	local := ""
+	if pkgPath != "" {
+		local = importName(f, pkgPath)
+		if local == "" || local == "_" {
+			return nil
+		}
+	}
+
+	var hits []astHit
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			if pkgPath == "" && fun.Name == name {
+				hits = append(hits, astHit{call.Pos(), fmt.Sprintf("Call to %s", target)})
+			}
+		case *ast.SelectorExpr:
+			if id, ok := fun.X.(*ast.Ident); ok && pkgPath != "" && fun.Sel.Name == name && (id.Name == local || local == ".") {
+				hits = append(hits, astHit{call.Pos(), fmt.Sprintf("Call to %s", target)})
+			}
+		}
+		return true
+	})
+	return hits
+}
+
+// importName returns the name pkgPath is imported under in f, or "".
+func importName(f *ast.File, pkgPath string) string {
+	for _, imp := range f.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || p != pkgPath {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return path.Base(p)
+	}
+	return ""
+}
+
+// findImports finds imports of pkgPath or any package below it.
+func findImports(f *ast.File, pkgPath string) []astHit {
+	var hits []astHit
+	for _, imp := range f.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if p == pkgPath || strings.HasPrefix(p, pkgPath+"/") {
+			hits = append(hits, astHit{imp.Pos(), fmt.Sprintf("Import of %s", p)})
+		}
+	}
+	return hits
+}
+
+// findUndocumentedFuncs finds exported functions and methods on exported
+// types that have no doc comment and are not in existing.
+func findUndocumentedFuncs(f *ast.File, existing map[string]bool) []astHit {
+	var hits []astHit
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Doc != nil || !fn.Name.IsExported() {
+			continue
+		}
+		if recv := recvName(fn); fn.Recv != nil && !ast.IsExported(recv) {
+			continue
+		}
+		key := funcKey(fn)
+		if existing[key] {
+			continue
+		}
+		hits = append(hits, astHit{fn.Pos(), fmt.Sprintf("Exported function %s has no doc comment", key)})
+	}
+	return hits
+}
+
+// funcKey returns "Name" for functions and "Recv.Name" for methods.
+func funcKey(fn *ast.FuncDecl) string {
+	if fn.Recv == nil {
+		return fn.Name.Name
+	}
+	return recvName(fn) + "." + fn.Name.Name
+}
+
+// recvName returns the receiver type name of a method, or "".
+func recvName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	t := fn.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch r := t.(type) {
+	case *ast.IndexExpr:
+		t = r.X
+	case *ast.IndexListExpr:
+		t = r.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+// existingFuncs returns the function keys declared in file at the base
+// revision, so only newly added declarations are reported. git runs in
+// the project root, where staged paths are resolved.
+func (s *Skill) existingFuncs(file string) map[string]bool {
+	ref := s.baseRef
+	if ref == "" {
+		ref = "HEAD"
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command("git", "show", ref+":"+strings.TrimPrefix(file, "./"))
+	cmd.Dir = s.projectRoot
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return nil // new file or not a git repository
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), file, stdout.Bytes(), parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+	existing := make(map[string]bool)
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			existing[funcKey(fn)] = true
+		}
+	}
+	return existing
+}
@@ -0,0 +1,126 @@
+package guard
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+const goastSource = `package store
+
+import (
+	out "fmt"
+	"os/exec"
+)
+
+// Documented is fine.
+func Documented() {}
+
+func Undocumented() {
+	out.Println("x")
+	panic("boom")
+}
+
+type store struct{}
+
+func (s *store) Hidden() {}
+
+type Store struct{}
+
+func (s *Store) Open() { _ = exec.Command("ls") }
+`
+
+func TestCheckGoASTRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantLines []int
+	}{
+		{"builtin call", "call:panic", []int{13}},
+		{"aliased package call", "call:fmt.Println", []int{12}},
+		{"not imported", "call:log.Println", nil},
+		{"import", "import:os", []int{5}},
+		{"exported without doc", "exported-func-without-doc", []int{11, 22}},
+	}
+	s := &Skill{baseRef: "refs/does/not/exist"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := config.GuardRule{ID: "ast", Type: config.GuardRuleGoAST, Severity: "error", Query: tt.query}
+			findings := s.checkGoASTRule("internal/store/store.go", []byte(goastSource), rule)
+			if len(findings) != len(tt.wantLines) {
+				t.Fatalf("findings = %+v, want lines %v", findings, tt.wantLines)
+			}
+			for i, f := range findings {
+				if f.Line != tt.wantLines[i] {
+					t.Errorf("finding %d at line %d, want %d", i, f.Line, tt.wantLines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckGoASTRule_SkipsNonGoAndTests(t *testing.T) {
+	s := &Skill{}
+	rule := config.GuardRule{ID: "ast", Type: config.GuardRuleGoAST, Severity: "error", Query: "exported-func-without-doc"}
+	if f := s.checkGoASTRule("store_test.go", []byte(goastSource), rule); len(f) != 0 {
+		t.Errorf("test file findings = %+v, want none", f)
+	}
+	rule.Query = "call:panic"
+	if f := s.checkGoASTRule("store.py", []byte("panic()"), rule); len(f) != 0 {
+		t.Errorf("non-Go findings = %+v, want none", f)
+	}
+}
+
+func TestFindUndocumentedFuncs_OnlyNew(t *testing.T) {
+	s := &Skill{baseRef: "refs/does/not/exist"}
+	rule := config.GuardRule{ID: "ast", Type: config.GuardRuleGoAST, Severity: "warning", Query: "exported-func-without-doc"}
+	all := s.checkGoASTRule("store.go", []byte(goastSource), rule)
+	if len(all) != 2 {
+		t.Fatalf("findings = %d, want 2", len(all))
+	}
+
+	f := parseGoForTest(t, goastSource)
+	hits := findUndocumentedFuncs(f, map[string]bool{"Undocumented": true})
+	if len(hits) != 1 || hits[0].message != "Exported function Store.Open has no doc comment" {
+		t.Errorf("hits = %+v, want only Store.Open", hits)
+	}
+}
+
+func TestExistingFuncs_ReadsBaseFromProjectRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "store.go"), []byte("package store\n\nfunc Undocumented() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "store.go"},
+		{"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "-m", "base"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	// The test runs outside root, so git must not use the working directory.
+	s := &Skill{projectRoot: root}
+	if got := s.existingFuncs("store.go"); !got["Undocumented"] {
+		t.Errorf("existingFuncs = %v, want the committed Undocumented", got)
+	}
+}
+
+func parseGoForTest(t *testing.T, src string) *ast.File {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "x.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
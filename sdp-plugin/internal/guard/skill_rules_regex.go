@@ -0,0 +1,40 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// checkRegexRule reports each line of file matching rule.Pattern.
+func (s *Skill) checkRegexRule(file string, content []byte, rule config.GuardRule) []Finding {
+	severity, ok := s.severityFor(rule.Severity)
+	if !ok {
+		return nil
+	}
+	re, err := regexp.Compile(rule.Pattern)
+	if err != nil {
+		// LoadGuardRules validates patterns; rules built in code may not be.
+		return []Finding{{Severity: severity, Rule: rule.ID, File: file, Message: fmt.Sprintf("invalid rule pattern: %v", err)}}
+	}
+
+	var findings []Finding
+	message := ruleMessage(rule, fmt.Sprintf("Line matches forbidden pattern %q", rule.Pattern))
+	for i, line := range strings.Split(string(content), "\n") {
+		loc := re.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		findings = append(findings, Finding{
+			Severity: severity,
+			Rule:     rule.ID,
+			File:     file,
+			Line:     i + 1,
+			Column:   loc[0] + 1,
+			Message:  message,
+		})
+	}
+	return findings
+}
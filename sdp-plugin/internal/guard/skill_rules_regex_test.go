@@ -0,0 +1,70 @@
+package guard
+
+import (
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+func TestCheckRegexRule(t *testing.T) {
+	s := &Skill{}
+	rule := config.GuardRule{ID: "no-debug", Type: config.GuardRuleRegex, Severity: "error", Pattern: `debugger|console\.log`}
+	content := []byte("a := 1\n  console.log(a)\nok()\nx; debugger\n")
+
+	findings := s.checkRegexRule("web/app.js", content, rule)
+	if len(findings) != 2 {
+		t.Fatalf("findings = %d, want 2: %+v", len(findings), findings)
+	}
+	if f := findings[0]; f.Line != 2 || f.Column != 3 || f.Severity != SeverityError || f.Rule != "no-debug" {
+		t.Errorf("first finding = %+v, want line 2 column 3 error", f)
+	}
+	if findings[1].Line != 4 || findings[1].Column != 4 {
+		t.Errorf("second finding at %d:%d, want 4:4", findings[1].Line, findings[1].Column)
+	}
+	if findings[0].Message == "" {
+		t.Error("finding message is empty")
+	}
+}
+
+func TestRuleAppliesTo(t *testing.T) {
+	rule := config.GuardRule{Paths: []string{"internal/**/*.go"}, Exclude: []string{"**/*_test.go"}}
+	tests := []struct {
+		file string
+		want bool
+	}{
+		{"internal/guard/skill.go", true},
+		{"./internal/guard/skill.go", true},
+		{"internal/guard/skill_test.go", false},
+		{"cmd/sdp/main.go", false},
+	}
+	for _, tt := range tests {
+		if got := ruleAppliesTo(rule, tt.file); got != tt.want {
+			t.Errorf("ruleAppliesTo(%q) = %v, want %v", tt.file, got, tt.want)
+		}
+	}
+	cmdOnly := config.GuardRule{Exclude: []string{"**/cmd/**"}}
+	if ruleAppliesTo(cmdOnly, "sdp-plugin/cmd/sdp/main.go") || !ruleAppliesTo(cmdOnly, "sdp-plugin/internal/x.go") {
+		t.Error("**/cmd/** should exclude files under any cmd directory only")
+	}
+	if !ruleAppliesTo(config.GuardRule{}, "any/file.py") {
+		t.Error("rule without paths should apply to every file")
+	}
+}
+
+func TestSeverityFor(t *testing.T) {
+	mapped := &Skill{severityMapping: map[string]string{"error": "block", "warning": "block", "info": "log"}}
+	if sev, ok := mapped.severityFor("warning"); !ok || sev != SeverityError {
+		t.Errorf("mapped warning = %q, %v; want error", sev, ok)
+	}
+	if _, ok := mapped.severityFor("info"); ok {
+		t.Error("info mapped to log should be dropped")
+	}
+
+	unmapped := &Skill{}
+	if sev, _ := unmapped.severityFor("error"); sev != SeverityError {
+		t.Errorf("unmapped error = %q, want error", sev)
+	}
+	if sev, _ := unmapped.severityFor("info"); sev != SeverityWarning {
+		t.Errorf("unmapped info = %q, want warning", sev)
+	}
+}
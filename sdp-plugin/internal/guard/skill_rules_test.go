@@ -519,3 +519,33 @@ func generateLines(n int) string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// TestApplyGuardRules_PluginRules tests path filtering and dispatch of regex and go-ast rules
+func TestApplyGuardRules_PluginRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	files := map[string]string{
+		"internal/store/store.go": "package store\n\nfunc f() { panic(\"x\") }\n",
+		"cmd/tool/main.go":        "package main\n\nfunc main() { panic(\"x\") }\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rules := &config.GuardRules{Rules: []config.GuardRule{
+		{ID: "no-panic-in-internal", Enabled: true, Severity: "error", Type: config.GuardRuleGoAST, Query: "call:panic", Paths: []string{"internal/**"}},
+		{ID: "no-panic-text", Enabled: false, Severity: "error", Type: config.GuardRuleRegex, Pattern: "panic"},
+	}}
+
+	findings := (&Skill{}).applyGuardRules([]string{"internal/store/store.go", "cmd/tool/main.go"}, rules)
+	if len(findings) != 1 {
+		t.Fatalf("findings = %+v, want 1", findings)
+	}
+	if f := findings[0]; f.File != "internal/store/store.go" || f.Line != 3 || f.Rule != "no-panic-in-internal" {
+		t.Errorf("finding = %+v", f)
+	}
+}
@@ -51,6 +51,14 @@ func (s *Skill) StagedCheck(opts CheckOptions) (*CheckResult, error) {
 		}, fmt.Errorf("failed to load guard rules: %w", err)
 	}
 
+	// Severity mapping applies to all rule findings (block/warn/log)
+	if cfg, err := config.Load(projectRoot); err == nil {
+		s.severityMapping = cfg.Guard.SeverityMapping
+	}
+	// New-code rules compare against the CI base when given, HEAD otherwise
+	s.baseRef = opts.Base
+	s.projectRoot = projectRoot
+
 	// Get staged files (pass options for CI diff-range support)
 	stagedFiles, err := getStagedFiles(opts)
 	if err != nil {
@@ -116,8 +124,23 @@ func (s *Skill) StagedCheck(opts CheckOptions) (*CheckResult, error) {
 	// Apply guard rules to staged files (AC1)
 	findings = append(findings, s.applyGuardRules(stagedFiles, guardRules)...)
 
-	// Build result with hybrid mode enforcement (AC8)
-	return BuildCheckResult(findings), nil
+	// Build result with exceptions and hybrid mode enforcement (AC8)
+	return BuildCheckResultWithExceptions(findings, guardExceptions(guardRules.Exceptions)), nil
+}
+
+// guardExceptions converts configured exceptions to the guard model.
+func guardExceptions(configured []config.GuardException) []Exception {
+	exceptions := make([]Exception, 0, len(configured))
+	for _, e := range configured {
+		exceptions = append(exceptions, Exception{
+			RuleID:    e.RuleID,
+			PathGlob:  e.PathGlob,
+			Reason:    e.Reason,
+			Owner:     e.Owner,
+			ExpiresAt: e.ExpiresAt,
+		})
+	}
+	return exceptions
 }
 
 // BuildCheckResult builds a check result with hybrid mode enforcement (AC8: block on ERROR, warn on WARNING)
@@ -5,6 +5,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/config"
 )
 
 // TestStagedCheck tests AC1: staged checks
@@ -479,3 +481,17 @@ func runCommand(dir string, name string, args ...string) error {
 	cmd.Dir = dir
 	return cmd.Run()
 }
+
+// TestGuardExceptions tests that configured exceptions suppress matching findings
+func TestGuardExceptions(t *testing.T) {
+	exceptions := guardExceptions([]config.GuardException{
+		{RuleID: "no-panic-in-internal", PathGlob: "internal/legacy/**", Reason: "migration", Owner: "core", ExpiresAt: "2999-01-01T00:00:00Z"},
+	})
+	result := BuildCheckResultWithExceptions([]Finding{
+		{Severity: SeverityError, Rule: "no-panic-in-internal", File: "internal/legacy/old.go", Line: 4},
+		{Severity: SeverityError, Rule: "no-panic-in-internal", File: "internal/store/store.go", Line: 9},
+	}, exceptions)
+	if result.Summary.Errors != 1 || result.Summary.AppliedExceptions != 1 {
+		t.Errorf("summary = %+v, want 1 error and 1 applied exception", result.Summary)
+	}
+}
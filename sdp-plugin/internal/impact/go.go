@@ -0,0 +1,186 @@
+package impact
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/gomod"
+)
+
+// GoProvider selects Go packages whose tests depend on the changed files,
+// using the import graph reported by `go list -deps -json ./...`.
+type GoProvider struct{}
+
+// Name implements Provider.
+func (GoProvider) Name() string { return "go" }
+
+// Detect implements Provider: dir must be inside a Go module.
+func (GoProvider) Detect(dir string) bool {
+	_, err := gomod.Root(dir)
+	return err == nil
+}
+
+// Command implements Provider.
+func (GoProvider) Command(sel *Selection) []string {
+	if sel.Full {
+		return []string{"go", "test", "./..."}
+	}
+	return append([]string{"go", "test"}, sel.Targets...)
+}
+
+type goPackage struct {
+	ImportPath   string
+	Dir          string
+	Standard     bool
+	DepOnly      bool
+	Module       *struct{ Main bool }
+	TestGoFiles  []string
+	XTestGoFiles []string
+	EmbedFiles   []string
+	Deps         []string
+	TestImports  []string
+	XTestImports []string
+}
+
+// Select implements Provider. A package is affected when one of its files
+// (including tests, embedded files and testdata) changed, or when it or its
+// tests import an affected package, directly or transitively. A change to
+// go.mod, go.sum or go.work selects everything.
+func (GoProvider) Select(ctx context.Context, dir string, changed []string) (*Selection, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	sel := &Selection{Provider: "go", Dir: dir}
+	modRoot, err := gomod.Root(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range changed {
+		base := filepath.Base(f)
+		if (base == "go.mod" || base == "go.sum") && filepath.Dir(f) == modRoot ||
+			strings.HasPrefix(base, "go.work") && within(filepath.Dir(f), modRoot) {
+			sel.Full, sel.Reason = true, base+" changed"
+			return sel, nil
+		}
+	}
+
+	pkgs, err := goListDeps(ctx, dir)
+	if err != nil {
+		sel.Full, sel.Reason = true, err.Error()
+		return sel, nil
+	}
+
+	local := make(map[string]*goPackage)
+	for _, p := range pkgs {
+		if !p.Standard && p.Module != nil && p.Module.Main {
+			local[p.ImportPath] = p
+		}
+	}
+
+	// Packages owning a changed file.
+	touched := make(map[string]bool)
+	for _, f := range changed {
+		for _, p := range owningPackages(local, f) {
+			touched[p.ImportPath] = true
+		}
+	}
+
+	reaches := func(path string) bool {
+		if touched[path] {
+			return true
+		}
+		p, ok := local[path]
+		if !ok {
+			return false
+		}
+		for _, d := range p.Deps {
+			if touched[d] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, p := range local {
+		if p.DepOnly || !hasTests(p) {
+			continue
+		}
+		affected := reaches(p.ImportPath)
+		for _, imp := range append(append([]string(nil), p.TestImports...), p.XTestImports...) {
+			affected = affected || reaches(imp)
+		}
+		if !affected {
+			continue
+		}
+		rel, err := filepath.Rel(dir, p.Dir)
+		if err != nil {
+			continue
+		}
+		target := "."
+		if rel != "." {
+			target = "./" + filepath.ToSlash(rel)
+		}
+		sel.Targets = append(sel.Targets, target)
+	}
+	sort.Strings(sel.Targets)
+	return sel, nil
+}
+
+func goListDeps(ctx context.Context, dir string) ([]*goPackage, error) {
+	cmd := exec.CommandContext(ctx, "go", "list", "-deps", "-e", "-json", "./...")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var pkgs []*goPackage
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var p goPackage
+		if err := dec.Decode(&p); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("go list output: %w", err)
+		}
+		pkgs = append(pkgs, &p)
+	}
+	return pkgs, nil
+}
+
+// owningPackages returns the packages file belongs to: the package in its
+// directory, packages embedding it, and the package owning a testdata tree
+// containing it.
+func owningPackages(local map[string]*goPackage, file string) []*goPackage {
+	var out []*goPackage
+	for _, p := range local {
+		switch {
+		case p.Dir == filepath.Dir(file):
+			out = append(out, p)
+		case within(filepath.Join(p.Dir, "testdata"), file):
+			out = append(out, p)
+		default:
+			for _, e := range p.EmbedFiles {
+				if filepath.Join(p.Dir, e) == file {
+					out = append(out, p)
+					break
+				}
+			}
+		}
+	}
+	return out
+}
+
+func hasTests(p *goPackage) bool {
+	return len(p.TestGoFiles)+len(p.XTestGoFiles) > 0
+}
@@ -0,0 +1,96 @@
+package impact
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func goModule(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	return writeTree(t, map[string]string{
+		"go.mod":              "module example.com/m\n\ngo 1.21\n",
+		"a/a.go":              "package a\n\nfunc A() int { return 1 }\n",
+		"b/b.go":              "package b\n\nimport \"example.com/m/a\"\n\nfunc B() int { return a.A() }\n",
+		"b/b_test.go":         "package b\n\nimport \"testing\"\n\nfunc TestB(t *testing.T) { _ = B() }\n",
+		"c/c.go":              "package c\n\nfunc C() {}\n",
+		"c/c_test.go":         "package c\n\nimport \"testing\"\n\nfunc TestC(t *testing.T) { C() }\n",
+		"c/testdata/in.txt":   "fixture\n",
+		"d/d.go":              "package d\n",
+		"d/d_test.go":         "package d_test\n\nimport (\n\t\"testing\"\n\n\t\"example.com/m/a\"\n)\n\nfunc TestD(t *testing.T) { _ = a.A() }\n",
+		"e/e.go":              "package e\n\nimport \"example.com/m/b\"\n\nfunc E() int { return b.B() }\n",
+		"e/e_test.go":         "package e\n\nimport \"testing\"\n\nfunc TestE(t *testing.T) { _ = E() }\n",
+		"docs/readme.md":      "docs\n",
+		"a/internal/x/x.go":   "package x\n",
+		"a/internal/x/doc.md": "x\n",
+	})
+}
+
+func TestGoProvider_Select(t *testing.T) {
+	dir := goModule(t)
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+		full    bool
+	}{
+		{"transitive and test imports", []string{"a/a.go"}, []string{"./b", "./d", "./e"}, false},
+		{"own package", []string{"c/c.go"}, []string{"./c"}, false},
+		{"testdata", []string{"c/testdata/in.txt"}, []string{"./c"}, false},
+		{"test file", []string{"e/e_test.go"}, []string{"./e"}, false},
+		{"unrelated file", []string{"docs/readme.md"}, nil, false},
+		{"go.mod", []string{"go.mod"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := GoProvider{}.Select(context.Background(), dir, absPaths(dir, tt.changed))
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			if sel.Full != tt.full {
+				t.Fatalf("Full = %v (%s), want %v", sel.Full, sel.Reason, tt.full)
+			}
+			if !reflect.DeepEqual(sel.Targets, tt.want) {
+				t.Errorf("Targets = %v, want %v", sel.Targets, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoProvider_SubdirAndCommand(t *testing.T) {
+	dir := goModule(t)
+	sel, err := GoProvider{}.Select(context.Background(), filepath.Join(dir, "b"), absPaths(dir, []string{"a/a.go"}))
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if !reflect.DeepEqual(sel.Targets, []string{"."}) {
+		t.Errorf("Targets = %v, want only the package under the subdirectory", sel.Targets)
+	}
+	if got := (GoProvider{}).Command(&Selection{Targets: []string{"./b"}}); !reflect.DeepEqual(got, []string{"go", "test", "./b"}) {
+		t.Errorf("Command = %v", got)
+	}
+	if got := (GoProvider{}).Command(&Selection{Full: true}); !reflect.DeepEqual(got, []string{"go", "test", "./..."}) {
+		t.Errorf("full Command = %v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package impact
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// skipDirs are never scanned for sources.
+var skipDirs = map[string]bool{
+	".git": true, "node_modules": true, ".venv": true, "venv": true,
+	"__pycache__": true, ".tox": true, "dist": true, "build": true,
+	"vendor": true, "coverage": true,
+}
+
+// fileGraph maps an absolute source path to the source files it imports.
+type fileGraph map[string][]string
+
+// dependents returns the files that import one of changed, directly or
+// transitively, including the changed files themselves.
+func (g fileGraph) dependents(changed []string) map[string]bool {
+	reverse := make(map[string][]string)
+	for file, deps := range g {
+		for _, d := range deps {
+			reverse[d] = append(reverse[d], file)
+		}
+	}
+	seen := make(map[string]bool)
+	queue := append([]string(nil), changed...)
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		queue = append(queue, reverse[f]...)
+	}
+	return seen
+}
+
+// walkSources returns absolute paths of files under dir with one of exts.
+func walkSources(dir string, exts []string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if slices.Contains(exts, filepath.Ext(path)) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// selectFiles builds a Selection from a file graph: tests reaching a
+// changed file, as paths relative to dir.
+func selectFiles(provider, dir string, graph fileGraph, tests []string, changed []string) *Selection {
+	sel := &Selection{Provider: provider, Dir: dir}
+	affected := graph.dependents(changed)
+	for _, t := range tests {
+		if !affected[t] {
+			continue
+		}
+		if rel, err := filepath.Rel(dir, t); err == nil {
+			sel.Targets = append(sel.Targets, filepath.ToSlash(rel))
+		}
+	}
+	sort.Strings(sel.Targets)
+	return sel
+}
+
+// manifestChanged returns the first changed file directly in dir whose base
+// name is listed in manifests.
+func manifestChanged(dir string, changed []string, manifests func(string) bool) string {
+	for _, f := range changed {
+		if filepath.Dir(f) == dir && manifests(filepath.Base(f)) {
+			return filepath.Base(f)
+		}
+	}
+	return ""
+}
+
+func readFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,173 @@
+// Package impact selects the tests affected by a set of changed files, so
+// whole-suite test commands can be narrowed to the packages or test files
+// that depend on the change.
+package impact
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Selection is the set of tests one provider considers affected.
+type Selection struct {
+	Provider string
+	Dir      string // absolute directory the selection was computed for
+	// Full means every test must run, e.g. because a dependency manifest
+	// changed or the import graph could not be built. Reason explains why.
+	Full   bool
+	Reason string
+	// Targets are provider-specific test targets relative to the analysed
+	// directory: Go package patterns ("./internal/x") or test file paths.
+	Targets []string
+}
+
+// Provider computes affected tests for one language ecosystem.
+type Provider interface {
+	// Name identifies the provider ("go", "python", "js").
+	Name() string
+	// Detect reports whether the provider applies to dir.
+	Detect(dir string) bool
+	// Select returns the tests under dir affected by the changed files.
+	// Changed paths are absolute.
+	Select(ctx context.Context, dir string, changed []string) (*Selection, error)
+	// Command returns the default command running sel's targets.
+	Command(sel *Selection) []string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = []Provider{GoProvider{}, PythonProvider{}, JSProvider{}}
+)
+
+// Register adds a provider, replacing any provider with the same name.
+func Register(p Provider) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for i, existing := range registry {
+		if existing.Name() == p.Name() {
+			registry[i] = p
+			return
+		}
+	}
+	registry = append(registry, p)
+}
+
+// Providers returns the registered providers.
+func Providers() []Provider {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Provider(nil), registry...)
+}
+
+// Command returns the default command running sel, built by the provider
+// that produced it, or nil when that provider is no longer registered.
+func Command(sel *Selection) []string {
+	for _, p := range Providers() {
+		if p.Name() == sel.Provider {
+			return p.Command(sel)
+		}
+	}
+	return nil
+}
+
+// Analyze runs every registered provider that detects dir. Relative changed
+// paths resolve against dir.
+func Analyze(ctx context.Context, dir string, changed []string) ([]*Selection, error) {
+	changed = absPaths(dir, changed)
+	var out []*Selection
+	for _, p := range Providers() {
+		if !p.Detect(dir) {
+			continue
+		}
+		sel, err := p.Select(ctx, dir, changed)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name(), err)
+		}
+		out = append(out, sel)
+	}
+	return out, nil
+}
+
+// ChangedFiles returns absolute paths of files changed in the git work tree
+// at root: relative to the merge base with base when base is set, otherwise
+// relative to HEAD. Untracked files are included.
+func ChangedFiles(root, base string) ([]string, error) {
+	rev := "HEAD"
+	if base != "" {
+		out, err := git(root, "merge-base", "HEAD", base)
+		if err != nil {
+			return nil, fmt.Errorf("merge-base HEAD %s: %w", base, err)
+		}
+		rev = strings.TrimSpace(out)
+	}
+	top, err := git(root, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top = strings.TrimSpace(top)
+
+	diff, err := git(root, "diff", "--name-only", rev)
+	if err != nil {
+		return nil, fmt.Errorf("diff %s: %w", rev, err)
+	}
+	untracked, err := git(root, "ls-files", "--others", "--exclude-standard", "--full-name")
+	if err != nil {
+		return nil, fmt.Errorf("ls-files: %w", err)
+	}
+	seen := make(map[string]bool)
+	var files []string
+	for line := range strings.SplitSeq(diff+"\n"+untracked, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		files = append(files, filepath.Join(top, filepath.FromSlash(line)))
+	}
+	return files, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var stderr string
+		if ee, ok := err.(*exec.ExitError); ok {
+			stderr = strings.TrimSpace(string(ee.Stderr))
+		}
+		return "", fmt.Errorf("git %s: %v %s", args[0], err, stderr)
+	}
+	return string(out), nil
+}
+
+// absPaths resolves paths against dir.
+func absPaths(dir string, paths []string) []string {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		if abs, err := filepath.Abs(p); err == nil {
+			p = abs
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// within reports whether path is dir or below it.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,113 @@
+package impact
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// JSProvider selects JavaScript/TypeScript test files that import a changed
+// module through relative imports, directly or transitively.
+type JSProvider struct{}
+
+// Name implements Provider.
+func (JSProvider) Name() string { return "js" }
+
+// Detect implements Provider.
+func (JSProvider) Detect(dir string) bool {
+	return exists(filepath.Join(dir, "package.json"))
+}
+
+// Command implements Provider. Vitest is used when package.json mentions it,
+// otherwise Jest.
+func (JSProvider) Command(sel *Selection) []string {
+	cmd := []string{"npx", "jest"}
+	if strings.Contains(readFile(filepath.Join(sel.Dir, "package.json")), `"vitest"`) {
+		cmd = []string{"npx", "vitest", "run"}
+	}
+	if sel.Full {
+		return cmd
+	}
+	return append(cmd, sel.Targets...)
+}
+
+var jsExts = []string{".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs"}
+
+var jsImportRes = []*regexp.Regexp{
+	regexp.MustCompile(`(?:import|export)\s[^'"]*?from\s*['"]([^'"]+)['"]`),
+	regexp.MustCompile(`import\s*['"]([^'"]+)['"]`),
+	regexp.MustCompile(`(?:require|import)\s*\(\s*['"]([^'"]+)['"]\s*\)`),
+}
+
+// Select implements Provider. Changes to package manifests, lockfiles or
+// test runner configuration select everything.
+func (JSProvider) Select(_ context.Context, dir string, changed []string) (*Selection, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if m := manifestChanged(dir, changed, isJSManifest); m != "" {
+		return &Selection{Provider: "js", Dir: dir, Full: true, Reason: m + " changed"}, nil
+	}
+	files, err := walkSources(dir, jsExts)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(files))
+	for _, f := range files {
+		known[f] = true
+	}
+
+	graph := make(fileGraph)
+	var tests []string
+	for _, f := range files {
+		if isJSTest(f) {
+			tests = append(tests, f)
+		}
+		src := readFile(f)
+		for _, re := range jsImportRes {
+			for _, m := range re.FindAllStringSubmatch(src, -1) {
+				if target := resolveJSImport(known, f, m[1]); target != "" {
+					graph[f] = append(graph[f], target)
+				}
+			}
+		}
+	}
+	return selectFiles("js", dir, graph, tests, changed), nil
+}
+
+func isJSManifest(name string) bool {
+	switch name {
+	case "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "tsconfig.json", "babel.config.js":
+		return true
+	}
+	return strings.HasPrefix(name, "jest.config.") || strings.HasPrefix(name, "vitest.config.")
+}
+
+func isJSTest(path string) bool {
+	base := filepath.Base(path)
+	return strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		strings.Contains(filepath.ToSlash(path), "/__tests__/")
+}
+
+// resolveJSImport resolves a relative specifier the way bundlers do: exact
+// file, added extension, directory index, or a .js specifier naming a
+// TypeScript source.
+func resolveJSImport(known map[string]bool, from, spec string) string {
+	if !strings.HasPrefix(spec, ".") {
+		return ""
+	}
+	base := filepath.Join(filepath.Dir(from), filepath.FromSlash(spec))
+	candidates := []string{base}
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	for _, ext := range jsExts {
+		candidates = append(candidates, base+ext, filepath.Join(base, "index"+ext), stem+ext)
+	}
+	for _, c := range candidates {
+		if known[c] {
+			return c
+		}
+	}
+	return ""
+}
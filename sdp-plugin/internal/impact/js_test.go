@@ -0,0 +1,51 @@
+package impact
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestJSProvider_Select(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"package.json":             `{"devDependencies": {"vitest": "^1.0.0"}}`,
+		"src/a.ts":                 "export const a = 1\n",
+		"src/b.ts":                 "import { a } from './a.js'\nexport const b = a\n",
+		"src/b.test.ts":            "import { b } from \"./b\"\n",
+		"src/lib/index.js":         "module.exports = {}\n",
+		"src/c.js":                 "const lib = require('./lib')\n",
+		"src/__tests__/c.js":       "const c = require(\"../c\")\n",
+		"node_modules/x/x.test.js": "import '../../src/a'\n",
+		"src/unrelated.spec.tsx":   "import React from 'react'\n",
+	})
+	p := JSProvider{}
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+		full    bool
+	}{
+		{"ts source via .js specifier", []string{"src/a.ts"}, []string{"src/b.test.ts"}, false},
+		{"directory index", []string{"src/lib/index.js"}, []string{"src/__tests__/c.js"}, false},
+		{"lockfile", []string{"package-lock.json"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := p.Select(context.Background(), dir, absPaths(dir, tt.changed))
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			if sel.Full != tt.full {
+				t.Fatalf("Full = %v, want %v", sel.Full, tt.full)
+			}
+			if !reflect.DeepEqual(sel.Targets, tt.want) {
+				t.Errorf("Targets = %v, want %v", sel.Targets, tt.want)
+			}
+		})
+	}
+
+	sel := &Selection{Dir: dir, Targets: []string{"src/b.test.ts"}}
+	if got := p.Command(sel); !reflect.DeepEqual(got, []string{"npx", "vitest", "run", "src/b.test.ts"}) {
+		t.Errorf("Command = %v", got)
+	}
+}
@@ -0,0 +1,198 @@
+package impact
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PythonProvider selects pytest files that import a changed module, directly
+// or transitively, or that are governed by a changed conftest.py.
+type PythonProvider struct{}
+
+// Name implements Provider.
+func (PythonProvider) Name() string { return "python" }
+
+var pythonMarkers = []string{"pyproject.toml", "setup.py", "setup.cfg", "pytest.ini", "tox.ini"}
+
+// Detect implements Provider.
+func (PythonProvider) Detect(dir string) bool {
+	for _, m := range pythonMarkers {
+		if exists(filepath.Join(dir, m)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Command implements Provider.
+func (PythonProvider) Command(sel *Selection) []string {
+	if sel.Full {
+		return []string{"pytest"}
+	}
+	return append([]string{"pytest"}, sel.Targets...)
+}
+
+var (
+	pyImportRe = regexp.MustCompile(`^\s*import\s+(.+)$`)
+	pyFromRe   = regexp.MustCompile(`^\s*from\s+(\.*[\w.]*)\s+import\s+(.+)$`)
+)
+
+// Select implements Provider. Changes to packaging or pytest configuration
+// select everything.
+func (PythonProvider) Select(_ context.Context, dir string, changed []string) (*Selection, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if m := manifestChanged(dir, changed, isPythonManifest); m != "" {
+		return &Selection{Provider: "python", Dir: dir, Full: true, Reason: m + " changed"}, nil
+	}
+	files, err := walkSources(dir, []string{".py"})
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make(map[string]string)
+	var conftests, tests []string
+	for _, f := range files {
+		for _, name := range pythonModuleNames(dir, f) {
+			modules[name] = f
+		}
+		switch base := filepath.Base(f); {
+		case base == "conftest.py":
+			conftests = append(conftests, f)
+		case strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py"):
+			tests = append(tests, f)
+		}
+	}
+
+	graph := make(fileGraph)
+	for _, f := range files {
+		pkg := pythonPackage(dir, f)
+		for _, name := range pythonImports(readFile(f), pkg) {
+			if target, ok := modules[name]; ok && target != f {
+				graph[f] = append(graph[f], target)
+			}
+		}
+	}
+	for _, t := range tests {
+		for _, c := range conftests {
+			if within(filepath.Dir(c), t) {
+				graph[t] = append(graph[t], c)
+			}
+		}
+	}
+	return selectFiles("python", dir, graph, tests, changed), nil
+}
+
+func isPythonManifest(name string) bool {
+	switch name {
+	case "pyproject.toml", "setup.py", "setup.cfg", "pytest.ini", "tox.ini", "poetry.lock", "uv.lock":
+		return true
+	}
+	return strings.HasPrefix(name, "requirements") && strings.HasSuffix(name, ".txt")
+}
+
+// pythonModuleNames returns the dotted names a file can be imported as,
+// with and without a leading "src" layout directory.
+func pythonModuleNames(dir, file string) []string {
+	rel, err := filepath.Rel(dir, file)
+	if err != nil {
+		return nil
+	}
+	rel = strings.TrimSuffix(filepath.ToSlash(rel), ".py")
+	rel = strings.TrimSuffix(strings.TrimSuffix(rel, "__init__"), "/")
+	if rel == "" {
+		return nil
+	}
+	name := strings.ReplaceAll(rel, "/", ".")
+	names := []string{name}
+	if trimmed, ok := strings.CutPrefix(name, "src."); ok {
+		names = append(names, trimmed)
+	}
+	return names
+}
+
+// pythonPackage returns the dotted package containing file, used to resolve
+// relative imports.
+func pythonPackage(dir, file string) string {
+	rel, err := filepath.Rel(dir, filepath.Dir(file))
+	if err != nil || rel == "." {
+		return ""
+	}
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", ".")
+}
+
+// pythonImports lists the module names src imports, including parent
+// packages (whose __init__ runs on import) and "from X import name"
+// submodule candidates. Relative imports resolve against pkg.
+func pythonImports(src, pkg string) []string {
+	var names []string
+	addWithParents := func(name string) {
+		parts := strings.Split(name, ".")
+		for i := len(parts); i > 0; i-- {
+			names = append(names, strings.Join(parts[:i], "."))
+		}
+	}
+	for line := range strings.SplitSeq(src, "\n") {
+		if m := pyFromRe.FindStringSubmatch(line); m != nil {
+			from := resolveRelative(m[1], pkg)
+			if from == "" {
+				continue
+			}
+			addWithParents(from)
+			for _, item := range splitImportList(m[2]) {
+				names = append(names, from+"."+item)
+			}
+			continue
+		}
+		if m := pyImportRe.FindStringSubmatch(line); m != nil {
+			for _, item := range splitImportList(m[1]) {
+				addWithParents(item)
+			}
+		}
+	}
+	return names
+}
+
+func resolveRelative(name, pkg string) string {
+	dots := len(name) - len(strings.TrimLeft(name, "."))
+	if dots == 0 {
+		return name
+	}
+	parts := []string{}
+	if pkg != "" {
+		parts = strings.Split(pkg, ".")
+	}
+	if dots-1 > len(parts) {
+		return ""
+	}
+	base := strings.Join(parts[:len(parts)-(dots-1)], ".")
+	rest := name[dots:]
+	switch {
+	case base == "":
+		return rest
+	case rest == "":
+		return base
+	default:
+		return base + "." + rest
+	}
+}
+
+// splitImportList splits "a as b, c, (d" into bare names.
+func splitImportList(s string) []string {
+	if i := strings.Index(s, "#"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.NewReplacer("(", "", ")", "", "\\", "").Replace(s)
+	var out []string
+	for item := range strings.SplitSeq(s, ",") {
+		fields := strings.Fields(item)
+		if len(fields) > 0 && fields[0] != "*" {
+			out = append(out, fields[0])
+		}
+	}
+	return out
+}
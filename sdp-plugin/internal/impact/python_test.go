@@ -0,0 +1,66 @@
+package impact
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func pythonProject(t *testing.T) string {
+	return writeTree(t, map[string]string{
+		"pyproject.toml":        "[project]\nname = \"pkg\"\n",
+		"pkg/__init__.py":       "",
+		"pkg/core.py":           "def x():\n    return 1\n",
+		"pkg/util.py":           "from .core import x\n\ndef y():\n    return x()\n",
+		"pkg/extra.py":          "import os\n",
+		"tests/conftest.py":     "import pytest\n",
+		"tests/test_util.py":    "from pkg.util import y  # noqa\n",
+		"tests/test_other.py":   "import os, sys\n",
+		"tests/sub/test_pkg.py": "from pkg import (\n    extra,\n)\n",
+	})
+}
+
+func TestPythonProvider_Select(t *testing.T) {
+	dir := pythonProject(t)
+	p := PythonProvider{}
+	if !p.Detect(dir) {
+		t.Fatal("expected pyproject.toml to be detected")
+	}
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+		full    bool
+	}{
+		{"transitive relative import", []string{"pkg/core.py"}, []string{"tests/test_util.py"}, false},
+		{"package init", []string{"pkg/__init__.py"}, []string{"tests/sub/test_pkg.py", "tests/test_util.py"}, false},
+		{"conftest", []string{"tests/conftest.py"}, []string{"tests/sub/test_pkg.py", "tests/test_other.py", "tests/test_util.py"}, false},
+		{"test itself", []string{"tests/test_other.py"}, []string{"tests/test_other.py"}, false},
+		{"manifest", []string{"requirements-dev.txt"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := p.Select(context.Background(), dir, absPaths(dir, tt.changed))
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			if sel.Full != tt.full {
+				t.Fatalf("Full = %v, want %v", sel.Full, tt.full)
+			}
+			if !reflect.DeepEqual(sel.Targets, tt.want) {
+				t.Errorf("Targets = %v, want %v", sel.Targets, tt.want)
+			}
+		})
+	}
+}
+
+func TestPythonImports(t *testing.T) {
+	got := pythonImports("import a.b as c, d\nfrom ..e import f\nfrom . import g\n", "pkg.sub")
+	want := []string{"a.b", "a", "d", "pkg.e", "pkg", "pkg.e.f", "pkg.sub", "pkg", "pkg.sub.g"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pythonImports = %v, want %v", got, want)
+	}
+	if got := resolveRelative("...x", "a"); got != "" {
+		t.Errorf("resolveRelative beyond top level = %q, want empty", got)
+	}
+}
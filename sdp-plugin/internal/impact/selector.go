@@ -0,0 +1,191 @@
+package impact
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Selector narrows whole-suite test commands run in Dir to the tests
+// affected by Changed. Selections are computed once per provider.
+type Selector struct {
+	Dir     string
+	Changed []string
+
+	mu    sync.Mutex
+	cache map[string]*Selection
+}
+
+// NewSelector returns a Selector for commands run in dir. Relative changed
+// paths resolve against dir.
+func NewSelector(dir string, changed []string) *Selector {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return &Selector{Dir: dir, Changed: absPaths(dir, changed)}
+}
+
+// Rewrite returns args narrowed to the affected tests. Commands it does not
+// recognise, commands that already name specific tests, and full selections
+// are returned unchanged. skip is true when no test is affected.
+//
+// Recognised commands: `go test` with ./... style patterns, pytest (also via
+// python -m) with path arguments, and jest/vitest without arguments.
+func (s *Selector) Rewrite(ctx context.Context, args []string) (out []string, skip bool, err error) {
+	switch {
+	case len(args) >= 2 && args[0] == "go" && args[1] == "test":
+		return s.rewriteGo(ctx, args)
+	case len(args) >= 1 && (args[0] == "pytest" || args[0] == "py.test"):
+		return s.rewritePytest(ctx, args, 1)
+	case len(args) >= 3 && strings.HasPrefix(args[0], "python") && args[1] == "-m" && args[2] == "pytest":
+		return s.rewritePytest(ctx, args, 3)
+	default:
+		return s.rewriteJS(ctx, args)
+	}
+}
+
+// Selection returns the named provider's selection for Dir, or nil when the
+// provider does not apply.
+func (s *Selector) Selection(ctx context.Context, provider string) (*Selection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sel, ok := s.cache[provider]; ok {
+		return sel, nil
+	}
+	var sel *Selection
+	for _, p := range Providers() {
+		if p.Name() != provider || !p.Detect(s.Dir) {
+			continue
+		}
+		var err error
+		if sel, err = p.Select(ctx, s.Dir, s.Changed); err != nil {
+			return nil, err
+		}
+	}
+	if s.cache == nil {
+		s.cache = make(map[string]*Selection)
+	}
+	s.cache[provider] = sel
+	return sel, nil
+}
+
+// goTestValueFlags are `go test` and build flags that take a separate value.
+var goTestValueFlags = []string{
+	"-run", "-skip", "-bench", "-benchtime", "-count", "-cpu", "-parallel", "-timeout",
+	"-coverprofile", "-covermode", "-coverpkg", "-cpuprofile", "-memprofile", "-blockprofile",
+	"-mutexprofile", "-outputdir", "-trace", "-shuffle", "-fuzz", "-fuzztime", "-list",
+	"-tags", "-p", "-o", "-exec", "-ldflags", "-gcflags", "-mod", "-modfile", "-overlay", "-C",
+}
+
+func (s *Selector) rewriteGo(ctx context.Context, args []string) ([]string, bool, error) {
+	flags, patterns := splitArgs(args[2:], goTestValueFlags)
+	if len(patterns) == 0 {
+		return args, false, nil
+	}
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, ".") || !strings.HasSuffix(p, "...") {
+			return args, false, nil
+		}
+	}
+	sel, err := s.Selection(ctx, "go")
+	if err != nil || sel == nil || sel.Full {
+		return args, false, err
+	}
+	var targets []string
+	for _, t := range sel.Targets {
+		for _, p := range patterns {
+			prefix := strings.TrimSuffix(strings.TrimSuffix(p, "..."), "/")
+			if prefix == "." || t == prefix || strings.HasPrefix(t, prefix+"/") {
+				targets = append(targets, t)
+				break
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil, true, nil
+	}
+	out := append([]string{"go", "test"}, flags...)
+	return append(out, targets...), false, nil
+}
+
+// pytestValueFlags are pytest flags that take a separate value.
+var pytestValueFlags = []string{
+	"-k", "-m", "-c", "-p", "-o", "-n", "--rootdir", "--ignore", "--deselect", "--maxfail",
+	"--junitxml", "--cov", "--cov-report", "--cov-fail-under", "--durations", "--basetemp", "--tb",
+}
+
+func (s *Selector) rewritePytest(ctx context.Context, args []string, n int) ([]string, bool, error) {
+	flags, paths := splitArgs(args[n:], pytestValueFlags)
+	for _, p := range paths {
+		if strings.Contains(p, "::") || !exists(filepath.Join(s.Dir, p)) {
+			return args, false, nil
+		}
+	}
+	sel, err := s.Selection(ctx, "python")
+	if err != nil || sel == nil || sel.Full {
+		return args, false, err
+	}
+	var targets []string
+	for _, t := range sel.Targets {
+		if len(paths) == 0 || slices.ContainsFunc(paths, func(p string) bool {
+			return within(filepath.Join(s.Dir, p), filepath.Join(s.Dir, t))
+		}) {
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, true, nil
+	}
+	out := append(append([]string(nil), args[:n]...), flags...)
+	return append(out, targets...), false, nil
+}
+
+func (s *Selector) rewriteJS(ctx context.Context, args []string) ([]string, bool, error) {
+	rest := args
+	if len(rest) > 0 && rest[0] == "npx" {
+		rest = rest[1:]
+	}
+	if len(rest) == 0 || (rest[0] != "jest" && rest[0] != "vitest") {
+		return args, false, nil
+	}
+	rest = rest[1:]
+	if len(rest) > 0 && rest[0] == "run" && args[len(args)-len(rest)-1] == "vitest" {
+		rest = rest[1:]
+	}
+	for _, a := range rest {
+		if !strings.HasPrefix(a, "-") {
+			return args, false, nil
+		}
+	}
+	sel, err := s.Selection(ctx, "js")
+	if err != nil || sel == nil || sel.Full {
+		return args, false, err
+	}
+	if len(sel.Targets) == 0 {
+		return nil, true, nil
+	}
+	return append(append([]string(nil), args...), sel.Targets...), false, nil
+}
+
+// splitArgs separates flags (with their values) from positional arguments.
+func splitArgs(args, valueFlags []string) (flags, positional []string) {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if !strings.HasPrefix(a, "-") {
+			positional = append(positional, a)
+			continue
+		}
+		flags = append(flags, a)
+		name := strings.Replace(a, "--", "-", 1)
+		if strings.Contains(a, "=") || i+1 >= len(args) {
+			continue
+		}
+		if slices.Contains(valueFlags, a) || slices.Contains(valueFlags, name) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+	return flags, positional
+}
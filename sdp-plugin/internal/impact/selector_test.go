@@ -0,0 +1,158 @@
+package impact
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSelector_RewriteGo(t *testing.T) {
+	dir := goModule(t)
+	ctx := context.Background()
+	s := NewSelector(dir, []string{"a/a.go"})
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+		skip bool
+	}{
+		{"whole module", []string{"go", "test", "-count", "1", "./...", "-v"}, []string{"go", "test", "-count", "1", "-v", "./b", "./d", "./e"}, false},
+		{"subtree", []string{"go", "test", "./e/..."}, []string{"go", "test", "./e"}, false},
+		{"nothing affected in subtree", []string{"go", "test", "./c/..."}, nil, true},
+		{"explicit package", []string{"go", "test", "./c"}, []string{"go", "test", "./c"}, false},
+		{"no patterns", []string{"go", "test", "-run", "TestX"}, []string{"go", "test", "-run", "TestX"}, false},
+		{"other command", []string{"make", "test"}, []string{"make", "test"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skip, err := s.Rewrite(ctx, tt.args)
+			if err != nil {
+				t.Fatalf("Rewrite: %v", err)
+			}
+			if skip != tt.skip || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rewrite = %v, skip=%v; want %v, skip=%v", got, skip, tt.want, tt.skip)
+			}
+		})
+	}
+
+	full := NewSelector(dir, []string{"go.sum"})
+	args := []string{"go", "test", "./..."}
+	if got, skip, _ := full.Rewrite(ctx, args); skip || !reflect.DeepEqual(got, args) {
+		t.Errorf("full selection should leave the command unchanged, got %v", got)
+	}
+}
+
+func TestSelector_RewritePytestAndJS(t *testing.T) {
+	ctx := context.Background()
+	dir := pythonProject(t)
+	s := NewSelector(dir, []string{"pkg/core.py"})
+
+	got, skip, err := s.Rewrite(ctx, []string{"python3", "-m", "pytest", "-k", "util", "tests"})
+	if err != nil || skip {
+		t.Fatalf("Rewrite: %v skip=%v", err, skip)
+	}
+	if want := []string{"python3", "-m", "pytest", "-k", "util", "tests/test_util.py"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pytest rewrite = %v, want %v", got, want)
+	}
+	args := []string{"pytest", "tests/test_util.py::test_y"}
+	if got, _, _ := s.Rewrite(ctx, args); !reflect.DeepEqual(got, args) {
+		t.Errorf("node ids should be left alone, got %v", got)
+	}
+
+	js := writeTree(t, map[string]string{
+		"package.json":  "{}",
+		"src/a.js":      "export const a = 1\n",
+		"src/a.test.js": "import { a } from './a'\n",
+	})
+	s = NewSelector(js, []string{"src/a.js"})
+	if got, _, _ := s.Rewrite(ctx, []string{"npx", "jest", "--ci"}); !reflect.DeepEqual(got, []string{"npx", "jest", "--ci", "src/a.test.js"}) {
+		t.Errorf("jest rewrite = %v", got)
+	}
+	s = NewSelector(js, []string{"README.md"})
+	if _, skip, _ := s.Rewrite(ctx, []string{"vitest", "run"}); !skip {
+		t.Error("expected skip when no JS test is affected")
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v %s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("init", "-q", "-b", "main")
+	write("a.go", "package a\n")
+	write("b.go", "package a\n")
+	run("add", ".")
+	run("commit", "-qm", "base")
+	run("checkout", "-qb", "feature")
+	write("a.go", "package a // changed\n")
+	run("commit", "-qam", "change a")
+	write("b.go", "package a // wip\n")
+	write("sub/new.go", "package sub\n")
+
+	files, err := ChangedFiles(filepath.Join(dir, "sub"), "main")
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	top, _ := filepath.EvalSymlinks(dir)
+	for i, f := range files {
+		files[i], _ = filepath.EvalSymlinks(f)
+	}
+	sort.Strings(files)
+	want := []string{filepath.Join(top, "a.go"), filepath.Join(top, "b.go"), filepath.Join(top, "sub", "new.go")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ChangedFiles(main) = %v, want %v", files, want)
+	}
+
+	files, err = ChangedFiles(dir, "")
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("ChangedFiles(HEAD) = %v, want the uncommitted and untracked files", files)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	flags, pos := splitArgs([]string{"-run=X", "-timeout", "5m", "./...", "-v", "./a"}, goTestValueFlags)
+	if !reflect.DeepEqual(flags, []string{"-run=X", "-timeout", "5m", "-v"}) || !reflect.DeepEqual(pos, []string{"./...", "./a"}) {
+		t.Errorf("splitArgs = %v %v", flags, pos)
+	}
+}
+
+func TestAnalyzeAndCommand(t *testing.T) {
+	dir := pythonProject(t)
+	sels, err := Analyze(context.Background(), dir, []string{"pkg/util.py"})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+	if len(sels) != 1 || sels[0].Provider != "python" {
+		t.Fatalf("expected one python selection, got %+v", sels)
+	}
+	if got := Command(sels[0]); !reflect.DeepEqual(got, []string{"pytest", "tests/test_util.py"}) {
+		t.Errorf("Command = %v", got)
+	}
+	if got := Command(&Selection{Provider: "unknown"}); got != nil {
+		t.Errorf("Command for unknown provider = %v, want nil", got)
+	}
+}
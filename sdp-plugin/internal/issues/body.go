@@ -0,0 +1,77 @@
+package issues
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// The part of an issue body between these markers is owned by SDP and
+// rewritten on every push; text outside them is left to tracker users.
+const (
+	blockStart = "<!-- sdp:workstream -->"
+	blockEnd   = "<!-- /sdp:workstream -->"
+)
+
+// dependency is one entry of the task list in an issue body.
+type dependency struct {
+	WSID   string
+	Title  string
+	Number int // 0 while the dependency has no issue
+	Done   bool
+}
+
+// renderBlock returns the SDP-owned part of a workstream's issue body.
+func renderBlock(ws *Workstream, deps []dependency) string {
+	var b strings.Builder
+	b.WriteString(blockStart + "\n")
+	fmt.Fprintf(&b, "Workstream `%s`", ws.ID)
+	if ws.Feature != "" {
+		fmt.Fprintf(&b, " · feature %s", ws.Feature)
+	}
+	fmt.Fprintf(&b, " · `%s`\n", strings.ReplaceAll(ws.Path, "\\", "/"))
+	if ws.Goal != "" {
+		b.WriteString("\n" + ws.Goal + "\n")
+	}
+	if len(deps) > 0 {
+		b.WriteString("\n**Depends on**\n\n")
+		for _, d := range deps {
+			box := " "
+			if d.Done {
+				box = "x"
+			}
+			ref := d.WSID
+			if d.Number > 0 {
+				ref = fmt.Sprintf("#%d %s", d.Number, d.WSID)
+			}
+			if d.Title != "" && d.Title != d.WSID {
+				ref += " " + strings.TrimPrefix(d.Title, d.WSID+": ")
+			}
+			fmt.Fprintf(&b, "- [%s] %s\n", box, ref)
+		}
+	}
+	b.WriteString(blockEnd)
+	return b.String()
+}
+
+// spliceBlock replaces the SDP block in body, or puts it first when the
+// body has none.
+func spliceBlock(body, block string) string {
+	start := strings.Index(body, blockStart)
+	end := strings.Index(body, blockEnd)
+	if start >= 0 && end > start {
+		return body[:start] + block + body[end+len(blockEnd):]
+	}
+	if strings.TrimSpace(body) == "" {
+		return block + "\n"
+	}
+	return block + "\n\n" + body
+}
+
+// blockHash fingerprints what a push writes besides the synced fields, so
+// a changed title, goal or dependency list is pushed once.
+func blockHash(title, block string) string {
+	sum := sha256.Sum256([]byte(title + "\n" + block))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,169 @@
+package issues
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultGitHubURL is the public GitHub REST API
+const DefaultGitHubURL = "https://api.github.com"
+
+// GitHub is a Tracker backed by the GitHub Issues REST API.
+type GitHub struct {
+	repo string
+	rest *restClient
+}
+
+// NewGitHub returns a client for repo ("owner/name"). baseURL is empty for
+// github.com or the API root of a GitHub Enterprise server.
+func NewGitHub(baseURL, repo, token string) *GitHub {
+	if baseURL == "" {
+		baseURL = DefaultGitHubURL
+	}
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return &GitHub{repo: repo, rest: newRESTClient(baseURL, header)}
+}
+
+// Name returns "github".
+func (g *GitHub) Name() string { return "github" }
+
+type githubIssue struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	Body     string `json:"body"`
+	State    string `json:"state"`
+	HTMLURL  string `json:"html_url"`
+	Assignee *struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	PullRequest any       `json:"pull_request"`
+}
+
+func (gi githubIssue) issue() Issue {
+	is := Issue{
+		Number:    gi.Number,
+		Title:     gi.Title,
+		Body:      gi.Body,
+		Closed:    gi.State == "closed",
+		URL:       gi.HTMLURL,
+		UpdatedAt: gi.UpdatedAt,
+	}
+	if gi.Assignee != nil {
+		is.Assignee = gi.Assignee.Login
+	}
+	for _, a := range gi.Assignees {
+		if is.Assignee == "" {
+			is.Assignee = a.Login
+		} else if a.Login != is.Assignee {
+			is.OtherAssignees = append(is.OtherAssignees, a.Login)
+		}
+	}
+	for _, l := range gi.Labels {
+		is.Labels = append(is.Labels, l.Name)
+	}
+	return is
+}
+
+func (g *GitHub) issuesPath() string { return "/repos/" + g.repo + "/issues" }
+
+// ListUpdated pages through issues updated since, skipping pull requests.
+func (g *GitHub) ListUpdated(since time.Time) ([]Issue, error) {
+	var out []Issue
+	for page := 1; ; page++ {
+		q := url.Values{
+			"state":     {"all"},
+			"sort":      {"updated"},
+			"direction": {"asc"},
+			"per_page":  {strconv.Itoa(pageSize)},
+			"page":      {strconv.Itoa(page)},
+		}
+		if !since.IsZero() {
+			q.Set("since", since.UTC().Format(time.RFC3339))
+		}
+		var batch []githubIssue
+		if err := g.rest.do(http.MethodGet, g.issuesPath(), q, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, gi := range batch {
+			if gi.PullRequest == nil {
+				out = append(out, gi.issue())
+			}
+		}
+		if len(batch) < pageSize {
+			return out, nil
+		}
+	}
+}
+
+// Get returns one issue.
+func (g *GitHub) Get(number int) (*Issue, error) {
+	var gi githubIssue
+	if err := g.rest.do(http.MethodGet, fmt.Sprintf("%s/%d", g.issuesPath(), number), nil, nil, &gi); err != nil {
+		return nil, err
+	}
+	is := gi.issue()
+	return &is, nil
+}
+
+type githubWrite struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	State     string   `json:"state,omitempty"`
+	Labels    []string `json:"labels"`
+	Assignees []string `json:"assignees"`
+}
+
+func newGitHubWrite(is Issue) githubWrite {
+	w := githubWrite{Title: is.Title, Body: is.Body, Labels: is.Labels, Assignees: is.assignees()}
+	if w.Labels == nil {
+		w.Labels = []string{}
+	}
+	if w.Assignees == nil {
+		w.Assignees = []string{}
+	}
+	return w
+}
+
+// Create opens an issue; GitHub cannot create closed issues, so a closed
+// one is closed with a follow-up update.
+func (g *GitHub) Create(is Issue) (*Issue, error) {
+	var gi githubIssue
+	if err := g.rest.do(http.MethodPost, g.issuesPath(), nil, newGitHubWrite(is), &gi); err != nil {
+		return nil, err
+	}
+	if is.Closed {
+		return g.Update(gi.Number, is)
+	}
+	created := gi.issue()
+	return &created, nil
+}
+
+// Update replaces the issue's title, body, state, labels and assignees.
+func (g *GitHub) Update(number int, is Issue) (*Issue, error) {
+	w := newGitHubWrite(is)
+	w.State = "open"
+	if is.Closed {
+		w.State = "closed"
+	}
+	var gi githubIssue
+	if err := g.rest.do(http.MethodPatch, fmt.Sprintf("%s/%d", g.issuesPath(), number), nil, w, &gi); err != nil {
+		return nil, err
+	}
+	updated := gi.issue()
+	return &updated, nil
+}
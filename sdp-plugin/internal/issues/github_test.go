@@ -0,0 +1,100 @@
+package issues
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitHub_ListUpdated(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/app/issues" || r.Header.Get("Authorization") != "Bearer tok" {
+			http.Error(w, "bad request "+r.URL.Path, http.StatusBadRequest)
+			return
+		}
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`[
+ {"number":1,"title":"00-050-01: Sync","body":null,"state":"closed","html_url":"https://x/1",
+  "assignee":{"login":"alice"},"assignees":[{"login":"alice"},{"login":"carol"}],"labels":[{"name":"api"},{"name":"status:done"}],"updated_at":"2026-10-01T10:00:00Z"},
+ {"number":2,"title":"A PR","state":"open","pull_request":{"url":"https://x/pr/2"},"updated_at":"2026-10-01T11:00:00Z"}]`))
+	}))
+	defer srv.Close()
+
+	gh := NewGitHub(srv.URL, "acme/app", "tok")
+	got, err := gh.ListUpdated(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ListUpdated: %v", err)
+	}
+	if !strings.Contains(query, "since=2026-09-30T00%3A00%3A00Z") || !strings.Contains(query, "state=all") {
+		t.Errorf("query = %s", query)
+	}
+	if len(got) != 1 {
+		t.Fatalf("pull requests must be skipped, got %+v", got)
+	}
+	is := got[0]
+	if !is.Closed || is.Assignee != "alice" || strings.Join(is.OtherAssignees, ",") != "carol" ||
+		strings.Join(is.Labels, ",") != "api,status:done" || is.URL != "https://x/1" {
+		t.Errorf("issue = %+v", is)
+	}
+}
+
+func TestGitHub_CreateAndUpdate(t *testing.T) {
+	var requests []string
+	var bodies []githubWrite
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body githubWrite
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"number":7,"state":"open"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"number":7,"state":"closed","updated_at":"2026-10-02T00:00:00Z"}`))
+	}))
+	defer srv.Close()
+
+	gh := NewGitHub(srv.URL, "acme/app", "")
+	is, err := gh.Create(Issue{Title: "T", Body: "B", Closed: true, Labels: []string{"x"}, Assignee: "bob"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !is.Closed || is.Number != 7 {
+		t.Errorf("created = %+v", is)
+	}
+	if strings.Join(requests, ";") != "POST /repos/acme/app/issues;PATCH /repos/acme/app/issues/7" {
+		t.Errorf("requests = %v; a closed issue is closed after creation", requests)
+	}
+	if bodies[0].Assignees[0] != "bob" || bodies[1].State != "closed" || bodies[1].Labels[0] != "x" {
+		t.Errorf("bodies = %+v", bodies)
+	}
+
+	if _, err := gh.Update(7, Issue{Title: "T"}); err != nil {
+		t.Fatal(err)
+	}
+	if last := bodies[len(bodies)-1]; last.State != "open" || last.Labels == nil || len(last.Assignees) != 0 {
+		t.Errorf("update must clear labels and assignees explicitly: %+v", last)
+	}
+	if _, err := gh.Update(7, Issue{Title: "T", Assignee: "bob", OtherAssignees: []string{"carol", "bob"}}); err != nil {
+		t.Fatal(err)
+	}
+	if last := bodies[len(bodies)-1]; strings.Join(last.Assignees, ",") != "bob,carol" {
+		t.Errorf("assignees = %v; the synced assignee comes first, others are kept", last.Assignees)
+	}
+}
+
+func TestGitHub_ErrorIncludesStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	_, err := NewGitHub(srv.URL, "acme/app", "bad").Get(1)
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package issues
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGitLabURL is the gitlab.com REST API
+const DefaultGitLabURL = "https://gitlab.com/api/v4"
+
+// GitLab is a Tracker backed by the GitLab issues REST API.
+type GitLab struct {
+	project string
+	rest    *restClient
+	userIDs map[string]int
+}
+
+// NewGitLab returns a client for project (a path like "group/name" or a
+// numeric ID). baseURL is empty for gitlab.com or the /api/v4 root of a
+// self-hosted instance.
+func NewGitLab(baseURL, project, token string) *GitLab {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	header := http.Header{}
+	if token != "" {
+		header.Set("PRIVATE-TOKEN", token)
+	}
+	return &GitLab{project: project, rest: newRESTClient(baseURL, header), userIDs: make(map[string]int)}
+}
+
+// Name returns "gitlab".
+func (g *GitLab) Name() string { return "gitlab" }
+
+type gitlabIssue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"`
+	WebURL      string   `json:"web_url"`
+	Labels      []string `json:"labels"`
+	Assignees   []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (gi gitlabIssue) issue() Issue {
+	is := Issue{
+		Number:    gi.IID,
+		Title:     gi.Title,
+		Body:      gi.Description,
+		Closed:    gi.State == "closed",
+		Labels:    gi.Labels,
+		URL:       gi.WebURL,
+		UpdatedAt: gi.UpdatedAt,
+	}
+	for i, a := range gi.Assignees {
+		if i == 0 {
+			is.Assignee = a.Username
+		} else {
+			is.OtherAssignees = append(is.OtherAssignees, a.Username)
+		}
+	}
+	return is
+}
+
+func (g *GitLab) issuesPath() string {
+	return "/projects/" + url.PathEscape(g.project) + "/issues"
+}
+
+// ListUpdated pages through issues updated since.
+func (g *GitLab) ListUpdated(since time.Time) ([]Issue, error) {
+	var out []Issue
+	for page := 1; ; page++ {
+		q := url.Values{
+			"scope":    {"all"},
+			"order_by": {"updated_at"},
+			"sort":     {"asc"},
+			"per_page": {strconv.Itoa(pageSize)},
+			"page":     {strconv.Itoa(page)},
+		}
+		if !since.IsZero() {
+			q.Set("updated_after", since.UTC().Format(time.RFC3339))
+		}
+		var batch []gitlabIssue
+		if err := g.rest.do(http.MethodGet, g.issuesPath(), q, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, gi := range batch {
+			out = append(out, gi.issue())
+		}
+		if len(batch) < pageSize {
+			return out, nil
+		}
+	}
+}
+
+// Get returns one issue by its project-scoped IID.
+func (g *GitLab) Get(number int) (*Issue, error) {
+	var gi gitlabIssue
+	if err := g.rest.do(http.MethodGet, fmt.Sprintf("%s/%d", g.issuesPath(), number), nil, nil, &gi); err != nil {
+		return nil, err
+	}
+	is := gi.issue()
+	return &is, nil
+}
+
+type gitlabWrite struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Labels      string `json:"labels"`
+	AssigneeIDs []int  `json:"assignee_ids"`
+	StateEvent  string `json:"state_event,omitempty"`
+}
+
+func (g *GitLab) newWrite(is Issue) (gitlabWrite, error) {
+	w := gitlabWrite{Title: is.Title, Description: is.Body, Labels: strings.Join(is.Labels, ","), AssigneeIDs: []int{}}
+	for _, username := range is.assignees() {
+		id, err := g.userID(username)
+		if err != nil {
+			return w, err
+		}
+		w.AssigneeIDs = append(w.AssigneeIDs, id)
+	}
+	return w, nil
+}
+
+// userID resolves a username, since GitLab assigns by user ID.
+func (g *GitLab) userID(username string) (int, error) {
+	if id, ok := g.userIDs[username]; ok {
+		return id, nil
+	}
+	var users []struct {
+		ID int `json:"id"`
+	}
+	if err := g.rest.do(http.MethodGet, "/users", url.Values{"username": {username}}, nil, &users); err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("gitlab user %q not found", username)
+	}
+	g.userIDs[username] = users[0].ID
+	return users[0].ID, nil
+}
+
+// Create opens an issue, closing it right away when is.Closed is set.
+func (g *GitLab) Create(is Issue) (*Issue, error) {
+	w, err := g.newWrite(is)
+	if err != nil {
+		return nil, err
+	}
+	var gi gitlabIssue
+	if err := g.rest.do(http.MethodPost, g.issuesPath(), nil, w, &gi); err != nil {
+		return nil, err
+	}
+	if is.Closed {
+		return g.Update(gi.IID, is)
+	}
+	created := gi.issue()
+	return &created, nil
+}
+
+// Update replaces the issue's title, description, labels, assignees and state.
+func (g *GitLab) Update(number int, is Issue) (*Issue, error) {
+	w, err := g.newWrite(is)
+	if err != nil {
+		return nil, err
+	}
+	w.StateEvent = "reopen"
+	if is.Closed {
+		w.StateEvent = "close"
+	}
+	var gi gitlabIssue
+	if err := g.rest.do(http.MethodPut, fmt.Sprintf("%s/%d", g.issuesPath(), number), nil, w, &gi); err != nil {
+		return nil, err
+	}
+	updated := gi.issue()
+	return &updated, nil
+}
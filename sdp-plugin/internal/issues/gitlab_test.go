@@ -0,0 +1,83 @@
+package issues
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitLab_ListUpdated(t *testing.T) {
+	var path, query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "tok" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		path, query = r.URL.EscapedPath(), r.URL.RawQuery
+		_, _ = w.Write([]byte(`[{"iid":3,"title":"00-050-02: Pull","description":"d","state":"opened",
+ "labels":["status:in_progress","ui"],"assignees":[{"username":"carol"}],"web_url":"https://gl/3","updated_at":"2026-10-01T10:00:00Z"}]`))
+	}))
+	defer srv.Close()
+
+	gl := NewGitLab(srv.URL, "group/app", "tok")
+	got, err := gl.ListUpdated(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ListUpdated: %v", err)
+	}
+	if path != "/projects/group%2Fapp/issues" || !strings.Contains(query, "updated_after=2026-09-30T00%3A00%3A00Z") {
+		t.Errorf("request = %s?%s", path, query)
+	}
+	if len(got) != 1 || got[0].Number != 3 || got[0].Closed || got[0].Assignee != "carol" || got[0].Body != "d" {
+		t.Errorf("issues = %+v", got)
+	}
+}
+
+func TestGitLab_CreateAndUpdate(t *testing.T) {
+	var requests []string
+	var writes []gitlabWrite
+	userLookups := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		switch {
+		case r.URL.Path == "/users":
+			userLookups++
+			if r.URL.Query().Get("username") == "dave" {
+				_, _ = w.Write([]byte(`[{"id":42}]`))
+			} else {
+				_, _ = w.Write([]byte(`[]`))
+			}
+			return
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"iid":9,"state":"opened"}`))
+		default:
+			_, _ = w.Write([]byte(`{"iid":9,"state":"closed"}`))
+		}
+		var body gitlabWrite
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		writes = append(writes, body)
+	}))
+	defer srv.Close()
+
+	gl := NewGitLab(srv.URL, "group/app", "")
+	is, err := gl.Create(Issue{Title: "T", Labels: []string{"a", "b"}, Assignee: "dave", Closed: true})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !is.Closed || is.Number != 9 {
+		t.Errorf("created = %+v", is)
+	}
+	want := "GET /users;POST /projects/group%2Fapp/issues;PUT /projects/group%2Fapp/issues/9"
+	if strings.Join(requests, ";") != want {
+		t.Errorf("requests = %v (user IDs are cached)", requests)
+	}
+	if writes[0].Labels != "a,b" || writes[0].AssigneeIDs[0] != 42 || writes[1].StateEvent != "close" || userLookups != 1 {
+		t.Errorf("writes = %+v, lookups = %d", writes, userLookups)
+	}
+
+	if _, err := gl.Update(9, Issue{Title: "T", Assignee: "nobody"}); err == nil || !strings.Contains(err.Error(), "nobody") {
+		t.Errorf("unknown assignee: err = %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package issues
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// Files that hold sync state, relative to the project root. Both are
+// meant to be committed so every clone syncs from the same baseline.
+const (
+	MappingFile = ".sdp-issues-mapping.jsonl"
+	CursorFile  = ".sdp/issues-cursor.json"
+)
+
+// syncLockFile serializes sync runs of one project; it is not committed.
+const syncLockFile = ".sdp/issues-sync.lock"
+
+// Link maps a workstream to its tracker issue.
+type Link struct {
+	SdpID   string `json:"sdp_id"`
+	Tracker string `json:"tracker"` // e.g. "github:owner/name"
+	Number  int    `json:"issue_number"`
+	URL     string `json:"url,omitempty"`
+	// Base holds the values both sides agreed on at the last sync.
+	Base Fields `json:"base"`
+	// BlockHash fingerprints the title and SDP block last pushed.
+	BlockHash string `json:"block_hash,omitempty"`
+	// Conflict is set while a manual conflict waits for resolution.
+	Conflict        bool      `json:"conflict,omitempty"`
+	RemoteUpdatedAt time.Time `json:"remote_updated_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ReadLinks returns the links for tracker keyed by workstream ID, and
+// the links of other trackers so they survive a rewrite.
+func ReadLinks(path, tracker string) (map[string]*Link, []Link, error) {
+	links := make(map[string]*Link)
+	var others []Link
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return links, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("open mapping: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var l Link
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		if l.Tracker == tracker {
+			links[l.SdpID] = &l
+		} else {
+			others = append(others, l)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read mapping: %w", err)
+	}
+	return links, others, nil
+}
+
+// writeLinks replaces the mapping file, one link per line sorted by
+// workstream ID so concurrent branches merge cleanly.
+func writeLinks(path string, links map[string]*Link, others []Link) error {
+	all := append([]Link{}, others...)
+	for _, l := range links {
+		all = append(all, *l)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].SdpID != all[j].SdpID {
+			return all[i].SdpID < all[j].SdpID
+		}
+		return all[i].Tracker < all[j].Tracker
+	})
+	var data []byte
+	for _, l := range all {
+		line, err := json.Marshal(l)
+		if err != nil {
+			return fmt.Errorf("encode link: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+	return writeFileAtomic(path, data)
+}
+
+// readCursor returns the updated-since cursor for tracker; zero when the
+// tracker was never synced.
+func readCursor(path, tracker string) (time.Time, error) {
+	cursors, err := readCursors(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cursors[tracker], nil
+}
+
+func readCursors(path string) (map[string]time.Time, error) {
+	cursors := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cursor: %w", err)
+	}
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cursors, nil
+}
+
+func writeCursor(path, tracker string, at time.Time) error {
+	cursors, err := readCursors(path)
+	if err != nil {
+		return err
+	}
+	cursors[tracker] = at.UTC()
+	data, err := json.MarshalIndent(cursors, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cursor: %w", err)
+	}
+	return writeFileAtomic(path, append(data, '\n'))
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// lockSync takes the project's sync lock; the returned func releases it.
+func lockSync(root string) (func(), error) {
+	path := filepath.Join(root, syncLockFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open sync lock: %w", err)
+	}
+	if err := filelock.Lock(f); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("lock sync: %w", err)
+	}
+	return func() {
+		_ = filelock.Unlock(f)
+		_ = f.Close()
+	}, nil
+}
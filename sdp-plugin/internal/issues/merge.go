@@ -0,0 +1,124 @@
+package issues
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Rule resolves a field changed differently on both sides since the last
+// sync.
+type Rule string
+
+const (
+	// RuleManual leaves both sides as they are and reports the conflict
+	// until one side is edited to match the other.
+	RuleManual Rule = "manual"
+	// RuleLocal keeps the workstream's value.
+	RuleLocal Rule = "local"
+	// RuleRemote keeps the issue's value.
+	RuleRemote Rule = "remote"
+	// RuleNewer keeps the side modified last: the workstream file's mtime
+	// against the issue's updated_at.
+	RuleNewer Rule = "newer"
+)
+
+// ParseRule validates a conflict rule name; empty means manual.
+func ParseRule(s string) (Rule, error) {
+	switch r := Rule(s); r {
+	case "":
+		return RuleManual, nil
+	case RuleManual, RuleLocal, RuleRemote, RuleNewer:
+		return r, nil
+	}
+	return "", fmt.Errorf("unknown conflict rule %q (valid: manual, local, remote, newer)", s)
+}
+
+// Conflict is a field changed differently on both sides.
+type Conflict struct {
+	WSID     string `json:"ws_id"`
+	Field    string `json:"field"`
+	Local    string `json:"local"`
+	Remote   string `json:"remote"`
+	Resolved string `json:"resolved"` // "local", "remote" or "manual"
+}
+
+// mergeResult is the outcome of merging one workstream with its issue.
+type mergeResult struct {
+	Local     Fields // values to write to the workstream
+	Remote    Fields // values to push to the issue
+	Base      Fields // new agreed baseline
+	Conflicts []Conflict
+}
+
+// field is one synced value: how to compare it, copy it between Fields
+// and show it in a Conflict.
+type field struct {
+	name  string
+	equal func(a, b Fields) bool
+	take  func(dst *Fields, src Fields)
+	show  func(Fields) string
+}
+
+var syncedFields = []field{
+	{"status",
+		func(a, b Fields) bool { return a.Status == b.Status },
+		func(dst *Fields, src Fields) { dst.Status = src.Status },
+		func(f Fields) string { return f.Status }},
+	{"labels",
+		func(a, b Fields) bool { return slices.Equal(a.Labels, b.Labels) },
+		func(dst *Fields, src Fields) { dst.Labels = slices.Clone(src.Labels) },
+		func(f Fields) string { return strings.Join(f.Labels, ",") }},
+	{"assignee",
+		func(a, b Fields) bool { return a.Assignee == b.Assignee },
+		func(dst *Fields, src Fields) { dst.Assignee = src.Assignee },
+		func(f Fields) string { return f.Assignee }},
+}
+
+// merge takes each field from the side that changed it since base. A
+// field changed on both sides goes to winner ("local" or "remote"); with
+// no winner both sides keep their value and base is left as it was.
+func merge(wsID string, local, base, remote Fields, winner string) mergeResult {
+	var res mergeResult
+	for _, fd := range syncedFields {
+		var toLocal, toRemote, newBase Fields
+		switch {
+		case fd.equal(local, base) || fd.equal(local, remote):
+			toLocal, toRemote, newBase = remote, remote, remote
+		case fd.equal(remote, base):
+			toLocal, toRemote, newBase = local, local, local
+		default:
+			c := Conflict{WSID: wsID, Field: fd.name, Local: fd.show(local), Remote: fd.show(remote), Resolved: winner}
+			switch winner {
+			case "local":
+				toLocal, toRemote, newBase = local, local, local
+			case "remote":
+				toLocal, toRemote, newBase = remote, remote, remote
+			default:
+				c.Resolved = string(RuleManual)
+				toLocal, toRemote, newBase = local, remote, base
+			}
+			res.Conflicts = append(res.Conflicts, c)
+		}
+		fd.take(&res.Local, toLocal)
+		fd.take(&res.Remote, toRemote)
+		fd.take(&res.Base, newBase)
+	}
+	return res
+}
+
+// winner picks the side that takes conflicting fields under rule.
+func winner(rule Rule, ws *Workstream, remote *Issue) string {
+	switch rule {
+	case RuleLocal:
+		return "local"
+	case RuleRemote:
+		return "remote"
+	case RuleNewer:
+		if remote == nil || ws.ModTime.After(remote.UpdatedAt) {
+			return "local"
+		}
+		return "remote"
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package issues
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	base := Fields{Status: "backlog", Assignee: "alice"}
+	tests := []struct {
+		name                  string
+		local, remote         Fields
+		winner                string
+		wantLocal, wantRemote Fields
+		wantBase              Fields
+		conflicts             int
+	}{
+		{"unchanged", base, base, "", base, base, base, 0},
+		{"remote change", base, Fields{Status: "in_progress", Assignee: "alice"}, "",
+			Fields{Status: "in_progress", Assignee: "alice"}, Fields{Status: "in_progress", Assignee: "alice"}, Fields{Status: "in_progress", Assignee: "alice"}, 0},
+		{"local change", Fields{Status: "backlog", Assignee: "bob"}, base, "",
+			Fields{Status: "backlog", Assignee: "bob"}, Fields{Status: "backlog", Assignee: "bob"}, Fields{Status: "backlog", Assignee: "bob"}, 0},
+		{"different fields", Fields{Status: "backlog", Assignee: "bob"}, Fields{Status: "completed", Assignee: "alice"}, "",
+			Fields{Status: "completed", Assignee: "bob"}, Fields{Status: "completed", Assignee: "bob"}, Fields{Status: "completed", Assignee: "bob"}, 0},
+		{"same change", Fields{Status: "done"}, Fields{Status: "done"}, "",
+			Fields{Status: "done"}, Fields{Status: "done"}, Fields{Status: "done"}, 0},
+		{"conflict manual", Fields{Status: "blocked", Assignee: "alice"}, Fields{Status: "completed", Assignee: "alice"}, "",
+			Fields{Status: "blocked", Assignee: "alice"}, Fields{Status: "completed", Assignee: "alice"}, base, 1},
+		{"conflict local", Fields{Status: "blocked", Assignee: "alice"}, Fields{Status: "completed", Assignee: "alice"}, "local",
+			Fields{Status: "blocked", Assignee: "alice"}, Fields{Status: "blocked", Assignee: "alice"}, Fields{Status: "blocked", Assignee: "alice"}, 1},
+		{"conflict remote", Fields{Status: "blocked", Assignee: "alice"}, Fields{Status: "completed", Assignee: "alice"}, "remote",
+			Fields{Status: "completed", Assignee: "alice"}, Fields{Status: "completed", Assignee: "alice"}, Fields{Status: "completed", Assignee: "alice"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := merge("00-050-01", tt.local, base, tt.remote, tt.winner)
+			if !res.Local.equal(tt.wantLocal) || !res.Remote.equal(tt.wantRemote) || !res.Base.equal(tt.wantBase) {
+				t.Errorf("merge = local %+v remote %+v base %+v", res.Local, res.Remote, res.Base)
+			}
+			if len(res.Conflicts) != tt.conflicts {
+				t.Errorf("conflicts = %+v", res.Conflicts)
+			}
+		})
+	}
+}
+
+func TestMerge_LabelsContainingCommas(t *testing.T) {
+	local := Fields{Status: "backlog", Labels: []string{"a,b"}}
+	remote := Fields{Status: "backlog", Labels: []string{"a", "b"}}
+	res := merge("00-050-01", local, Fields{Status: "backlog"}, remote, "")
+	if len(res.Conflicts) != 1 || res.Conflicts[0].Field != "labels" {
+		t.Fatalf("conflicts = %+v; [a,b] and [a b] differ", res.Conflicts)
+	}
+	if len(res.Local.Labels) != 1 || res.Local.Labels[0] != "a,b" || len(res.Remote.Labels) != 2 {
+		t.Errorf("merge = local %+v remote %+v", res.Local, res.Remote)
+	}
+}
+
+func TestWinnerAndParseRule(t *testing.T) {
+	now := time.Now()
+	ws := &Workstream{ModTime: now}
+	if winner(RuleNewer, ws, &Issue{UpdatedAt: now.Add(time.Minute)}) != "remote" ||
+		winner(RuleNewer, ws, &Issue{UpdatedAt: now.Add(-time.Minute)}) != "local" ||
+		winner(RuleManual, ws, nil) != "" {
+		t.Error("unexpected winner")
+	}
+	if r, err := ParseRule(""); err != nil || r != RuleManual {
+		t.Errorf("ParseRule(\"\") = %q, %v", r, err)
+	}
+	if _, err := ParseRule("mine"); err == nil {
+		t.Error("expected error for unknown rule")
+	}
+}
+
+func TestRemoteFields(t *testing.T) {
+	tests := []struct {
+		issue Issue
+		want  string
+	}{
+		{Issue{Labels: []string{"status:in_progress"}}, "in_progress"},
+		{Issue{Closed: true, Labels: []string{"status:in_progress"}}, "completed"},
+		{Issue{Closed: true, Labels: []string{"status:done"}}, "done"},
+		{Issue{Labels: []string{"status:completed"}}, "backlog"},
+		{Issue{}, "backlog"},
+	}
+	for _, tt := range tests {
+		if got := remoteFields(&tt.issue).Status; got != tt.want {
+			t.Errorf("remoteFields(%+v).Status = %q, want %q", tt.issue, got, tt.want)
+		}
+	}
+	f := remoteFields(&Issue{Labels: []string{"ui", "feature:F050", "api", "api"}, Assignee: "bob"})
+	if len(f.Labels) != 2 || f.Labels[0] != "api" || f.Assignee != "bob" {
+		t.Errorf("labels = %+v; reserved labels are dropped, others sorted", f)
+	}
+}
@@ -0,0 +1,130 @@
+package issues
+
+import (
+	"fmt"
+	"slices"
+)
+
+// push creates issues for unlinked workstreams and updates issues whose
+// synced fields, title, goal or dependencies changed.
+func (s *Syncer) push(st *syncState) error {
+	for _, ws := range st.workstreams {
+		block := renderBlock(ws, st.dependencies(ws))
+		hash := blockHash(ws.Title, block)
+		link := st.links[ws.ID]
+		if link == nil {
+			if isClosedStatus(ws.Status) {
+				st.report.Skipped = append(st.report.Skipped, ws.ID)
+				continue
+			}
+			if err := s.create(st, ws, block, hash); err != nil {
+				return err
+			}
+			continue
+		}
+
+		res := st.merged[ws.ID]
+		current := res.Remote.equal(link.Base)
+		if is := st.remote[link.Number]; is != nil {
+			current = showsFields(is, res.Remote, ws.Feature)
+		}
+		link.Conflict = hasManualConflict(res.Conflicts)
+		if current && hash == link.BlockHash {
+			link.Base = res.Base
+			continue
+		}
+		st.report.Pushed = append(st.report.Pushed, ws.ID)
+		if s.DryRun {
+			continue
+		}
+		is := st.remote[link.Number]
+		if is == nil {
+			got, err := s.Tracker.Get(link.Number)
+			if err != nil {
+				return fmt.Errorf("%s: get issue #%d: %w", ws.ID, link.Number, err)
+			}
+			is = got
+		}
+		updated, err := s.Tracker.Update(link.Number, Issue{
+			Title:    ws.Title,
+			Body:     spliceBlock(is.Body, block),
+			Closed:   isClosedStatus(res.Remote.Status),
+			Labels:   issueLabels(res.Remote, ws.Feature),
+			Assignee: res.Remote.Assignee,
+			// Assignees added on the tracker beyond the synced one stay.
+			OtherAssignees: is.OtherAssignees,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: update issue #%d: %w", ws.ID, link.Number, err)
+		}
+		link.Base = res.Base
+		link.BlockHash = hash
+		link.URL = updated.URL
+		link.RemoteUpdatedAt = updated.UpdatedAt
+		link.UpdatedAt = s.Now().UTC()
+	}
+	return nil
+}
+
+func (s *Syncer) create(st *syncState, ws *Workstream, block, hash string) error {
+	st.report.Created = append(st.report.Created, ws.ID)
+	if s.DryRun {
+		return nil
+	}
+	local := localFields(ws)
+	created, err := s.Tracker.Create(Issue{
+		Title:    ws.Title,
+		Body:     spliceBlock("", block),
+		Labels:   issueLabels(local, ws.Feature),
+		Assignee: local.Assignee,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: create issue: %w", ws.ID, err)
+	}
+	st.links[ws.ID] = &Link{
+		SdpID:           ws.ID,
+		Tracker:         s.Key,
+		Number:          created.Number,
+		URL:             created.URL,
+		Base:            local,
+		BlockHash:       hash,
+		RemoteUpdatedAt: created.UpdatedAt,
+		UpdatedAt:       s.Now().UTC(),
+	}
+	return nil
+}
+
+// dependencies lists a workstream's depends_on entries with their issue
+// numbers and whether they are finished.
+func (st *syncState) dependencies(ws *Workstream) []dependency {
+	var deps []dependency
+	for _, id := range ws.DependsOn {
+		d := dependency{WSID: id}
+		if dep := st.byID[id]; dep != nil {
+			d.Title = dep.Title
+			d.Done = isClosedStatus(dep.Status)
+		}
+		if l := st.links[id]; l != nil {
+			d.Number = l.Number
+		}
+		deps = append(deps, d)
+	}
+	return deps
+}
+
+// showsFields reports whether an issue already carries f: state, assignee
+// and labels, including the status label a tracker user may have left
+// stale when closing the issue.
+func showsFields(is *Issue, f Fields, feature string) bool {
+	return is.Closed == isClosedStatus(f.Status) && is.Assignee == f.Assignee &&
+		slices.Equal(normalizeLabels(is.Labels), normalizeLabels(issueLabels(f, feature)))
+}
+
+func hasManualConflict(conflicts []Conflict) bool {
+	for _, c := range conflicts {
+		if c.Resolved == string(RuleManual) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package issues
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// Syncer reconciles the workstreams of a project with a tracker.
+type Syncer struct {
+	Root    string
+	Tracker Tracker
+	// Key scopes links and the cursor to one tracker project, e.g.
+	// "github:owner/name".
+	Key    string
+	Rule   Rule
+	DryRun bool
+	// Full fetches every issue instead of those updated since the cursor.
+	Full bool
+	Now  func() time.Time
+}
+
+// Report summarizes a sync run. Entries are workstream IDs.
+type Report struct {
+	Created   []string   `json:"created,omitempty"`
+	Pushed    []string   `json:"pushed,omitempty"`
+	Pulled    []string   `json:"pulled,omitempty"`
+	Skipped   []string   `json:"skipped,omitempty"` // finished workstreams without an issue
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+	Fetched   int        `json:"fetched"`
+	Cursor    time.Time  `json:"cursor"`
+}
+
+// syncState is shared by the pull and push passes of one run.
+type syncState struct {
+	workstreams []*Workstream
+	byID        map[string]*Workstream
+	links       map[string]*Link
+	remote      map[int]*Issue
+	merged      map[string]mergeResult
+	report      *Report
+}
+
+// Sync fetches issues updated since the last sync, merges them into the
+// workstreams, then creates or updates issues for workstreams that
+// changed. The mapping is saved even when a later step fails, so issues
+// created before the failure are not created twice. A run holds the
+// project's sync lock from reading the mapping to writing the cursor, so
+// concurrent runs do not create the same issues.
+func (s *Syncer) Sync() (*Report, error) {
+	if s.Now == nil {
+		s.Now = time.Now
+	}
+	if !s.DryRun {
+		unlock, err := lockSync(s.Root)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	workstreams, err := LoadWorkstreams(s.Root)
+	if err != nil {
+		return nil, err
+	}
+	mappingPath := filepath.Join(s.Root, MappingFile)
+	cursorPath := filepath.Join(s.Root, CursorFile)
+	links, others, err := ReadLinks(mappingPath, s.Key)
+	if err != nil {
+		return nil, err
+	}
+	var cursor time.Time
+	if !s.Full {
+		if cursor, err = readCursor(cursorPath, s.Key); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := s.Tracker.ListUpdated(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("list %s issues: %w", s.Tracker.Name(), err)
+	}
+
+	st := &syncState{
+		workstreams: workstreams,
+		byID:        make(map[string]*Workstream),
+		links:       links,
+		remote:      make(map[int]*Issue),
+		merged:      make(map[string]mergeResult),
+		report:      &Report{Fetched: len(updated), Cursor: cursor},
+	}
+	for _, ws := range workstreams {
+		st.byID[ws.ID] = ws
+	}
+	// The cursor only advances over fetched issues: an issue edited while
+	// this run pushes is newer than all of them and is fetched next time.
+	for i := range updated {
+		st.remote[updated[i].Number] = &updated[i]
+		if updated[i].UpdatedAt.After(st.report.Cursor) {
+			st.report.Cursor = updated[i].UpdatedAt
+		}
+	}
+
+	err = s.pull(st)
+	if err == nil {
+		err = s.push(st)
+	}
+	if s.DryRun {
+		return st.report, err
+	}
+	if werr := writeLinks(mappingPath, links, others); werr != nil && err == nil {
+		err = werr
+	}
+	if err == nil && !st.report.Cursor.IsZero() {
+		err = writeCursor(cursorPath, s.Key, st.report.Cursor)
+	}
+	return st.report, err
+}
+
+// pull merges each linked issue into its workstream. It runs before any
+// push so task lists and issue states reflect merged statuses.
+func (s *Syncer) pull(st *syncState) error {
+	for _, ws := range st.workstreams {
+		link := st.links[ws.ID]
+		if link == nil {
+			continue
+		}
+		is := st.remote[link.Number]
+		if is == nil && link.Conflict {
+			// A manual conflict is re-checked against the live issue,
+			// which may have been fixed before the cursor.
+			got, err := s.Tracker.Get(link.Number)
+			if err != nil {
+				return fmt.Errorf("%s: get issue #%d: %w", ws.ID, link.Number, err)
+			}
+			st.remote[link.Number], is = got, got
+		}
+		remote := link.Base
+		if is != nil {
+			remote = remoteFields(is)
+		}
+		local := localFields(ws)
+		res := merge(ws.ID, local, link.Base, remote, winner(s.Rule, ws, is))
+		st.merged[ws.ID] = res
+		st.report.Conflicts = append(st.report.Conflicts, res.Conflicts...)
+		if res.Local.equal(local) {
+			continue
+		}
+		st.report.Pulled = append(st.report.Pulled, ws.ID)
+		if s.DryRun {
+			ws.Status, ws.Assignee, ws.Labels = res.Local.Status, res.Local.Assignee, res.Local.Labels
+			continue
+		}
+		if err := writeFields(s.Root, ws, res.Local); err != nil {
+			return fmt.Errorf("%s: %w", ws.ID, err)
+		}
+	}
+	return nil
+}
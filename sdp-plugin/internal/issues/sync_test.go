@@ -0,0 +1,246 @@
+package issues
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTracker keeps issues in memory and stamps every write with a clock
+// that advances one minute per call.
+type fakeTracker struct {
+	issues    map[int]*Issue
+	clock     time.Time
+	sinces    []time.Time
+	writes    int
+	failWrite bool
+}
+
+func newFakeTracker() *fakeTracker {
+	return &fakeTracker{issues: make(map[int]*Issue), clock: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeTracker) Name() string { return "fake" }
+
+func (f *fakeTracker) tick() time.Time {
+	f.clock = f.clock.Add(time.Minute)
+	return f.clock
+}
+
+func (f *fakeTracker) ListUpdated(since time.Time) ([]Issue, error) {
+	f.sinces = append(f.sinces, since)
+	var out []Issue
+	for n := 1; n <= len(f.issues); n++ {
+		if is := f.issues[n]; is != nil && !is.UpdatedAt.Before(since) {
+			out = append(out, *is)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTracker) Get(number int) (*Issue, error) {
+	is, ok := f.issues[number]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	c := *is
+	return &c, nil
+}
+
+func (f *fakeTracker) Create(is Issue) (*Issue, error) {
+	if f.failWrite {
+		return nil, errors.New("boom")
+	}
+	f.writes++
+	is.Number = len(f.issues) + 1
+	is.UpdatedAt = f.tick()
+	f.issues[is.Number] = &is
+	return f.Get(is.Number)
+}
+
+func (f *fakeTracker) Update(number int, is Issue) (*Issue, error) {
+	if f.failWrite {
+		return nil, errors.New("boom")
+	}
+	f.writes++
+	is.Number = number
+	is.UpdatedAt = f.tick()
+	f.issues[number] = &is
+	return f.Get(number)
+}
+
+// edit changes an issue as a tracker user would.
+func (f *fakeTracker) edit(number int, change func(*Issue)) {
+	change(f.issues[number])
+	f.issues[number].UpdatedAt = f.tick()
+}
+
+func newSyncer(root string, tr Tracker) *Syncer {
+	return &Syncer{Root: root, Tracker: tr, Key: "fake:acme/app", Rule: RuleManual}
+}
+
+func TestSync_CreatesIssuesAndPersistsMapping(t *testing.T) {
+	root := t.TempDir()
+	writeWorkstream(t, root, "backlog", "00-050-01", "status: backlog\nassignee: alice\nlabels: [api]\ndepends_on: [\"00-050-02\"]\n")
+	writeWorkstream(t, root, "backlog", "00-050-02", "status: in_progress\n")
+	writeWorkstream(t, root, "completed", "00-050-03", "status: completed\n")
+	tr := newFakeTracker()
+
+	report, err := newSyncer(root, tr).Sync()
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if strings.Join(report.Created, ",") != "00-050-01,00-050-02" || strings.Join(report.Skipped, ",") != "00-050-03" {
+		t.Errorf("report = %+v; finished workstreams without an issue are skipped", report)
+	}
+	first := tr.issues[1]
+	if first.Title != "00-050-01: Title of 00-050-01" || first.Assignee != "alice" ||
+		strings.Join(first.Labels, ",") != "api,status:backlog,feature:F050" {
+		t.Errorf("issue 1 = %+v", first)
+	}
+	if !strings.Contains(first.Body, "- [ ] 00-050-02 Title of 00-050-02") {
+		t.Errorf("dependency task list missing:\n%s", first.Body)
+	}
+
+	links, _, err := ReadLinks(filepath.Join(root, MappingFile), "fake:acme/app")
+	if err != nil || len(links) != 2 || links["00-050-02"].Number != 2 {
+		t.Fatalf("links = %+v, %v", links, err)
+	}
+
+	// The second run pushes the dependency's issue number it could not
+	// know before; later runs fetch only issues changed since the cursor.
+	report, err = newSyncer(root, tr).Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(report.Pushed, ",") != "00-050-01" || !strings.Contains(tr.issues[1].Body, "- [ ] #2 00-050-02") {
+		t.Errorf("report = %+v, body:\n%s", report, tr.issues[1].Body)
+	}
+	writes := tr.writes
+	if report, _ = newSyncer(root, tr).Sync(); tr.writes != writes || len(report.Pushed)+len(report.Pulled) != 0 {
+		t.Errorf("steady state must not write: %+v", report)
+	}
+	if last := tr.sinces[len(tr.sinces)-1]; last.IsZero() {
+		t.Error("later runs should use the cursor")
+	}
+}
+
+func TestSync_PullsAndPushesChanges(t *testing.T) {
+	root := t.TempDir()
+	path := writeWorkstream(t, root, "backlog", "00-050-01", "status: backlog\n")
+	writeWorkstream(t, root, "backlog", "00-050-02", "status: backlog\n")
+	tr := newFakeTracker()
+	if _, err := newSyncer(root, tr).Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A PM closes issue 1 and assigns it; the workstream picks both up.
+	tr.edit(1, func(is *Issue) { is.Closed, is.Assignee = true, "pm" })
+	// A reviewer is added to issue 2 next to the synced assignee.
+	tr.edit(2, func(is *Issue) { is.OtherAssignees = []string{"qa"} })
+	// Someone starts 00-050-02 locally; the issue follows.
+	p2 := filepath.Join(root, "docs", "workstreams", "backlog", "00-050-02.md")
+	data, _ := os.ReadFile(p2)
+	if err := os.WriteFile(p2, []byte(strings.Replace(string(data), "status: backlog", "status: in_progress\nlabels: [ui]", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := newSyncer(root, tr).Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(report.Pulled, ",") != "00-050-01" {
+		t.Errorf("pulled = %v", report.Pulled)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), "status: completed") || !strings.Contains(string(data), "assignee: pm") {
+		t.Errorf("workstream not updated:\n%s", data)
+	}
+	if is := tr.issues[2]; is.Closed || strings.Join(is.Labels, ",") != "ui,status:in_progress,feature:F050" ||
+		strings.Join(is.OtherAssignees, ",") != "qa" {
+		t.Errorf("issue 2 = %+v; unsynced assignees are kept", is)
+	}
+	if is := tr.issues[1]; !strings.Contains(strings.Join(is.Labels, ","), "status:completed") {
+		t.Errorf("closed issue should carry the merged status label: %+v", is.Labels)
+	}
+}
+
+func TestSync_ConflictRules(t *testing.T) {
+	root := t.TempDir()
+	path := writeWorkstream(t, root, "backlog", "00-050-01", "status: backlog\n")
+	tr := newFakeTracker()
+	if _, err := newSyncer(root, tr).Sync(); err != nil {
+		t.Fatal(err)
+	}
+	tr.edit(1, func(is *Issue) { is.Labels = []string{"status:blocked"} })
+	data, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "status: backlog", "status: in_progress", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dry := newSyncer(root, tr)
+	dry.DryRun, dry.Rule = true, RuleRemote
+	if report, err := dry.Sync(); err != nil || len(report.Pulled) != 1 {
+		t.Fatalf("dry run = %+v, %v", report, err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "status: in_progress") {
+		t.Error("dry run must not write the workstream")
+	}
+
+	// Manual: reported on every run, nothing written, until resolved.
+	for i := 0; i < 2; i++ {
+		report, err := newSyncer(root, tr).Sync()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(report.Conflicts) != 1 || report.Conflicts[0].Field != "status" || report.Conflicts[0].Resolved != "manual" {
+			t.Fatalf("run %d conflicts = %+v", i, report.Conflicts)
+		}
+	}
+	if !strings.Contains(strings.Join(tr.issues[1].Labels, ","), "status:blocked") {
+		t.Errorf("manual conflict must not overwrite the issue: %+v", tr.issues[1].Labels)
+	}
+
+	s := newSyncer(root, tr)
+	s.Rule = RuleRemote
+	report, err := s.Sync()
+	if err != nil || len(report.Conflicts) != 1 || report.Conflicts[0].Resolved != "remote" {
+		t.Fatalf("remote rule = %+v, %v", report, err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "status: blocked") {
+		t.Errorf("remote rule should take the issue's status:\n%s", data)
+	}
+	if report, _ := newSyncer(root, tr).Sync(); len(report.Conflicts) != 0 {
+		t.Errorf("conflict should be resolved: %+v", report.Conflicts)
+	}
+}
+
+func TestSync_SavesMappingOnFailure(t *testing.T) {
+	root := t.TempDir()
+	writeWorkstream(t, root, "backlog", "00-050-01", "status: backlog\n")
+	writeWorkstream(t, root, "backlog", "00-050-02", "status: backlog\n")
+	tr := &onceTracker{fakeTracker: newFakeTracker()}
+
+	if _, err := newSyncer(root, tr).Sync(); err == nil {
+		t.Fatal("expected error from second create")
+	}
+	links, _, _ := ReadLinks(filepath.Join(root, MappingFile), "fake:acme/app")
+	if len(links) != 1 || links["00-050-01"] == nil {
+		t.Errorf("links = %+v; the created issue must be recorded", links)
+	}
+	if _, err := os.Stat(filepath.Join(root, CursorFile)); err == nil {
+		t.Error("cursor must not advance after a failed run")
+	}
+}
+
+// onceTracker fails every write after the first.
+type onceTracker struct{ *fakeTracker }
+
+func (o *onceTracker) Create(is Issue) (*Issue, error) {
+	created, err := o.fakeTracker.Create(is)
+	o.failWrite = true
+	return created, err
+}
@@ -0,0 +1,140 @@
+// Package issues syncs workstreams with GitHub Issues or GitLab issues.
+//
+// Each workstream maps to one tracker issue, recorded in
+// .sdp-issues-mapping.jsonl. Status, labels and assignee sync both ways
+// with a three-way merge against the values both sides agreed on at the
+// last sync; title, goal and dependencies (as a task list) flow from the
+// workstream to the issue. Only issues updated since the previous sync
+// are fetched.
+package issues
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Label prefixes reserved for values SDP derives itself
+const (
+	statusLabelPrefix  = "status:"
+	featureLabelPrefix = "feature:"
+)
+
+// Issue is a tracker issue in the shape both REST clients share.
+type Issue struct {
+	Number   int
+	Title    string
+	Body     string
+	Closed   bool
+	Labels   []string
+	Assignee string
+	// OtherAssignees are the issue's further assignees. Only Assignee
+	// syncs; Create and Update set them as given.
+	OtherAssignees []string
+	URL            string
+	UpdatedAt      time.Time
+}
+
+// Tracker is a remote issue tracker.
+type Tracker interface {
+	Name() string
+	// ListUpdated returns issues updated at or after since; all issues
+	// when since is zero.
+	ListUpdated(since time.Time) ([]Issue, error)
+	Get(number int) (*Issue, error)
+	Create(is Issue) (*Issue, error)
+	// Update replaces title, body, state, labels and assignees.
+	Update(number int, is Issue) (*Issue, error)
+}
+
+// Fields are the values synced in both directions.
+type Fields struct {
+	Status   string   `json:"status"`
+	Labels   []string `json:"labels,omitempty"`
+	Assignee string   `json:"assignee,omitempty"`
+}
+
+func (f Fields) equal(o Fields) bool {
+	return f.Status == o.Status && f.Assignee == o.Assignee && slices.Equal(f.Labels, o.Labels)
+}
+
+// isClosedStatus reports whether a workstream status means the work is
+// finished and its issue should be closed.
+func isClosedStatus(status string) bool {
+	switch status {
+	case "completed", "done", "closed":
+		return true
+	}
+	return false
+}
+
+// localFields returns the synced values of a workstream.
+func localFields(ws *Workstream) Fields {
+	return Fields{Status: ws.Status, Labels: normalizeLabels(ws.Labels), Assignee: ws.Assignee}
+}
+
+// remoteFields derives workstream values from an issue. The status label
+// carries states the tracker has no notion of (in_progress, blocked);
+// closing or reopening the issue overrides it.
+func remoteFields(is *Issue) Fields {
+	var f Fields
+	var labels []string
+	for _, l := range is.Labels {
+		switch {
+		case strings.HasPrefix(l, statusLabelPrefix):
+			f.Status = strings.TrimPrefix(l, statusLabelPrefix)
+		case strings.HasPrefix(l, featureLabelPrefix):
+		default:
+			labels = append(labels, l)
+		}
+	}
+	switch {
+	case is.Closed && !isClosedStatus(f.Status):
+		f.Status = "completed"
+	case !is.Closed && (f.Status == "" || isClosedStatus(f.Status)):
+		f.Status = "backlog"
+	}
+	f.Labels = normalizeLabels(labels)
+	f.Assignee = is.Assignee
+	return f
+}
+
+// assignees lists Assignee first, then the other assignees, without
+// duplicates.
+func (is Issue) assignees() []string {
+	var out []string
+	for _, a := range append([]string{is.Assignee}, is.OtherAssignees...) {
+		if a != "" && !slices.Contains(out, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// issueLabels returns the labels to set on a workstream's issue.
+func issueLabels(f Fields, feature string) []string {
+	labels := append([]string{}, f.Labels...)
+	if f.Status != "" {
+		labels = append(labels, statusLabelPrefix+f.Status)
+	}
+	if feature != "" {
+		labels = append(labels, featureLabelPrefix+feature)
+	}
+	return labels
+}
+
+// normalizeLabels sorts and de-duplicates labels so both sides compare equal.
+func normalizeLabels(labels []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, l := range labels {
+		l = strings.TrimSpace(l)
+		if l != "" && !seen[l] {
+			seen[l] = true
+			out = append(out, l)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,183 @@
+package issues
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workstreamDirs are searched for workstream files, as by "sdp parse"
+var workstreamDirs = []string{
+	"docs/workstreams/backlog",
+	"docs/workstreams/in_progress",
+	"docs/workstreams/completed",
+}
+
+// Workstream is the part of a workstream file that is synced.
+type Workstream struct {
+	ID        string
+	Title     string
+	Feature   string
+	Status    string
+	Goal      string
+	Assignee  string
+	Labels    []string
+	DependsOn []string
+	Path      string // relative to the project root
+	ModTime   time.Time
+}
+
+type workstreamFrontmatter struct {
+	WSID      string   `yaml:"ws_id"`
+	Feature   string   `yaml:"feature"`
+	FeatureID string   `yaml:"feature_id"`
+	Status    string   `yaml:"status"`
+	Assignee  string   `yaml:"assignee"`
+	Labels    []string `yaml:"labels"`
+	DependsOn []string `yaml:"depends_on"`
+}
+
+// LoadWorkstreams reads every workstream under root, sorted by ID. Files
+// without ws_id frontmatter are skipped.
+func LoadWorkstreams(root string) ([]*Workstream, error) {
+	var out []*Workstream
+	for _, dir := range workstreamDirs {
+		matches, _ := filepath.Glob(filepath.Join(root, dir, "*.md"))
+		for _, path := range matches {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("read workstream: %w", err)
+			}
+			ws, ok := parseWorkstream(content)
+			if !ok {
+				continue
+			}
+			ws.Path, _ = filepath.Rel(root, path)
+			if info, err := os.Stat(path); err == nil {
+				ws.ModTime = info.ModTime()
+			}
+			out = append(out, ws)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func parseWorkstream(content []byte) (*Workstream, bool) {
+	parts := bytes.SplitN(content, []byte("---"), 3)
+	if len(parts) < 3 || len(bytes.TrimSpace(parts[0])) != 0 {
+		return nil, false
+	}
+	var fm workstreamFrontmatter
+	if err := yaml.Unmarshal(parts[1], &fm); err != nil || fm.WSID == "" {
+		return nil, false
+	}
+	ws := &Workstream{
+		ID:        fm.WSID,
+		Feature:   fm.FeatureID,
+		Status:    fm.Status,
+		Assignee:  fm.Assignee,
+		Labels:    fm.Labels,
+		DependsOn: fm.DependsOn,
+	}
+	if ws.Feature == "" {
+		ws.Feature = fm.Feature
+	}
+	inGoal := false
+	var goal []string
+	for _, line := range strings.Split(string(parts[2]), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "# ") && ws.Title == "":
+			ws.Title = strings.TrimPrefix(trimmed, "# ")
+		case strings.HasPrefix(trimmed, "#"):
+			inGoal = strings.TrimLeft(trimmed, "# ") == "Goal"
+		case inGoal && trimmed != "":
+			goal = append(goal, trimmed)
+		}
+	}
+	ws.Goal = strings.Join(goal, " ")
+	if ws.Title == "" {
+		ws.Title = ws.ID
+	}
+	return ws, true
+}
+
+// writeFields stores synced values in the workstream's frontmatter,
+// leaving the rest of the file untouched.
+func writeFields(root string, ws *Workstream, f Fields) error {
+	path := filepath.Join(root, ws.Path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read workstream: %w", err)
+	}
+	lines := strings.Split(string(content), "\n")
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" || end < 0 {
+		return fmt.Errorf("%s: no frontmatter", ws.Path)
+	}
+	fm := lines[1:end]
+	fm = setFrontmatterKey(fm, "status", yamlScalar(f.Status))
+	fm = setFrontmatterKey(fm, "assignee", yamlScalar(f.Assignee))
+	labels := ""
+	if len(f.Labels) > 0 {
+		data, _ := json.Marshal(f.Labels) // a JSON array is a YAML flow sequence
+		labels = string(data)
+	}
+	fm = setFrontmatterKey(fm, "labels", labels)
+	out := append(append([]string{lines[0]}, fm...), lines[end:]...)
+	if err := os.WriteFile(path, []byte(strings.Join(out, "\n")), 0o644); err != nil {
+		return fmt.Errorf("write workstream: %w", err)
+	}
+	ws.Status, ws.Assignee, ws.Labels = f.Status, f.Assignee, f.Labels
+	return nil
+}
+
+// setFrontmatterKey replaces a top-level key (and any block list under
+// it), appends it when missing, or removes it when value is empty.
+func setFrontmatterKey(fm []string, key, value string) []string {
+	var out []string
+	found := false
+	for i := 0; i < len(fm); i++ {
+		if !strings.HasPrefix(fm[i], key+":") {
+			out = append(out, fm[i])
+			continue
+		}
+		for i+1 < len(fm) && (strings.HasPrefix(fm[i+1], " ") || strings.HasPrefix(fm[i+1], "-")) {
+			i++
+		}
+		if value != "" && !found {
+			out = append(out, key+": "+value)
+		}
+		found = true
+	}
+	if !found && value != "" {
+		out = append(out, key+": "+value)
+	}
+	return out
+}
+
+var plainScalar = regexp.MustCompile(`^[A-Za-z0-9_.@/-]*$`)
+
+// yamlScalar quotes s unless it is safe as a plain YAML scalar.
+func yamlScalar(s string) string {
+	if plainScalar.MatchString(s) {
+		return s
+	}
+	data, _ := json.Marshal(s)
+	return string(data)
+}
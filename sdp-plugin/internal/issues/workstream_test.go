@@ -0,0 +1,91 @@
+package issues
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeWorkstream(t *testing.T, root, dir, id, frontmatter string) string {
+	t.Helper()
+	path := filepath.Join(root, "docs", "workstreams", dir, id+".md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nws_id: " + id + "\nfeature_id: F050\n" + frontmatter + "---\n\n# " + id + ": Title of " + id + "\n\n## Goal\n\nShip " + id + ".\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadWorkstreams(t *testing.T) {
+	root := t.TempDir()
+	writeWorkstream(t, root, "completed", "00-050-02", "status: completed\n")
+	writeWorkstream(t, root, "backlog", "00-050-01", "status: backlog\nassignee: alice\nlabels: [api]\ndepends_on: [\"00-050-02\"]\n")
+	if err := os.WriteFile(filepath.Join(root, "docs", "workstreams", "backlog", "README.md"), []byte("# notes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := LoadWorkstreams(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].ID != "00-050-01" {
+		t.Fatalf("LoadWorkstreams = %+v", all)
+	}
+	ws := all[0]
+	if ws.Title != "00-050-01: Title of 00-050-01" || ws.Goal != "Ship 00-050-01." || ws.Feature != "F050" ||
+		ws.Assignee != "alice" || ws.Labels[0] != "api" || ws.DependsOn[0] != "00-050-02" ||
+		ws.Path != filepath.Join("docs", "workstreams", "backlog", "00-050-01.md") || ws.ModTime.IsZero() {
+		t.Errorf("workstream = %+v", ws)
+	}
+}
+
+func TestWriteFields(t *testing.T) {
+	root := t.TempDir()
+	path := writeWorkstream(t, root, "backlog", "00-050-01", "status: backlog\nlabels:\n  - old\n  - stale\nassignee: alice\nsize: SMALL\n")
+	all, _ := LoadWorkstreams(root)
+
+	if err := writeFields(root, all[0], Fields{Status: "in_progress", Labels: []string{"api", "needs review"}}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	for _, want := range []string{"status: in_progress\n", "labels: [\"api\",\"needs review\"]\n", "size: SMALL\n", "## Goal\n\nShip 00-050-01.\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "assignee") || strings.Contains(got, "stale") {
+		t.Errorf("empty assignee and old labels must be removed:\n%s", got)
+	}
+
+	again, _ := LoadWorkstreams(root)
+	if f := localFields(again[0]); f.Status != "in_progress" || strings.Join(f.Labels, ",") != "api,needs review" {
+		t.Errorf("round trip = %+v", f)
+	}
+}
+
+func TestSpliceBlock(t *testing.T) {
+	ws := &Workstream{ID: "00-050-01", Feature: "F050", Path: "docs/workstreams/backlog/00-050-01.md", Goal: "Ship it."}
+	block := renderBlock(ws, []dependency{
+		{WSID: "00-050-02", Title: "00-050-02: Mapping", Number: 12, Done: true},
+		{WSID: "00-049-01"},
+	})
+	for _, want := range []string{"- [x] #12 00-050-02 Mapping\n", "- [ ] 00-049-01\n", "Ship it."} {
+		if !strings.Contains(block, want) {
+			t.Errorf("block missing %q:\n%s", want, block)
+		}
+	}
+
+	body := spliceBlock("PM notes", block)
+	if !strings.HasPrefix(body, blockStart) || !strings.HasSuffix(body, "PM notes") {
+		t.Errorf("first splice = %q", body)
+	}
+	replaced := spliceBlock(body, renderBlock(ws, nil))
+	if strings.Contains(replaced, "00-049-01") || !strings.HasSuffix(replaced, "PM notes") || strings.Count(replaced, blockStart) != 1 {
+		t.Errorf("second splice = %q", replaced)
+	}
+}
@@ -0,0 +1,156 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/doctor"
+	"github.com/fall-out-bug/sdp/internal/parser"
+)
+
+// diagnosticSource names the server in editor diagnostics
+const diagnosticSource = "sdp"
+
+var strictWSID = regexp.MustCompile(`^\d{2}-\d{3}-\d{2}$`)
+
+// diagnose checks a workstream document: parser.ValidateContent issues,
+// depends_on references that are malformed or unknown, and dependency
+// cycles reachable from it (doctor.DetectCycles). Documents without
+// workstream frontmatter get no diagnostics.
+func (w *workspace) diagnose(path, text string) []Diagnostic {
+	e, ok := parseEntry(path, []byte(text))
+	if !ok {
+		return []Diagnostic{}
+	}
+	lines := strings.Split(text, "\n")
+	diags := []Diagnostic{}
+	add := func(r Range, severity int, msg string) {
+		diags = append(diags, Diagnostic{Range: r, Severity: severity, Source: diagnosticSource, Message: msg})
+	}
+
+	issues, err := parser.ValidateContent(path, []byte(text))
+	if err != nil {
+		add(lineRange(lines, 0), SeverityError, err.Error())
+	}
+	for _, issue := range issues {
+		severity := SeverityWarning
+		if issue.Severity == "ERROR" {
+			severity = SeverityError
+		}
+		add(lineRange(lines, issueLine(lines, issue)), severity, issue.Message)
+	}
+
+	for _, dep := range e.DependsOn {
+		r := dependencyRange(lines, dep)
+		switch {
+		case !strictWSID.MatchString(dep):
+			add(r, SeverityError, fmt.Sprintf("invalid workstream ID in depends_on: %s (expected PP-FFF-SS)", dep))
+		case w.entries[dep] == nil:
+			add(r, SeverityError, fmt.Sprintf("unknown dependency: %s (no workstream file found)", dep))
+		}
+	}
+
+	depsLine := keyLine(lines, "depends_on")
+	for _, cycle := range w.cyclesFrom(e) {
+		add(lineRange(lines, depsLine), SeverityError, "circular dependency: "+cycle)
+	}
+	return diags
+}
+
+// cyclesFrom runs cycle detection on the dependency graph reachable from e,
+// using e's current (possibly unsaved) depends_on.
+func (w *workspace) cyclesFrom(e *entry) []string {
+	deps := make(map[string][]string)
+	queue := []string{e.ID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, seen := deps[id]; seen {
+			continue
+		}
+		var next []string
+		if id == e.ID {
+			next = e.DependsOn
+		} else if dep := w.entries[id]; dep != nil {
+			next = dep.DependsOn
+		}
+		deps[id] = next
+		queue = append(queue, next...)
+	}
+	return doctor.DetectCycles(deps)
+}
+
+// issueLine finds the line a validation issue refers to, defaulting to
+// the first line.
+func issueLine(lines []string, issue parser.ValidationIssue) int {
+	switch issue.Field {
+	case "ws_id", "status":
+		return keyLine(lines, issue.Field)
+	case "feature":
+		if n := keyLine(lines, "feature_id"); n > 0 {
+			return n
+		}
+		return keyLine(lines, "feature")
+	case "goal":
+		return headingLine(lines, "Goal")
+	case "acceptance_criteria":
+		return headingLine(lines, "Acceptance Criteria")
+	case "scope_files":
+		if _, file, ok := strings.Cut(issue.Message, ": "); ok {
+			for i, line := range lines {
+				if strings.Contains(line, file) {
+					return i
+				}
+			}
+		}
+		return headingLine(lines, "Scope Files")
+	}
+	return 0
+}
+
+// keyLine returns the frontmatter line defining key, or 0
+func keyLine(lines []string, key string) int {
+	end := frontmatterEnd(lines)
+	for i := 1; i < end; i++ {
+		if strings.HasPrefix(lines[i], key+":") {
+			return i
+		}
+	}
+	return 0
+}
+
+// headingLine returns the line of a "## name" heading, or 0
+func headingLine(lines []string, name string) int {
+	for i, line := range lines {
+		t := strings.TrimSpace(line)
+		if strings.HasPrefix(t, "##") && strings.TrimSpace(strings.TrimLeft(t, "#")) == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// frontmatterEnd returns the index of the closing "---" line
+func frontmatterEnd(lines []string) int {
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+// dependencyRange locates dep in the depends_on entry of the frontmatter
+func dependencyRange(lines []string, dep string) Range {
+	start := keyLine(lines, "depends_on")
+	for i := start; i < frontmatterEnd(lines) && dep != ""; i++ {
+		if i > start && !strings.HasPrefix(strings.TrimSpace(lines[i]), "-") {
+			break
+		}
+		if j := strings.Index(lines[i], dep); j >= 0 {
+			return spanRange(lines, i, j, j+len(dep))
+		}
+	}
+	return lineRange(lines, start)
+}
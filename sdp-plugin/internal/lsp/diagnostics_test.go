@@ -0,0 +1,64 @@
+package lsp
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	root := writeProject(t)
+	t.Chdir(root)
+	w := loadWorkspace(root)
+	path := filepath.Join(root, "docs/workstreams/backlog/00-047-05.md")
+
+	tests := []struct {
+		name string
+		text string
+		want []string // expected messages, by substring
+	}{
+		{"valid", wsDoc("00-047-05", "backlog", "00-047-01"), nil},
+		{"unknown dependency", wsDoc("00-047-05", "backlog", "00-047-99"), []string{"unknown dependency: 00-047-99"}},
+		{"malformed dependency", wsDoc("00-047-05", "backlog", "47-1"), []string{"invalid workstream ID in depends_on: 47-1"}},
+		{"cycle", wsDoc("00-047-05", "backlog", "00-047-03"), []string{"circular dependency: "}},
+		{"parser issues", strings.Replace(wsDoc("00-47-05", "", "00-047-01"), "internal/app/main.go", "internal/app/gone.go", 1),
+			[]string{"invalid workstream ID format", "status field is recommended", "implementation file not found: internal/app/gone.go"}},
+		{"not a workstream", "# Spec F047\n\nDepends on 00-047-99.\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := w.diagnose(path, tt.text)
+			if len(diags) != len(tt.want) {
+				t.Fatalf("got %d diagnostics %+v, want %v", len(diags), diags, tt.want)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(diags[i].Message, want) || diags[i].Source != "sdp" {
+					t.Errorf("diagnostic %d = %+v, want %q", i, diags[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnose_Ranges(t *testing.T) {
+	root := writeProject(t)
+	t.Chdir(root)
+	text := wsDoc("00-047-05", "backlog", "00-047-01", "00-047-99")
+	diags := loadWorkspace(root).diagnose("00-047-05.md", text)
+	if len(diags) != 1 {
+		t.Fatalf("diagnostics = %+v", diags)
+	}
+	// depends_on: [00-047-01, 00-047-99] is line 4; the ID starts at byte 24
+	want := Range{Start: Position{Line: 4, Character: 24}, End: Position{Line: 4, Character: 33}}
+	if diags[0].Range != want || diags[0].Severity != SeverityError {
+		t.Errorf("diagnostic = %+v, want range %+v", diags[0], want)
+	}
+
+	lines := strings.Split(text, "\n")
+	if got := keyLine(lines, "status"); got != 3 {
+		t.Errorf("keyLine(status) = %d", got)
+	}
+	if got := headingLine(lines, "Acceptance Criteria"); lines[got] != "## Acceptance Criteria" {
+		t.Errorf("headingLine = %d", got)
+	}
+}
@@ -0,0 +1,192 @@
+package lsp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxFileCompletions caps scope file proposals per request
+const maxFileCompletions = 200
+
+// What a completion position expects
+const (
+	expectNone = iota
+	expectWorkstream
+	expectFeature
+	expectFile
+)
+
+// complete proposes workstream IDs in depends_on, feature IDs in
+// feature_id and project files in scope file lists.
+func (w *workspace) complete(text string, pos Position) []CompletionItem {
+	lines := strings.Split(text, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return []CompletionItem{}
+	}
+	line := lines[pos.Line]
+	col := byteOffset(line, pos.Character)
+	start, _ := tokenBounds(line, col)
+	prefix := line[start:col]
+	edit := func(label string) *TextEdit {
+		return &TextEdit{Range: spanRange(lines, pos.Line, start, col), NewText: label}
+	}
+
+	items := []CompletionItem{}
+	switch expectAt(lines, pos.Line, prefix) {
+	case expectWorkstream:
+		self := frontmatterValue(lines, "ws_id")
+		for _, id := range w.ids() {
+			if strings.HasPrefix(id, prefix) && id != self {
+				e := w.entries[id]
+				items = append(items, CompletionItem{Label: id, Kind: KindReference, Detail: e.Status + " · " + e.Title, TextEdit: edit(id)})
+			}
+		}
+	case expectFeature:
+		for feature, entries := range w.features() {
+			if strings.HasPrefix(feature, prefix) {
+				items = append(items, CompletionItem{Label: feature, Kind: KindModule, Detail: fmt.Sprintf("%d workstreams", len(entries)), TextEdit: edit(feature)})
+			}
+		}
+	case expectFile:
+		for _, f := range w.projectFiles() {
+			if strings.HasPrefix(f, prefix) {
+				items = append(items, CompletionItem{Label: f, Kind: KindFile, TextEdit: edit(f)})
+				if len(items) == maxFileCompletions {
+					break
+				}
+			}
+		}
+	}
+	return items
+}
+
+// expectAt works out what belongs at line n from the frontmatter key or
+// markdown section it is in, falling back to the shape of the prefix.
+func expectAt(lines []string, n int, prefix string) int {
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" && n > 0 && n < frontmatterEnd(lines) {
+		for i := n; i > 0; i-- {
+			key, _, ok := strings.Cut(lines[i], ":")
+			if !ok || strings.HasPrefix(lines[i], " ") || strings.HasPrefix(lines[i], "-") {
+				continue
+			}
+			switch key {
+			case "depends_on", "parent":
+				return expectWorkstream
+			case "feature_id", "feature":
+				return expectFeature
+			case "scope_files":
+				return expectFile
+			}
+			return expectNone
+		}
+	}
+	if strings.HasPrefix(strings.TrimSpace(lines[n]), "-") && currentHeading(lines, n) == "Scope Files" {
+		return expectFile
+	}
+	switch {
+	case prefix == "":
+		return expectNone
+	case prefix[0] >= '0' && prefix[0] <= '9':
+		return expectWorkstream
+	case prefix[0] == 'F':
+		return expectFeature
+	}
+	return expectNone
+}
+
+// frontmatterValue returns the scalar value of key, read line by line so
+// that it works while the rest of the frontmatter is being edited.
+func frontmatterValue(lines []string, key string) string {
+	if n := keyLine(lines, key); n > 0 {
+		return strings.TrimSpace(strings.TrimPrefix(lines[n], key+":"))
+	}
+	return ""
+}
+
+// currentHeading returns the name of the nearest heading above line n
+func currentHeading(lines []string, n int) string {
+	for i := n; i >= 0; i-- {
+		if t := strings.TrimSpace(lines[i]); strings.HasPrefix(t, "#") {
+			return strings.TrimSpace(strings.TrimLeft(t, "#"))
+		}
+	}
+	return ""
+}
+
+// hover describes the workstream or feature ID under the cursor
+func (w *workspace) hover(text string, pos Position) *Hover {
+	tok, r, ok := tokenAtPosition(text, pos)
+	if !ok {
+		return nil
+	}
+	var md strings.Builder
+	switch {
+	case w.entries[tok] != nil:
+		e := w.entries[tok]
+		fmt.Fprintf(&md, "**%s** %s\n\nStatus: `%s` · Feature: %s\n", e.ID, e.Title, e.Status, e.Feature)
+		if e.Goal != "" {
+			fmt.Fprintf(&md, "\n%s\n", e.Goal)
+		}
+		if len(e.DependsOn) > 0 {
+			fmt.Fprintf(&md, "\nDepends on: %s\n", strings.Join(e.DependsOn, ", "))
+		}
+	case featureIDPattern.MatchString(tok) && len(w.features()[tok]) > 0:
+		fmt.Fprintf(&md, "**%s**\n\n", tok)
+		for _, e := range w.features()[tok] {
+			fmt.Fprintf(&md, "- %s `%s` %s\n", e.ID, e.Status, e.Title)
+		}
+	default:
+		return nil
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: md.String()}, Range: &r}
+}
+
+// definition resolves a workstream ID to its file, or a scope file path
+// to the file itself.
+func (w *workspace) definition(text string, pos Position) []Location {
+	tok, _, ok := tokenAtPosition(text, pos)
+	if !ok {
+		return []Location{}
+	}
+	if e := w.entries[tok]; e != nil {
+		return []Location{{URI: pathToURI(e.Path)}}
+	}
+	if filepath.IsLocal(tok) {
+		path := filepath.Join(w.root, filepath.FromSlash(tok))
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return []Location{{URI: pathToURI(path)}}
+		}
+	}
+	return []Location{}
+}
+
+// tokenAtPosition returns the ID or path token under the cursor
+func tokenAtPosition(text string, pos Position) (string, Range, bool) {
+	lines := strings.Split(text, "\n")
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return "", Range{}, false
+	}
+	line := lines[pos.Line]
+	start, end := tokenBounds(line, byteOffset(line, pos.Character))
+	if start == end {
+		return "", Range{}, false
+	}
+	return line[start:end], spanRange(lines, pos.Line, start, end), true
+}
+
+// tokenBounds expands col to the surrounding run of ID/path characters
+func tokenBounds(line string, col int) (start, end int) {
+	isToken := func(c byte) bool {
+		return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-_./", c) >= 0
+	}
+	start, end = col, col
+	for start > 0 && isToken(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isToken(line[end]) {
+		end++
+	}
+	return start, end
+}
@@ -0,0 +1,90 @@
+package lsp
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cursor returns the position just after the first occurrence of marker
+func cursor(t *testing.T, text, marker string) Position {
+	t.Helper()
+	for i, line := range strings.Split(text, "\n") {
+		if j := strings.Index(line, marker); j >= 0 {
+			return Position{Line: i, Character: j + len(marker)}
+		}
+	}
+	t.Fatalf("marker %q not found", marker)
+	return Position{}
+}
+
+func labels(items []CompletionItem) string {
+	var out []string
+	for _, it := range items {
+		out = append(out, it.Label)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestComplete(t *testing.T) {
+	root := writeProject(t)
+	w := loadWorkspace(root)
+
+	tests := []struct {
+		name, text, marker, want string
+	}{
+		{"depends_on inline", "---\nws_id: 00-047-02\ndepends_on: [00-047-0\n---\n", "[00-047-0", "00-047-01,00-047-03,00-047-04"},
+		{"depends_on list", "---\nws_id: 00-047-09\ndepends_on:\n  - 00-047-03\n---\n", "- 00-047-0", "00-047-01,00-047-02,00-047-03,00-047-04"},
+		{"feature_id", "---\nws_id: 00-047-09\nfeature_id: F0\n---\n", "F0", "F047"},
+		{"scope frontmatter", "---\nws_id: 00-047-09\nscope_files:\n  - internal/ap\n---\n", "internal/ap", "internal/app/main.go"},
+		{"scope section", "# Spec\n\n## Scope Files\n\n- `internal/\n", "internal/", "internal/app/main.go"},
+		{"prose", "# Spec\n\nThe status is fine\n", "status", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := w.complete(tt.text, cursor(t, tt.text, tt.marker))
+			if got := labels(items); got != tt.want {
+				t.Errorf("completions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	text := "---\nws_id: 00-047-09\ndepends_on: [00-047-0\n---\n"
+	items := w.complete(text, cursor(t, text, "[00-047-0"))
+	if e := items[0].TextEdit; e == nil || e.Range.Start.Character != 13 || e.Range.End.Character != 21 {
+		t.Errorf("text edit should replace the typed prefix: %+v", e)
+	}
+	if !strings.Contains(items[0].Detail, "completed") {
+		t.Errorf("detail should show status: %q", items[0].Detail)
+	}
+}
+
+func TestHoverAndDefinition(t *testing.T) {
+	root := writeProject(t)
+	w := loadWorkspace(root)
+	text := "# Spec F047\n\nBuilds on 00-047-02 and `internal/app/main.go`.\n"
+
+	h := w.hover(text, cursor(t, text, "on 00-04"))
+	if h == nil || !strings.Contains(h.Contents.Value, "**00-047-02**") || !strings.Contains(h.Contents.Value, "Status: `backlog`") ||
+		!strings.Contains(h.Contents.Value, "Ship 00-047-02.") || !strings.Contains(h.Contents.Value, "Depends on: 00-047-01") {
+		t.Errorf("workstream hover = %+v", h)
+	}
+	if h := w.hover(text, cursor(t, text, "F04")); h == nil || strings.Count(h.Contents.Value, "\n- ") != 4 {
+		t.Errorf("feature hover = %+v", h)
+	}
+	if h := w.hover(text, cursor(t, text, "Bui")); h != nil {
+		t.Errorf("hover on prose = %+v", h)
+	}
+
+	locs := w.definition(text, cursor(t, text, "on 00-04"))
+	if len(locs) != 1 || uriToPath(locs[0].URI) != filepath.Join(root, "docs/workstreams/backlog/00-047-02.md") {
+		t.Errorf("workstream definition = %+v", locs)
+	}
+	locs = w.definition(text, cursor(t, text, "internal/a"))
+	if len(locs) != 1 || uriToPath(locs[0].URI) != filepath.Join(root, "internal/app/main.go") {
+		t.Errorf("file definition = %+v", locs)
+	}
+	if locs := w.definition(text, cursor(t, text, "Bui")); len(locs) != 0 {
+		t.Errorf("definition on prose = %+v", locs)
+	}
+}
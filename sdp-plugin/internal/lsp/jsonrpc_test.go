@@ -0,0 +1,90 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// frame wraps a JSON-RPC body in LSP base protocol headers
+func frame(body string) string {
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// readMessages decodes every framed message written by the server
+func readMessages(t *testing.T, out []byte) []map[string]any {
+	t.Helper()
+	c := newConn(bytes.NewReader(out), io.Discard)
+	var msgs []map[string]any
+	for {
+		header, err := c.r.ReadMIMEHeader()
+		if err == io.EOF {
+			return msgs
+		}
+		if err != nil {
+			t.Fatalf("read header: %v", err)
+		}
+		var n int
+		fmt.Sscan(header.Get("Content-Length"), &n)
+		body := make([]byte, n)
+		if _, err := io.ReadFull(c.br, body); err != nil {
+			t.Fatal(err)
+		}
+		var m map[string]any
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, m)
+	}
+}
+
+func TestConn_ReadWrite(t *testing.T) {
+	in := frame(`{"jsonrpc":"2.0","id":1,"method":"hover","params":{}}`) + frame(`{"jsonrpc":"2.0","method":"initialized"}`)
+	var out bytes.Buffer
+	c := newConn(strings.NewReader(in), &out)
+
+	req, err := c.read()
+	if err != nil || req.Method != "hover" || string(req.ID) != "1" {
+		t.Fatalf("read = %+v, %v", req, err)
+	}
+	if err := c.reply(req.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if req, err = c.read(); err != nil || req.ID != nil {
+		t.Fatalf("notification = %+v, %v", req, err)
+	}
+	if _, err := c.read(); err != io.EOF {
+		t.Errorf("expected io.EOF at end of stream, got %v", err)
+	}
+
+	want := frame(`{"jsonrpc":"2.0","id":1,"result":null}`)
+	if out.String() != want {
+		t.Errorf("reply = %q, want %q", out.String(), want)
+	}
+}
+
+func TestConn_ReadErrors(t *testing.T) {
+	tests := []struct {
+		name, in string
+		fatal    bool
+	}{
+		{"missing length", "X-Other: 1\r\n\r\n{}", true},
+		{"oversized", fmt.Sprintf("Content-Length: %d\r\n\r\n", maxMessageBytes+1), true},
+		{"truncated body", "Content-Length: 10\r\n\r\n{}", true},
+		{"bad json", frame("{not json"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := newConn(strings.NewReader(tt.in), io.Discard).read()
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if fatal := req == nil; fatal != tt.fatal {
+				t.Errorf("fatal = %v, want %v (%v)", fatal, tt.fatal, err)
+			}
+		})
+	}
+}
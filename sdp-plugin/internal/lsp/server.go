@@ -0,0 +1,193 @@
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Server is an LSP server for SDP markdown. It keeps open documents in
+// memory and answers from a workstream index of the project root.
+type Server struct {
+	version  string
+	root     string
+	ws       *workspace
+	docs     map[string]string // open documents by URI
+	conn     *conn
+	shutdown bool
+}
+
+// NewServer creates a server for the project at root. The client's
+// rootUri, if any, replaces root on initialize.
+func NewServer(root, version string) *Server {
+	return &Server{version: version, root: root, docs: make(map[string]string)}
+}
+
+// Serve handles requests from in and writes responses to out until the
+// client sends exit or closes the stream. On initialize it changes the
+// working directory to the project root, because parser resolves scope
+// files relative to it.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.conn = newConn(in, out)
+	for {
+		req, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if req == nil {
+				return err
+			}
+			_ = s.conn.replyError(nil, codeParseError, err.Error())
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errors.New("exit before shutdown")
+			}
+			return nil
+		}
+		result, err := s.handleSafely(req)
+		if req.ID == nil {
+			continue // notifications get no response
+		}
+		if err != nil {
+			var rpcErr *responseError
+			if errors.As(err, &rpcErr) {
+				_ = s.conn.replyError(req.ID, rpcErr.Code, rpcErr.Message)
+			} else {
+				_ = s.conn.replyError(req.ID, codeInvalidParams, err.Error())
+			}
+			continue
+		}
+		if err := s.conn.reply(req.ID, result); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+}
+
+// handleSafely runs handle and turns a panic into an internal error, so
+// one bad request does not stop the server.
+func (s *Server) handleSafely(req *request) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, &responseError{Code: codeInternalError, Message: fmt.Sprintf("%s: internal error: %v", req.Method, r)}
+		}
+	}()
+	return s.handle(req)
+}
+
+// handle dispatches one request or notification
+func (s *Server) handle(req *request) (any, error) {
+	if s.ws == nil && req.Method != "initialize" {
+		s.ws = loadWorkspace(s.root)
+	}
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var p didOpenParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, err
+		}
+		s.setDocument(p.TextDocument.URI, p.TextDocument.Text)
+	case "textDocument/didChange":
+		var p didChangeParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if n := len(p.ContentChanges); n > 0 {
+			s.setDocument(p.TextDocument.URI, p.ContentChanges[n-1].Text)
+		}
+	case "textDocument/didSave":
+		var p didSaveParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.Text != nil {
+			s.setDocument(p.TextDocument.URI, *p.Text)
+		} else if text, ok := s.docs[p.TextDocument.URI]; ok {
+			s.setDocument(p.TextDocument.URI, text)
+		}
+	case "textDocument/didClose":
+		var p didOpenParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, err
+		}
+		uri := p.TextDocument.URI
+		delete(s.docs, uri)
+		if content, err := os.ReadFile(uriToPath(uri)); err == nil {
+			s.ws.update(uriToPath(uri), content)
+		}
+		return nil, s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}})
+	case "textDocument/completion", "textDocument/hover", "textDocument/definition":
+		var p positionParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, err
+		}
+		if p.Position.Line < 0 || p.Position.Character < 0 {
+			return nil, fmt.Errorf("invalid position %d:%d", p.Position.Line, p.Position.Character)
+		}
+		text := s.docs[p.TextDocument.URI]
+		switch req.Method {
+		case "textDocument/completion":
+			return s.ws.complete(text, p.Position), nil
+		case "textDocument/hover":
+			return s.ws.hover(text, p.Position), nil
+		default:
+			return s.ws.definition(text, p.Position), nil
+		}
+	default:
+		if req.ID != nil {
+			return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+		}
+	}
+	return nil, nil
+}
+
+// initialize records the workspace root and advertises capabilities
+func (s *Server) initialize(raw json.RawMessage) (any, error) {
+	var p initializeParams
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, err
+		}
+	}
+	if root := uriToPath(p.RootURI); root != "" {
+		s.root = root
+	} else if p.RootPath != "" {
+		s.root = p.RootPath
+	}
+	if abs, err := filepath.Abs(s.root); err == nil {
+		s.root = abs
+	}
+	if err := os.Chdir(s.root); err != nil {
+		return nil, fmt.Errorf("failed to enter workspace root: %w", err)
+	}
+	s.ws = loadWorkspace(s.root)
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync":   map[string]any{"openClose": true, "change": 1, "save": map[string]any{"includeText": false}},
+			"completionProvider": map[string]any{"triggerCharacters": []string{"-", "/"}},
+			"hoverProvider":      true,
+			"definitionProvider": true,
+		},
+		"serverInfo": map[string]any{"name": "sdp", "version": s.version},
+	}, nil
+}
+
+// setDocument stores a document, re-indexes it and publishes diagnostics
+func (s *Server) setDocument(uri, text string) {
+	s.docs[uri] = text
+	path := uriToPath(uri)
+	s.ws.update(path, []byte(text))
+	_ = s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: s.ws.diagnose(path, text)})
+}
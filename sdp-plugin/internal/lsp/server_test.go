@@ -0,0 +1,142 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// session runs the server over the given JSON-RPC bodies and returns what
+// it wrote.
+func session(t *testing.T, root string, bodies ...any) ([]map[string]any, error) {
+	t.Helper()
+	t.Chdir(root) // Serve changes directory; restore it after the test
+	var in strings.Builder
+	for _, b := range bodies {
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		in.WriteString(frame(string(data)))
+	}
+	var out bytes.Buffer
+	err := NewServer(root, "test").Serve(strings.NewReader(in.String()), &out)
+	return readMessages(t, out.Bytes()), err
+}
+
+func call(id int, method string, params any) map[string]any {
+	return map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params}
+}
+
+func notify(method string, params any) map[string]any {
+	return map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+}
+
+func TestServer_Session(t *testing.T) {
+	root := writeProject(t)
+	uri := pathToURI(filepath.Join(root, "docs/workstreams/backlog/00-047-05.md"))
+	text := wsDoc("00-047-05", "backlog", "00-047-02", "00-047-99")
+	at := func(marker string) map[string]any {
+		return map[string]any{"textDocument": map[string]any{"uri": uri}, "position": cursor(t, text, marker)}
+	}
+
+	msgs, err := session(t, root,
+		call(1, "initialize", map[string]any{"rootUri": pathToURI(root)}),
+		notify("initialized", map[string]any{}),
+		notify("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "languageId": "markdown", "version": 1, "text": text}}),
+		call(2, "textDocument/hover", at("[00-047-0")),
+		call(3, "textDocument/definition", at("[00-047-0")),
+		call(4, "textDocument/completion", at("[00-047-0")),
+		call(5, "workspace/unknown", map[string]any{}),
+		call(6, "shutdown", nil),
+		notify("exit", nil),
+	)
+	if err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if len(msgs) != 7 {
+		t.Fatalf("got %d messages: %v", len(msgs), msgs)
+	}
+
+	caps := msgs[0]["result"].(map[string]any)["capabilities"].(map[string]any)
+	if caps["hoverProvider"] != true || caps["definitionProvider"] != true || caps["completionProvider"] == nil {
+		t.Errorf("capabilities = %v", caps)
+	}
+
+	diags := msgs[1]["params"].(map[string]any)["diagnostics"].([]any)
+	if msgs[1]["method"] != "textDocument/publishDiagnostics" || len(diags) != 1 ||
+		!strings.Contains(diags[0].(map[string]any)["message"].(string), "unknown dependency: 00-047-99") {
+		t.Errorf("diagnostics = %v", msgs[1])
+	}
+
+	hover := msgs[2]["result"].(map[string]any)["contents"].(map[string]any)["value"].(string)
+	if !strings.Contains(hover, "**00-047-02**") || !strings.Contains(hover, "Status: `backlog`") {
+		t.Errorf("hover = %q", hover)
+	}
+	locs := msgs[3]["result"].([]any)
+	if len(locs) != 1 || !strings.HasSuffix(locs[0].(map[string]any)["uri"].(string), "/00-047-02.md") {
+		t.Errorf("definition = %v", locs)
+	}
+	if items := msgs[4]["result"].([]any); len(items) != 4 {
+		t.Errorf("completion = %v", items)
+	}
+	if e := msgs[5]["error"].(map[string]any); e["code"].(float64) != codeMethodNotFound {
+		t.Errorf("unknown method error = %v", e)
+	}
+	if _, ok := msgs[6]["result"]; !ok || msgs[6]["result"] != nil {
+		t.Errorf("shutdown reply = %v", msgs[6])
+	}
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	root := writeProject(t)
+	if _, err := session(t, root, notify("exit", nil)); err == nil {
+		t.Error("exit before shutdown should be reported")
+	}
+}
+
+func TestServer_RejectsNegativePositions(t *testing.T) {
+	root := writeProject(t)
+	uri := pathToURI(filepath.Join(root, "docs/workstreams/backlog/00-047-05.md"))
+	text := wsDoc("00-047-05", "backlog", "00-047-02")
+	bad := map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": -1, "character": 0}}
+
+	msgs, err := session(t, root,
+		call(1, "initialize", map[string]any{"rootUri": pathToURI(root)}),
+		notify("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "languageId": "markdown", "version": 1, "text": text}}),
+		call(2, "textDocument/hover", bad),
+		call(3, "textDocument/completion", bad),
+		call(4, "shutdown", nil),
+		notify("exit", nil),
+	)
+	if err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if len(msgs) != 5 {
+		t.Fatalf("got %d messages: %v", len(msgs), msgs)
+	}
+	for _, m := range msgs[2:4] {
+		if e, ok := m["error"].(map[string]any); !ok || e["code"].(float64) != codeInvalidParams {
+			t.Errorf("negative position reply = %v", m)
+		}
+	}
+	if ws := (&workspace{}); len(ws.complete(text, Position{Line: -1})) != 0 {
+		t.Error("complete should ignore a negative line")
+	}
+	if _, _, ok := tokenAtPosition(text, Position{Line: -1}); ok {
+		t.Error("tokenAtPosition should ignore a negative line")
+	}
+}
+
+func TestServer_RecoversFromPanic(t *testing.T) {
+	s := &Server{ws: &workspace{}} // nil docs map: setDocument panics
+	params, _ := json.Marshal(map[string]any{"textDocument": map[string]any{"uri": "file:///x.md", "text": "x"}})
+	_, err := s.handleSafely(&request{Method: "textDocument/didOpen", Params: params})
+	var rpcErr *responseError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != codeInternalError {
+		t.Errorf("err = %v, want an internal error", err)
+	}
+}
@@ -0,0 +1,173 @@
+package lsp
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workstreamDirs are searched for workstream files, as by "sdp parse"
+var workstreamDirs = []string{
+	"docs/workstreams/backlog",
+	"docs/workstreams/in_progress",
+	"docs/workstreams/completed",
+}
+
+const (
+	// maxIndexedFiles caps the scope file completion index
+	maxIndexedFiles = 20000
+	// fileIndexTTL is how long the scope file index is reused
+	fileIndexTTL = 10 * time.Second
+)
+
+var featureIDPattern = regexp.MustCompile(`^F\d{2,4}$`)
+
+// entry is the part of a workstream the server shows and links to. It is
+// read leniently so files that fail strict parsing are still indexed.
+type entry struct {
+	ID        string
+	Feature   string
+	Status    string
+	Title     string
+	Goal      string
+	DependsOn []string
+	Path      string
+}
+
+type entryFrontmatter struct {
+	WSID      string   `yaml:"ws_id"`
+	Feature   string   `yaml:"feature"`
+	FeatureID string   `yaml:"feature_id"`
+	Status    string   `yaml:"status"`
+	DependsOn []string `yaml:"depends_on"`
+}
+
+// parseEntry reads a workstream's frontmatter, title and goal. It returns
+// false when content has no frontmatter with a ws_id.
+func parseEntry(path string, content []byte) (*entry, bool) {
+	parts := bytes.SplitN(content, []byte("---"), 3)
+	if len(parts) < 3 || len(bytes.TrimSpace(parts[0])) != 0 {
+		return nil, false
+	}
+	var fm entryFrontmatter
+	if err := yaml.Unmarshal(parts[1], &fm); err != nil || fm.WSID == "" {
+		return nil, false
+	}
+	e := &entry{ID: fm.WSID, Feature: fm.FeatureID, Status: fm.Status, DependsOn: fm.DependsOn, Path: path}
+	if e.Feature == "" {
+		e.Feature = fm.Feature
+	}
+	inGoal := false
+	var goal []string
+	for _, line := range strings.Split(string(parts[2]), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "# ") && e.Title == "":
+			e.Title = strings.TrimPrefix(trimmed, "# ")
+		case strings.HasPrefix(trimmed, "#"):
+			inGoal = strings.TrimLeft(trimmed, "# ") == "Goal"
+		case inGoal && trimmed != "":
+			goal = append(goal, trimmed)
+		}
+	}
+	e.Goal = strings.Join(goal, " ")
+	return e, true
+}
+
+// workspace indexes workstreams and files under the project root
+type workspace struct {
+	root      string
+	entries   map[string]*entry
+	files     []string
+	filesTime time.Time
+}
+
+// loadWorkspace indexes every workstream file under root
+func loadWorkspace(root string) *workspace {
+	w := &workspace{root: root, entries: make(map[string]*entry)}
+	for _, dir := range workstreamDirs {
+		matches, _ := filepath.Glob(filepath.Join(root, dir, "*.md"))
+		for _, path := range matches {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			if e, ok := parseEntry(path, content); ok {
+				w.entries[e.ID] = e
+			}
+		}
+	}
+	return w
+}
+
+// update re-indexes the workstream at path from content (an open buffer
+// or the file on disk).
+func (w *workspace) update(path string, content []byte) {
+	for id, e := range w.entries {
+		if e.Path == path {
+			delete(w.entries, id)
+		}
+	}
+	if e, ok := parseEntry(path, content); ok {
+		w.entries[e.ID] = e
+	}
+}
+
+// ids returns workstream IDs in sorted order
+func (w *workspace) ids() []string {
+	out := make([]string, 0, len(w.entries))
+	for id := range w.entries {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// features maps feature IDs to their workstreams, sorted by ID
+func (w *workspace) features() map[string][]*entry {
+	out := make(map[string][]*entry)
+	for _, id := range w.ids() {
+		e := w.entries[id]
+		if e.Feature != "" {
+			out[e.Feature] = append(out[e.Feature], e)
+		}
+	}
+	return out
+}
+
+// projectFiles lists files relative to root for scope completion,
+// skipping hidden and vendored directories.
+func (w *workspace) projectFiles() []string {
+	if w.files != nil && time.Since(w.filesTime) < fileIndexTTL {
+		return w.files
+	}
+	var files []string
+	_ = filepath.WalkDir(w.root, func(path string, d fs.DirEntry, err error) error {
+		if len(files) >= maxIndexedFiles {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != w.root && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel, err := filepath.Rel(w.root, path); err == nil {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	w.files, w.filesTime = files, time.Now()
+	return files
+}
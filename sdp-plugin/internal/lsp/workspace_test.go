@@ -0,0 +1,79 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// wsDoc renders a workstream file with the given depends_on list
+func wsDoc(id, status string, deps ...string) string {
+	return "---\nws_id: " + id + "\nfeature_id: F047\nstatus: " + status +
+		"\ndepends_on: [" + strings.Join(deps, ", ") + "]\n---\n\n" +
+		"# " + id + ": Workstream " + id + "\n\n## Goal\n\nShip " + id + ".\n\n" +
+		"## Acceptance Criteria\n\n- [ ] Works\n\n## Scope Files\n\n**Implementation:**\n- `internal/app/main.go`\n"
+}
+
+// writeProject creates a project with four workstreams (03 and 04 form a
+// cycle) and one source file.
+func writeProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"docs/workstreams/backlog/00-047-01.md":   wsDoc("00-047-01", "completed"),
+		"docs/workstreams/backlog/00-047-02.md":   wsDoc("00-047-02", "backlog", "00-047-01"),
+		"docs/workstreams/backlog/00-047-03.md":   wsDoc("00-047-03", "backlog", "00-047-04"),
+		"docs/workstreams/completed/00-047-04.md": wsDoc("00-047-04", "completed", "00-047-03"),
+		"internal/app/main.go":                    "package app\n",
+		".git/HEAD":                               "ref: refs/heads/main\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestLoadWorkspace(t *testing.T) {
+	root := writeProject(t)
+	w := loadWorkspace(root)
+
+	if got := strings.Join(w.ids(), ","); got != "00-047-01,00-047-02,00-047-03,00-047-04" {
+		t.Fatalf("ids = %s", got)
+	}
+	e := w.entries["00-047-02"]
+	if e.Feature != "F047" || e.Status != "backlog" || e.Title != "00-047-02: Workstream 00-047-02" || e.Goal != "Ship 00-047-02." {
+		t.Errorf("entry = %+v", e)
+	}
+	if len(w.features()["F047"]) != 4 {
+		t.Errorf("features = %v", w.features())
+	}
+
+	files := strings.Join(w.projectFiles(), ",")
+	if !strings.Contains(files, "internal/app/main.go") || strings.Contains(files, ".git/") {
+		t.Errorf("projectFiles = %s", files)
+	}
+
+	w.update(e.Path, []byte("# no longer a workstream\n"))
+	if w.entries["00-047-02"] != nil {
+		t.Error("update should drop entries whose file lost its frontmatter")
+	}
+}
+
+func TestParseEntry_Lenient(t *testing.T) {
+	// priority: P0 fails strict parsing but the entry is still indexed
+	content := "---\nws_id: 00-047-09\nfeature: F047\npriority: P0\n---\n# Title\n"
+	e, ok := parseEntry("x.md", []byte(content))
+	if !ok || e.ID != "00-047-09" || e.Feature != "F047" || e.Title != "Title" {
+		t.Errorf("parseEntry = %+v, %v", e, ok)
+	}
+	if _, ok := parseEntry("spec.md", []byte("# Spec\n\nSee 00-047-01.\n")); ok {
+		t.Error("markdown without frontmatter is not a workstream")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/evidence"
 )
 
 // Indexer indexes project artifacts into the memory store
@@ -29,6 +31,12 @@ func (i *Indexer) Close() error {
 	return i.store.Close()
 }
 
+// ImportEvidence stores evidence events (lessons, verifications) as
+// artifacts so lesson search can find them
+func (i *Indexer) ImportEvidence(events []evidence.Event) (int, error) {
+	return NewEvidenceAdapter(i.store).ImportEvents(events)
+}
+
 // IndexDirectory indexes all markdown files in the docs directory
 func (i *Indexer) IndexDirectory() (*IndexStats, error) {
 	stats := &IndexStats{}
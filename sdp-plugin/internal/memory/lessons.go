@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/fall-out-bug/sdp/internal/evidence"
+)
+
+const (
+	// DefaultLessonLimit is how many lessons FindLessons returns by default
+	DefaultLessonLimit = 5
+	// maxLessonKeywords caps the terms in the memory query
+	maxLessonKeywords = 16
+	// fileMatchBonus ranks lessons that mention a scope file higher
+	fileMatchBonus = 0.3
+	// failedBonus ranks failures above mixed outcomes
+	failedBonus = 0.1
+)
+
+var (
+	reOutcome = regexp.MustCompile(`"outcome":"(failed|mixed)"`)
+	// queryStopwords are common question words that would match anything
+	queryStopwords = map[string]bool{
+		"should": true, "what": true, "which": true, "with": true, "that": true,
+		"this": true, "from": true, "will": true, "have": true, "into": true,
+		"when": true, "where": true, "does": true, "there": true, "their": true,
+	}
+)
+
+// Lesson is a recorded failure or mixed outcome relevant to new work
+type Lesson struct {
+	Source  string  `json:"source"` // "memory" or "decision"
+	Ref     string  `json:"ref"`    // artifact path or decisions.jsonl#<n>
+	Title   string  `json:"title"`
+	Summary string  `json:"summary"`
+	Outcome string  `json:"outcome"`
+	Score   float64 `json:"score"`
+}
+
+// LessonQuery describes the work lessons are searched for
+type LessonQuery struct {
+	Goal  string
+	Files []string
+	Limit int
+}
+
+// FindLessons ranks failed and mixed lessons for a goal and its scope
+// files. Memory artifacts come from a hybrid search (searcher may be nil)
+// and decisions from evidence.FindSimilarDecisions; both are scored by
+// keyword overlap, with a bonus for mentioning a scope file.
+func FindLessons(searcher *Searcher, decisions []decision.Decision, q LessonQuery) []Lesson {
+	if q.Limit <= 0 {
+		q.Limit = DefaultLessonLimit
+	}
+	keywords := lessonKeywords(q.Goal, q.Files)
+	if len(keywords) == 0 {
+		return nil
+	}
+
+	var lessons []Lesson
+	if searcher != nil {
+		opts := SearchOptions{Mode: SearchModeHybrid, Limit: q.Limit * 4}
+		if result, err := searcher.Search(strings.Join(keywords, " OR "), opts); err == nil {
+			for _, a := range result.Artifacts {
+				outcome := artifactOutcome(a.Artifact)
+				if outcome == "" {
+					continue
+				}
+				text := a.Title + " " + a.Content
+				lessons = append(lessons, Lesson{
+					Source:  "memory",
+					Ref:     a.Path,
+					Title:   a.Title,
+					Summary: a.Content,
+					Outcome: outcome,
+					Score:   a.Score + lessonScore(text, keywords, q.Files, outcome),
+				})
+			}
+		}
+	}
+
+	// FindSimilarDecisions with no query returns every failed/mixed decision
+	index := decisionIndex(decisions)
+	for _, m := range evidence.FindSimilarDecisions("", nil, decisions) {
+		text := m.Question + " " + strings.Join(m.Tags, " ")
+		outcome := strings.ToLower(strings.TrimSpace(m.Outcome))
+		score := lessonScore(text, keywords, q.Files, outcome)
+		if score <= failedBonus {
+			continue // no keyword overlap
+		}
+		lessons = append(lessons, Lesson{
+			Source:  "decision",
+			Ref:     "docs/decisions/decisions.jsonl#" + strconv.Itoa(index[m.Question]),
+			Title:   m.Question,
+			Summary: decisionSummary(decisions, index[m.Question]),
+			Outcome: m.Outcome,
+			Score:   score,
+		})
+	}
+
+	sort.SliceStable(lessons, func(i, j int) bool { return lessons[i].Score > lessons[j].Score })
+	if len(lessons) > q.Limit {
+		lessons = lessons[:q.Limit]
+	}
+	return lessons
+}
+
+// artifactOutcome returns "failed" or "mixed" for evidence artifacts that
+// record an unsuccessful lesson or verification, otherwise "".
+func artifactOutcome(a *Artifact) string {
+	if m := reOutcome.FindStringSubmatch(a.Content); m != nil {
+		return m[1]
+	}
+	if strings.Contains(a.Content, `"passed":false`) {
+		return "failed"
+	}
+	return ""
+}
+
+// lessonScore is the share of query keywords found in text, plus bonuses
+// for mentioning a scope file and for outright failures.
+func lessonScore(text string, keywords, files []string, outcome string) float64 {
+	lower := strings.ToLower(text)
+	hits := 0
+	for _, k := range keywords {
+		if strings.Contains(lower, k) {
+			hits++
+		}
+	}
+	score := float64(hits) / float64(len(keywords))
+	for _, f := range files {
+		if f != "" && strings.Contains(lower, strings.ToLower(f)) {
+			score += fileMatchBonus
+			break
+		}
+	}
+	if strings.Contains(outcome, "fail") {
+		score += failedBonus
+	}
+	return score
+}
+
+// lessonKeywords extracts keywords from the goal and the base names of
+// scope files.
+func lessonKeywords(goal string, files []string) []string {
+	text := goal
+	for _, f := range files {
+		text += " " + strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+	}
+	return Keywords(text, maxLessonKeywords)
+}
+
+// Keywords returns up to limit distinct lower-cased words of four or more
+// letters or digits from text, skipping common question words, for use as
+// the terms of a memory search OR-query.
+func Keywords(text string, limit int) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		if len(w) < 4 || seen[w] || queryStopwords[w] || len(out) == limit {
+			continue
+		}
+		seen[w] = true
+		out = append(out, w)
+	}
+	return out
+}
+
+// decisionIndex maps questions to their 1-based line in the decision log
+func decisionIndex(decisions []decision.Decision) map[string]int {
+	index := make(map[string]int, len(decisions))
+	for i, d := range decisions {
+		index[d.Question] = i + 1
+	}
+	return index
+}
+
+// decisionSummary returns the choice and rationale of the n-th decision
+func decisionSummary(decisions []decision.Decision, n int) string {
+	if n < 1 || n > len(decisions) {
+		return ""
+	}
+	d := decisions[n-1]
+	if d.Rationale == "" {
+		return d.Decision
+	}
+	return d.Decision + " (" + d.Rationale + ")"
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/decision"
+	"github.com/fall-out-bug/sdp/internal/evidence"
+)
+
+func lessonEvent(id, ws string, lesson evidence.Lesson) evidence.Event {
+	ev := evidence.LessonEvent(lesson)
+	ev.ID = id
+	ev.WSID = ws
+	return *ev
+}
+
+func TestFindLessons(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "memory.db"))
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	events := []evidence.Event{
+		lessonEvent("ev-1", "00-051-01", evidence.Lesson{
+			Category: "webhook", WSID: "00-051-01", Outcome: "failed",
+			WhatFailed: []string{"webhook retry storm in internal/webhook/send.go"},
+		}),
+		lessonEvent("ev-2", "00-051-02", evidence.Lesson{
+			Category: "webhook", WSID: "00-051-02", Outcome: "passed",
+			WhatWorked: []string{"webhook retry with backoff"},
+		}),
+		{ID: "ev-3", Type: "verification", WSID: "00-051-03", Data: evidence.VerificationData{GateName: "webhook retry tests", Passed: false}},
+	}
+	if _, err := NewEvidenceAdapter(store).ImportEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	decisions := []decision.Decision{
+		{Question: "Retry webhook delivery inline?", Decision: "Retry inline", Rationale: "simple", Outcome: "failed"},
+		{Question: "Retry webhook delivery in a queue?", Decision: "Queue", Outcome: "worked"},
+		{Question: "Which logger?", Decision: "slog", Outcome: "failed"},
+	}
+
+	lessons := FindLessons(NewSearcher(store), decisions, LessonQuery{
+		Goal:  "Add retry to webhook delivery",
+		Files: []string{"internal/webhook/send.go"},
+	})
+
+	refs := make(map[string]Lesson)
+	for _, l := range lessons {
+		refs[l.Ref] = l
+	}
+	if len(lessons) != 3 {
+		t.Fatalf("expected 3 lessons, got %+v", lessons)
+	}
+	if lessons[0].Ref != ".sdp/log/events.jsonl#ev-1" || lessons[0].Outcome != "failed" {
+		t.Errorf("top lesson should mention the scope file: %+v", lessons[0])
+	}
+	if _, ok := refs[".sdp/log/events.jsonl#ev-2"]; ok {
+		t.Error("successful lesson should be excluded")
+	}
+	d, ok := refs["docs/decisions/decisions.jsonl#1"]
+	if !ok || d.Source != "decision" || d.Summary != "Retry inline (simple)" {
+		t.Errorf("failed decision lesson = %+v", d)
+	}
+	if _, ok := refs[".sdp/log/events.jsonl#ev-3"]; !ok {
+		t.Error("failed verification should be included")
+	}
+
+	if got := FindLessons(nil, decisions, LessonQuery{Goal: "Add retry to webhook delivery", Limit: 1}); len(got) != 1 || got[0].Source != "decision" {
+		t.Errorf("decisions-only lessons = %+v", got)
+	}
+	if got := FindLessons(nil, decisions, LessonQuery{Goal: "a b"}); got != nil {
+		t.Errorf("expected no lessons without keywords, got %+v", got)
+	}
+}
+
+func TestLessonKeywords(t *testing.T) {
+	got := lessonKeywords("Add retry to the Webhook, retry!", []string{"internal/webhook/send_queue.go"})
+	want := []string{"retry", "webhook", "send", "queue"}
+	if len(got) != len(want) {
+		t.Fatalf("keywords = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("keywords = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestKeywords_StopwordsAndLimit(t *testing.T) {
+	got := Keywords("Which message queue should we use? Kafka, kafka!", 2)
+	if len(got) != 2 || got[0] != "message" || got[1] != "queue" {
+		t.Errorf("Keywords = %v, want [message queue]", got)
+	}
+}
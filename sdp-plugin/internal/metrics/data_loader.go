@@ -11,6 +11,7 @@ type ReportData struct {
 	Metrics    MetricsSummary    `json:"metrics"`
 	Taxonomy   TaxonomySummary   `json:"taxonomy"`
 	Historical []HistoricalEntry `json:"historical,omitempty"`
+	Fallback   []ModelFallback   `json:"model_fallback,omitempty"`
 }
 
 // MetricsSummary summarizes key metrics for report (AC2).
@@ -97,5 +98,14 @@ func (r *Reporter) loadReportData() (ReportData, error) {
 		data.Historical = historical
 	}
 
+	// Load model fallback stats if path set
+	if r.modelStatsPath != "" {
+		fallback, err := loadModelFallback(r.modelStatsPath)
+		if err != nil {
+			return data, err
+		}
+		data.Fallback = fallback
+	}
+
 	return data, nil
 }
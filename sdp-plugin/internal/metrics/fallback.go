@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ModelFallback is the fallback rate for one routed model profile.
+type ModelFallback struct {
+	Profile      string  `json:"profile"`
+	Calls        int64   `json:"calls"`
+	Successes    int64   `json:"successes"`
+	Errors       int64   `json:"errors"`
+	Fallbacks    int64   `json:"fallbacks"`
+	FallbackRate float64 `json:"fallback_rate"`
+}
+
+// modelStatsFile mirrors model.StatsData (.sdp/model-stats.json, written by the orchestrator).
+type modelStatsFile struct {
+	Models map[string]struct {
+		Calls     int64 `json:"calls"`
+		Successes int64 `json:"successes"`
+		Errors    int64 `json:"errors"`
+		Fallbacks int64 `json:"fallbacks"`
+	} `json:"models"`
+}
+
+// loadModelFallback reads per-model fallback rates sorted by profile.
+// A missing file yields no entries.
+func loadModelFallback(path string) ([]ModelFallback, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read model stats: %w", err)
+	}
+	var stats modelStatsFile
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		return nil, fmt.Errorf("parse model stats: %w", err)
+	}
+	out := make([]ModelFallback, 0, len(stats.Models))
+	for profile, m := range stats.Models {
+		mf := ModelFallback{Profile: profile, Calls: m.Calls, Successes: m.Successes, Errors: m.Errors, Fallbacks: m.Fallbacks}
+		if m.Calls > 0 {
+			mf.FallbackRate = float64(m.Fallbacks) / float64(m.Calls)
+		}
+		out = append(out, mf)
+	}
+	slices.SortFunc(out, func(a, b ModelFallback) int { return strings.Compare(a.Profile, b.Profile) })
+	return out, nil
+}
+
+// generateFallbackSection creates the model fallback table. Empty without stats.
+func (r *Reporter) generateFallbackSection(data ReportData) string {
+	if len(data.Fallback) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\n## Model Fallback\n\n")
+	sb.WriteString("| Model | Calls | Fallback Rate | Errors |\n")
+	sb.WriteString("|-------|-------|---------------|--------|\n")
+	for _, f := range data.Fallback {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %.1f%% | %d |\n", f.Profile, f.Calls, f.FallbackRate*100, f.Errors))
+	}
+	return sb.String()
+}
@@ -17,6 +17,7 @@ func (r *Reporter) GenerateMarkdown() (string, error) {
 	sb.WriteString(r.generateMarkdownHeader(data))
 	sb.WriteString(r.generateMetricsSection(data))
 	sb.WriteString(r.generateModelComparison(data))
+	sb.WriteString(r.generateFallbackSection(data))
 	sb.WriteString(r.generateTaxonomySection(data))
 	sb.WriteString(r.generateTrendSection(data))
 	sb.WriteString(r.generateMethodology())
@@ -12,6 +12,7 @@ type Reporter struct {
 	metricsPath    string
 	taxonomyPath   string
 	historicalPath string
+	modelStatsPath string
 }
 
 // NewReporter creates a reporter for given paths.
@@ -27,6 +28,11 @@ func (r *Reporter) SetHistoricalPath(path string) {
 	r.historicalPath = path
 }
 
+// SetModelStatsPath sets path to persisted model fallback stats (.sdp/model-stats.json).
+func (r *Reporter) SetModelStatsPath(path string) {
+	r.modelStatsPath = path
+}
+
 // GetDefaultOutputPath returns the default report path (AC6).
 func (r *Reporter) GetDefaultOutputPath() string {
 	quarter := r.GetCurrentQuarter()
@@ -719,3 +719,35 @@ func TestReport_GenerateTrendSection_StableTrend(t *testing.T) {
 		t.Error("Expected 'Stable' trend when catch rate is unchanged")
 	}
 }
+
+func TestReport_GenerateMarkdown_IncludesModelFallback(t *testing.T) {
+	tempDir := t.TempDir()
+	metricsPath := filepath.Join(tempDir, "metrics.json")
+	statsPath := filepath.Join(tempDir, "model-stats.json")
+	if err := os.WriteFile(metricsPath, []byte(`{"catch_rate": 0.1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stats := `{"version":1,"models":{"quality":{"calls":4,"successes":3,"errors":1,"fallbacks":1},"balanced":{"calls":2,"successes":2}}}`
+	if err := os.WriteFile(statsPath, []byte(stats), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	reporter := NewReporter(metricsPath, filepath.Join(tempDir, "taxonomy.json"))
+	reporter.SetModelStatsPath(statsPath)
+	report, err := reporter.GenerateMarkdown()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(report, "## Model Fallback") {
+		t.Error("Expected report to contain model fallback section")
+	}
+	if !strings.Contains(report, "| quality | 4 | 25.0% | 1 |") {
+		t.Errorf("Expected quality fallback rate row, got:\n%s", report)
+	}
+
+	reporter.SetModelStatsPath(filepath.Join(tempDir, "missing.json"))
+	report, err = reporter.GenerateMarkdown()
+	if err != nil || strings.Contains(report, "## Model Fallback") {
+		t.Errorf("missing stats must omit the section, err=%v", err)
+	}
+}
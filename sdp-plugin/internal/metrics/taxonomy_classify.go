@@ -27,6 +27,24 @@ func (t *Taxonomy) ClassifyFromOutput(eventID, wsID, modelID, language, output s
 	return *fc
 }
 
+// ClassifyFlakyTest records a test classified as flaky by re-runs. The event
+// ID is "flaky:<test id>", so repeated detections update one entry.
+func (t *Taxonomy) ClassifyFlakyTest(testID, wsID, language, notes string) FailureClassification {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fc := &FailureClassification{
+		EventID:     "flaky:" + testID,
+		WSID:        wsID,
+		Language:    language,
+		FailureType: FailureFlakyTest,
+		Severity:    t.severityForType(FailureFlakyTest),
+		Notes:       notes,
+	}
+	t.classifications[fc.EventID] = fc
+	return *fc
+}
+
 // classifyByPattern determines failure type from output patterns (AC4).
 func (t *Taxonomy) classifyByPattern(output string) string {
 	outputLower := strings.ToLower(output)
@@ -29,6 +29,7 @@ const (
 	FailureTestPassingWrong = "test_passing_but_wrong"
 	FailureCompilationError = "compilation_error"
 	FailureImportError      = "import_error"
+	FailureFlakyTest        = "flaky_test"
 	FailureUnknown          = "unknown"
 )
 
@@ -0,0 +1,151 @@
+// Package mutation implements source-level mutation testing for Go files:
+// it generates mutants from the AST and runs the package tests against each.
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+)
+
+// Mutation operators.
+const (
+	OpConditionalNegation = "conditional-negation"
+	OpConditionalBoundary = "conditional-boundary"
+	OpArithmetic          = "arithmetic"
+	OpLogical             = "logical"
+	OpStatementRemoval    = "statement-removal"
+)
+
+// Mutant is a single textual change to a source file. Offset and Length
+// locate the replaced bytes in the original source.
+type Mutant struct {
+	ID       int
+	File     string
+	Line     int
+	Col      int
+	Operator string
+	Original string
+	Mutated  string
+	Offset   int
+	Length   int
+}
+
+// String renders the mutant as "file:line:col operator: original -> mutated".
+func (m Mutant) String() string {
+	mutated := m.Mutated
+	if mutated == "" {
+		mutated = "<removed>"
+	}
+	return fmt.Sprintf("%s:%d:%d %s: %s -> %s", m.File, m.Line, m.Col, m.Operator, m.Original, mutated)
+}
+
+// Apply returns src with the mutant's replacement applied.
+func (m Mutant) Apply(src []byte) []byte {
+	out := make([]byte, 0, len(src)-m.Length+len(m.Mutated))
+	out = append(out, src[:m.Offset]...)
+	out = append(out, m.Mutated...)
+	return append(out, src[m.Offset+m.Length:]...)
+}
+
+var negations = map[token.Token]token.Token{
+	token.EQL: token.NEQ, token.NEQ: token.EQL,
+	token.LSS: token.GEQ, token.GEQ: token.LSS,
+	token.GTR: token.LEQ, token.LEQ: token.GTR,
+}
+
+var boundaries = map[token.Token]token.Token{
+	token.LSS: token.LEQ, token.LEQ: token.LSS,
+	token.GTR: token.GEQ, token.GEQ: token.GTR,
+}
+
+var arithmetic = map[token.Token]token.Token{
+	token.ADD: token.SUB, token.SUB: token.ADD,
+	token.MUL: token.QUO, token.QUO: token.MUL, token.REM: token.MUL,
+	token.ADD_ASSIGN: token.SUB_ASSIGN, token.SUB_ASSIGN: token.ADD_ASSIGN,
+	token.MUL_ASSIGN: token.QUO_ASSIGN, token.QUO_ASSIGN: token.MUL_ASSIGN,
+	token.INC: token.DEC, token.DEC: token.INC,
+}
+
+var logical = map[token.Token]token.Token{
+	token.LAND: token.LOR, token.LOR: token.LAND,
+}
+
+// Generate parses src and returns its mutants ordered by position. File is
+// recorded on each mutant as given; IDs are assigned by the caller.
+func Generate(file string, src []byte) ([]Mutant, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutants []Mutant
+	add := func(pos token.Pos, length int, op, mutated string) {
+		p := fset.Position(pos)
+		mutants = append(mutants, Mutant{
+			File:     file,
+			Line:     p.Line,
+			Col:      p.Column,
+			Operator: op,
+			Original: string(src[p.Offset : p.Offset+length]),
+			Mutated:  mutated,
+			Offset:   p.Offset,
+			Length:   length,
+		})
+	}
+	swap := func(pos token.Pos, tok token.Token, table map[token.Token]token.Token, op string) {
+		if to, ok := table[tok]; ok {
+			add(pos, len(tok.String()), op, to.String())
+		}
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			// Constant and type expressions rarely yield useful mutants.
+			return n.Tok == token.VAR
+		case *ast.BinaryExpr:
+			if n.Op == token.ADD && (isString(n.X) || isString(n.Y)) {
+				break
+			}
+			swap(n.OpPos, n.Op, negations, OpConditionalNegation)
+			swap(n.OpPos, n.Op, boundaries, OpConditionalBoundary)
+			swap(n.OpPos, n.Op, arithmetic, OpArithmetic)
+			swap(n.OpPos, n.Op, logical, OpLogical)
+		case *ast.AssignStmt:
+			if n.Tok == token.ADD_ASSIGN && len(n.Rhs) == 1 && isString(n.Rhs[0]) {
+				break
+			}
+			swap(n.TokPos, n.Tok, arithmetic, OpArithmetic)
+		case *ast.IncDecStmt:
+			swap(n.TokPos, n.Tok, arithmetic, OpArithmetic)
+			add(n.Pos(), int(n.End()-n.Pos()), OpStatementRemoval, "")
+		case *ast.ExprStmt:
+			if _, ok := n.X.(*ast.CallExpr); ok && !isPanic(n.X) {
+				add(n.Pos(), int(n.End()-n.Pos()), OpStatementRemoval, "")
+			}
+		}
+		return true
+	})
+
+	sort.SliceStable(mutants, func(i, j int) bool { return mutants[i].Offset < mutants[j].Offset })
+	return mutants, nil
+}
+
+// isString reports whether e is a string literal, the only case where the
+// operand type is known without type checking.
+func isString(e ast.Expr) bool {
+	lit, ok := e.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING
+}
+
+// isPanic reports whether e calls panic; removing it usually leaves a
+// function without a terminating statement, which does not compile.
+func isPanic(e ast.Expr) bool {
+	call := e.(*ast.CallExpr)
+	id, ok := call.Fun.(*ast.Ident)
+	return ok && id.Name == "panic"
+}
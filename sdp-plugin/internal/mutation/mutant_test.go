@@ -0,0 +1,84 @@
+package mutation
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+const sample = `package calc
+
+import "fmt"
+
+const limit = 1 + 2
+
+func Clamp(x, max int) int {
+	if x > max && max >= 0 {
+		return max
+	}
+	x++
+	fmt.Println("label: " + "x")
+	return x * 2
+}
+`
+
+func TestGenerate_Operators(t *testing.T) {
+	mutants, err := Generate("calc.go", []byte(sample))
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := make(map[string]int)
+	for _, m := range mutants {
+		got[m.Operator+" "+m.Original+"->"+m.Mutated]++
+	}
+	want := []string{
+		OpConditionalNegation + " >-><=",
+		OpConditionalBoundary + " >->>=",
+		OpLogical + " &&->||",
+		OpConditionalNegation + " >=-><",
+		OpConditionalBoundary + " >=->>",
+		OpArithmetic + " ++->--",
+		OpStatementRemoval + " x++->",
+		OpStatementRemoval + ` fmt.Println("label: " + "x")->`,
+		OpArithmetic + " *->/",
+	}
+	for _, w := range want {
+		if got[w] != 1 {
+			t.Errorf("missing mutant %q in %v", w, got)
+		}
+	}
+	if len(mutants) != len(want) {
+		t.Errorf("got %d mutants, want %d: %v", len(mutants), len(want), got)
+	}
+	for i := 1; i < len(mutants); i++ {
+		if mutants[i].Offset < mutants[i-1].Offset {
+			t.Fatalf("mutants not ordered by offset")
+		}
+	}
+}
+
+func TestMutant_ApplyCompiles(t *testing.T) {
+	mutants, err := Generate("calc.go", []byte(sample))
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	for _, m := range mutants {
+		out := m.Apply([]byte(sample))
+		if _, err := parser.ParseFile(token.NewFileSet(), "calc.go", out, 0); err != nil {
+			t.Errorf("%s: mutated source does not parse: %v", m, err)
+		}
+	}
+}
+
+func TestMutant_String(t *testing.T) {
+	m := Mutant{File: "a.go", Line: 3, Col: 5, Operator: OpStatementRemoval, Original: "f()"}
+	if got, want := m.String(), "a.go:3:5 statement-removal: f() -> <removed>"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerate_ParseError(t *testing.T) {
+	if _, err := Generate("bad.go", []byte("package")); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
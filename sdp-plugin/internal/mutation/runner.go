@@ -0,0 +1,302 @@
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/gomod"
+)
+
+// Mutant statuses.
+const (
+	StatusKilled   = "killed"
+	StatusSurvived = "survived"
+	StatusTimeout  = "timeout" // tests hung; counted as killed
+	StatusInvalid  = "invalid" // mutant did not compile; excluded from the score
+	StatusSkipped  = "skipped" // time budget exhausted or run cancelled
+)
+
+// Runner runs the tests of each mutated file's package against every mutant.
+// Mutants are injected with `go test -overlay`, so the working tree is never
+// modified.
+type Runner struct {
+	Root       string        // project root; relative file paths resolve against it
+	Budget     time.Duration // total wall-clock budget, enforced inside each mutant's tests; 0 = unlimited
+	MaxMutants int           // 0 = no limit; the cap is shared across files in turn
+}
+
+// Result is the outcome of one mutant.
+type Result struct {
+	Mutant   Mutant
+	Status   string
+	Duration time.Duration
+}
+
+// Report summarises a mutation run.
+type Report struct {
+	Files    []string
+	Results  []Result
+	Killed   int
+	Survived int
+	TimedOut int
+	Invalid  int
+	Skipped  int
+	Score    float64 // (killed + timed out) / (killed + timed out + survived), %; 0 when none was evaluated
+	Duration time.Duration
+}
+
+// Evaluated returns the number of mutants that contribute to the score.
+// Score is meaningless when it is zero.
+func (r *Report) Evaluated() int {
+	return r.Killed + r.TimedOut + r.Survived
+}
+
+// Survivors returns the mutants the tests did not detect.
+func (r *Report) Survivors() []Mutant {
+	var out []Mutant
+	for _, res := range r.Results {
+		if res.Status == StatusSurvived {
+			out = append(out, res.Mutant)
+		}
+	}
+	return out
+}
+
+// target is a mutated file resolved to its module and package.
+type target struct {
+	rel     string // path as reported, relative to Root
+	abs     string
+	src     []byte
+	modRoot string
+	pkg     string // ./relative/dir from modRoot
+	timeout time.Duration
+}
+
+// Run mutates the given Go files and runs their package tests per mutant.
+// Test files and non-Go files are ignored. The package tests must pass on
+// the unmutated sources; the per-mutant timeout is derived from that run.
+func (r *Runner) Run(ctx context.Context, files []string) (*Report, error) {
+	start := time.Now()
+
+	targets, mutants, err := r.collect(files)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	for _, t := range targets {
+		report.Files = append(report.Files, t.rel)
+	}
+	if len(mutants) == 0 {
+		report.Duration = time.Since(start)
+		return report, nil
+	}
+
+	baselines := make(map[string]time.Duration)
+	for _, t := range targets {
+		key := t.modRoot + "|" + t.pkg
+		if d, ok := baselines[key]; ok {
+			t.timeout = d
+			continue
+		}
+		began := time.Now()
+		if out, err := goTest(ctx, t.modRoot, t.pkg, "", 0); err != nil {
+			return nil, fmt.Errorf("tests fail without mutations in %s: %v\n%s", t.pkg, err, tail(out, 20))
+		}
+		t.timeout = 3*time.Since(began) + 5*time.Second
+		baselines[key] = t.timeout
+	}
+
+	tmp, err := os.MkdirTemp("", "sdp-mutation-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	// The budget bounds the mutant runs themselves, so a slow mutant is
+	// stopped at the deadline and reported as skipped.
+	mutantCtx := ctx
+	if r.Budget > 0 {
+		var cancel context.CancelFunc
+		mutantCtx, cancel = context.WithDeadline(ctx, start.Add(r.Budget))
+		defer cancel()
+	}
+	byFile := make(map[string]*target, len(targets))
+	for _, t := range targets {
+		byFile[t.rel] = t
+	}
+	for _, m := range mutants {
+		if mutantCtx.Err() != nil {
+			report.add(Result{Mutant: m, Status: StatusSkipped})
+			continue
+		}
+		t := byFile[m.File]
+		began := time.Now()
+		status, err := runMutant(mutantCtx, tmp, t, m)
+		if err != nil {
+			return nil, err
+		}
+		report.add(Result{Mutant: m, Status: status, Duration: time.Since(began)})
+	}
+
+	if n := report.Evaluated(); n > 0 {
+		report.Score = float64(report.Killed+report.TimedOut) / float64(n) * 100
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+func (r *Report) add(res Result) {
+	r.Results = append(r.Results, res)
+	switch res.Status {
+	case StatusKilled:
+		r.Killed++
+	case StatusSurvived:
+		r.Survived++
+	case StatusTimeout:
+		r.TimedOut++
+	case StatusInvalid:
+		r.Invalid++
+	case StatusSkipped:
+		r.Skipped++
+	}
+}
+
+// collect resolves files to targets and generates their mutants.
+func (r *Runner) collect(files []string) ([]*target, []Mutant, error) {
+	var targets []*target
+	var perFile [][]Mutant
+	seen := make(map[string]bool)
+	for _, f := range files {
+		if filepath.Ext(f) != ".go" || strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		abs := f
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(r.Root, f)
+		}
+		abs = filepath.Clean(abs)
+		if seen[abs] {
+			continue
+		}
+		seen[abs] = true
+		src, err := os.ReadFile(abs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read %s: %w", f, err)
+		}
+		modRoot, err := gomod.Root(filepath.Dir(abs))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", f, err)
+		}
+		pkgDir, _ := filepath.Rel(modRoot, filepath.Dir(abs))
+		t := &target{
+			rel:     filepath.ToSlash(f),
+			abs:     abs,
+			src:     src,
+			modRoot: modRoot,
+			pkg:     "./" + filepath.ToSlash(pkgDir),
+		}
+		ms, err := Generate(t.rel, src)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse %s: %w", f, err)
+		}
+		targets = append(targets, t)
+		perFile = append(perFile, ms)
+	}
+	mutants := capMutants(perFile, r.MaxMutants)
+	for i := range mutants {
+		mutants[i].ID = i + 1
+	}
+	return targets, mutants, nil
+}
+
+// capMutants flattens the mutants of each file, keeping at most max of
+// them (0 = all). The cap is shared out one mutant per file in turn, so
+// the first file cannot use up the whole budget.
+func capMutants(perFile [][]Mutant, max int) []Mutant {
+	keep := make([]int, len(perFile))
+	for n, progressed := 0, true; progressed && (max <= 0 || n < max); {
+		progressed = false
+		for i, ms := range perFile {
+			if keep[i] < len(ms) && (max <= 0 || n < max) {
+				keep[i]++
+				n++
+				progressed = true
+			}
+		}
+	}
+	var out []Mutant
+	for i, ms := range perFile {
+		out = append(out, ms[:keep[i]]...)
+	}
+	return out
+}
+
+// runMutant writes the mutated file and an overlay mapping it over the
+// original, then runs the package tests.
+func runMutant(ctx context.Context, tmp string, t *target, m Mutant) (string, error) {
+	mutated := filepath.Join(tmp, fmt.Sprintf("mutant-%d.go", m.ID))
+	if err := os.WriteFile(mutated, m.Apply(t.src), 0o644); err != nil {
+		return "", err
+	}
+	overlay := filepath.Join(tmp, fmt.Sprintf("overlay-%d.json", m.ID))
+	data, _ := json.Marshal(map[string]map[string]string{"Replace": {t.abs: mutated}})
+	if err := os.WriteFile(overlay, data, 0o644); err != nil {
+		return "", err
+	}
+	defer os.Remove(mutated)
+	defer os.Remove(overlay)
+
+	out, err := goTest(ctx, t.modRoot, t.pkg, overlay, t.timeout)
+	switch {
+	case ctx.Err() != nil:
+		// The run was cancelled mid-test; the mutant was not evaluated.
+		return StatusSkipped, nil
+	case err == nil:
+		return StatusSurvived, nil
+	case errors.Is(err, context.DeadlineExceeded) || strings.Contains(out, "panic: test timed out"):
+		return StatusTimeout, nil
+	case strings.Contains(out, "[build failed]") || strings.Contains(out, "[setup failed]"):
+		return StatusInvalid, nil
+	default:
+		return StatusKilled, nil
+	}
+}
+
+// goTest runs `go test` for one package. A non-zero timeout bounds both the
+// test binary (-timeout) and the go command itself.
+func goTest(ctx context.Context, dir, pkg, overlay string, timeout time.Duration) (string, error) {
+	args := []string{"test", "-count=1", "-failfast"}
+	if overlay != "" {
+		args = append(args, "-overlay="+overlay)
+	}
+	if timeout > 0 {
+		args = append(args, "-timeout="+timeout.String())
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout+10*time.Second)
+		defer cancel()
+	}
+	args = append(args, pkg)
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = 5 * time.Second
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return string(out), ctx.Err()
+	}
+	return string(out), err
+}
+
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
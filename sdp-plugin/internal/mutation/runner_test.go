@@ -0,0 +1,189 @@
+package mutation
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/m\n\ngo 1.21\n"
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRunner_Run_ScoresMutants(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test per mutant")
+	}
+	dir := writeModule(t, map[string]string{
+		"calc/calc.go": `package calc
+
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+`,
+		// a == b is never exercised, so the boundary mutant survives.
+		"calc/calc_test.go": `package calc
+
+import "testing"
+
+func TestMax(t *testing.T) {
+	if Max(3, 1) != 3 || Max(1, 3) != 3 {
+		t.Fatal("wrong max")
+	}
+}
+`,
+	})
+
+	r := &Runner{Root: dir, Budget: 5 * time.Minute}
+	report, err := r.Run(context.Background(), []string{"calc/calc.go", "calc/calc_test.go"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Files) != 1 || report.Files[0] != "calc/calc.go" {
+		t.Errorf("Files = %v, want [calc/calc.go]", report.Files)
+	}
+	if report.Killed != 1 || report.Survived != 1 {
+		t.Fatalf("killed=%d survived=%d, want 1/1: %+v", report.Killed, report.Survived, report.Results)
+	}
+	if report.Score != 50 {
+		t.Errorf("Score = %.1f, want 50", report.Score)
+	}
+	survivors := report.Survivors()
+	if len(survivors) != 1 || survivors[0].Operator != OpConditionalBoundary {
+		t.Errorf("survivors = %v, want the boundary mutant", survivors)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "calc/calc.go")); string(data) == "" {
+		t.Error("source file was modified or removed")
+	}
+}
+
+func TestRunner_Run_BaselineFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	dir := writeModule(t, map[string]string{
+		"p/p.go":      "package p\n\nfunc One() int { return 1 + 0 }\n",
+		"p/p_test.go": "package p\n\nimport \"testing\"\n\nfunc TestOne(t *testing.T) { t.Fatal(\"broken\") }\n",
+	})
+	r := &Runner{Root: dir}
+	if _, err := r.Run(context.Background(), []string{"p/p.go"}); err == nil {
+		t.Fatal("expected error when tests fail without mutations")
+	}
+}
+
+func TestRunner_Run_BudgetAndLimit(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"p/p.go": "package p\n\nfunc F(a, b int) int { return a + b*a - b }\n",
+	})
+	r := &Runner{Root: dir, MaxMutants: 2, Budget: time.Nanosecond}
+	report, err := r.Run(context.Background(), []string{"p/p.go"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Results) != 2 || report.Skipped != 2 {
+		t.Errorf("results=%d skipped=%d, want 2/2", len(report.Results), report.Skipped)
+	}
+	if report.Evaluated() != 0 || report.Score != 0 {
+		t.Errorf("evaluated=%d score=%.1f, want no score when nothing ran", report.Evaluated(), report.Score)
+	}
+}
+
+func TestRunner_Run_NoGoFiles(t *testing.T) {
+	r := &Runner{Root: t.TempDir()}
+	report, err := r.Run(context.Background(), []string{"README.md", "x_test.go"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(report.Results) != 0 || report.Evaluated() != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestRunMutant_CancelledIsSkipped(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"p/p.go": "package p\n\nfunc F(a, b int) int { return a + b }\n",
+	})
+	src, _ := os.ReadFile(filepath.Join(dir, "p/p.go"))
+	mutants, err := Generate("p/p.go", src)
+	if err != nil || len(mutants) == 0 {
+		t.Fatalf("Generate: %v (%d mutants)", err, len(mutants))
+	}
+	tgt := &target{rel: "p/p.go", abs: filepath.Join(dir, "p/p.go"), src: src, modRoot: dir, pkg: "./p"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	status, err := runMutant(ctx, t.TempDir(), tgt, mutants[0])
+	if err != nil {
+		t.Fatalf("runMutant: %v", err)
+	}
+	if status != StatusSkipped {
+		t.Errorf("status = %s, want %s for a cancelled run", status, StatusSkipped)
+	}
+}
+
+func TestCapMutants_SharesCapAcrossFiles(t *testing.T) {
+	file := func(name string, n int) []Mutant {
+		ms := make([]Mutant, n)
+		for i := range ms {
+			ms[i] = Mutant{File: name, Line: i + 1}
+		}
+		return ms
+	}
+	perFile := [][]Mutant{file("a.go", 5), file("b.go", 1), file("c.go", 3)}
+
+	got := capMutants(perFile, 5)
+	count := map[string]int{}
+	for _, m := range got {
+		count[m.File]++
+	}
+	if len(got) != 5 || count["a.go"] != 2 || count["b.go"] != 1 || count["c.go"] != 2 {
+		t.Errorf("capMutants = %v, want a.go 2, b.go 1, c.go 2", count)
+	}
+	if got[0].File != "a.go" || got[1].Line != 2 {
+		t.Errorf("mutants must keep file and source order: %+v", got)
+	}
+	if all := capMutants(perFile, 0); len(all) != 9 {
+		t.Errorf("no cap kept %d mutants, want 9", len(all))
+	}
+}
+
+func TestRunner_Run_BudgetStopsSlowMutant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	// Every mutant makes the test sleep far past the budget.
+	dir := writeModule(t, map[string]string{
+		"p/p.go": "package p\n\nfunc F(a, b int) int { return a + b }\n",
+		"p/p_test.go": "package p\n\nimport (\n\t\"testing\"\n\t\"time\"\n)\n\n" +
+			"func TestF(t *testing.T) {\n\tif F(1, 2) != 3 {\n\t\ttime.Sleep(60 * time.Second)\n\t}\n}\n",
+	})
+	r := &Runner{Root: dir, MaxMutants: 1, Budget: 5 * time.Second}
+	report, err := r.Run(context.Background(), []string{"p/p.go"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Skipped != 1 || report.Duration > 20*time.Second {
+		t.Errorf("skipped=%d after %s, want the mutant stopped at the budget: %+v", report.Skipped, report.Duration, report.Results)
+	}
+}
@@ -0,0 +1,140 @@
+package nextstep
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Budget files are written by the orchestrator and sdp-ci-loop (root module src/sdp/budget).
+const (
+	budgetConfigPath = ".sdp/budgets.yaml"
+	budgetLedgerPath = ".sdp/budget-ledger.json"
+)
+
+// BudgetStatus is the burn-down for one feature or workstream budget.
+type BudgetStatus struct {
+	Scope     string  `json:"scope"` // feature, workstream
+	ID        string  `json:"id"`
+	Tokens    int     `json:"tokens"`
+	MaxTokens int     `json:"max_tokens,omitempty"`
+	Cost      float64 `json:"cost"`
+	MaxCost   float64 `json:"max_cost,omitempty"`
+	Used      float64 `json:"used"`  // fraction of the tighter limit, 0..1+
+	Level     string  `json:"level"` // ok, warning, exhausted
+}
+
+// budgetLimit mirrors budget.Limit.
+type budgetLimit struct {
+	MaxTokens int     `yaml:"max_tokens"`
+	MaxCost   float64 `yaml:"max_cost"`
+}
+
+// budgetConfig mirrors the .sdp/budgets.yaml schema.
+type budgetConfig struct {
+	SoftWarning float64 `yaml:"soft_warning"`
+	HardStop    float64 `yaml:"hard_stop"`
+	Defaults    struct {
+		Feature    budgetLimit `yaml:"feature"`
+		Workstream budgetLimit `yaml:"workstream"`
+	} `yaml:"defaults"`
+	Features    map[string]budgetLimit `yaml:"features"`
+	Workstreams map[string]budgetLimit `yaml:"workstreams"`
+}
+
+// budgetSpend mirrors budget.Spend.
+type budgetSpend struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	Cost         float64 `json:"cost"`
+}
+
+// budgetLedger mirrors the .sdp/budget-ledger.json schema.
+type budgetLedger struct {
+	Features    map[string]budgetSpend `json:"features"`
+	Workstreams map[string]budgetSpend `json:"workstreams"`
+}
+
+// LoadBudgetStatus returns burn-down for every budgeted scope.
+// Returns nil when budgets are not configured or the files are unreadable.
+func LoadBudgetStatus(projectRoot string) []BudgetStatus {
+	data, err := os.ReadFile(filepath.Join(projectRoot, budgetConfigPath))
+	if err != nil {
+		return nil
+	}
+	var cfg budgetConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil
+	}
+	if cfg.SoftWarning == 0 {
+		cfg.SoftWarning = 0.8
+	}
+	if cfg.HardStop == 0 {
+		cfg.HardStop = 1.0
+	}
+
+	var ledger budgetLedger
+	if data, err := os.ReadFile(filepath.Join(projectRoot, budgetLedgerPath)); err == nil {
+		_ = json.Unmarshal(data, &ledger)
+	}
+
+	var out []BudgetStatus
+	for _, id := range budgetIDs(ledger.Features, cfg.Features) {
+		limit, ok := cfg.Features[id]
+		if !ok {
+			limit = cfg.Defaults.Feature
+		}
+		out = append(out, newBudgetStatus(cfg, "feature", id, limit, ledger.Features[id]))
+	}
+	for _, id := range budgetIDs(ledger.Workstreams, cfg.Workstreams) {
+		limit, ok := cfg.Workstreams[id]
+		if !ok {
+			limit = cfg.Defaults.Workstream
+		}
+		out = append(out, newBudgetStatus(cfg, "workstream", id, limit, ledger.Workstreams[id]))
+	}
+	return out
+}
+
+func newBudgetStatus(cfg budgetConfig, scope, id string, limit budgetLimit, spend budgetSpend) BudgetStatus {
+	s := BudgetStatus{
+		Scope:     scope,
+		ID:        id,
+		Tokens:    spend.InputTokens + spend.OutputTokens,
+		MaxTokens: limit.MaxTokens,
+		Cost:      spend.Cost,
+		MaxCost:   limit.MaxCost,
+		Level:     "ok",
+	}
+	if limit.MaxTokens > 0 {
+		s.Used = max(s.Used, float64(s.Tokens)/float64(limit.MaxTokens))
+	}
+	if limit.MaxCost > 0 {
+		s.Used = max(s.Used, spend.Cost/limit.MaxCost)
+	}
+	switch {
+	case limit.MaxTokens == 0 && limit.MaxCost == 0:
+	case s.Used >= cfg.HardStop:
+		s.Level = "exhausted"
+	case s.Used >= cfg.SoftWarning:
+		s.Level = "warning"
+	}
+	return s
+}
+
+func budgetIDs(spent map[string]budgetSpend, configured map[string]budgetLimit) []string {
+	ids := make([]string, 0, len(spent)+len(configured))
+	for id := range spent {
+		ids = append(ids, id)
+	}
+	for id := range configured {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
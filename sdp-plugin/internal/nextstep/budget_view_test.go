@@ -0,0 +1,46 @@
+package nextstep
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBudgetFixture(t *testing.T, root string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(root, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "defaults:\n  workstream: {max_tokens: 1000}\nfeatures:\n  F069: {max_cost: 10}\n"
+	if err := os.WriteFile(filepath.Join(root, budgetConfigPath), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ledger := `{"version":1,"features":{"F069":{"input_tokens":900,"output_tokens":300,"cost":2.5}},` +
+		`"workstreams":{"00-069-01":{"input_tokens":900,"output_tokens":300,"cost":2.5}}}`
+	if err := os.WriteFile(filepath.Join(root, budgetLedgerPath), []byte(ledger), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBudgetStatus(t *testing.T) {
+	root := t.TempDir()
+	writeBudgetFixture(t, root)
+
+	got := LoadBudgetStatus(root)
+	if len(got) != 2 {
+		t.Fatalf("expected feature and workstream budgets, got %+v", got)
+	}
+	feature, ws := got[0], got[1]
+	if feature.ID != "F069" || feature.Level != "ok" || feature.Used != 0.25 {
+		t.Errorf("unexpected feature burn-down: %+v", feature)
+	}
+	if ws.ID != "00-069-01" || ws.Level != "exhausted" || ws.Tokens != 1200 {
+		t.Errorf("unexpected workstream burn-down: %+v", ws)
+	}
+}
+
+func TestLoadBudgetStatus_NoConfig(t *testing.T) {
+	if got := LoadBudgetStatus(t.TempDir()); got != nil {
+		t.Errorf("expected nil without budgets config, got %+v", got)
+	}
+}
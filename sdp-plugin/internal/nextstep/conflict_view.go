@@ -0,0 +1,39 @@
+package nextstep
+
+import (
+	"github.com/fall-out-bug/sdp/internal/collision"
+)
+
+// ConflictStatus is a predicted merge problem between two in-flight branches,
+// from the last `sdp collision predict` run.
+type ConflictStatus struct {
+	BranchA string   `json:"branch_a"`
+	BranchB string   `json:"branch_b"`
+	Level   string   `json:"level"` // conflict (textual), overlap (same symbols edited)
+	Files   []string `json:"files,omitempty"`
+	Symbols []string `json:"symbols,omitempty"` // "file: symbol"
+}
+
+// LoadConflictStatus returns cached merge-conflict predictions.
+// Returns nil when no prediction has been run or the cache is unreadable.
+func LoadConflictStatus(projectRoot string) []ConflictStatus {
+	report, err := collision.LoadPredictions(projectRoot)
+	if err != nil || report == nil {
+		return nil
+	}
+	var out []ConflictStatus
+	for _, p := range report.Predictions {
+		s := ConflictStatus{BranchA: p.BranchA, BranchB: p.BranchB, Level: "overlap"}
+		if p.HasConflicts() {
+			s.Level = "conflict"
+		}
+		for _, c := range p.Conflicts {
+			s.Files = append(s.Files, c.File)
+		}
+		for _, e := range p.SymbolEdits {
+			s.Symbols = append(s.Symbols, e.File+": "+e.Symbol)
+		}
+		out = append(out, s)
+	}
+	return out
+}
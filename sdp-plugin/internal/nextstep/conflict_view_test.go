@@ -0,0 +1,39 @@
+package nextstep
+
+import (
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/collision"
+)
+
+func writeConflictFixture(t *testing.T, root string) {
+	t.Helper()
+	err := collision.SavePredictions(root, &collision.PredictionReport{
+		Base:     "main",
+		Branches: []string{"feature/a", "feature/b"},
+		Predictions: []collision.Prediction{{
+			BranchA:     "feature/a",
+			BranchB:     "feature/b",
+			Conflicts:   []collision.FileConflict{{File: "go.mod", Kind: "content"}},
+			SymbolEdits: []collision.SymbolEdit{{File: "app.go", Symbol: "Run"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConflictStatus(t *testing.T) {
+	root := t.TempDir()
+	if got := LoadConflictStatus(root); got != nil {
+		t.Errorf("expected nil without predictions, got %+v", got)
+	}
+	writeConflictFixture(t, root)
+	got := LoadConflictStatus(root)
+	if len(got) != 1 {
+		t.Fatalf("expected one conflict, got %+v", got)
+	}
+	if got[0].Level != "conflict" || got[0].Files[0] != "go.mod" || got[0].Symbols[0] != "app.go: Run" {
+		t.Errorf("unexpected conflict status: %+v", got[0])
+	}
+}
@@ -8,16 +8,18 @@ import (
 )
 
 type StatusView struct {
-	Version       string          `json:"version"`
-	HasGit        bool            `json:"has_git"`
-	HasClaude     bool            `json:"has_claude"`
-	HasSDP        bool            `json:"has_sdp"`
-	HasBeads      bool            `json:"has_beads"`
-	Environment   EnvironmentView `json:"environment"`
-	Workstreams   WorkstreamView  `json:"workstreams"`
-	ActiveSession *SessionState   `json:"active_session,omitempty"`
-	NextAction    string          `json:"next_action"`
-	NextStep      *Recommendation `json:"next_step,omitempty"`
+	Version       string           `json:"version"`
+	HasGit        bool             `json:"has_git"`
+	HasClaude     bool             `json:"has_claude"`
+	HasSDP        bool             `json:"has_sdp"`
+	HasBeads      bool             `json:"has_beads"`
+	Environment   EnvironmentView  `json:"environment"`
+	Workstreams   WorkstreamView   `json:"workstreams"`
+	ActiveSession *SessionState    `json:"active_session,omitempty"`
+	NextAction    string           `json:"next_action"`
+	NextStep      *Recommendation  `json:"next_step,omitempty"`
+	Budgets       []BudgetStatus   `json:"budgets,omitempty"`
+	Conflicts     []ConflictStatus `json:"conflicts,omitempty"`
 }
 
 type EnvironmentView struct {
@@ -92,6 +94,8 @@ func BuildStatusView(projectRoot string, state ProjectState, nextStep *Recommend
 		Workstreams:   workstreams,
 		ActiveSession: state.Session,
 		NextStep:      nextStep,
+		Budgets:       LoadBudgetStatus(projectRoot),
+		Conflicts:     LoadConflictStatus(projectRoot),
 	}
 	if nextStep != nil {
 		view.NextAction = nextStep.Command
@@ -93,7 +93,10 @@ func TestStatusAndInstructionSchemasValidateContracts(t *testing.T) {
 	}
 	rec.enrich()
 
-	view := BuildStatusView(t.TempDir(), ProjectState{
+	root := t.TempDir()
+	writeBudgetFixture(t, root)
+	writeConflictFixture(t, root)
+	view := BuildStatusView(root, ProjectState{
 		Workstreams: []WorkstreamStatus{{ID: "00-069-01", Status: StatusReady, Priority: 0, Feature: "F069"}},
 		GitStatus:   GitStatusInfo{IsRepo: true},
 		Config:      ConfigInfo{HasSDPConfig: true},
@@ -103,6 +106,12 @@ func TestStatusAndInstructionSchemasValidateContracts(t *testing.T) {
 	if err := instructionsSchema.Validate(instructionDoc); err != nil {
 		t.Fatalf("instructions schema validation failed: %v", err)
 	}
+	if len(view.Budgets) == 0 {
+		t.Fatal("expected budgets in status view")
+	}
+	if len(view.Conflicts) != 1 || view.Conflicts[0].Level != "conflict" {
+		t.Fatalf("expected one predicted conflict in status view, got %+v", view.Conflicts)
+	}
 	statusDoc := mustJSONDoc(t, view)
 	if err := statusViewSchema.Validate(statusDoc); err != nil {
 		t.Fatalf("status-view schema validation failed: %v", err)
@@ -13,7 +13,21 @@ func ValidateFile(wsPath string) ([]ValidationIssue, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse workstream: %w", err)
 	}
+	return ValidateWorkstream(ws), nil
+}
+
+// ValidateContent validates unsaved workstream markdown and returns any issues
+func ValidateContent(name string, content []byte) ([]ValidationIssue, error) {
+	ws, err := ParseWorkstreamContent(name, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workstream: %w", err)
+	}
+	return ValidateWorkstream(ws), nil
+}
 
+// ValidateWorkstream checks a parsed workstream. Scope files are resolved
+// relative to the working directory.
+func ValidateWorkstream(ws *Workstream) []ValidationIssue {
 	var issues []ValidationIssue
 
 	// Validate workstream ID
@@ -81,7 +95,7 @@ func ValidateFile(wsPath string) ([]ValidationIssue, error) {
 		}
 	}
 
-	return issues, nil
+	return issues
 }
 
 // fileExists checks if a file exists
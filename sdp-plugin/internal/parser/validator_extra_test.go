@@ -409,3 +409,30 @@ Test goal
 	}
 }
 */
+
+// TestValidateContent tests validation of unsaved workstream content
+func TestValidateContent(t *testing.T) {
+	content := `---
+ws_id: 00-050-1
+feature: F050
+status: backlog
+---
+
+## Goal
+Validate editor buffers
+
+## Acceptance Criteria
+- [ ] Issues are reported without saving
+`
+	issues, err := ValidateContent("buffer.md", []byte(content))
+	if err != nil {
+		t.Fatalf("ValidateContent: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Field != "ws_id" || issues[0].Severity != "ERROR" {
+		t.Errorf("issues = %+v, want one ws_id error", issues)
+	}
+
+	if _, err := ValidateContent("buffer.md", nil); err == nil || !strings.Contains(err.Error(), "buffer.md") {
+		t.Errorf("expected empty-content error naming the buffer, got %v", err)
+	}
+}
@@ -15,10 +15,15 @@ func ParseWorkstream(wsPath string) (*Workstream, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return ParseWorkstreamContent(wsPath, content)
+}
 
+// ParseWorkstreamContent parses workstream markdown that has not been saved
+// yet (e.g. an editor buffer). name is only used in error messages.
+func ParseWorkstreamContent(name string, content []byte) (*Workstream, error) {
 	// Check if file is empty
 	if len(content) == 0 {
-		return nil, fmt.Errorf("file is empty: %s", wsPath)
+		return nil, fmt.Errorf("file is empty: %s", name)
 	}
 
 	// Validate file size
@@ -0,0 +1,50 @@
+// Package pathmatch matches slash-separated paths against globs with "**".
+package pathmatch
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether the slash-separated name matches pattern. A "**"
+// segment matches zero or more segments; "**" inside a segment ("**.go")
+// behaves like "*"; other segments follow path.Match. An empty or malformed
+// pattern matches nothing.
+func Match(pattern, name string) bool {
+	if pattern == "" {
+		return false
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// Any reports whether name matches any of patterns.
+func Any(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if Match(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		segment := strings.ReplaceAll(pattern[0], "**", "*")
+		if ok, err := path.Match(segment, name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,38 @@
+package pathmatch
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"", "a.go", false},
+		{"a/b.go", "a/b.go", true},
+		{"a/*.go", "a/b.go", true},
+		{"a/*.go", "a/c/b.go", false},
+		{"**/*.go", "b.go", true},
+		{"**/*.go", "a/c/b.go", true},
+		{"internal/**", "internal/a/b.go", true},
+		{"internal/**", "cmd/a.go", false},
+		{"internal/**/store.go", "internal/store.go", true},
+		{"internal/**/store.go", "internal/a/b/store.go", true},
+		{"src/**.go", "src/a.go", true},
+		{"a?.go", "ab.go", true},
+		{"[", "[", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAny(t *testing.T) {
+	if !Any([]string{"*.md", "**/*_test.go"}, "a/b_test.go") {
+		t.Error("expected a match")
+	}
+	if Any(nil, "a.go") {
+		t.Error("expected no match for no patterns")
+	}
+}
@@ -15,9 +15,10 @@ const (
 )
 
 type Checker struct {
-	projectPath string
-	projectType Type
-	strictMode  bool
+	projectPath    string
+	projectType    Type
+	strictMode     bool
+	complexityBase string
 }
 
 type CoverageResult struct {
@@ -37,16 +38,24 @@ type FileCoverage struct {
 type ComplexityResult struct {
 	AverageCC    float64
 	MaxCC        int
+	MaxCognitive int
 	Threshold    int
 	Passed       bool
 	ComplexFiles []FileComplexity
+	// Files is the full per-file breakdown (Go only).
+	Files []FileComplexity
+	// Base is the merge base the deltas were computed against, if any.
+	Base   string
+	Deltas []ComplexityDelta
 }
 
 type FileComplexity struct {
 	File             string
 	AverageCC        float64
 	MaxCC            int
+	MaxCognitive     int
 	ExceedsThreshold bool
+	Functions        []FunctionComplexity
 }
 
 type FileSizeResult struct {
@@ -154,6 +163,12 @@ func (c *Checker) SetStrictMode(strict bool) {
 	c.strictMode = strict
 }
 
+// SetComplexityBase makes the Go complexity gate compare against the merge
+// base of HEAD and ref, failing only on functions the change made worse.
+func (c *Checker) SetComplexityBase(ref string) {
+	c.complexityBase = ref
+}
+
 // IsStrictMode returns whether strict mode is enabled
 func (c *Checker) IsStrictMode() bool {
 	return c.strictMode
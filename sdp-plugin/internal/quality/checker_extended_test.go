@@ -166,7 +166,7 @@ func TestBasicPythonComplexity(t *testing.T) {
 	_ = result.ComplexFiles
 }
 
-func TestBasicGoComplexity(t *testing.T) {
+func TestGoComplexityIgnoresLength(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "sdp-test-complexity-go-basic-*")
 	if err != nil {
 		t.Fatal(err)
@@ -178,7 +178,7 @@ func TestBasicGoComplexity(t *testing.T) {
 	for i := range lines {
 		lines[i] = "// line " + string(rune('0'+i%10))
 	}
-	content := strings.Join(lines, "\n")
+	content := "package main\n\nfunc long() {\n" + strings.Join(lines, "\n") + "\n}\n"
 
 	if err := os.WriteFile(filepath.Join(tmpDir, "complex.go"), []byte(content), 0o644); err != nil {
 		t.Fatal(err)
@@ -189,13 +189,14 @@ func TestBasicGoComplexity(t *testing.T) {
 		projectType: Go,
 	}
 
-	result, err := checker.basicGoComplexity(&ComplexityResult{Threshold: 10})
+	result, err := checker.checkGoComplexity(&ComplexityResult{Threshold: 10})
 	if err != nil {
-		t.Fatalf("basicGoComplexity failed: %v", err)
+		t.Fatalf("checkGoComplexity failed: %v", err)
 	}
 
-	if result.MaxCC == 0 {
-		t.Errorf("Expected MaxCC > 0, got %d", result.MaxCC)
+	// Length alone does not add complexity
+	if result.MaxCC != 1 || !result.Passed {
+		t.Errorf("Expected MaxCC 1 and pass, got %d (passed %v)", result.MaxCC, result.Passed)
 	}
 }
 
@@ -371,11 +372,12 @@ func TestDetectProjectTypeFallback(t *testing.T) {
 	}
 }
 
-// TestBasicGoComplexityFallback tests fallback when gocyclo not available
-func TestBasicGoComplexityFallback(t *testing.T) {
+// TestGoComplexityManySimpleFunctions tests that a long file of trivial
+// functions is not flagged: complexity is measured per function, not per LOC
+func TestGoComplexityManySimpleFunctions(t *testing.T) {
 	tmpDir := t.TempDir()
 
-	// Create a Go file that will exceed threshold (>100 LOC)
+	// Create a long Go file (>100 LOC) of trivial functions
 	complexFile := filepath.Join(tmpDir, "complex.go")
 	lines := make([]string, 0, 452)
 	lines = append(lines, "package main")
@@ -398,25 +400,19 @@ func TestBasicGoComplexityFallback(t *testing.T) {
 		Threshold: 10,
 	}
 
-	// Run basic complexity check (gocyclo will not be available)
-	finalResult, err := checker.basicGoComplexity(result)
+	finalResult, err := checker.checkGoComplexity(result)
 	if err != nil {
-		t.Fatalf("basicGoComplexity() failed: %v", err)
+		t.Fatalf("checkGoComplexity() failed: %v", err)
 	}
 
-	// Should have detected complex file
-	if len(finalResult.ComplexFiles) == 0 {
-		t.Error("basicGoComplexity() should detect complex files")
+	if len(finalResult.ComplexFiles) != 0 {
+		t.Errorf("checkGoComplexity() flagged simple functions: %+v", finalResult.ComplexFiles)
 	}
-
-	// MaxCC should be set (estimated from LOC)
-	if finalResult.MaxCC == 0 {
-		t.Error("basicGoComplexity() should set MaxCC")
+	if finalResult.MaxCC != 1 || !finalResult.Passed {
+		t.Errorf("Expected MaxCC 1 and pass, got %d (passed %v)", finalResult.MaxCC, finalResult.Passed)
 	}
-
-	// Should fail because file exceeds threshold
-	if finalResult.Passed {
-		t.Error("basicGoComplexity() should fail when files exceed threshold")
+	if len(finalResult.Files) != 1 || len(finalResult.Files[0].Functions) != 150 {
+		t.Errorf("Expected 150 functions in breakdown, got %+v", finalResult.Files)
 	}
 }
 
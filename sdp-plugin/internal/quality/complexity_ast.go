@@ -0,0 +1,251 @@
+package quality
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+)
+
+// FunctionComplexity is the complexity of a single Go function or method.
+type FunctionComplexity struct {
+	Name       string
+	Line       int
+	Cyclomatic int
+	Cognitive  int
+}
+
+// AnalyzeGoSource computes cyclomatic and cognitive complexity for every
+// function declared in src. Functions are returned in source order; methods
+// are named "Recv.Method".
+func AnalyzeGoSource(filename string, src []byte) ([]FunctionComplexity, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []FunctionComplexity
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		recv := receiverName(fn)
+		name := fn.Name.Name
+		if recv != "" {
+			name = recv + "." + name
+		}
+		cw := &cognitiveWalker{name: fn.Name.Name, method: fn.Recv != nil, recv: receiverVar(fn)}
+		ast.Walk(cw, fn.Body)
+		funcs = append(funcs, FunctionComplexity{
+			Name:       name,
+			Line:       fset.Position(fn.Pos()).Line,
+			Cyclomatic: cyclomatic(fn.Body),
+			Cognitive:  cw.score,
+		})
+	}
+	return funcs, nil
+}
+
+// cyclomatic follows gocyclo: 1 plus one per if, for, range, non-default
+// case, non-default select clause, && and ||.
+func cyclomatic(body *ast.BlockStmt) int {
+	cc := 1
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			cc++
+		case *ast.CaseClause:
+			if n.List != nil {
+				cc++
+			}
+		case *ast.CommClause:
+			if n.Comm != nil {
+				cc++
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				cc++
+			}
+		}
+		return true
+	})
+	return cc
+}
+
+// cognitiveWalker scores a function body using the cognitive complexity
+// rules: structural breaks cost 1 plus the current nesting depth, else
+// branches and labelled jumps cost 1, each run of mixed logical operators
+// costs 1, and direct recursion costs 1. Closures raise nesting.
+type cognitiveWalker struct {
+	name    string
+	method  bool
+	recv    string
+	nesting int
+	score   int
+}
+
+func (w *cognitiveWalker) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.IfStmt:
+		w.score += 1 + w.nesting
+		w.walkIf(n)
+		return nil
+	case *ast.ForStmt:
+		w.score += 1 + w.nesting
+		w.walk(n.Init)
+		w.walk(n.Cond)
+		w.walk(n.Post)
+		w.nested(n.Body)
+		return nil
+	case *ast.RangeStmt:
+		w.score += 1 + w.nesting
+		w.walk(n.X)
+		w.nested(n.Body)
+		return nil
+	case *ast.SwitchStmt:
+		w.score += 1 + w.nesting
+		w.walk(n.Init)
+		w.walk(n.Tag)
+		w.nested(n.Body)
+		return nil
+	case *ast.TypeSwitchStmt:
+		w.score += 1 + w.nesting
+		w.walk(n.Init)
+		w.walk(n.Assign)
+		w.nested(n.Body)
+		return nil
+	case *ast.SelectStmt:
+		w.score += 1 + w.nesting
+		w.nested(n.Body)
+		return nil
+	case *ast.FuncLit:
+		w.nested(n.Body)
+		return nil
+	case *ast.BranchStmt:
+		if n.Tok == token.GOTO || n.Label != nil {
+			w.score++
+		}
+	case *ast.BinaryExpr:
+		if n.Op == token.LAND || n.Op == token.LOR {
+			w.walkLogical(n)
+			return nil
+		}
+	case *ast.CallExpr:
+		if w.isRecursive(n) {
+			w.score++
+		}
+	}
+	return w
+}
+
+func (w *cognitiveWalker) walk(n ast.Node) {
+	if n != nil {
+		ast.Walk(w, n)
+	}
+}
+
+func (w *cognitiveWalker) nested(body *ast.BlockStmt) {
+	w.nesting++
+	ast.Walk(w, body)
+	w.nesting--
+}
+
+// walkIf scores the else chain of n; the caller has already scored n itself.
+func (w *cognitiveWalker) walkIf(n *ast.IfStmt) {
+	w.walk(n.Init)
+	w.walk(n.Cond)
+	w.nested(n.Body)
+	switch e := n.Else.(type) {
+	case *ast.IfStmt:
+		w.score++
+		w.walkIf(e)
+	case *ast.BlockStmt:
+		w.score++
+		w.nested(e)
+	}
+}
+
+// walkLogical charges one point per run of identical operators in a chain
+// such as a && b && c || d (two runs), then visits the operands.
+func (w *cognitiveWalker) walkLogical(n *ast.BinaryExpr) {
+	var ops []token.Token
+	var operands []ast.Expr
+	var flatten func(e ast.Expr)
+	flatten = func(e ast.Expr) {
+		if b, ok := e.(*ast.BinaryExpr); ok && (b.Op == token.LAND || b.Op == token.LOR) {
+			flatten(b.X)
+			ops = append(ops, b.Op)
+			flatten(b.Y)
+			return
+		}
+		operands = append(operands, e)
+	}
+	flatten(n)
+
+	for i, op := range ops {
+		if i == 0 || op != ops[i-1] {
+			w.score++
+		}
+	}
+	for _, e := range operands {
+		w.walk(e)
+	}
+}
+
+func (w *cognitiveWalker) isRecursive(call *ast.CallExpr) bool {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return !w.method && fun.Name == w.name
+	case *ast.SelectorExpr:
+		x, ok := fun.X.(*ast.Ident)
+		return ok && w.recv != "" && x.Name == w.recv && fun.Sel.Name == w.name
+	}
+	return false
+}
+
+// receiverName returns the receiver type name of a method without pointer
+// or type parameters, or "" for plain functions.
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	t := fn.Recv.List[0].Type
+	for {
+		switch x := t.(type) {
+		case *ast.StarExpr:
+			t = x.X
+		case *ast.IndexExpr:
+			t = x.X
+		case *ast.IndexListExpr:
+			t = x.X
+		case *ast.Ident:
+			return x.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// receiverVar returns the receiver variable name used for recursion detection.
+func receiverVar(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+		return ""
+	}
+	return fn.Recv.List[0].Names[0].Name
+}
+
+// sortByCyclomatic orders functions by descending cyclomatic complexity,
+// breaking ties by cognitive complexity and then name.
+func sortByCyclomatic(funcs []FunctionComplexity) {
+	sort.Slice(funcs, func(i, j int) bool {
+		if funcs[i].Cyclomatic != funcs[j].Cyclomatic {
+			return funcs[i].Cyclomatic > funcs[j].Cyclomatic
+		}
+		if funcs[i].Cognitive != funcs[j].Cognitive {
+			return funcs[i].Cognitive > funcs[j].Cognitive
+		}
+		return funcs[i].Name < funcs[j].Name
+	})
+}
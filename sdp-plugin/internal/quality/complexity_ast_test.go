@@ -0,0 +1,88 @@
+package quality
+
+import "testing"
+
+func TestAnalyzeGoSource(t *testing.T) {
+	src := `package sample
+
+type Tree[T any] struct{ left, right *Tree[T] }
+
+func simple() int { return 1 }
+
+// cyclomatic 1+2(if)+1(&&)+1(||)+1(range)+2(case)=8
+// cognitive: if 1, && || 2, else 1, range 1, switch 2, if in closure 4
+func mixed(a, b, c bool, xs []int) int {
+	n := 0
+	if a && b || c {
+		n++
+	} else {
+		n--
+	}
+	for _, x := range xs {
+		switch x {
+		case 1:
+			n++
+		case 2:
+			n += 2
+		default:
+			func() {
+				if n > 10 {
+					n = 0
+				}
+			}()
+		}
+	}
+	return n
+}
+
+func (t *Tree[T]) Depth() int {
+	if t == nil {
+		return 0
+	}
+	return 1 + max(t.left.Depth(), t.right.Depth())
+}
+
+func loop() {
+outer:
+	for {
+		select {
+		case <-make(chan int):
+			break outer
+		default:
+		}
+	}
+}
+
+func fact(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * fact(n-1)
+}
+`
+	funcs, err := AnalyzeGoSource("sample.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FunctionComplexity{
+		{Name: "simple", Line: 5, Cyclomatic: 1, Cognitive: 0},
+		{Name: "mixed", Line: 9, Cyclomatic: 8, Cognitive: 11},
+		{Name: "Tree.Depth", Line: 33, Cyclomatic: 2, Cognitive: 1},
+		{Name: "loop", Line: 40, Cyclomatic: 3, Cognitive: 4},
+		{Name: "fact", Line: 51, Cyclomatic: 2, Cognitive: 2},
+	}
+	if len(funcs) != len(want) {
+		t.Fatalf("expected %d functions, got %+v", len(want), funcs)
+	}
+	for i, w := range want {
+		if funcs[i] != w {
+			t.Errorf("function %d = %+v, want %+v", i, funcs[i], w)
+		}
+	}
+}
+
+func TestAnalyzeGoSourceParseError(t *testing.T) {
+	if _, err := AnalyzeGoSource("bad.go", []byte("package x\nfunc {")); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,117 @@
+package quality
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// ComplexityDelta is the change in complexity of one function between the
+// merge base and the working tree. New functions have zero base values.
+type ComplexityDelta struct {
+	File           string
+	Function       string
+	New            bool
+	BaseCyclomatic int
+	Cyclomatic     int
+	BaseCognitive  int
+	Cognitive      int
+}
+
+// Worsened reports whether either metric increased.
+func (d ComplexityDelta) Worsened() bool {
+	return d.Cyclomatic > d.BaseCyclomatic || d.Cognitive > d.BaseCognitive
+}
+
+// goComplexityDeltas compares the analysed working tree against the merge
+// base of HEAD and the configured base ref. Only files changed since the
+// merge base are re-analysed at the base revision.
+func (c *Checker) goComplexityDeltas(files map[string][]FunctionComplexity) (string, []ComplexityDelta, error) {
+	out, err := c.git("merge-base", "HEAD", c.complexityBase)
+	if err != nil {
+		return "", nil, fmt.Errorf("merge-base HEAD %s: %w", c.complexityBase, err)
+	}
+	mergeBase := strings.TrimSpace(string(out))
+
+	changed, err := c.changedSince(mergeBase)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var deltas []ComplexityDelta
+	for _, path := range changed {
+		current, ok := files[path]
+		if !ok {
+			continue
+		}
+		before := make(map[string]FunctionComplexity)
+		if src, err := c.git("show", mergeBase+":./"+path); err == nil {
+			if funcs, err := AnalyzeGoSource(path, src); err == nil {
+				for _, fn := range funcs {
+					before[fn.Name] = fn
+				}
+			}
+		}
+		for _, fn := range current {
+			old, existed := before[fn.Name]
+			if existed && old.Cyclomatic == fn.Cyclomatic && old.Cognitive == fn.Cognitive {
+				continue
+			}
+			deltas = append(deltas, ComplexityDelta{
+				File:           path,
+				Function:       fn.Name,
+				New:            !existed,
+				BaseCyclomatic: old.Cyclomatic,
+				Cyclomatic:     fn.Cyclomatic,
+				BaseCognitive:  old.Cognitive,
+				Cognitive:      fn.Cognitive,
+			})
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].File != deltas[j].File {
+			return deltas[i].File < deltas[j].File
+		}
+		return deltas[i].Function < deltas[j].Function
+	})
+	return mergeBase, deltas, nil
+}
+
+// changedSince lists project-relative Go files modified since rev, including
+// uncommitted and untracked files.
+func (c *Checker) changedSince(rev string) ([]string, error) {
+	diff, err := c.git("diff", "--name-only", "--relative", rev, "--", "*.go")
+	if err != nil {
+		return nil, fmt.Errorf("diff %s: %w", rev, err)
+	}
+	untracked, err := c.git("ls-files", "--others", "--exclude-standard", "--", "*.go")
+	if err != nil {
+		return nil, fmt.Errorf("ls-files: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var paths []string
+	for line := range strings.SplitSeq(string(diff)+"\n"+string(untracked), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !seen[line] {
+			seen[line] = true
+			paths = append(paths, line)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (c *Checker) git(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = c.projectPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
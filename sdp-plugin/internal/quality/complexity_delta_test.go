@@ -0,0 +1,112 @@
+package quality
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// legacy is already over the threshold; tidy is simple. The feature branch
+// leaves legacy alone and makes tidy worse.
+const deltaBase = `package app
+
+func legacy(x int) int {
+` + "\tif x > 0 {\n\t\tx--\n\t}\n\tif x > 1 {\n\t\tx--\n\t}\n\tif x > 2 {\n\t\tx--\n\t}\n\tif x > 3 {\n\t\tx--\n\t}\n" + `	return x
+}
+
+func tidy(x int) int {
+	return x
+}
+`
+
+func deltaRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module app\n\ngo 1.21\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "app.go"), []byte(deltaBase), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("init", "-q", "-b", "main")
+	run("add", ".")
+	run("commit", "-qm", "base")
+	run("checkout", "-q", "-b", "feature")
+	return dir
+}
+
+func writeTidy(t *testing.T, dir, body string) {
+	t.Helper()
+	src := strings.Replace(deltaBase, "func tidy(x int) int {\n\treturn x\n}", "func tidy(x int) int {\n"+body+"\treturn x\n}", 1)
+	if err := os.WriteFile(filepath.Join(dir, "app.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGoComplexityDeltas_LegacyIgnored(t *testing.T) {
+	dir := deltaRepo(t)
+	writeTidy(t, dir, "\tif x > 0 {\n\t\tx++\n\t}\n")
+	if err := os.WriteFile(filepath.Join(dir, "extra.go"), []byte("package app\n\nfunc extra() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := &Checker{projectPath: dir, projectType: Go}
+	checker.SetComplexityBase("main")
+	result, err := checker.checkGoComplexity(&ComplexityResult{Threshold: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ComplexFiles) != 1 {
+		t.Errorf("expected legacy file still reported, got %+v", result.ComplexFiles)
+	}
+	if !result.Passed {
+		t.Errorf("expected pass: legacy untouched and tidy under threshold, deltas %+v", result.Deltas)
+	}
+	if result.Base == "" || len(result.Deltas) != 2 {
+		t.Fatalf("expected tidy and extra deltas, got %+v", result.Deltas)
+	}
+	if d := result.Deltas[0]; d.File != "app.go" || d.Function != "tidy" || d.BaseCyclomatic != 1 || d.Cyclomatic != 2 || !d.Worsened() {
+		t.Errorf("unexpected tidy delta: %+v", d)
+	}
+	if d := result.Deltas[1]; d.File != "extra.go" || !d.New {
+		t.Errorf("expected new function in untracked file, got %+v", d)
+	}
+}
+
+func TestGoComplexityDeltas_WorsenedOverThreshold(t *testing.T) {
+	dir := deltaRepo(t)
+	writeTidy(t, dir, strings.Repeat("\tif x > 0 {\n\t\tx++\n\t}\n", 5))
+
+	checker := &Checker{projectPath: dir, projectType: Go}
+	checker.SetComplexityBase("main")
+	result, err := checker.checkGoComplexity(&ComplexityResult{Threshold: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Passed {
+		t.Errorf("expected failure when tidy grows past the threshold, deltas %+v", result.Deltas)
+	}
+}
+
+func TestGoComplexityDeltas_UnknownBase(t *testing.T) {
+	dir := deltaRepo(t)
+	checker := &Checker{projectPath: dir, projectType: Go}
+	checker.SetComplexityBase("no-such-branch")
+	if _, err := checker.checkGoComplexity(&ComplexityResult{Threshold: 10}); err == nil {
+		t.Error("expected error for unknown base ref")
+	}
+}
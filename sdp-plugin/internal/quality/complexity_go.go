@@ -1,10 +1,10 @@
 package quality
 
 import (
+	"io/fs"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/fall-out-bug/sdp/internal/config"
@@ -29,86 +29,82 @@ func (c *Checker) shouldExcludeComplexity(fileName string) bool {
 	return false
 }
 
+// checkGoComplexity analyses every non-test Go file in-process. Without a
+// complexity base the gate fails on any function over the threshold; with a
+// base it fails only on functions this change made worse and that end up
+// over the threshold.
 func (c *Checker) checkGoComplexity(result *ComplexityResult) (*ComplexityResult, error) {
-	// Use gocyclo if available
-	over := strconv.Itoa(result.Threshold)
-	cmd := exec.Command("gocyclo", "-over", over, ".")
-	cmd.Dir = c.projectPath
-	output, err := cmd.CombinedOutput()
-
+	files, err := c.analyzeGoFiles()
 	if err != nil {
-		// gocyclo not available, do basic check
-		return c.basicGoComplexity(result)
+		return nil, err
 	}
 
-	// Parse gocyclo output
-	outputStr := string(output)
-	lines := strings.Split(outputStr, "\n")
-
 	totalCC := 0
-	fileCount := 0
-	maxCC := 0
-	complexFiles := []FileComplexity{}
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
+	funcCount := 0
+	for _, path := range sortedKeys(files) {
+		funcs := files[path]
+		if len(funcs) == 0 {
 			continue
 		}
-
-		parts := strings.Split(line, " ")
-		if len(parts) >= 2 {
-			fileName := parts[len(parts)-1]
-			if c.shouldExcludeComplexity(fileName) {
-				continue
-			}
-
-			cc, err := strconv.Atoi(parts[0])
-			if err == nil {
-				totalCC += cc
-				fileCount++
-				if cc > maxCC {
-					maxCC = cc
-				}
-
-				if cc > result.Threshold {
-					complexFiles = append(complexFiles, FileComplexity{
-						File:             fileName,
-						AverageCC:        float64(cc),
-						MaxCC:            cc,
-						ExceedsThreshold: true,
-					})
-				}
-			}
+		fc := FileComplexity{File: path, Functions: funcs}
+		fileCC := 0
+		for _, fn := range funcs {
+			fileCC += fn.Cyclomatic
+			fc.MaxCC = max(fc.MaxCC, fn.Cyclomatic)
+			fc.MaxCognitive = max(fc.MaxCognitive, fn.Cognitive)
 		}
+		fc.AverageCC = float64(fileCC) / float64(len(funcs))
+		fc.ExceedsThreshold = fc.MaxCC > result.Threshold
+		sortByCyclomatic(fc.Functions)
+
+		totalCC += fileCC
+		funcCount += len(funcs)
+		result.MaxCC = max(result.MaxCC, fc.MaxCC)
+		result.MaxCognitive = max(result.MaxCognitive, fc.MaxCognitive)
+		result.Files = append(result.Files, fc)
+		if fc.ExceedsThreshold {
+			result.ComplexFiles = append(result.ComplexFiles, fc)
+		}
+	}
+	if funcCount > 0 {
+		result.AverageCC = float64(totalCC) / float64(funcCount)
 	}
 
-	if fileCount > 0 {
-		result.AverageCC = float64(totalCC) / float64(fileCount)
+	if c.complexityBase == "" {
+		result.Passed = result.MaxCC <= result.Threshold
+		return result, nil
 	}
-	result.MaxCC = maxCC
-	result.Passed = maxCC <= result.Threshold
-	result.ComplexFiles = complexFiles
 
+	mergeBase, deltas, err := c.goComplexityDeltas(files)
+	if err != nil {
+		return nil, err
+	}
+	result.Base = mergeBase
+	result.Deltas = deltas
+	result.Passed = true
+	for _, d := range deltas {
+		if d.Worsened() && d.Cyclomatic > result.Threshold {
+			result.Passed = false
+		}
+	}
 	return result, nil
 }
 
-func (c *Checker) basicGoComplexity(result *ComplexityResult) (*ComplexityResult, error) {
-	// Basic check similar to Python
-	err := filepath.Walk(c.projectPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+// analyzeGoFiles maps project-relative paths of non-test, non-generated Go
+// files to their function complexities.
+func (c *Checker) analyzeGoFiles() (map[string][]FunctionComplexity, error) {
+	files := make(map[string][]FunctionComplexity)
+	err := filepath.WalkDir(c.projectPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
 			return err
 		}
-
-		if !strings.HasSuffix(path, ".go") {
-			return nil
-		}
-
-		// Skip generated, test, and excluded paths
-		if strings.Contains(path, "generated") || strings.Contains(path, "_test.go") {
+		if d.IsDir() {
+			if path != c.projectPath && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
 			return nil
 		}
-		if c.shouldExcludeComplexity(path) {
+		if !isComplexityCandidate(path) || c.shouldExcludeComplexity(path) {
 			return nil
 		}
 
@@ -116,31 +112,35 @@ func (c *Checker) basicGoComplexity(result *ComplexityResult) (*ComplexityResult
 		if err != nil {
 			return err
 		}
-
-		lines := strings.Split(string(content), "\n")
-		loc := len(lines)
-
-		estimatedCC := loc / 10
-		if estimatedCC > result.Threshold {
-			result.ComplexFiles = append(result.ComplexFiles, FileComplexity{
-				File:             path,
-				AverageCC:        float64(estimatedCC),
-				MaxCC:            estimatedCC,
-				ExceedsThreshold: true,
-			})
+		funcs, err := AnalyzeGoSource(path, content)
+		if err != nil {
+			// Unparseable files are reported by the type check, not here.
+			return nil
 		}
-
-		if estimatedCC > result.MaxCC {
-			result.MaxCC = estimatedCC
+		rel, err := filepath.Rel(c.projectPath, path)
+		if err != nil {
+			rel = path
 		}
-
+		files[filepath.ToSlash(rel)] = funcs
 		return nil
 	})
-
 	if err != nil {
 		return nil, err
 	}
+	return files, nil
+}
 
-	result.Passed = len(result.ComplexFiles) == 0
-	return result, nil
+func isComplexityCandidate(path string) bool {
+	return strings.HasSuffix(path, ".go") &&
+		!strings.HasSuffix(path, "_test.go") &&
+		!strings.Contains(path, "generated")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
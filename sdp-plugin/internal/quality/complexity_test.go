@@ -75,7 +75,7 @@ func TestCheckGoComplexity_LargeFile(t *testing.T) {
 	}
 }
 
-func TestBasicGoComplexity_EmptyDir(t *testing.T) {
+func TestCheckGoComplexity_EmptyDir(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	checker, err := NewChecker(tmpDir)
@@ -87,14 +87,14 @@ func TestBasicGoComplexity_EmptyDir(t *testing.T) {
 		Threshold: 10,
 	}
 
-	got, err := checker.basicGoComplexity(result)
+	got, err := checker.checkGoComplexity(result)
 	if err != nil {
-		t.Errorf("basicGoComplexity returned error: %v", err)
+		t.Errorf("checkGoComplexity returned error: %v", err)
 		return
 	}
 
 	if got == nil {
-		t.Error("basicGoComplexity should return non-nil result")
+		t.Error("checkGoComplexity should return non-nil result")
 		return
 	}
 
@@ -103,7 +103,7 @@ func TestBasicGoComplexity_EmptyDir(t *testing.T) {
 	}
 }
 
-func TestBasicGoComplexity_WithFiles(t *testing.T) {
+func TestCheckGoComplexity_WithFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Create Go files
@@ -129,14 +129,14 @@ func simple() int {
 		Threshold: 10,
 	}
 
-	got, err := checker.basicGoComplexity(result)
+	got, err := checker.checkGoComplexity(result)
 	if err != nil {
-		t.Errorf("basicGoComplexity returned error: %v", err)
+		t.Errorf("checkGoComplexity returned error: %v", err)
 		return
 	}
 
 	if got == nil {
-		t.Error("basicGoComplexity should return non-nil result")
+		t.Error("checkGoComplexity should return non-nil result")
 	}
 }
 
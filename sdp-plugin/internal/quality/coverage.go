@@ -8,7 +8,25 @@ import (
 )
 
 func (c *Checker) CheckCoverage(ctx context.Context) (*CoverageResult, error) {
-	// Load threshold from project config first, then guard rules
+	result := &CoverageResult{
+		Threshold: coverageThreshold(),
+	}
+
+	switch c.projectType {
+	case Python:
+		return c.checkPythonCoverage(ctx, result)
+	case Go:
+		return c.checkGoCoverage(ctx, result)
+	case Java:
+		return c.checkJavaCoverage(ctx, result)
+	default:
+		return result, fmt.Errorf("unsupported project type: %d", c.projectType)
+	}
+}
+
+// coverageThreshold loads the threshold from project config first, then
+// guard rules, defaulting to 80%.
+func coverageThreshold() float64 {
 	threshold := 80.0 // default
 	projectRoot, rootErr := config.FindProjectRoot()
 	if rootErr == nil {
@@ -33,19 +51,5 @@ func (c *Checker) CheckCoverage(ctx context.Context) (*CoverageResult, error) {
 			}
 		}
 	}
-
-	result := &CoverageResult{
-		Threshold: threshold,
-	}
-
-	switch c.projectType {
-	case Python:
-		return c.checkPythonCoverage(ctx, result)
-	case Go:
-		return c.checkGoCoverage(ctx, result)
-	case Java:
-		return c.checkJavaCoverage(ctx, result)
-	default:
-		return result, fmt.Errorf("unsupported project type: %d", c.projectType)
-	}
+	return threshold
 }
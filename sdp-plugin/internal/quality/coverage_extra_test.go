@@ -347,8 +347,8 @@ func TestCheckCoverage_JavaProject(t *testing.T) {
 	t.Logf("Coverage: %.1f%%, Passed: %v", result.Coverage, result.Passed)
 }
 
-// TestBasicGoComplexity_SkipFiles tests that generated and test files are skipped
-func TestBasicGoComplexity_SkipFiles(t *testing.T) {
+// TestGoComplexity_SkipFiles tests that generated and test files are skipped
+func TestGoComplexity_SkipFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Create go.mod
@@ -356,14 +356,12 @@ func TestBasicGoComplexity_SkipFiles(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Create large test file (should be skipped)
-	largeTest := strings.Repeat("// line\n", 300)
-	if err := os.WriteFile(filepath.Join(tmpDir, "large_test.go"), []byte("package main\n\n"+largeTest), 0o644); err != nil {
+	// Complex test and generated files (should be skipped)
+	complexFunc := "package main\n\nfunc f(x int) int {\n" + strings.Repeat("\tif x > 0 {\n\t\tx--\n\t}\n", 20) + "\treturn x\n}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "large_test.go"), []byte(complexFunc), 0o644); err != nil {
 		t.Fatal(err)
 	}
-
-	// Create large generated file (should be skipped)
-	if err := os.WriteFile(filepath.Join(tmpDir, "generated_code.go"), []byte("package main\n\n"+largeTest), 0o644); err != nil {
+	if err := os.WriteFile(filepath.Join(tmpDir, "generated_code.go"), []byte(complexFunc), 0o644); err != nil {
 		t.Fatal(err)
 	}
 
@@ -377,17 +375,21 @@ func TestBasicGoComplexity_SkipFiles(t *testing.T) {
 		projectType: Go,
 	}
 
-	result, err := checker.basicGoComplexity(&ComplexityResult{Threshold: 10})
+	result, err := checker.checkGoComplexity(&ComplexityResult{Threshold: 10})
 	if err != nil {
-		t.Fatalf("basicGoComplexity failed: %v", err)
+		t.Fatalf("checkGoComplexity failed: %v", err)
 	}
 
-	// Should only have the normal file checked
-	t.Logf("ComplexFiles: %d, MaxCC: %d, Passed: %v", len(result.ComplexFiles), result.MaxCC, result.Passed)
+	if len(result.Files) != 1 || result.Files[0].File != "normal.go" {
+		t.Errorf("Expected only normal.go analysed, got %+v", result.Files)
+	}
+	if !result.Passed || result.MaxCC != 1 {
+		t.Errorf("Expected pass with MaxCC 1, got MaxCC %d, Passed %v", result.MaxCC, result.Passed)
+	}
 }
 
-// TestBasicGoComplexity_ComplexFile tests detection of complex files
-func TestBasicGoComplexity_ComplexFile(t *testing.T) {
+// TestGoComplexity_ComplexFile tests detection of complex files
+func TestGoComplexity_ComplexFile(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Create go.mod
@@ -395,9 +397,12 @@ func TestBasicGoComplexity_ComplexFile(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Create a large file that exceeds threshold (250 lines -> CC 25 > 10)
-	largeContent := strings.Repeat("// comment line\n", 250)
-	if err := os.WriteFile(filepath.Join(tmpDir, "complex.go"), []byte("package main\n\n"+largeContent), 0o644); err != nil {
+	// 12 sequential ifs -> CC 13 > 10; a long comment-only file stays simple
+	complexFunc := "package main\n\nfunc f(x int) int {\n" + strings.Repeat("\tif x > 0 {\n\t\tx--\n\t}\n", 12) + "\treturn x\n}\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "complex.go"), []byte(complexFunc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "comments.go"), []byte("package main\n\n"+strings.Repeat("// comment line\n", 250)+"func g() {}\n"), 0o644); err != nil {
 		t.Fatal(err)
 	}
 
@@ -406,20 +411,21 @@ func TestBasicGoComplexity_ComplexFile(t *testing.T) {
 		projectType: Go,
 	}
 
-	result, err := checker.basicGoComplexity(&ComplexityResult{Threshold: 10})
+	result, err := checker.checkGoComplexity(&ComplexityResult{Threshold: 10})
 	if err != nil {
-		t.Fatalf("basicGoComplexity failed: %v", err)
+		t.Fatalf("checkGoComplexity failed: %v", err)
 	}
 
-	if len(result.ComplexFiles) == 0 {
-		t.Error("Expected complex file to be detected")
+	if len(result.ComplexFiles) != 1 || result.ComplexFiles[0].File != "complex.go" {
+		t.Fatalf("Expected complex.go to be detected, got %+v", result.ComplexFiles)
+	}
+	if fn := result.ComplexFiles[0].Functions[0]; fn.Name != "f" || fn.Cyclomatic != 13 || fn.Cognitive != 12 {
+		t.Errorf("Unexpected function breakdown: %+v", fn)
 	}
 
 	if result.Passed {
 		t.Error("Expected Passed=false for complex file")
 	}
-
-	t.Logf("ComplexFiles: %d, MaxCC: %d", len(result.ComplexFiles), result.MaxCC)
 }
 
 // TestBasicPythonComplexity_SkipFiles tests that generated and test files are skipped
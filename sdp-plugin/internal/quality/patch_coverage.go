@@ -0,0 +1,203 @@
+package quality
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// PatchCoverageResult is the coverage of lines added or changed since the
+// merge base with a base branch.
+type PatchCoverageResult struct {
+	Base      string
+	Reports   []string
+	Threshold float64
+	Covered   int
+	Total     int
+	Coverage  float64
+	Passed    bool
+	Files     []FilePatchCoverage
+}
+
+// FilePatchCoverage is the patch coverage of one file. Uncovered lists the
+// changed, executable lines no test executed.
+type FilePatchCoverage struct {
+	File      string
+	Covered   int
+	Total     int
+	Uncovered []int
+}
+
+// CheckPatchCoverage intersects existing coverage reports with the lines
+// changed since the merge base of HEAD and base. Only files matching scope
+// (exact paths or directory prefixes) are counted; an empty scope counts
+// every changed file. Reports must already exist, e.g. from CheckCoverage.
+func (c *Checker) CheckPatchCoverage(base string, scope []string) (*PatchCoverageResult, error) {
+	cov, reports, err := LoadCoverageReports(c.projectPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no coverage report found (coverage.out, coverage.xml or coverage.json)")
+	}
+
+	mergeBase, err := c.git("merge-base", "HEAD", base)
+	if err != nil {
+		return nil, fmt.Errorf("merge-base HEAD %s: %w", base, err)
+	}
+	rev := strings.TrimSpace(string(mergeBase))
+	added, err := c.addedLines(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PatchCoverageResult{
+		Base:      rev,
+		Reports:   reports,
+		Threshold: patchCoverageThreshold(),
+		Files:     ComputePatchCoverage(cov, added, scope),
+	}
+	for _, f := range result.Files {
+		result.Covered += f.Covered
+		result.Total += f.Total
+	}
+	result.Coverage = 100
+	if result.Total > 0 {
+		result.Coverage = float64(result.Covered) / float64(result.Total) * 100
+	}
+	result.Passed = result.Coverage >= result.Threshold
+	return result, nil
+}
+
+// ComputePatchCoverage counts, per file in scope, how many added lines the
+// coverage data marks as executable and how many of those were executed.
+// Files without executable added lines are omitted.
+func ComputePatchCoverage(cov LineCoverage, added map[string][]int, scope []string) []FilePatchCoverage {
+	var files []FilePatchCoverage
+	for _, file := range sortedKeys(added) {
+		if !inScope(file, scope) {
+			continue
+		}
+		lines, ok := cov[file]
+		if !ok {
+			continue
+		}
+		fc := FilePatchCoverage{File: file}
+		for _, l := range added[file] {
+			hit, executable := lines[l]
+			if !executable {
+				continue
+			}
+			fc.Total++
+			if hit {
+				fc.Covered++
+			} else {
+				fc.Uncovered = append(fc.Uncovered, l)
+			}
+		}
+		if fc.Total > 0 {
+			files = append(files, fc)
+		}
+	}
+	return files
+}
+
+func inScope(file string, scope []string) bool {
+	if len(scope) == 0 {
+		return true
+	}
+	for _, s := range scope {
+		s = filepath.ToSlash(filepath.Clean(s))
+		if file == s || strings.HasPrefix(file, strings.TrimSuffix(s, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// addedLines returns project-relative paths mapped to the line numbers added
+// or modified since rev, including uncommitted and untracked files.
+func (c *Checker) addedLines(rev string) (map[string][]int, error) {
+	diff, err := c.git("diff", "-U0", "--no-color", "--relative", rev)
+	if err != nil {
+		return nil, fmt.Errorf("diff %s: %w", rev, err)
+	}
+	added := parseAddedLines(diff)
+
+	untracked, err := c.git("ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("ls-files: %w", err)
+	}
+	for path := range strings.SplitSeq(strings.TrimSpace(string(untracked)), "\n") {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(c.projectPath, path))
+		if err != nil {
+			continue
+		}
+		n := bytes.Count(data, []byte("\n"))
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			n++
+		}
+		for l := 1; l <= n; l++ {
+			added[path] = append(added[path], l)
+		}
+	}
+	return added, nil
+}
+
+// parseAddedLines reads a zero-context unified diff.
+func parseAddedLines(diff []byte) map[string][]int {
+	added := make(map[string][]int)
+	var file string
+	scanner := bufio.NewScanner(bytes.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "+++ "); ok {
+			file = ""
+			if name != "/dev/null" {
+				file = strings.TrimPrefix(name, "b/")
+			}
+			continue
+		}
+		m := hunkHeaderRe.FindStringSubmatch(line)
+		if m == nil || file == "" {
+			continue
+		}
+		start, _ := strconv.Atoi(m[1])
+		count := 1
+		if m[2] != "" {
+			count, _ = strconv.Atoi(m[2])
+		}
+		for l := start; l < start+count; l++ {
+			added[file] = append(added[file], l)
+		}
+	}
+	for _, lines := range added {
+		sort.Ints(lines)
+	}
+	return added
+}
+
+// patchCoverageThreshold returns quality.patch_coverage_threshold, falling
+// back to the whole-project coverage threshold.
+func patchCoverageThreshold() float64 {
+	if projectRoot, err := config.FindProjectRoot(); err == nil {
+		if cfg, err := config.Load(projectRoot); err == nil && cfg.Quality.PatchCoverageThreshold > 0 {
+			return float64(cfg.Quality.PatchCoverageThreshold)
+		}
+	}
+	return coverageThreshold()
+}
@@ -0,0 +1,207 @@
+package quality
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/gomod"
+)
+
+// LineCoverage maps a project-relative file path to its instrumented lines
+// and whether each was executed. Lines absent from the map are not
+// executable (comments, declarations) and do not count towards coverage.
+//
+// This file is the source of truth for coverage report parsing. The root
+// module cannot import it, so internal/evidenceenv/coverage_parse.go is a
+// copy that differs only in its package clause; the evidenceenv tests fail
+// when the two drift apart.
+type LineCoverage map[string]map[int]bool
+
+func (lc LineCoverage) mark(file string, line int, hit bool) {
+	lines, ok := lc[file]
+	if !ok {
+		lines = make(map[int]bool)
+		lc[file] = lines
+	}
+	lines[line] = lines[line] || hit
+}
+
+func (lc LineCoverage) merge(other LineCoverage) {
+	for file, lines := range other {
+		for line, hit := range lines {
+			lc.mark(file, line, hit)
+		}
+	}
+}
+
+// coverageReports lists the report files LoadCoverageReports looks for,
+// relative to the project root.
+var coverageReports = []string{
+	"coverage.out",
+	"cover.out",
+	"coverage.xml",
+	"target/site/cobertura/coverage.xml",
+	"coverage.json",
+}
+
+// LoadCoverageReports reads every known coverage report under projectPath
+// and merges them. It returns the report paths that were used.
+func LoadCoverageReports(projectPath string) (LineCoverage, []string, error) {
+	merged := make(LineCoverage)
+	var used []string
+	for _, r := range coverageReports {
+		path := filepath.Join(projectPath, r)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		lc, err := ParseCoverageReport(path, projectPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", r, err)
+		}
+		merged.merge(lc)
+		used = append(used, r)
+	}
+	return merged, used, nil
+}
+
+// ParseCoverageReport reads the report at path, picking the format from
+// its extension: Cobertura for .xml, coverage.py for .json and a Go cover
+// profile otherwise. File names are made relative to projectPath.
+func ParseCoverageReport(path, projectPath string) (LineCoverage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xml":
+		return ParseCobertura(data, projectPath)
+	case ".json":
+		return ParseCoveragePyJSON(data, projectPath)
+	default:
+		return ParseGoCoverProfile(data, gomod.ModulePath(projectPath))
+	}
+}
+
+// ParseGoCoverProfile parses a `go test -coverprofile` file. Import-path
+// file names are made relative by stripping modulePath.
+func ParseGoCoverProfile(data []byte, modulePath string) (LineCoverage, error) {
+	lc := make(LineCoverage)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		// name.go:startLine.startCol,endLine.endCol numStmt count
+		file, rest, ok := strings.Cut(line, ":")
+		fields := strings.Fields(rest)
+		if !ok || len(fields) != 3 {
+			return nil, fmt.Errorf("malformed profile line %q", line)
+		}
+		start, end, ok := strings.Cut(fields[0], ",")
+		if !ok {
+			return nil, fmt.Errorf("malformed block %q", fields[0])
+		}
+		startLine, err1 := strconv.Atoi(strings.Split(start, ".")[0])
+		endLine, err2 := strconv.Atoi(strings.Split(end, ".")[0])
+		count, err3 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("malformed profile line %q", line)
+		}
+		if modulePath != "" {
+			file = strings.TrimPrefix(file, modulePath+"/")
+		}
+		for l := startLine; l <= endLine; l++ {
+			lc.mark(file, l, count > 0)
+		}
+	}
+	return lc, scanner.Err()
+}
+
+type coberturaReport struct {
+	Sources []string `xml:"sources>source"`
+	Classes []struct {
+		Filename string `xml:"filename,attr"`
+		Lines    []struct {
+			Number int `xml:"number,attr"`
+			Hits   int `xml:"hits,attr"`
+		} `xml:"lines>line"`
+	} `xml:"packages>package>classes>class"`
+}
+
+// ParseCobertura parses a Cobertura XML report (coverage.py, JaCoCo
+// converters, gocover-cobertura). Class file names are resolved against the
+// report's <source> roots and made relative to projectPath.
+func ParseCobertura(data []byte, projectPath string) (LineCoverage, error) {
+	var report coberturaReport
+	if err := xml.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	lc := make(LineCoverage)
+	for _, class := range report.Classes {
+		file := resolveCoberturaFile(projectPath, report.Sources, class.Filename)
+		for _, l := range class.Lines {
+			lc.mark(file, l.Number, l.Hits > 0)
+		}
+	}
+	return lc, nil
+}
+
+func resolveCoberturaFile(projectPath string, sources []string, filename string) string {
+	for _, src := range sources {
+		candidate := filepath.Join(strings.TrimSpace(src), filename)
+		if !filepath.IsAbs(candidate) {
+			candidate = filepath.Join(projectPath, candidate)
+		}
+		if _, err := os.Stat(candidate); err == nil {
+			return relToProject(projectPath, candidate)
+		}
+	}
+	return relToProject(projectPath, filename)
+}
+
+type coveragePyReport struct {
+	Files map[string]struct {
+		ExecutedLines []int `json:"executed_lines"`
+		MissingLines  []int `json:"missing_lines"`
+	} `json:"files"`
+}
+
+// ParseCoveragePyJSON parses the output of `coverage json`.
+func ParseCoveragePyJSON(data []byte, projectPath string) (LineCoverage, error) {
+	var report coveragePyReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	lc := make(LineCoverage)
+	for name, f := range report.Files {
+		file := relToProject(projectPath, name)
+		for _, l := range f.ExecutedLines {
+			lc.mark(file, l, true)
+		}
+		for _, l := range f.MissingLines {
+			lc.mark(file, l, false)
+		}
+	}
+	return lc, nil
+}
+
+// relToProject returns path relative to projectPath with forward slashes.
+// Relative paths are assumed to be relative to the project already.
+func relToProject(projectPath, path string) string {
+	if filepath.IsAbs(path) {
+		if abs, err := filepath.Abs(projectPath); err == nil {
+			if rel, err := filepath.Rel(abs, path); err == nil {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,101 @@
+package quality
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoCoverProfile(t *testing.T) {
+	profile := `mode: set
+example.com/app/pkg/calc.go:3.24,5.2 1 1
+example.com/app/pkg/calc.go:7.24,8.10 1 0
+example.com/app/pkg/calc.go:8.10,10.3 2 1
+`
+	lc, err := ParseGoCoverProfile([]byte(profile), "example.com/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := lc["pkg/calc.go"]
+	if lines == nil {
+		t.Fatalf("expected module prefix stripped, got %v", lc)
+	}
+	for line, want := range map[int]bool{3: true, 4: true, 5: true, 7: false, 8: true, 10: true} {
+		if got, ok := lines[line]; !ok || got != want {
+			t.Errorf("line %d = %v (present %v), want %v", line, got, ok, want)
+		}
+	}
+	if _, ok := lines[6]; ok {
+		t.Error("line 6 is not instrumented")
+	}
+
+	if _, err := ParseGoCoverProfile([]byte("mode: set\nbroken line\n"), ""); err == nil {
+		t.Error("expected error for malformed profile")
+	}
+}
+
+func TestParseCobertura(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src", "app"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "app", "calc.py"), []byte("x = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report := `<?xml version="1.0" ?>
+<coverage version="7.4">
+  <sources><source>` + filepath.Join(dir, "src") + `</source></sources>
+  <packages><package name="app"><classes>
+    <class name="calc.py" filename="app/calc.py">
+      <lines><line number="1" hits="1"/><line number="2" hits="0"/></lines>
+    </class>
+  </classes></package></packages>
+</coverage>`
+	lc, err := ParseCobertura([]byte(report), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := lc["src/app/calc.py"]
+	if !lines[1] || lines[2] || len(lines) != 2 {
+		t.Errorf("unexpected cobertura coverage: %v", lc)
+	}
+}
+
+func TestParseCoveragePyJSON(t *testing.T) {
+	dir := t.TempDir()
+	report := `{"meta": {}, "files": {
+		"app/calc.py": {"executed_lines": [1, 2], "missing_lines": [4]},
+		"` + filepath.Join(dir, "app", "util.py") + `": {"executed_lines": [], "missing_lines": [1]}
+	}}`
+	lc, err := ParseCoveragePyJSON([]byte(report), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calc := lc["app/calc.py"]; !calc[1] || !calc[2] || calc[4] || len(calc) != 3 {
+		t.Errorf("unexpected calc coverage: %v", calc)
+	}
+	if util, ok := lc["app/util.py"]; !ok || util[1] {
+		t.Errorf("expected absolute path made relative, got %v", lc)
+	}
+}
+
+func TestLoadCoverageReports(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":        "module example.com/app\n\ngo 1.22\n",
+		"coverage.out":  "mode: set\nexample.com/app/main.go:3.13,5.2 1 1\n",
+		"coverage.json": `{"files": {"tool.py": {"executed_lines": [1], "missing_lines": []}}}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lc, used, err := LoadCoverageReports(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(used) != 2 || !lc["main.go"][4] || !lc["tool.py"][1] {
+		t.Errorf("expected merged Go and Python reports, got %v from %v", lc, used)
+	}
+}
@@ -0,0 +1,128 @@
+package quality
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseAddedLines(t *testing.T) {
+	diff := `diff --git a/pkg/calc.go b/pkg/calc.go
+--- a/pkg/calc.go
++++ b/pkg/calc.go
+@@ -3,0 +4,2 @@ func Add(a, b int) int {
++	if a < 0 {
++		return 0
+@@ -10 +12 @@ func Sub(a, b int) int {
+-	return a - b
++	return b - a
+@@ -20,2 +21,0 @@ func Gone() {
+diff --git a/old.go b/old.go
+--- a/old.go
++++ /dev/null
+@@ -1,3 +0,0 @@
+`
+	got := parseAddedLines([]byte(diff))
+	want := map[string][]int{"pkg/calc.go": {4, 5, 12}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseAddedLines = %v, want %v", got, want)
+	}
+}
+
+func TestComputePatchCoverage(t *testing.T) {
+	cov := LineCoverage{
+		"pkg/calc.go":  {4: true, 5: false, 12: true},
+		"cmd/main.go":  {1: false},
+		"pkg/other.go": {1: true},
+	}
+	added := map[string][]int{
+		"pkg/calc.go": {3, 4, 5, 12}, // line 3 is not executable
+		"cmd/main.go": {1},
+		"README.md":   {1, 2},
+	}
+
+	files := ComputePatchCoverage(cov, added, nil)
+	if len(files) != 2 {
+		t.Fatalf("expected two files with executable changes, got %+v", files)
+	}
+	calc := files[1]
+	if calc.File != "pkg/calc.go" || calc.Covered != 2 || calc.Total != 3 || !reflect.DeepEqual(calc.Uncovered, []int{5}) {
+		t.Errorf("unexpected calc coverage: %+v", calc)
+	}
+
+	scoped := ComputePatchCoverage(cov, added, []string{"pkg/"})
+	if len(scoped) != 1 || scoped[0].File != "pkg/calc.go" {
+		t.Errorf("expected scope to keep only pkg/, got %+v", scoped)
+	}
+}
+
+func TestCheckPatchCoverage(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("go.mod", "module example.com/app\n\ngo 1.22\n")
+	write(".gitignore", "coverage.out\n")
+	write("calc.go", "package app\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n")
+	run("init", "-q", "-b", "main")
+	run("add", ".")
+	run("commit", "-qm", "base")
+	run("checkout", "-q", "-b", "feature")
+
+	// Legacy Add stays uncovered; new Sub is half covered.
+	write("calc.go", "package app\n\nfunc Add(a, b int) int {\n\treturn a + b\n}\n\nfunc Sub(a, b int) int {\n\tif a < b {\n\t\treturn 0\n\t}\n\treturn a - b\n}\n")
+	write("coverage.out", `mode: set
+example.com/app/calc.go:3.24,5.2 1 0
+example.com/app/calc.go:7.24,8.10 1 1
+example.com/app/calc.go:8.10,10.3 1 0
+example.com/app/calc.go:11.2,11.14 1 1
+`)
+
+	checker := &Checker{projectPath: dir, projectType: Go}
+	result, err := checker.CheckPatchCoverage("main", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 5 || result.Covered != 3 {
+		t.Fatalf("expected 3/5 changed lines covered, got %d/%d (%+v)", result.Covered, result.Total, result.Files)
+	}
+	if !reflect.DeepEqual(result.Files[0].Uncovered, []int{9, 10}) {
+		t.Errorf("unexpected uncovered lines: %v", result.Files[0].Uncovered)
+	}
+	if result.Passed != (result.Coverage >= result.Threshold) {
+		t.Errorf("Passed inconsistent with coverage %.1f / threshold %.1f", result.Coverage, result.Threshold)
+	}
+
+	outOfScope, err := checker.CheckPatchCoverage("main", []string{"other/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outOfScope.Total != 0 || !outOfScope.Passed {
+		t.Errorf("expected empty scope result to pass, got %+v", outOfScope)
+	}
+}
+
+func TestCheckPatchCoverage_NoReports(t *testing.T) {
+	checker := &Checker{projectPath: t.TempDir(), projectType: Go}
+	if _, err := checker.CheckPatchCoverage("main", nil); err == nil {
+		t.Error("expected error without coverage reports")
+	}
+}
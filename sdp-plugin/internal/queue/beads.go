@@ -0,0 +1,179 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/beads"
+)
+
+// BeadsQueue adapts the Beads client to IssueQueue. It needs bd on PATH;
+// status history is kept by Beads and not returned.
+type BeadsQueue struct {
+	client *beads.Client
+}
+
+// NewBeadsQueue returns a Beads queue that runs bd in root
+func NewBeadsQueue(root string) *BeadsQueue {
+	return &BeadsQueue{client: beads.NewClientWithDir(root)}
+}
+
+// bdIssue is an issue as printed by bd --json
+type bdIssue struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Status       string    `json:"status"`
+	Priority     int       `json:"priority"`
+	IssueType    string    `json:"issue_type"`
+	Assignee     string    `json:"assignee"`
+	Labels       []string  `json:"labels"`
+	Notes        string    `json:"notes"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Dependencies []struct {
+		// bd list prints dependency records, bd show the depended-on issues
+		DependsOnID    string `json:"depends_on_id"`
+		ID             string `json:"id"`
+		Type           string `json:"type"`
+		DependencyType string `json:"dependency_type"`
+	} `json:"dependencies"`
+}
+
+// Name implements IssueQueue
+func (q *BeadsQueue) Name() string { return "beads" }
+
+// List implements IssueQueue
+func (q *BeadsQueue) List() ([]Issue, error) {
+	return q.issues("list", "--json")
+}
+
+// Ready implements IssueQueue
+func (q *BeadsQueue) Ready() ([]Issue, error) {
+	return q.issues("ready", "--json")
+}
+
+// Show implements IssueQueue
+func (q *BeadsQueue) Show(id string) (*Issue, error) {
+	issues, err := q.issues("show", id, "--json")
+	if err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return &issues[0], nil
+}
+
+// Create implements IssueQueue. Beads assigns the ID.
+func (q *BeadsQueue) Create(issue Issue) (*Issue, error) {
+	if issue.Title == "" {
+		return nil, fmt.Errorf("issue title is required")
+	}
+	args := []string{"create", "--title", issue.Title, "--priority", strconv.Itoa(issue.Priority), "--json"}
+	optional := [][2]string{
+		{"--description", issue.Description},
+		{"--type", issue.Type},
+		{"--assignee", issue.Assignee},
+		{"--labels", strings.Join(issue.Labels, ",")},
+		{"--deps", strings.Join(issue.DependsOn, ",")},
+	}
+	for _, opt := range optional {
+		if opt[1] != "" {
+			args = append(args, opt[0], opt[1])
+		}
+	}
+	created, err := q.issues(args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(created) == 0 {
+		return nil, fmt.Errorf("bd create printed no issue")
+	}
+	if issue.WSID != "" {
+		if err := q.client.UpdateMapping(issue.WSID, created[0].ID); err != nil {
+			return nil, err
+		}
+		created[0].WSID = issue.WSID
+	}
+	if issue.Status != "" && issue.Status != StatusOpen {
+		return q.Update(created[0].ID, Update{Status: issue.Status})
+	}
+	return &created[0], nil
+}
+
+// Update implements IssueQueue
+func (q *BeadsQueue) Update(id string, u Update) (*Issue, error) {
+	args := []string{"update", id, "--json"}
+	if u.Status != "" {
+		args = append(args, "--status", u.Status)
+	}
+	if u.Assignee != nil {
+		args = append(args, "--assignee", *u.Assignee)
+	}
+	if u.Priority != nil {
+		args = append(args, "--priority", strconv.Itoa(*u.Priority))
+	}
+	if u.Notes != nil {
+		args = append(args, "--notes", *u.Notes)
+	}
+	if u.Actor != "" {
+		args = append(args, "--actor", u.Actor)
+	}
+	if _, err := q.client.Run(args...); err != nil {
+		return nil, err
+	}
+	return q.Show(id)
+}
+
+// AddDependency implements IssueQueue
+func (q *BeadsQueue) AddDependency(id, dependsOn string) error {
+	_, err := q.client.Run("dep", "add", id, dependsOn)
+	return err
+}
+
+// issues runs bd and decodes its JSON output, an issue or a list of them
+func (q *BeadsQueue) issues(args ...string) ([]Issue, error) {
+	out, err := q.client.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	out = bytes.TrimSpace(out)
+	var raw []bdIssue
+	if len(out) > 0 && out[0] == '{' {
+		raw = make([]bdIssue, 1)
+		err = json.Unmarshal(out, &raw[0])
+	} else if len(out) > 0 {
+		err = json.Unmarshal(out, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse bd %s output: %w", args[0], err)
+	}
+	links, err := q.client.Links()
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(raw))
+	for _, r := range raw {
+		is := Issue{
+			ID: r.ID, Title: r.Title, Description: r.Description, Status: r.Status,
+			Priority: r.Priority, Type: r.IssueType, Assignee: r.Assignee, Labels: r.Labels,
+			Notes: r.Notes, WSID: links[r.ID], CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+		}
+		for _, d := range r.Dependencies {
+			kind, dep := d.Type+d.DependencyType, d.DependsOnID
+			if dep == "" {
+				dep = d.ID
+			}
+			if dep != "" && dep != r.ID && (kind == "" || kind == "blocks") {
+				is.DependsOn = append(is.DependsOn, dep)
+			}
+		}
+		issues = append(issues, is)
+	}
+	return issues, nil
+}
@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/beads"
+)
+
+// fakeBD puts a bd script on PATH that logs its arguments to bd.log in
+// root and answers list/ready/show/create/update with canned JSON.
+func fakeBD(t *testing.T, root string) {
+	t.Helper()
+	bin := t.TempDir()
+	script := `#!/bin/sh
+echo "$@" >> "` + filepath.Join(root, "bd.log") + `"
+case "$1" in
+list|ready) cat <<'EOF'
+[{"id":"sdp-a1","title":"Parent","status":"closed","priority":1,"issue_type":"task","created_at":"2026-01-02T03:04:05Z"},
+ {"id":"sdp-b2","title":"Child","status":"open","priority":2,"assignee":"bob","labels":["api"],
+  "dependencies":[{"issue_id":"sdp-b2","depends_on_id":"sdp-a1","type":"blocks"},{"issue_id":"sdp-b2","depends_on_id":"sdp-x9","type":"related"}]}]
+EOF
+;;
+show) case "$2" in
+  sdp-a1|sdp-b2|sdp-c3|sdp-new) echo '[{"id":"'"$2"'","title":"Shown","status":"in_progress","dependencies":[{"id":"sdp-a1","title":"Parent","dependency_type":"blocks"}]}]' ;;
+  *) echo "Error: issue $2 not found" >&2; exit 1 ;;
+  esac ;;
+create) echo '{"id":"sdp-new","title":"created","status":"open","priority":2}' ;;
+update|dep) ;;
+*) echo "unknown command $1" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "bd"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func bdLog(t *testing.T, root string) string {
+	t.Helper()
+	data, _ := os.ReadFile(filepath.Join(root, "bd.log"))
+	return string(data)
+}
+
+func TestBeadsQueue_ReadAndShow(t *testing.T) {
+	root := t.TempDir()
+	fakeBD(t, root)
+	if err := os.WriteFile(filepath.Join(root, beads.MappingFile), []byte(`{"sdp_id":"00-049-02","beads_id":"sdp-b2"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	q := NewBeadsQueue(root)
+
+	all, err := q.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("List = %+v", all)
+	}
+	child := all[1]
+	if child.Assignee != "bob" || child.WSID != "00-049-02" || strings.Join(child.DependsOn, ",") != "sdp-a1" {
+		t.Errorf("child = %+v (only blocking dependencies count)", child)
+	}
+	if all[0].Type != "task" || all[0].CreatedAt.IsZero() {
+		t.Errorf("parent = %+v", all[0])
+	}
+
+	is, err := q.Show("sdp-c3")
+	if err != nil || is.ID != "sdp-c3" || is.Status != StatusInProgress || strings.Join(is.DependsOn, ",") != "sdp-a1" {
+		t.Errorf("Show = %+v, %v", is, err)
+	}
+	if _, err := q.issues("bogus"); err == nil || !strings.Contains(err.Error(), "unknown command bogus") {
+		t.Errorf("expected bd stderr in error, got %v", err)
+	}
+}
+
+func TestBeadsQueue_Write(t *testing.T) {
+	root := t.TempDir()
+	fakeBD(t, root)
+	q := NewBeadsQueue(root)
+
+	created, err := q.Create(Issue{Title: "New work", Priority: 1, Labels: []string{"a", "b"}, DependsOn: []string{"sdp-a1"}, WSID: "00-049-03"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID != "sdp-new" || created.WSID != "00-049-03" {
+		t.Errorf("created = %+v", created)
+	}
+	if links, err := q.client.Links(); err != nil || links["sdp-new"] != "00-049-03" {
+		t.Errorf("mapping = %v, %v", links, err)
+	}
+	alice := "alice"
+	if _, err := q.Update("sdp-new", Update{Status: StatusInProgress, Assignee: &alice, Actor: "alice"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := q.AddDependency("sdp-new", "sdp-a1"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+
+	log := bdLog(t, root)
+	for _, want := range []string{
+		"create --title New work --priority 1 --json --labels a,b --deps sdp-a1",
+		"update sdp-new --json --status in_progress --assignee alice --actor alice",
+		"dep add sdp-new sdp-a1",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("bd log missing %q:\n%s", want, log)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package queue
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// FileQueue stores issues in a JSONL file, one issue per line sorted by
+// ID, so concurrent branches touch separate lines and merge cleanly.
+// Writes hold an exclusive lock on the directory and replace the file
+// atomically, so several sessions can share one queue.
+type FileQueue struct {
+	path string
+	now  func() time.Time
+}
+
+// NewFileQueue returns a queue stored at path; the file is created on the
+// first write.
+func NewFileQueue(path string) *FileQueue {
+	return &FileQueue{path: path, now: func() time.Time { return time.Now().UTC() }}
+}
+
+// Name implements IssueQueue
+func (q *FileQueue) Name() string { return "file" }
+
+// Path returns the store location
+func (q *FileQueue) Path() string { return q.path }
+
+// List implements IssueQueue
+func (q *FileQueue) List() ([]Issue, error) {
+	return q.load()
+}
+
+// Ready implements IssueQueue
+func (q *FileQueue) Ready() ([]Issue, error) {
+	all, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	return ReadyFrom(all), nil
+}
+
+// Show implements IssueQueue
+func (q *FileQueue) Show(id string) (*Issue, error) {
+	all, err := q.load()
+	if err != nil {
+		return nil, err
+	}
+	if i := indexOf(all, id); i >= 0 {
+		return &all[i], nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Create implements IssueQueue. A given ID is kept, so migrated issues
+// keep their IDs; otherwise one is generated.
+func (q *FileQueue) Create(issue Issue) (*Issue, error) {
+	if issue.Title == "" {
+		return nil, fmt.Errorf("issue title is required")
+	}
+	if issue.Status == "" {
+		issue.Status = StatusOpen
+	}
+	if !ValidStatus(issue.Status) {
+		return nil, fmt.Errorf("invalid status %q", issue.Status)
+	}
+	err := q.modify(func(all []Issue) ([]Issue, error) {
+		if issue.ID == "" {
+			for issue.ID == "" || indexOf(all, issue.ID) >= 0 {
+				issue.ID = newID()
+			}
+		} else if indexOf(all, issue.ID) >= 0 {
+			return nil, fmt.Errorf("issue %s already exists", issue.ID)
+		}
+		for _, dep := range issue.DependsOn {
+			if indexOf(all, dep) < 0 {
+				return nil, fmt.Errorf("%w: dependency %s", ErrNotFound, dep)
+			}
+		}
+		now := q.now()
+		if issue.CreatedAt.IsZero() {
+			issue.CreatedAt = now
+		}
+		if issue.UpdatedAt.IsZero() {
+			issue.UpdatedAt = now
+		}
+		if len(issue.History) == 0 {
+			issue.History = []StatusChange{{To: issue.Status, At: issue.CreatedAt}}
+		}
+		return append(all, issue), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// Update implements IssueQueue, recording status changes in the history
+func (q *FileQueue) Update(id string, u Update) (*Issue, error) {
+	if u.Status != "" && !ValidStatus(u.Status) {
+		return nil, fmt.Errorf("invalid status %q", u.Status)
+	}
+	var updated Issue
+	err := q.modify(func(all []Issue) ([]Issue, error) {
+		i := indexOf(all, id)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		is := &all[i]
+		now := q.now()
+		if u.Status != "" && u.Status != is.Status {
+			is.History = append(is.History, StatusChange{From: is.Status, To: u.Status, By: u.Actor, At: now})
+			is.Status = u.Status
+		}
+		if u.Assignee != nil {
+			is.Assignee = *u.Assignee
+		}
+		if u.Priority != nil {
+			is.Priority = *u.Priority
+		}
+		if u.Notes != nil {
+			is.Notes = *u.Notes
+		}
+		is.UpdatedAt = now
+		updated = *is
+		return all, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// AddDependency implements IssueQueue, rejecting unknown IDs and cycles
+func (q *FileQueue) AddDependency(id, dependsOn string) error {
+	return q.modify(func(all []Issue) ([]Issue, error) {
+		i, j := indexOf(all, id), indexOf(all, dependsOn)
+		switch {
+		case i < 0:
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		case j < 0:
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, dependsOn)
+		case reaches(all, dependsOn, id):
+			return nil, fmt.Errorf("dependency %s -> %s would create a cycle", id, dependsOn)
+		}
+		if !slices.Contains(all[i].DependsOn, dependsOn) {
+			all[i].DependsOn = append(all[i].DependsOn, dependsOn)
+			all[i].UpdatedAt = q.now()
+		}
+		return all, nil
+	})
+}
@@ -0,0 +1,136 @@
+package queue
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// idPrefix starts generated file backend IDs, as in Beads
+const idPrefix = "sdp-"
+
+// reaches reports whether to is from or one of its transitive dependencies
+func reaches(all []Issue, from, to string) bool {
+	seen := make(map[string]bool)
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == to {
+			return true
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if i := indexOf(all, id); i >= 0 {
+			stack = append(stack, all[i].DependsOn...)
+		}
+	}
+	return false
+}
+
+// load reads every issue; a missing file is an empty queue
+func (q *FileQueue) load() ([]Issue, error) {
+	data, err := os.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return []Issue{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+	all := []Issue{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var is Issue
+		if err := json.Unmarshal(line, &is); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", q.path, n, err)
+		}
+		all = append(all, is)
+	}
+	return all, scanner.Err()
+}
+
+// modify runs fn on the issues under the queue lock and saves the result
+func (q *FileQueue) modify(fn func([]Issue) ([]Issue, error)) error {
+	dir := filepath.Dir(q.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create queue directory: %w", err)
+	}
+	lock, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open queue directory: %w", err)
+	}
+	defer func() { _ = lock.Close() }()
+	if err := filelock.Lock(lock); err != nil {
+		return fmt.Errorf("failed to lock queue: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lock) }()
+
+	all, err := q.load()
+	if err != nil {
+		return err
+	}
+	if all, err = fn(all); err != nil {
+		return err
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+	var buf bytes.Buffer
+	for _, is := range all {
+		line, err := json.Marshal(is)
+		if err != nil {
+			return fmt.Errorf("failed to encode issue %s: %w", is.ID, err)
+		}
+		buf.Write(append(line, '\n'))
+	}
+	tmp, err := os.CreateTemp(dir, ".issues-*.jsonl")
+	if err != nil {
+		return fmt.Errorf("failed to write queue: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write queue: %w", err)
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write queue: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write queue: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), q.path); err != nil {
+		return fmt.Errorf("failed to replace queue: %w", err)
+	}
+	return nil
+}
+
+// indexOf returns the position of id in all, or -1
+func indexOf(all []Issue, id string) int {
+	for i := range all {
+		if all[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// newID returns a random short ID such as "sdp-3fa9c1"
+func newID() string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return idPrefix + hex.EncodeToString(b)
+}
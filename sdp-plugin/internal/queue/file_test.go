@@ -0,0 +1,185 @@
+package queue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestQueue(t *testing.T) *FileQueue {
+	t.Helper()
+	return NewFileQueue(filepath.Join(t.TempDir(), ".sdp", "queue", "issues.jsonl"))
+}
+
+func TestFileQueue_CreateShowList(t *testing.T) {
+	q := newTestQueue(t)
+	if all, err := q.List(); err != nil || len(all) != 0 {
+		t.Fatalf("empty queue: List() = %v, %v", all, err)
+	}
+	a, err := q.Create(Issue{Title: "Add retry", Priority: 1, WSID: "00-049-01", Labels: []string{"backend"}})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasPrefix(a.ID, idPrefix) || a.Status != StatusOpen {
+		t.Errorf("created = %+v", a)
+	}
+	if len(a.History) != 1 || a.History[0].To != StatusOpen {
+		t.Errorf("history = %+v", a.History)
+	}
+	kept, err := q.Create(Issue{ID: "sdp-abc", Title: "Migrated", Status: StatusInProgress})
+	if err != nil || kept.ID != "sdp-abc" {
+		t.Fatalf("Create with ID = %+v, %v", kept, err)
+	}
+	if _, err := q.Create(Issue{ID: "sdp-abc", Title: "Again"}); err == nil {
+		t.Error("expected duplicate ID error")
+	}
+	if _, err := q.Create(Issue{Title: ""}); err == nil {
+		t.Error("expected missing title error")
+	}
+	if _, err := q.Create(Issue{Title: "x", DependsOn: []string{"sdp-nope"}}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown dependency: err = %v", err)
+	}
+
+	got, err := q.Show(a.ID)
+	if err != nil || got.Title != "Add retry" || got.WSID != "00-049-01" {
+		t.Errorf("Show = %+v, %v", got, err)
+	}
+	if _, err := q.Show("sdp-missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Show missing: err = %v", err)
+	}
+	all, _ := q.List()
+	if len(all) != 2 || all[0].ID > all[1].ID {
+		t.Errorf("List not sorted by ID: %+v", all)
+	}
+
+	data, err := os.ReadFile(q.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("store has %d lines, want one per issue:\n%s", lines, data)
+	}
+}
+
+func TestFileQueue_UpdateRecordsHistory(t *testing.T) {
+	q := newTestQueue(t)
+	is, _ := q.Create(Issue{Title: "Work"})
+	alice, prio, notes := "alice", 0, "see PR"
+	got, err := q.Update(is.ID, Update{Status: StatusInProgress, Assignee: &alice, Priority: &prio, Notes: &notes, Actor: "alice"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got.Status != StatusInProgress || got.Assignee != "alice" || got.Priority != 0 || got.Notes != "see PR" {
+		t.Errorf("updated = %+v", got)
+	}
+	if _, err := q.Update(is.ID, Update{Assignee: new(string)}); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = q.Show(is.ID)
+	if got.Assignee != "" || got.Status != StatusInProgress {
+		t.Errorf("unassign changed other fields: %+v", got)
+	}
+	want := []StatusChange{{To: StatusOpen}, {From: StatusOpen, To: StatusInProgress, By: "alice"}}
+	if len(got.History) != len(want) {
+		t.Fatalf("history = %+v", got.History)
+	}
+	for i, h := range got.History {
+		if h.From != want[i].From || h.To != want[i].To || h.By != want[i].By || h.At.IsZero() {
+			t.Errorf("history[%d] = %+v, want %+v", i, h, want[i])
+		}
+	}
+	if _, err := q.Update(is.ID, Update{Status: "done"}); err == nil {
+		t.Error("expected invalid status error")
+	}
+	if _, err := q.Update("sdp-missing", Update{Status: StatusClosed}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update missing: err = %v", err)
+	}
+}
+
+func TestFileQueue_DependenciesAndReady(t *testing.T) {
+	q := newTestQueue(t)
+	a, _ := q.Create(Issue{Title: "A", Priority: 2})
+	b, _ := q.Create(Issue{Title: "B", Priority: 1})
+	c, _ := q.Create(Issue{Title: "C", Priority: 0})
+	if err := q.AddDependency(b.ID, a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.AddDependency(c.ID, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.AddDependency(c.ID, b.ID); err != nil {
+		t.Errorf("repeated dependency: %v", err)
+	}
+	if err := q.AddDependency(a.ID, c.ID); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+	if err := q.AddDependency(a.ID, a.ID); err == nil {
+		t.Error("expected self-dependency error")
+	}
+
+	readyIDs := func() []string {
+		ready, err := q.Ready()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, is := range ready {
+			ids = append(ids, is.Title)
+		}
+		return ids
+	}
+	if got := strings.Join(readyIDs(), ","); got != "A" {
+		t.Errorf("ready = %s, want A", got)
+	}
+	if _, err := q.Update(a.ID, Update{Status: StatusClosed}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(readyIDs(), ","); got != "B" {
+		t.Errorf("ready = %s, want B", got)
+	}
+	if _, err := q.Update(b.ID, Update{Status: StatusClosed}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Create(Issue{Title: "D", Priority: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(readyIDs(), ","); got != "C,D" {
+		t.Errorf("ready = %s, want C,D by priority", got)
+	}
+}
+
+func TestFileQueue_ConcurrentWriters(t *testing.T) {
+	q := newTestQueue(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			other := NewFileQueue(q.Path()) // a separate session
+			if _, err := other.Create(Issue{Title: "parallel"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	all, err := q.List()
+	if err != nil || len(all) != 20 {
+		t.Errorf("List() = %d issues, %v; want 20", len(all), err)
+	}
+}
+
+func TestFileQueue_CorruptLine(t *testing.T) {
+	q := newTestQueue(t)
+	if err := os.MkdirAll(filepath.Dir(q.Path()), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(q.Path(), []byte("{\"id\":\"sdp-1\",\"title\":\"ok\"}\nnot json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.List(); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("expected error naming line 2, got %v", err)
+	}
+}
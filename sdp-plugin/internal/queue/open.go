@@ -0,0 +1,115 @@
+package queue
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+	"github.com/fall-out-bug/sdp/internal/resolver"
+)
+
+// Backend names for queue.backend
+const (
+	BackendAuto  = "auto"
+	BackendFile  = "file"
+	BackendBeads = "beads"
+)
+
+// DefaultPath is the file backend store relative to the project root
+const DefaultPath = ".sdp/queue/issues.jsonl"
+
+// Open returns the queue configured for the project at root by
+// queue.backend and queue.path in .sdp/config.yml.
+func Open(root string) (IssueQueue, error) {
+	cfg, err := config.Load(root)
+	if err != nil {
+		return nil, err
+	}
+	return OpenBackend(root, cfg.Queue.Backend, cfg.Queue.Path)
+}
+
+// OpenBackend returns the named backend for the project at root. "auto"
+// picks Beads when bd is on PATH and .beads/ exists, else the file queue.
+func OpenBackend(root, backend, path string) (IssueQueue, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	switch backend {
+	case "", BackendAuto:
+		if resolver.NewBeadsDetectorWithDir(root).IsEnabled() {
+			return NewBeadsQueue(root), nil
+		}
+		return NewFileQueue(path), nil
+	case BackendFile:
+		return NewFileQueue(path), nil
+	case BackendBeads:
+		if _, err := exec.LookPath("bd"); err != nil {
+			return nil, fmt.Errorf("beads backend needs the bd CLI on PATH: %w", err)
+		}
+		return NewBeadsQueue(root), nil
+	}
+	return nil, fmt.Errorf("unknown queue backend %q (want auto, file or beads)", backend)
+}
+
+// Migrate copies every issue from one backend to another with status,
+// assignee and dependencies, and returns the ID each issue got in to.
+// Issues already in to, by ID or by linked workstream, are linked, not
+// copied again, and dependencies to already has are not added twice, so an
+// interrupted migration can be re-run.
+func Migrate(from, to IssueQueue) (map[string]string, error) {
+	all, err := from.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s issues: %w", from.Name(), err)
+	}
+	existing, err := to.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s issues: %w", to.Name(), err)
+	}
+	byID := make(map[string]Issue, len(existing))
+	byWS := make(map[string]string)
+	for _, is := range existing {
+		byID[is.ID] = is
+		if is.WSID != "" {
+			byWS[is.WSID] = is.ID
+		}
+	}
+
+	ids := make(map[string]string, len(all))
+	for _, is := range all {
+		if _, ok := byID[is.ID]; ok {
+			ids[is.ID] = is.ID
+			continue
+		}
+		if linked, ok := byWS[is.WSID]; ok && is.WSID != "" {
+			ids[is.ID] = linked
+			continue
+		}
+		copied := is
+		copied.DependsOn = nil
+		created, err := to.Create(copied)
+		if err != nil {
+			return ids, fmt.Errorf("failed to copy %s: %w", is.ID, err)
+		}
+		ids[is.ID] = created.ID
+	}
+	for _, is := range all {
+		for _, dep := range is.DependsOn {
+			target, ok := ids[dep]
+			if !ok {
+				continue // dependency outside the listed issues
+			}
+			if slices.Contains(byID[ids[is.ID]].DependsOn, target) {
+				continue
+			}
+			if err := to.AddDependency(ids[is.ID], target); err != nil {
+				return ids, fmt.Errorf("failed to copy dependency %s -> %s: %w", is.ID, dep, err)
+			}
+		}
+	}
+	return ids, nil
+}
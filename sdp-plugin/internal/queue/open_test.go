@@ -0,0 +1,159 @@
+package queue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/beads"
+)
+
+func TestOpenBackend(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("PATH", t.TempDir()) // no bd
+
+	q, err := OpenBackend(root, BackendAuto, "")
+	if err != nil || q.Name() != BackendFile {
+		t.Fatalf("auto without bd = %v, %v; want file", q, err)
+	}
+	if fq := q.(*FileQueue); fq.Path() != filepath.Join(root, DefaultPath) {
+		t.Errorf("path = %s", fq.Path())
+	}
+	if _, err := OpenBackend(root, BackendBeads, ""); err == nil {
+		t.Error("beads backend without bd should fail")
+	}
+	if _, err := OpenBackend(root, "jira", ""); err == nil {
+		t.Error("unknown backend should fail")
+	}
+
+	fakeBD(t, root)
+	if q, _ := OpenBackend(root, BackendAuto, ""); q.Name() != BackendFile {
+		t.Errorf("auto without .beads/ = %s, want file", q.Name())
+	}
+	if err := os.Mkdir(filepath.Join(root, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := OpenBackend(root, BackendAuto, ""); q.Name() != BackendBeads {
+		t.Errorf("auto with bd and .beads/ = %s, want beads", q.Name())
+	}
+	if q, _ := OpenBackend(root, BackendFile, "custom/q.jsonl"); q.(*FileQueue).Path() != filepath.Join(root, "custom/q.jsonl") {
+		t.Errorf("custom path ignored")
+	}
+}
+
+func TestOpen_ReadsConfig(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "version: 1\nqueue:\n  backend: file\n  path: work/queue.jsonl\n"
+	if err := os.WriteFile(filepath.Join(root, ".sdp", "config.yml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	q, err := Open(root)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if fq, ok := q.(*FileQueue); !ok || fq.Path() != filepath.Join(root, "work/queue.jsonl") {
+		t.Errorf("Open = %#v", q)
+	}
+}
+
+func TestMigrate_BeadsToFile(t *testing.T) {
+	root := t.TempDir()
+	fakeBD(t, root)
+	dst := newTestQueue(t)
+
+	ids, err := Migrate(NewBeadsQueue(root), dst)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(ids) != 2 || ids["sdp-a1"] != "sdp-a1" || ids["sdp-b2"] != "sdp-b2" {
+		t.Errorf("ids = %v, want IDs kept", ids)
+	}
+	child, err := dst.Show("sdp-b2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if child.Assignee != "bob" || strings.Join(child.DependsOn, ",") != "sdp-a1" || strings.Join(child.Labels, ",") != "api" {
+		t.Errorf("child = %+v", child)
+	}
+	ready, _ := dst.Ready()
+	if len(ready) != 1 || ready[0].ID != "sdp-b2" {
+		t.Errorf("ready after migration = %+v", ready)
+	}
+
+	// Re-running links existing issues instead of duplicating them
+	if _, err := Migrate(NewBeadsQueue(root), dst); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+	if all, _ := dst.List(); len(all) != 2 {
+		t.Errorf("second migration duplicated issues: %d", len(all))
+	}
+}
+
+func TestMigrate_FileToBeads(t *testing.T) {
+	root := t.TempDir()
+	fakeBD(t, root)
+	src := newTestQueue(t)
+	a, _ := src.Create(Issue{Title: "A", WSID: "00-049-04"})
+	b, _ := src.Create(Issue{Title: "B", DependsOn: []string{a.ID}})
+
+	ids, err := Migrate(src, NewBeadsQueue(root))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if ids[a.ID] != "sdp-new" || ids[b.ID] != "sdp-new" {
+		t.Errorf("ids = %v", ids)
+	}
+	if log := bdLog(t, root); strings.Count(log, "create ") != 2 || !strings.Contains(log, "dep add sdp-new sdp-new") {
+		t.Errorf("bd log:\n%s", log)
+	}
+}
+
+func TestMigrate_FileToBeadsSkipsLinkedWorkstreams(t *testing.T) {
+	root := t.TempDir()
+	fakeBD(t, root)
+	if err := os.WriteFile(filepath.Join(root, beads.MappingFile), []byte(`{"sdp_id":"00-049-04","beads_id":"sdp-b2"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src := newTestQueue(t)
+	a, _ := src.Create(Issue{Title: "A", WSID: "00-049-04"})
+	b, _ := src.Create(Issue{Title: "B", DependsOn: []string{a.ID}})
+
+	ids, err := Migrate(src, NewBeadsQueue(root))
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if ids[a.ID] != "sdp-b2" || ids[b.ID] != "sdp-new" {
+		t.Errorf("ids = %v", ids)
+	}
+	if log := bdLog(t, root); strings.Count(log, "create ") != 1 || !strings.Contains(log, "dep add sdp-new sdp-b2") {
+		t.Errorf("bd log:\n%s", log)
+	}
+}
+
+func TestFindByWSAndReadyFrom(t *testing.T) {
+	q := newTestQueue(t)
+	if _, err := q.Create(Issue{Title: "linked", WSID: "00-049-05"}); err != nil {
+		t.Fatal(err)
+	}
+	is, err := FindByWS(q, "00-049-05")
+	if err != nil || is.Title != "linked" {
+		t.Errorf("FindByWS = %+v, %v", is, err)
+	}
+	if _, err := FindByWS(q, "00-049-99"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByWS missing: %v", err)
+	}
+
+	ready := ReadyFrom([]Issue{
+		{ID: "a", Status: StatusOpen, DependsOn: []string{"gone"}},
+		{ID: "b", Status: StatusBlocked},
+		{ID: "c", Status: StatusOpen},
+	})
+	if len(ready) != 1 || ready[0].ID != "c" {
+		t.Errorf("ReadyFrom = %+v; unknown dependencies block", ready)
+	}
+}
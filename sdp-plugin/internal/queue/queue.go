@@ -0,0 +1,135 @@
+// Package queue is the issue queue behind Operator Mode: ready work,
+// assignees, dependencies and status history. IssueQueue has a built-in
+// file backend and a Beads (bd CLI) backend.
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Issue statuses, shared with Beads
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusClosed     = "closed"
+)
+
+// DefaultPriority is the --priority default of the queue commands (0 is
+// highest). IssueQueue.Create stores Issue.Priority as given, since 0 is a
+// valid priority.
+const DefaultPriority = 2
+
+// ErrNotFound is returned for an unknown issue ID
+var ErrNotFound = errors.New("issue not found")
+
+// StatusChange is one entry of an issue's status history
+type StatusChange struct {
+	From string    `json:"from,omitempty"`
+	To   string    `json:"to"`
+	By   string    `json:"by,omitempty"`
+	At   time.Time `json:"at"`
+}
+
+// Issue is a unit of queued work
+type Issue struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Status      string         `json:"status"`
+	Priority    int            `json:"priority"`
+	Type        string         `json:"type,omitempty"`
+	Assignee    string         `json:"assignee,omitempty"`
+	Labels      []string       `json:"labels,omitempty"`
+	Notes       string         `json:"notes,omitempty"`
+	WSID        string         `json:"ws_id,omitempty"`
+	DependsOn   []string       `json:"depends_on,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	History     []StatusChange `json:"history,omitempty"`
+}
+
+// Update changes fields of an issue; zero values keep the current value
+type Update struct {
+	Status   string
+	Assignee *string // "" unassigns
+	Priority *int
+	Notes    *string
+	Actor    string // recorded in status history
+}
+
+// IssueQueue is a backend for queue-backed work
+type IssueQueue interface {
+	// Name identifies the backend ("file" or "beads")
+	Name() string
+	// List returns every issue, ordered by ID
+	List() ([]Issue, error)
+	// Ready returns open issues whose dependencies are all closed, by priority
+	Ready() ([]Issue, error)
+	// Show returns one issue, or ErrNotFound
+	Show(id string) (*Issue, error)
+	// Create adds an issue and returns it with its assigned ID
+	Create(issue Issue) (*Issue, error)
+	// Update applies u to an issue and returns the result
+	Update(id string, u Update) (*Issue, error)
+	// AddDependency records that id cannot start before dependsOn is closed
+	AddDependency(id, dependsOn string) error
+}
+
+// ValidStatus reports whether s is a known status
+func ValidStatus(s string) bool {
+	switch s {
+	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed:
+		return true
+	}
+	return false
+}
+
+// ReadyFrom selects the ready issues of all: open, with every dependency
+// known and closed, ordered by priority and then age.
+func ReadyFrom(all []Issue) []Issue {
+	status := make(map[string]string, len(all))
+	for _, is := range all {
+		status[is.ID] = is.Status
+	}
+	ready := []Issue{}
+	for _, is := range all {
+		if is.Status != StatusOpen {
+			continue
+		}
+		blocked := false
+		for _, dep := range is.DependsOn {
+			if status[dep] != StatusClosed {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			ready = append(ready, is)
+		}
+	}
+	sort.SliceStable(ready, func(i, j int) bool {
+		if ready[i].Priority != ready[j].Priority {
+			return ready[i].Priority < ready[j].Priority
+		}
+		return ready[i].CreatedAt.Before(ready[j].CreatedAt)
+	})
+	return ready
+}
+
+// FindByWS returns the issue linked to a workstream, or ErrNotFound
+func FindByWS(q IssueQueue, wsID string) (*Issue, error) {
+	all, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		if all[i].WSID == wsID {
+			return &all[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no issue for workstream %s", ErrNotFound, wsID)
+}
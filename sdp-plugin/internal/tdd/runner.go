@@ -5,7 +5,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"time"
+
+	"github.com/fall-out-bug/sdp/internal/flaky"
+	"github.com/fall-out-bug/sdp/internal/impact"
 )
 
 // Runner executes TDD phases for different programming languages
@@ -13,6 +17,24 @@ type Runner struct {
 	language    Language
 	testCmd     string
 	projectRoot string
+	selector    *impact.Selector
+	flakes      *flaky.Checker
+}
+
+// SetFlakeChecker re-runs failed tests in isolation. In the Green and
+// Refactor phases, failures that are all flaky or quarantined do not fail
+// the phase; in the Red phase they do not count as the expected failure.
+func (r *Runner) SetFlakeChecker(c *flaky.Checker) {
+	r.flakes = c
+}
+
+// SetChangedFiles narrows test runs to the tests affected by changed
+// (absolute or project-relative paths). nil runs the full command.
+func (r *Runner) SetChangedFiles(changed []string) {
+	r.selector = nil
+	if changed != nil {
+		r.selector = impact.NewSelector(r.projectRoot, changed)
+	}
 }
 
 // PhaseResult represents the result of running a TDD phase
@@ -31,6 +53,19 @@ func (r *Runner) RunPhase(ctx context.Context, phase Phase, wsPath string) (*Pha
 
 	// Build command based on language
 	cmd := r.buildTestCommand(wsPath)
+	if r.selector != nil {
+		args, skip, err := r.selector.Rewrite(ctx, cmd.Args)
+		if skip {
+			result := &PhaseResult{Phase: phase, Success: true, Duration: time.Since(start), Stdout: "No affected tests"}
+			if phase == Red {
+				return result, fmt.Errorf("red phase expected failure but no tests are affected by the change")
+			}
+			return result, nil
+		}
+		if err == nil {
+			cmd = exec.Command(args[0], args[1:]...)
+		}
+	}
 
 	// Set working directory to project root if set
 	if r.projectRoot != "" {
@@ -81,12 +116,31 @@ func (r *Runner) RunPhase(ctx context.Context, phase Phase, wsPath string) (*Pha
 			Error:    err,
 		}
 
+		nonBlocking := ""
+		if err != nil && r.flakes != nil {
+			outcome, ferr := r.flakes.Check(ctx, cmd.Dir, cmd.Args, stdout.String()+stderr.String())
+			if ferr != nil {
+				fmt.Fprintf(os.Stderr, "warning: flaky test check: %v\n", ferr)
+			} else if outcome.NonBlocking() {
+				nonBlocking = outcome.Summary()
+			}
+		}
+
 		// Validate result based on phase expectations
 		if phase == Red {
 			// Red phase expects failure
 			if err == nil {
 				return result, fmt.Errorf("red phase expected failure but tests passed")
 			}
+			if nonBlocking != "" {
+				return result, fmt.Errorf("red phase failed only on non-blocking tests (%s)", nonBlocking)
+			}
+			return result, nil
+		}
+		if nonBlocking != "" {
+			result.Success = true
+			result.Error = nil
+			result.Stdout += "\nNon-blocking test failures: " + nonBlocking + "\n"
 			return result, nil
 		}
 
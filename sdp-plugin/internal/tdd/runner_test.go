@@ -4,7 +4,10 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/fall-out-bug/sdp/internal/flaky"
 )
 
 func TestRedPhaseFails(t *testing.T) {
@@ -313,3 +316,79 @@ func TestFindProjectRoot(t *testing.T) {
 		t.Error("Project root is empty")
 	}
 }
+
+func TestRunPhaseAffectedTests(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":        "module example.com/m\n\ngo 1.21\n",
+		"a/a.go":        "package a\n\nfunc A() int { return 1 }\n",
+		"a/a_test.go":   "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+		"b/b.go":        "package b\n",
+		"b/b_test.go":   "package b\n\nimport \"testing\"\n\nfunc TestB(t *testing.T) { t.Fatal(\"b runs\") }\n",
+		"docs/notes.md": "notes\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runner := &Runner{language: Go, testCmd: "go test", projectRoot: dir}
+
+	// Only package a is affected, so the failing test in b does not run.
+	runner.SetChangedFiles([]string{"a/a.go"})
+	result, err := runner.RunPhase(context.Background(), Green, "./...")
+	if err != nil {
+		t.Fatalf("Expected affected tests to pass, got %v\n%s%s", err, result.Stdout, result.Stderr)
+	}
+	if !strings.Contains(result.Stdout, "example.com/m/a") || strings.Contains(result.Stdout, "example.com/m/b") {
+		t.Errorf("Expected only package a to run, got:\n%s", result.Stdout)
+	}
+
+	runner.SetChangedFiles([]string{"docs/notes.md"})
+	result, err = runner.RunPhase(context.Background(), Green, "./...")
+	if err != nil || result.Stdout != "No affected tests" {
+		t.Errorf("Expected skip without affected tests, got %v %q", err, result.Stdout)
+	}
+	if _, err := runner.RunPhase(context.Background(), Red, "./..."); err == nil {
+		t.Error("Expected red phase to fail without affected tests")
+	}
+}
+
+func TestRunPhaseFlakyTests(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":          "module example.com/m\n\ngo 1.21\n",
+		".sdp/config.yml": "version: 1\nquality:\n  flaky:\n    enabled: true\n",
+		// Fails on the first run only.
+		"a/a_test.go": "package a\n\nimport (\n\t\"os\"\n\t\"testing\"\n)\n\n" +
+			"func TestFlaky(t *testing.T) {\n\tif _, err := os.Stat(\"ran\"); err != nil {\n" +
+			"\t\t_ = os.WriteFile(\"ran\", nil, 0o644)\n\t\tt.Fatal(\"first run\")\n\t}\n}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checker, err := flaky.New(dir, "tdd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runner := &Runner{language: Go, testCmd: "go test", projectRoot: dir}
+	runner.SetFlakeChecker(checker)
+
+	result, err := runner.RunPhase(context.Background(), Green, "./...")
+	if err != nil {
+		t.Fatalf("Expected flaky failure to be non-blocking, got %v\n%s%s", err, result.Stdout, result.Stderr)
+	}
+	if !strings.Contains(result.Stdout, "flaky: example.com/m/a.TestFlaky") {
+		t.Errorf("Expected flaky summary, got:\n%s", result.Stdout)
+	}
+}
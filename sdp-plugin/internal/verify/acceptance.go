@@ -0,0 +1,202 @@
+package verify
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Acceptance check kinds
+const (
+	ACKindCommand = "command"
+	ACKindTest    = "test"
+	ACKindFile    = "file"
+	ACKindHTTP    = "http"
+)
+
+// AcceptanceCheck binds one acceptance criterion to an executable check.
+// Exactly one of Run, Test, File or HTTP is set.
+type AcceptanceCheck struct {
+	ID        string `json:"id" yaml:"id"`
+	Criterion string `json:"criterion,omitempty" yaml:"criterion"`
+	// Run is a command, split on whitespace and run without a shell like
+	// verification_commands.
+	Run      string `json:"run,omitempty" yaml:"run"`
+	ExitCode *int   `json:"exit_code,omitempty" yaml:"exit_code"`
+	// Output is a regular expression the command output or HTTP body must match.
+	Output string `json:"output,omitempty" yaml:"output"`
+	// Test names a Go test (TestName or TestName/subtest) or a pytest node ID.
+	Test    string `json:"test,omitempty" yaml:"test"`
+	Package string `json:"package,omitempty" yaml:"package"`
+	File    string `json:"file,omitempty" yaml:"file"`
+	Symbol  string `json:"symbol,omitempty" yaml:"symbol"`
+	// HTTP is a URL on a local server, probed with GET.
+	HTTP    string `json:"http,omitempty" yaml:"http"`
+	Status  int    `json:"status,omitempty" yaml:"status"`
+	Timeout string `json:"timeout,omitempty" yaml:"timeout"`
+}
+
+// AcceptanceResult is the outcome of one acceptance check
+type AcceptanceResult struct {
+	ID        string `json:"id"`
+	Criterion string `json:"criterion,omitempty"`
+	Kind      string `json:"kind"`
+	Passed    bool   `json:"passed"`
+	Message   string `json:"message,omitempty"`
+	Evidence  string `json:"evidence,omitempty"`
+}
+
+// Kind returns which check the criterion is bound to
+func (c AcceptanceCheck) Kind() string {
+	switch {
+	case c.Run != "":
+		return ACKindCommand
+	case c.Test != "":
+		return ACKindTest
+	case c.File != "":
+		return ACKindFile
+	case c.HTTP != "":
+		return ACKindHTTP
+	}
+	return ""
+}
+
+// Validate rejects checks that bind zero or several kinds, bad patterns
+// and probes of non-local hosts.
+func (c AcceptanceCheck) Validate() error {
+	n := 0
+	for _, s := range []string{c.Run, c.Test, c.File, c.HTTP} {
+		if s != "" {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("set exactly one of run, test, file or http")
+	}
+	if c.Symbol != "" && c.File == "" {
+		return fmt.Errorf("symbol requires file")
+	}
+	if c.Output != "" {
+		if _, err := regexp.Compile(c.Output); err != nil {
+			return fmt.Errorf("invalid output pattern: %w", err)
+		}
+	}
+	if c.Timeout != "" {
+		if d, err := time.ParseDuration(c.Timeout); err != nil || d <= 0 {
+			return fmt.Errorf("invalid timeout %q", c.Timeout)
+		}
+	}
+	if c.HTTP != "" {
+		u, err := url.Parse(c.HTTP)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("http must be an http(s) URL: %s", c.HTTP)
+		}
+		if !isLoopbackHost(u.Hostname()) {
+			return fmt.Errorf("http probes are limited to local servers, got host %q", u.Hostname())
+		}
+	}
+	return nil
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback address
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// acceptanceKey matches a top-level, possibly quoted, "acceptance" key on
+// any line of the frontmatter.
+var acceptanceKey = regexp.MustCompile(`(?m)^["']?acceptance["']?\s*:`)
+
+// checkboxItem matches "- [ ] AC1: text" and captures an optional ID
+var checkboxItem = regexp.MustCompile(`^\s*[-*]\s+\[[ xX]\]\s+(?:(AC[\w.-]*)\s*[:.)-]\s*)?(.*)$`)
+
+// ParseAcceptanceChecks reads structured acceptance criteria from a
+// workstream: the frontmatter "acceptance" list and fenced "acceptance"
+// blocks in the body. A fenced block holds one check or a list of them; a
+// check without id or criterion takes them from the checkbox item above it.
+func ParseAcceptanceChecks(frontmatter, body string) ([]AcceptanceCheck, error) {
+	var fm struct {
+		Acceptance []AcceptanceCheck `yaml:"acceptance"`
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		if acceptanceKey.MatchString(frontmatter) {
+			return nil, fmt.Errorf("invalid acceptance frontmatter: %w", err)
+		}
+		fm.Acceptance = nil // other keys the line parser tolerates
+	}
+	checks := fm.Acceptance
+
+	lines := strings.Split(body, "\n")
+	lastID, lastCriterion := "", ""
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if m := checkboxItem.FindStringSubmatch(lines[i]); m != nil {
+			lastID, lastCriterion = m[1], strings.TrimSpace(m[2])
+			continue
+		}
+		if trimmed != "```acceptance" {
+			continue
+		}
+		start := i + 1
+		for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "```"; i++ {
+		}
+		block := strings.Join(lines[start:min(i, len(lines))], "\n")
+		parsed, err := parseAcceptanceBlock(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid acceptance block at body line %d: %w", start, err)
+		}
+		for _, c := range parsed {
+			if c.ID == "" && len(parsed) == 1 {
+				c.ID = lastID
+			}
+			if c.Criterion == "" {
+				c.Criterion = lastCriterion
+			}
+			checks = append(checks, c)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range checks {
+		if checks[i].ID == "" {
+			checks[i].ID = fmt.Sprintf("AC%d", i+1)
+		}
+		id := checks[i].ID
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate acceptance check id %s", id)
+		}
+		seen[id] = true
+		if err := checks[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid acceptance check %s: %w", id, err)
+		}
+	}
+	return checks, nil
+}
+
+// parseAcceptanceBlock decodes a fenced block as a list or a single check
+func parseAcceptanceBlock(block string) ([]AcceptanceCheck, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(block), &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return nil, fmt.Errorf("empty block")
+	}
+	if node.Content[0].Kind == yaml.SequenceNode {
+		var list []AcceptanceCheck
+		err := node.Decode(&list)
+		return list, err
+	}
+	var one AcceptanceCheck
+	err := node.Decode(&one)
+	return []AcceptanceCheck{one}, err
+}
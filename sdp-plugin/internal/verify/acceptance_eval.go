@@ -0,0 +1,154 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultHTTPProbeTimeout bounds an HTTP probe without its own timeout
+	defaultHTTPProbeTimeout = 10 * time.Second
+	// maxProbeBody caps how much of a response body is matched
+	maxProbeBody = 1 << 20
+)
+
+// VerifyAcceptance evaluates each structured acceptance criterion on its own
+func (v *Verifier) VerifyAcceptance(ctx context.Context, wsData *WorkstreamData) []AcceptanceResult {
+	results := make([]AcceptanceResult, 0, len(wsData.Acceptance))
+	for _, ac := range wsData.Acceptance {
+		r := AcceptanceResult{ID: ac.ID, Criterion: ac.Criterion, Kind: ac.Kind()}
+		var err error
+		switch r.Kind {
+		case ACKindCommand:
+			r.Evidence, err = v.runAcceptanceCommand(ctx, ac, strings.Fields(ac.Run))
+		case ACKindTest:
+			r.Evidence, err = v.runAcceptanceTest(ctx, ac)
+		case ACKindFile:
+			r.Evidence, err = v.checkAcceptanceFile(ac)
+		case ACKindHTTP:
+			r.Evidence, err = probeAcceptanceHTTP(ctx, ac)
+		default:
+			err = errors.New("no check bound")
+		}
+		r.Passed = err == nil
+		if err != nil {
+			r.Message = err.Error()
+		} else {
+			r.Message = "OK"
+		}
+		r.Evidence = truncate(r.Evidence, 500)
+		results = append(results, r)
+	}
+	return results
+}
+
+// acceptanceTimeout is the check's own timeout or the verification default
+func acceptanceTimeout(ac AcceptanceCheck, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(ac.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// runAcceptanceCommand runs args and matches the exit code and output
+func (v *Verifier) runAcceptanceCommand(ctx context.Context, ac AcceptanceCheck, args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("empty command")
+	}
+	cmdCtx, cancel := context.WithTimeout(ctx, acceptanceTimeout(ac, verificationTimeout()))
+	defer cancel()
+	cr := v.commandRunner
+	if cr == nil {
+		cr = defaultCommandRunner()
+	}
+	command, err := cr.SafeCommand(cmdCtx, args[0], args[1:]...)
+	if err != nil {
+		return "", fmt.Errorf("security validation: %w", err)
+	}
+	out, runErr := command.CombinedOutput()
+	output := string(out)
+
+	code := 0
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(runErr, &exitErr):
+		code = exitErr.ExitCode()
+	case runErr != nil:
+		return output, fmt.Errorf("run %s: %w", args[0], runErr)
+	}
+	want := 0
+	if ac.ExitCode != nil {
+		want = *ac.ExitCode
+	}
+	if code != want {
+		return output, fmt.Errorf("exit code %d, want %d", code, want)
+	}
+	if ac.Output != "" && !regexp.MustCompile(ac.Output).MatchString(output) {
+		return output, fmt.Errorf("output does not match %q", ac.Output)
+	}
+	return output, nil
+}
+
+// runAcceptanceTest runs one named test: a pytest node ID ("path::name")
+// or a Go test, which must be reported as passed so that a -run pattern
+// matching nothing does not count.
+func (v *Verifier) runAcceptanceTest(ctx context.Context, ac AcceptanceCheck) (string, error) {
+	if strings.Contains(ac.Test, "::") {
+		return v.runAcceptanceCommand(ctx, ac, []string{"pytest", "-q", ac.Test})
+	}
+	parts := strings.Split(ac.Test, "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	pkg := ac.Package
+	if pkg == "" {
+		pkg = "./..."
+	}
+	args := []string{"go", "test", "-count=1", "-v", "-run", strings.Join(parts, "/"), pkg}
+	output, err := v.runAcceptanceCommand(ctx, ac, args)
+	if err != nil {
+		return output, err
+	}
+	if !strings.Contains(output, "--- PASS: "+ac.Test+" ") {
+		return output, fmt.Errorf("test %s did not run", ac.Test)
+	}
+	return "--- PASS: " + ac.Test, nil
+}
+
+// probeAcceptanceHTTP GETs a local URL and matches status and body.
+// Redirects are not followed so a probe cannot leave the local server.
+func probeAcceptanceHTTP(ctx context.Context, ac AcceptanceCheck) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, acceptanceTimeout(ac, defaultHTTPProbeTimeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ac.HTTP, nil)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("GET %s: %w", ac.HTTP, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxProbeBody))
+	evidence := fmt.Sprintf("%s %s", resp.Status, body)
+
+	want := ac.Status
+	if want == 0 {
+		want = http.StatusOK
+	}
+	if resp.StatusCode != want {
+		return evidence, fmt.Errorf("status %d, want %d", resp.StatusCode, want)
+	}
+	if ac.Output != "" && !regexp.MustCompile(ac.Output).Match(body) {
+		return evidence, fmt.Errorf("body does not match %q", ac.Output)
+	}
+	return evidence, nil
+}
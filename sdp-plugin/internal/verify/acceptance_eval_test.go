@@ -0,0 +1,114 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// shellCommandRunner runs any command unvalidated, for acceptance tests
+type shellCommandRunner struct{}
+
+func (shellCommandRunner) SafeCommand(ctx context.Context, name string, args ...string) (*exec.Cmd, error) {
+	return exec.CommandContext(ctx, name, args...), nil
+}
+
+func TestVerifyAcceptance(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("SDP_TIMEOUT_VERIFICATION", "60s")
+	files := map[string]string{
+		"go.mod":      "module example.com/ac\n\ngo 1.21\n",
+		"svc.go":      "package ac\n\ntype Server struct{}\n\nfunc (s *Server) Start() {}\n\nfunc Health() string { return \"ok\" }\n",
+		"svc_test.go": "package ac\n\nimport \"testing\"\n\nfunc TestHealth(t *testing.T) {\n\tt.Run(\"ok\", func(t *testing.T) {})\n}\n",
+		"README.md":   "Run the Server with make serve.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"status":"ok"}`)
+	}))
+	defer srv.Close()
+
+	one := 1
+	tests := []struct {
+		name string
+		ac   AcceptanceCheck
+		pass bool
+		msg  string
+	}{
+		{"exit zero", AcceptanceCheck{Run: "echo hello"}, true, "OK"},
+		{"output matches", AcceptanceCheck{Run: "echo hello", Output: "^hel+o"}, true, "OK"},
+		{"output mismatch", AcceptanceCheck{Run: "echo hello", Output: "bye"}, false, "output does not match"},
+		{"expected exit code", AcceptanceCheck{Run: "false", ExitCode: &one}, true, "OK"},
+		{"wrong exit code", AcceptanceCheck{Run: "false"}, false, "exit code 1, want 0"},
+		{"named test", AcceptanceCheck{Test: "TestHealth"}, true, "OK"},
+		{"named subtest", AcceptanceCheck{Test: "TestHealth/ok"}, true, "OK"},
+		{"missing test", AcceptanceCheck{Test: "TestMissing"}, false, "did not run"},
+		{"file exists", AcceptanceCheck{File: "svc.go"}, true, "OK"},
+		{"file missing", AcceptanceCheck{File: "nope.go"}, false, "missing: nope.go"},
+		{"go func", AcceptanceCheck{File: "svc.go", Symbol: "Health"}, true, "OK"},
+		{"go method", AcceptanceCheck{File: "svc.go", Symbol: "Server.Start"}, true, "OK"},
+		{"go symbol missing", AcceptanceCheck{File: "svc.go", Symbol: "Stop"}, false, "not found"},
+		{"text symbol", AcceptanceCheck{File: "README.md", Symbol: "Server"}, true, "OK"},
+		{"text partial word", AcceptanceCheck{File: "README.md", Symbol: "Serve"}, false, "not found"},
+		{"http ok", AcceptanceCheck{HTTP: srv.URL + "/healthz", Output: `"ok"`}, true, "OK"},
+		{"http status", AcceptanceCheck{HTTP: srv.URL + "/missing"}, false, "status 404, want 200"},
+		{"http expected status", AcceptanceCheck{HTTP: srv.URL + "/missing", Status: 404}, true, "OK"},
+		{"http body mismatch", AcceptanceCheck{HTTP: srv.URL + "/healthz", Output: "degraded"}, false, "body does not match"},
+	}
+	verifier := NewVerifierWithOptions("", WithCommandRunner(shellCommandRunner{}), WithPathValidator(mockPathValidator{}))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ac.ID = "AC"
+			results := verifier.VerifyAcceptance(context.Background(), &WorkstreamData{Acceptance: []AcceptanceCheck{tt.ac}})
+			if len(results) != 1 {
+				t.Fatalf("got %d results", len(results))
+			}
+			r := results[0]
+			if r.Passed != tt.pass || !strings.Contains(r.Message, tt.msg) {
+				t.Errorf("passed=%v message=%q evidence=%q, want passed=%v message containing %q", r.Passed, r.Message, r.Evidence, tt.pass, tt.msg)
+			}
+			if r.Kind != tt.ac.Kind() {
+				t.Errorf("kind = %q, want %q", r.Kind, tt.ac.Kind())
+			}
+		})
+	}
+}
+
+func TestVerifyRecordsAcceptance(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll("docs/workstreams/backlog", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ws := "---\nws_id: 00-048-02\ncoverage_threshold: 0\n---\n\n## Acceptance Criteria\n\n" +
+		"- [ ] AC1: passes\n```acceptance\nrun: true\n```\n" +
+		"- [ ] AC2: fails\n```acceptance\nrun: false\n```\n"
+	if err := os.WriteFile("docs/workstreams/backlog/00-048-02.md", []byte(ws), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	verifier := NewVerifierWithOptions("docs/workstreams", WithCommandRunner(&mockCommandRunner{}))
+	result := verifier.Verify(context.Background(), "00-048-02")
+	if result.Passed {
+		t.Error("verification passed with a failing acceptance criterion")
+	}
+	if len(result.Acceptance) != 2 || !result.Acceptance[0].Passed || result.Acceptance[1].Passed {
+		t.Fatalf("Acceptance = %+v", result.Acceptance)
+	}
+	if result.Acceptance[1].ID != "AC2" || result.Acceptance[1].Criterion != "fails" {
+		t.Errorf("AC2 = %+v", result.Acceptance[1])
+	}
+}
@@ -0,0 +1,84 @@
+package verify
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// checkAcceptanceFile checks a file inside the project exists and, for a
+// symbol, declares it: top-level Go declarations (Type.Method for methods)
+// or a whole-word match in other files.
+func (v *Verifier) checkAcceptanceFile(ac AcceptanceCheck) (string, error) {
+	if root, err := config.FindProjectRoot(); err == nil {
+		pv := v.pathValidator
+		if pv == nil {
+			pv = defaultPathValidator()
+		}
+		if err := pv.ValidatePathInDirectory(root, ac.File); err != nil {
+			return "", fmt.Errorf("path outside project: %w", err)
+		}
+	}
+	content, err := os.ReadFile(ac.File)
+	if err != nil {
+		return "", fmt.Errorf("missing: %s", ac.File)
+	}
+	if ac.Symbol == "" {
+		return ac.File, nil
+	}
+	if strings.HasSuffix(ac.File, ".go") {
+		f, err := parser.ParseFile(token.NewFileSet(), ac.File, content, parser.SkipObjectResolution)
+		if err != nil {
+			return "", fmt.Errorf("parse %s: %w", ac.File, err)
+		}
+		if goDeclares(f, ac.Symbol) {
+			return ac.File + ": " + ac.Symbol, nil
+		}
+	} else if regexp.MustCompile(`\b` + regexp.QuoteMeta(ac.Symbol) + `\b`).Match(content) {
+		return ac.File + ": " + ac.Symbol, nil
+	}
+	return "", fmt.Errorf("symbol %s not found in %s", ac.Symbol, ac.File)
+}
+
+// goDeclares reports whether f declares name at top level
+func goDeclares(f *ast.File, name string) bool {
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Name.Name == name {
+				return true
+			}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := d.Recv.List[0].Type
+				if star, ok := recv.(*ast.StarExpr); ok {
+					recv = star.X
+				}
+				if id, ok := recv.(*ast.Ident); ok && id.Name+"."+d.Name.Name == name {
+					return true
+				}
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if s.Name.Name == name {
+						return true
+					}
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						if n.Name == name {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
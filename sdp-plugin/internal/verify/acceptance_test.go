@@ -0,0 +1,108 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAcceptanceChecks(t *testing.T) {
+	frontmatter := `
+ws_id: 00-048-01
+status: backlog
+acceptance:
+  - id: AC1
+    criterion: version flag works
+    run: sdp --version
+    output: '^sdp'
+`
+	body := "\n## Acceptance Criteria\n\n" +
+		"- [ ] AC2: health endpoint answers\n" +
+		"```acceptance\nhttp: http://127.0.0.1:8080/healthz\nstatus: 200\n```\n" +
+		"- [ ] parser is exported\n" +
+		"```acceptance\nfile: internal/verify/acceptance.go\nsymbol: ParseAcceptanceChecks\n```\n" +
+		"```acceptance\n- id: T1\n  test: TestParseAcceptanceChecks\n- test: tests/test_api.py::test_get\n```\n"
+
+	checks, err := ParseAcceptanceChecks(frontmatter, body)
+	if err != nil {
+		t.Fatalf("ParseAcceptanceChecks: %v", err)
+	}
+	want := []struct{ id, criterion, kind string }{
+		{"AC1", "version flag works", ACKindCommand},
+		{"AC2", "health endpoint answers", ACKindHTTP},
+		{"AC3", "parser is exported", ACKindFile},
+		{"T1", "parser is exported", ACKindTest},
+		{"AC5", "parser is exported", ACKindTest},
+	}
+	if len(checks) != len(want) {
+		t.Fatalf("got %d checks, want %d: %+v", len(checks), len(want), checks)
+	}
+	for i, w := range want {
+		c := checks[i]
+		if c.ID != w.id || c.Criterion != w.criterion || c.Kind() != w.kind {
+			t.Errorf("check %d = %s %q %s, want %s %q %s", i, c.ID, c.Criterion, c.Kind(), w.id, w.criterion, w.kind)
+		}
+	}
+}
+
+func TestParseAcceptanceChecksErrors(t *testing.T) {
+	tests := []struct {
+		name, frontmatter, body, want string
+	}{
+		{"no kind", "\nacceptance:\n  - id: AC1\n", "", "exactly one of"},
+		{"two kinds", "\nacceptance:\n  - run: true\n    file: x.go\n", "", "exactly one of"},
+		{"remote host", "", "```acceptance\nhttp: https://example.com/\n```\n", "local servers"},
+		{"bad pattern", "", "```acceptance\nrun: true\noutput: '('\n```\n", "invalid output pattern"},
+		{"symbol without file", "", "```acceptance\nrun: true\nsymbol: Foo\n```\n", "symbol requires file"},
+		{"bad yaml first key", "acceptance:\n  - run: [\n", "", "invalid acceptance frontmatter"},
+		{"bad yaml later key", "ws_id: 00-048-01\nacceptance :\n  - {run: 'true'\n", "", "invalid acceptance frontmatter"},
+		{"duplicate id", "\nacceptance:\n  - {id: A, run: 'true'}\n  - {id: A, run: 'false'}\n", "", "duplicate"},
+		{"bad yaml", "", "```acceptance\nrun: [\n```\n", "invalid acceptance block"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAcceptanceChecks(tt.frontmatter, tt.body)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWSFileAcceptance(t *testing.T) {
+	content := `---
+ws_id: 00-048-01
+title: Acceptance
+status: in_progress
+acceptance:
+  - id: AC1
+    http: http://localhost:9000/
+    status: 404
+    title: not a workstream field
+scope_files:
+  - a.go
+---
+
+## Acceptance Criteria
+
+- [ ] AC2: builds
+` + "```acceptance\nrun: go build ./...\n```\n"
+	path := filepath.Join(t.TempDir(), "00-048-01.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := NewParser("").ParseWSFile(path)
+	if err != nil {
+		t.Fatalf("ParseWSFile: %v", err)
+	}
+	if data.Status != "in_progress" || data.Title != "Acceptance" {
+		t.Errorf("nested acceptance keys leaked into frontmatter: status=%q title=%q", data.Status, data.Title)
+	}
+	if len(data.ScopeFiles) != 1 || data.ScopeFiles[0] != "a.go" {
+		t.Errorf("ScopeFiles = %v", data.ScopeFiles)
+	}
+	if len(data.Acceptance) != 2 || data.Acceptance[0].Status != 404 || data.Acceptance[1].ID != "AC2" {
+		t.Errorf("Acceptance = %+v", data.Acceptance)
+	}
+}
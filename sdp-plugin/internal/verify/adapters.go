@@ -2,8 +2,12 @@ package verify
 
 import (
 	"context"
+	"log/slog"
 	"os/exec"
+	"time"
 
+	"github.com/fall-out-bug/sdp/internal/flaky"
+	"github.com/fall-out-bug/sdp/internal/mutation"
 	"github.com/fall-out-bug/sdp/internal/quality"
 	"github.com/fall-out-bug/sdp/internal/security"
 )
@@ -25,6 +29,63 @@ func (q *qualityCoverageChecker) CheckCoverage(ctx context.Context) (*CoverageRe
 	}, nil
 }
 
+func (q *qualityCoverageChecker) CheckPatchCoverage(base string, scope []string) (*PatchCoverageResult, error) {
+	result, err := q.checker.CheckPatchCoverage(base, scope)
+	if err != nil {
+		return nil, err
+	}
+	uncovered := make(map[string][]int)
+	for _, f := range result.Files {
+		if len(f.Uncovered) > 0 {
+			uncovered[f.File] = f.Uncovered
+		}
+	}
+	return &PatchCoverageResult{
+		Base:      result.Base,
+		Coverage:  result.Coverage,
+		Threshold: result.Threshold,
+		Covered:   result.Covered,
+		Total:     result.Total,
+		Uncovered: uncovered,
+	}, nil
+}
+
+// mutationRunner adapts mutation.Runner to MutationTester.
+type mutationRunner struct {
+	runner *mutation.Runner
+}
+
+func (m *mutationRunner) Mutate(ctx context.Context, files []string) (*MutationResult, error) {
+	report, err := m.runner.Run(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	result := &MutationResult{
+		Score:    report.Score,
+		Mutants:  len(report.Results),
+		Killed:   report.Killed + report.TimedOut,
+		Survived: report.Survived,
+		Skipped:  report.Skipped,
+	}
+	for _, s := range report.Survivors() {
+		result.Survivors = append(result.Survivors, s.String())
+	}
+	return result, nil
+}
+
+// flakyChecker adapts flaky.Checker to FlakeChecker.
+type flakyChecker struct {
+	checker *flaky.Checker
+}
+
+func (f *flakyChecker) Check(ctx context.Context, args []string, output string) (*FlakeResult, error) {
+	outcome, err := f.checker.Check(ctx, "", args, output)
+	if err != nil {
+		return nil, err
+	}
+	return &FlakeResult{NonBlocking: outcome.NonBlocking(), Summary: outcome.Summary()}, nil
+}
+
 // securityPathValidator adapts security.ValidatePathInDirectory to PathValidator.
 type securityPathValidator struct{}
 
@@ -48,6 +109,26 @@ func defaultCoverageChecker(projectRoot string) (CoverageChecker, error) {
 	return &qualityCoverageChecker{checker: checker}, nil
 }
 
+// defaultMutationTester returns a MutationTester for the given project root.
+func defaultMutationTester(projectRoot string, budget time.Duration, maxMutants int) MutationTester {
+	return &mutationRunner{runner: &mutation.Runner{Root: projectRoot, Budget: budget, MaxMutants: maxMutants}}
+}
+
+// DefaultFlakeChecker returns a FlakeChecker for the project root, or nil
+// when quality.flaky is disabled or the config cannot be read.
+func DefaultFlakeChecker(projectRoot, wsID string) FlakeChecker {
+	c, err := flaky.New(projectRoot, "verify")
+	if err != nil {
+		slog.Debug("flaky test detection unavailable", "error", err)
+		return nil
+	}
+	if c == nil {
+		return nil
+	}
+	c.WSID = wsID
+	return &flakyChecker{checker: c}
+}
+
 // defaultPathValidator returns the production PathValidator.
 func defaultPathValidator() PathValidator {
 	return securityPathValidator{}
@@ -18,6 +18,61 @@ type CoverageChecker interface {
 	CheckCoverage(ctx context.Context) (*CoverageResult, error)
 }
 
+// PatchCoverageResult is the minimal changed-lines coverage result needed
+// for verification.
+type PatchCoverageResult struct {
+	Base      string
+	Coverage  float64
+	Threshold float64
+	Covered   int
+	Total     int
+	// Uncovered maps files to changed lines no test executed.
+	Uncovered map[string][]int
+}
+
+// PatchCoverageChecker measures coverage of lines changed since base,
+// restricted to scope. Injectable for tests.
+type PatchCoverageChecker interface {
+	CheckPatchCoverage(base string, scope []string) (*PatchCoverageResult, error)
+}
+
+// MutationResult is the minimal mutation testing result needed for
+// verification.
+type MutationResult struct {
+	Score    float64 // meaningful only when Killed+Survived > 0
+	Mutants  int     // mutants generated, evaluated or not
+	Killed   int
+	Survived int
+	Skipped  int
+	// Survivors describes mutants the tests did not detect.
+	Survivors []string
+}
+
+// MutationTester mutates files and runs their tests. Injectable for tests.
+type MutationTester interface {
+	Mutate(ctx context.Context, files []string) (*MutationResult, error)
+}
+
+// TestSelector narrows whole-suite test commands to the tests affected by
+// the change under verification. skip reports that no test is affected.
+// Injectable for tests; nil runs commands unchanged.
+type TestSelector interface {
+	Rewrite(ctx context.Context, args []string) (out []string, skip bool, err error)
+}
+
+// FlakeResult is the triage of a failed test command.
+type FlakeResult struct {
+	// NonBlocking is set when every failed test is flaky or quarantined.
+	NonBlocking bool
+	Summary     string
+}
+
+// FlakeChecker re-runs the failed tests of a command in isolation. Injectable
+// for tests; nil treats every failure as blocking.
+type FlakeChecker interface {
+	Check(ctx context.Context, args []string, output string) (*FlakeResult, error)
+}
+
 // PathValidator validates that a path is within a base directory.
 // Injectable for tests.
 type PathValidator interface {
@@ -19,6 +19,8 @@ type VerificationResult struct {
 	MissingFiles   []string      `json:"missing_files,omitempty"`
 	FailedCommands []string      `json:"failed_commands,omitempty"`
 	Duration       time.Duration `json:"duration"`
+	// Acceptance holds per-criterion results of structured acceptance checks.
+	Acceptance []AcceptanceResult `json:"acceptance,omitempty"`
 }
 
 // WorkstreamData represents parsed workstream frontmatter
@@ -29,4 +31,8 @@ type WorkstreamData struct {
 	ScopeFiles           []string `json:"scope_files" yaml:"scope_files"`
 	VerificationCommands []string `json:"verification_commands" yaml:"verification_commands"`
 	CoverageThreshold    float64  `json:"coverage_threshold" yaml:"coverage_threshold"`
+	// PatchCoverageThreshold enables the changed-lines gate for this workstream.
+	PatchCoverageThreshold float64 `json:"patch_coverage_threshold,omitempty" yaml:"patch_coverage_threshold"`
+	// Acceptance binds acceptance criteria to executable checks.
+	Acceptance []AcceptanceCheck `json:"acceptance,omitempty" yaml:"acceptance"`
 }
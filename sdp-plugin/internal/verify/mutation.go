@@ -0,0 +1,89 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// defaultMutationThreshold is the minimum mutation score when
+// quality.mutation.threshold is unset.
+const defaultMutationThreshold = 60.0
+
+// defaultMutationBudget bounds a mutation run when quality.mutation.budget
+// is unset.
+const defaultMutationBudget = 10 * time.Minute
+
+// VerifyMutation runs mutation testing on the workstream's Go scope files
+// when quality.mutation.enabled is set in the project config; otherwise it
+// returns nil. Mutants left untested by the time budget do not count
+// towards the score; the check fails when mutants were generated but none
+// was evaluated.
+func (v *Verifier) VerifyMutation(ctx context.Context, wsData *WorkstreamData) *CheckResult {
+	projectRoot, err := config.FindProjectRoot()
+	if err != nil {
+		return nil
+	}
+	cfg, err := config.Load(projectRoot)
+	if err != nil || cfg == nil || !cfg.Quality.Mutation.Enabled {
+		return nil
+	}
+	section := cfg.Quality.Mutation
+
+	var files []string
+	for _, f := range wsData.ScopeFiles {
+		if strings.HasSuffix(f, ".go") && !strings.HasSuffix(f, "_test.go") {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+
+	threshold := defaultMutationThreshold
+	if section.Threshold > 0 {
+		threshold = float64(section.Threshold)
+	}
+	mt := v.mutationTester
+	if mt == nil {
+		budget := config.TimeoutFromConfigOrEnv(section.Budget, "SDP_MUTATION_BUDGET", defaultMutationBudget)
+		mt = defaultMutationTester(projectRoot, budget, section.MaxMutants)
+	}
+
+	result, err := mt.Mutate(ctx, files)
+	if err != nil {
+		return &CheckResult{
+			Name:    "Mutation Check",
+			Passed:  false,
+			Message: fmt.Sprintf("mutation testing: %v", err),
+		}
+	}
+	if result.Killed+result.Survived == 0 {
+		if result.Mutants == 0 {
+			return &CheckResult{
+				Name:    "Mutation Check",
+				Passed:  true,
+				Message: "Mutation score: N/A (no mutants evaluated: nothing to mutate)",
+			}
+		}
+		return &CheckResult{
+			Name:    "Mutation Check",
+			Passed:  false,
+			Message: fmt.Sprintf("Mutation score: N/A (no mutants evaluated: %d generated, %d skipped by budget)", result.Mutants, result.Skipped),
+		}
+	}
+	msg := fmt.Sprintf("Mutation score: %.1f%% (%d killed, %d survived, threshold: %.1f%%)",
+		result.Score, result.Killed, result.Survived, threshold)
+	if result.Skipped > 0 {
+		msg += fmt.Sprintf(", %d skipped by budget", result.Skipped)
+	}
+	return &CheckResult{
+		Name:     "Mutation Check",
+		Passed:   result.Score >= threshold,
+		Message:  msg,
+		Evidence: truncate(strings.Join(result.Survivors, "\n"), 500),
+	}
+}
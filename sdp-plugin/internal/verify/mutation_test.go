@@ -0,0 +1,95 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type mockMutationTester struct {
+	result   *MutationResult
+	err      error
+	gotFiles []string
+}
+
+func (m *mockMutationTester) Mutate(_ context.Context, files []string) (*MutationResult, error) {
+	m.gotFiles = files
+	return m.result, m.err
+}
+
+func chdirProject(t *testing.T, configYAML string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".sdp"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".sdp", "config.yml"), []byte(configYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+}
+
+func TestVerifyMutation_DisabledByDefault(t *testing.T) {
+	chdirProject(t, "version: 1\n")
+	mock := &mockMutationTester{}
+	verifier := NewVerifierWithOptions("/tmp", WithMutationTester(mock))
+	if check := verifier.VerifyMutation(context.Background(), &WorkstreamData{ScopeFiles: []string{"a.go"}}); check != nil || mock.gotFiles != nil {
+		t.Errorf("expected no mutation check when disabled, got %+v", check)
+	}
+}
+
+func TestVerifyMutation_GoScopeFiles(t *testing.T) {
+	chdirProject(t, "version: 1\nquality:\n  mutation:\n    enabled: true\n    threshold: 75\n")
+	mock := &mockMutationTester{result: &MutationResult{
+		Score: 50, Mutants: 4, Killed: 1, Survived: 1, Skipped: 2,
+		Survivors: []string{"pkg/calc.go:4:7 conditional-boundary: > -> >="},
+	}}
+	verifier := NewVerifierWithOptions("/tmp", WithMutationTester(mock))
+	wsData := &WorkstreamData{ScopeFiles: []string{"pkg/calc.go", "pkg/calc_test.go", "docs/calc.md"}}
+
+	check := verifier.VerifyMutation(context.Background(), wsData)
+	if check == nil {
+		t.Fatal("expected mutation check")
+	}
+	if !reflect.DeepEqual(mock.gotFiles, []string{"pkg/calc.go"}) {
+		t.Errorf("expected only implementation Go files, got %v", mock.gotFiles)
+	}
+	if check.Passed {
+		t.Errorf("expected failure below 75%% threshold: %s", check.Message)
+	}
+	if !strings.Contains(check.Message, "2 skipped by budget") || !strings.Contains(check.Evidence, "conditional-boundary") {
+		t.Errorf("unexpected check %+v", check)
+	}
+
+	mock.result.Score = 80
+	if check := verifier.VerifyMutation(context.Background(), wsData); !check.Passed {
+		t.Errorf("expected pass at 80%%: %s", check.Message)
+	}
+
+	mock.err = fmt.Errorf("tests fail without mutations")
+	if check := verifier.VerifyMutation(context.Background(), wsData); check.Passed {
+		t.Error("expected failure on mutation error")
+	}
+}
+
+func TestVerifyMutation_NoMutantsEvaluated(t *testing.T) {
+	chdirProject(t, "version: 1\nquality:\n  mutation:\n    enabled: true\n")
+	mock := &mockMutationTester{result: &MutationResult{Mutants: 3, Skipped: 3}}
+	verifier := NewVerifierWithOptions("/tmp", WithMutationTester(mock))
+	wsData := &WorkstreamData{ScopeFiles: []string{"pkg/calc.go"}}
+
+	check := verifier.VerifyMutation(context.Background(), wsData)
+	if check == nil || check.Passed || !strings.Contains(check.Message, "N/A (no mutants evaluated") {
+		t.Errorf("expected failure when every mutant was skipped, got %+v", check)
+	}
+
+	mock.result = &MutationResult{}
+	check = verifier.VerifyMutation(context.Background(), wsData)
+	if check == nil || !check.Passed || !strings.Contains(check.Message, "N/A") {
+		t.Errorf("expected N/A pass when nothing could be mutated, got %+v", check)
+	}
+}
@@ -56,7 +56,7 @@ func (p *Parser) ParseWSFile(wsPath string) (*WorkstreamData, error) {
 		return nil, fmt.Errorf("no frontmatter found in %s", wsPath)
 	}
 	afterOpen := strings.TrimPrefix(contentStr, "---")
-	frontmatter, _, ok := strings.Cut(afterOpen, "---")
+	frontmatter, body, ok := strings.Cut(afterOpen, "---")
 	if !ok {
 		return nil, fmt.Errorf("no frontmatter found in %s", wsPath)
 	}
@@ -70,9 +70,15 @@ func (p *Parser) ParseWSFile(wsPath string) (*WorkstreamData, error) {
 
 	lines := strings.Split(frontmatter, "\n")
 	inList := false
+	inAcceptance := false
 	currentList := &data.ScopeFiles
 
 	for _, line := range lines {
+		// Nested acceptance keys (status, title...) are not workstream fields
+		if inAcceptance && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "-")) {
+			continue
+		}
+		inAcceptance = false
 		line = strings.TrimSpace(line)
 
 		// Skip empty lines and markers
@@ -103,12 +109,18 @@ func (p *Parser) ParseWSFile(wsPath string) (*WorkstreamData, error) {
 					// Use default value if parsing fails
 					data.CoverageThreshold = 80.0
 				}
+			case "patch_coverage_threshold":
+				if _, err := fmt.Sscanf(value, "%f", &data.PatchCoverageThreshold); err != nil {
+					data.PatchCoverageThreshold = 0
+				}
 			case "scope_files":
 				inList = true
 				currentList = &data.ScopeFiles
 			case "verification_commands":
 				inList = true
 				currentList = &data.VerificationCommands
+			case "acceptance":
+				inAcceptance = true
 			}
 		} else if inList {
 			// Parse list items
@@ -120,5 +132,11 @@ func (p *Parser) ParseWSFile(wsPath string) (*WorkstreamData, error) {
 		}
 	}
 
+	acceptance, err := ParseAcceptanceChecks(frontmatter, body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", wsPath, err)
+	}
+	data.Acceptance = acceptance
+
 	return data, nil
 }
@@ -0,0 +1,113 @@
+package verify
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fall-out-bug/sdp/internal/basebranch"
+	"github.com/fall-out-bug/sdp/internal/config"
+)
+
+// VerifyPatchCoverage checks coverage of the lines changed since the base
+// branch within the workstream's scope files. It runs when the workstream
+// sets patch_coverage_threshold or the project config sets
+// quality.patch_coverage_base / patch_coverage_threshold; otherwise it
+// returns nil. Coverage reports are expected to exist already, normally
+// written by the preceding whole-project coverage check.
+func (v *Verifier) VerifyPatchCoverage(wsData *WorkstreamData) *CheckResult {
+	projectRoot, rootErr := config.FindProjectRoot()
+	var quality config.QualitySection
+	if rootErr == nil {
+		if cfg, err := config.Load(projectRoot); err == nil && cfg != nil {
+			quality = cfg.Quality
+		}
+	}
+	if wsData.PatchCoverageThreshold == 0 && quality.PatchCoverageBase == "" && quality.PatchCoverageThreshold == 0 {
+		return nil
+	}
+	// Without a configured base, use the default branch as sdp collision
+	// predict and the CI attestation do.
+	base := quality.PatchCoverageBase
+	if base == "" && rootErr == nil {
+		base = basebranch.Default(projectRoot)
+	}
+
+	pc, err := v.patchChecker(projectRoot, rootErr)
+	if err != nil {
+		return &CheckResult{
+			Name:    "Patch Coverage Check",
+			Passed:  false,
+			Message: err.Error(),
+		}
+	}
+	result, err := pc.CheckPatchCoverage(base, wsData.ScopeFiles)
+	if err != nil {
+		return &CheckResult{
+			Name:    "Patch Coverage Check",
+			Passed:  false,
+			Message: fmt.Sprintf("patch coverage: %v", err),
+		}
+	}
+
+	threshold := result.Threshold
+	if wsData.PatchCoverageThreshold > 0 {
+		threshold = wsData.PatchCoverageThreshold
+	}
+	return &CheckResult{
+		Name:   "Patch Coverage Check",
+		Passed: result.Total == 0 || result.Coverage >= threshold,
+		Message: fmt.Sprintf("Patch coverage: %.1f%% of %d changed lines since %s (threshold: %.1f%%)",
+			result.Coverage, result.Total, base, threshold),
+		Evidence: truncate(formatUncovered(result.Uncovered), 500),
+	}
+}
+
+func (v *Verifier) patchChecker(projectRoot string, rootErr error) (PatchCoverageChecker, error) {
+	if v.patchCoverageChecker != nil {
+		return v.patchCoverageChecker, nil
+	}
+	if pc, ok := v.coverageChecker.(PatchCoverageChecker); ok {
+		return pc, nil
+	}
+	if rootErr != nil {
+		return nil, fmt.Errorf("project root: %v", rootErr)
+	}
+	cc, err := defaultCoverageChecker(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("checker init: %v", err)
+	}
+	return cc.(PatchCoverageChecker), nil
+}
+
+// formatUncovered renders uncovered lines as "file: 3, 7-9" per line.
+func formatUncovered(uncovered map[string][]int) string {
+	files := make([]string, 0, len(uncovered))
+	for f := range uncovered {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	var sb strings.Builder
+	for _, f := range files {
+		fmt.Fprintf(&sb, "%s: %s\n", f, lineRanges(uncovered[f]))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func lineRanges(lines []int) string {
+	var parts []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.Itoa(lines[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
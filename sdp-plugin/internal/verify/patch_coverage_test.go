@@ -0,0 +1,64 @@
+package verify
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type mockPatchCoverageChecker struct {
+	result    *PatchCoverageResult
+	err       error
+	gotBase   string
+	gotScope  []string
+	callCount int
+}
+
+func (m *mockPatchCoverageChecker) CheckPatchCoverage(base string, scope []string) (*PatchCoverageResult, error) {
+	m.callCount++
+	m.gotBase, m.gotScope = base, scope
+	return m.result, m.err
+}
+
+func TestVerifyPatchCoverage_DisabledByDefault(t *testing.T) {
+	t.Chdir(t.TempDir()) // no project config
+	mock := &mockPatchCoverageChecker{}
+	verifier := NewVerifierWithOptions("/tmp", WithPatchCoverageChecker(mock))
+	if check := verifier.VerifyPatchCoverage(&WorkstreamData{CoverageThreshold: 80}); check != nil || mock.callCount != 0 {
+		t.Errorf("expected no check without patch coverage settings, got %+v", check)
+	}
+}
+
+func TestVerifyPatchCoverage_WorkstreamScope(t *testing.T) {
+	mock := &mockPatchCoverageChecker{result: &PatchCoverageResult{
+		Base: "abc", Coverage: 60, Threshold: 80, Covered: 3, Total: 5,
+		Uncovered: map[string][]int{"pkg/calc.go": {9, 10, 14}},
+	}}
+	verifier := NewVerifierWithOptions("/tmp", WithPatchCoverageChecker(mock))
+	wsData := &WorkstreamData{ScopeFiles: []string{"pkg/calc.go"}, PatchCoverageThreshold: 50}
+
+	check := verifier.VerifyPatchCoverage(wsData)
+	if check == nil {
+		t.Fatal("expected patch coverage check")
+	}
+	if !reflect.DeepEqual(mock.gotScope, wsData.ScopeFiles) {
+		t.Errorf("expected workstream scope passed through, got %v", mock.gotScope)
+	}
+	if !check.Passed {
+		t.Errorf("expected pass against workstream threshold 50%%: %s", check.Message)
+	}
+	if check.Evidence != "pkg/calc.go: 9-10, 14" {
+		t.Errorf("unexpected evidence %q", check.Evidence)
+	}
+
+	wsData.PatchCoverageThreshold = 90
+	if check := verifier.VerifyPatchCoverage(wsData); check.Passed || !strings.Contains(check.Message, "90.0%") {
+		t.Errorf("expected failure against 90%% threshold, got %+v", check)
+	}
+
+	mock.err = fmt.Errorf("no coverage report found")
+	if check := verifier.VerifyPatchCoverage(wsData); check.Passed {
+		t.Error("expected failure when the checker errors")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -464,6 +465,66 @@ func TestVerifierVerifyCommandsSuccess(t *testing.T) {
 	}
 }
 
+// mockTestSelector maps whole commands to rewritten ones; absent commands are skipped.
+type mockTestSelector struct {
+	rewrites map[string][]string
+}
+
+func (m *mockTestSelector) Rewrite(ctx context.Context, args []string) ([]string, bool, error) {
+	out, ok := m.rewrites[strings.Join(args, " ")]
+	return out, !ok, nil
+}
+
+func TestVerifierVerifyCommandsAffectedTests(t *testing.T) {
+	selector := &mockTestSelector{rewrites: map[string][]string{"go test ./...": {"true", "./b"}}}
+	verifier := NewVerifierWithOptions("/tmp", WithCommandRunner(&mockCommandRunner{}), WithTestSelector(selector))
+
+	checks := verifier.VerifyCommands(context.Background(), &WorkstreamData{
+		VerificationCommands: []string{"go test ./...", "pytest"},
+	})
+	if len(checks) != 2 {
+		t.Fatalf("Expected 2 checks, got %d", len(checks))
+	}
+	if !checks[0].Passed || !strings.Contains(checks[0].Message, "affected tests: true ./b") {
+		t.Errorf("Expected narrowed command to run, got: %+v", checks[0])
+	}
+	if checks[0].Name != "Command: go test ./..." {
+		t.Errorf("Expected check named after the original command, got %q", checks[0].Name)
+	}
+	if !checks[1].Passed || checks[1].Message != "Skipped: no affected tests" {
+		t.Errorf("Expected skipped command to pass, got: %+v", checks[1])
+	}
+}
+
+type mockFlakeChecker struct {
+	result *FlakeResult
+	calls  int
+}
+
+func (m *mockFlakeChecker) Check(ctx context.Context, args []string, output string) (*FlakeResult, error) {
+	m.calls++
+	return m.result, nil
+}
+
+func TestVerifierVerifyCommandsFlakyTests(t *testing.T) {
+	flakes := &mockFlakeChecker{result: &FlakeResult{NonBlocking: true, Summary: "flaky: a.TestX"}}
+	verifier := NewVerifierWithOptions("/tmp", WithCommandRunner(&mockCommandRunner{}), WithFlakeChecker(flakes))
+
+	checks := verifier.VerifyCommands(context.Background(), &WorkstreamData{VerificationCommands: []string{"false", "true"}})
+	if !checks[0].Passed || checks[0].Message != "Non-blocking: flaky: a.TestX" {
+		t.Errorf("Expected flaky failure to pass, got: %+v", checks[0])
+	}
+	if flakes.calls != 1 {
+		t.Errorf("Expected flake check only for the failed command, got %d calls", flakes.calls)
+	}
+
+	flakes.result = &FlakeResult{Summary: "failing: a.TestY"}
+	checks = verifier.VerifyCommands(context.Background(), &WorkstreamData{VerificationCommands: []string{"false"}})
+	if checks[0].Passed {
+		t.Errorf("Expected blocking failure to fail, got: %+v", checks[0])
+	}
+}
+
 func TestTruncateEdgeCases(t *testing.T) {
 	tests := []struct {
 		input    string
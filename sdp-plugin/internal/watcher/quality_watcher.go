@@ -17,6 +17,8 @@ type QualityWatcher struct {
 	mu         sync.RWMutex
 	quiet      bool
 	watchPath  string
+	runTests   bool
+	fullTests  bool
 }
 
 // Violation represents a quality violation
@@ -37,6 +39,12 @@ type QualityWatcherConfig struct {
 
 	// Quiet suppresses output
 	Quiet bool
+
+	// RunTests runs the tests affected by each changed file
+	RunTests bool
+
+	// FullTests runs the whole test suite instead of affected tests
+	FullTests bool
 }
 
 // NewQualityWatcher creates a new quality watcher
@@ -68,6 +76,8 @@ func NewQualityWatcher(watchPath string, config *QualityWatcherConfig) (*Quality
 		checker:   checker,
 		watchPath: watchPath,
 		quiet:     config.Quiet,
+		runTests:  config.RunTests,
+		fullTests: config.FullTests,
 	}
 
 	// Create file watcher
@@ -32,6 +32,11 @@ func (qw *QualityWatcher) checkFile(path string) {
 	// Check types
 	qw.checkTypes(path, relPath)
 
+	// Run affected tests
+	if qw.runTests {
+		qw.checkTests(path, relPath)
+	}
+
 	if !qw.quiet {
 		fmt.Println("\033[90m────────────────────────────────────\033[0m")
 	}
@@ -0,0 +1,73 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/impact"
+)
+
+// testRunTimeout bounds one affected-test run triggered by a save.
+const testRunTimeout = 5 * time.Minute
+
+// checkTests runs the tests affected by the changed file (or the whole
+// suite with FullTests) and records failures as violations.
+func (qw *QualityWatcher) checkTests(path, relPath string) {
+	ctx, cancel := context.WithTimeout(context.Background(), testRunTimeout)
+	defer cancel()
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	selections, err := impact.Analyze(ctx, qw.watchPath, []string{abs})
+	if err != nil {
+		if !qw.quiet {
+			fmt.Printf("\033[33m⚠ tests\033[0m\n  \033[90mMessage:\033[0m affected test selection failed: %v\n", err)
+		}
+		return
+	}
+	for _, sel := range selections {
+		if qw.fullTests {
+			sel.Full = true
+		}
+		if !sel.Full && len(sel.Targets) == 0 {
+			continue
+		}
+		args := impact.Command(sel)
+		if len(args) == 0 {
+			continue
+		}
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Dir = sel.Dir
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			if !qw.quiet {
+				fmt.Printf("\033[32m✔ tests\033[0m %s\n", strings.Join(args, " "))
+			}
+			continue
+		}
+		violation := Violation{
+			File:     relPath,
+			Check:    "tests",
+			Message:  fmt.Sprintf("%s failed: %v\n%s", strings.Join(args, " "), err, lastLines(string(out), 15)),
+			Severity: "error",
+		}
+		qw.addViolation(violation)
+		if !qw.quiet {
+			qw.printViolation(violation)
+		}
+	}
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,55 @@
+package watcher
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestQualityWatcher_CheckTests(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":      "module example.com/m\n\ngo 1.21\n",
+		"a/a.go":      "package a\n\nfunc A() int { return 1 }\n",
+		"a/a_test.go": "package a\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+		"b/b.go":      "package b\n",
+		"b/b_test.go": "package b\n\nimport \"testing\"\n\nfunc TestB(t *testing.T) { t.Fatal(\"broken\") }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	qw, err := NewQualityWatcher(dir, &QualityWatcherConfig{Quiet: true, RunTests: true})
+	if err != nil {
+		t.Fatalf("NewQualityWatcher: %v", err)
+	}
+	defer qw.Close()
+
+	qw.checkTests(filepath.Join(dir, "a", "a.go"), "a/a.go")
+	if v := qw.GetViolations(); len(v) != 0 {
+		t.Fatalf("expected no violations when affected tests pass, got %+v", v)
+	}
+
+	qw.checkTests(filepath.Join(dir, "b", "b.go"), "b/b.go")
+	v := qw.GetViolations()
+	if len(v) != 1 || v[0].Check != "tests" || !strings.Contains(v[0].Message, "go test ./b") {
+		t.Fatalf("expected a failing tests violation for ./b, got %+v", v)
+	}
+
+	qw.fullTests = true
+	qw.checkTests(filepath.Join(dir, "a", "a.go"), "a/a.go")
+	if v := qw.GetViolations(); len(v) != 2 || !strings.Contains(v[1].Message, "go test ./...") {
+		t.Errorf("expected full suite run to fail on b, got %+v", v)
+	}
+}
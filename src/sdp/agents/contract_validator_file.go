@@ -3,6 +3,7 @@ package agents
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -20,6 +21,16 @@ func (cv *ContractValidator) ValidateContractFile(contractPath string) ([]*Contr
 		return nil, fmt.Errorf("failed to read contract: %w", err)
 	}
 
+	if filepath.Ext(contractPath) == ".proto" {
+		proto, parseErr := ParseProtoContract(content)
+		cv.metrics.RecordSchemaParse(parseErr == nil)
+		if parseErr != nil {
+			return nil, fmt.Errorf("failed to parse contract: %w", parseErr)
+		}
+		success = true
+		return validateProtoContract(proto), nil
+	}
+
 	contract := &OpenAPIContract{}
 	parseErr := safeYAMLUnmarshal(content, contract)
 	cv.metrics.RecordSchemaParse(parseErr == nil)
@@ -0,0 +1,25 @@
+package agents
+
+// validateProtoContract checks a parsed .proto contract for basic issues
+func validateProtoContract(contract *ProtoContract) []*ContractMismatch {
+	var mismatches []*ContractMismatch
+	if contract.Syntax == "" {
+		mismatches = append(mismatches, &ContractMismatch{
+			Severity: "WARNING",
+			Type:     "invalid_contract",
+			Expected: "syntax declaration",
+			Actual:   "missing",
+			Fix:      `Add syntax = "proto3"; to contract`,
+		})
+	}
+	if len(contract.RPCNames()) == 0 {
+		mismatches = append(mismatches, &ContractMismatch{
+			Severity: "WARNING",
+			Type:     "invalid_contract",
+			Expected: "at least one rpc",
+			Actual:   "no services defined",
+			Fix:      "Add a service with rpc methods to contract",
+		})
+	}
+	return mismatches
+}
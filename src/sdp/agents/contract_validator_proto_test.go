@@ -0,0 +1,38 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateContractFile_Proto(t *testing.T) {
+	cv := NewContractValidator()
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "users.proto")
+	if err := os.WriteFile(valid, []byte(usersProtoContract), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mismatches, err := cv.ValidateContractFile(valid)
+	if err != nil || len(mismatches) != 0 {
+		t.Errorf("valid contract: %v, %v", mismatches, err)
+	}
+
+	bare := filepath.Join(dir, "bare.proto")
+	if err := os.WriteFile(bare, []byte("message A { string id = 1; }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mismatches, err = cv.ValidateContractFile(bare)
+	if err != nil || len(mismatches) != 2 {
+		t.Errorf("expected syntax and service warnings, got %v, %v", mismatches, err)
+	}
+
+	broken := filepath.Join(dir, "broken.proto")
+	if err := os.WriteFile(broken, []byte("service S {"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cv.ValidateContractFile(broken); err == nil {
+		t.Error("expected parse error")
+	}
+}
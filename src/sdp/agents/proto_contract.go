@@ -0,0 +1,104 @@
+package agents
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProtoContract represents a protobuf/gRPC contract. It mirrors the model
+// in sdp-plugin internal/contract, which drives lock and breaking-change
+// checks; this copy covers what synthesis and validation need.
+type ProtoContract struct {
+	Syntax   string             `yaml:"syntax"`
+	Package  string             `yaml:"package"`
+	Services []ProtoServiceSpec `yaml:"services"`
+	Messages []ProtoMessageSpec `yaml:"messages"`
+}
+
+// ProtoServiceSpec represents a gRPC service
+type ProtoServiceSpec struct {
+	Name string         `yaml:"name"`
+	RPCs []ProtoRPCSpec `yaml:"rpcs"`
+}
+
+// ProtoRPCSpec represents a single RPC method
+type ProtoRPCSpec struct {
+	Name            string `yaml:"name"`
+	Request         string `yaml:"request"`
+	Response        string `yaml:"response"`
+	ClientStreaming bool   `yaml:"client_streaming"`
+	ServerStreaming bool   `yaml:"server_streaming"`
+}
+
+// ProtoMessageSpec represents a protobuf message
+type ProtoMessageSpec struct {
+	Name   string           `yaml:"name"`
+	Fields []ProtoFieldSpec `yaml:"fields"`
+}
+
+// ProtoFieldSpec represents a numbered message field
+type ProtoFieldSpec struct {
+	Name     string `yaml:"name"`
+	Number   int    `yaml:"number"`
+	Type     string `yaml:"type"`
+	Repeated bool   `yaml:"repeated"`
+}
+
+// ParseProtoContract parses .proto source without protoc. Options, imports
+// and nested declarations are skipped; only top-level services and
+// messages are recorded.
+func ParseProtoContract(data []byte) (*ProtoContract, error) {
+	if len(data) > MaxYAMLFileSize {
+		return nil, fmt.Errorf("proto file size %d bytes exceeds maximum allowed size %d bytes", len(data), MaxYAMLFileSize)
+	}
+	toks, err := lexProto(string(data))
+	if err != nil {
+		return nil, err
+	}
+	p := &protoScanner{toks: toks}
+	contract := &ProtoContract{}
+	for p.pos < len(p.toks) {
+		switch tok := p.next(); tok {
+		case "syntax":
+			p.next() // =
+			contract.Syntax = strings.Trim(p.next(), `"'`)
+			p.skipTo(";")
+		case "package":
+			contract.Package = p.next()
+			p.skipTo(";")
+		case "service":
+			svc, err := p.service()
+			if err != nil {
+				return nil, err
+			}
+			contract.Services = append(contract.Services, svc)
+		case "message":
+			msg, err := p.message()
+			if err != nil {
+				return nil, err
+			}
+			contract.Messages = append(contract.Messages, msg)
+		case "enum", "extend":
+			p.next()
+			p.skipBlock()
+		case ";":
+		default:
+			p.skipTo(";")
+		}
+	}
+	if contract.Syntax == "" && len(contract.Services) == 0 && len(contract.Messages) == 0 {
+		return nil, fmt.Errorf("no proto declarations found")
+	}
+	return contract, nil
+}
+
+// RPCNames returns "Service/Method" for every RPC in the contract
+func (pc *ProtoContract) RPCNames() []string {
+	var names []string
+	for _, svc := range pc.Services {
+		for _, rpc := range svc.RPCs {
+			names = append(names, svc.Name+"/"+rpc.Name)
+		}
+	}
+	return names
+}
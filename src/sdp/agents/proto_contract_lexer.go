@@ -0,0 +1,79 @@
+package agents
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// protoToken is a lexical token of a .proto file. Strings keep their
+// quotes so they are never mistaken for identifiers.
+type protoToken struct {
+	text string
+	line int
+}
+
+// lexProto splits a .proto source into tokens, dropping comments.
+//
+// This file is the source of truth for the proto lexer. The root module
+// cannot import it, so src/sdp/agents/proto_contract_lexer.go is a copy
+// that differs only in its package clause; the agents tests fail when the
+// two drift apart.
+func lexProto(src string) ([]protoToken, error) {
+	var toks []protoToken
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && src[j] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			toks = append(toks, protoToken{src[i : j+1], line})
+			i = j + 1
+		case isProtoWordByte(c):
+			j := i
+			for j < len(src) && isProtoWordByte(src[j]) {
+				j++
+			}
+			toks = append(toks, protoToken{src[i:j], line})
+			i = j
+		default:
+			toks = append(toks, protoToken{string(c), line})
+			i++
+		}
+	}
+	return toks, nil
+}
+
+// isProtoWordByte reports whether c continues an identifier, a dotted
+// type name or a number.
+func isProtoWordByte(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '+' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
@@ -0,0 +1,143 @@
+package agents
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// protoScanner walks proto tokens
+type protoScanner struct {
+	toks []protoToken
+	pos  int
+}
+
+func (p *protoScanner) next() string {
+	if p.pos >= len(p.toks) {
+		return ""
+	}
+	p.pos++
+	return p.toks[p.pos-1].text
+}
+
+// skipTo consumes tokens up to and including end
+func (p *protoScanner) skipTo(end string) {
+	for p.pos < len(p.toks) && p.next() != end {
+	}
+}
+
+// skipBlock consumes a brace-delimited block including its braces
+func (p *protoScanner) skipBlock() {
+	p.skipTo("{")
+	for depth := 1; depth > 0 && p.pos < len(p.toks); {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+}
+
+func (p *protoScanner) service() (ProtoServiceSpec, error) {
+	svc := ProtoServiceSpec{Name: p.next()}
+	if p.next() != "{" {
+		return svc, fmt.Errorf("service %s: expected {", svc.Name)
+	}
+	for tok := p.next(); tok != "}"; tok = p.next() {
+		switch tok {
+		case "":
+			return svc, fmt.Errorf("service %s: unexpected end of file", svc.Name)
+		case "rpc":
+			rpc := ProtoRPCSpec{Name: p.next()}
+			rpc.Request, rpc.ClientStreaming = p.rpcType()
+			if p.next() != "returns" {
+				return svc, fmt.Errorf("rpc %s: expected returns", rpc.Name)
+			}
+			rpc.Response, rpc.ServerStreaming = p.rpcType()
+			if p.next() == "{" {
+				p.pos--
+				p.skipBlock()
+			}
+			svc.RPCs = append(svc.RPCs, rpc)
+		case "option":
+			p.skipTo(";")
+		}
+	}
+	return svc, nil
+}
+
+// rpcType reads "(stream Type)" and reports whether it streams
+func (p *protoScanner) rpcType() (string, bool) {
+	p.next() // (
+	name, stream := p.next(), false
+	if name == "stream" {
+		name, stream = p.next(), true
+	}
+	p.next() // )
+	return strings.TrimPrefix(name, "."), stream
+}
+
+func (p *protoScanner) message() (ProtoMessageSpec, error) {
+	msg := ProtoMessageSpec{Name: p.next()}
+	if p.next() != "{" {
+		return msg, fmt.Errorf("message %s: expected {", msg.Name)
+	}
+	inOneof := false
+	for {
+		switch tok := p.next(); tok {
+		case "":
+			return msg, fmt.Errorf("message %s: unexpected end of file", msg.Name)
+		case "}":
+			if !inOneof {
+				return msg, nil
+			}
+			inOneof = false
+		case "oneof":
+			p.skipTo("{")
+			inOneof = true
+		case "message", "enum", "extend":
+			p.next()
+			p.skipBlock()
+		case "extensions", "reserved", "option":
+			p.skipTo(";")
+		case ";":
+		default:
+			field, err := p.field(tok)
+			if err != nil {
+				return msg, fmt.Errorf("message %s: %w", msg.Name, err)
+			}
+			msg.Fields = append(msg.Fields, field)
+		}
+	}
+}
+
+// field reads "[label] type name = number [options];" starting at tok
+func (p *protoScanner) field(tok string) (ProtoFieldSpec, error) {
+	var f ProtoFieldSpec
+	switch tok {
+	case "repeated":
+		f.Repeated, tok = true, p.next()
+	case "optional", "required":
+		tok = p.next()
+	}
+	if tok == "map" {
+		var parts []string
+		for t := p.next(); t != ">" && t != ""; t = p.next() {
+			parts = append(parts, t)
+		}
+		tok = "map" + strings.Join(parts, "") + ">"
+	}
+	f.Type = strings.TrimPrefix(tok, ".")
+	f.Name = p.next()
+	if p.next() != "=" {
+		return f, fmt.Errorf("field %s: expected =", f.Name)
+	}
+	n, err := strconv.Atoi(p.next())
+	if err != nil {
+		return f, fmt.Errorf("field %s: invalid number: %w", f.Name, err)
+	}
+	f.Number = n
+	p.skipTo(";")
+	return f, nil
+}
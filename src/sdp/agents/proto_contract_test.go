@@ -0,0 +1,117 @@
+package agents
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const usersProtoContract = `// Users API
+syntax = "proto3";
+package users.v1;
+
+import "google/protobuf/timestamp.proto";
+option go_package = "example.com/users/v1;usersv1";
+
+service UserService {
+  option deprecated = false;
+  rpc GetUser(GetUserRequest) returns (User);
+  rpc ListUsers(ListUsersRequest) returns (stream User) {
+    option idempotency_level = NO_SIDE_EFFECTS;
+  }
+}
+
+/* request */
+message GetUserRequest { string id = 1; }
+
+message ListUsersRequest {}
+
+message User {
+  reserved 4;
+  string id = 1;
+  repeated string tags = 2 [packed = true];
+  map<string, int64> quota = 3;
+  oneof contact {
+    string email = 5;
+    string phone = 6;
+  }
+  message Address { string city = 1; }
+  Address address = 7;
+}
+
+enum Role { ROLE_UNSPECIFIED = 0; }
+`
+
+func TestParseProtoContract(t *testing.T) {
+	contract, err := ParseProtoContract([]byte(usersProtoContract))
+	if err != nil {
+		t.Fatalf("ParseProtoContract failed: %v", err)
+	}
+	if contract.Syntax != "proto3" || contract.Package != "users.v1" {
+		t.Errorf("syntax/package = %q/%q", contract.Syntax, contract.Package)
+	}
+	if got := contract.RPCNames(); !reflect.DeepEqual(got, []string{"UserService/GetUser", "UserService/ListUsers"}) {
+		t.Errorf("RPCNames = %v", got)
+	}
+	list := contract.Services[0].RPCs[1]
+	if list.Request != "ListUsersRequest" || list.ClientStreaming || !list.ServerStreaming {
+		t.Errorf("ListUsers = %+v", list)
+	}
+	if len(contract.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(contract.Messages))
+	}
+	user := contract.Messages[2]
+	want := []ProtoFieldSpec{
+		{Name: "id", Number: 1, Type: "string"},
+		{Name: "tags", Number: 2, Type: "string", Repeated: true},
+		{Name: "quota", Number: 3, Type: "map<string,int64>"},
+		{Name: "email", Number: 5, Type: "string"},
+		{Name: "phone", Number: 6, Type: "string"},
+		{Name: "address", Number: 7, Type: "Address"},
+	}
+	if !reflect.DeepEqual(user.Fields, want) {
+		t.Errorf("User fields = %+v", user.Fields)
+	}
+}
+
+func TestParseProtoContract_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "// nothing here\n",
+		"bad number":     "message A { string id = one; }",
+		"missing brace":  "service S rpc A(B) returns (C);",
+		"missing return": "service S { rpc A(B) (C); }",
+		"unterminated":   "message A { string id = 1;",
+		"open comment":   "message A { string id = 1; } /* trailing",
+		"open string":    "syntax = \"proto3;\nmessage A {}",
+	}
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseProtoContract([]byte(src)); err == nil {
+				t.Errorf("expected error for %q", src)
+			}
+		})
+	}
+}
+
+// TestProtoLexer_PluginCopyMatches keeps the lexer in step with
+// sdp-plugin/internal/contract/proto_lexer.go, its source of truth; only
+// the package clause differs.
+func TestProtoLexer_PluginCopyMatches(t *testing.T) {
+	plugin, err := os.ReadFile(filepath.Join("..", "..", "..", "sdp-plugin", "internal", "contract", "proto_lexer.go"))
+	if os.IsNotExist(err) {
+		t.Skip("sdp-plugin not checked out")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := os.ReadFile("proto_contract_lexer.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plugin = bytes.Replace(plugin, []byte("package contract\n"), []byte("package agents\n"), 1)
+	if !bytes.Equal(local, plugin) {
+		t.Error("src/sdp/agents/proto_contract_lexer.go differs from sdp-plugin/internal/contract/proto_lexer.go; copy the change over")
+	}
+}
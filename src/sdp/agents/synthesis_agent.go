@@ -171,6 +171,17 @@ func (cs *ContractSynthesizer) SynthesizeContract(featureName, reqPath, outputPa
 		return nil, fmt.Errorf("apply synthesis rules failed: %w", err)
 	}
 
+	if filepath.Ext(outputPath) == ".proto" {
+		protoContract, err := cs.ProposeProtoContract(requirements)
+		if err != nil {
+			return nil, fmt.Errorf("propose proto contract failed: %w", err)
+		}
+		if err := cs.WriteProtoContract(protoContract, outputPath); err != nil {
+			return nil, fmt.Errorf("write contract failed: %w", err)
+		}
+		return result, nil
+	}
+
 	finalContract := result.Solution.(*OpenAPIContract)
 	if err := cs.WriteContract(finalContract, outputPath); err != nil {
 		return nil, fmt.Errorf("write contract failed: %w", err)
@@ -0,0 +1,155 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	protoVersionSegment = regexp.MustCompile(`^v[0-9]+$`)
+	protoRPCVerbs       = map[string]string{
+		"GET": "Get", "POST": "Create", "PUT": "Update", "PATCH": "Patch", "DELETE": "Delete",
+	}
+)
+
+// ProposeProtoContract generates an initial gRPC contract from
+// requirements: one <Feature>Service with an RPC per endpoint and a
+// request/response message pair per RPC.
+func (cs *ContractSynthesizer) ProposeProtoContract(requirements *ContractRequirements) (*ProtoContract, error) {
+	feature := protoIdentifier(requirements.FeatureName)
+	contract := &ProtoContract{
+		Syntax:  "proto3",
+		Package: strings.ToLower(strings.ReplaceAll(requirements.FeatureName, "-", "_")) + ".v1",
+	}
+	service := ProtoServiceSpec{Name: feature + "Service"}
+
+	seen := make(map[string]int)
+	for _, endpoint := range requirements.Endpoints {
+		name := protoRPCName(endpoint)
+		if seen[name]++; seen[name] > 1 {
+			name = fmt.Sprintf("%s%d", name, seen[name])
+		}
+		rpc := ProtoRPCSpec{Name: name, Request: name + "Request", Response: name + "Response"}
+		service.RPCs = append(service.RPCs, rpc)
+		contract.Messages = append(contract.Messages,
+			protoMessageFromSchema(rpc.Request, endpoint.Request),
+			protoMessageFromSchema(rpc.Response, endpoint.Response))
+	}
+	contract.Services = []ProtoServiceSpec{service}
+	return contract, nil
+}
+
+// FormatProtoContract renders a contract as .proto source
+func FormatProtoContract(contract *ProtoContract) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "syntax = %q;\n", contract.Syntax)
+	if contract.Package != "" {
+		fmt.Fprintf(&sb, "\npackage %s;\n", contract.Package)
+	}
+	for _, svc := range contract.Services {
+		fmt.Fprintf(&sb, "\nservice %s {\n", svc.Name)
+		for _, rpc := range svc.RPCs {
+			fmt.Fprintf(&sb, "  rpc %s(%s%s) returns (%s%s);\n", rpc.Name,
+				streamPrefix(rpc.ClientStreaming), rpc.Request,
+				streamPrefix(rpc.ServerStreaming), rpc.Response)
+		}
+		sb.WriteString("}\n")
+	}
+	for _, msg := range contract.Messages {
+		if len(msg.Fields) == 0 {
+			fmt.Fprintf(&sb, "\nmessage %s {}\n", msg.Name)
+			continue
+		}
+		fmt.Fprintf(&sb, "\nmessage %s {\n", msg.Name)
+		for _, f := range msg.Fields {
+			label := ""
+			if f.Repeated {
+				label = "repeated "
+			}
+			fmt.Fprintf(&sb, "  %s%s %s = %d;\n", label, f.Type, f.Name, f.Number)
+		}
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// WriteProtoContract writes the agreed contract to a .proto file
+func (cs *ContractSynthesizer) WriteProtoContract(contract *ProtoContract, outputPath string) error {
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(outputPath, []byte(FormatProtoContract(contract)), 0644); err != nil {
+		return fmt.Errorf("failed to write contract: %w", err)
+	}
+	return nil
+}
+
+func streamPrefix(stream bool) string {
+	if stream {
+		return "stream "
+	}
+	return ""
+}
+
+// protoRPCName derives an RPC name from an endpoint, e.g.
+// GET /api/v1/users/{id} becomes GetUsersById.
+func protoRPCName(endpoint EndpointSpec) string {
+	verb, ok := protoRPCVerbs[endpoint.Method]
+	if !ok {
+		verb = protoIdentifier(strings.ToLower(endpoint.Method))
+	}
+	name := verb
+	for _, seg := range strings.Split(endpoint.Path, "/") {
+		switch {
+		case seg == "" || seg == "api" || protoVersionSegment.MatchString(seg):
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			name += "By" + protoIdentifier(strings.Trim(seg, "{}"))
+		default:
+			name += protoIdentifier(seg)
+		}
+	}
+	return name
+}
+
+// protoMessageFromSchema numbers schema fields in declaration order
+func protoMessageFromSchema(name string, schema SchemaSpec) ProtoMessageSpec {
+	msg := ProtoMessageSpec{Name: name}
+	for i, field := range schema.Fields {
+		typ, repeated := protoFieldType(field.Type)
+		msg.Fields = append(msg.Fields, ProtoFieldSpec{Name: field.Name, Number: i + 1, Type: typ, Repeated: repeated})
+	}
+	return msg
+}
+
+// protoFieldType maps a requirements field type to a proto scalar
+func protoFieldType(t string) (string, bool) {
+	switch strings.ToLower(t) {
+	case "integer", "int", "int64":
+		return "int64", false
+	case "int32":
+		return "int32", false
+	case "number", "float", "double":
+		return "double", false
+	case "boolean", "bool":
+		return "bool", false
+	case "bytes", "binary":
+		return "bytes", false
+	case "array", "list":
+		return "string", true
+	}
+	return "string", false
+}
+
+// protoIdentifier turns snake, kebab or lower case words into CamelCase
+func protoIdentifier(s string) string {
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || r == ' '
+	}) {
+		sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return sb.String()
+}
@@ -0,0 +1,65 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProposeProtoContract(t *testing.T) {
+	requirements := &ContractRequirements{
+		FeatureName: "user-profile",
+		Endpoints: []EndpointSpec{
+			{
+				Path:     "/api/v1/profiles/{id}",
+				Method:   "GET",
+				Response: SchemaSpec{Fields: []FieldSpec{{Name: "name", Type: "string"}, {Name: "age", Type: "integer"}, {Name: "tags", Type: "array"}}},
+			},
+			{
+				Path:    "/api/v1/profiles",
+				Method:  "POST",
+				Request: SchemaSpec{Fields: []FieldSpec{{Name: "name", Type: "string"}, {Name: "admin", Type: "boolean"}}},
+			},
+		},
+	}
+
+	contract, err := NewContractSynthesizer().ProposeProtoContract(requirements)
+	if err != nil {
+		t.Fatalf("ProposeProtoContract failed: %v", err)
+	}
+	if contract.Package != "user_profile.v1" || contract.Services[0].Name != "UserProfileService" {
+		t.Errorf("package/service = %s/%s", contract.Package, contract.Services[0].Name)
+	}
+	if got := contract.RPCNames(); !reflect.DeepEqual(got, []string{"UserProfileService/GetProfilesById", "UserProfileService/CreateProfiles"}) {
+		t.Errorf("RPCNames = %v", got)
+	}
+
+	// The formatted source must parse back to the same contract
+	parsed, err := ParseProtoContract([]byte(FormatProtoContract(contract)))
+	if err != nil {
+		t.Fatalf("formatted contract does not parse: %v\n%s", err, FormatProtoContract(contract))
+	}
+	if !reflect.DeepEqual(parsed, contract) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", parsed, contract)
+	}
+}
+
+func TestSynthesizeContract_Proto(t *testing.T) {
+	cs := NewContractSynthesizer()
+	tmpDir := t.TempDir()
+
+	reqPath := filepath.Join(tmpDir, "sdp-test-requirements.md")
+	if err := os.WriteFile(reqPath, []byte("# Test\n\n### GET /api/test\nResponse: {data}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	outputPath := filepath.Join(tmpDir, "contract.proto")
+
+	if _, err := cs.SynthesizeContract("test", reqPath, outputPath); err != nil {
+		t.Fatalf("SynthesizeContract failed: %v", err)
+	}
+	mismatches, err := NewContractValidator().ValidateContractFile(outputPath)
+	if err != nil || len(mismatches) != 0 {
+		t.Errorf("synthesized contract invalid: %v, %v", mismatches, err)
+	}
+}
@@ -0,0 +1,134 @@
+// Package budget caps token and cost spend per feature and per workstream.
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPath is the budgets configuration file, relative to the project root.
+const ConfigPath = ".sdp/budgets.yaml"
+
+// DefaultSoftWarning is the fraction of a limit at which a soft warning fires.
+const DefaultSoftWarning = 0.8
+
+// Limit caps spend for one scope. Zero means unlimited.
+type Limit struct {
+	MaxTokens int     `yaml:"max_tokens" json:"max_tokens,omitempty"`
+	MaxCost   float64 `yaml:"max_cost" json:"max_cost,omitempty"`
+}
+
+// IsZero returns true if neither tokens nor cost are capped.
+func (l Limit) IsZero() bool {
+	return l.MaxTokens == 0 && l.MaxCost == 0
+}
+
+// Config is the schema for .sdp/budgets.yaml.
+//
+//	soft_warning: 0.8
+//	hard_stop: 1.0
+//	defaults:
+//	  feature: {max_tokens: 2000000, max_cost: 40}
+//	  workstream: {max_tokens: 400000, max_cost: 8}
+//	features:
+//	  F027: {max_cost: 60}
+//	workstreams:
+//	  00-027-01: {max_tokens: 600000}
+//	context:
+//	  default: 32000
+//	  runtimes: {opencode: 48000}
+//	  models: {claude-opus: 64000}
+type Config struct {
+	SoftWarning float64          `yaml:"soft_warning"`
+	HardStop    float64          `yaml:"hard_stop"`
+	Defaults    DefaultLimits    `yaml:"defaults"`
+	Features    map[string]Limit `yaml:"features"`
+	Workstreams map[string]Limit `yaml:"workstreams"`
+	Context     ContextLimits    `yaml:"context"`
+}
+
+// ContextLimits cap the pre-hydrated context packet injected into a prompt,
+// in estimated tokens. A model entry wins over its runtime, which wins over
+// the default. Zero means not configured.
+type ContextLimits struct {
+	Default  int            `yaml:"default"`
+	Runtimes map[string]int `yaml:"runtimes"`
+	Models   map[string]int `yaml:"models"`
+}
+
+// DefaultLimits apply to any feature or workstream without an explicit entry.
+type DefaultLimits struct {
+	Feature    Limit `yaml:"feature"`
+	Workstream Limit `yaml:"workstream"`
+}
+
+// LoadConfig reads .sdp/budgets.yaml. Returns nil if the file is missing (budgets disabled).
+func LoadConfig(projectRoot string) (*Config, error) {
+	path := filepath.Join(projectRoot, ConfigPath)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read budgets config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse budgets config: %w", err)
+	}
+	if err := cfg.normalize(); err != nil {
+		return nil, fmt.Errorf("%s: %w", ConfigPath, err)
+	}
+	return &cfg, nil
+}
+
+func (c *Config) normalize() error {
+	if c.SoftWarning == 0 {
+		c.SoftWarning = DefaultSoftWarning
+	}
+	if c.HardStop == 0 {
+		c.HardStop = 1.0
+	}
+	if c.SoftWarning < 0 || c.HardStop < 0 {
+		return errors.New("soft_warning and hard_stop must be positive")
+	}
+	if c.Context.Default < 0 {
+		return errors.New("context.default must not be negative")
+	}
+	if c.SoftWarning > c.HardStop {
+		return fmt.Errorf("soft_warning %.2f exceeds hard_stop %.2f", c.SoftWarning, c.HardStop)
+	}
+	return nil
+}
+
+// FeatureLimit returns the limit for a feature, falling back to the default.
+func (c *Config) FeatureLimit(featureID string) Limit {
+	if l, ok := c.Features[featureID]; ok {
+		return l
+	}
+	return c.Defaults.Feature
+}
+
+// WorkstreamLimit returns the limit for a workstream, falling back to the default.
+func (c *Config) WorkstreamLimit(wsID string) Limit {
+	if l, ok := c.Workstreams[wsID]; ok {
+		return l
+	}
+	return c.Defaults.Workstream
+}
+
+// ContextBudget returns the context packet token budget for a runtime and
+// model, or 0 if none is configured.
+func (c *Config) ContextBudget(runtime, modelID string) int {
+	if n := c.Context.Models[modelID]; n > 0 {
+		return n
+	}
+	if n := c.Context.Runtimes[runtime]; n > 0 {
+		return n
+	}
+	return c.Context.Default
+}
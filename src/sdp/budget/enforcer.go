@@ -0,0 +1,166 @@
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+)
+
+// ErrBudgetExhausted is returned when a hard budget limit has been reached.
+// It is recoverable: raise the limit in .sdp/budgets.yaml (or reset the ledger) and resume.
+var ErrBudgetExhausted = errors.New("budget exhausted")
+
+// Level is the state of a scope relative to its limit.
+type Level string
+
+const (
+	LevelOK        Level = "ok"
+	LevelWarning   Level = "warning"
+	LevelExhausted Level = "exhausted"
+)
+
+// ScopeStatus is the burn-down for one feature or workstream.
+type ScopeStatus struct {
+	Scope    string  `json:"scope"` // "feature" or "workstream"
+	ID       string  `json:"id"`
+	Limit    Limit   `json:"limit"`
+	Spend    Spend   `json:"spend"`
+	Fraction float64 `json:"fraction"` // highest of tokens/cost used over limit
+	Level    Level   `json:"level"`
+}
+
+// ExhaustedError identifies which scope ran out of budget.
+type ExhaustedError struct {
+	Status ScopeStatus
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("%s %s: %s (%d tokens, $%.2f; limit %d tokens, $%.2f)",
+		e.Status.Scope, e.Status.ID, ErrBudgetExhausted,
+		e.Status.Spend.Tokens(), e.Status.Spend.Cost,
+		e.Status.Limit.MaxTokens, e.Status.Limit.MaxCost)
+}
+
+func (e *ExhaustedError) Unwrap() error {
+	return ErrBudgetExhausted
+}
+
+// Enforcer evaluates the ledger against configured limits.
+type Enforcer struct {
+	config *Config
+	ledger *Ledger
+}
+
+// NewEnforcer creates an enforcer. A nil config disables enforcement.
+func NewEnforcer(config *Config, ledger *Ledger) *Enforcer {
+	return &Enforcer{config: config, ledger: ledger}
+}
+
+// LoadEnforcer loads .sdp/budgets.yaml and the ledger for projectRoot.
+func LoadEnforcer(projectRoot string) (*Enforcer, error) {
+	cfg, err := LoadConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	return NewEnforcer(cfg, NewLedger(projectRoot)), nil
+}
+
+// Enabled returns true if budgets are configured.
+func (e *Enforcer) Enabled() bool {
+	return e != nil && e.config != nil
+}
+
+// Ledger returns the underlying ledger.
+func (e *Enforcer) Ledger() *Ledger {
+	return e.ledger
+}
+
+// Status returns burn-down for the feature and workstream (either may be empty).
+func (e *Enforcer) Status(featureID, wsID string) ([]ScopeStatus, error) {
+	if !e.Enabled() {
+		return nil, nil
+	}
+	ld, err := e.ledger.Load()
+	if err != nil {
+		return nil, err
+	}
+	var out []ScopeStatus
+	if featureID != "" {
+		out = append(out, e.evaluate("feature", featureID, e.config.FeatureLimit(featureID), ld.Features[featureID]))
+	}
+	if wsID != "" {
+		out = append(out, e.evaluate("workstream", wsID, e.config.WorkstreamLimit(wsID), ld.Workstreams[wsID]))
+	}
+	return out, nil
+}
+
+// StatusAll returns burn-down for every feature and workstream in the ledger or config.
+func (e *Enforcer) StatusAll() ([]ScopeStatus, error) {
+	if !e.Enabled() {
+		return nil, nil
+	}
+	ld, err := e.ledger.Load()
+	if err != nil {
+		return nil, err
+	}
+	var out []ScopeStatus
+	for _, id := range unionKeys(ld.Features, e.config.Features) {
+		out = append(out, e.evaluate("feature", id, e.config.FeatureLimit(id), ld.Features[id]))
+	}
+	for _, id := range unionKeys(ld.Workstreams, e.config.Workstreams) {
+		out = append(out, e.evaluate("workstream", id, e.config.WorkstreamLimit(id), ld.Workstreams[id]))
+	}
+	return out, nil
+}
+
+// Check returns an *ExhaustedError (matching ErrBudgetExhausted) when the feature
+// or workstream reached its hard stop. Soft warnings are logged, not returned.
+func (e *Enforcer) Check(featureID, wsID string) error {
+	statuses, err := e.Status(featureID, wsID)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		switch s.Level {
+		case LevelExhausted:
+			return &ExhaustedError{Status: s}
+		case LevelWarning:
+			slog.Warn("budget soft limit reached", "scope", s.Scope, "id", s.ID,
+				"used", fmt.Sprintf("%.0f%%", s.Fraction*100), "tokens", s.Spend.Tokens(), "cost", s.Spend.Cost)
+		}
+	}
+	return nil
+}
+
+func (e *Enforcer) evaluate(scope, id string, limit Limit, spend Spend) ScopeStatus {
+	s := ScopeStatus{Scope: scope, ID: id, Limit: limit, Spend: spend, Level: LevelOK}
+	if limit.MaxTokens > 0 {
+		s.Fraction = max(s.Fraction, float64(spend.Tokens())/float64(limit.MaxTokens))
+	}
+	if limit.MaxCost > 0 {
+		s.Fraction = max(s.Fraction, spend.Cost/limit.MaxCost)
+	}
+	switch {
+	case limit.IsZero():
+	case s.Fraction >= e.config.HardStop:
+		s.Level = LevelExhausted
+	case s.Fraction >= e.config.SoftWarning:
+		s.Level = LevelWarning
+	}
+	return s
+}
+
+func unionKeys(a map[string]Spend, b map[string]Limit) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,161 @@
+package budget
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBudgets(t *testing.T, root, content string) {
+	t.Helper()
+	path := filepath.Join(root, ConfigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig_MissingDisablesBudgets(t *testing.T) {
+	e, err := LoadEnforcer(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Enabled() {
+		t.Error("expected budgets disabled without config")
+	}
+	if err := e.Check("F001", "00-001-01"); err != nil {
+		t.Errorf("disabled enforcer must not fail: %v", err)
+	}
+}
+
+func TestLoadConfig_Overrides(t *testing.T) {
+	root := t.TempDir()
+	writeBudgets(t, root, `
+defaults:
+  feature: {max_tokens: 1000}
+  workstream: {max_cost: 1.5}
+features:
+  F027: {max_cost: 10}
+`)
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SoftWarning != DefaultSoftWarning || cfg.HardStop != 1.0 {
+		t.Errorf("defaults not applied: soft=%v hard=%v", cfg.SoftWarning, cfg.HardStop)
+	}
+	if got := cfg.FeatureLimit("F027"); got.MaxCost != 10 || got.MaxTokens != 0 {
+		t.Errorf("expected F027 override, got %+v", got)
+	}
+	if got := cfg.FeatureLimit("F001"); got.MaxTokens != 1000 {
+		t.Errorf("expected default feature limit, got %+v", got)
+	}
+	if got := cfg.WorkstreamLimit("00-001-01"); got.MaxCost != 1.5 {
+		t.Errorf("expected default workstream limit, got %+v", got)
+	}
+}
+
+func TestConfig_ContextBudget(t *testing.T) {
+	root := t.TempDir()
+	writeBudgets(t, root, `
+context:
+  default: 32000
+  runtimes: {opencode: 48000}
+  models: {claude-opus: 64000}
+`)
+	cfg, err := LoadConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		runtime, model string
+		want           int
+	}{
+		{"opencode", "claude-opus", 64000},
+		{"opencode", "claude-sonnet", 48000},
+		{"claude", "claude-sonnet", 32000},
+		{"", "", 32000},
+	}
+	for _, tt := range tests {
+		if got := cfg.ContextBudget(tt.runtime, tt.model); got != tt.want {
+			t.Errorf("ContextBudget(%q, %q) = %d, want %d", tt.runtime, tt.model, got, tt.want)
+		}
+	}
+	if got := (&Config{}).ContextBudget("opencode", "claude-opus"); got != 0 {
+		t.Errorf("unconfigured ContextBudget = %d, want 0", got)
+	}
+}
+
+func TestLoadConfig_SoftAboveHardRejected(t *testing.T) {
+	root := t.TempDir()
+	writeBudgets(t, root, "soft_warning: 0.9\nhard_stop: 0.5\n")
+	if _, err := LoadConfig(root); err == nil {
+		t.Error("expected error when soft_warning > hard_stop")
+	}
+}
+
+func TestEnforcer_WarningThenExhausted(t *testing.T) {
+	root := t.TempDir()
+	writeBudgets(t, root, `
+defaults:
+  workstream: {max_tokens: 1000}
+`)
+	e, err := LoadEnforcer(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Ledger().Record(Usage{FeatureID: "F027", WSID: "00-027-01", InputTokens: 700, OutputTokens: 150}); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := e.Status("F027", "00-027-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected feature and workstream status, got %d", len(statuses))
+	}
+	if statuses[0].Level != LevelOK {
+		t.Errorf("feature has no limit, expected ok, got %s", statuses[0].Level)
+	}
+	if statuses[1].Level != LevelWarning {
+		t.Errorf("expected workstream warning at 85%%, got %s", statuses[1].Level)
+	}
+	if err := e.Check("F027", "00-027-01"); err != nil {
+		t.Errorf("soft warning must not halt: %v", err)
+	}
+
+	if err := e.Ledger().Record(Usage{FeatureID: "F027", WSID: "00-027-01", OutputTokens: 200}); err != nil {
+		t.Fatal(err)
+	}
+	err = e.Check("F027", "00-027-01")
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("expected ErrBudgetExhausted, got %v", err)
+	}
+	var exhausted *ExhaustedError
+	if !errors.As(err, &exhausted) || exhausted.Status.ID != "00-027-01" {
+		t.Errorf("expected workstream scope in error, got %v", err)
+	}
+}
+
+func TestEnforcer_StatusAllIncludesConfiguredScopes(t *testing.T) {
+	root := t.TempDir()
+	writeBudgets(t, root, "features:\n  F030: {max_cost: 5}\n")
+	e, err := LoadEnforcer(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Ledger().Record(Usage{FeatureID: "F027", Cost: 1}); err != nil {
+		t.Fatal(err)
+	}
+	all, err := e.StatusAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].ID != "F027" || all[1].ID != "F030" {
+		t.Errorf("expected F027 and F030 sorted, got %+v", all)
+	}
+}
@@ -0,0 +1,57 @@
+package budget
+
+import (
+	"github.com/fall-out-bug/sdp/src/sdp/hooks"
+)
+
+// gatewayHookPriority runs budget handlers before the default gateway handlers.
+const gatewayHookPriority = 100
+
+// RegisterGatewayHooks enforces budgets at the AI gateway:
+// gateway:request fails with ErrBudgetExhausted once a hard stop is reached,
+// gateway:response and gateway:error add the call's tokens and cost to the ledger.
+func RegisterGatewayHooks(registry *hooks.HookRegistry, e *Enforcer) {
+	if !e.Enabled() {
+		return
+	}
+
+	registry.Subscribe(hooks.EventTypeGatewayRequest, func(event hooks.HookEvent) error {
+		featureID, wsID := scopeFromPayload(event.Payload)
+		return e.Check(featureID, wsID)
+	}, gatewayHookPriority)
+
+	record := func(event hooks.HookEvent) error {
+		featureID, wsID := scopeFromPayload(event.Payload)
+		in, _ := event.Payload["input_tokens"].(int)
+		out, _ := event.Payload["output_tokens"].(int)
+		cost, _ := event.Payload["cost"].(float64)
+		if in == 0 && out == 0 && cost == 0 {
+			return nil
+		}
+		return e.ledger.Record(Usage{
+			FeatureID:    featureID,
+			WSID:         wsID,
+			InputTokens:  in,
+			OutputTokens: out,
+			Cost:         cost,
+			At:           event.Timestamp.UTC(),
+		})
+	}
+	registry.Subscribe(hooks.EventTypeGatewayResponse, record, gatewayHookPriority)
+	registry.Subscribe(hooks.EventTypeGatewayError, record, gatewayHookPriority)
+}
+
+func scopeFromPayload(payload map[string]any) (featureID, wsID string) {
+	featureID, _ = payload["feature_id"].(string)
+	wsID, _ = payload["ws_id"].(string)
+	return featureID, wsID
+}
+
+// EstimateTokens approximates the token count of text (about 4 characters per token).
+// Used when the runtime does not report usage.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,63 @@
+package budget
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fall-out-bug/sdp/src/sdp/hooks"
+)
+
+func TestRegisterGatewayHooks_RecordsAndBlocks(t *testing.T) {
+	root := t.TempDir()
+	writeBudgets(t, root, "defaults:\n  feature: {max_cost: 1}\n")
+	e, err := LoadEnforcer(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := hooks.NewRegistry()
+	RegisterGatewayHooks(registry, e)
+
+	req := hooks.NewGatewayEvent("claude-sonnet", "h", 100, 0, 0, 0, nil).WithScope("F027", "00-027-01")
+	if err := registry.Publish(req.ToHookEvent(hooks.EventTypeGatewayRequest)); err != nil {
+		t.Fatalf("request under budget must pass: %v", err)
+	}
+
+	resp := hooks.NewGatewayEvent("claude-sonnet", "h", 100, 400, time.Second, 1.2, nil).WithScope("F027", "00-027-01")
+	if err := registry.Publish(resp.ToHookEvent(hooks.EventTypeGatewayResponse)); err != nil {
+		t.Fatalf("record response: %v", err)
+	}
+
+	ld, err := e.Ledger().Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ld.Features["F027"]; got.Tokens() != 500 || got.Cost != 1.2 {
+		t.Errorf("expected spend recorded from gateway event, got %+v", got)
+	}
+
+	err = registry.Publish(req.ToHookEvent(hooks.EventTypeGatewayRequest))
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("expected request to be blocked, got %v", err)
+	}
+}
+
+func TestRegisterGatewayHooks_DisabledIsNoop(t *testing.T) {
+	registry := hooks.NewRegistry()
+	RegisterGatewayHooks(registry, NewEnforcer(nil, NewLedger(t.TempDir())))
+	if len(registry.GetEventTypes()) != 0 {
+		t.Error("disabled budgets must not subscribe handlers")
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if EstimateTokens("") != 0 {
+		t.Error("empty text should be 0 tokens")
+	}
+	if got := EstimateTokens("abcdefgh"); got != 2 {
+		t.Errorf("expected 2 tokens, got %d", got)
+	}
+	if got := EstimateTokens("abcde"); got != 2 {
+		t.Errorf("expected rounding up to 2 tokens, got %d", got)
+	}
+}
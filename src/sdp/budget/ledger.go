@@ -0,0 +1,175 @@
+package budget
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// LedgerPath is the persistent spend ledger, relative to the project root.
+const LedgerPath = ".sdp/budget-ledger.json"
+
+// Spend is the accumulated usage for one feature or workstream.
+type Spend struct {
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	Cost         float64   `json:"cost"`
+	Calls        int       `json:"calls"`
+	FirstAt      time.Time `json:"first_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Tokens returns input plus output tokens.
+func (s Spend) Tokens() int {
+	return s.InputTokens + s.OutputTokens
+}
+
+// LedgerData is the .sdp/budget-ledger.json schema.
+type LedgerData struct {
+	Version     int              `json:"version"`
+	Features    map[string]Spend `json:"features"`
+	Workstreams map[string]Spend `json:"workstreams"`
+}
+
+// Usage is one metered LLM call.
+type Usage struct {
+	FeatureID    string
+	WSID         string
+	InputTokens  int
+	OutputTokens int
+	Cost         float64
+	At           time.Time
+}
+
+// Ledger persists spend under .sdp/ so separate processes share one total.
+type Ledger struct {
+	path string
+}
+
+// NewLedger returns a ledger stored at projectRoot/.sdp/budget-ledger.json.
+func NewLedger(projectRoot string) *Ledger {
+	return &Ledger{path: filepath.Join(projectRoot, LedgerPath)}
+}
+
+// Path returns the ledger file path.
+func (l *Ledger) Path() string {
+	return l.path
+}
+
+// Load reads the ledger. A missing file yields an empty ledger.
+func (l *Ledger) Load() (*LedgerData, error) {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return newLedgerData(), nil
+		}
+		return nil, fmt.Errorf("read ledger: %w", err)
+	}
+	ld := newLedgerData()
+	if err := json.Unmarshal(data, ld); err != nil {
+		return nil, fmt.Errorf("parse ledger %s: %w", l.path, err)
+	}
+	if ld.Features == nil {
+		ld.Features = make(map[string]Spend)
+	}
+	if ld.Workstreams == nil {
+		ld.Workstreams = make(map[string]Spend)
+	}
+	return ld, nil
+}
+
+// Record adds usage to the feature and workstream totals.
+// The read-modify-write runs under an exclusive file lock and the file is
+// replaced atomically, so concurrent orchestrator and CI-loop processes do not lose spend.
+func (l *Ledger) Record(u Usage) error {
+	if u.FeatureID == "" && u.WSID == "" {
+		return nil
+	}
+	if u.At.IsZero() {
+		u.At = time.Now().UTC()
+	}
+	return l.update(func(ld *LedgerData) {
+		if u.FeatureID != "" {
+			ld.Features[u.FeatureID] = addUsage(ld.Features[u.FeatureID], u)
+		}
+		if u.WSID != "" {
+			ld.Workstreams[u.WSID] = addUsage(ld.Workstreams[u.WSID], u)
+		}
+	})
+}
+
+// Reset removes recorded spend for a feature and the given workstreams.
+// It takes the same lock as Record.
+func (l *Ledger) Reset(featureID string, wsIDs ...string) error {
+	return l.update(func(ld *LedgerData) {
+		delete(ld.Features, featureID)
+		for _, ws := range wsIDs {
+			delete(ld.Workstreams, ws)
+		}
+	})
+}
+
+// update applies change to the ledger under an exclusive file lock and
+// saves the result.
+func (l *Ledger) update(change func(*LedgerData)) error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("create ledger dir: %w", err)
+	}
+	lf, err := os.OpenFile(l.path+".lock", os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open ledger lock: %w", err)
+	}
+	defer lf.Close()
+	if err := filelock.Lock(lf); err != nil {
+		return fmt.Errorf("acquire ledger lock: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lf) }()
+
+	ld, err := l.Load()
+	if err != nil {
+		return err
+	}
+	change(ld)
+	return l.save(ld)
+}
+
+func (l *Ledger) save(ld *LedgerData) error {
+	data, err := json.MarshalIndent(ld, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func newLedgerData() *LedgerData {
+	return &LedgerData{
+		Version:     1,
+		Features:    make(map[string]Spend),
+		Workstreams: make(map[string]Spend),
+	}
+}
+
+func addUsage(s Spend, u Usage) Spend {
+	if s.FirstAt.IsZero() {
+		s.FirstAt = u.At
+	}
+	s.InputTokens += u.InputTokens
+	s.OutputTokens += u.OutputTokens
+	s.Cost += u.Cost
+	s.Calls++
+	s.UpdatedAt = u.At
+	return s
+}
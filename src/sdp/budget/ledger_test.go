@@ -0,0 +1,95 @@
+package budget
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestLedger_RecordAccumulates(t *testing.T) {
+	l := NewLedger(t.TempDir())
+
+	for range 3 {
+		if err := l.Record(Usage{FeatureID: "F027", WSID: "00-027-01", InputTokens: 100, OutputTokens: 50, Cost: 0.25}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ld, err := l.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := ld.Features["F027"]
+	if f.Tokens() != 450 || f.Calls != 3 || f.Cost != 0.75 {
+		t.Errorf("unexpected feature spend: %+v", f)
+	}
+	if ld.Workstreams["00-027-01"].Calls != 3 {
+		t.Errorf("unexpected workstream spend: %+v", ld.Workstreams["00-027-01"])
+	}
+	if f.FirstAt.IsZero() || f.UpdatedAt.Before(f.FirstAt) {
+		t.Errorf("timestamps not tracked: %+v", f)
+	}
+}
+
+func TestLedger_ConcurrentRecords(t *testing.T) {
+	root := t.TempDir()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			// Separate Ledger values mimic separate processes sharing the file.
+			if err := NewLedger(root).Record(Usage{FeatureID: "F027", InputTokens: 10}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	ld, err := NewLedger(root).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ld.Features["F027"].Calls; got != 10 {
+		t.Errorf("expected 10 calls recorded, got %d", got)
+	}
+}
+
+func TestLedger_Reset(t *testing.T) {
+	l := NewLedger(t.TempDir())
+	if err := l.Record(Usage{FeatureID: "F027", WSID: "00-027-01", Cost: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Reset("F027", "00-027-01"); err != nil {
+		t.Fatal(err)
+	}
+	ld, err := l.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ld.Features) != 0 || len(ld.Workstreams) != 0 {
+		t.Errorf("expected empty ledger after reset, got %+v", ld)
+	}
+}
+
+func TestLedger_ResetConcurrentWithRecords(t *testing.T) {
+	root := t.TempDir()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if err := NewLedger(root).Record(Usage{FeatureID: "F027", InputTokens: 10}); err != nil {
+				t.Error(err)
+			}
+		})
+		wg.Go(func() {
+			if err := NewLedger(root).Reset("F999"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	ld, err := NewLedger(root).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ld.Features["F027"].Calls; got != 10 {
+		t.Errorf("a reset of another feature lost spend: %d calls recorded, want 10", got)
+	}
+}
@@ -0,0 +1,350 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// Version is the unified store schema version. Version 1 is the per-engine
+// files (F{NNN}.json, {feature}-checkpoint.json, {id}.json) that predate it.
+const Version = 2
+
+// RunsDir is the store directory inside the checkpoint directory.
+const RunsDir = "runs"
+
+// HistoryLimit is how many previous revisions each run keeps. Older ones are
+// pruned on write.
+const HistoryLimit = 20
+
+// Engines that write sections.
+const (
+	EngineOrchestrate  = "orchestrate"  // internal/orchestrate state machine
+	EngineCILoop       = "ciloop"       // sdp-ci-loop
+	EngineGraph        = "graph"        // src/sdp/graph dispatcher
+	EngineOrchestrator = "orchestrator" // sdp CLI feature orchestrator
+)
+
+// Envelope is one run's state across every engine that touched it.
+type Envelope struct {
+	Version   int                `json:"version"`
+	RunID     string             `json:"run_id"`
+	FeatureID string             `json:"feature_id,omitempty"`
+	Revision  int                `json:"revision"`
+	Engine    string             `json:"engine"` // engine of the last write
+	UpdatedAt time.Time          `json:"updated_at"`
+	Sections  map[string]Section `json:"sections"`
+}
+
+// Section is one engine's checkpoint. File names the legacy file (relative to
+// the checkpoint directory) the engine keeps in sync for existing readers;
+// rollback rewrites it from Data.
+type Section struct {
+	File      string          `json:"file,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Store is the unified checkpoint store under {checkpointDir}/runs.
+// Each write keeps the previous revision under runs/{run}.history/, up to
+// HistoryLimit revisions per run.
+//
+// src/sdp/checkpoint/store.go is the source of truth. The sdp CLI is a
+// separate module with the same import path and cannot import it, so
+// sdp-plugin/internal/checkpoint/store.go is a byte-for-byte copy; the root
+// store tests fail when the two differ. Plugin-only operations (migration,
+// diff, clean) live in other files of the plugin package.
+type Store struct {
+	dir string // checkpoint directory (.sdp/checkpoints)
+}
+
+// NewStore returns a store for the given checkpoint directory.
+func NewStore(checkpointDir string) *Store {
+	return &Store{dir: checkpointDir}
+}
+
+var reRunID = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// ValidateRunID rejects IDs that are not safe file names.
+func ValidateRunID(runID string) error {
+	if !reRunID.MatchString(runID) || strings.Trim(runID, ".") == "" {
+		return fmt.Errorf("invalid run id %q", runID)
+	}
+	return nil
+}
+
+// Put stores v as the engine's section of the run and records the previous
+// revision in history. Writes are locked per run and atomic.
+func (s *Store) Put(runID, featureID, engine, file string, v any) error {
+	if err := ValidateRunID(runID); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal %s section: %w", engine, err)
+	}
+	_, err = s.update(runID, func(env *Envelope) {
+		if featureID != "" {
+			env.FeatureID = featureID
+		}
+		env.Engine = engine
+		env.Sections[engine] = Section{File: file, UpdatedAt: time.Now().UTC(), Data: data}
+	})
+	return err
+}
+
+// Load returns the current envelope, or nil when the run has none.
+func (s *Store) Load(runID string) (*Envelope, error) {
+	if err := ValidateRunID(runID); err != nil {
+		return nil, err
+	}
+	return readEnvelope(s.runPath(runID))
+}
+
+// Section decodes the engine's section into v. Returns false when absent.
+func (s *Store) Section(runID, engine string, v any) (bool, error) {
+	env, err := s.Load(runID)
+	if err != nil || env == nil {
+		return false, err
+	}
+	sec, ok := env.Sections[engine]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(sec.Data, v); err != nil {
+		return false, fmt.Errorf("decode %s section of %s: %w", engine, runID, err)
+	}
+	return true, nil
+}
+
+// Runs returns the current envelope of every run, most recently updated first.
+func (s *Store) Runs() ([]Envelope, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, RunsDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var out []Envelope
+	for _, path := range matches {
+		env, err := readEnvelope(path)
+		if err != nil {
+			return nil, err
+		}
+		if env != nil {
+			out = append(out, *env)
+		}
+	}
+	slices.SortFunc(out, func(a, b Envelope) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return out, nil
+}
+
+// History returns the stored revisions of a run, oldest first, excluding the current one.
+func (s *Store) History(runID string) ([]int, error) {
+	if err := ValidateRunID(runID); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(s.historyDir(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var revs []int
+	for _, e := range entries {
+		if rev, err := strconv.Atoi(strings.TrimSuffix(e.Name(), ".json")); err == nil {
+			revs = append(revs, rev)
+		}
+	}
+	slices.Sort(revs)
+	return revs, nil
+}
+
+// Revision returns the envelope at rev, which may be the current revision.
+func (s *Store) Revision(runID string, rev int) (*Envelope, error) {
+	cur, err := s.Load(runID)
+	if err != nil {
+		return nil, err
+	}
+	if cur != nil && cur.Revision == rev {
+		return cur, nil
+	}
+	env, err := readEnvelope(filepath.Join(s.historyDir(runID), strconv.Itoa(rev)+".json"))
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		return nil, fmt.Errorf("run %s has no revision %d", runID, rev)
+	}
+	return env, nil
+}
+
+// Rollback makes the sections of rev current again as a new revision and
+// rewrites the legacy files they name, so engines resume from that state.
+func (s *Store) Rollback(runID string, rev int) (*Envelope, error) {
+	target, err := s.Revision(runID, rev)
+	if err != nil {
+		return nil, err
+	}
+	env, err := s.update(runID, func(env *Envelope) {
+		env.Sections = target.Sections
+		env.Engine = "rollback"
+	})
+	if err != nil {
+		return nil, err
+	}
+	return env, ExportLegacy(s.dir, target)
+}
+
+// ExportLegacy rewrites the legacy file of every section in env. Sections that
+// share a file (orchestrate and ciloop both use F{NNN}.json) are merged
+// field by field, oldest first, so the newest write of each field wins.
+func ExportLegacy(checkpointDir string, env *Envelope) error {
+	byFile := make(map[string][]Section)
+	for _, sec := range env.Sections {
+		if sec.File != "" {
+			byFile[sec.File] = append(byFile[sec.File], sec)
+		}
+	}
+	for file, secs := range byFile {
+		slices.SortFunc(secs, func(a, b Section) int { return a.UpdatedAt.Compare(b.UpdatedAt) })
+		merged := make(map[string]json.RawMessage)
+		for _, sec := range secs {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(sec.Data, &fields); err != nil {
+				return fmt.Errorf("decode section for %s: %w", file, err)
+			}
+			for k, v := range fields {
+				merged[k] = v
+			}
+		}
+		data, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := writeAtomic(filepath.Join(checkpointDir, filepath.Base(file)), data); err != nil {
+			return fmt.Errorf("export %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// update runs fn on the current envelope under the run lock, archives the
+// previous revision and writes the next one.
+func (s *Store) update(runID string, fn func(*Envelope)) (*Envelope, error) {
+	runs := filepath.Join(s.dir, RunsDir)
+	if err := os.MkdirAll(runs, 0o755); err != nil {
+		return nil, fmt.Errorf("create checkpoint store: %w", err)
+	}
+	path := s.runPath(runID)
+	lf, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open checkpoint lock: %w", err)
+	}
+	defer lf.Close()
+	if err := filelock.Lock(lf); err != nil {
+		return nil, fmt.Errorf("acquire checkpoint lock: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lf) }()
+
+	env, err := readEnvelope(path)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		env = &Envelope{RunID: runID, Sections: make(map[string]Section)}
+	} else {
+		if err := os.MkdirAll(s.historyDir(runID), 0o755); err != nil {
+			return nil, fmt.Errorf("create checkpoint history: %w", err)
+		}
+		prev, err := json.MarshalIndent(env, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeAtomic(filepath.Join(s.historyDir(runID), strconv.Itoa(env.Revision)+".json"), prev); err != nil {
+			return nil, fmt.Errorf("archive revision %d: %w", env.Revision, err)
+		}
+		if _, err := s.prune(runID, HistoryLimit); err != nil {
+			return nil, fmt.Errorf("prune history: %w", err)
+		}
+	}
+
+	fn(env)
+	env.Version = Version
+	env.RunID = runID
+	env.Revision++
+	env.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeAtomic(path, data); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// prune removes all but the newest keep history revisions of a run.
+// Callers hold the run lock. Returns the number removed.
+func (s *Store) prune(runID string, keep int) (int, error) {
+	revs, err := s.History(runID)
+	if err != nil || len(revs) <= keep {
+		return 0, err
+	}
+	old := revs[:len(revs)-max(keep, 0)]
+	for _, rev := range old {
+		if err := os.Remove(filepath.Join(s.historyDir(runID), strconv.Itoa(rev)+".json")); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, err
+		}
+	}
+	return len(old), nil
+}
+
+func (s *Store) runPath(runID string) string {
+	return filepath.Join(s.dir, RunsDir, runID+".json")
+}
+
+func (s *Store) historyDir(runID string) string {
+	return filepath.Join(s.dir, RunsDir, runID+".history")
+}
+
+func readEnvelope(path string) (*Envelope, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint store: %w", err)
+	}
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("parse checkpoint store %s: %w", path, err)
+	}
+	if env.Version > Version {
+		return nil, fmt.Errorf("checkpoint store %s has version %d, newer than supported %d", path, env.Version, Version)
+	}
+	if env.Sections == nil {
+		env.Sections = make(map[string]Section)
+	}
+	return &env, nil
+}
+
+func writeAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
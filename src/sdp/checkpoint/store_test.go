@@ -0,0 +1,178 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type phaseState struct {
+	FeatureID string `json:"feature_id"`
+	Phase     string `json:"phase"`
+	PRNumber  int    `json:"pr_number,omitempty"`
+}
+
+func TestStore_PutKeepsSectionsAndHistory(t *testing.T) {
+	s := NewStore(t.TempDir())
+	if err := s.Put("F014", "F014", EngineOrchestrate, "F014.json", phaseState{"F014", "build", 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put("F014", "F014", EngineCILoop, "F014.json", phaseState{"F014", "ci", 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := s.Load("F014")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Version != Version || env.Revision != 2 || env.Engine != EngineCILoop || len(env.Sections) != 2 {
+		t.Fatalf("unexpected envelope: %+v", env)
+	}
+	var got phaseState
+	if ok, err := s.Section("F014", EngineOrchestrate, &got); !ok || err != nil || got.Phase != "build" {
+		t.Errorf("orchestrate section = %+v, %v, %v", got, ok, err)
+	}
+
+	revs, err := s.History("F014")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != 1 || revs[0] != 1 {
+		t.Errorf("expected history [1], got %v", revs)
+	}
+	prev, err := s.Revision("F014", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prev.Sections) != 1 {
+		t.Errorf("expected one section at revision 1, got %d", len(prev.Sections))
+	}
+}
+
+func TestStore_RollbackRewritesLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	if err := s.Put("F014", "F014", EngineOrchestrate, "F014.json", phaseState{"F014", "review", 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put("F014", "F014", EngineOrchestrate, "F014.json", phaseState{"F014", "done", 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := s.Rollback("F014", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Revision != 3 || env.Engine != "rollback" {
+		t.Errorf("expected rollback as revision 3, got %+v", env)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "F014.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var legacy phaseState
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if legacy.Phase != "review" {
+		t.Errorf("expected legacy file rolled back to review, got %q", legacy.Phase)
+	}
+}
+
+func TestExportLegacy_MergesSharedFileNewestWins(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	if err := s.Put("F014", "F014", EngineOrchestrate, "F014.json", map[string]any{"phase": "ci", "workstreams": []string{"00-014-01"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put("F014", "F014", EngineCILoop, "F014.json", map[string]any{"phase": "done", "pr_number": 7}); err != nil {
+		t.Fatal(err)
+	}
+	env, err := s.Load("F014")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ExportLegacy(dir, env); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "F014.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"phase": "done"`, `"pr_number": 7`, "00-014-01"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("merged file missing %s: %s", want, data)
+		}
+	}
+}
+
+func TestStore_RejectsUnsafeRunID(t *testing.T) {
+	s := NewStore(t.TempDir())
+	for _, id := range []string{"", "..", "../etc", "a/b"} {
+		if err := s.Put(id, "", EngineGraph, "", struct{}{}); err == nil {
+			t.Errorf("expected error for run id %q", id)
+		}
+	}
+}
+
+func TestStore_RunsAndNewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(dir)
+	if err := s.Put("F001", "F001", EngineGraph, "F001-checkpoint.json", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put("F002", "F002", EngineGraph, "F002-checkpoint.json", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+	runs, err := s.Runs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].RunID != "F002" {
+		t.Errorf("expected two runs newest first, got %+v", runs)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, RunsDir, "F003.json"), []byte(`{"version":99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load("F003"); err == nil {
+		t.Error("expected error for newer store version")
+	}
+}
+
+func TestStore_PrunesHistory(t *testing.T) {
+	s := NewStore(t.TempDir())
+	for i := range HistoryLimit + 5 {
+		if err := s.Put("F030", "F030", EngineOrchestrate, "F030.json", phaseState{"F030", "build", i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	revs, err := s.History("F030")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revs) != HistoryLimit || revs[0] != 5 || revs[len(revs)-1] != HistoryLimit+4 {
+		t.Errorf("expected the newest %d revisions, got %v", HistoryLimit, revs)
+	}
+}
+
+// TestStore_PluginCopyMatches keeps the sdp CLI copy of the store in sync.
+func TestStore_PluginCopyMatches(t *testing.T) {
+	plugin, err := os.ReadFile(filepath.Join("..", "..", "..", "sdp-plugin", "internal", "checkpoint", "store.go"))
+	if os.IsNotExist(err) {
+		t.Skip("sdp-plugin not checked out")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := os.ReadFile("store.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(root, plugin) {
+		t.Error("sdp-plugin/internal/checkpoint/store.go differs from src/sdp/checkpoint/store.go; copy the change over")
+	}
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/src/sdp/synthesis"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultPanelConfigPath is where design panel agents are configured.
+const defaultPanelConfigPath = ".sdp/design-panel.yaml"
+
+var designCmd = &cobra.Command{
+	Use:   "design",
+	Short: "Resolve design decisions",
+	Long: `Resolve design decisions.
+
+With --panel, several configured agents/models each propose an answer to the
+question. Their proposals are resolved by the synthesis rule engine
+(unanimous, weighted voting, confidence threshold, escalate to human) and
+the outcome is recorded as a decision event with alternatives and dissent.`,
+	RunE: runDesign,
+}
+
+// Design flags
+var (
+	designPanel    bool
+	designQuestion string
+	designOptions  []string
+	designContext  string
+	designConfig   string
+	designWSID     string
+	designTags     []string
+	designJSON     bool
+)
+
+// PanelConfig is the schema for .sdp/design-panel.yaml.
+type PanelConfig struct {
+	Agents              []PanelAgentConfig `yaml:"agents"`
+	Quorum              float64            `yaml:"quorum"`
+	ConfidenceThreshold float64            `yaml:"confidence_threshold"`
+	TimeoutSeconds      int                `yaml:"timeout_seconds"`
+}
+
+// PanelAgentConfig configures one panel member.
+type PanelAgentConfig struct {
+	ID      string   `yaml:"id"`
+	Command []string `yaml:"command"`
+	Weight  float64  `yaml:"weight"`
+}
+
+func init() {
+	designCmd.Flags().BoolVar(&designPanel, "panel", false, "Ask the configured agent panel for proposals")
+	designCmd.Flags().StringVarP(&designQuestion, "question", "q", "", "Design question (required)")
+	designCmd.Flags().StringSliceVar(&designOptions, "option", []string{}, "Candidate option (repeatable)")
+	designCmd.Flags().StringVar(&designContext, "context", "", "Path to a file with background context")
+	designCmd.Flags().StringVar(&designConfig, "config", defaultPanelConfigPath, "Panel configuration file")
+	designCmd.Flags().StringVar(&designWSID, "ws", "00-000-00", "Workstream ID for the decision event")
+	designCmd.Flags().StringSliceVar(&designTags, "tag", []string{}, "Decision tag (repeatable)")
+	designCmd.Flags().BoolVar(&designJSON, "json", false, "Print the panel decision as JSON")
+}
+
+// RegisterDesignCommand registers the design command with root
+func RegisterDesignCommand(rootCmd *cobra.Command) {
+	rootCmd.AddCommand(designCmd)
+}
+
+func runDesign(cmd *cobra.Command, args []string) error {
+	if !designPanel {
+		return cmd.Help()
+	}
+	if strings.TrimSpace(designQuestion) == "" {
+		return fmt.Errorf("--question is required with --panel")
+	}
+
+	cfg, err := LoadPanelConfig(designConfig)
+	if err != nil {
+		return err
+	}
+
+	question := synthesis.DesignQuestion{
+		Question: designQuestion,
+		Options:  designOptions,
+	}
+	if designContext != "" {
+		content, err := os.ReadFile(designContext)
+		if err != nil {
+			return fmt.Errorf("failed to read context: %w", err)
+		}
+		question.Context = string(content)
+	}
+
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to resolve working directory: %w", err)
+	}
+
+	decision, err := runPanel(cfg, question, projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if err := recordPanelDecision(projectRoot, designWSID, designTags, decision); err != nil {
+		return fmt.Errorf("failed to record decision: %w", err)
+	}
+
+	if designJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(decision)
+	}
+	printPanelDecision(cmd, decision)
+	return nil
+}
+
+// LoadPanelConfig reads and validates a design panel configuration
+func LoadPanelConfig(path string) (*PanelConfig, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read panel config: %w", err)
+	}
+
+	var cfg PanelConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse panel config: %w", err)
+	}
+
+	if len(cfg.Agents) < 2 {
+		return nil, fmt.Errorf("panel config %s: at least 2 agents required, got %d", path, len(cfg.Agents))
+	}
+	for i, a := range cfg.Agents {
+		if a.ID == "" || len(a.Command) == 0 {
+			return nil, fmt.Errorf("panel config %s: agent %d needs id and command", path, i)
+		}
+		if a.Weight < 0 {
+			return nil, fmt.Errorf("panel config %s: agent %s has negative weight", path, a.ID)
+		}
+	}
+	if cfg.ConfidenceThreshold == 0 {
+		cfg.ConfidenceThreshold = 0.8
+	}
+	if cfg.TimeoutSeconds == 0 {
+		cfg.TimeoutSeconds = 120
+	}
+
+	return &cfg, nil
+}
+
+// runPanel builds command agents from cfg and deliberates on question
+func runPanel(cfg *PanelConfig, question synthesis.DesignQuestion, dir string) (*synthesis.PanelDecision, error) {
+	weights := make(map[string]float64, len(cfg.Agents))
+	agents := make([]synthesis.Agent, 0, len(cfg.Agents))
+	for _, a := range cfg.Agents {
+		if a.Weight > 0 {
+			weights[a.ID] = a.Weight
+		}
+		agents = append(agents, synthesis.NewCommandAgent(a.ID, a.Command, dir))
+	}
+
+	engine := synthesis.PanelRuleEngine(weights, cfg.Quorum, cfg.ConfidenceThreshold)
+	panel := synthesis.NewPanel(engine, agents...)
+	panel.SetTimeout(time.Duration(cfg.TimeoutSeconds) * time.Second)
+
+	return panel.Deliberate(question)
+}
+
+// recordPanelDecision appends the panel outcome to the evidence log as a decision event
+func recordPanelDecision(projectRoot, wsID string, tags []string, d *synthesis.PanelDecision) error {
+	choice := d.Choice
+	rationale := d.Rationale
+	if d.Escalated() {
+		choice = "escalated"
+	}
+	if len(d.Dissent) > 0 {
+		parts := make([]string, 0, len(d.Dissent))
+		for _, ds := range d.Dissent {
+			parts = append(parts, fmt.Sprintf("%s preferred %q (%.2f)", ds.AgentID, ds.Choice, ds.Confidence))
+		}
+		rationale += ". Dissent: " + strings.Join(parts, "; ")
+	}
+
+	return appendEvidenceEvent(projectRoot, &evidenceEvent{
+		Type: "decision",
+		WSID: wsID,
+		Data: decisionEventData{
+			Question:     d.Question,
+			Choice:       choice,
+			Rationale:    rationale,
+			Alternatives: d.Alternatives,
+			Confidence:   d.Confidence,
+			Tags:         append([]string{"design-panel"}, tags...),
+		},
+	})
+}
+
+func printPanelDecision(cmd *cobra.Command, d *synthesis.PanelDecision) {
+	out := cmd.OutOrStdout()
+	if d.Escalated() {
+		fmt.Fprintf(out, "⚠️  Panel could not agree, escalated to human\n")
+	} else {
+		fmt.Fprintf(out, "✓ Decision: %s\n", d.Choice)
+		fmt.Fprintf(out, "  Rule: %s\n", d.Rule)
+		if d.WinningAgent != "" {
+			fmt.Fprintf(out, "  Winning agent: %s\n", d.WinningAgent)
+		}
+		fmt.Fprintf(out, "  Confidence: %.2f\n", d.Confidence)
+	}
+	fmt.Fprintf(out, "  Rationale: %s\n", d.Rationale)
+	for _, alt := range d.Alternatives {
+		fmt.Fprintf(out, "  Alternative: %s\n", alt)
+	}
+	for _, ds := range d.Dissent {
+		fmt.Fprintf(out, "  Dissent: %s preferred %q (%.2f)\n", ds.AgentID, ds.Choice, ds.Confidence)
+	}
+}
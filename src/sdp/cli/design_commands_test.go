@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/fall-out-bug/sdp/src/sdp/synthesis"
+)
+
+// TestDesignCmd_FlagParsing verifies design command flags
+func TestDesignCmd_FlagParsing(t *testing.T) {
+	for _, name := range []string{"panel", "question", "option", "config", "ws"} {
+		if designCmd.Flag(name) == nil {
+			t.Errorf("flag %q not found", name)
+		}
+	}
+}
+
+// TestLoadPanelConfig verifies config parsing and defaults
+func TestLoadPanelConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "design-panel.yaml")
+	content := `agents:
+  - id: architect
+    command: ["claude", "-p"]
+    weight: 2
+  - id: reviewer
+    command: ["opencode", "run"]
+quorum: 0.6
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadPanelConfig(path)
+	if err != nil {
+		t.Fatalf("LoadPanelConfig failed: %v", err)
+	}
+	if len(cfg.Agents) != 2 || cfg.Agents[0].Weight != 2 {
+		t.Errorf("unexpected agents: %+v", cfg.Agents)
+	}
+	if cfg.ConfidenceThreshold != 0.8 || cfg.TimeoutSeconds != 120 {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+}
+
+// TestLoadPanelConfig_TooFewAgents verifies a single agent is rejected
+func TestLoadPanelConfig_TooFewAgents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "design-panel.yaml")
+	if err := os.WriteFile(path, []byte("agents:\n  - id: solo\n    command: [echo]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPanelConfig(path); err == nil {
+		t.Error("expected error for a one-agent panel")
+	}
+}
+
+// TestRecordPanelDecision_HashChain verifies decision events chain prev_hash
+func TestRecordPanelDecision_HashChain(t *testing.T) {
+	root := t.TempDir()
+	d := &synthesis.PanelDecision{
+		Question:     "Storage?",
+		Status:       "approved",
+		Choice:       "postgres",
+		Rationale:    "Solution holds 70% of the weighted vote",
+		Alternatives: []string{"sqlite"},
+		Dissent:      []synthesis.Dissent{{AgentID: "sre", Choice: "sqlite", Confidence: 0.9}},
+		Confidence:   0.8,
+	}
+
+	for range 2 {
+		if err := recordPanelDecision(root, "00-026-01", []string{"storage"}, d); err != nil {
+			t.Fatalf("recordPanelDecision failed: %v", err)
+		}
+	}
+
+	f, err := os.Open(filepath.Join(root, evidenceLogPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(lines))
+	}
+
+	var first, second struct {
+		Type     string            `json:"type"`
+		PrevHash string            `json:"prev_hash"`
+		Data     decisionEventData `json:"data"`
+	}
+	if err := json.Unmarshal(lines[0], &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(lines[1], &second); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Type != "decision" || first.PrevHash != "genesis" {
+		t.Errorf("unexpected first event: %+v", first)
+	}
+	sum := sha256.Sum256(lines[0])
+	if second.PrevHash != hex.EncodeToString(sum[:]) {
+		t.Error("second event does not chain to the first")
+	}
+	if first.Data.Choice != "postgres" || len(first.Data.Alternatives) != 1 {
+		t.Errorf("unexpected decision data: %+v", first.Data)
+	}
+	if len(first.Data.Tags) != 2 || first.Data.Tags[0] != "design-panel" {
+		t.Errorf("expected design-panel tag first, got %v", first.Data.Tags)
+	}
+}
+
+// TestAppendEvidenceEvent_Concurrent verifies parallel writers keep the chain intact
+func TestAppendEvidenceEvent_Concurrent(t *testing.T) {
+	root := t.TempDir()
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := appendEvidenceEvent(root, &evidenceEvent{Type: "decision", WSID: "00-026-01"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(filepath.Join(root, evidenceLogPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prev := "genesis"
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	n := 0
+	for scanner.Scan() {
+		var ev evidenceEvent
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			t.Fatal(err)
+		}
+		if ev.PrevHash != prev {
+			t.Fatalf("event %d: prev_hash %s, want %s", n, ev.PrevHash, prev)
+		}
+		sum := sha256.Sum256(scanner.Bytes())
+		prev = hex.EncodeToString(sum[:])
+		n++
+	}
+	if n != 20 {
+		t.Errorf("expected 20 events, got %d", n)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// evidenceLogPath is the hash-chained evidence log shared with the sdp CLI.
+const evidenceLogPath = ".sdp/log/events.jsonl"
+
+// evidenceEvent mirrors the sdp-plugin evidence.Event schema.
+type evidenceEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	WSID      string `json:"ws_id"`
+	PrevHash  string `json:"prev_hash,omitempty"`
+	Data      any    `json:"data,omitempty"`
+}
+
+// decisionEventData mirrors the sdp-plugin evidence.DecisionEventData schema.
+type decisionEventData struct {
+	Question     string   `json:"question"`
+	Choice       string   `json:"choice"`
+	Rationale    string   `json:"rationale"`
+	Alternatives []string `json:"alternatives,omitempty"`
+	Confidence   float64  `json:"confidence,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+// appendEvidenceEvent appends ev to the evidence log at projectRoot, chaining
+// prev_hash to the sha256 of the last line exactly like the sdp CLI writer.
+// It takes the same events.jsonl.lock flock as that writer, so the chain
+// stays intact when both append at once.
+func appendEvidenceEvent(projectRoot string, ev *evidenceEvent) error {
+	path := filepath.Join(projectRoot, evidenceLogPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create log dir: %w", err)
+	}
+
+	lf, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open lock file: %w", err)
+	}
+	defer lf.Close()
+	if err := filelock.Lock(lf); err != nil {
+		return fmt.Errorf("acquire file lock: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lf) }()
+
+	if ev.ID == "" {
+		ev.ID = "evt-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	if ev.Timestamp == "" {
+		ev.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	ev.PrevHash = "genesis"
+	if b, err := os.ReadFile(path); err == nil {
+		if last := lastLine(b); len(last) > 0 {
+			sum := sha256.Sum256(last)
+			ev.PrevHash = hex.EncodeToString(sum[:])
+		}
+	}
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open evidence log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write evidence log: %w", err)
+	}
+	return f.Sync()
+}
+
+// lastLine returns the last non-empty line of b without its newline
+func lastLine(b []byte) []byte {
+	b = bytes.TrimRight(b, "\n")
+	if i := bytes.LastIndexByte(b, '\n'); i >= 0 {
+		return b[i+1:]
+	}
+	return b
+}
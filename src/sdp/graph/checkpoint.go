@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	cpstore "github.com/fall-out-bug/sdp/src/sdp/checkpoint"
 )
 
 // CheckpointManager manages atomic checkpoint persistence
@@ -42,7 +44,8 @@ func (cm *CheckpointManager) GetTempPath() string {
 }
 
 // Save writes the checkpoint to disk atomically
-// Algorithm: write to temp file -> fsync -> atomic rename
+// Algorithm: write to temp file -> fsync -> atomic rename -> record graph
+// section in the unified checkpoint store
 func (cm *CheckpointManager) Save(checkpoint *Checkpoint) error {
 	// Ensure checkpoint directory exists
 	if err := os.MkdirAll(cm.checkpointDir, 0755); err != nil {
@@ -77,6 +80,12 @@ func (cm *CheckpointManager) Save(checkpoint *Checkpoint) error {
 		return fmt.Errorf("failed to rename temp file: %w", err)
 	}
 
+	// Step 4: Record in the unified store (history, diff, rollback)
+	store := cpstore.NewStore(cm.checkpointDir)
+	if err := store.Put(cm.featureID, checkpoint.FeatureID, cpstore.EngineGraph, filepath.Base(finalPath), checkpoint); err != nil {
+		return fmt.Errorf("failed to store checkpoint: %w", err)
+	}
+
 	return nil
 }
 
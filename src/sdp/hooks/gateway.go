@@ -21,6 +21,8 @@ type GatewayEvent struct {
 	Latency      time.Duration // API call duration
 	Cost         float64       // Calculated cost in dollars
 	Error        error         // Error if call failed
+	FeatureID    string        // Feature the call is billed to (optional)
+	WSID         string        // Workstream the call is billed to (optional)
 }
 
 // NewGatewayEvent creates a new gateway event.
@@ -36,6 +38,13 @@ func NewGatewayEvent(model, promptHash string, inputTokens, outputTokens int, la
 	}
 }
 
+// WithScope returns a copy of the event billed to the given feature and workstream.
+func (e GatewayEvent) WithScope(featureID, wsID string) GatewayEvent {
+	e.FeatureID = featureID
+	e.WSID = wsID
+	return e
+}
+
 // ToHookEvent converts a GatewayEvent to a HookEvent.
 func (e GatewayEvent) ToHookEvent(eventType string) HookEvent {
 	return NewEvent(eventType, map[string]any{
@@ -46,6 +55,8 @@ func (e GatewayEvent) ToHookEvent(eventType string) HookEvent {
 		"latency":       e.Latency,
 		"cost":          e.Cost,
 		"error":         e.Error,
+		"feature_id":    e.FeatureID,
+		"ws_id":         e.WSID,
 	})
 }
 
@@ -69,12 +80,21 @@ func (e GatewayEvent) CostPerToken() float64 {
 	return e.Cost / float64(total)
 }
 
+// rateLimitMarkers are lower-case substrings providers and CLIs use for rate limiting.
+var rateLimitMarkers = []string{"rate limit", "rate_limit", "ratelimit", "too many requests", "429", "overloaded"}
+
 // IsRateLimited returns true if the error indicates rate limiting.
 func (e GatewayEvent) IsRateLimited() bool {
 	if e.Error == nil {
 		return false
 	}
-	return strings.Contains(e.Error.Error(), "rate limit")
+	msg := strings.ToLower(e.Error.Error())
+	for _, marker := range rateLimitMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
 }
 
 // RegisterGatewayHooks registers default handlers for gateway lifecycle events.
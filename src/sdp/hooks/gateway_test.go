@@ -91,6 +91,19 @@ func TestGatewayEvent_ToHookEvent(t *testing.T) {
 	}
 }
 
+func TestGatewayEvent_WithScope(t *testing.T) {
+	base := NewGatewayEvent("claude-sonnet", "h", 10, 5, time.Second, 0.01, nil)
+	scoped := base.WithScope("F027", "00-027-01")
+
+	if base.FeatureID != "" || base.WSID != "" {
+		t.Error("WithScope must not modify the original event")
+	}
+	hookEvent := scoped.ToHookEvent(EventTypeGatewayResponse)
+	if hookEvent.Payload["feature_id"] != "F027" || hookEvent.Payload["ws_id"] != "00-027-01" {
+		t.Errorf("expected scope in payload, got %v", hookEvent.Payload)
+	}
+}
+
 func TestRegisterGatewayHooks(t *testing.T) {
 	registry := NewRegistry()
 	RegisterGatewayHooks(registry)
@@ -233,6 +246,9 @@ func TestGatewayEvent_IsRateLimited(t *testing.T) {
 		expected bool
 	}{
 		{"rate limit error", errors.New("rate limit exceeded"), true},
+		{"http 429", errors.New("API error 429: Too Many Requests"), true},
+		{"provider code", errors.New(`{"type":"rate_limit_error"}`), true},
+		{"capitalised", errors.New("Rate Limit reached"), true},
 		{"other error", errors.New("network error"), false},
 		{"no error", nil, false},
 	}
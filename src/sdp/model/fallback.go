@@ -12,17 +12,39 @@ var ErrAllModelsFailed = errors.New("all models in fallback chain failed")
 
 // FallbackStats tracks fallback statistics
 type FallbackStats struct {
-	TotalCalls   int64
-	Fallbacks    int64
-	Successes    int64
-	ModelErrors  map[string]int64
-	mu           sync.Mutex
+	TotalCalls     int64
+	Fallbacks      int64
+	Successes      int64
+	ModelErrors    map[string]int64
+	ModelCalls     map[string]int64 // attempts per model
+	ModelSuccesses map[string]int64 // successful attempts per model
+	ModelFallbacks map[string]int64 // errors that moved the chain past the model
+	mu             sync.Mutex
+}
+
+// ModelStats is a per-model snapshot of FallbackStats.
+type ModelStats struct {
+	Calls     int64 `json:"calls"`
+	Successes int64 `json:"successes"`
+	Errors    int64 `json:"errors"`
+	Fallbacks int64 `json:"fallbacks"`
+}
+
+// FallbackRate returns the share of calls to the model that fell back to the next one.
+func (m ModelStats) FallbackRate() float64 {
+	if m.Calls == 0 {
+		return 0
+	}
+	return float64(m.Fallbacks) / float64(m.Calls)
 }
 
 // NewFallbackStats creates new stats
 func NewFallbackStats() *FallbackStats {
 	return &FallbackStats{
-		ModelErrors: make(map[string]int64),
+		ModelErrors:    make(map[string]int64),
+		ModelCalls:     make(map[string]int64),
+		ModelSuccesses: make(map[string]int64),
+		ModelFallbacks: make(map[string]int64),
 	}
 }
 
@@ -48,6 +70,45 @@ func (s *FallbackStats) RecordError(model string) {
 	s.ModelErrors[model]++
 }
 
+// RecordModelCall records an attempt against a model
+func (s *FallbackStats) RecordModelCall(model string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ModelCalls[model]++
+}
+
+// RecordModelSuccess records a successful attempt against a model
+func (s *FallbackStats) RecordModelSuccess(model string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ModelSuccesses[model]++
+}
+
+// RecordModelFallback records that a model's error moved the chain to the next model
+func (s *FallbackStats) RecordModelFallback(model string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ModelFallbacks[model]++
+}
+
+// Models returns a per-model snapshot
+func (s *FallbackStats) Models() map[string]ModelStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]ModelStats)
+	for _, counts := range []map[string]int64{s.ModelCalls, s.ModelSuccesses, s.ModelErrors, s.ModelFallbacks} {
+		for m := range counts {
+			out[m] = ModelStats{
+				Calls:     s.ModelCalls[m],
+				Successes: s.ModelSuccesses[m],
+				Errors:    s.ModelErrors[m],
+				Fallbacks: s.ModelFallbacks[m],
+			}
+		}
+	}
+	return out
+}
+
 // GetStats returns current statistics
 func (s *FallbackStats) GetStats() (total, fallbacks, successes int64) {
 	return atomic.LoadInt64(&s.TotalCalls),
@@ -57,11 +118,12 @@ func (s *FallbackStats) GetStats() (total, fallbacks, successes int64) {
 
 // FallbackChain defines a chain of models to try
 type FallbackChain struct {
-	primary   string
-	fallbacks []string
-	stats     *FallbackStats
-	router    *Router
-	costAware bool
+	primary        string
+	fallbacks      []string
+	stats          *FallbackStats
+	router         *Router
+	costAware      bool
+	shouldFallback func(error) bool
 }
 
 // NewFallbackChain creates a new fallback chain
@@ -80,6 +142,13 @@ func (f *FallbackChain) SetCostAware(enabled bool) {
 	f.costAware = enabled
 }
 
+// SetFallbackOn restricts fallback to errors for which shouldFallback returns true
+// (e.g. rate limits). Other errors are returned immediately without trying the
+// next model. A nil function falls back on every error, which is the default.
+func (f *FallbackChain) SetFallbackOn(shouldFallback func(error) bool) {
+	f.shouldFallback = shouldFallback
+}
+
 // Execute runs the function with fallback support
 func (f *FallbackChain) Execute(ctx context.Context, fn func(model string) error) error {
 	f.stats.RecordCall()
@@ -98,14 +167,22 @@ func (f *FallbackChain) Execute(ctx context.Context, fn func(model string) error
 			f.stats.RecordFallback()
 		}
 
+		f.stats.RecordModelCall(model)
 		err := fn(model)
 		if err == nil {
 			f.stats.RecordSuccess()
+			f.stats.RecordModelSuccess(model)
 			return nil
 		}
 
 		f.stats.RecordError(model)
 		lastErr = err
+		if f.shouldFallback != nil && !f.shouldFallback(err) {
+			return err
+		}
+		if i < len(models)-1 {
+			f.stats.RecordModelFallback(model)
+		}
 
 		// Check if context cancelled
 		select {
@@ -187,3 +187,40 @@ func TestFallbackStats(t *testing.T) {
 		t.Errorf("Expected 2 errors for model1, got %d", stats.ModelErrors["model1"])
 	}
 }
+
+func TestFallbackChain_FallbackOnFilter(t *testing.T) {
+	fc := NewFallbackChain("balanced", []string{"fast"}, NewRouter())
+	fc.SetFallbackOn(func(err error) bool { return err.Error() == "rate limit" })
+
+	var calls []string
+	err := fc.Execute(context.Background(), func(model string) error {
+		calls = append(calls, model)
+		return errors.New("compile error")
+	})
+	if err == nil || errors.Is(err, ErrAllModelsFailed) {
+		t.Errorf("expected non-retryable error returned as-is, got %v", err)
+	}
+	if len(calls) != 1 {
+		t.Errorf("expected no fallback on non-retryable error, got %v", calls)
+	}
+
+	calls = nil
+	err = fc.Execute(context.Background(), func(model string) error {
+		calls = append(calls, model)
+		if model == "balanced" {
+			return errors.New("rate limit")
+		}
+		return nil
+	})
+	if err != nil || len(calls) != 2 {
+		t.Errorf("expected fallback to fast on rate limit, got err=%v calls=%v", err, calls)
+	}
+
+	models := fc.GetStats().Models()
+	if got := models["balanced"]; got.Calls != 2 || got.Fallbacks != 1 || got.Errors != 2 {
+		t.Errorf("unexpected balanced stats: %+v", got)
+	}
+	if got := models["fast"]; got.Calls != 1 || got.Successes != 1 {
+		t.Errorf("unexpected fast stats: %+v", got)
+	}
+}
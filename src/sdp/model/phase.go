@@ -0,0 +1,62 @@
+package model
+
+import "strings"
+
+// Orchestrated phases that invoke an LLM.
+const (
+	PhaseBuild  = "build"
+	PhaseReview = "review"
+	PhaseCIFix  = "ci-fix"
+)
+
+// TaskTypeForPhase maps an orchestrator phase to a routing task type.
+func TaskTypeForPhase(phase string) string {
+	switch phase {
+	case PhaseBuild:
+		return "code"
+	case PhaseReview:
+		return "review"
+	case PhaseCIFix:
+		return "debug"
+	default:
+		return phase
+	}
+}
+
+// ComplexityForSize maps a workstream size (S/M/L) to routing complexity (0-10).
+// Unknown or empty sizes map to the mid-point.
+func ComplexityForSize(size string) int {
+	switch strings.ToUpper(strings.TrimSpace(size)) {
+	case "XS":
+		return 1
+	case "S", "SMALL":
+		return 3
+	case "L", "LARGE":
+		return 8
+	case "XL":
+		return 10
+	default:
+		return 5
+	}
+}
+
+// SelectForPhase selects the profile for an orchestrator phase and workstream size.
+func (r *Router) SelectForPhase(phase, size string) Profile {
+	return r.SelectModel(TaskTypeForPhase(phase), ComplexityForSize(size))
+}
+
+// ChainForPhase returns a fallback chain whose primary is the routed profile for
+// the phase and size, followed by the default chain for the phase's task type.
+func ChainForPhase(r *Router, phase, size string) *FallbackChain {
+	taskType := TaskTypeForPhase(phase)
+	primary := r.SelectForPhase(phase, size).Name
+	var fallbacks []string
+	if def, ok := DefaultChains(r)[taskType]; ok {
+		for _, name := range append([]string{def.GetPrimary()}, def.GetFallbacks()...) {
+			if name != primary {
+				fallbacks = append(fallbacks, name)
+			}
+		}
+	}
+	return NewFallbackChain(primary, fallbacks, r)
+}
@@ -0,0 +1,54 @@
+package model
+
+import "testing"
+
+func TestComplexityForSize(t *testing.T) {
+	tests := []struct {
+		size string
+		want int
+	}{
+		{"S", 3},
+		{"small", 3},
+		{"M", 5},
+		{"", 5},
+		{"L", 8},
+		{"unknown", 5},
+	}
+	for _, tt := range tests {
+		if got := ComplexityForSize(tt.size); got != tt.want {
+			t.Errorf("ComplexityForSize(%q) = %d, want %d", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestRouter_SelectForPhase(t *testing.T) {
+	r := NewRouter()
+	if got := r.SelectForPhase(PhaseBuild, "S").Name; got != "balanced" {
+		t.Errorf("build expected balanced, got %s", got)
+	}
+	if got := r.SelectForPhase(PhaseReview, "L").Name; got != "quality" {
+		t.Errorf("review expected quality, got %s", got)
+	}
+	if got := r.SelectForPhase(PhaseCIFix, "M").Name; got != "fast" {
+		t.Errorf("ci-fix expected fast, got %s", got)
+	}
+}
+
+func TestChainForPhase_RoutedPrimaryThenDefaults(t *testing.T) {
+	r := NewRouter()
+	r.AddRule(RoutingRule{TaskType: "code", MinComplex: 7, MaxComplex: 10, Profile: "quality", Priority: 20})
+
+	chain := ChainForPhase(r, PhaseBuild, "L")
+	if chain.GetPrimary() != "quality" {
+		t.Fatalf("expected routed primary quality for large build, got %s", chain.GetPrimary())
+	}
+	want := []string{"balanced", "fast"}
+	got := chain.GetFallbacks()
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected fallbacks %v without the primary, got %v", want, got)
+	}
+
+	if small := ChainForPhase(r, PhaseBuild, "S"); small.GetPrimary() != "balanced" {
+		t.Errorf("expected balanced primary for small build, got %s", small.GetPrimary())
+	}
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// StatsPath is the persisted fallback statistics file, relative to the project root.
+const StatsPath = ".sdp/model-stats.json"
+
+// StatsData is the .sdp/model-stats.json schema. Models are keyed by profile name.
+type StatsData struct {
+	Version    int                   `json:"version"`
+	TotalCalls int64                 `json:"total_calls"`
+	Fallbacks  int64                 `json:"fallbacks"`
+	Successes  int64                 `json:"successes"`
+	Models     map[string]ModelStats `json:"models"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// StatsStore accumulates FallbackStats across processes under .sdp/.
+type StatsStore struct {
+	path string
+}
+
+// NewStatsStore returns a store at projectRoot/.sdp/model-stats.json.
+func NewStatsStore(projectRoot string) *StatsStore {
+	return &StatsStore{path: filepath.Join(projectRoot, StatsPath)}
+}
+
+// Load reads the persisted stats. A missing file yields empty stats.
+func (s *StatsStore) Load() (*StatsData, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &StatsData{Version: 1, Models: make(map[string]ModelStats)}, nil
+		}
+		return nil, fmt.Errorf("read model stats: %w", err)
+	}
+	sd := &StatsData{}
+	if err := json.Unmarshal(data, sd); err != nil {
+		return nil, fmt.Errorf("parse model stats %s: %w", s.path, err)
+	}
+	if sd.Models == nil {
+		sd.Models = make(map[string]ModelStats)
+	}
+	return sd, nil
+}
+
+// Add merges stats into the persisted totals under an exclusive file lock.
+// Callers pass stats collected since the last Add (e.g. one chain per call).
+func (s *StatsStore) Add(stats *FallbackStats) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create model stats dir: %w", err)
+	}
+	lf, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open model stats lock: %w", err)
+	}
+	defer lf.Close()
+	if err := filelock.Lock(lf); err != nil {
+		return fmt.Errorf("acquire model stats lock: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lf) }()
+
+	sd, err := s.Load()
+	if err != nil {
+		return err
+	}
+	total, fallbacks, successes := stats.GetStats()
+	sd.TotalCalls += total
+	sd.Fallbacks += fallbacks
+	sd.Successes += successes
+	for name, m := range stats.Models() {
+		cur := sd.Models[name]
+		cur.Calls += m.Calls
+		cur.Successes += m.Successes
+		cur.Errors += m.Errors
+		cur.Fallbacks += m.Fallbacks
+		sd.Models[name] = cur
+	}
+	sd.Version = 1
+	sd.UpdatedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(sd, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
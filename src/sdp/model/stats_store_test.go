@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestStatsStore_AddAccumulates(t *testing.T) {
+	root := t.TempDir()
+	store := NewStatsStore(root)
+
+	for range 2 {
+		fc := NewFallbackChain("quality", []string{"balanced"}, NewRouter())
+		_ = fc.Execute(context.Background(), func(model string) error {
+			if model == "quality" {
+				return errors.New("rate limit exceeded")
+			}
+			return nil
+		})
+		if err := store.Add(fc.GetStats()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sd, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sd.TotalCalls != 2 || sd.Fallbacks != 2 || sd.Successes != 2 {
+		t.Errorf("unexpected totals: %+v", sd)
+	}
+	q := sd.Models["quality"]
+	if q.Calls != 2 || q.Errors != 2 || q.Fallbacks != 2 || q.FallbackRate() != 1 {
+		t.Errorf("unexpected quality stats: %+v", q)
+	}
+	if b := sd.Models["balanced"]; b.Calls != 2 || b.Successes != 2 || b.FallbackRate() != 0 {
+		t.Errorf("unexpected balanced stats: %+v", b)
+	}
+}
+
+func TestStatsStore_ConcurrentAdds(t *testing.T) {
+	root := t.TempDir()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			stats := NewFallbackStats()
+			stats.RecordCall()
+			stats.RecordModelCall("fast")
+			if err := NewStatsStore(root).Add(stats); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	sd, err := NewStatsStore(root).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sd.TotalCalls != 10 || sd.Models["fast"].Calls != 10 {
+		t.Errorf("expected 10 calls recorded, got %+v", sd)
+	}
+}
@@ -3,8 +3,11 @@ package runtime
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/fall-out-bug/sdp/src/sdp/state"
 )
 
 // CircuitState represents the state of a circuit breaker
@@ -52,6 +55,9 @@ type CircuitBreaker struct {
 	failures     int
 	successes    int
 	lastFailTime time.Time
+
+	store  *state.Store // optional; shares state across processes
+	target string
 }
 
 // NewCircuitBreaker creates a new circuit breaker
@@ -62,17 +68,93 @@ func NewCircuitBreaker(config CircuitConfig) *CircuitBreaker {
 	}
 }
 
+// Persist shares the breaker's state for target through store, so separate
+// processes and parallel workers see the same open circuit. Returns cb.
+func (cb *CircuitBreaker) Persist(store *state.Store, target string) *CircuitBreaker {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.store = store
+	cb.target = target
+	return cb
+}
+
 // Execute runs fn through the circuit breaker
 func (cb *CircuitBreaker) Execute(ctx context.Context, fn func() error) error {
-	if !cb.allowRequest() {
+	if !cb.syncAllow() {
 		return ErrCircuitOpen
 	}
 
 	err := fn()
-	cb.recordResult(err)
+	cb.syncRecord(err)
 	return err
 }
 
+// syncAllow is allowRequest against the persisted state when a store is set.
+// Store failures degrade to in-memory state rather than blocking calls.
+func (cb *CircuitBreaker) syncAllow() bool {
+	if cb.store == nil {
+		return cb.allowRequest()
+	}
+	var allowed bool
+	err := cb.store.UpdateCircuit(cb.target, func(c *state.Circuit) {
+		cb.load(c)
+		allowed = cb.allowRequest()
+		cb.save(c)
+	})
+	if err != nil {
+		slog.Warn("circuit state unavailable", "target", cb.target, "error", err)
+		return cb.allowRequest()
+	}
+	return allowed
+}
+
+// syncRecord is recordResult against the persisted state when a store is set.
+func (cb *CircuitBreaker) syncRecord(result error) {
+	if cb.store == nil {
+		cb.recordResult(result)
+		return
+	}
+	err := cb.store.UpdateCircuit(cb.target, func(c *state.Circuit) {
+		cb.load(c)
+		cb.recordResult(result)
+		cb.save(c)
+	})
+	if err != nil {
+		slog.Warn("circuit state unavailable", "target", cb.target, "error", err)
+		cb.recordResult(result)
+	}
+}
+
+// load replaces in-memory state with the persisted circuit.
+func (cb *CircuitBreaker) load(c *state.Circuit) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch c.State {
+	case state.CircuitOpen:
+		cb.state = StateOpen
+	case state.CircuitHalfOpen:
+		cb.state = StateHalfOpen
+	default:
+		cb.state = StateClosed
+	}
+	cb.failures = c.Failures
+	cb.successes = c.Successes
+	cb.lastFailTime = c.LastFailure
+}
+
+// save copies in-memory state into the persisted circuit.
+func (cb *CircuitBreaker) save(c *state.Circuit) {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+	if name := cb.state.String(); name != c.State {
+		c.LastStateChange = time.Now().UTC()
+		c.State = name
+	}
+	c.Failures = cb.failures
+	c.Successes = cb.successes
+	c.LastFailure = cb.lastFailTime
+}
+
 // ErrCircuitOpen is returned when circuit is open
 var ErrCircuitOpen = errors.New("circuit breaker is open")
 
@@ -143,6 +225,11 @@ func (cb *CircuitBreaker) onFailure() {
 
 // State returns current circuit state
 func (cb *CircuitBreaker) State() CircuitState {
+	if cb.store != nil {
+		if c, err := cb.store.Circuit(cb.target); err == nil {
+			cb.load(&c)
+		}
+	}
 	cb.mu.RLock()
 	defer cb.mu.RUnlock()
 	return cb.state
@@ -155,4 +242,9 @@ func (cb *CircuitBreaker) Reset() {
 	cb.state = StateClosed
 	cb.failures = 0
 	cb.successes = 0
+	if cb.store != nil {
+		if err := cb.store.ResetCircuit(cb.target); err != nil {
+			slog.Warn("reset circuit state", "target", cb.target, "error", err)
+		}
+	}
 }
@@ -1,9 +1,12 @@
 package runtime
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
+
+	"github.com/fall-out-bug/sdp/src/sdp/state"
 )
 
 func TestCircuitBreaker_Closed(t *testing.T) {
@@ -185,3 +188,29 @@ func TestCircuitState_String(t *testing.T) {
 		}
 	}
 }
+
+func TestCircuitBreaker_PersistSharesState(t *testing.T) {
+	store := state.NewStore(t.TempDir())
+	cfg := CircuitConfig{FailureThreshold: 2, SuccessThreshold: 1, Timeout: time.Hour}
+	first := NewCircuitBreaker(cfg).Persist(store, "model:claude-opus")
+
+	for range 2 {
+		_ = first.Execute(context.Background(), func() error { return errors.New("rate limit") })
+	}
+
+	// A fresh breaker, as in a new process, starts from the persisted open state.
+	second := NewCircuitBreaker(cfg).Persist(store, "model:claude-opus")
+	if second.State() != StateOpen {
+		t.Fatalf("expected persisted open state, got %s", second.State())
+	}
+	called := false
+	err := second.Execute(context.Background(), func() error { called = true; return nil })
+	if !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("expected fail-fast on shared open circuit, err=%v called=%v", err, called)
+	}
+
+	second.Reset()
+	if c, _ := store.Circuit("model:claude-opus"); c.IsOpen() {
+		t.Errorf("expected reset to clear persisted state, got %+v", c)
+	}
+}
@@ -2,8 +2,11 @@ package runtime
 
 import (
 	"context"
+	"log/slog"
 	"sync"
 	"time"
+
+	"github.com/fall-out-bug/sdp/src/sdp/state"
 )
 
 // DegradedMode tracks degraded mode state
@@ -13,6 +16,7 @@ type DegradedMode struct {
 	reason      string
 	since       time.Time
 	cachedItems map[string]any
+	store       *state.Store // optional; shares active/reason/since across processes
 }
 
 // GlobalDegradedMode is the global degraded mode instance
@@ -25,8 +29,34 @@ func NewDegradedMode() *DegradedMode {
 	}
 }
 
+// Persist shares degraded mode through store so other processes and
+// `sdp health` see it. The cache stays in memory. Returns d.
+func (d *DegradedMode) Persist(store *state.Store) *DegradedMode {
+	d.mu.Lock()
+	d.store = store
+	d.mu.Unlock()
+	d.refresh()
+	return d
+}
+
+// refresh loads the persisted state when a store is set.
+func (d *DegradedMode) refresh() {
+	if d.store == nil {
+		return
+	}
+	st, err := d.store.Degraded()
+	if err != nil {
+		slog.Warn("degraded state unavailable", "error", err)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.active, d.reason, d.since = st.Active, st.Reason, st.Since
+}
+
 // IsActive returns whether degraded mode is active
 func (d *DegradedMode) IsActive() bool {
+	d.refresh()
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 	return d.active
@@ -34,6 +64,7 @@ func (d *DegradedMode) IsActive() bool {
 
 // Reason returns the reason for degraded mode
 func (d *DegradedMode) Reason() string {
+	d.refresh()
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 	return d.reason
@@ -41,6 +72,7 @@ func (d *DegradedMode) Reason() string {
 
 // Since returns when degraded mode started
 func (d *DegradedMode) Since() time.Time {
+	d.refresh()
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 	return d.since
@@ -48,6 +80,7 @@ func (d *DegradedMode) Since() time.Time {
 
 // Duration returns how long degraded mode has been active
 func (d *DegradedMode) Duration() time.Duration {
+	d.refresh()
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 	if !d.active {
@@ -58,6 +91,21 @@ func (d *DegradedMode) Duration() time.Duration {
 
 // Enter activates degraded mode
 func (d *DegradedMode) Enter(reason string) {
+	if d.store != nil {
+		err := d.store.UpdateDegraded(func(st *state.Degraded) {
+			if !st.Active {
+				*st = state.Degraded{Active: true, Reason: reason, Since: time.Now().UTC()}
+			}
+		})
+		if err != nil {
+			slog.Warn("persist degraded mode", "error", err)
+		}
+		d.refresh()
+		if err == nil {
+			return
+		}
+	}
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -70,6 +118,12 @@ func (d *DegradedMode) Enter(reason string) {
 
 // Exit deactivates degraded mode
 func (d *DegradedMode) Exit() {
+	if d.store != nil {
+		if err := d.store.ResetDegraded(); err != nil {
+			slog.Warn("clear degraded mode", "error", err)
+		}
+	}
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/fall-out-bug/sdp/src/sdp/state"
 )
 
 func TestDegradedMode_EnterExit(t *testing.T) {
@@ -174,3 +176,18 @@ func TestGlobalDegradedMode(t *testing.T) {
 
 	GlobalDegradedMode.Exit()
 }
+
+func TestDegradedMode_PersistSharesState(t *testing.T) {
+	store := state.NewStore(t.TempDir())
+	NewDegradedMode().Persist(store).Enter("github unavailable")
+
+	other := NewDegradedMode().Persist(store)
+	if !other.IsActive() || other.Reason() != "github unavailable" {
+		t.Fatalf("expected shared degraded mode, got active=%v reason=%q", other.IsActive(), other.Reason())
+	}
+
+	other.Exit()
+	if d, _ := store.Degraded(); d.Active {
+		t.Error("expected exit to clear persisted degraded mode")
+	}
+}
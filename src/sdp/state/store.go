@@ -0,0 +1,205 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fall-out-bug/sdp/internal/filelock"
+)
+
+// Dir holds breaker and degraded-mode state shared across processes, relative to the project root.
+const Dir = ".sdp/state"
+
+const (
+	circuitPrefix = "circuit-"
+	degradedFile  = "degraded.json"
+)
+
+// Circuit states as persisted. Breakers map their own enums to these names.
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half-open"
+)
+
+// Circuit is the persisted state of one circuit breaker target (a model, a CI provider).
+type Circuit struct {
+	Target           string    `json:"target"`
+	State            string    `json:"state"`
+	Failures         int       `json:"failures"`
+	Successes        int       `json:"successes"`
+	ConsecutiveOpens int       `json:"consecutive_opens,omitempty"`
+	LastFailure      time.Time `json:"last_failure,omitzero"`
+	LastStateChange  time.Time `json:"last_state_change,omitzero"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// IsOpen reports whether the circuit is rejecting or probing calls.
+func (c Circuit) IsOpen() bool {
+	return c.State == CircuitOpen || c.State == CircuitHalfOpen
+}
+
+// Degraded is the persisted degraded-mode state.
+type Degraded struct {
+	Active bool      `json:"active"`
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since,omitzero"`
+}
+
+// Store reads and writes state files under .sdp/state/.
+// Updates run under an exclusive per-file lock and replace the file atomically,
+// so parallel workers and separate CLI invocations see one state per target.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at projectRoot/.sdp/state.
+func NewStore(projectRoot string) *Store {
+	return &Store{dir: filepath.Join(projectRoot, Dir)}
+}
+
+// Circuit returns the state for target. A missing file yields a closed circuit.
+func (s *Store) Circuit(target string) (Circuit, error) {
+	c := Circuit{Target: target, State: CircuitClosed}
+	err := readJSON(s.circuitPath(target), &c)
+	return c, err
+}
+
+// UpdateCircuit applies fn to the target's state under the file lock and persists the result.
+func (s *Store) UpdateCircuit(target string, fn func(*Circuit)) error {
+	path := s.circuitPath(target)
+	return s.withLock(path, func() error {
+		c := Circuit{Target: target, State: CircuitClosed}
+		if err := readJSON(path, &c); err != nil {
+			return err
+		}
+		fn(&c)
+		c.Target = target
+		c.UpdatedAt = time.Now().UTC()
+		return writeJSON(path, c)
+	})
+}
+
+// Circuits returns every persisted circuit sorted by target.
+func (s *Store) Circuits() ([]Circuit, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, circuitPrefix+"*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var out []Circuit
+	for _, path := range matches {
+		var c Circuit
+		if err := readJSON(path, &c); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	slices.SortFunc(out, func(a, b Circuit) int { return strings.Compare(a.Target, b.Target) })
+	return out, nil
+}
+
+// ResetCircuit closes the target's circuit by removing its state.
+func (s *Store) ResetCircuit(target string) error {
+	path := s.circuitPath(target)
+	return s.withLock(path, func() error { return removeIfExists(path) })
+}
+
+// Degraded returns the degraded-mode state. A missing file yields inactive.
+func (s *Store) Degraded() (Degraded, error) {
+	var d Degraded
+	err := readJSON(filepath.Join(s.dir, degradedFile), &d)
+	return d, err
+}
+
+// UpdateDegraded applies fn to the degraded-mode state under the file lock and persists the result.
+func (s *Store) UpdateDegraded(fn func(*Degraded)) error {
+	path := filepath.Join(s.dir, degradedFile)
+	return s.withLock(path, func() error {
+		var d Degraded
+		if err := readJSON(path, &d); err != nil {
+			return err
+		}
+		fn(&d)
+		return writeJSON(path, d)
+	})
+}
+
+// ResetDegraded clears degraded mode.
+func (s *Store) ResetDegraded() error {
+	path := filepath.Join(s.dir, degradedFile)
+	return s.withLock(path, func() error { return removeIfExists(path) })
+}
+
+var reUnsafeTarget = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// circuitPath maps a target such as "model:claude-opus" to a safe file
+// name. The sanitized target keeps the name readable; a hash of the raw
+// target keeps targets that sanitize alike, such as "model:a/b" and
+// "model:a_b", in separate files.
+func (s *Store) circuitPath(target string) string {
+	sum := sha256.Sum256([]byte(target))
+	name := reUnsafeTarget.ReplaceAllString(target, "_") + "-" + hex.EncodeToString(sum[:4])
+	return filepath.Join(s.dir, circuitPrefix+name+".json")
+}
+
+func (s *Store) withLock(path string, fn func() error) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	lf, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open state lock: %w", err)
+	}
+	defer lf.Close()
+	if err := filelock.Lock(lf); err != nil {
+		return fmt.Errorf("acquire state lock: %w", err)
+	}
+	defer func() { _ = filelock.Unlock(lf) }()
+	return fn()
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse state %s: %w", path, err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package state
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestStore_CircuitDefaultsClosed(t *testing.T) {
+	c, err := NewStore(t.TempDir()).Circuit("model:claude-opus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.State != CircuitClosed || c.IsOpen() {
+		t.Errorf("expected closed circuit without state, got %+v", c)
+	}
+}
+
+func TestStore_UpdateListReset(t *testing.T) {
+	s := NewStore(t.TempDir())
+	if err := s.UpdateCircuit("model:claude-opus", func(c *Circuit) { c.State = CircuitOpen; c.Failures = 5 }); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateCircuit("ci:github", func(c *Circuit) { c.Failures = 1 }); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := s.Circuits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].Target != "ci:github" || all[1].Target != "model:claude-opus" {
+		t.Fatalf("expected two circuits sorted by target, got %+v", all)
+	}
+	if !all[1].IsOpen() || all[1].Failures != 5 || all[1].UpdatedAt.IsZero() {
+		t.Errorf("unexpected persisted circuit: %+v", all[1])
+	}
+
+	if err := s.ResetCircuit("model:claude-opus"); err != nil {
+		t.Fatal(err)
+	}
+	c, err := s.Circuit("model:claude-opus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.IsOpen() || c.Failures != 0 {
+		t.Errorf("expected reset circuit closed, got %+v", c)
+	}
+}
+
+func TestStore_CircuitTargetsDoNotCollide(t *testing.T) {
+	s := NewStore(t.TempDir())
+	// sdp-plugin/internal/breakerstate pins the same file name.
+	if got := filepath.Base(s.circuitPath("model:claude-opus")); got != "circuit-model_claude-opus-9665e2f5.json" {
+		t.Errorf("circuitPath = %s", got)
+	}
+	if err := s.UpdateCircuit("model:a/b", func(c *Circuit) { c.Failures = 1 }); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateCircuit("model:a_b", func(c *Circuit) { c.Failures = 2 }); err != nil {
+		t.Fatal(err)
+	}
+	if c, err := s.Circuit("model:a/b"); err != nil || c.Failures != 1 {
+		t.Errorf("model:a/b = %+v, %v; overwritten by model:a_b", c, err)
+	}
+	if all, _ := s.Circuits(); len(all) != 2 {
+		t.Errorf("circuits = %+v", all)
+	}
+}
+
+func TestStore_ConcurrentUpdates(t *testing.T) {
+	root := t.TempDir()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			// Separate Store values mimic separate processes sharing the file.
+			if err := NewStore(root).UpdateCircuit("ci:github", func(c *Circuit) { c.Failures++ }); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	c, err := NewStore(root).Circuit("ci:github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Failures != 10 {
+		t.Errorf("expected 10 failures recorded, got %d", c.Failures)
+	}
+}
+
+func TestStore_Degraded(t *testing.T) {
+	s := NewStore(t.TempDir())
+	if err := s.UpdateDegraded(func(d *Degraded) { d.Active = true; d.Reason = "github unavailable" }); err != nil {
+		t.Fatal(err)
+	}
+	d, err := s.Degraded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Active || d.Reason != "github unavailable" {
+		t.Errorf("unexpected degraded state: %+v", d)
+	}
+	if err := s.ResetDegraded(); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := s.Degraded(); d.Active {
+		t.Error("expected degraded mode cleared")
+	}
+}
@@ -0,0 +1,108 @@
+package synthesis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// CommandAgent consults an external agent/model CLI (e.g. `claude -p`, `opencode run`).
+// The prompt is written to stdin; the command must print a JSON object
+// {"choice": "...", "confidence": 0.8, "reasoning": "..."} somewhere in its output.
+type CommandAgent struct {
+	id      string
+	command []string
+	dir     string
+}
+
+// NewCommandAgent creates an agent backed by the given command line
+func NewCommandAgent(id string, command []string, dir string) *CommandAgent {
+	return &CommandAgent{id: id, command: command, dir: dir}
+}
+
+// ID returns the agent identifier
+func (a *CommandAgent) ID() string {
+	return a.id
+}
+
+// Available returns true if the agent's executable is on PATH
+func (a *CommandAgent) Available() bool {
+	if len(a.command) == 0 {
+		return false
+	}
+	_, err := exec.LookPath(a.command[0])
+	return err == nil
+}
+
+// Consult runs the command with a design prompt and parses its proposal
+func (a *CommandAgent) Consult(task Task, timeout time.Duration) (*Proposal, error) {
+	question, ok := task.(DesignQuestion)
+	if !ok {
+		return nil, fmt.Errorf("agent %s: unsupported task type %T", a.id, task)
+	}
+	if len(a.command) == 0 {
+		return nil, errors.New("agent command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, a.command[0], a.command[1:]...)
+	cmd.Dir = a.dir
+	cmd.Stdin = strings.NewReader(DesignPrompt(question))
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("agent %s: %w", a.id, err)
+	}
+
+	return ParseProposalOutput(a.id, string(out))
+}
+
+// DesignPrompt renders the prompt sent to every panel agent
+func DesignPrompt(q DesignQuestion) string {
+	var b strings.Builder
+	b.WriteString("You are a member of a design review panel.\n\n")
+	b.WriteString("Question: " + q.Question + "\n")
+	if len(q.Options) > 0 {
+		b.WriteString("\nOptions:\n")
+		for _, opt := range q.Options {
+			b.WriteString("- " + opt + "\n")
+		}
+	}
+	if q.Context != "" {
+		b.WriteString("\nContext:\n" + q.Context + "\n")
+	}
+	b.WriteString("\nReply with a single JSON object and nothing else:\n")
+	b.WriteString(`{"choice": "<option>", "confidence": <0.0-1.0>, "reasoning": "<one paragraph>"}` + "\n")
+	return b.String()
+}
+
+// ParseProposalOutput extracts the proposal JSON object from agent output
+func ParseProposalOutput(agentID, output string) (*Proposal, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("agent %s: no JSON proposal in output", agentID)
+	}
+
+	var reply struct {
+		Choice     string  `json:"choice"`
+		Confidence float64 `json:"confidence"`
+		Reasoning  string  `json:"reasoning"`
+	}
+	if err := json.Unmarshal([]byte(output[start:end+1]), &reply); err != nil {
+		return nil, fmt.Errorf("agent %s: invalid proposal: %w", agentID, err)
+	}
+	if strings.TrimSpace(reply.Choice) == "" {
+		return nil, fmt.Errorf("agent %s: proposal has no choice", agentID)
+	}
+	if reply.Confidence < 0 || reply.Confidence > 1 {
+		return nil, fmt.Errorf("agent %s: confidence %.2f out of range [0,1]", agentID, reply.Confidence)
+	}
+
+	return NewProposal(agentID, strings.TrimSpace(reply.Choice), reply.Confidence, reply.Reasoning), nil
+}
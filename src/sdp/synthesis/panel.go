@@ -0,0 +1,146 @@
+package synthesis
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DesignQuestion is the task a design panel deliberates on
+type DesignQuestion struct {
+	Question string   `json:"question"`
+	Options  []string `json:"options,omitempty"`
+	Context  string   `json:"context,omitempty"`
+}
+
+// Dissent records a proposal that lost the panel vote
+type Dissent struct {
+	AgentID    string  `json:"agent_id"`
+	Choice     string  `json:"choice"`
+	Confidence float64 `json:"confidence"`
+	Reasoning  string  `json:"reasoning,omitempty"`
+}
+
+// PanelDecision is the outcome of a design panel
+type PanelDecision struct {
+	Question     string      `json:"question"`
+	Status       string      `json:"status"` // "approved" or "escalated"
+	Choice       string      `json:"choice,omitempty"`
+	Rule         string      `json:"rule,omitempty"`
+	WinningAgent string      `json:"winning_agent,omitempty"`
+	Confidence   float64     `json:"confidence,omitempty"`
+	Rationale    string      `json:"rationale"`
+	Alternatives []string    `json:"alternatives,omitempty"`
+	Dissent      []Dissent   `json:"dissent,omitempty"`
+	Proposals    []*Proposal `json:"proposals"`
+}
+
+// Escalated returns true if the panel handed the decision to a human
+func (d *PanelDecision) Escalated() bool {
+	return d.Status == "escalated"
+}
+
+// Panel asks several agents for proposals on a design question and
+// resolves their disagreement through a rule engine
+type Panel struct {
+	supervisor *Supervisor
+	engine     *RuleEngine
+}
+
+// NewPanel creates a design panel over the given engine and agents
+func NewPanel(engine *RuleEngine, agents ...Agent) *Panel {
+	supervisor := NewSupervisor(engine, len(agents))
+	for _, agent := range agents {
+		supervisor.RegisterAgent(agent)
+	}
+	return &Panel{supervisor: supervisor, engine: engine}
+}
+
+// SetTimeout sets the per-agent consultation timeout
+func (p *Panel) SetTimeout(timeout time.Duration) {
+	p.supervisor.SetTimeout(timeout)
+}
+
+// Deliberate consults all agents and returns the panel decision.
+// A decision the rules cannot settle is returned with status "escalated"
+// and every distinct proposal listed as an alternative.
+func (p *Panel) Deliberate(question DesignQuestion) (*PanelDecision, error) {
+	if question.Question == "" {
+		return nil, errors.New("design question is empty")
+	}
+
+	proposals, err := p.supervisor.ConsultAgents(question)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consult panel: %w", err)
+	}
+	// Agents are consulted in map order; sort so the record is reproducible
+	slices.SortFunc(proposals, func(a, b *Proposal) int {
+		return strings.Compare(a.AgentID, b.AgentID)
+	})
+
+	decision := &PanelDecision{
+		Question:  question.Question,
+		Proposals: proposals,
+	}
+
+	result, err := p.engine.Execute(proposals)
+	if err != nil {
+		log.Printf("[Panel] No rule settled %q, escalating to human: %v", question.Question, err)
+		decision.Status = "escalated"
+		decision.Rationale = fmt.Sprintf("Panel could not agree: %v", err)
+		decision.Alternatives = distinctSolutions(proposals)
+		decision.Dissent = dissentFrom(proposals, "")
+		return decision, nil
+	}
+
+	decision.Status = "approved"
+	decision.Choice = solutionKey(result.Solution)
+	decision.Rule = result.Rule
+	decision.WinningAgent = result.WinningAgent
+	decision.Rationale = result.Reasoning
+	decision.Confidence = supportingConfidence(proposals, decision.Choice)
+	for _, alt := range distinctSolutions(proposals) {
+		if alt != decision.Choice {
+			decision.Alternatives = append(decision.Alternatives, alt)
+		}
+	}
+	decision.Dissent = dissentFrom(proposals, decision.Choice)
+
+	log.Printf("[Panel] Decision approved using rule '%s': %s", result.Rule, decision.Choice)
+	return decision, nil
+}
+
+// dissentFrom lists proposals whose solution differs from choice
+func dissentFrom(proposals []*Proposal, choice string) []Dissent {
+	var out []Dissent
+	for _, p := range proposals {
+		if p == nil || solutionKey(p.Solution) == choice {
+			continue
+		}
+		out = append(out, Dissent{
+			AgentID:    p.AgentID,
+			Choice:     solutionKey(p.Solution),
+			Confidence: p.Confidence,
+			Reasoning:  p.Reasoning,
+		})
+	}
+	return out
+}
+
+// supportingConfidence averages the confidence of proposals backing choice
+func supportingConfidence(proposals []*Proposal, choice string) float64 {
+	sum, n := 0.0, 0
+	for _, p := range proposals {
+		if p != nil && solutionKey(p.Solution) == choice {
+			sum += p.Confidence
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
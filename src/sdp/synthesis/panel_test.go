@@ -0,0 +1,105 @@
+package synthesis
+
+import (
+	"strings"
+	"testing"
+)
+
+func panelAgent(id, choice string, confidence float64) *MockAgent {
+	return &MockAgent{
+		id:        id,
+		available: true,
+		proposal:  NewProposal(id, choice, confidence, id+" reasoning"),
+	}
+}
+
+// TestPanel_Deliberate_Approved verifies a majority decision records dissent
+func TestPanel_Deliberate_Approved(t *testing.T) {
+	panel := NewPanel(PanelRuleEngine(nil, 0.5, 0.8),
+		panelAgent("architect", "event-sourcing", 0.8),
+		panelAgent("backend", "event-sourcing", 0.7),
+		panelAgent("sre", "crud", 0.9),
+	)
+
+	d, err := panel.Deliberate(DesignQuestion{Question: "Storage model?"})
+	if err != nil {
+		t.Fatalf("Deliberate failed: %v", err)
+	}
+	if d.Escalated() {
+		t.Fatalf("expected approved decision, got escalated: %s", d.Rationale)
+	}
+	if d.Choice != "event-sourcing" || d.Rule != "weighted_voting" {
+		t.Errorf("expected event-sourcing via weighted_voting, got %s via %s", d.Choice, d.Rule)
+	}
+	if len(d.Alternatives) != 1 || d.Alternatives[0] != "crud" {
+		t.Errorf("expected alternatives [crud], got %v", d.Alternatives)
+	}
+	if len(d.Dissent) != 1 || d.Dissent[0].AgentID != "sre" {
+		t.Errorf("expected dissent from sre, got %+v", d.Dissent)
+	}
+	if d.Confidence != 0.75 {
+		t.Errorf("expected supporting confidence 0.75, got %.2f", d.Confidence)
+	}
+}
+
+// TestPanel_Deliberate_Escalated verifies an unresolved split escalates
+func TestPanel_Deliberate_Escalated(t *testing.T) {
+	panel := NewPanel(PanelRuleEngine(nil, 0.5, 0.8),
+		panelAgent("a", "x", 0.6),
+		panelAgent("b", "y", 0.6),
+	)
+
+	d, err := panel.Deliberate(DesignQuestion{Question: "Pick one"})
+	if err != nil {
+		t.Fatalf("Deliberate failed: %v", err)
+	}
+	if !d.Escalated() {
+		t.Fatalf("expected escalation, got %s", d.Status)
+	}
+	if len(d.Alternatives) != 2 {
+		t.Errorf("expected both options as alternatives, got %v", d.Alternatives)
+	}
+	if len(d.Dissent) != 2 {
+		t.Errorf("expected every proposal as dissent, got %d", len(d.Dissent))
+	}
+}
+
+// TestPanel_Deliberate_EmptyQuestion verifies validation
+func TestPanel_Deliberate_EmptyQuestion(t *testing.T) {
+	panel := NewPanel(PanelRuleEngine(nil, 0.5, 0.8), panelAgent("a", "x", 0.9))
+	if _, err := panel.Deliberate(DesignQuestion{}); err == nil {
+		t.Error("expected error for empty question")
+	}
+}
+
+// TestParseProposalOutput verifies agent output parsing
+func TestParseProposalOutput(t *testing.T) {
+	out := "thinking...\n{\"choice\": \"grpc\", \"confidence\": 0.85, \"reasoning\": \"typed\"}\n"
+	p, err := ParseProposalOutput("agent-1", out)
+	if err != nil {
+		t.Fatalf("ParseProposalOutput failed: %v", err)
+	}
+	if p.Solution != "grpc" || p.Confidence != 0.85 || p.AgentID != "agent-1" {
+		t.Errorf("unexpected proposal: %+v", p)
+	}
+
+	for _, bad := range []string{
+		"no json here",
+		`{"choice": "", "confidence": 0.5}`,
+		`{"choice": "x", "confidence": 1.5}`,
+	} {
+		if _, err := ParseProposalOutput("agent-1", bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+// TestDesignPrompt_IncludesOptions verifies prompt rendering
+func TestDesignPrompt_IncludesOptions(t *testing.T) {
+	prompt := DesignPrompt(DesignQuestion{Question: "Queue?", Options: []string{"nats", "kafka"}})
+	for _, want := range []string{"Queue?", "- nats", "- kafka", `"choice"`} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+}
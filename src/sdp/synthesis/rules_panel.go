@@ -0,0 +1,224 @@
+package synthesis
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrEscalateToHuman is returned when the panel cannot agree and a human must decide
+var ErrEscalateToHuman = errors.New("escalated to human: no rule reached a decision")
+
+// WeightedVotingRule picks the solution with the largest share of weighted votes.
+// Each proposal votes for its solution with weight(agent) * confidence.
+type WeightedVotingRule struct {
+	weights map[string]float64
+	quorum  float64
+}
+
+// NewWeightedVotingRule creates a weighted voting rule.
+// Agents missing from weights vote with weight 1. The winning solution must
+// hold more than quorum (0..1) of the total vote; quorum <= 0 defaults to 0.5.
+func NewWeightedVotingRule(weights map[string]float64, quorum float64) *WeightedVotingRule {
+	if quorum <= 0 {
+		quorum = 0.5
+	}
+	return &WeightedVotingRule{weights: weights, quorum: quorum}
+}
+
+// Name returns the rule name
+func (r *WeightedVotingRule) Name() string {
+	return "weighted_voting"
+}
+
+// Priority returns 3
+func (r *WeightedVotingRule) Priority() int {
+	return 3
+}
+
+// CanApply checks if one solution holds a quorum of the weighted vote
+func (r *WeightedVotingRule) CanApply(proposals []*Proposal) bool {
+	_, share := r.tally(proposals)
+	return share > r.quorum
+}
+
+// Apply returns the solution with the largest weighted vote
+func (r *WeightedVotingRule) Apply(proposals []*Proposal) (*SynthesisResult, error) {
+	winner, share := r.tally(proposals)
+	if winner == nil {
+		return nil, ErrCannotSynthesize
+	}
+
+	return &SynthesisResult{
+		Solution:     winner.Solution,
+		Rule:         r.Name(),
+		WinningAgent: winner.AgentID,
+		Reasoning:    fmt.Sprintf("Solution holds %.0f%% of the weighted vote", share*100),
+		Proposals:    proposals,
+	}, nil
+}
+
+// tally returns the strongest proposal backing the winning solution and its vote share
+func (r *WeightedVotingRule) tally(proposals []*Proposal) (*Proposal, float64) {
+	votes := make(map[string]float64)
+	champions := make(map[string]*Proposal)
+	order := make([]string, 0)
+	total := 0.0
+
+	for _, p := range proposals {
+		if p == nil {
+			continue
+		}
+		weight, ok := r.weights[p.AgentID]
+		if !ok {
+			weight = 1
+		}
+		vote := weight * p.Confidence
+		key := solutionKey(p.Solution)
+		if _, seen := votes[key]; !seen {
+			order = append(order, key)
+		}
+		votes[key] += vote
+		total += vote
+		if c := champions[key]; c == nil || p.Confidence > c.Confidence {
+			champions[key] = p
+		}
+	}
+
+	if total == 0 {
+		return nil, 0
+	}
+
+	best := ""
+	for _, key := range order {
+		if best == "" || votes[key] > votes[best] {
+			best = key
+		}
+	}
+	return champions[best], votes[best] / total
+}
+
+// ConfidenceThresholdRule accepts the most confident proposal only when its
+// confidence reaches the threshold and no other solution is equally confident.
+type ConfidenceThresholdRule struct {
+	threshold float64
+}
+
+// NewConfidenceThresholdRule creates a confidence threshold rule
+func NewConfidenceThresholdRule(threshold float64) *ConfidenceThresholdRule {
+	return &ConfidenceThresholdRule{threshold: threshold}
+}
+
+// Name returns the rule name
+func (r *ConfidenceThresholdRule) Name() string {
+	return "confidence_threshold"
+}
+
+// Priority returns 4
+func (r *ConfidenceThresholdRule) Priority() int {
+	return 4
+}
+
+// CanApply checks if a single solution clears the confidence threshold
+func (r *ConfidenceThresholdRule) CanApply(proposals []*Proposal) bool {
+	return r.best(proposals) != nil
+}
+
+// Apply returns the solution that cleared the threshold
+func (r *ConfidenceThresholdRule) Apply(proposals []*Proposal) (*SynthesisResult, error) {
+	best := r.best(proposals)
+	if best == nil {
+		return nil, ErrCannotSynthesize
+	}
+
+	return &SynthesisResult{
+		Solution:     best.Solution,
+		Rule:         r.Name(),
+		WinningAgent: best.AgentID,
+		Reasoning:    fmt.Sprintf("Agent %s cleared confidence threshold %.2f (%.2f)", best.AgentID, r.threshold, best.Confidence),
+		Proposals:    proposals,
+	}, nil
+}
+
+// best returns the top proposal above the threshold, or nil if none or tied across solutions
+func (r *ConfidenceThresholdRule) best(proposals []*Proposal) *Proposal {
+	var best *Proposal
+	tied := false
+	for _, p := range proposals {
+		if p == nil || p.Confidence < r.threshold {
+			continue
+		}
+		switch {
+		case best == nil || p.Confidence > best.Confidence:
+			best = p
+			tied = false
+		case p.Confidence == best.Confidence && !solutionsEqual(p.Solution, best.Solution):
+			tied = true
+		}
+	}
+	if tied {
+		return nil
+	}
+	return best
+}
+
+// EscalateToHumanRule is the catch-all rule: it always applies and hands the decision to a human
+type EscalateToHumanRule struct{}
+
+// NewEscalateToHumanRule creates a new escalate-to-human rule
+func NewEscalateToHumanRule() *EscalateToHumanRule {
+	return &EscalateToHumanRule{}
+}
+
+// Name returns the rule name
+func (r *EscalateToHumanRule) Name() string {
+	return "escalate_to_human"
+}
+
+// Priority returns 5 (lowest priority)
+func (r *EscalateToHumanRule) Priority() int {
+	return 5
+}
+
+// CanApply always returns true
+func (r *EscalateToHumanRule) CanApply(proposals []*Proposal) bool {
+	return true
+}
+
+// Apply returns ErrEscalateToHuman
+func (r *EscalateToHumanRule) Apply(proposals []*Proposal) (*SynthesisResult, error) {
+	return nil, ErrEscalateToHuman
+}
+
+// PanelRuleEngine creates a rule engine for design panels.
+// Rules run in order: unanimous, weighted voting, confidence threshold,
+// escalate to human. Domain expertise is left out on purpose: a single
+// slightly more confident agent must not outvote the rest of the panel.
+func PanelRuleEngine(weights map[string]float64, quorum, threshold float64) *RuleEngine {
+	engine := NewRuleEngine()
+	engine.AddRule(NewUnanimousRule())
+	engine.AddRule(NewWeightedVotingRule(weights, quorum))
+	engine.AddRule(NewConfidenceThresholdRule(threshold))
+	engine.AddRule(NewEscalateToHumanRule())
+	return engine
+}
+
+// solutionKey returns a stable key for grouping equal solutions
+func solutionKey(solution any) string {
+	return fmt.Sprintf("%v", solution)
+}
+
+// distinctSolutions returns the distinct solution keys in first-seen order
+func distinctSolutions(proposals []*Proposal) []string {
+	keys := make([]string, 0, len(proposals))
+	for _, p := range proposals {
+		if p == nil {
+			continue
+		}
+		key := solutionKey(p.Solution)
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,103 @@
+package synthesis
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestWeightedVotingRule_MajorityWins verifies weighted majority selection
+func TestWeightedVotingRule_MajorityWins(t *testing.T) {
+	rule := NewWeightedVotingRule(map[string]float64{"architect": 3}, 0.5)
+	proposals := []*Proposal{
+		NewProposal("architect", "postgres", 0.7, ""),
+		NewProposal("backend", "sqlite", 0.9, ""),
+		NewProposal("sre", "sqlite", 0.6, ""),
+	}
+
+	if !rule.CanApply(proposals) {
+		t.Fatal("should apply when weighted vote holds a quorum")
+	}
+	result, err := rule.Apply(proposals)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.Solution != "postgres" {
+		t.Errorf("expected postgres (2.1 vs 1.5), got %v", result.Solution)
+	}
+	if result.WinningAgent != "architect" {
+		t.Errorf("expected winning agent architect, got %s", result.WinningAgent)
+	}
+}
+
+// TestWeightedVotingRule_NoQuorum verifies split votes are not decided
+func TestWeightedVotingRule_NoQuorum(t *testing.T) {
+	rule := NewWeightedVotingRule(nil, 0)
+	proposals := []*Proposal{
+		NewProposal("a", "x", 0.8, ""),
+		NewProposal("b", "y", 0.8, ""),
+	}
+
+	if rule.CanApply(proposals) {
+		t.Error("should not apply on an even split")
+	}
+}
+
+// TestConfidenceThresholdRule verifies threshold and tie handling
+func TestConfidenceThresholdRule(t *testing.T) {
+	rule := NewConfidenceThresholdRule(0.8)
+
+	below := []*Proposal{
+		NewProposal("a", "x", 0.7, ""),
+		NewProposal("b", "y", 0.6, ""),
+	}
+	if rule.CanApply(below) {
+		t.Error("should not apply when nobody clears the threshold")
+	}
+
+	tied := []*Proposal{
+		NewProposal("a", "x", 0.9, ""),
+		NewProposal("b", "y", 0.9, ""),
+	}
+	if rule.CanApply(tied) {
+		t.Error("should not apply when two solutions tie above the threshold")
+	}
+
+	clear := []*Proposal{
+		NewProposal("a", "x", 0.95, ""),
+		NewProposal("b", "y", 0.85, ""),
+	}
+	result, err := rule.Apply(clear)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if result.Solution != "x" {
+		t.Errorf("expected x, got %v", result.Solution)
+	}
+}
+
+// TestEscalateToHumanRule verifies escalation always applies and errors
+func TestEscalateToHumanRule(t *testing.T) {
+	rule := NewEscalateToHumanRule()
+	if !rule.CanApply(nil) {
+		t.Error("escalate rule should always apply")
+	}
+	if _, err := rule.Apply(nil); !errors.Is(err, ErrEscalateToHuman) {
+		t.Errorf("expected ErrEscalateToHuman, got %v", err)
+	}
+}
+
+// TestPanelRuleEngine_Order verifies panel rule ordering
+func TestPanelRuleEngine_Order(t *testing.T) {
+	engine := PanelRuleEngine(nil, 0.5, 0.8)
+	want := []string{"unanimous", "weighted_voting", "confidence_threshold", "escalate_to_human"}
+
+	rules := engine.GetRules()
+	if len(rules) != len(want) {
+		t.Fatalf("expected %d rules, got %d", len(want), len(rules))
+	}
+	for i, r := range rules {
+		if r.Name() != want[i] {
+			t.Errorf("rule %d: expected %s, got %s", i, want[i], r.Name())
+		}
+	}
+}